@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
@@ -168,38 +169,51 @@ func (ba *buildAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 		return nil, err
 	}
 
-	for _, svc := range stableServices {
-		stepMessage := fmt.Sprintf("Building service %s", svc.Name)
-		ba.console.ShowSpinner(ctx, stepMessage, input.Step)
+	reporter := project.NewServiceProgressReporter(ba.console, "Building")
+	servicesToBuild := []*project.ServiceConfig{}
 
+	for _, svc := range stableServices {
 		// Skip this service if both cases are true:
 		// 1. The user specified a service name
 		// 2. This service is not the one the user specified
 		if targetServiceName != "" && targetServiceName != svc.Name {
-			ba.console.StopSpinner(ctx, stepMessage, input.StepSkipped)
+			reporter.Skip(ctx, svc.Name)
 			continue
 		}
 
+		servicesToBuild = append(servicesToBuild, svc)
+	}
+
+	var buildResultsMu sync.Mutex
+	scheduler := project.NewServiceScheduler(project.ServiceMaxParallelism(ba.projectConfig))
+	scheduler.OnSkipped(reporter.SkipFailedDependency)
+
+	err = scheduler.Run(ctx, servicesToBuild, func(ctx context.Context, svc *project.ServiceConfig) error {
+		reporter.Start(ctx, svc.Name)
+
 		buildResult, err := async.RunWithProgress(
 			func(buildProgress project.ServiceProgress) {
-				progressMessage := fmt.Sprintf("Building service %s (%s)", svc.Name, buildProgress.Message)
-				ba.console.ShowSpinner(ctx, progressMessage, input.Step)
+				reporter.Progress(ctx, svc.Name, buildProgress)
 			},
 			func(progress *async.Progress[project.ServiceProgress]) (*project.ServiceBuildResult, error) {
 				return ba.serviceManager.Build(ctx, svc, nil, progress)
 			},
 		)
-
 		if err != nil {
-			ba.console.StopSpinner(ctx, stepMessage, input.StepFailed)
-			return nil, err
+			reporter.Stop(ctx, svc.Name, err)
+			return err
 		}
 
-		ba.console.StopSpinner(ctx, stepMessage, input.StepDone)
+		buildResultsMu.Lock()
 		buildResults[svc.Name] = buildResult
+		buildResultsMu.Unlock()
 
 		// report build outputs
-		ba.console.MessageUxItem(ctx, buildResult)
+		reporter.Stop(ctx, svc.Name, nil, buildResult)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	if ba.formatter.Kind() == output.JsonFormat {
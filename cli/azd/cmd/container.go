@@ -52,6 +52,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/kubelogin"
 	"github.com/azure/azure-dev/cli/azd/pkg/kustomize"
 	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/pkg/oci"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/azure/azure-dev/cli/azd/pkg/pipeline"
 	"github.com/azure/azure-dev/cli/azd/pkg/platform"
@@ -258,6 +259,7 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 
 	container.MustRegisterSingleton(environment.NewLocalFileDataStore)
 	container.MustRegisterSingleton(environment.NewManager)
+	container.MustRegisterSingleton(environment.NewExternalDataStoreRegistry)
 
 	container.MustRegisterSingleton(func(serviceLocator ioc.ServiceLocator) *lazy.Lazy[environment.LocalDataStore] {
 		return lazy.NewLazy(func() (environment.LocalDataStore, error) {
@@ -508,6 +510,7 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 
 	container.MustRegisterSingleton(templates.NewTemplateManager)
 	container.MustRegisterSingleton(templates.NewSourceManager)
+	container.MustRegisterSingleton(oci.NewClient)
 	container.MustRegisterScoped(project.NewResourceManager)
 	container.MustRegisterScoped(func(serviceLocator ioc.ServiceLocator) *lazy.Lazy[project.ResourceManager] {
 		return lazy.NewLazy(func() (project.ResourceManager, error) {
@@ -655,6 +658,8 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 	container.MustRegisterScoped(infra.NewDeploymentManager)
 	container.MustRegisterSingleton(infra.NewAzureResourceManager)
 	container.MustRegisterScoped(provisioning.NewManager)
+	container.MustRegisterScoped(cmd.NewProvisioner)
+	container.MustRegisterScoped(provisioning.NewExternalProviderRegistry)
 	container.MustRegisterScoped(provisioning.NewPrincipalIdProvider)
 	container.MustRegisterScoped(prompt.NewDefaultPrompter)
 
@@ -678,6 +683,9 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 		container.MustRegisterNamedScoped(string(target), constructor)
 	}
 
+	// Service targets for custom service hosts provided by extensions
+	container.MustRegisterScoped(project.NewExternalServiceTargetRegistry)
+
 	// Languages
 	frameworkServiceMap := map[project.ServiceLanguageKind]any{
 		project.ServiceLanguageNone:       project.NewNoOpProject,
@@ -698,6 +706,9 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 
 	container.MustRegisterNamedScoped(string(project.ServiceLanguageDocker), project.NewDockerProjectAsFrameworkService)
 
+	// Framework services for custom service languages provided by extensions
+	container.MustRegisterScoped(project.NewExternalFrameworkServiceRegistry)
+
 	// Pipelines
 	container.MustRegisterScoped(pipeline.NewPipelineManager)
 	container.MustRegisterSingleton(func(flags *pipelineConfigFlags) *pipeline.PipelineManagerArgs {
@@ -705,10 +716,16 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 	})
 
 	pipelineProviderMap := map[string]any{
-		"github-ci":  pipeline.NewGitHubCiProvider,
-		"github-scm": pipeline.NewGitHubScmProvider,
-		"azdo-ci":    pipeline.NewAzdoCiProvider,
-		"azdo-scm":   pipeline.NewAzdoScmProvider,
+		"github-ci":     pipeline.NewGitHubCiProvider,
+		"github-scm":    pipeline.NewGitHubScmProvider,
+		"azdo-ci":       pipeline.NewAzdoCiProvider,
+		"azdo-scm":      pipeline.NewAzdoScmProvider,
+		"gitlab-ci":     pipeline.NewGitLabCiProvider,
+		"gitlab-scm":    pipeline.NewGitLabScmProvider,
+		"bitbucket-ci":  pipeline.NewBitbucketCiProvider,
+		"bitbucket-scm": pipeline.NewBitbucketScmProvider,
+		"export-ci":     pipeline.NewExportCiProvider,
+		"export-scm":    pipeline.NewExportScmProvider,
 	}
 
 	for provider, constructor := range pipelineProviderMap {
@@ -824,6 +841,11 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 	container.MustRegisterScoped(grpcserver.NewPromptService)
 	container.MustRegisterScoped(grpcserver.NewDeploymentService)
 	container.MustRegisterScoped(grpcserver.NewEventService)
+	container.MustRegisterScoped(grpcserver.NewServiceTargetService)
+	container.MustRegisterScoped(grpcserver.NewFrameworkService)
+	container.MustRegisterScoped(grpcserver.NewProvisioningService)
+	container.MustRegisterScoped(grpcserver.NewOperationsService)
+	container.MustRegisterScoped(grpcserver.NewRemoteDataStoreService)
 	container.MustRegisterSingleton(grpcserver.NewUserConfigService)
 
 	// Required for nested actions called from composite actions like 'up'
@@ -5,7 +5,9 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
@@ -13,6 +15,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/alpha"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
@@ -95,23 +98,38 @@ func (a *downAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 
 	startTime := time.Now()
 
-	infra, err := a.importManager.ProjectInfrastructure(ctx, a.projectConfig)
+	projectInfra, err := a.importManager.ProjectInfrastructure(ctx, a.projectConfig)
 	if err != nil {
 		return nil, err
 	}
-	defer func() { _ = infra.Cleanup() }()
-
-	if err := a.provisionManager.Initialize(ctx, a.projectConfig.Path, infra.Options); err != nil {
-		return nil, fmt.Errorf("initializing provisioning manager: %w", err)
-	}
+	defer func() { _ = projectInfra.Cleanup() }()
 
 	if a.alphaFeatureManager.IsEnabled(azapi.FeatureDeploymentStacks) {
 		a.console.WarnForFeature(ctx, azapi.FeatureDeploymentStacks)
 	}
 
+	// Layers are destroyed in the reverse order of provisioning, as later layers may depend on earlier ones
+	layers := projectInfra.Options.GetLayers()
+	slices.Reverse(layers)
+
 	destroyOptions := provisioning.NewDestroyOptions(a.flags.forceDelete, a.flags.purgeDelete)
-	if _, err := a.provisionManager.Destroy(ctx, destroyOptions); err != nil {
-		return nil, fmt.Errorf("deleting infrastructure: %w", err)
+	for _, layer := range layers {
+		if layer.Name != "" {
+			a.console.Message(ctx, fmt.Sprintf("Layer: %s", output.WithHighLightFormat(layer.Name)))
+		}
+
+		if err := a.provisionManager.Initialize(ctx, a.projectConfig.Path, layer); err != nil {
+			return nil, fmt.Errorf("initializing provisioning manager: %w", err)
+		}
+
+		_, err := a.provisionManager.Destroy(ctx, destroyOptions)
+		if layer.Name != "" && errors.Is(err, infra.ErrDeploymentsNotFound) {
+			// layers that were never provisioned have nothing to delete
+			a.console.Message(ctx, output.WithGrayFormat("No deployment found for layer '%s'.", layer.Name))
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("deleting infrastructure: %w", err)
+		}
 	}
 
 	return &actions.ActionResult{
@@ -124,7 +142,8 @@ func (a *downAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 func getCmdDownHelpDescription(*cobra.Command) string {
 	return generateCmdHelpDescription(fmt.Sprintf(
 		"Delete Azure resources for an application. Running %s will not delete application"+
-			" files on your local machine.", output.WithHighLightFormat("azd down")), nil)
+			" files on your local machine. When the infrastructure defines layers, the layers are deleted"+
+			" in the reverse order of provisioning.", output.WithHighLightFormat("azd down")), nil)
 }
 
 func getCmdDownHelpFooter(*cobra.Command) string {
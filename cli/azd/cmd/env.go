@@ -14,6 +14,7 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/cmd/middleware"
 	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
 	"github.com/azure/azure-dev/cli/azd/pkg/alpha"
@@ -30,6 +31,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
 	"github.com/azure/azure-dev/cli/azd/pkg/project"
 	"github.com/azure/azure-dev/cli/azd/pkg/prompt"
+	"github.com/azure/azure-dev/cli/azd/pkg/workflow"
 	"github.com/sethvargo/go-retry"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -57,6 +59,12 @@ func envActions(root *actions.ActionDescriptor) *actions.ActionDescriptor {
 		ActionResolver: newEnvSetAction,
 	})
 
+	group.Add("unset", &actions.ActionDescriptorOptions{
+		Command:        newEnvUnsetCmd(),
+		FlagsResolver:  newEnvUnsetFlags,
+		ActionResolver: newEnvUnsetAction,
+	})
+
 	group.Add("set-secret", &actions.ActionDescriptorOptions{
 		Command: &cobra.Command{
 			Use:   "set-secret <name>",
@@ -79,6 +87,12 @@ func envActions(root *actions.ActionDescriptor) *actions.ActionDescriptor {
 		ActionResolver: newEnvNewAction,
 	})
 
+	group.Add("delete", &actions.ActionDescriptorOptions{
+		Command:        newEnvDeleteCmd(),
+		FlagsResolver:  newEnvDeleteFlags,
+		ActionResolver: newEnvDeleteAction,
+	})
+
 	group.Add("list", &actions.ActionDescriptorOptions{
 		Command:        newEnvListCmd(),
 		ActionResolver: newEnvListAction,
@@ -92,7 +106,9 @@ func envActions(root *actions.ActionDescriptor) *actions.ActionDescriptor {
 		ActionResolver: newEnvRefreshAction,
 		OutputFormats:  []output.Format{output.JsonFormat, output.NoneFormat},
 		DefaultFormat:  output.NoneFormat,
-	})
+	}).
+		// The state of projects provisioned by an extension is read from the provisioning provider of the extension
+		UseMiddleware("provisioningExtensions", middleware.NewProvisioningExtensionsMiddleware)
 
 	group.Add("get-values", &actions.ActionDescriptorOptions{
 		Command:        newEnvGetValuesCmd(),
@@ -108,6 +124,25 @@ func envActions(root *actions.ActionDescriptor) *actions.ActionDescriptor {
 		ActionResolver: newEnvGetValueAction,
 	})
 
+	group.Add("diff", &actions.ActionDescriptorOptions{
+		Command:        newEnvDiffCmd(),
+		ActionResolver: newEnvDiffAction,
+		OutputFormats:  []output.Format{output.JsonFormat, output.NoneFormat},
+		DefaultFormat:  output.NoneFormat,
+	})
+
+	group.Add("copy", &actions.ActionDescriptorOptions{
+		Command:        newEnvCopyCmd(),
+		FlagsResolver:  newEnvCopyFlags,
+		ActionResolver: newEnvCopyAction,
+	})
+
+	group.Add("unlock", &actions.ActionDescriptorOptions{
+		Command:        newEnvUnlockCmd(),
+		FlagsResolver:  newEnvUnlockFlags,
+		ActionResolver: newEnvUnlockAction,
+	})
+
 	return group
 }
 
@@ -179,6 +214,77 @@ func (e *envSetAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 	return nil, nil
 }
 
+func newEnvUnsetFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *envUnsetFlags {
+	flags := &envUnsetFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
+func newEnvUnsetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unset <key>...",
+		Short: "Remove one or more keys from the environment.",
+		Args:  cobra.MinimumNArgs(1),
+	}
+}
+
+type envUnsetFlags struct {
+	internal.EnvFlag
+	global *internal.GlobalCommandOptions
+}
+
+func (f *envUnsetFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	f.EnvFlag.Bind(local, global)
+	f.global = global
+}
+
+type envUnsetAction struct {
+	console    input.Console
+	env        *environment.Environment
+	envManager environment.Manager
+	args       []string
+}
+
+func newEnvUnsetAction(
+	env *environment.Environment,
+	envManager environment.Manager,
+	console input.Console,
+	args []string,
+) actions.Action {
+	return &envUnsetAction{
+		console:    console,
+		env:        env,
+		envManager: envManager,
+		args:       args,
+	}
+}
+
+func (e *envUnsetAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	dotEnv := e.env.Dotenv()
+
+	for _, key := range e.args {
+		if key == environment.EnvNameEnvVarName {
+			return nil, fmt.Errorf("'%s' cannot be removed from the environment", key)
+		}
+
+		if _, has := dotEnv[key]; !has {
+			e.console.MessageUxItem(ctx, &ux.WarningMessage{
+				Description: fmt.Sprintf("'%s' is not set in environment '%s'", key, e.env.Name()),
+			})
+			continue
+		}
+
+		e.env.DotenvDelete(key)
+	}
+
+	if err := e.envManager.Save(ctx, e.env); err != nil {
+		return nil, fmt.Errorf("saving environment: %w", err)
+	}
+
+	return nil, nil
+}
+
 // Prints a warning message if there are any case-insensitive conflicts with the provided key
 func warnKeyCaseConflicts(
 	ctx context.Context,
@@ -676,6 +782,142 @@ func (e *envSelectAction) Run(ctx context.Context) (*actions.ActionResult, error
 	return nil, nil
 }
 
+type envDeleteFlags struct {
+	remote      bool
+	down        bool
+	forceDelete bool
+	purgeDelete bool
+	global      *internal.GlobalCommandOptions
+}
+
+func (f *envDeleteFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	local.BoolVar(&f.remote, "remote", false, "Also deletes the environment from the configured remote state.")
+	local.BoolVar(&f.down, "down", false, "Deletes the Azure resources of the environment before deleting it.")
+	local.BoolVar(&f.forceDelete, "force", false, "Does not require confirmation before it deletes the environment.")
+	local.BoolVar(
+		&f.purgeDelete,
+		"purge",
+		false,
+		"When used with --down, permanently deletes resources that are soft-deleted by default (for example, key vaults).",
+	)
+	f.global = global
+}
+
+func newEnvDeleteFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *envDeleteFlags {
+	flags := &envDeleteFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
+func newEnvDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <environment>",
+		Short: "Delete an environment.",
+		Long: "Delete an environment from local storage and, with --remote, from the configured remote state.\n" +
+			"Azure resources are only deleted when --down is specified.",
+		Args: cobra.ExactArgs(1),
+	}
+}
+
+type envDeleteAction struct {
+	envManager     environment.Manager
+	console        input.Console
+	workflowRunner *workflow.Runner
+	flags          *envDeleteFlags
+	args           []string
+}
+
+func newEnvDeleteAction(
+	envManager environment.Manager,
+	console input.Console,
+	workflowRunner *workflow.Runner,
+	flags *envDeleteFlags,
+	args []string,
+) actions.Action {
+	return &envDeleteAction{
+		envManager:     envManager,
+		console:        console,
+		workflowRunner: workflowRunner,
+		flags:          flags,
+		args:           args,
+	}
+}
+
+func (e *envDeleteAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	name := e.args[0]
+
+	envs, err := e.envManager.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing environments: %w", err)
+	}
+
+	idx := slices.IndexFunc(envs, func(env *environment.Description) bool { return env.Name == name })
+	if idx < 0 {
+		return nil, fmt.Errorf("environment '%s' does not exist", name)
+	}
+
+	// Preconditions are checked before any Azure resources are deleted with --down
+	if e.flags.remote && !e.envManager.HasRemote() {
+		return nil, fmt.Errorf("deleting environment '%s' with --remote: %w", name, environment.ErrRemoteNotConfigured)
+	}
+
+	if !envs[idx].HasLocal && !e.flags.remote {
+		return nil, fmt.Errorf(
+			"environment '%s' only exists in the remote state. Use --remote to delete it from the remote state",
+			name,
+		)
+	}
+
+	if !e.flags.forceDelete {
+		message := fmt.Sprintf("Delete environment '%s'?", name)
+		if e.flags.down {
+			message = fmt.Sprintf("Delete environment '%s' and its Azure resources?", name)
+		}
+
+		confirm, err := e.console.Confirm(ctx, input.ConsoleOptions{
+			Message:      message,
+			DefaultValue: false,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("user cancelled delete confirmation, %w", err)
+		}
+
+		if !confirm {
+			return nil, nil
+		}
+	}
+
+	if e.flags.down {
+		// Azure resources are deleted by 'azd down' so the command hooks and environment lock apply
+		downArgs := []string{"down", "--environment", name, "--force"}
+		if e.flags.purgeDelete {
+			downArgs = append(downArgs, "--purge")
+		}
+
+		err := e.workflowRunner.Run(ctx, &workflow.Workflow{
+			Name: "delete",
+			Steps: []*workflow.Step{
+				{AzdCommand: workflow.Command{Args: downArgs}},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = e.envManager.DeleteWithOptions(ctx, name, &environment.DeleteOptions{Remote: e.flags.remote})
+	if err != nil {
+		return nil, fmt.Errorf("deleting environment '%s': %w", name, err)
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: fmt.Sprintf("Deleted environment '%s'", name),
+		},
+	}, nil
+}
+
 func newEnvListCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:     "list",
@@ -946,38 +1188,46 @@ func (ef *envRefreshAction) Run(ctx context.Context) (*actions.ActionResult, err
 	}
 	defer func() { _ = infra.Cleanup() }()
 
-	// env refresh supports "BYOI" infrastructure where bicep isn't available
-	err = ef.provisionManager.Initialize(ctx, ef.projectConfig.Path, infra.Options)
-	if errors.Is(err, bicep.ErrEnsureEnvPreReqBicepCompileFailed) {
-		// If bicep is not available, we continue to prompt for subscription and location unfiltered
-		err = provisioning.EnsureSubscriptionAndLocation(ctx, ef.envManager, ef.env, ef.prompters,
-			provisioning.EnsureSubscriptionAndLocationOptions{})
+	// The outputs of all the layers of the infrastructure are refreshed in provisioning order
+	var states []*provisioning.State
+	for _, layer := range infra.Options.GetLayers() {
+		// env refresh supports "BYOI" infrastructure where bicep isn't available
+		err = ef.provisionManager.Initialize(ctx, ef.projectConfig.Path, layer)
+		if errors.Is(err, bicep.ErrEnsureEnvPreReqBicepCompileFailed) {
+			// If bicep is not available, we continue to prompt for subscription and location unfiltered
+			err = provisioning.EnsureSubscriptionAndLocation(ctx, ef.envManager, ef.env, ef.prompters,
+				provisioning.EnsureSubscriptionAndLocationOptions{})
+			if err != nil {
+				return nil, err
+			}
+		} else if err != nil {
+			return nil, fmt.Errorf("initializing provisioning manager: %w", err)
+		}
+		// If resource group is defined within the project but not in the environment then
+		// add it to the environment to support BYOI lookup scenarios like ADE
+		// Infra providers do not currently have access to project configuration
+		projectResourceGroup, _ := ef.projectConfig.ResourceGroupName.Envsubst(ef.env.Getenv)
+		if _, has := ef.env.LookupEnv(environment.ResourceGroupEnvVarName); !has && projectResourceGroup != "" {
+			ef.env.DotenvSet(environment.ResourceGroupEnvVarName, projectResourceGroup)
+		}
+
+		stateOptions := provisioning.NewStateOptions(ef.flags.hint)
+		getStateResult, err := ef.provisionManager.State(ctx, stateOptions)
 		if err != nil {
+			return nil, fmt.Errorf("getting deployment: %w", err)
+		}
+
+		if err := ef.provisionManager.UpdateEnvironment(ctx, getStateResult.State.Outputs); err != nil {
 			return nil, err
 		}
-	} else if err != nil {
-		return nil, fmt.Errorf("initializing provisioning manager: %w", err)
-	}
-	// If resource group is defined within the project but not in the environment then
-	// add it to the environment to support BYOI lookup scenarios like ADE
-	// Infra providers do not currently have access to project configuration
-	projectResourceGroup, _ := ef.projectConfig.ResourceGroupName.Envsubst(ef.env.Getenv)
-	if _, has := ef.env.LookupEnv(environment.ResourceGroupEnvVarName); !has && projectResourceGroup != "" {
-		ef.env.DotenvSet(environment.ResourceGroupEnvVarName, projectResourceGroup)
-	}
 
-	stateOptions := provisioning.NewStateOptions(ef.flags.hint)
-	getStateResult, err := ef.provisionManager.State(ctx, stateOptions)
-	if err != nil {
-		return nil, fmt.Errorf("getting deployment: %w", err)
+		states = append(states, getStateResult.State)
 	}
 
-	if err := ef.provisionManager.UpdateEnvironment(ctx, getStateResult.State.Outputs); err != nil {
-		return nil, err
-	}
+	state := provisioning.MergeStates(states...)
 
 	if ef.formatter.Kind() == output.JsonFormat {
-		err = ef.formatter.Format(provisioning.NewEnvRefreshResultFromState(getStateResult.State), ef.writer, nil)
+		err = ef.formatter.Format(provisioning.NewEnvRefreshResultFromState(state), ef.writer, nil)
 		if err != nil {
 			return nil, fmt.Errorf("writing deployment result in JSON format: %w", err)
 		}
@@ -993,7 +1243,7 @@ func (ef *envRefreshAction) Run(ctx context.Context) (*actions.ActionResult, err
 			Project: ef.projectConfig,
 			Service: svc,
 			Args: map[string]any{
-				"bicepOutput": getStateResult.State.Outputs,
+				"bicepOutput": state.Outputs,
 			},
 		}
 
@@ -1185,6 +1435,270 @@ func (eg *envGetValueAction) Run(ctx context.Context) (*actions.ActionResult, er
 	return nil, nil
 }
 
+func newEnvDiffCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff <environment> <environment>",
+		Short: "Compare the values of two environments.",
+		Long: "Compare the .env values and config.json settings of two environments.\n" +
+			"Values referencing Azure Key Vault secrets and secure parameters stored as secrets by azd are masked. " +
+			"Other values are displayed as stored, including secure values stored in plain text.",
+		Args: cobra.ExactArgs(2),
+	}
+}
+
+type envDiffAction struct {
+	envManager environment.Manager
+	console    input.Console
+	formatter  output.Formatter
+	writer     io.Writer
+	args       []string
+}
+
+func newEnvDiffAction(
+	envManager environment.Manager,
+	console input.Console,
+	formatter output.Formatter,
+	writer io.Writer,
+	args []string,
+) actions.Action {
+	return &envDiffAction{
+		envManager: envManager,
+		console:    console,
+		formatter:  formatter,
+		writer:     writer,
+		args:       args,
+	}
+}
+
+func (e *envDiffAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	envs := make([]*environment.Environment, 0, len(e.args))
+	for _, name := range e.args {
+		env, err := e.envManager.Get(ctx, name)
+		if errors.Is(err, environment.ErrNotFound) {
+			return nil, fmt.Errorf("environment '%s' does not exist", name)
+		} else if err != nil {
+			return nil, fmt.Errorf("loading environment '%s': %w", name, err)
+		}
+
+		envs = append(envs, env)
+	}
+
+	diff, err := environment.Compare(envs[0], envs[1])
+	if err != nil {
+		return nil, err
+	}
+
+	if e.formatter.Kind() == output.JsonFormat {
+		return nil, e.formatter.Format(diff, e.writer, nil)
+	}
+
+	if !diff.HasChanges() {
+		e.console.Message(ctx, fmt.Sprintf("Environments '%s' and '%s' have the same values.", diff.From, diff.To))
+		return nil, nil
+	}
+
+	e.console.Message(ctx, output.WithBold("Comparing '%s' to '%s'", diff.From, diff.To))
+	for _, section := range []struct {
+		title   string
+		changes []*environment.Change
+	}{
+		{title: ".env", changes: diff.Dotenv},
+		{title: "config.json", changes: diff.Config},
+	} {
+		if len(section.changes) == 0 {
+			continue
+		}
+
+		e.console.Message(ctx, fmt.Sprintf("\n%s", output.WithHighLightFormat(section.title)))
+		for _, change := range section.changes {
+			e.console.Message(ctx, formatEnvChange(change))
+		}
+	}
+
+	return nil, nil
+}
+
+// formatEnvChange formats a change between two environments as a single line, ex) ~ KEY: old -> new
+func formatEnvChange(change *environment.Change) string {
+	switch change.Kind {
+	case environment.ChangeAdded:
+		return output.WithSuccessFormat("+ %s=%s", change.Key, change.NewValue)
+	case environment.ChangeRemoved:
+		return output.WithErrorFormat("- %s=%s", change.Key, change.OldValue)
+	default:
+		return output.WithWarningFormat("~ %s: %s -> %s", change.Key, change.OldValue, change.NewValue)
+	}
+}
+
+type envCopyFlags struct {
+	keys             []string
+	includeResources bool
+	global           *internal.GlobalCommandOptions
+}
+
+func (f *envCopyFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	local.StringSliceVar(
+		&f.keys,
+		"keys",
+		nil,
+		"The .env keys to copy. Config settings are not copied when keys are specified. "+
+			"When not specified, all .env values and config settings are copied.",
+	)
+	local.BoolVar(
+		&f.includeResources,
+		"include-resources",
+		false,
+		"Also copy the values referencing the resources of the source environment, such as AZURE_RESOURCE_GROUP, "+
+			"SERVICE_<NAME>_* values, the outputs of the provisioned infrastructure and the deployment history.",
+	)
+	f.global = global
+}
+
+func newEnvCopyFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *envCopyFlags {
+	flags := &envCopyFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
+func newEnvCopyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "copy <source> <target>",
+		Short: "Copy values from one environment to another.",
+		Long: "Copy the .env values and config.json settings of the source environment to the target environment.\n" +
+			"The target environment is created when it does not exist. Values referencing the resources of the " +
+			"source environment are only copied with --include-resources.",
+		Args: cobra.ExactArgs(2),
+	}
+}
+
+type envCopyAction struct {
+	envManager environment.Manager
+	flags      *envCopyFlags
+	args       []string
+}
+
+func newEnvCopyAction(envManager environment.Manager, flags *envCopyFlags, args []string) actions.Action {
+	return &envCopyAction{
+		envManager: envManager,
+		flags:      flags,
+		args:       args,
+	}
+}
+
+func (e *envCopyAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	sourceName, targetName := e.args[0], e.args[1]
+	if sourceName == targetName {
+		return nil, errors.New("the source and target environments must be different")
+	}
+
+	if len(e.flags.keys) > 0 && e.flags.includeResources {
+		return nil, errors.New("--keys cannot be combined with --include-resources, the specified keys are always copied")
+	}
+
+	source, err := e.envManager.Get(ctx, sourceName)
+	if errors.Is(err, environment.ErrNotFound) {
+		return nil, fmt.Errorf("environment '%s' does not exist", sourceName)
+	} else if err != nil {
+		return nil, fmt.Errorf("loading environment '%s': %w", sourceName, err)
+	}
+
+	target, err := e.envManager.Get(ctx, targetName)
+	if errors.Is(err, environment.ErrNotFound) {
+		target, err = e.envManager.Create(ctx, environment.Spec{Name: targetName})
+		if err != nil {
+			return nil, fmt.Errorf("creating environment '%s': %w", targetName, err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("loading environment '%s': %w", targetName, err)
+	}
+
+	if err := environment.CopyValues(source, target, e.flags.keys, e.flags.includeResources); err != nil {
+		return nil, err
+	}
+
+	if err := e.envManager.Save(ctx, target); err != nil {
+		return nil, fmt.Errorf("saving environment '%s': %w", targetName, err)
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: fmt.Sprintf("Copied values from environment '%s' to '%s'", sourceName, targetName),
+		},
+	}, nil
+}
+
+func newEnvUnlockFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *envUnlockFlags {
+	flags := &envUnlockFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
+func newEnvUnlockCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unlock",
+		Short: "Release the lock held on an environment.",
+		Long: "Release the lock held on an environment by an operation such as provision, deploy or down.\n" +
+			"Only use this command when the operation holding the lock is no longer running.",
+		Args: cobra.NoArgs,
+	}
+}
+
+type envUnlockFlags struct {
+	internal.EnvFlag
+	global *internal.GlobalCommandOptions
+}
+
+func (f *envUnlockFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	f.EnvFlag.Bind(local, global)
+	f.global = global
+}
+
+type envUnlockAction struct {
+	azdCtx     *azdcontext.AzdContext
+	envManager environment.Manager
+	flags      *envUnlockFlags
+}
+
+func newEnvUnlockAction(
+	azdCtx *azdcontext.AzdContext,
+	envManager environment.Manager,
+	flags *envUnlockFlags,
+) actions.Action {
+	return &envUnlockAction{
+		azdCtx:     azdCtx,
+		envManager: envManager,
+		flags:      flags,
+	}
+}
+
+func (e *envUnlockAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	name := e.flags.EnvironmentName
+	if name == "" {
+		defaultName, err := e.azdCtx.GetDefaultEnvironmentName()
+		if err != nil {
+			return nil, err
+		}
+
+		name = defaultName
+	}
+
+	if name == "" {
+		return nil, environment.ErrNameNotSpecified
+	}
+
+	if err := e.envManager.Unlock(ctx, name, nil); err != nil {
+		return nil, fmt.Errorf("releasing lock of environment '%s': %w", name, err)
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: fmt.Sprintf("Released the lock of environment '%s'", name),
+		},
+	}, nil
+}
+
 func getCmdEnvHelpDescription(*cobra.Command) string {
 	return generateCmdHelpDescription(
 		"Manage your application environments. With this command group, you can create a new environment or get, set,"+
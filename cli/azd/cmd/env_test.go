@@ -0,0 +1,122 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/workflow"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockenv"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_EnvDeleteAction(t *testing.T) {
+	t.Run("DownWithRemoteNotConfigured", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		azdRunner := &envDeleteAzdRunner{}
+
+		envManager := &mockenv.MockEnvManager{}
+		envManager.On("List", mock.Anything).Return([]*environment.Description{{Name: "dev", HasLocal: true}}, nil)
+		envManager.On("HasRemote").Return(false)
+
+		action := newEnvDeleteAction(
+			envManager,
+			mockContext.Console,
+			workflow.NewRunner(azdRunner, mockContext.Console, mockContext.Container),
+			&envDeleteFlags{remote: true, down: true, forceDelete: true},
+			[]string{"dev"},
+		)
+
+		_, err := action.Run(*mockContext.Context)
+		require.ErrorIs(t, err, environment.ErrRemoteNotConfigured)
+		require.Empty(t, azdRunner.executed, "azd down must not run when the environment cannot be deleted")
+		envManager.AssertNotCalled(t, "DeleteWithOptions", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("DownWithRemote", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		azdRunner := &envDeleteAzdRunner{}
+
+		envManager := &mockenv.MockEnvManager{}
+		envManager.On("List", mock.Anything).Return(
+			[]*environment.Description{{Name: "dev", HasLocal: true, HasRemote: true}}, nil)
+		envManager.On("HasRemote").Return(true)
+		envManager.On("DeleteWithOptions", mock.Anything, "dev", &environment.DeleteOptions{Remote: true}).Return(nil)
+
+		action := newEnvDeleteAction(
+			envManager,
+			mockContext.Console,
+			workflow.NewRunner(azdRunner, mockContext.Console, mockContext.Container),
+			&envDeleteFlags{remote: true, down: true, forceDelete: true},
+			[]string{"dev"},
+		)
+
+		_, err := action.Run(*mockContext.Context)
+		require.NoError(t, err)
+		require.Equal(t, [][]string{{"down", "--environment", "dev", "--force"}}, azdRunner.executed)
+		envManager.AssertExpectations(t)
+	})
+
+	t.Run("RemoteOnlyWithoutRemoteFlag", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		azdRunner := &envDeleteAzdRunner{}
+
+		envManager := &mockenv.MockEnvManager{}
+		envManager.On("List", mock.Anything).Return([]*environment.Description{{Name: "dev", HasRemote: true}}, nil)
+
+		action := newEnvDeleteAction(
+			envManager,
+			mockContext.Console,
+			workflow.NewRunner(azdRunner, mockContext.Console, mockContext.Container),
+			&envDeleteFlags{down: true, forceDelete: true},
+			[]string{"dev"},
+		)
+
+		_, err := action.Run(*mockContext.Context)
+		require.ErrorContains(t, err, "Use --remote")
+		require.Empty(t, azdRunner.executed)
+		envManager.AssertNotCalled(t, "DeleteWithOptions", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("RemoteOnlyWithRemoteFlag", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		azdRunner := &envDeleteAzdRunner{}
+
+		envManager := &mockenv.MockEnvManager{}
+		envManager.On("List", mock.Anything).Return([]*environment.Description{{Name: "dev", HasRemote: true}}, nil)
+		envManager.On("HasRemote").Return(true)
+		envManager.On("DeleteWithOptions", mock.Anything, "dev", &environment.DeleteOptions{Remote: true}).Return(nil)
+
+		action := newEnvDeleteAction(
+			envManager,
+			mockContext.Console,
+			workflow.NewRunner(azdRunner, mockContext.Console, mockContext.Container),
+			&envDeleteFlags{remote: true, forceDelete: true},
+			[]string{"dev"},
+		)
+
+		_, err := action.Run(*mockContext.Context)
+		require.NoError(t, err)
+		require.Empty(t, azdRunner.executed)
+		envManager.AssertExpectations(t)
+	})
+}
+
+type envDeleteAzdRunner struct {
+	args     []string
+	executed [][]string
+}
+
+func (r *envDeleteAzdRunner) SetArgs(args []string) {
+	r.args = args
+}
+
+func (r *envDeleteAzdRunner) ExecuteContext(ctx context.Context) error {
+	r.executed = append(r.executed, r.args)
+	return nil
+}
@@ -8,14 +8,23 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"text/tabwriter"
 
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/internal/tracing/resource"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
 	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
 	"github.com/spf13/cobra"
 )
 
@@ -56,11 +65,11 @@ func extensionActions(root *actions.ActionDescriptor) *actions.ActionDescriptor
 		ActionResolver: newExtensionShowAction,
 	})
 
-	// azd extension install <extension-name>
+	// azd extension install [<extension-name>...]
 	group.Add("install", &actions.ActionDescriptorOptions{
 		Command: &cobra.Command{
-			Use:   "install <extension-name>",
-			Short: "Installs specified extensions.",
+			Use:   "install [<extension-name>...]",
+			Short: "Installs specified extensions or the extensions required by the project.",
 		},
 		ActionResolver: newExtensionInstallAction,
 		FlagsResolver:  newExtensionInstallFlags,
@@ -390,12 +399,25 @@ func (a *extensionShowAction) Run(ctx context.Context) (*actions.ActionResult, e
 type extensionInstallFlags struct {
 	version string
 	source  string
+	frozen  bool
+	// frozenSet is true when --frozen is specified, to disable frozen restores on CI
+	frozenSet func() bool
 }
 
 func newExtensionInstallFlags(cmd *cobra.Command) *extensionInstallFlags {
 	flags := &extensionInstallFlags{}
 	cmd.Flags().StringVarP(&flags.source, "source", "s", "", "The extension source to use for installs")
 	cmd.Flags().StringVarP(&flags.version, "version", "v", "", "The version of the extension to install")
+	cmd.Flags().BoolVar(
+		&flags.frozen,
+		"frozen",
+		false,
+		fmt.Sprintf(
+			"Fail instead of updating %s when the required extensions no longer match it. Enabled by default on CI.",
+			extensions.LockFileName,
+		),
+	)
+	flags.frozenSet = func() bool { return cmd.Flags().Changed("frozen") }
 
 	return flags
 }
@@ -406,6 +428,7 @@ type extensionInstallAction struct {
 	flags            *extensionInstallFlags
 	console          input.Console
 	extensionManager *extensions.Manager
+	lazyAzdContext   *lazy.Lazy[*azdcontext.AzdContext]
 }
 
 func newExtensionInstallAction(
@@ -413,12 +436,14 @@ func newExtensionInstallAction(
 	flags *extensionInstallFlags,
 	console input.Console,
 	extensionManager *extensions.Manager,
+	lazyAzdContext *lazy.Lazy[*azdcontext.AzdContext],
 ) actions.Action {
 	return &extensionInstallAction{
 		args:             args,
 		flags:            flags,
 		console:          console,
 		extensionManager: extensionManager,
+		lazyAzdContext:   lazyAzdContext,
 	}
 }
 
@@ -430,7 +455,7 @@ func (a *extensionInstallAction) Run(ctx context.Context) (*actions.ActionResult
 
 	extensionIds := a.args
 	if len(extensionIds) == 0 {
-		return nil, fmt.Errorf("must specify an extension name")
+		return a.restore(ctx)
 	}
 
 	if len(extensionIds) > 1 && a.flags.version != "" {
@@ -467,6 +492,7 @@ func (a *extensionInstallAction) Run(ctx context.Context) (*actions.ActionResult
 		stepMessage += output.WithGrayFormat(" (%s)", extensionVersion.Version)
 		a.console.StopSpinner(ctx, stepMessage, input.StepDone)
 
+		showExtensionPublisher(ctx, a.console, a.extensionManager, extensionId)
 		a.console.Message(ctx, fmt.Sprintf("      %s %s", output.WithBold("Usage: "), extensionVersion.Usage))
 		a.console.Message(ctx, output.WithBold("      Examples:"))
 
@@ -482,6 +508,194 @@ func (a *extensionInstallAction) Run(ctx context.Context) (*actions.ActionResult
 	}, nil
 }
 
+// restore installs the extensions required by the project and their transitive dependencies.
+// The resolved versions are pinned in the lock file next to azure.yaml and honored on subsequent restores.
+func (a *extensionInstallAction) restore(ctx context.Context) (*actions.ActionResult, error) {
+	if a.flags.version != "" {
+		return nil, fmt.Errorf("cannot specify --version flag when installing the extensions required by the project")
+	}
+
+	azdContext, err := a.lazyAzdContext.GetValue()
+	if err != nil {
+		if errors.Is(err, azdcontext.ErrNoProject) {
+			return nil, fmt.Errorf("must specify an extension name or run the command within an azd project: %w", err)
+		}
+
+		return nil, err
+	}
+
+	projectConfig, err := project.Load(ctx, azdContext.ProjectPath())
+	if err != nil {
+		return nil, fmt.Errorf("loading project config: %w", err)
+	}
+
+	if projectConfig.RequiredVersions == nil || len(projectConfig.RequiredVersions.Extensions) == 0 {
+		return &actions.ActionResult{
+			Message: &actions.ResultMessage{
+				Header: "No extensions are required by the project",
+			},
+		}, nil
+	}
+
+	requirements := []extensions.ExtensionDependency{}
+	for _, extensionId := range slices.Sorted(maps.Keys(projectConfig.RequiredVersions.Extensions)) {
+		requirement := extensions.ExtensionDependency{Id: extensionId}
+		if versionConstraint := projectConfig.RequiredVersions.Extensions[extensionId]; versionConstraint != nil {
+			requirement.Version = *versionConstraint
+		}
+
+		requirements = append(requirements, requirement)
+	}
+
+	// Frozen restores install the locked extensions as is, which is the default on CI
+	frozen := a.flags.frozen
+	if a.flags.frozenSet != nil && !a.flags.frozenSet() {
+		frozen = resource.IsRunningOnCI()
+	}
+
+	installedExtensions, err := a.extensionManager.ListInstalled()
+	if err != nil {
+		return nil, fmt.Errorf("listing installed extensions: %w", err)
+	}
+
+	// Installed versions are kept when they satisfy the constraints, locked versions take precedence
+	preferredVersions := map[string]string{}
+	if !frozen {
+		for extensionId, installed := range installedExtensions {
+			preferredVersions[extensionId] = installed.Version
+		}
+	}
+
+	lockFilePath := filepath.Join(azdContext.ProjectDirectory(), extensions.LockFileName)
+	lockFile, err := extensions.LoadLockFile(lockFilePath)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+
+		if frozen {
+			return nil, &internal.ErrorWithSuggestion{
+				Err: fmt.Errorf("%s is required by frozen restores: %w", extensions.LockFileName, err),
+				Suggestion: fmt.Sprintf(
+					"Run 'azd extension install --frozen=false' to create %s, and commit it with the project.",
+					extensions.LockFileName,
+				),
+			}
+		}
+
+		lockFile = nil
+	}
+
+	if lockFile != nil {
+		maps.Copy(preferredVersions, lockFile.Versions())
+	}
+
+	resolved, err := a.extensionManager.Resolve(ctx, requirements, &extensions.ResolveOptions{
+		Source:    a.flags.source,
+		Preferred: preferredVersions,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("resolving required extensions: %w", err)
+	}
+
+	if frozen {
+		if err := lockFile.Verify(resolved); err != nil {
+			return nil, &internal.ErrorWithSuggestion{
+				Err: fmt.Errorf("the required extensions no longer match %s:\n%w", extensions.LockFileName, err),
+				Suggestion: fmt.Sprintf(
+					"Run 'azd extension install --frozen=false' to update %s, and commit it with the project.",
+					extensions.LockFileName,
+				),
+			}
+		}
+	}
+
+	for _, resolvedExtension := range resolved {
+		extensionId := resolvedExtension.Metadata.Id
+		stepMessage := fmt.Sprintf("Installing %s extension", output.WithHighLightFormat(extensionId))
+		a.console.ShowSpinner(ctx, stepMessage, input.Step)
+
+		var locked *extensions.LockedExtension
+		if lockFile != nil {
+			locked = lockFile.Extensions[extensionId]
+			if locked != nil && locked.Version != resolvedExtension.Version.Version {
+				// The constraints changed since the lock file was written, the extension is locked again
+				locked = nil
+			}
+		}
+
+		// Installed extensions are kept when they were installed from the locked artifacts
+		installed, isInstalled := installedExtensions[extensionId]
+		if isInstalled && installed.Version == resolvedExtension.Version.Version &&
+			(locked == nil || locked.VerifyInstalled(installed) == nil) {
+			stepMessage += output.WithGrayFormat(" (version %s already installed)", installed.Version)
+			a.console.StopSpinner(ctx, stepMessage, input.StepSkipped)
+			continue
+		}
+
+		if err := a.extensionManager.InstallResolved(ctx, resolvedExtension, locked); err != nil {
+			a.console.StopSpinner(ctx, stepMessage, input.StepFailed)
+			return nil, fmt.Errorf("failed to install extension: %w", err)
+		}
+
+		stepMessage += output.WithGrayFormat(" (%s)", resolvedExtension.Version.Version)
+		a.console.StopSpinner(ctx, stepMessage, input.StepDone)
+
+		showExtensionPublisher(ctx, a.console, a.extensionManager, extensionId)
+	}
+
+	if frozen {
+		return &actions.ActionResult{
+			Message: &actions.ResultMessage{
+				Header: fmt.Sprintf(
+					"Extension(s) installed successfully from %s", output.WithHighLightFormat(extensions.LockFileName)),
+			},
+		}, nil
+	}
+
+	if err := extensions.NewLockFile(resolved).Save(lockFilePath); err != nil {
+		return nil, err
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: "Extension(s) installed successfully",
+			FollowUp: fmt.Sprintf(
+				"Resolved versions are pinned in %s, commit it to install the same extensions on every machine.",
+				output.WithHighLightFormat(extensions.LockFileName),
+			),
+		},
+	}, nil
+}
+
+// showExtensionPublisher displays the identity of the publisher that signed the artifact of the installed extension
+func showExtensionPublisher(
+	ctx context.Context,
+	console input.Console,
+	extensionManager *extensions.Manager,
+	extensionId string,
+) {
+	installed, err := extensionManager.GetInstalled(extensions.LookupOptions{Id: extensionId})
+	// Extension packs do not install any artifact
+	if err != nil || installed.Path == "" {
+		return
+	}
+
+	var publisher string
+	switch {
+	case installed.Publisher == nil:
+		publisher = "Unknown" + output.WithGrayFormat(" (artifact is not signed)")
+	case installed.Publisher.Trusted:
+		publisher = installed.Publisher.Name +
+			output.WithGrayFormat(" (signed with trusted key %s)", installed.Publisher.KeyFingerprint)
+	default:
+		publisher = installed.Publisher.Name +
+			output.WithGrayFormat(" (signed with key %s, key is not trusted)", installed.Publisher.KeyFingerprint)
+	}
+
+	console.Message(ctx, fmt.Sprintf("      %s %s", output.WithBold("Publisher: "), publisher))
+}
+
 // azd extension uninstall
 type extensionUninstallFlags struct {
 	all bool
@@ -598,6 +812,7 @@ type extensionUpgradeAction struct {
 	flags            *extensionUpgradeFlags
 	console          input.Console
 	extensionManager *extensions.Manager
+	lazyAzdContext   *lazy.Lazy[*azdcontext.AzdContext]
 }
 
 func newExtensionUpgradeAction(
@@ -605,12 +820,14 @@ func newExtensionUpgradeAction(
 	flags *extensionUpgradeFlags,
 	console input.Console,
 	extensionManager *extensions.Manager,
+	lazyAzdContext *lazy.Lazy[*azdcontext.AzdContext],
 ) actions.Action {
 	return &extensionUpgradeAction{
 		args:             args,
 		flags:            flags,
 		console:          console,
 		extensionManager: extensionManager,
+		lazyAzdContext:   lazyAzdContext,
 	}
 }
 
@@ -649,6 +866,21 @@ func (a *extensionUpgradeAction) Run(ctx context.Context) (*actions.ActionResult
 		return nil, fmt.Errorf("no extensions to upgrade")
 	}
 
+	// Upgrades within a project update the versions pinned in the lock file of the project
+	var lockFile *extensions.LockFile
+	var lockFilePath string
+	if azdContext, err := a.lazyAzdContext.GetValue(); err == nil {
+		lockFilePath = filepath.Join(azdContext.ProjectDirectory(), extensions.LockFileName)
+		lockFile, err = extensions.LoadLockFile(lockFilePath)
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				return nil, err
+			}
+
+			lockFile = nil
+		}
+	}
+
 	for index, extensionId := range extensionIds {
 		if index > 0 {
 			a.console.Message(ctx, "")
@@ -680,14 +912,16 @@ func (a *extensionUpgradeAction) Run(ctx context.Context) (*actions.ActionResult
 			stepMessage += output.WithGrayFormat(" (No upgrade available)")
 			a.console.StopSpinner(ctx, stepMessage, input.StepSkipped)
 		} else {
-			extensionVersion, err := a.extensionManager.Upgrade(ctx, extensionId, filterOptions)
+			extensionVersion, err := a.extensionManager.Upgrade(ctx, extensionId, filterOptions, lockFile)
 			if err != nil {
+				a.console.StopSpinner(ctx, stepMessage, input.StepFailed)
 				return nil, fmt.Errorf("failed to upgrade extension: %w", err)
 			}
 
 			stepMessage += output.WithGrayFormat(" (%s)", extensionVersion.Version)
 			a.console.StopSpinner(ctx, stepMessage, input.StepDone)
 
+			showExtensionPublisher(ctx, a.console, a.extensionManager, extensionId)
 			a.console.Message(ctx, fmt.Sprintf("      %s %s", output.WithBold("Usage: "), extensionVersion.Usage))
 			a.console.Message(ctx, output.WithBold("      Examples:"))
 
@@ -697,6 +931,12 @@ func (a *extensionUpgradeAction) Run(ctx context.Context) (*actions.ActionResult
 		}
 	}
 
+	if lockFile != nil {
+		if err := lockFile.Save(lockFilePath); err != nil {
+			return nil, err
+		}
+	}
+
 	return &actions.ActionResult{
 		Message: &actions.ResultMessage{
 			Header: "Extensions upgraded successfully",
@@ -19,6 +19,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/pkg/ioc"
 	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
@@ -51,6 +52,21 @@ func bindExtension(
 	return nil
 }
 
+// registerExtensionProviders registers the providers declared by an installed extension, which makes the projects using
+// them valid when loaded
+func registerExtensionProviders(providers []extensions.Provider) {
+	for _, provider := range providers {
+		switch provider.Type {
+		case extensions.ServiceTargetProviderType:
+			project.RegisterExternalServiceHost(project.ServiceTargetKind(provider.Name))
+		case extensions.FrameworkServiceProviderType:
+			project.RegisterExternalServiceLanguage(project.ServiceLanguageKind(provider.Name))
+		case extensions.RemoteDataStoreProviderType:
+			environment.RegisterExternalRemoteKind(environment.RemoteKind(provider.Name))
+		}
+	}
+}
+
 // invokeExtensionHelp invokes the help for the extension
 func invokeExtensionHelp(console input.Console, commandRunner exec.CommandRunner, extensionManager *extensions.Manager) {
 	extensionNamespace := os.Args[1]
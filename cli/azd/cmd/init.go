@@ -69,7 +69,7 @@ func (i *initFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOpt
 		"t",
 		"",
 		//nolint:lll
-		"Initializes a new application from a template. You can use Full URI, <owner>/<repository>, or <repository> if it's part of the azure-samples organization.",
+		"Initializes a new application from a template. You can use Full URI, <owner>/<repository>, or <repository> if it's part of the azure-samples organization. OCI artifacts are referenced with oci://<registry>/<repository>:<tag>.",
 	)
 	local.StringVarP(
 		&i.templateBranch,
@@ -522,13 +522,15 @@ func (i *initAction) initializeExtensions(ctx context.Context, azdCtx *azdcontex
 		return nil
 	}
 
-	projectConfig, err := project.Load(ctx, azdCtx.ProjectPath())
+	// The service hosts and languages of the project may be provided by the required extensions, which are not
+	// installed yet
+	requiredVersions, err := project.LoadRequiredVersions(azdCtx.ProjectPath())
 	if err != nil {
 		return fmt.Errorf("loading project config: %w", err)
 	}
 
 	// No extensions required
-	if projectConfig.RequiredVersions == nil || len(projectConfig.RequiredVersions.Extensions) == 0 {
+	if requiredVersions == nil || len(requiredVersions.Extensions) == 0 {
 		return nil
 	}
 
@@ -539,7 +541,7 @@ func (i *initAction) initializeExtensions(ctx context.Context, azdCtx *azdcontex
 
 	i.console.Message(ctx, "\nInstalling required extensions...")
 
-	for extensionId, versionConstraint := range projectConfig.RequiredVersions.Extensions {
+	for extensionId, versionConstraint := range requiredVersions.Extensions {
 		stepMessage := fmt.Sprintf("Installing %s extension", output.WithHighLightFormat(extensionId))
 		i.console.ShowSpinner(ctx, stepMessage, input.Step)
 
@@ -563,6 +565,8 @@ func (i *initAction) initializeExtensions(ctx context.Context, azdCtx *azdcontex
 				return fmt.Errorf("installing extension %s: %w", extensionId, err)
 			}
 
+			registerExtensionProviders(extensionVersion.Providers)
+
 			stepMessage += output.WithGrayFormat(" (%s)", extensionVersion.Version)
 			i.console.StopSpinner(ctx, stepMessage, input.StepDone)
 		}
@@ -597,5 +601,9 @@ func getCmdInitHelpFooter(*cobra.Command) string {
 			output.WithHighLightFormat("--branch"),
 			output.WithWarningFormat("[Branch name]"),
 		),
+		"Initialize a template to your current local directory from an OCI registry.": fmt.Sprintf("%s %s",
+			output.WithHighLightFormat("azd init --template"),
+			output.WithWarningFormat("oci://[registry]/[repository]:[tag]"),
+		),
 	})
 }
@@ -0,0 +1,62 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+)
+
+// EnvLockMiddleware holds the lock of the azd environment for the duration of commands that modify the environment,
+// preventing concurrent operations, ex) two users running `azd provision` against the same remote environment.
+type EnvLockMiddleware struct {
+	lazyEnvManager *lazy.Lazy[environment.Manager]
+	lazyEnv        *lazy.Lazy[*environment.Environment]
+	options        *Options
+}
+
+// NewEnvLockMiddleware creates a new instance of the environment lock middleware
+func NewEnvLockMiddleware(
+	lazyEnvManager *lazy.Lazy[environment.Manager],
+	lazyEnv *lazy.Lazy[*environment.Environment],
+	options *Options,
+) Middleware {
+	return &EnvLockMiddleware{
+		lazyEnvManager: lazyEnvManager,
+		lazyEnv:        lazyEnv,
+		options:        options,
+	}
+}
+
+// Run acquires the lock of the environment, runs the next middleware and releases the lock
+func (m *EnvLockMiddleware) Run(ctx context.Context, next NextFn) (*actions.ActionResult, error) {
+	// Child actions run under the lock of the parent
+	if m.options.IsChildAction(ctx) {
+		return next(ctx)
+	}
+
+	env, err := m.lazyEnv.GetValue()
+	if err != nil {
+		log.Println("azd environment is not available, skipping environment lock.")
+		return next(ctx)
+	}
+
+	envManager, err := m.lazyEnvManager.GetValue()
+	if err != nil {
+		return nil, fmt.Errorf("failed getting environment manager, %w", err)
+	}
+
+	release, err := environment.AcquireLock(ctx, envManager, env.Name(), m.options.CommandPath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return next(ctx)
+}
@@ -0,0 +1,118 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockenv"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_EnvLock_Middleware(t *testing.T) {
+	env := environment.NewWithValues("test", nil)
+
+	t.Run("LocksForDurationOfCommand", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		lock := environment.NewLockInfo("provision")
+
+		envManager := &mockenv.MockEnvManager{}
+		envManager.On("Lock", mock.Anything, "test", "provision").Return(lock, nil)
+		envManager.On("Unlock", mock.Anything, "test", lock).Return(nil)
+
+		middleware := newEnvLockMiddlewareForTest(envManager, env, "provision")
+		nextFn, actionRan := createNextFn()
+		_, err := middleware.Run(*mockContext.Context, nextFn)
+
+		require.NoError(t, err)
+		require.True(t, *actionRan)
+		envManager.AssertExpectations(t)
+	})
+
+	t.Run("Locked", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		lockedErr := &environment.LockedError{
+			EnvName: "test",
+			Lock:    &environment.LockInfo{Owner: "alice@workstation", Operation: "deploy"},
+		}
+
+		envManager := &mockenv.MockEnvManager{}
+		envManager.On("Lock", mock.Anything, "test", "provision").Return((*environment.LockInfo)(nil), lockedErr)
+
+		middleware := newEnvLockMiddlewareForTest(envManager, env, "provision")
+		nextFn, actionRan := createNextFn()
+		_, err := middleware.Run(*mockContext.Context, nextFn)
+
+		require.ErrorIs(t, err, environment.ErrLocked)
+		var suggestionErr *internal.ErrorWithSuggestion
+		require.ErrorAs(t, err, &suggestionErr)
+		require.Contains(t, suggestionErr.Suggestion, "azd env unlock -e test")
+		require.False(t, *actionRan)
+	})
+
+	t.Run("ChildAction", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		envManager := &mockenv.MockEnvManager{}
+
+		middleware := newEnvLockMiddlewareForTest(envManager, env, "provision")
+		nextFn, actionRan := createNextFn()
+		_, err := middleware.Run(WithChildAction(*mockContext.Context), nextFn)
+
+		require.NoError(t, err)
+		require.True(t, *actionRan)
+		envManager.AssertNotCalled(t, "Lock", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("NestedProcessWithParentLock", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		parentLock := environment.NewLockInfo("up")
+		t.Setenv(environment.LockIdEnvVarName, parentLock.Id)
+
+		envManager := &mockenv.MockEnvManager{}
+		envManager.On("Lock", mock.Anything, "test", "provision").
+			Return((*environment.LockInfo)(nil), &environment.LockedError{EnvName: "test", Lock: parentLock})
+
+		middleware := newEnvLockMiddlewareForTest(envManager, env, "provision")
+		nextFn, actionRan := createNextFn()
+		_, err := middleware.Run(*mockContext.Context, nextFn)
+
+		require.NoError(t, err)
+		require.True(t, *actionRan)
+		envManager.AssertNotCalled(t, "Unlock", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("NestedProcessWithOtherLock", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		t.Setenv(environment.LockIdEnvVarName, "lock-of-another-environment")
+
+		envManager := &mockenv.MockEnvManager{}
+		envManager.On("Lock", mock.Anything, "test", "provision").Return((*environment.LockInfo)(nil),
+			&environment.LockedError{EnvName: "test", Lock: environment.NewLockInfo("deploy")})
+
+		middleware := newEnvLockMiddlewareForTest(envManager, env, "provision")
+		nextFn, actionRan := createNextFn()
+		_, err := middleware.Run(*mockContext.Context, nextFn)
+
+		require.ErrorIs(t, err, environment.ErrLocked)
+		require.False(t, *actionRan)
+	})
+}
+
+func newEnvLockMiddlewareForTest(
+	envManager environment.Manager,
+	env *environment.Environment,
+	commandPath string,
+) Middleware {
+	return NewEnvLockMiddleware(
+		lazy.From(envManager),
+		lazy.From(env),
+		&Options{CommandPath: commandPath},
+	)
+}
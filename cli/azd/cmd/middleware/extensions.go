@@ -18,11 +18,22 @@ import (
 	"github.com/fatih/color"
 )
 
+// listenCapabilities are the capabilities of extensions that are started with the `listen` command
+// to communicate with azd while a command is running.
+var listenCapabilities = []extensions.CapabilityType{
+	extensions.LifecycleEventsCapability,
+	extensions.ServiceTargetProviderCapability,
+	extensions.FrameworkServiceProviderCapability,
+	extensions.ProvisioningProviderCapability,
+}
+
 type ExtensionsMiddleware struct {
 	extensionManager *extensions.Manager
 	extensionRunner  *extensions.Runner
 	serviceLocator   ioc.ServiceLocator
 	console          input.Console
+	// capabilities are the capabilities of the extensions started by the middleware
+	capabilities []extensions.CapabilityType
 }
 
 func NewExtensionsMiddleware(
@@ -36,6 +47,43 @@ func NewExtensionsMiddleware(
 		extensionManager: extensionsManager,
 		extensionRunner:  extensionRunner,
 		console:          console,
+		capabilities:     listenCapabilities,
+	}
+}
+
+// NewRemoteStateExtensionsMiddleware creates a middleware only starting the extensions providing a remote state
+// backend. The middleware runs before the environment of the command is loaded or locked, since environments stored
+// within the backend of an extension are only available once the extension has been started.
+func NewRemoteStateExtensionsMiddleware(
+	serviceLocator ioc.ServiceLocator,
+	extensionsManager *extensions.Manager,
+	extensionRunner *extensions.Runner,
+	console input.Console,
+) Middleware {
+	return &ExtensionsMiddleware{
+		serviceLocator:   serviceLocator,
+		extensionManager: extensionsManager,
+		extensionRunner:  extensionRunner,
+		console:          console,
+		capabilities:     []extensions.CapabilityType{extensions.RemoteDataStoreProviderCapability},
+	}
+}
+
+// NewProvisioningExtensionsMiddleware creates a middleware only starting the extensions providing a provisioning
+// provider. This is used by read-only commands, ex) `azd provision --preview`, which must not run lifecycle event
+// handlers but still require the provisioning provider of the project.
+func NewProvisioningExtensionsMiddleware(
+	serviceLocator ioc.ServiceLocator,
+	extensionsManager *extensions.Manager,
+	extensionRunner *extensions.Runner,
+	console input.Console,
+) Middleware {
+	return &ExtensionsMiddleware{
+		serviceLocator:   serviceLocator,
+		extensionManager: extensionsManager,
+		extensionRunner:  extensionRunner,
+		console:          console,
+		capabilities:     []extensions.CapabilityType{extensions.ProvisioningProviderCapability},
 	}
 }
 
@@ -45,18 +93,19 @@ func (m *ExtensionsMiddleware) Run(ctx context.Context, next NextFn) (*actions.A
 		return nil, err
 	}
 
-	requireLifecycleEvents := false
 	extensionList := []*extensions.Extension{}
 
-	// Find extensions that require lifecycle events
+	// Find extensions that communicate with azd while the command is running.
+	// Extensions that have already been started, ex) by the remote state middleware, keep running.
 	for _, extension := range installedExtensions {
-		if slices.Contains(extension.Capabilities, extensions.LifecycleEventsCapability) {
+		if slices.ContainsFunc(extension.Capabilities, func(capability extensions.CapabilityType) bool {
+			return slices.Contains(m.capabilities, capability)
+		}) && extension.MarkStarted() {
 			extensionList = append(extensionList, extension)
-			requireLifecycleEvents = true
 		}
 	}
 
-	if !requireLifecycleEvents {
+	if len(extensionList) == 0 {
 		return next(ctx)
 	}
 
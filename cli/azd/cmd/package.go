@@ -7,10 +7,12 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
 	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/internal/cmd"
 	"github.com/azure/azure-dev/cli/azd/pkg/async"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
@@ -151,8 +153,10 @@ func (pa *packageAction) Run(ctx context.Context) (*actions.ActionResult, error)
 	if err != nil {
 		return nil, err
 	}
-	serviceCount := len(serviceTable)
-	for index, svc := range serviceTable {
+	reporter := project.NewServiceProgressReporter(pa.console, "Packaging")
+	servicesToPackage := []*project.ServiceConfig{}
+
+	for _, svc := range serviceTable {
 		// TODO(ellismg): We need to figure out what packaging an containerized dotnet app means. For now, just skip it.
 		//  We "package" the app during deploy when we call `dotnet publish /p:PublishProfile=DefaultContainer` to build
 		//  and push the container image.
@@ -187,39 +191,52 @@ func (pa *packageAction) Run(ctx context.Context) (*actions.ActionResult, error)
 			continue
 		}
 
-		stepMessage := fmt.Sprintf("Packaging service %s", svc.Name)
-		pa.console.ShowSpinner(ctx, stepMessage, input.Step)
-
 		// Skip this service if both cases are true:
 		// 1. The user specified a service name
 		// 2. This service is not the one the user specified
 		if targetServiceName != "" && targetServiceName != svc.Name {
-			pa.console.StopSpinner(ctx, stepMessage, input.StepSkipped)
+			reporter.Skip(ctx, svc.Name)
 			continue
 		}
 
-		options := &project.PackageOptions{OutputPath: pa.flags.outputPath}
+		servicesToPackage = append(servicesToPackage, svc)
+	}
+
+	var packageResultsMu sync.Mutex
+	options := &project.PackageOptions{OutputPath: pa.flags.outputPath}
+	scheduler := project.NewServiceScheduler(project.ServiceMaxParallelism(pa.projectConfig))
+	scheduler.OnSkipped(reporter.SkipFailedDependency)
+
+	err = scheduler.Run(ctx, servicesToPackage, func(ctx context.Context, svc *project.ServiceConfig) error {
+		reporter.Start(ctx, svc.Name)
+
 		packageResult, err := async.RunWithProgress(
 			func(packageProgress project.ServiceProgress) {
-				progressMessage := fmt.Sprintf("Packaging service %s (%s)", svc.Name, packageProgress.Message)
-				pa.console.ShowSpinner(ctx, progressMessage, input.Step)
+				reporter.Progress(ctx, svc.Name, packageProgress)
 			},
 			func(progress *async.Progress[project.ServiceProgress]) (*project.ServicePackageResult, error) {
 				return pa.serviceManager.Package(ctx, svc, nil, progress, options)
 			},
 		)
-		pa.console.StopSpinner(ctx, stepMessage, input.GetStepResultFormat(err))
-
 		if err != nil {
-			return nil, err
+			reporter.Stop(ctx, svc.Name, err)
+			return err
 		}
+
+		packageResultsMu.Lock()
 		packageResults[svc.Name] = packageResult
+		isLast := len(packageResults) == len(servicesToPackage)
+		packageResultsMu.Unlock()
 
 		// report package output
-		pa.console.MessageUxItem(ctx, packageResult)
-		if index < serviceCount-1 {
+		reporter.Stop(ctx, svc.Name, nil, packageResult)
+		if !isLast {
 			pa.console.Message(ctx, "")
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	if pa.formatter.Kind() == output.JsonFormat {
@@ -251,6 +268,7 @@ func getCmdPackageHelpDescription(*cobra.Command) string {
 		formatHelpNote(
 			fmt.Sprintf("When %s is set, only the specific service is packaged.", output.WithHighLightFormat("<service>"))),
 		formatHelpNote("After the packaging is complete, the package locations are printed."),
+		formatHelpNote(cmd.ServiceParallelismHelpNote("packaged")),
 	})
 }
 
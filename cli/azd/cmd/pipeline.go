@@ -65,7 +65,11 @@ func (pc *pipelineConfigFlags) Bind(local *pflag.FlagSet, global *internal.Globa
 	// default provider is empty because it can be set from azure.yaml. By letting default here be empty, we know that
 	// there no customer input using --provider
 	local.StringVar(&pc.PipelineProvider, "provider", "",
-		"The pipeline provider to use (github for Github Actions and azdo for Azure Pipelines).")
+		"The pipeline provider to use (github for Github Actions, azdo for Azure Pipelines, gitlab for GitLab CI/CD, "+
+			"bitbucket for Bitbucket Pipelines and export to only export the pipeline variables and secrets).")
+	local.StringVar(&pc.PipelineExportFile, "export-file", "",
+		"The file to write the pipeline variables and secrets to, or - to write them to the standard output. "+
+			"Required when using the export provider.")
 	local.StringVarP(&pc.ServiceManagementReference, "applicationServiceManagementReference", "m", "",
 		"Service Management Reference. "+
 			"References application or service contact information from a Service or Asset Management database. "+
@@ -165,7 +169,8 @@ func (p *pipelineConfigAction) Run(ctx context.Context) (*actions.ActionResult,
 	}
 	defer func() { _ = infra.Cleanup() }()
 
-	err = p.provisioningManager.Initialize(ctx, p.projectConfig.Path, infra.Options)
+	// the first layer of the infrastructure ensures the subscription and location of the environment
+	err = p.provisioningManager.Initialize(ctx, p.projectConfig.Path, infra.Options.GetLayers()[0])
 	if err != nil {
 		return nil, err
 	}
@@ -182,6 +187,21 @@ func (p *pipelineConfigAction) Run(ctx context.Context) (*actions.ActionResult,
 		return nil, err
 	}
 
+	if pipelineResult.ExportOnly {
+		followUp := "Set the exported variables and secrets on your CI/CD system to run azd in your pipeline."
+		if pipelineResult.PipelineLink != "" {
+			followUp = fmt.Sprintf("Pipeline variables and secrets were exported to %s\n%s",
+				output.WithHighLightFormat(pipelineResult.PipelineLink), followUp)
+		}
+
+		return &actions.ActionResult{
+			Message: &actions.ResultMessage{
+				Header:   "Your pipeline configuration has been exported!",
+				FollowUp: followUp,
+			},
+		}, nil
+	}
+
 	return &actions.ActionResult{
 		Message: &actions.ResultMessage{
 			Header: fmt.Sprintf("Your %s pipeline has been configured!", pipelineProviderName),
@@ -225,7 +245,8 @@ func getCmdPipelineConfigHelpDescription(*cobra.Command) string {
 		"Configure your deployment pipeline to connect securely to Azure",
 		[]string{
 			formatHelpNote(
-				"Supports GitHub Actions and Azure Pipelines. To configure using a specific pipeline provider, " +
+				"Supports GitHub Actions, Azure Pipelines, GitLab CI/CD and Bitbucket Pipelines. " +
+					"To configure using a specific pipeline provider, " +
 					"provide a value for the '--provider' flag."),
 			formatHelpNote(
 				output.WithHighLightFormat("pipeline config") +
@@ -253,5 +274,9 @@ func getCmdPipelineConfigHelpFooter(c *cobra.Command) string {
 			output.WithWarningFormat("app-test"),
 			output.WithHighLightFormat("--provider azdo"),
 		),
+		"Export the pipeline variables and secrets for a CI/CD system without configuring it": fmt.Sprintf("%s %s",
+			output.WithHighLightFormat("azd pipeline config --provider export --export-file"),
+			output.WithWarningFormat("[File path]"),
+		),
 	})
 }
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
@@ -160,35 +161,50 @@ func (ra *restoreAction) Run(ctx context.Context) (*actions.ActionResult, error)
 		return nil, err
 	}
 
-	for _, svc := range stableServices {
-		stepMessage := fmt.Sprintf("Restoring service %s", svc.Name)
-		ra.console.ShowSpinner(ctx, stepMessage, input.Step)
+	reporter := project.NewServiceProgressReporter(ra.console, "Restoring")
+	servicesToRestore := []*project.ServiceConfig{}
 
+	for _, svc := range stableServices {
 		// Skip this service if both cases are true:
 		// 1. The user specified a service name
 		// 2. This service is not the one the user specified
 		if targetServiceName != "" && targetServiceName != svc.Name {
-			ra.console.StopSpinner(ctx, stepMessage, input.StepSkipped)
+			reporter.Skip(ctx, svc.Name)
 			continue
 		}
 
+		servicesToRestore = append(servicesToRestore, svc)
+	}
+
+	var restoreResultsMu sync.Mutex
+	scheduler := project.NewServiceScheduler(project.ServiceMaxParallelism(ra.projectConfig))
+	scheduler.OnSkipped(reporter.SkipFailedDependency)
+
+	err = scheduler.Run(ctx, servicesToRestore, func(ctx context.Context, svc *project.ServiceConfig) error {
+		reporter.Start(ctx, svc.Name)
+
 		restoreResult, err := async.RunWithProgress(
-			func(buildProgress project.ServiceProgress) {
-				progressMessage := fmt.Sprintf("Building service %s (%s)", svc.Name, buildProgress.Message)
-				ra.console.ShowSpinner(ctx, progressMessage, input.Step)
+			func(restoreProgress project.ServiceProgress) {
+				reporter.Progress(ctx, svc.Name, restoreProgress)
 			},
 			func(progress *async.Progress[project.ServiceProgress]) (*project.ServiceRestoreResult, error) {
 				return ra.serviceManager.Restore(ctx, svc, progress)
 			},
 		)
-
 		if err != nil {
-			ra.console.StopSpinner(ctx, stepMessage, input.StepFailed)
-			return nil, err
+			reporter.Stop(ctx, svc.Name, err)
+			return err
 		}
 
-		ra.console.StopSpinner(ctx, stepMessage, input.StepDone)
+		restoreResultsMu.Lock()
 		restoreResults[svc.Name] = restoreResult
+		restoreResultsMu.Unlock()
+
+		reporter.Stop(ctx, svc.Name, nil)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	if ra.formatter.Kind() == output.JsonFormat {
@@ -239,20 +239,30 @@ func NewRootCmd(
 				RootLevelHelp: actions.CmdGroupManage,
 			},
 		}).
+		UseMiddlewareWhen("envLock", middleware.NewEnvLockMiddleware, func(descriptor *actions.ActionDescriptor) bool {
+			// Previews don't modify the environment
+			return !isReadOnlyProvision(descriptor)
+		}).
 		UseMiddlewareWhen("hooks", middleware.NewHooksMiddleware, func(descriptor *actions.ActionDescriptor) bool {
-			if onPreview, _ := descriptor.Options.Command.Flags().GetBool("preview"); onPreview {
-				log.Println("Skipping provision hooks due to preview flag.")
+			if isReadOnlyProvision(descriptor) {
+				log.Println("Skipping provision hooks due to preview or check-drift flag.")
 				return false
 			}
 			return true
 		}).
 		UseMiddlewareWhen("extensions", middleware.NewExtensionsMiddleware, func(descriptor *actions.ActionDescriptor) bool {
-			if onPreview, _ := descriptor.Options.Command.Flags().GetBool("preview"); onPreview {
-				log.Println("Skipping provision hooks due to preview flag.")
+			if isReadOnlyProvision(descriptor) {
+				log.Println("Skipping lifecycle event extensions due to preview or check-drift flag.")
 				return false
 			}
 			return true
-		})
+		}).
+		// Previews still require the provisioning provider registered by an extension
+		UseMiddlewareWhen(
+			"provisioningExtensions",
+			middleware.NewProvisioningExtensionsMiddleware,
+			isReadOnlyProvision,
+		)
 
 	root.
 		Add("package", &actions.ActionDescriptorOptions{
@@ -287,6 +297,7 @@ func NewRootCmd(
 				RootLevelHelp: actions.CmdGroupManage,
 			},
 		}).
+		UseMiddleware("envLock", middleware.NewEnvLockMiddleware).
 		UseMiddleware("hooks", middleware.NewHooksMiddleware).
 		UseMiddleware("extensions", middleware.NewExtensionsMiddleware)
 
@@ -304,6 +315,7 @@ func NewRootCmd(
 				RootLevelHelp: actions.CmdGroupManage,
 			},
 		}).
+		UseMiddleware("envLock", middleware.NewEnvLockMiddleware).
 		UseMiddleware("hooks", middleware.NewHooksMiddleware).
 		UseMiddleware("extensions", middleware.NewExtensionsMiddleware)
 
@@ -335,6 +347,7 @@ func NewRootCmd(
 				RootLevelHelp: actions.CmdGroupManage,
 			},
 		}).
+		UseMiddleware("envLock", middleware.NewEnvLockMiddleware).
 		UseMiddleware("hooks", middleware.NewHooksMiddleware).
 		UseMiddleware("extensions", middleware.NewExtensionsMiddleware)
 	root.
@@ -356,7 +369,16 @@ func NewRootCmd(
 		UseMiddleware("ux", middleware.NewUxMiddleware).
 		UseMiddlewareWhen("telemetry", middleware.NewTelemetryMiddleware, func(descriptor *actions.ActionDescriptor) bool {
 			return !descriptor.Options.DisableTelemetry
-		})
+		}).
+		// Environments stored within the remote state backend of an extension require the extension to be running
+		UseMiddlewareWhen(
+			"remoteStateExtensions",
+			middleware.NewRemoteStateExtensionsMiddleware,
+			func(descriptor *actions.ActionDescriptor) bool {
+				// Extensions are not started while they are managed, ex) upgraded
+				return !isExtensionManagementCommand(descriptor)
+			},
+		)
 
 	// Register common dependencies for the IoC rootContainer
 	if rootContainer == nil {
@@ -379,6 +401,8 @@ func NewRootCmd(
 
 			// Bind custom extension commands for extensions that expose the capability
 			for _, ext := range installedExtensions {
+				registerExtensionProviders(ext.Providers)
+
 				if ext.HasCapability(extensions.CustomCommandCapability) {
 					if err := bindExtension(rootContainer, root, ext); err != nil {
 						return fmt.Errorf("Failed to bind extension commands: %w", err)
@@ -427,6 +451,27 @@ func NewRootCmd(
 	return cmd
 }
 
+// isReadOnlyProvision returns true when `azd provision` only previews the changes, which is the case for both --preview
+// and --check-drift. Read-only provisioning doesn't lock the environment or run the provision hooks.
+func isReadOnlyProvision(descriptor *actions.ActionDescriptor) bool {
+	flags := descriptor.Options.Command.Flags()
+	onPreview, _ := flags.GetBool("preview")
+	checkDrift, _ := flags.GetBool("check-drift")
+
+	return onPreview || checkDrift
+}
+
+// isExtensionManagementCommand returns true for the commands of the `azd extension` command group
+func isExtensionManagementCommand(descriptor *actions.ActionDescriptor) bool {
+	for current := descriptor; current != nil; current = current.Parent() {
+		if current.Name == "extension" && current.Parent() != nil && current.Parent().Parent() == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
 func getCmdRootHelpFooter(cmd *cobra.Command) string {
 	return fmt.Sprintf("%s\n%s\n%s\n\n%s\n\n%s",
 		output.WithBold("%s", output.WithUnderline("Deploying a sample application")),
@@ -243,6 +243,9 @@ func getCmdTemplateSourceAddHelpFooter(*cobra.Command) string {
 		"Add templates from a file path": output.WithHighLightFormat(
 			"azd template source add <key> --type file --location /path/to/templates.json",
 		),
+		"Add templates from an OCI registry": output.WithHighLightFormat(
+			"azd template source add <key> --type oci --location oci://<registry>/<namespace>",
+		),
 	})
 }
 
@@ -370,7 +373,7 @@ func newTemplateSourceAddFlags(cmd *cobra.Command) *templateSourceAddFlags {
 	flags := &templateSourceAddFlags{}
 
 	cmd.Flags().StringVarP(&flags.kind, "type", "t", "", "Kind of the template source. Supported types are "+
-		"'file', 'url' and 'gh'.")
+		"'file', 'url', 'gh' and 'oci'.")
 	cmd.Flags().StringVarP(&flags.location, "location", "l", "", "Location of the template source. "+
 		"Required when using type flag.")
 	cmd.Flags().StringVarP(&flags.name, "name", "n", "", "Display name of the template source.")
@@ -421,7 +424,7 @@ func (a *templateSourceAddAction) Run(ctx context.Context) (*actions.ActionResul
 					"run `azd template source add %s` (w/o the --type flag). ",
 				a.flags.kind,
 				key,
-				ux.ListAsText([]string{"'file'", "'url'", "'gh'"}),
+				ux.ListAsText([]string{"'file'", "'url'", "'gh'", "'oci'"}),
 				a.flags.kind,
 				a.flags.kind,
 			)
@@ -444,7 +447,7 @@ func (a *templateSourceAddAction) Run(ctx context.Context) (*actions.ActionResul
 				return nil, fmt.Errorf(
 					"template source type '%s' is not supported. Supported types are %s",
 					a.flags.kind,
-					ux.ListAsText([]string{"'file'", "'url'", "'gh'"}),
+					ux.ListAsText([]string{"'file'", "'url'", "'gh'", "'oci'"}),
 				)
 			}
 
@@ -551,6 +554,9 @@ func getCmdTemplateSourceHelpFooter(*cobra.Command) string {
 		"Add a new GitHub template source.": output.WithHighLightFormat(
 			"azd template source add <key> --type gh --location <GitHub URL>",
 		),
+		"Add a new OCI registry template source.": output.WithHighLightFormat(
+			"azd template source add <key> --type oci --location oci://<registry>/<namespace>",
+		),
 		"Remove a previously registered template source.": output.WithHighLightFormat(
 			"azd template source remove <key>",
 		),
@@ -5,6 +5,7 @@ package cmd
 
 import (
 	"archive/zip"
+	"crypto"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -32,6 +33,8 @@ func newPackageCommand() *cobra.Command {
 	rootCmd.Flags().StringP("registry", "r", "registry.json", "Path to the registry.json file.")
 	rootCmd.Flags().StringP("output", "o", "artifacts", "Path to the artifacts output directory.")
 	rootCmd.Flags().StringP("base-url", "b", "", "Base URL for artifact paths")
+	rootCmd.Flags().String("signing-key", "", "Path to the PEM encoded private key used to sign the artifacts.")
+	rootCmd.Flags().String("publisher", "", "Identity of the publisher signing the artifacts.")
 
 	return rootCmd
 }
@@ -41,6 +44,22 @@ func buildRegistry(cmd *cobra.Command, args []string) error {
 	registryPath, _ := cmd.Flags().GetString("registry")
 	outputPath, _ := cmd.Flags().GetString("output")
 	baseURL, _ := cmd.Flags().GetString("base-url")
+	signingKeyPath, _ := cmd.Flags().GetString("signing-key")
+	publisher, _ := cmd.Flags().GetString("publisher")
+
+	var signer crypto.Signer
+	if signingKeyPath != "" {
+		if publisher == "" {
+			return fmt.Errorf("--publisher is required when signing artifacts")
+		}
+
+		key, err := internal.LoadSigningKey(signingKeyPath)
+		if err != nil {
+			return fmt.Errorf("failed to load signing key: %w", err)
+		}
+
+		signer = key
+	}
 
 	extensionYamlPath := filepath.Join(extensionPath, "extension.yaml")
 	if _, err := os.Stat(extensionYamlPath); err != nil {
@@ -71,7 +90,8 @@ func buildRegistry(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get absolute path for output directory: %w", err)
 	}
 
-	if err := processExtension(absExtensionPath, absArtifactsOutputPath, baseURL, &registry); err != nil {
+	err = processExtension(absExtensionPath, absArtifactsOutputPath, baseURL, signer, publisher, &registry)
+	if err != nil {
 		return fmt.Errorf("failed to process extension: %w", err)
 	}
 
@@ -84,7 +104,14 @@ func buildRegistry(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func processExtension(extensionPath string, outputPath string, baseURL string, registry *extensions.Registry) error {
+func processExtension(
+	extensionPath string,
+	outputPath string,
+	baseURL string,
+	signer crypto.Signer,
+	publisher string,
+	registry *extensions.Registry,
+) error {
 	// Load metadata
 	metadataPath := filepath.Join(extensionPath, "extension.yaml")
 	metadataData, err := os.ReadFile(metadataPath)
@@ -153,6 +180,15 @@ func processExtension(extensionPath string, outputPath string, baseURL string, r
 				return fmt.Errorf("failed to compute checksum for %s: %w", targetFilePath, err)
 			}
 
+			// Sign the archive with the signing key of the publisher
+			var signature *extensions.ExtensionSignature
+			if signer != nil {
+				signature, err = extensions.SignArtifact(signer, publisher, targetFilePath)
+				if err != nil {
+					return fmt.Errorf("failed to sign %s: %w", targetFilePath, err)
+				}
+			}
+
 			// Parse artifact filename to infer OS/ARCH
 			osArch, err := inferOSArch(artifact.Name())
 			if err != nil {
@@ -195,6 +231,7 @@ func processExtension(extensionPath string, outputPath string, baseURL string, r
 					Algorithm: "sha256",
 					Value:     checksum,
 				},
+				Signature:          signature,
 				AdditionalMetadata: platformMetadata,
 			}
 		}
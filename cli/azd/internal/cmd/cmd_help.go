@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
 )
 
 // formatHelpNote provides the expected format in description notes using `•`.
@@ -25,6 +26,18 @@ func generateCmdHelpDescription(title string, notes []string) string {
 	return fmt.Sprintf("%s\n\n%s", title, note)
 }
 
+// ServiceParallelismHelpNote describes how many services are processed at the same time and how to configure it
+func ServiceParallelismHelpNote(verb string) string {
+	return fmt.Sprintf(
+		"Up to %d services are %s at the same time, after the services they depend on. Set %s in 'azure.yaml'"+
+			" or the %s environment variable to change this limit. Services with interactive hooks run on their own.",
+		project.DefaultServiceMaxParallelism,
+		verb,
+		output.WithHighLightFormat("serviceParallelism"),
+		output.WithHighLightFormat(project.ServiceMaxParallelismEnvVarName),
+	)
+}
+
 // generateCmdHelpSamplesBlock converts the samples within the input `samples` to a help text block describing each sample
 // title and the command to run it.
 func generateCmdHelpSamplesBlock(samples map[string]string) string {
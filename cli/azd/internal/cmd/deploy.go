@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
@@ -22,6 +23,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
 	"github.com/azure/azure-dev/cli/azd/pkg/project"
@@ -33,6 +35,9 @@ type DeployFlags struct {
 	serviceName string
 	All         bool
 	fromPackage string
+	slot        string
+	swap        bool
+	rollback    bool
 	global      *internal.GlobalCommandOptions
 	*internal.EnvFlag
 }
@@ -74,6 +79,24 @@ func (d *DeployFlags) bindCommon(local *pflag.FlagSet, global *internal.GlobalCo
 		//nolint:lll
 		"Deploys the packaged service located at the provided path. Supports zipped file packages (file path) or container images (image tag).",
 	)
+	local.StringVar(
+		&d.slot,
+		"slot",
+		"",
+		"Deploys App Service and Function App services to the specified deployment slot instead of production.",
+	)
+	local.BoolVar(
+		&d.swap,
+		"swap",
+		false,
+		"Swaps the deployment slot with production after a successful deployment.",
+	)
+	local.BoolVar(
+		&d.rollback,
+		"rollback",
+		false,
+		"Rolls back the specified Container Apps service to its previous deployment.",
+	)
 }
 
 func (d *DeployFlags) SetCommon(envFlag *internal.EnvFlag) {
@@ -208,6 +231,14 @@ func (da *DeployAction) Run(ctx context.Context) (*actions.ActionResult, error)
 		)
 	}
 
+	if da.flags.rollback {
+		return da.rollback(ctx, targetServiceName)
+	}
+
+	if err := da.applyDeploymentSlotFlags(targetServiceName); err != nil {
+		return nil, err
+	}
+
 	if err := da.projectManager.Initialize(ctx, da.projectConfig); err != nil {
 		return nil, err
 	}
@@ -231,15 +262,15 @@ func (da *DeployAction) Run(ctx context.Context) (*actions.ActionResult, error)
 		return nil, err
 	}
 
-	for _, svc := range stableServices {
-		stepMessage := fmt.Sprintf("Deploying service %s", svc.Name)
-		da.console.ShowSpinner(ctx, stepMessage, input.Step)
+	reporter := project.NewServiceProgressReporter(da.console, "Deploying")
+	servicesToDeploy := []*project.ServiceConfig{}
 
+	for _, svc := range stableServices {
 		// Skip this service if both cases are true:
 		// 1. The user specified a service name
 		// 2. This service is not the one the user specified
 		if targetServiceName != "" && targetServiceName != svc.Name {
-			da.console.StopSpinner(ctx, stepMessage, input.StepSkipped)
+			reporter.Skip(ctx, svc.Name)
 			continue
 		}
 
@@ -249,6 +280,19 @@ func (da *DeployAction) Run(ctx context.Context) (*actions.ActionResult, error)
 			da.console.WarnForFeature(ctx, alphaFeatureId)
 		}
 
+		servicesToDeploy = append(servicesToDeploy, svc)
+	}
+
+	var deployResultsMu sync.Mutex
+	scheduler := project.NewServiceScheduler(project.ServiceMaxParallelism(da.projectConfig))
+	scheduler.OnSkipped(reporter.SkipFailedDependency)
+
+	err = scheduler.Run(ctx, servicesToDeploy, func(ctx context.Context, svc *project.ServiceConfig) error {
+		reporter.Start(ctx, svc.Name)
+		onProgress := func(deployProgress project.ServiceProgress) {
+			reporter.Progress(ctx, svc.Name, deployProgress)
+		}
+
 		var packageResult *project.ServicePackageResult
 		if da.flags.fromPackage != "" {
 			// --from-package set, skip packaging
@@ -257,11 +301,8 @@ func (da *DeployAction) Run(ctx context.Context) (*actions.ActionResult, error)
 			}
 		} else {
 			//  --from-package not set, package the application
-			packageResult, err = async.RunWithProgress(
-				func(packageProgress project.ServiceProgress) {
-					progressMessage := fmt.Sprintf("Deploying service %s (%s)", svc.Name, packageProgress.Message)
-					da.console.ShowSpinner(ctx, progressMessage, input.Step)
-				},
+			result, err := async.RunWithProgress(
+				onProgress,
 				func(progress *async.Progress[project.ServiceProgress]) (*project.ServicePackageResult, error) {
 					return da.serviceManager.Package(ctx, svc, nil, progress, nil)
 				},
@@ -269,30 +310,34 @@ func (da *DeployAction) Run(ctx context.Context) (*actions.ActionResult, error)
 
 			// do not stop progress here as next step is to deploy
 			if err != nil {
-				da.console.StopSpinner(ctx, stepMessage, input.StepFailed)
-				return nil, err
+				reporter.Stop(ctx, svc.Name, err)
+				return err
 			}
+
+			packageResult = result
 		}
 
 		deployResult, err := async.RunWithProgress(
-			func(deployProgress project.ServiceProgress) {
-				progressMessage := fmt.Sprintf("Deploying service %s (%s)", svc.Name, deployProgress.Message)
-				da.console.ShowSpinner(ctx, progressMessage, input.Step)
-			},
+			onProgress,
 			func(progress *async.Progress[project.ServiceProgress]) (*project.ServiceDeployResult, error) {
 				return da.serviceManager.Deploy(ctx, svc, packageResult, progress)
 			},
 		)
-
-		da.console.StopSpinner(ctx, stepMessage, input.GetStepResultFormat(err))
 		if err != nil {
-			return nil, err
+			reporter.Stop(ctx, svc.Name, err)
+			return err
 		}
 
+		deployResultsMu.Lock()
 		deployResults[svc.Name] = deployResult
+		deployResultsMu.Unlock()
 
 		// report deploy outputs
-		da.console.MessageUxItem(ctx, deployResult)
+		reporter.Stop(ctx, svc.Name, nil, deployResult)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	aspireDashboardUrl := apphost.AspireDashboardUrl(ctx, da.env, da.alphaFeatureManager)
@@ -326,6 +371,115 @@ func (da *DeployAction) Run(ctx context.Context) (*actions.ActionResult, error)
 	}, nil
 }
 
+// rollback rolls back the target service to the previous deployment recorded in the deployment history
+func (da *DeployAction) rollback(ctx context.Context, targetServiceName string) (*actions.ActionResult, error) {
+	if targetServiceName == "" || da.flags.All {
+		return nil, errors.New("'--rollback' requires a specific service. Specify a service by passing a <service>")
+	}
+
+	if da.flags.fromPackage != "" || da.flags.slot != "" || da.flags.swap {
+		return nil, errors.New("'--rollback' cannot be combined with '--from-package', '--slot' or '--swap'")
+	}
+
+	svc, has := da.projectConfig.Services[targetServiceName]
+	if !has {
+		return nil, fmt.Errorf("service name '%s' doesn't exist", targetServiceName)
+	}
+
+	if err := da.projectManager.Initialize(ctx, da.projectConfig); err != nil {
+		return nil, err
+	}
+
+	// Command title
+	da.console.MessageUxItem(ctx, &ux.MessageTitle{
+		Title: "Rolling back service (azd deploy --rollback)",
+	})
+
+	startTime := time.Now()
+	reporter := project.NewServiceProgressReporter(da.console, "Rolling back")
+	reporter.Start(ctx, svc.Name)
+
+	deployResult, err := async.RunWithProgress(
+		func(rollbackProgress project.ServiceProgress) {
+			reporter.Progress(ctx, svc.Name, rollbackProgress)
+		},
+		func(progress *async.Progress[project.ServiceProgress]) (*project.ServiceDeployResult, error) {
+			return da.serviceManager.Rollback(ctx, svc, progress)
+		},
+	)
+	if err != nil {
+		reporter.Stop(ctx, svc.Name, err)
+		return nil, err
+	}
+
+	reporter.Stop(ctx, svc.Name, nil, deployResult)
+
+	if da.formatter.Kind() == output.JsonFormat {
+		rollbackResult := DeploymentResult{
+			Timestamp: time.Now(),
+			Services:  map[string]*project.ServiceDeployResult{svc.Name: deployResult},
+		}
+
+		if fmtErr := da.formatter.Format(rollbackResult, da.writer, nil); fmtErr != nil {
+			return nil, fmt.Errorf("rollback result could not be displayed: %w", fmtErr)
+		}
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: fmt.Sprintf(
+				"Service %s was rolled back to its previous deployment in %s.",
+				svc.Name,
+				ux.DurationAsText(since(startTime)),
+			),
+		},
+	}, nil
+}
+
+// applyDeploymentSlotFlags applies the --slot and --swap flags to the services that support deployment slots
+func (da *DeployAction) applyDeploymentSlotFlags(targetServiceName string) error {
+	if da.flags.slot == "" && !da.flags.swap {
+		return nil
+	}
+
+	if targetServiceName != "" {
+		svc, has := da.projectConfig.Services[targetServiceName]
+		if has && !svc.Host.SupportsDeploymentSlots() {
+			return fmt.Errorf(
+				"'--slot' and '--swap' are not supported for service '%s' with host '%s'. "+
+					"Deployment slots are only supported for '%s' and '%s' hosts",
+				svc.Name,
+				svc.Host,
+				project.AppServiceTarget,
+				project.AzureFunctionTarget,
+			)
+		}
+	}
+
+	for _, svc := range da.projectConfig.Services {
+		if !svc.Host.SupportsDeploymentSlots() || (targetServiceName != "" && targetServiceName != svc.Name) {
+			continue
+		}
+
+		if da.flags.slot != "" {
+			svc.DeploymentSlot.Name = osutil.NewExpandableString(da.flags.slot)
+		}
+
+		if da.flags.swap {
+			if svc.DeploymentSlot.Name.Empty() {
+				return fmt.Errorf(
+					"'--swap' requires a deployment slot. Specify '--slot' or set 'deploymentSlot.name' for service '%s'",
+					svc.Name,
+				)
+			}
+
+			svc.DeploymentSlot.Swap = true
+		}
+	}
+
+	return nil
+}
+
 func GetCmdDeployHelpDescription(*cobra.Command) string {
 	return generateCmdHelpDescription("Deploy application to Azure.", []string{
 		formatHelpNote(
@@ -335,6 +489,7 @@ func GetCmdDeployHelpDescription(*cobra.Command) string {
 			fmt.Sprintf("When %s is set, only the specific service is deployed.", output.WithHighLightFormat("<service>"))),
 		formatHelpNote("After the deployment is complete, the endpoint is printed. To start the service, select" +
 			" the endpoint or paste it in a browser."),
+		formatHelpNote(ServiceParallelismHelpNote("deployed")),
 	})
 }
 
@@ -352,5 +507,11 @@ func GetCmdDeployHelpFooter(*cobra.Command) string {
 		"Deploy the service named 'api' to Azure from a previously generated package.": output.WithHighLightFormat(
 			"azd deploy api --from-package <package-path>",
 		),
+		"Deploy the service named 'api' to the 'staging' slot and swap it into production.": output.WithHighLightFormat(
+			"azd deploy api --slot staging --swap",
+		),
+		"Roll back the service named 'api' to its previous deployment.": output.WithHighLightFormat(
+			"azd deploy --rollback api",
+		),
 	})
 }
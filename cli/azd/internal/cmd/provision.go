@@ -4,7 +4,9 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -22,6 +24,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
 	"github.com/azure/azure-dev/cli/azd/pkg/project"
@@ -33,6 +36,10 @@ import (
 type ProvisionFlags struct {
 	noProgress            bool
 	preview               bool
+	checkDrift            bool
+	previewFormat         string
+	previewOutput         string
+	overridePolicy        bool
 	ignoreDeploymentState bool
 	global                *internal.GlobalCommandOptions
 	*internal.EnvFlag
@@ -44,6 +51,10 @@ const (
 		"from kind 'OpenAI'"
 	responsibleAITerms              = "until you agree to Responsible AI terms for this resource"
 	specialFeatureOrQuotaIdRequired = "SpecialFeatureOrQuotaIdRequired"
+
+	// DriftDetectedExitCode is the exit code of 'azd provision --check-drift' when resources have drifted from the
+	// infrastructure
+	DriftDetectedExitCode = 2
 )
 
 func (i *ProvisionFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
@@ -60,6 +71,26 @@ func (i *ProvisionFlags) BindNonCommon(local *pflag.FlagSet, global *internal.Gl
 
 func (i *ProvisionFlags) bindCommon(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
 	local.BoolVar(&i.preview, "preview", false, "Preview changes to Azure resources.")
+	local.BoolVar(
+		&i.checkDrift,
+		"check-drift",
+		false,
+		"Check whether the Azure resources were changed outside of the infrastructure, without applying changes.")
+	local.StringVar(
+		&i.previewFormat,
+		"preview-format",
+		"",
+		"Render the preview as a report of the changes (markdown, html or sarif), for example to post as a PR comment.")
+	local.StringVar(
+		&i.previewOutput,
+		"preview-output",
+		"",
+		"The file to write the preview report to, instead of the standard output shared with the progress of the command.")
+	local.BoolVar(
+		&i.overridePolicy,
+		"override-policy",
+		false,
+		"Provision the Azure resources even when they violate the policy rules of the infrastructure.")
 	local.BoolVar(
 		&i.ignoreDeploymentState,
 		"no-state",
@@ -91,16 +122,20 @@ func NewProvisionFlagsFromEnvAndOptions(envFlag *internal.EnvFlag, global *inter
 }
 
 func NewProvisionCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "provision",
+	cmd := &cobra.Command{
+		Use:   "provision <layer>",
 		Short: "Provision the Azure resources for an application.",
 	}
+	cmd.Args = cobra.MaximumNArgs(1)
+
+	return cmd
 }
 
 type ProvisionAction struct {
 	flags               *ProvisionFlags
+	args                []string
 	provisionManager    *provisioning.Manager
-	projectManager      project.ProjectManager
+	provisioner         *Provisioner
 	resourceManager     project.ResourceManager
 	env                 *environment.Environment
 	envManager          environment.Manager
@@ -109,16 +144,15 @@ type ProvisionAction struct {
 	writer              io.Writer
 	console             input.Console
 	subManager          *account.SubscriptionsManager
-	importManager       *project.ImportManager
 	alphaFeatureManager *alpha.FeatureManager
 	portalUrlBase       string
 }
 
 func NewProvisionAction(
 	flags *ProvisionFlags,
+	args []string,
 	provisionManager *provisioning.Manager,
-	projectManager project.ProjectManager,
-	importManager *project.ImportManager,
+	provisioner *Provisioner,
 	resourceManager project.ResourceManager,
 	projectConfig *project.ProjectConfig,
 	env *environment.Environment,
@@ -132,8 +166,9 @@ func NewProvisionAction(
 ) actions.Action {
 	return &ProvisionAction{
 		flags:               flags,
+		args:                args,
 		provisionManager:    provisionManager,
-		projectManager:      projectManager,
+		provisioner:         provisioner,
 		resourceManager:     resourceManager,
 		env:                 env,
 		envManager:          envManager,
@@ -142,7 +177,6 @@ func NewProvisionAction(
 		writer:              writer,
 		console:             console,
 		subManager:          subManager,
-		importManager:       importManager,
 		alphaFeatureManager: alphaFeatureManager,
 		portalUrlBase:       cloud.PortalUrlBase,
 	}
@@ -167,12 +201,33 @@ func (p *ProvisionAction) Run(ctx context.Context) (*actions.ActionResult, error
 			),
 		)
 	}
-	previewMode := p.flags.preview
+	// drift detection previews the changes against the current state of the resources
+	driftMode := p.flags.checkDrift
+	previewMode := p.flags.preview || driftMode
+
+	var previewFormat provisioning.PreviewFormat
+	if p.flags.previewFormat != "" {
+		if !previewMode {
+			return nil, errors.New("--preview-format requires --preview or --check-drift")
+		}
+
+		format, err := provisioning.ParsePreviewFormat(p.flags.previewFormat)
+		if err != nil {
+			return nil, err
+		}
+
+		previewFormat = format
+	} else if p.flags.previewOutput != "" {
+		return nil, errors.New("--preview-output requires --preview-format")
+	}
 
 	// Command title
 	defaultTitle := "Provisioning Azure resources (azd provision)"
 	defaultTitleNote := "Provisioning Azure resources can take some time"
-	if previewMode {
+	if driftMode {
+		defaultTitle = "Checking Azure resources for drift (azd provision --check-drift)"
+		defaultTitleNote = "No changes will be applied to your Azure resources."
+	} else if previewMode {
 		defaultTitle = "Previewing Azure resource changes (azd provision --preview)"
 		defaultTitleNote = "This is a preview. No changes will be applied to your Azure resources."
 	}
@@ -184,26 +239,105 @@ func (p *ProvisionAction) Run(ctx context.Context) (*actions.ActionResult, error
 
 	startTime := time.Now()
 
-	if err := p.projectManager.Initialize(ctx, p.projectConfig); err != nil {
-		return nil, err
+	layer := ""
+	if len(p.args) == 1 {
+		layer = p.args[0]
 	}
 
-	if err := p.projectManager.EnsureAllTools(ctx, p.projectConfig, nil); err != nil {
+	provisionResult, err := p.provisioner.Provision(ctx, ProvisionOptions{
+		Layer:                 layer,
+		Preview:               p.flags.preview,
+		CheckDrift:            driftMode,
+		OverridePolicy:        p.flags.overridePolicy,
+		IgnoreDeploymentState: p.flags.ignoreDeploymentState,
+		CollectState:          p.formatter.Kind() == output.JsonFormat,
+		OnLayer: func(ctx context.Context, layer provisioning.Options) {
+			if layer.Name != "" {
+				p.console.Message(ctx, fmt.Sprintf("Layer: %s", output.WithHighLightFormat(layer.Name)))
+			}
+		},
+		OnInitialized: p.displayEnvironmentDetails,
+	})
+
+	var deployErr *deploymentError
+	if errors.As(err, &deployErr) {
+		return nil, p.deploymentFailed(ctx, deployErr)
+	} else if err != nil {
 		return nil, err
 	}
 
-	infra, err := p.importManager.ProjectInfrastructure(ctx, p.projectConfig)
-	if err != nil {
-		return nil, err
+	if driftMode {
+		return p.reportDrift(ctx, provisionResult.PreviewResult, previewFormat, startTime)
+	}
+
+	if previewMode {
+		if err := p.displayPreview(ctx, provisionResult.PreviewResult, previewFormat); err != nil {
+			return nil, err
+		}
+
+		return &actions.ActionResult{
+			Message: &actions.ResultMessage{
+				Header: fmt.Sprintf(
+					"Generated provisioning preview in %s.", ux.DurationAsText(since(startTime))),
+				FollowUp: getResourceGroupFollowUp(
+					ctx,
+					p.formatter,
+					p.portalUrlBase,
+					p.projectConfig,
+					p.resourceManager,
+					p.env,
+					true,
+				),
+			},
+		}, nil
+	}
+
+	if provisionResult.DeployResult.SkippedReason == provisioning.DeploymentStateSkipped {
+		return &actions.ActionResult{
+			Message: &actions.ResultMessage{
+				Header: "There are no changes to provision for your application.",
+			},
+		}, nil
 	}
-	defer func() { _ = infra.Cleanup() }()
 
-	infraOptions := infra.Options
-	infraOptions.IgnoreDeploymentState = p.flags.ignoreDeploymentState
-	if err := p.provisionManager.Initialize(ctx, p.projectConfig.Path, infraOptions); err != nil {
-		return nil, fmt.Errorf("initializing provisioning manager: %w", err)
+	if p.formatter.Kind() == output.JsonFormat {
+		if provisionResult.StateErr != nil {
+			return nil, fmt.Errorf(
+				"deployment succeeded but the deployment result is unavailable: %w",
+				multierr.Combine(provisionResult.StateErr, provisionResult.StateErr),
+			)
+		}
+
+		if err := p.formatter.Format(
+			provisioning.NewEnvRefreshResultFromState(
+				provisioning.MergeStates(provisionResult.States...)), p.writer, nil); err != nil {
+			return nil, fmt.Errorf(
+				"deployment succeeded but the deployment result could not be displayed: %w",
+				multierr.Combine(err, err),
+			)
+		}
 	}
 
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: fmt.Sprintf(
+				"Your application was provisioned in Azure in %s.", ux.DurationAsText(since(startTime))),
+			FollowUp: getResourceGroupFollowUp(
+				ctx,
+				p.formatter,
+				p.portalUrlBase,
+				p.projectConfig,
+				p.resourceManager,
+				p.env,
+				false,
+			),
+		},
+	}, nil
+}
+
+// displayEnvironmentDetails displays the subscription and location of the environment, once the provisioning manager
+// is initialized for the first layer.
+func (p *ProvisionAction) displayEnvironmentDetails(ctx context.Context) {
 	// Get Subscription to Display in Command Title Note
 	// Subscription and Location are ONLY displayed when they are available (found from env), otherwise, this message
 	// is not displayed.
@@ -235,166 +369,162 @@ func (p *ProvisionAction) Run(ctx context.Context) (*actions.ActionResult, error
 		log.Printf("failed getting subscriptions. Skip displaying sub and location: %v", subErr)
 	}
 
-	var deployResult *provisioning.DeployResult
-	var deployPreviewResult *provisioning.DeployPreviewResult
-
-	projectEventArgs := project.ProjectLifecycleEventArgs{
-		Project: p.projectConfig,
-		Args: map[string]any{
-			"preview": previewMode,
-		},
-	}
-
 	if p.alphaFeatureManager.IsEnabled(azapi.FeatureDeploymentStacks) {
 		p.console.WarnForFeature(ctx, azapi.FeatureDeploymentStacks)
 	}
+}
 
-	err = p.projectConfig.Invoke(ctx, project.ProjectEventProvision, projectEventArgs, func() error {
-		var err error
-		if previewMode {
-			deployPreviewResult, err = p.provisionManager.Preview(ctx)
-		} else {
-			deployResult, err = p.provisionManager.Deploy(ctx)
+// deploymentFailed displays the state of the infrastructure for the JSON output and suggests actions for the known
+// causes of the failed deployment.
+func (p *ProvisionAction) deploymentFailed(ctx context.Context, deployErr *deploymentError) error {
+	err := deployErr.err
+	if p.formatter.Kind() == output.JsonFormat {
+		stateResult, err := p.provisionManager.State(ctx, nil)
+		if err != nil {
+			return fmt.Errorf(
+				"deployment failed and the deployment result is unavailable: %w",
+				multierr.Combine(err, err),
+			)
 		}
-		return err
-	})
 
-	if err != nil {
-		if p.formatter.Kind() == output.JsonFormat {
-			stateResult, err := p.provisionManager.State(ctx, nil)
-			if err != nil {
-				return nil, fmt.Errorf(
-					"deployment failed and the deployment result is unavailable: %w",
-					multierr.Combine(err, err),
-				)
-			}
-
-			if err := p.formatter.Format(
-				provisioning.NewEnvRefreshResultFromState(stateResult.State), p.writer, nil); err != nil {
-				return nil, fmt.Errorf(
-					"deployment failed and the deployment result could not be displayed: %w",
-					multierr.Combine(err, err),
-				)
-			}
+		if err := p.formatter.Format(
+			provisioning.NewEnvRefreshResultFromState(stateResult.State), p.writer, nil); err != nil {
+			return fmt.Errorf(
+				"deployment failed and the deployment result could not be displayed: %w",
+				multierr.Combine(err, err),
+			)
 		}
+	}
 
-		//if user don't have access to openai
-		errorMsg := err.Error()
-		if strings.Contains(errorMsg, specialFeatureOrQuotaIdRequired) && strings.Contains(errorMsg, "OpenAI") {
-			requestAccessLink := "https://go.microsoft.com/fwlink/?linkid=2259205&clcid=0x409"
-			return nil, &internal.ErrorWithSuggestion{
-				Err: err,
-				Suggestion: "\nSuggested Action: The selected subscription does not have access to" +
-					" Azure OpenAI Services. Please visit " + output.WithLinkFormat("%s", requestAccessLink) +
-					" to request access.",
-			}
+	//if user don't have access to openai
+	errorMsg := err.Error()
+	if strings.Contains(errorMsg, specialFeatureOrQuotaIdRequired) && strings.Contains(errorMsg, "OpenAI") {
+		requestAccessLink := "https://go.microsoft.com/fwlink/?linkid=2259205&clcid=0x409"
+		return &internal.ErrorWithSuggestion{
+			Err: err,
+			Suggestion: "\nSuggested Action: The selected subscription does not have access to" +
+				" Azure OpenAI Services. Please visit " + output.WithLinkFormat("%s", requestAccessLink) +
+				" to request access.",
 		}
+	}
 
-		if strings.Contains(errorMsg, AINotValid) &&
-			strings.Contains(errorMsg, openAIsubscriptionNoQuotaId) {
-			return nil, &internal.ErrorWithSuggestion{
-				Suggestion: "\nSuggested Action: The selected " +
-					"subscription has not been enabled for use of Azure AI service and does not have quota for " +
-					"any pricing tiers. Please visit " + output.WithLinkFormat("%s", p.portalUrlBase) +
-					" and select 'Create' on specific services to request access.",
-				Err: err,
-			}
+	if strings.Contains(errorMsg, AINotValid) &&
+		strings.Contains(errorMsg, openAIsubscriptionNoQuotaId) {
+		return &internal.ErrorWithSuggestion{
+			Suggestion: "\nSuggested Action: The selected " +
+				"subscription has not been enabled for use of Azure AI service and does not have quota for " +
+				"any pricing tiers. Please visit " + output.WithLinkFormat("%s", p.portalUrlBase) +
+				" and select 'Create' on specific services to request access.",
+			Err: err,
 		}
+	}
 
-		//if user haven't agree to Responsible AI terms
-		if strings.Contains(errorMsg, responsibleAITerms) {
-			return nil, &internal.ErrorWithSuggestion{
-				Suggestion: "\nSuggested Action: Please visit azure portal in " +
-					output.WithLinkFormat("%s", p.portalUrlBase) + ". Create the resource in azure portal " +
-					"to go through Responsible AI terms, and then delete it. " +
-					"After that, run 'azd provision' again",
-				Err: err,
-			}
+	//if user haven't agree to Responsible AI terms
+	if strings.Contains(errorMsg, responsibleAITerms) {
+		return &internal.ErrorWithSuggestion{
+			Suggestion: "\nSuggested Action: Please visit azure portal in " +
+				output.WithLinkFormat("%s", p.portalUrlBase) + ". Create the resource in azure portal " +
+				"to go through Responsible AI terms, and then delete it. " +
+				"After that, run 'azd provision' again",
+			Err: err,
 		}
+	}
+
+	return deployErr
+}
 
-		return nil, fmt.Errorf("deployment failed: %w", err)
+// reportDrift displays the resources whose current state differs from the infrastructure. Drift is reported as an
+// error with the DriftDetectedExitCode exit code, so automation can alert on it.
+func (p *ProvisionAction) reportDrift(
+	ctx context.Context,
+	previewResult *provisioning.DeployPreviewResult,
+	previewFormat provisioning.PreviewFormat,
+	startTime time.Time,
+) (*actions.ActionResult, error) {
+	changes := previewResult.Preview.Properties.Changes
+	if undeployed := provisioning.UndeployedChanges(changes); len(undeployed) > 0 {
+		p.console.MessageUxItem(ctx, &ux.WarningMessage{
+			Description: fmt.Sprintf(
+				"%d resource(s) of the infrastructure haven't been deployed yet and aren't reported as drift.",
+				len(undeployed)),
+		})
 	}
 
-	if previewMode {
-		p.console.MessageUxItem(ctx, deployResultToUx(deployPreviewResult))
+	if removed := provisioning.RemovedChanges(changes); len(removed) > 0 {
+		p.console.MessageUxItem(ctx, &ux.WarningMessage{
+			Description: fmt.Sprintf(
+				"%d resource(s) are no longer declared by the infrastructure and aren't reported as drift.",
+				len(removed)),
+		})
+	}
 
+	drifted := provisioning.DriftedChanges(changes)
+	if len(drifted) == 0 {
 		return &actions.ActionResult{
 			Message: &actions.ResultMessage{
 				Header: fmt.Sprintf(
-					"Generated provisioning preview in %s.", ux.DurationAsText(since(startTime))),
-				FollowUp: getResourceGroupFollowUp(
-					ctx,
-					p.formatter,
-					p.portalUrlBase,
-					p.projectConfig,
-					p.resourceManager,
-					p.env,
-					true,
-				),
+					"No drift detected for your Azure resources in %s.", ux.DurationAsText(since(startTime))),
 			},
 		}, nil
 	}
 
-	if deployResult.SkippedReason == provisioning.DeploymentStateSkipped {
-		return &actions.ActionResult{
-			Message: &actions.ResultMessage{
-				Header: "There are no changes to provision for your application.",
+	driftResult := &provisioning.DeployPreviewResult{
+		Preview: &provisioning.DeploymentPreview{
+			Status: previewResult.Preview.Status,
+			Properties: &provisioning.DeploymentPreviewProperties{
+				Changes: drifted,
 			},
-		}, nil
+		},
 	}
-
-	servicesStable, err := p.importManager.ServiceStable(ctx, p.projectConfig)
-	if err != nil {
+	if err := p.displayPreview(ctx, driftResult, previewFormat); err != nil {
 		return nil, err
 	}
 
-	for _, svc := range servicesStable {
-		eventArgs := project.ServiceLifecycleEventArgs{
-			Project: p.projectConfig,
-			Service: svc,
-			Args: map[string]any{
-				"bicepOutput": deployResult.Deployment.Outputs,
-			},
-		}
+	return nil, &internal.ErrorWithExitCode{
+		ExitCode: DriftDetectedExitCode,
+		Err: &internal.ErrorWithSuggestion{
+			Err: fmt.Errorf("drift detected: %d resource(s) differ from the infrastructure", len(drifted)),
+			Suggestion: fmt.Sprintf(
+				"Suggested Action: Run %s to restore the resources to the state defined by the infrastructure.",
+				output.WithHighLightFormat("azd provision")),
+		},
+	}
+}
 
-		if err := svc.RaiseEvent(ctx, project.ServiceEventEnvUpdated, eventArgs); err != nil {
-			return nil, err
-		}
+// displayPreview displays the changes of a preview, as a report in the preview format when specified. The report is
+// written to the preview output file when specified.
+func (p *ProvisionAction) displayPreview(
+	ctx context.Context,
+	previewResult *provisioning.DeployPreviewResult,
+	previewFormat provisioning.PreviewFormat,
+) error {
+	if previewFormat == "" {
+		p.console.MessageUxItem(ctx, deployResultToUx(previewResult))
+		return nil
 	}
 
-	if p.formatter.Kind() == output.JsonFormat {
-		stateResult, err := p.provisionManager.State(ctx, nil)
-		if err != nil {
-			return nil, fmt.Errorf(
-				"deployment succeeded but the deployment result is unavailable: %w",
-				multierr.Combine(err, err),
-			)
+	if p.flags.previewOutput == "" {
+		if err := provisioning.WritePreviewReport(p.writer, previewFormat, previewResult.Preview); err != nil {
+			return fmt.Errorf("writing preview report: %w", err)
 		}
 
-		if err := p.formatter.Format(
-			provisioning.NewEnvRefreshResultFromState(stateResult.State), p.writer, nil); err != nil {
-			return nil, fmt.Errorf(
-				"deployment succeeded but the deployment result could not be displayed: %w",
-				multierr.Combine(err, err),
-			)
-		}
+		return nil
 	}
 
-	return &actions.ActionResult{
-		Message: &actions.ResultMessage{
-			Header: fmt.Sprintf(
-				"Your application was provisioned in Azure in %s.", ux.DurationAsText(since(startTime))),
-			FollowUp: getResourceGroupFollowUp(
-				ctx,
-				p.formatter,
-				p.portalUrlBase,
-				p.projectConfig,
-				p.resourceManager,
-				p.env,
-				false,
-			),
-		},
-	}, nil
+	var report bytes.Buffer
+	if err := provisioning.WritePreviewReport(&report, previewFormat, previewResult.Preview); err != nil {
+		return fmt.Errorf("writing preview report: %w", err)
+	}
+
+	if err := os.WriteFile(p.flags.previewOutput, report.Bytes(), osutil.PermissionFile); err != nil {
+		return fmt.Errorf("writing preview report: %w", err)
+	}
+
+	p.console.MessageUxItem(ctx, &ux.DoneMessage{
+		Message: fmt.Sprintf("Preview report written to %s", output.WithHighLightFormat(p.flags.previewOutput)),
+	})
+
+	return nil
 }
 
 // deployResultToUx creates the ux element to display from a provision preview
@@ -417,6 +547,12 @@ func GetCmdProvisionHelpDescription(c *cobra.Command) string {
 		"Provision the Azure resources for an application."+
 			" This step may take a while depending on the resources provisioned."+
 			" You should run %s any time you update your Bicep or Terraform file."+
+			" When the infrastructure defines layers, the layers are provisioned in order,"+
+			" or only the layer specified as argument."+
+			" Use --check-drift to list the resources changed outside of the infrastructure, which exits with"+
+			" code 2 when drift is detected."+
+			" When the infrastructure defines a policy directory, the resources are evaluated against its rules"+
+			" before they are provisioned."+
 			"\n\nThis command prompts you to input the following:",
 		output.WithHighLightFormat(c.CommandPath())), []string{
 		formatHelpNote("Azure location: The Azure location where your resources will be deployed."),
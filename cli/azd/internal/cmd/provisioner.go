@@ -0,0 +1,361 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+)
+
+// Provisioner provisions the layers of the infrastructure of the project in order, within the provision event of the
+// project. It is shared by `azd provision` and the operations extensions use to provision the project.
+type Provisioner struct {
+	provisionManager *provisioning.Manager
+	projectManager   project.ProjectManager
+	importManager    *project.ImportManager
+	projectConfig    *project.ProjectConfig
+	env              *environment.Environment
+	envManager       environment.Manager
+}
+
+// ProvisionOptions are the options of a provisioning of the project
+type ProvisionOptions struct {
+	// The layer of the infrastructure to provision, all the layers when empty
+	Layer string
+	// Previews the changes to the Azure resources without applying them
+	Preview bool
+	// Previews the changes against the current state of the resources, to detect drift
+	CheckDrift bool
+	// Provisions the Azure resources even when they violate the policy rules of the infrastructure
+	OverridePolicy bool
+	// Ignores the state of the latest deployment
+	IgnoreDeploymentState bool
+	// Collects the state of the provisioned layers, ex) for the JSON output
+	CollectState bool
+	// Invoked before the provisioning manager is initialized for each layer of the infrastructure
+	OnLayer func(ctx context.Context, layer provisioning.Options)
+	// Invoked once the provisioning manager is initialized for the first layer, which makes the environment ready
+	// for the provisioning provider
+	OnInitialized func(ctx context.Context)
+}
+
+// ProvisionResult is the result of a provisioning of the project
+type ProvisionResult struct {
+	// The merged result of the provisioned layers, nil for previews
+	DeployResult *provisioning.DeployResult
+	// The changes of the previewed layers, nil when not previewing
+	PreviewResult *provisioning.DeployPreviewResult
+	// The states of the provisioned layers, when collected
+	States []*provisioning.State
+	// The error that stopped collecting the states of the provisioned layers
+	StateErr error
+}
+
+// deploymentError is returned when the deployment of a layer of the infrastructure failed, as opposed to failing to
+// prepare the deployment
+type deploymentError struct {
+	err error
+}
+
+func (e *deploymentError) Error() string {
+	return fmt.Sprintf("deployment failed: %s", e.err.Error())
+}
+
+func (e *deploymentError) Unwrap() error {
+	return e.err
+}
+
+func NewProvisioner(
+	provisionManager *provisioning.Manager,
+	projectManager project.ProjectManager,
+	importManager *project.ImportManager,
+	projectConfig *project.ProjectConfig,
+	env *environment.Environment,
+	envManager environment.Manager,
+) *Provisioner {
+	return &Provisioner{
+		provisionManager: provisionManager,
+		projectManager:   projectManager,
+		importManager:    importManager,
+		projectConfig:    projectConfig,
+		env:              env,
+		envManager:       envManager,
+	}
+}
+
+// Provision provisions, or previews, the infrastructure of the project. The environment is locked while the
+// infrastructure is provisioned, unless the current azd command already holds the lock.
+func (p *Provisioner) Provision(ctx context.Context, options ProvisionOptions) (*ProvisionResult, error) {
+	previewMode := options.Preview || options.CheckDrift
+	if !previewMode {
+		release, err := environment.AcquireLock(ctx, p.envManager, p.env.Name(), "azd provision")
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+
+	if err := p.projectManager.Initialize(ctx, p.projectConfig); err != nil {
+		return nil, err
+	}
+
+	if err := p.projectManager.EnsureAllTools(ctx, p.projectConfig, nil); err != nil {
+		return nil, err
+	}
+
+	infra, err := p.importManager.ProjectInfrastructure(ctx, p.projectConfig)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = infra.Cleanup() }()
+
+	infraOptions := infra.Options
+	infraOptions.IgnoreDeploymentState = options.IgnoreDeploymentState
+	infraOptions.CheckDrift = options.CheckDrift
+	infraOptions.OverridePolicy = options.OverridePolicy
+
+	// The layers of the infrastructure are provisioned in order, or only the specified layer
+	layers := infraOptions.GetLayers()
+	if options.Layer != "" {
+		layer, err := infraOptions.GetLayer(options.Layer)
+		if err != nil {
+			return nil, err
+		}
+
+		layers = []provisioning.Options{layer}
+	}
+
+	if err := p.initializeLayer(ctx, layers[0], options); err != nil {
+		return nil, err
+	}
+
+	if options.OnInitialized != nil {
+		options.OnInitialized(ctx)
+	}
+
+	result := &ProvisionResult{}
+	if previewMode {
+		result.PreviewResult = &provisioning.DeployPreviewResult{
+			Preview: &provisioning.DeploymentPreview{
+				Properties: &provisioning.DeploymentPreviewProperties{},
+			},
+		}
+	}
+
+	var deployResults []*provisioning.DeployResult
+	var previewStatuses []string
+	var layerErr error
+
+	// the outputs of the previewed layers are not in the environment until the layers are provisioned, placeholders
+	// are set for them while the following layers are previewed
+	var placeholders []string
+	defer func() {
+		for _, key := range placeholders {
+			_ = os.Unsetenv(key)
+		}
+	}()
+
+	projectEventArgs := project.ProjectLifecycleEventArgs{
+		Project: p.projectConfig,
+		Args: map[string]any{
+			"preview": previewMode,
+		},
+	}
+
+	err = p.projectConfig.Invoke(ctx, project.ProjectEventProvision, projectEventArgs, func() error {
+		for i, layer := range layers {
+			// the following layers are initialized once the previous layers are provisioned, which makes the outputs
+			// of the previous layers available in the environment
+			if i > 0 {
+				if layerErr = p.initializeLayer(ctx, layer, options); layerErr != nil {
+					return layerErr
+				}
+			}
+
+			if previewMode {
+				previewResult, err := p.provisionManager.Preview(ctx)
+				if err != nil {
+					return err
+				}
+
+				previewStatuses = append(previewStatuses, previewResult.Preview.Status)
+				result.PreviewResult.Preview.Properties.Changes = append(
+					result.PreviewResult.Preview.Properties.Changes, previewResult.Preview.Properties.Changes...)
+
+				if i < len(layers)-1 {
+					layerPlaceholders, err := p.setOutputPlaceholders(previewResult.Preview.Outputs)
+					placeholders = append(placeholders, layerPlaceholders...)
+					if err != nil {
+						return err
+					}
+				}
+			} else {
+				deployResult, err := p.provisionManager.Deploy(ctx)
+				if err != nil {
+					return err
+				}
+
+				deployResults = append(deployResults, deployResult)
+
+				if options.CollectState && result.StateErr == nil {
+					stateResult, err := p.provisionManager.State(ctx, nil)
+					if err != nil {
+						result.StateErr = err
+					} else {
+						result.States = append(result.States, stateResult.State)
+					}
+				}
+			}
+		}
+
+		return nil
+	})
+
+	if layerErr != nil {
+		return nil, layerErr
+	}
+
+	if err != nil {
+		return nil, &deploymentError{err: err}
+	}
+
+	if previewMode {
+		result.PreviewResult.Preview.Status = mergePreviewStatuses(layers, previewStatuses)
+		return result, nil
+	}
+
+	result.DeployResult = mergeDeployResults(deployResults)
+	if result.DeployResult.SkippedReason == provisioning.DeploymentStateSkipped {
+		return result, nil
+	}
+
+	servicesStable, err := p.importManager.ServiceStable(ctx, p.projectConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, svc := range servicesStable {
+		eventArgs := project.ServiceLifecycleEventArgs{
+			Project: p.projectConfig,
+			Service: svc,
+			Args: map[string]any{
+				"bicepOutput": result.DeployResult.Deployment.Outputs,
+			},
+		}
+
+		if err := svc.RaiseEvent(ctx, project.ServiceEventEnvUpdated, eventArgs); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// initializeLayer initializes the provisioning manager for the layer of the infrastructure.
+func (p *Provisioner) initializeLayer(ctx context.Context, layer provisioning.Options, options ProvisionOptions) error {
+	if options.OnLayer != nil {
+		options.OnLayer(ctx, layer)
+	}
+
+	if err := p.provisionManager.Initialize(ctx, p.projectConfig.Path, layer); err != nil {
+		return fmt.Errorf("initializing provisioning manager: %w", err)
+	}
+
+	return nil
+}
+
+// setOutputPlaceholders sets placeholder values for the outputs of a previewed layer that are not in the environment,
+// which lets the parameters of the following layers reference them before the layer is provisioned. The placeholders are
+// set in the environment of the process, which the environment falls back to, so they are never saved with the
+// environment. Returns the names of the variables set.
+func (p *Provisioner) setOutputPlaceholders(outputs map[string]provisioning.OutputParameter) ([]string, error) {
+	var keys []string
+	for key, output := range outputs {
+		if _, has := p.env.LookupEnv(key); has {
+			continue
+		}
+
+		if err := os.Setenv(key, outputPlaceholder(output.Type)); err != nil {
+			return keys, fmt.Errorf("setting placeholder for output '%s': %w", key, err)
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// outputPlaceholder returns a valid value of the output type, standing for the value of an output that is not known
+// until the layer is provisioned.
+func outputPlaceholder(outputType provisioning.ParameterType) string {
+	switch outputType {
+	case provisioning.ParameterTypeNumber:
+		return "0"
+	case provisioning.ParameterTypeBoolean:
+		return "false"
+	case provisioning.ParameterTypeArray:
+		return "[]"
+	case provisioning.ParameterTypeObject:
+		return "{}"
+	default:
+		return "azdpreviewplaceholder"
+	}
+}
+
+// mergePreviewStatuses combines the statuses of the previewed layers, which is the status shared by all the layers, or
+// the status of each layer when they differ.
+func mergePreviewStatuses(layers []provisioning.Options, statuses []string) string {
+	if len(statuses) == 0 {
+		return ""
+	}
+
+	layerStatuses := make([]string, len(statuses))
+	shared := true
+	for i, status := range statuses {
+		shared = shared && status == statuses[0]
+		layerStatuses[i] = fmt.Sprintf("%s: %s", layers[i].Name, status)
+	}
+
+	if shared {
+		return statuses[0]
+	}
+
+	return strings.Join(layerStatuses, ", ")
+}
+
+// mergeDeployResults combines the results of the layers of the infrastructure into a single result, skipped when all
+// the layers are skipped.
+func mergeDeployResults(deployResults []*provisioning.DeployResult) *provisioning.DeployResult {
+	if len(deployResults) == 1 {
+		return deployResults[0]
+	}
+
+	merged := &provisioning.DeployResult{
+		Deployment: &provisioning.Deployment{
+			Parameters: map[string]provisioning.InputParameter{},
+			Outputs:    map[string]provisioning.OutputParameter{},
+		},
+		SkippedReason: provisioning.DeploymentStateSkipped,
+	}
+
+	for _, deployResult := range deployResults {
+		if deployResult.SkippedReason != provisioning.DeploymentStateSkipped {
+			merged.SkippedReason = ""
+		}
+
+		if deployResult.Deployment != nil {
+			maps.Copy(merged.Deployment.Parameters, deployResult.Deployment.Parameters)
+			maps.Copy(merged.Deployment.Outputs, deployResult.Deployment.Outputs)
+		}
+	}
+
+	return merged
+}
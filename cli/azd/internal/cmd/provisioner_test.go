@@ -0,0 +1,74 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MergeDeployResults(t *testing.T) {
+	network := &provisioning.DeployResult{
+		Deployment:    &provisioning.Deployment{},
+		SkippedReason: provisioning.DeploymentStateSkipped,
+	}
+	app := &provisioning.DeployResult{
+		Deployment: &provisioning.Deployment{
+			Parameters: map[string]provisioning.InputParameter{
+				"vnetId": {Type: "string", Value: "vnet"},
+			},
+			Outputs: map[string]provisioning.OutputParameter{
+				"WEBSITE_URL": {Type: provisioning.ParameterTypeString, Value: "https://contoso.com"},
+			},
+		},
+	}
+
+	require.Same(t, app, mergeDeployResults([]*provisioning.DeployResult{app}))
+
+	merged := mergeDeployResults([]*provisioning.DeployResult{network, app})
+	require.Empty(t, merged.SkippedReason)
+	require.Equal(t, app.Deployment.Parameters, merged.Deployment.Parameters)
+	require.Equal(t, app.Deployment.Outputs, merged.Deployment.Outputs)
+
+	skipped := mergeDeployResults([]*provisioning.DeployResult{network, network})
+	require.Equal(t, provisioning.DeploymentStateSkipped, skipped.SkippedReason)
+}
+
+func Test_MergePreviewStatuses(t *testing.T) {
+	layers := []provisioning.Options{{Name: "network"}, {Name: "app"}}
+
+	require.Equal(t, "Succeeded", mergePreviewStatuses(layers, []string{"Succeeded", "Succeeded"}))
+	require.Equal(t, "network: Succeeded, app: Failed", mergePreviewStatuses(layers, []string{"Succeeded", "Failed"}))
+}
+
+func Test_SetOutputPlaceholders(t *testing.T) {
+	t.Setenv("AZURE_VNET_ID", "")
+	os.Unsetenv("AZURE_VNET_ID")
+	t.Setenv("AZURE_SUBNET_IDS", "")
+	os.Unsetenv("AZURE_SUBNET_IDS")
+
+	provisioner := &Provisioner{
+		env: environment.NewWithValues("dev", map[string]string{
+			"AZURE_LOCATION": "eastus2",
+		}),
+	}
+
+	keys, err := provisioner.setOutputPlaceholders(map[string]provisioning.OutputParameter{
+		"AZURE_LOCATION":   {Type: provisioning.ParameterTypeString},
+		"AZURE_VNET_ID":    {Type: provisioning.ParameterTypeString},
+		"AZURE_SUBNET_IDS": {Type: provisioning.ParameterTypeArray},
+	})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"AZURE_VNET_ID", "AZURE_SUBNET_IDS"}, keys)
+
+	// the placeholders are read through the environment without being stored in it
+	require.Equal(t, "eastus2", provisioner.env.Getenv("AZURE_LOCATION"))
+	require.Equal(t, "azdpreviewplaceholder", provisioner.env.Getenv("AZURE_VNET_ID"))
+	require.Equal(t, "[]", provisioner.env.Getenv("AZURE_SUBNET_IDS"))
+	require.NotContains(t, provisioner.env.Dotenv(), "AZURE_VNET_ID")
+}
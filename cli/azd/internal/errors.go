@@ -34,3 +34,20 @@ func (et *ErrorWithTraceId) Error() string {
 func (et *ErrorWithTraceId) Unwrap() error {
 	return et.Err
 }
+
+// ErrorWithExitCode is a custom error type that includes the exit code of the process, for errors that automation
+// needs to distinguish from a generic failure
+type ErrorWithExitCode struct {
+	ExitCode int
+	Err      error
+}
+
+// Error returns the error message
+func (ee *ErrorWithExitCode) Error() string {
+	return ee.Err.Error()
+}
+
+// Unwrap returns the wrapped error
+func (ee *ErrorWithExitCode) Unwrap() error {
+	return ee.Err
+}
@@ -175,7 +175,7 @@ func (s *eventService) sendProjectInvokeMessage(
 		MessageType: &azdext.EventMessage_InvokeProjectHandler{
 			InvokeProjectHandler: &azdext.InvokeProjectHandler{
 				EventName: eventName,
-				Project:   s.createProjectConfig(proj),
+				Project:   createProjectConfig(proj, s.lazyEnv),
 			},
 		},
 	})
@@ -273,8 +273,8 @@ func (s *eventService) sendServiceInvokeMessage(
 		MessageType: &azdext.EventMessage_InvokeServiceHandler{
 			InvokeServiceHandler: &azdext.InvokeServiceHandler{
 				EventName: eventName,
-				Project:   s.createProjectConfig(proj),
-				Service:   s.createServiceConfig(svc),
+				Project:   createProjectConfig(proj, s.lazyEnv),
+				Service:   createServiceConfig(svc, s.lazyEnv),
 			},
 		},
 	})
@@ -324,10 +324,13 @@ func (s *eventService) handleServiceHandlerStatus(statusMessage *azdext.ServiceH
 }
 
 // createProjectConfig converts a project.ProjectConfig into the azdext.ProjectConfig wire format.
-func (s *eventService) createProjectConfig(proj *project.ProjectConfig) *azdext.ProjectConfig {
+func createProjectConfig(
+	proj *project.ProjectConfig,
+	lazyEnv *lazy.Lazy[*environment.Environment],
+) *azdext.ProjectConfig {
 	resolver := noEnvResolver
 
-	env, err := s.lazyEnv.GetValue()
+	env, err := lazyEnv.GetValue()
 	if err == nil && env != nil {
 		resolver = env.Getenv
 	}
@@ -339,7 +342,7 @@ func (s *eventService) createProjectConfig(proj *project.ProjectConfig) *azdext.
 
 	services := make(map[string]*azdext.ServiceConfig, len(proj.Services))
 	for i, svc := range proj.Services {
-		services[i] = s.createServiceConfig(svc)
+		services[i] = createServiceConfig(svc, lazyEnv)
 	}
 
 	projectConfig := &azdext.ProjectConfig{
@@ -364,10 +367,13 @@ func (s *eventService) createProjectConfig(proj *project.ProjectConfig) *azdext.
 }
 
 // createServiceConfig converts a project.ServiceConfig into the azdext.ServiceConfig wire format.
-func (s *eventService) createServiceConfig(svc *project.ServiceConfig) *azdext.ServiceConfig {
+func createServiceConfig(
+	svc *project.ServiceConfig,
+	lazyEnv *lazy.Lazy[*environment.Environment],
+) *azdext.ServiceConfig {
 	resolver := noEnvResolver
 
-	env, err := s.lazyEnv.GetValue()
+	env, err := lazyEnv.GetValue()
 	if err == nil && env != nil {
 		resolver = env.Getenv
 	}
@@ -0,0 +1,196 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"google.golang.org/grpc"
+)
+
+// frameworkServiceConnection forwards framework service requests to an extension over the framework service stream.
+// A single connection is shared by all the languages registered by the extension.
+type frameworkServiceConnection struct {
+	*requestBroker[azdext.FrameworkServiceMessage, *azdext.FrameworkServiceMessage]
+	lazyEnv *lazy.Lazy[*environment.Environment]
+}
+
+func newFrameworkServiceConnection(
+	extension *extensions.Extension,
+	stream grpc.BidiStreamingServer[azdext.FrameworkServiceMessage, azdext.FrameworkServiceMessage],
+	lazyEnv *lazy.Lazy[*environment.Environment],
+) *frameworkServiceConnection {
+	return &frameworkServiceConnection{
+		requestBroker: newRequestBroker[azdext.FrameworkServiceMessage](extension, stream),
+		lazyEnv:       lazyEnv,
+	}
+}
+
+// extensionFrameworkService is a project.FrameworkService for a language registered by an extension.
+type extensionFrameworkService struct {
+	connection   *frameworkServiceConnection
+	requirements project.FrameworkRequirements
+}
+
+func newExtensionFrameworkService(
+	connection *frameworkServiceConnection,
+	requirements *azdext.FrameworkRequirements,
+) *extensionFrameworkService {
+	return &extensionFrameworkService{
+		connection: connection,
+		requirements: project.FrameworkRequirements{
+			Package: project.FrameworkPackageRequirements{
+				RequireRestore: requirements.GetRequireRestore(),
+				RequireBuild:   requirements.GetRequireBuild(),
+			},
+		},
+	}
+}
+
+// RequiredExternalTools returns no tools since the tools used by the extension are managed by the extension itself
+func (fs *extensionFrameworkService) RequiredExternalTools(
+	ctx context.Context,
+	serviceConfig *project.ServiceConfig,
+) []tools.ExternalTool {
+	return []tools.ExternalTool{}
+}
+
+func (fs *extensionFrameworkService) Initialize(ctx context.Context, serviceConfig *project.ServiceConfig) error {
+	_, err := fs.connection.invoke(ctx, &azdext.FrameworkServiceMessage{
+		MessageType: &azdext.FrameworkServiceMessage_InitializeRequest{
+			InitializeRequest: &azdext.FrameworkServiceInitializeRequest{
+				ServiceConfig: createServiceConfig(serviceConfig, fs.connection.lazyEnv),
+			},
+		},
+	}, nil)
+
+	return err
+}
+
+// Requirements returns the requirements declared by the extension when the language was registered
+func (fs *extensionFrameworkService) Requirements() project.FrameworkRequirements {
+	return fs.requirements
+}
+
+func (fs *extensionFrameworkService) Restore(
+	ctx context.Context,
+	serviceConfig *project.ServiceConfig,
+	progress *async.Progress[project.ServiceProgress],
+) (*project.ServiceRestoreResult, error) {
+	response, err := fs.connection.invoke(ctx, &azdext.FrameworkServiceMessage{
+		MessageType: &azdext.FrameworkServiceMessage_RestoreRequest{
+			RestoreRequest: &azdext.FrameworkServiceRestoreRequest{
+				ServiceConfig: createServiceConfig(serviceConfig, fs.connection.lazyEnv),
+			},
+		},
+	}, serviceProgress(progress))
+	if err != nil {
+		return nil, err
+	}
+
+	restoreResult := &project.ServiceRestoreResult{}
+	if result := response.GetRestoreResponse().GetResult(); result != nil {
+		restoreResult.Details = result.Details
+	}
+
+	return restoreResult, nil
+}
+
+func (fs *extensionFrameworkService) Build(
+	ctx context.Context,
+	serviceConfig *project.ServiceConfig,
+	restoreOutput *project.ServiceRestoreResult,
+	progress *async.Progress[project.ServiceProgress],
+) (*project.ServiceBuildResult, error) {
+	response, err := fs.connection.invoke(ctx, &azdext.FrameworkServiceMessage{
+		MessageType: &azdext.FrameworkServiceMessage_BuildRequest{
+			BuildRequest: &azdext.FrameworkServiceBuildRequest{
+				ServiceConfig: createServiceConfig(serviceConfig, fs.connection.lazyEnv),
+				RestoreOutput: createServiceRestoreResult(restoreOutput),
+			},
+		},
+	}, serviceProgress(progress))
+	if err != nil {
+		return nil, err
+	}
+
+	buildResult := &project.ServiceBuildResult{
+		Restore: restoreOutput,
+	}
+
+	if result := response.GetBuildResponse().GetResult(); result != nil {
+		buildResult.BuildOutputPath = result.BuildOutputPath
+		buildResult.Details = result.Details
+	}
+
+	return buildResult, nil
+}
+
+func (fs *extensionFrameworkService) Package(
+	ctx context.Context,
+	serviceConfig *project.ServiceConfig,
+	buildOutput *project.ServiceBuildResult,
+	progress *async.Progress[project.ServiceProgress],
+) (*project.ServicePackageResult, error) {
+	response, err := fs.connection.invoke(ctx, &azdext.FrameworkServiceMessage{
+		MessageType: &azdext.FrameworkServiceMessage_PackageRequest{
+			PackageRequest: &azdext.FrameworkServicePackageRequest{
+				ServiceConfig: createServiceConfig(serviceConfig, fs.connection.lazyEnv),
+				BuildOutput:   createServiceBuildResult(buildOutput),
+			},
+		},
+	}, serviceProgress(progress))
+	if err != nil {
+		return nil, err
+	}
+
+	packageResult := &project.ServicePackageResult{
+		Build: buildOutput,
+	}
+
+	if result := response.GetPackageResponse().GetResult(); result != nil {
+		packageResult.PackagePath = result.PackagePath
+		packageResult.Details = result.Details
+	}
+
+	return packageResult, nil
+}
+
+// createServiceRestoreResult converts a project.ServiceRestoreResult into the azdext.ServiceRestoreResult wire format.
+func createServiceRestoreResult(restoreResult *project.ServiceRestoreResult) *azdext.ServiceRestoreResult {
+	if restoreResult == nil {
+		return nil
+	}
+
+	result := &azdext.ServiceRestoreResult{}
+	if details, ok := restoreResult.Details.(map[string]string); ok {
+		result.Details = details
+	}
+
+	return result
+}
+
+// createServiceBuildResult converts a project.ServiceBuildResult into the azdext.ServiceBuildResult wire format.
+func createServiceBuildResult(buildResult *project.ServiceBuildResult) *azdext.ServiceBuildResult {
+	if buildResult == nil {
+		return nil
+	}
+
+	result := &azdext.ServiceBuildResult{
+		BuildOutputPath: buildResult.BuildOutputPath,
+	}
+
+	if details, ok := buildResult.Details.(map[string]string); ok {
+		result.Details = details
+	}
+
+	return result
+}
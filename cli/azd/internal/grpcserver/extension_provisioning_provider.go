@@ -0,0 +1,266 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"google.golang.org/grpc"
+)
+
+// extensionProvisioningProvider is a provisioning.Provider that forwards the provisioning operations
+// to an extension over the provisioning stream.
+type extensionProvisioningProvider struct {
+	*requestBroker[azdext.ProvisioningMessage, *azdext.ProvisioningMessage]
+	name    string
+	console input.Console
+}
+
+func newExtensionProvisioningProvider(
+	extension *extensions.Extension,
+	stream grpc.BidiStreamingServer[azdext.ProvisioningMessage, azdext.ProvisioningMessage],
+	console input.Console,
+) *extensionProvisioningProvider {
+	return &extensionProvisioningProvider{
+		requestBroker: newRequestBroker[azdext.ProvisioningMessage](extension, stream),
+		console:       console,
+	}
+}
+
+// Name returns the name registered by the extension, defaulting to the display name of the extension
+func (p *extensionProvisioningProvider) Name() string {
+	if p.name != "" {
+		return p.name
+	}
+
+	if p.extension.DisplayName != "" {
+		return p.extension.DisplayName
+	}
+
+	return p.extension.Id
+}
+
+func (p *extensionProvisioningProvider) Initialize(
+	ctx context.Context,
+	projectPath string,
+	options provisioning.Options,
+) error {
+	_, err := p.invoke(ctx, &azdext.ProvisioningMessage{
+		MessageType: &azdext.ProvisioningMessage_InitializeRequest{
+			InitializeRequest: &azdext.ProvisioningInitializeRequest{
+				ProjectPath: projectPath,
+				Options: &azdext.ProvisioningOptions{
+					Provider: string(options.Provider),
+					Path:     options.Path,
+					Module:   options.Module,
+				},
+			},
+		},
+	})
+
+	return err
+}
+
+func (p *extensionProvisioningProvider) State(
+	ctx context.Context,
+	options *provisioning.StateOptions,
+) (*provisioning.StateResult, error) {
+	hint := ""
+	if options != nil {
+		hint = options.Hint()
+	}
+
+	response, err := p.invoke(ctx, &azdext.ProvisioningMessage{
+		MessageType: &azdext.ProvisioningMessage_StateRequest{
+			StateRequest: &azdext.ProvisioningStateRequest{
+				Hint: hint,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	state := response.GetStateResponse().GetState()
+	outputs, err := p.createOutputParameters(state.GetOutputs())
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provisioning.Resource, len(state.GetResourceIds()))
+	for i, id := range state.GetResourceIds() {
+		resources[i] = provisioning.Resource{Id: id}
+	}
+
+	return &provisioning.StateResult{
+		State: &provisioning.State{
+			Outputs:   outputs,
+			Resources: resources,
+		},
+	}, nil
+}
+
+func (p *extensionProvisioningProvider) Deploy(ctx context.Context) (*provisioning.DeployResult, error) {
+	response, err := p.invoke(ctx, &azdext.ProvisioningMessage{
+		MessageType: &azdext.ProvisioningMessage_DeployRequest{
+			DeployRequest: &azdext.ProvisioningDeployRequest{},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := response.GetDeployResponse().GetResult()
+	deployment := result.GetDeployment()
+
+	parameters := make(map[string]provisioning.InputParameter, len(deployment.GetParameters()))
+	for name, parameter := range deployment.GetParameters() {
+		inputParameter := provisioning.InputParameter{
+			Type: parameter.Type,
+		}
+
+		if err := unmarshalParameterValue(parameter.DefaultValue, &inputParameter.DefaultValue); err != nil {
+			return nil, fmt.Errorf("extension '%s' returned an invalid parameter '%s': %w", p.extension.Id, name, err)
+		}
+
+		if err := unmarshalParameterValue(parameter.Value, &inputParameter.Value); err != nil {
+			return nil, fmt.Errorf("extension '%s' returned an invalid parameter '%s': %w", p.extension.Id, name, err)
+		}
+
+		parameters[name] = inputParameter
+	}
+
+	outputs, err := p.createOutputParameters(deployment.GetOutputs())
+	if err != nil {
+		return nil, err
+	}
+
+	return &provisioning.DeployResult{
+		Deployment: &provisioning.Deployment{
+			Parameters: parameters,
+			Outputs:    outputs,
+		},
+		SkippedReason: provisioning.SkippedReasonType(result.GetSkippedReason()),
+	}, nil
+}
+
+func (p *extensionProvisioningProvider) Preview(ctx context.Context) (*provisioning.DeployPreviewResult, error) {
+	response, err := p.invoke(ctx, &azdext.ProvisioningMessage{
+		MessageType: &azdext.ProvisioningMessage_PreviewRequest{
+			PreviewRequest: &azdext.ProvisioningPreviewRequest{},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	preview := response.GetPreviewResponse().GetPreview()
+	changes := make([]*provisioning.DeploymentPreviewChange, len(preview.GetChanges()))
+	for i, change := range preview.GetChanges() {
+		changes[i] = &provisioning.DeploymentPreviewChange{
+			ChangeType:   provisioning.ChangeType(change.ChangeType),
+			ResourceId:   provisioning.Resource{Id: change.ResourceId},
+			ResourceType: change.ResourceType,
+			Name:         change.Name,
+		}
+	}
+
+	return &provisioning.DeployPreviewResult{
+		Preview: &provisioning.DeploymentPreview{
+			Status: preview.GetStatus(),
+			Properties: &provisioning.DeploymentPreviewProperties{
+				Changes: changes,
+			},
+		},
+	}, nil
+}
+
+func (p *extensionProvisioningProvider) Destroy(
+	ctx context.Context,
+	options provisioning.DestroyOptions,
+) (*provisioning.DestroyResult, error) {
+	response, err := p.invoke(ctx, &azdext.ProvisioningMessage{
+		MessageType: &azdext.ProvisioningMessage_DestroyRequest{
+			DestroyRequest: &azdext.ProvisioningDestroyRequest{
+				Force: options.Force(),
+				Purge: options.Purge(),
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &provisioning.DestroyResult{
+		InvalidatedEnvKeys: response.GetDestroyResponse().GetInvalidatedEnvKeys(),
+	}, nil
+}
+
+func (p *extensionProvisioningProvider) EnsureEnv(ctx context.Context) error {
+	_, err := p.invoke(ctx, &azdext.ProvisioningMessage{
+		MessageType: &azdext.ProvisioningMessage_EnsureEnvRequest{
+			EnsureEnvRequest: &azdext.ProvisioningEnsureEnvRequest{},
+		},
+	})
+
+	return err
+}
+
+// createOutputParameters converts the outputs returned by the extension into provisioning output parameters
+func (p *extensionProvisioningProvider) createOutputParameters(
+	outputs map[string]*azdext.ProvisioningOutputParameter,
+) (map[string]provisioning.OutputParameter, error) {
+	result := make(map[string]provisioning.OutputParameter, len(outputs))
+	for name, output := range outputs {
+		parameterType := provisioning.ParameterType(output.Type)
+		switch parameterType {
+		case provisioning.ParameterTypeString,
+			provisioning.ParameterTypeNumber,
+			provisioning.ParameterTypeBoolean,
+			provisioning.ParameterTypeObject,
+			provisioning.ParameterTypeArray:
+		default:
+			return nil, fmt.Errorf(
+				"extension '%s' returned output '%s' with unsupported type '%s'", p.extension.Id, name, output.Type)
+		}
+
+		outputParameter := provisioning.OutputParameter{
+			Type: parameterType,
+		}
+
+		if err := unmarshalParameterValue(output.Value, &outputParameter.Value); err != nil {
+			return nil, fmt.Errorf("extension '%s' returned an invalid output '%s': %w", p.extension.Id, name, err)
+		}
+
+		result[name] = outputParameter
+	}
+
+	return result, nil
+}
+
+// invoke sends the request to the extension and waits for its response.
+// Progress messages sent by the extension while the request is running are displayed in the console spinner.
+func (p *extensionProvisioningProvider) invoke(
+	ctx context.Context,
+	request *azdext.ProvisioningMessage,
+) (*azdext.ProvisioningMessage, error) {
+	return p.requestBroker.invoke(ctx, request, func(message string) {
+		p.console.ShowSpinner(ctx, message, input.Step)
+	})
+}
+
+// unmarshalParameterValue decodes a JSON encoded parameter value, leaving the target unset when no value was sent
+func unmarshalParameterValue(value []byte, target *any) error {
+	if len(value) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(value, target)
+}
@@ -0,0 +1,227 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
+	"google.golang.org/grpc"
+)
+
+// extensionRemoteDataStore is an environment.ExternalDataStore that forwards the data store operations
+// to an extension over the remote data store stream.
+type extensionRemoteDataStore struct {
+	*requestBroker[azdext.RemoteDataStoreMessage, *azdext.RemoteDataStoreMessage]
+}
+
+func newExtensionRemoteDataStore(
+	extension *extensions.Extension,
+	stream grpc.BidiStreamingServer[azdext.RemoteDataStoreMessage, azdext.RemoteDataStoreMessage],
+) *extensionRemoteDataStore {
+	return &extensionRemoteDataStore{
+		requestBroker: newRequestBroker[azdext.RemoteDataStoreMessage](extension, stream),
+	}
+}
+
+func (ds *extensionRemoteDataStore) Initialize(ctx context.Context, config map[string]any) error {
+	configJson, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("marshalling remote state config: %w", err)
+	}
+
+	_, err = ds.request(ctx, "", &azdext.RemoteDataStoreMessage{
+		MessageType: &azdext.RemoteDataStoreMessage_InitializeRequest{
+			InitializeRequest: &azdext.RemoteDataStoreInitializeRequest{
+				Config: configJson,
+			},
+		},
+	})
+
+	return err
+}
+
+func (ds *extensionRemoteDataStore) List(ctx context.Context) ([]*contracts.EnvListEnvironment, error) {
+	response, err := ds.request(ctx, "", &azdext.RemoteDataStoreMessage{
+		MessageType: &azdext.RemoteDataStoreMessage_ListRequest{
+			ListRequest: &azdext.RemoteDataStoreListRequest{},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	envs := []*contracts.EnvListEnvironment{}
+	for _, env := range response.GetListResponse().GetEnvironments() {
+		envs = append(envs, &contracts.EnvListEnvironment{
+			Name:       env.Name,
+			DotEnvPath: env.DotEnvPath,
+			ConfigPath: env.ConfigPath,
+		})
+	}
+
+	return envs, nil
+}
+
+func (ds *extensionRemoteDataStore) Get(ctx context.Context, name string) (*environment.EnvironmentState, error) {
+	response, err := ds.request(ctx, name, &azdext.RemoteDataStoreMessage{
+		MessageType: &azdext.RemoteDataStoreMessage_GetRequest{
+			GetRequest: &azdext.RemoteDataStoreGetRequest{Name: name},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	env := response.GetGetResponse().GetEnvironment()
+	if env == nil {
+		return nil, fmt.Errorf("'%s': %w", name, environment.ErrNotFound)
+	}
+
+	return &environment.EnvironmentState{
+		Name:    name,
+		Dotenv:  env.Dotenv,
+		Config:  env.Config,
+		Version: env.Version,
+	}, nil
+}
+
+func (ds *extensionRemoteDataStore) Save(
+	ctx context.Context,
+	state *environment.EnvironmentState,
+	options *environment.SaveOptions,
+) (string, error) {
+	response, err := ds.request(ctx, state.Name, &azdext.RemoteDataStoreMessage{
+		MessageType: &azdext.RemoteDataStoreMessage_SaveRequest{
+			SaveRequest: &azdext.RemoteDataStoreSaveRequest{
+				Environment: &azdext.RemoteEnvironment{
+					Name:    state.Name,
+					Dotenv:  state.Dotenv,
+					Config:  state.Config,
+					Version: state.Version,
+				},
+				IsNew: options != nil && options.IsNew,
+			},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return response.GetSaveResponse().GetVersion(), nil
+}
+
+func (ds *extensionRemoteDataStore) Delete(ctx context.Context, name string) error {
+	_, err := ds.request(ctx, name, &azdext.RemoteDataStoreMessage{
+		MessageType: &azdext.RemoteDataStoreMessage_DeleteRequest{
+			DeleteRequest: &azdext.RemoteDataStoreDeleteRequest{Name: name},
+		},
+	})
+
+	return err
+}
+
+func (ds *extensionRemoteDataStore) Lock(ctx context.Context, name string, lock *environment.LockInfo) error {
+	_, err := ds.request(ctx, name, &azdext.RemoteDataStoreMessage{
+		MessageType: &azdext.RemoteDataStoreMessage_LockRequest{
+			LockRequest: &azdext.RemoteDataStoreLockRequest{
+				Name: name,
+				Lock: createEnvironmentLock(lock),
+			},
+		},
+	})
+
+	return err
+}
+
+func (ds *extensionRemoteDataStore) Unlock(ctx context.Context, name string, lockId string) error {
+	_, err := ds.request(ctx, name, &azdext.RemoteDataStoreMessage{
+		MessageType: &azdext.RemoteDataStoreMessage_UnlockRequest{
+			UnlockRequest: &azdext.RemoteDataStoreUnlockRequest{
+				Name:   name,
+				LockId: lockId,
+			},
+		},
+	})
+
+	return err
+}
+
+// request sends the request to the extension and converts the failures azd handles into the errors of the
+// environment data stores, ex) environment.ErrNotFound for an environment that doesn't exist
+func (ds *extensionRemoteDataStore) request(
+	ctx context.Context,
+	name string,
+	request *azdext.RemoteDataStoreMessage,
+) (*azdext.RemoteDataStoreMessage, error) {
+	response, err := ds.invoke(ctx, request, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Error == nil {
+		return response, nil
+	}
+
+	switch response.Error.Code {
+	case azdext.RemoteDataStoreErrorCode_REMOTE_DATA_STORE_ERROR_CODE_NOT_FOUND:
+		return nil, fmt.Errorf("'%s': %w", name, environment.ErrNotFound)
+	case azdext.RemoteDataStoreErrorCode_REMOTE_DATA_STORE_ERROR_CODE_CONFLICT:
+		return nil, fmt.Errorf("'%s': %w", name, environment.ErrConflict)
+	case azdext.RemoteDataStoreErrorCode_REMOTE_DATA_STORE_ERROR_CODE_LOCKED:
+		return nil, &environment.LockedError{EnvName: name, Lock: createLockInfo(response.Error.Lock)}
+	default:
+		return nil, fmt.Errorf("extension '%s' failed: %s", ds.extension.Id, response.Error.Message)
+	}
+}
+
+// createEnvironmentLock converts an environment.LockInfo into the azdext.EnvironmentLock wire format.
+func createEnvironmentLock(lock *environment.LockInfo) *azdext.EnvironmentLock {
+	if lock == nil {
+		return nil
+	}
+
+	environmentLock := &azdext.EnvironmentLock{
+		Id:         lock.Id,
+		Owner:      lock.Owner,
+		Operation:  lock.Operation,
+		AcquiredAt: lock.AcquiredAt.Format(time.RFC3339),
+	}
+
+	if !lock.ExpiresAt.IsZero() {
+		environmentLock.ExpiresAt = lock.ExpiresAt.Format(time.RFC3339)
+	}
+
+	return environmentLock
+}
+
+// createLockInfo converts an azdext.EnvironmentLock into an environment.LockInfo.
+// Times that can't be parsed are left empty.
+func createLockInfo(lock *azdext.EnvironmentLock) *environment.LockInfo {
+	if lock == nil {
+		return nil
+	}
+
+	lockInfo := &environment.LockInfo{
+		Id:        lock.Id,
+		Owner:     lock.Owner,
+		Operation: lock.Operation,
+	}
+
+	if acquiredAt, err := time.Parse(time.RFC3339, lock.AcquiredAt); err == nil {
+		lockInfo.AcquiredAt = acquiredAt
+	}
+
+	if expiresAt, err := time.Parse(time.RFC3339, lock.ExpiresAt); err == nil {
+		lockInfo.ExpiresAt = expiresAt
+	}
+
+	return lockInfo
+}
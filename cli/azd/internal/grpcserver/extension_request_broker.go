@@ -0,0 +1,122 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+)
+
+// requestBroker sends the requests of azd to the provider registered by an extension and dispatches the
+// messages received from the extension to the requests they belong to, ex) the service target stream.
+type requestBroker[M any, P interface {
+	*M
+	azdext.StreamMessage
+}] struct {
+	extension *extensions.Extension
+	stream    grpc.BidiStreamingServer[M, M]
+
+	sendMutex sync.Mutex
+	requests  sync.Map // key: string, value: *brokerRequest[M]
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// brokerRequest is a request sent to the extension that is waiting for its response
+type brokerRequest[M any] struct {
+	response chan *M
+	progress func(message string)
+}
+
+func newRequestBroker[M any, P interface {
+	*M
+	azdext.StreamMessage
+}](extension *extensions.Extension, stream grpc.BidiStreamingServer[M, M]) *requestBroker[M, P] {
+	return &requestBroker[M, P]{
+		extension: extension,
+		stream:    stream,
+		closed:    make(chan struct{}),
+	}
+}
+
+// invoke sends the request to the extension and waits for its response.
+// Progress messages sent by the extension while the request is running are reported to the optional progress.
+func (b *requestBroker[M, P]) invoke(ctx context.Context, request *M, progress func(message string)) (*M, error) {
+	requestId := uuid.NewString()
+	P(request).SetRequestId(requestId)
+
+	pending := &brokerRequest[M]{
+		response: make(chan *M, 1),
+		progress: progress,
+	}
+
+	b.requests.Store(requestId, pending)
+	defer b.requests.Delete(requestId)
+
+	if err := b.send(request); err != nil {
+		return nil, fmt.Errorf("sending request to extension '%s': %w", b.extension.Id, err)
+	}
+
+	var response *M
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-b.closed:
+		return nil, fmt.Errorf("extension '%s' disconnected before completing the request", b.extension.Id)
+	case response = <-pending.response:
+	}
+
+	if message, failed := P(response).Failure(); failed {
+		return nil, fmt.Errorf("extension '%s' failed: %s", b.extension.Id, message)
+	}
+
+	return response, nil
+}
+
+// send sends a message to the extension. Messages can be sent from concurrent operations.
+func (b *requestBroker[M, P]) send(msg *M) error {
+	b.sendMutex.Lock()
+	defer b.sendMutex.Unlock()
+
+	return b.stream.Send(msg)
+}
+
+// handleResponse dispatches a message received from the extension to the request it belongs to.
+// The receive loop of the stream is never blocked, extra responses to the same request are dropped.
+func (b *requestBroker[M, P]) handleResponse(msg *M) {
+	requestId := P(msg).GetRequestId()
+	val, has := b.requests.Load(requestId)
+	if !has {
+		return
+	}
+
+	pending := val.(*brokerRequest[M])
+	if message, ok := P(msg).Progress(); ok {
+		if pending.progress != nil {
+			pending.progress(message)
+		}
+
+		return
+	}
+
+	select {
+	case pending.response <- msg:
+	default:
+		log.Printf("ignoring duplicate response from extension '%s' for request %s", b.extension.Id, requestId)
+	}
+}
+
+// close fails the requests that are waiting for a response once the stream has been closed
+func (b *requestBroker[M, P]) close() {
+	b.closeOnce.Do(func() {
+		close(b.closed)
+	})
+}
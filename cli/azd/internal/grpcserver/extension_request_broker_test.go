@@ -0,0 +1,117 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func Test_RequestBroker(t *testing.T) {
+	newBroker := func() (*requestBroker[azdext.ProvisioningMessage, *azdext.ProvisioningMessage], *fakeServerStream) {
+		stream := &fakeServerStream{sent: make(chan *azdext.ProvisioningMessage, 1)}
+		broker := newRequestBroker[azdext.ProvisioningMessage](&extensions.Extension{Id: "contoso.cdktf"}, stream)
+
+		return broker, stream
+	}
+
+	type invokeResult struct {
+		response *azdext.ProvisioningMessage
+		err      error
+	}
+
+	invoke := func(
+		broker *requestBroker[azdext.ProvisioningMessage, *azdext.ProvisioningMessage],
+		progress func(message string),
+	) chan invokeResult {
+		result := make(chan invokeResult, 1)
+		go func() {
+			response, err := broker.invoke(context.Background(), &azdext.ProvisioningMessage{
+				MessageType: &azdext.ProvisioningMessage_DeployRequest{
+					DeployRequest: &azdext.ProvisioningDeployRequest{},
+				},
+			}, progress)
+			result <- invokeResult{response, err}
+		}()
+
+		return result
+	}
+
+	t.Run("Response", func(t *testing.T) {
+		broker, stream := newBroker()
+
+		var progressMessages []string
+		result := invoke(broker, func(message string) {
+			progressMessages = append(progressMessages, message)
+		})
+		request := <-stream.sent
+		require.NotEmpty(t, request.RequestId)
+
+		progress := &azdext.ProvisioningMessage{RequestId: request.RequestId}
+		progress.SetProgress("Applying stack")
+		broker.handleResponse(progress)
+
+		response := &azdext.ProvisioningMessage{RequestId: request.RequestId}
+		broker.handleResponse(response)
+		// a duplicate response must not block the receive loop of the stream
+		broker.handleResponse(&azdext.ProvisioningMessage{RequestId: request.RequestId})
+		// responses to unknown requests are ignored
+		broker.handleResponse(&azdext.ProvisioningMessage{RequestId: "unknown"})
+
+		invoked := <-result
+		require.NoError(t, invoked.err)
+		require.Same(t, response, invoked.response)
+		require.Equal(t, []string{"Applying stack"}, progressMessages)
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		broker, stream := newBroker()
+
+		result := invoke(broker, nil)
+		request := <-stream.sent
+
+		progress := &azdext.ProvisioningMessage{RequestId: request.RequestId}
+		progress.SetProgress("Applying stack")
+		broker.handleResponse(progress)
+
+		response := &azdext.ProvisioningMessage{RequestId: request.RequestId}
+		response.SetFailure("stack not found")
+		broker.handleResponse(response)
+
+		invoked := <-result
+		require.EqualError(t, invoked.err, "extension 'contoso.cdktf' failed: stack not found")
+	})
+
+	t.Run("Closed", func(t *testing.T) {
+		broker, stream := newBroker()
+
+		result := invoke(broker, nil)
+		<-stream.sent
+		broker.close()
+		broker.close()
+
+		invoked := <-result
+		require.ErrorContains(t, invoked.err, "disconnected before completing the request")
+	})
+}
+
+// fakeServerStream records the messages sent to the extension
+type fakeServerStream struct {
+	grpc.ServerStream
+	sent chan *azdext.ProvisioningMessage
+}
+
+func (s *fakeServerStream) Send(msg *azdext.ProvisioningMessage) error {
+	s.sent <- msg
+	return nil
+}
+
+func (s *fakeServerStream) Recv() (*azdext.ProvisioningMessage, error) {
+	select {}
+}
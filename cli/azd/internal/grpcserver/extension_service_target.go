@@ -0,0 +1,186 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"google.golang.org/grpc"
+)
+
+// extensionServiceTarget is a project.ServiceTarget that forwards the service target operations
+// to an extension over the service target stream.
+type extensionServiceTarget struct {
+	*requestBroker[azdext.ServiceTargetMessage, *azdext.ServiceTargetMessage]
+	lazyEnv *lazy.Lazy[*environment.Environment]
+}
+
+func newExtensionServiceTarget(
+	extension *extensions.Extension,
+	stream grpc.BidiStreamingServer[azdext.ServiceTargetMessage, azdext.ServiceTargetMessage],
+	lazyEnv *lazy.Lazy[*environment.Environment],
+) *extensionServiceTarget {
+	return &extensionServiceTarget{
+		requestBroker: newRequestBroker[azdext.ServiceTargetMessage](extension, stream),
+		lazyEnv:       lazyEnv,
+	}
+}
+
+func (st *extensionServiceTarget) Initialize(ctx context.Context, serviceConfig *project.ServiceConfig) error {
+	_, err := st.invoke(ctx, &azdext.ServiceTargetMessage{
+		MessageType: &azdext.ServiceTargetMessage_InitializeRequest{
+			InitializeRequest: &azdext.ServiceTargetInitializeRequest{
+				ServiceConfig: createServiceConfig(serviceConfig, st.lazyEnv),
+			},
+		},
+	}, nil)
+
+	return err
+}
+
+// RequiredExternalTools returns no tools since the tools used by the extension are managed by the extension itself
+func (st *extensionServiceTarget) RequiredExternalTools(
+	ctx context.Context,
+	serviceConfig *project.ServiceConfig,
+) []tools.ExternalTool {
+	return []tools.ExternalTool{}
+}
+
+func (st *extensionServiceTarget) Package(
+	ctx context.Context,
+	serviceConfig *project.ServiceConfig,
+	frameworkPackageOutput *project.ServicePackageResult,
+	progress *async.Progress[project.ServiceProgress],
+) (*project.ServicePackageResult, error) {
+	response, err := st.invoke(ctx, &azdext.ServiceTargetMessage{
+		MessageType: &azdext.ServiceTargetMessage_PackageRequest{
+			PackageRequest: &azdext.ServiceTargetPackageRequest{
+				ServiceConfig:    createServiceConfig(serviceConfig, st.lazyEnv),
+				FrameworkPackage: createServicePackageResult(frameworkPackageOutput),
+			},
+		},
+	}, serviceProgress(progress))
+	if err != nil {
+		return nil, err
+	}
+
+	result := response.GetPackageResponse().GetResult()
+	if result == nil {
+		return frameworkPackageOutput, nil
+	}
+
+	packageResult := &project.ServicePackageResult{
+		PackagePath: result.PackagePath,
+		Details:     result.Details,
+	}
+
+	if frameworkPackageOutput != nil {
+		packageResult.Build = frameworkPackageOutput.Build
+	}
+
+	return packageResult, nil
+}
+
+func (st *extensionServiceTarget) Deploy(
+	ctx context.Context,
+	serviceConfig *project.ServiceConfig,
+	servicePackage *project.ServicePackageResult,
+	targetResource *environment.TargetResource,
+	progress *async.Progress[project.ServiceProgress],
+) (*project.ServiceDeployResult, error) {
+	response, err := st.invoke(ctx, &azdext.ServiceTargetMessage{
+		MessageType: &azdext.ServiceTargetMessage_DeployRequest{
+			DeployRequest: &azdext.ServiceTargetDeployRequest{
+				ServiceConfig:  createServiceConfig(serviceConfig, st.lazyEnv),
+				ServicePackage: createServicePackageResult(servicePackage),
+				TargetResource: createTargetResource(targetResource),
+			},
+		},
+	}, serviceProgress(progress))
+	if err != nil {
+		return nil, err
+	}
+
+	deployResult := &project.ServiceDeployResult{
+		Package: servicePackage,
+		Kind:    serviceConfig.Host,
+	}
+
+	if result := response.GetDeployResponse().GetResult(); result != nil {
+		deployResult.TargetResourceId = result.TargetResourceId
+		deployResult.Endpoints = result.Endpoints
+		deployResult.Details = result.Details
+	}
+
+	return deployResult, nil
+}
+
+func (st *extensionServiceTarget) Endpoints(
+	ctx context.Context,
+	serviceConfig *project.ServiceConfig,
+	targetResource *environment.TargetResource,
+) ([]string, error) {
+	response, err := st.invoke(ctx, &azdext.ServiceTargetMessage{
+		MessageType: &azdext.ServiceTargetMessage_EndpointsRequest{
+			EndpointsRequest: &azdext.ServiceTargetEndpointsRequest{
+				ServiceConfig:  createServiceConfig(serviceConfig, st.lazyEnv),
+				TargetResource: createTargetResource(targetResource),
+			},
+		},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.GetEndpointsResponse().GetEndpoints(), nil
+}
+
+// serviceProgress reports the progress messages of a request to the optional progress of a service operation
+func serviceProgress(progress *async.Progress[project.ServiceProgress]) func(message string) {
+	if progress == nil {
+		return nil
+	}
+
+	return func(message string) {
+		progress.SetProgress(project.NewServiceProgress(message))
+	}
+}
+
+// createServicePackageResult converts a project.ServicePackageResult into the azdext.ServicePackageResult wire format.
+func createServicePackageResult(packageResult *project.ServicePackageResult) *azdext.ServicePackageResult {
+	if packageResult == nil {
+		return nil
+	}
+
+	result := &azdext.ServicePackageResult{
+		PackagePath: packageResult.PackagePath,
+	}
+
+	if details, ok := packageResult.Details.(map[string]string); ok {
+		result.Details = details
+	}
+
+	return result
+}
+
+// createTargetResource converts an environment.TargetResource into the azdext.TargetResource wire format.
+func createTargetResource(targetResource *environment.TargetResource) *azdext.TargetResource {
+	if targetResource == nil {
+		return nil
+	}
+
+	return &azdext.TargetResource{
+		SubscriptionId:    targetResource.SubscriptionId(),
+		ResourceGroupName: targetResource.ResourceGroupName(),
+		ResourceName:      targetResource.ResourceName(),
+		ResourceType:      targetResource.ResourceType(),
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// frameworkService implements azdext.FrameworkServiceServer.
+type frameworkService struct {
+	azdext.UnimplementedFrameworkServiceServer
+	extensionManager *extensions.Manager
+	registry         *project.ExternalFrameworkServiceRegistry
+	lazyEnv          *lazy.Lazy[*environment.Environment]
+}
+
+func NewFrameworkService(
+	extensionManager *extensions.Manager,
+	registry *project.ExternalFrameworkServiceRegistry,
+	lazyEnv *lazy.Lazy[*environment.Environment],
+) azdext.FrameworkServiceServer {
+	return &frameworkService{
+		extensionManager: extensionManager,
+		registry:         registry,
+		lazyEnv:          lazyEnv,
+	}
+}
+
+// Stream handles bidirectional streaming.
+// The framework services registered by the extension are available until the stream is closed.
+func (s *frameworkService) Stream(
+	stream grpc.BidiStreamingServer[azdext.FrameworkServiceMessage, azdext.FrameworkServiceMessage],
+) error {
+	ctx := stream.Context()
+	extensionClaims, err := GetExtensionClaims(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get extension claims: %w", err)
+	}
+
+	options := extensions.LookupOptions{
+		Id: extensionClaims.Subject,
+	}
+
+	extension, err := s.extensionManager.GetInstalled(options)
+	if err != nil {
+		return status.Errorf(codes.FailedPrecondition, "failed to get extension: %s", err.Error())
+	}
+
+	if !extension.HasCapability(extensions.FrameworkServiceProviderCapability) {
+		return status.Errorf(codes.PermissionDenied, "extension does not support framework service providers")
+	}
+
+	connection := newFrameworkServiceConnection(extension, stream, s.lazyEnv)
+	registeredLanguages := []project.ServiceLanguageKind{}
+
+	defer func() {
+		for _, language := range registeredLanguages {
+			s.registry.Unregister(language)
+		}
+
+		connection.close()
+	}()
+
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			log.Println("Stream closed by extension")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch msg.MessageType.(type) {
+		case *azdext.FrameworkServiceMessage_RegisterFrameworkServiceRequest:
+			request := msg.GetRegisterFrameworkServiceRequest()
+			language := project.ServiceLanguageKind(request.Language)
+			response := &azdext.FrameworkServiceMessage{
+				RequestId: msg.RequestId,
+				MessageType: &azdext.FrameworkServiceMessage_RegisterFrameworkServiceResponse{
+					RegisterFrameworkServiceResponse: &azdext.RegisterFrameworkServiceResponse{},
+				},
+			}
+
+			framework := newExtensionFrameworkService(connection, request.Requirements)
+			if err := s.registry.Register(language, framework); err != nil {
+				response.Error = &azdext.FrameworkServiceErrorMessage{Message: err.Error()}
+			} else {
+				registeredLanguages = append(registeredLanguages, language)
+				log.Printf("extension '%s' registered service language '%s'", extension.Id, language)
+			}
+
+			if err := connection.send(response); err != nil {
+				return err
+			}
+		case *azdext.FrameworkServiceMessage_ExtensionReadyEvent:
+			extension.Initialize()
+		default:
+			connection.handleResponse(msg)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// Test_FrameworkService_Flow validates that framework services registered by an extension
+// proxy the restore, build and package operations to the extension.
+func Test_FrameworkService_Flow(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	extensionManager, extension := newExtensionManagerForTest(
+		t,
+		mockContext,
+		"test.golang",
+		extensions.FrameworkServiceProviderCapability,
+	)
+	registry := project.NewExternalFrameworkServiceRegistry()
+
+	server := NewServer(
+		azdext.UnimplementedProjectServiceServer{},
+		azdext.UnimplementedEnvironmentServiceServer{},
+		azdext.UnimplementedPromptServiceServer{},
+		azdext.UnimplementedUserConfigServiceServer{},
+		azdext.UnimplementedDeploymentServiceServer{},
+		azdext.UnimplementedEventServiceServer{},
+		azdext.UnimplementedServiceTargetServiceServer{},
+		NewFrameworkService(extensionManager, registry, lazy.From(environment.New("dev"))),
+		azdext.UnimplementedProvisioningServiceServer{},
+		azdext.UnimplementedOperationsServiceServer{},
+		azdext.UnimplementedRemoteDataStoreServiceServer{},
+	)
+
+	serverInfo, err := server.Start()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, server.Stop())
+	}()
+
+	accessToken, err := GenerateExtensionToken(extension, serverInfo)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(azdext.WithAccessToken(*mockContext.Context, accessToken))
+	defer cancel()
+
+	client, err := azdext.NewAzdClient(azdext.WithAddress(serverInfo.Address))
+	require.NoError(t, err)
+	defer client.Close()
+
+	frameworkServiceManager := azdext.NewFrameworkServiceManager(client)
+	defer frameworkServiceManager.Close()
+
+	// Built-in languages cannot be overridden by extensions
+	err = frameworkServiceManager.Register(ctx, string(project.ServiceLanguagePython), &fakeFrameworkServiceProvider{})
+	require.ErrorContains(t, err, "cannot be overridden")
+
+	require.NoError(t, frameworkServiceManager.Register(ctx, "go", &fakeFrameworkServiceProvider{}))
+
+	go func() {
+		_ = frameworkServiceManager.Receive(ctx)
+	}()
+	require.NoError(t, extension.WaitUntilReady(ctx))
+
+	framework, has := registry.Get("go")
+	require.True(t, has)
+	require.True(t, framework.Requirements().Package.RequireBuild)
+	require.False(t, framework.Requirements().Package.RequireRestore)
+
+	serviceConfig := &project.ServiceConfig{Name: "api", Language: "go"}
+	require.NoError(t, framework.Initialize(ctx, serviceConfig))
+
+	restoreResult, err := framework.Restore(ctx, serviceConfig, async.NewProgress[project.ServiceProgress]())
+	require.NoError(t, err)
+
+	progress := async.NewProgress[project.ServiceProgress]()
+	progressMessages := []string{}
+	progressDone := make(chan struct{})
+	go func() {
+		defer close(progressDone)
+		for p := range progress.Progress() {
+			progressMessages = append(progressMessages, p.Message)
+		}
+	}()
+
+	buildResult, err := framework.Build(ctx, serviceConfig, restoreResult, progress)
+	progress.Done()
+	<-progressDone
+
+	require.NoError(t, err)
+	require.Equal(t, "bin/api", buildResult.BuildOutputPath)
+	require.Same(t, restoreResult, buildResult.Restore)
+	require.Equal(t, []string{"Compiling api"}, progressMessages)
+
+	packageResult, err := framework.Package(ctx, serviceConfig, buildResult, async.NewProgress[project.ServiceProgress]())
+	require.NoError(t, err)
+	require.Equal(t, "bin/api.zip", packageResult.PackagePath)
+	require.Same(t, buildResult, packageResult.Build)
+
+	_, err = framework.Restore(ctx, &project.ServiceConfig{Name: "web", Language: "go"}, nil)
+	require.ErrorContains(t, err, "extension 'test.golang' failed: go.mod not found for service 'web'")
+}
+
+type fakeFrameworkServiceProvider struct{}
+
+func (p *fakeFrameworkServiceProvider) Requirements() *azdext.FrameworkRequirements {
+	return &azdext.FrameworkRequirements{RequireBuild: true}
+}
+
+func (p *fakeFrameworkServiceProvider) Initialize(ctx context.Context, serviceConfig *azdext.ServiceConfig) error {
+	return nil
+}
+
+func (p *fakeFrameworkServiceProvider) Restore(
+	ctx context.Context,
+	serviceConfig *azdext.ServiceConfig,
+	progress azdext.ProgressReporter,
+) (*azdext.ServiceRestoreResult, error) {
+	if serviceConfig.Name != "api" {
+		return nil, errors.New("go.mod not found for service '" + serviceConfig.Name + "'")
+	}
+
+	return &azdext.ServiceRestoreResult{}, nil
+}
+
+func (p *fakeFrameworkServiceProvider) Build(
+	ctx context.Context,
+	serviceConfig *azdext.ServiceConfig,
+	restoreOutput *azdext.ServiceRestoreResult,
+	progress azdext.ProgressReporter,
+) (*azdext.ServiceBuildResult, error) {
+	progress("Compiling " + serviceConfig.Name)
+
+	return &azdext.ServiceBuildResult{BuildOutputPath: "bin/" + serviceConfig.Name}, nil
+}
+
+func (p *fakeFrameworkServiceProvider) Package(
+	ctx context.Context,
+	serviceConfig *azdext.ServiceConfig,
+	buildOutput *azdext.ServiceBuildResult,
+	progress azdext.ProgressReporter,
+) (*azdext.ServicePackageResult, error) {
+	return &azdext.ServicePackageResult{PackagePath: buildOutput.BuildOutputPath + ".zip"}, nil
+}
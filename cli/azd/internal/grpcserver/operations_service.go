@@ -0,0 +1,513 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/internal/cmd"
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/ioc"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// operationsService implements azdext.OperationsServiceServer.
+type operationsService struct {
+	azdext.UnimplementedOperationsServiceServer
+
+	extensionManager   *extensions.Manager
+	lazyProjectConfig  *lazy.Lazy[*project.ProjectConfig]
+	lazyServiceManager *lazy.Lazy[project.ServiceManager]
+	lazyEnv            *lazy.Lazy[*environment.Environment]
+	lazyEnvManager     *lazy.Lazy[environment.Manager]
+	serviceLocator     ioc.ServiceLocator
+}
+
+func NewOperationsService(
+	extensionManager *extensions.Manager,
+	lazyProjectConfig *lazy.Lazy[*project.ProjectConfig],
+	lazyServiceManager *lazy.Lazy[project.ServiceManager],
+	lazyEnv *lazy.Lazy[*environment.Environment],
+	lazyEnvManager *lazy.Lazy[environment.Manager],
+	serviceLocator ioc.ServiceLocator,
+) azdext.OperationsServiceServer {
+	return &operationsService{
+		extensionManager:   extensionManager,
+		lazyProjectConfig:  lazyProjectConfig,
+		lazyServiceManager: lazyServiceManager,
+		lazyEnv:            lazyEnv,
+		lazyEnvManager:     lazyEnvManager,
+		serviceLocator:     serviceLocator,
+	}
+}
+
+// ensureCapability ensures the extension calling the service declares the operations capability, since operations
+// provision and deploy the project on behalf of the user.
+func (s *operationsService) ensureCapability(ctx context.Context) error {
+	extensionClaims, err := GetExtensionClaims(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get extension claims: %w", err)
+	}
+
+	extension, err := s.extensionManager.GetInstalled(extensions.LookupOptions{Id: extensionClaims.Subject})
+	if err != nil {
+		return status.Errorf(codes.FailedPrecondition, "failed to get extension: %s", err.Error())
+	}
+
+	if !extension.HasCapability(extensions.OperationsCapability) {
+		return status.Errorf(codes.PermissionDenied, "extension does not support operations")
+	}
+
+	return nil
+}
+
+// Provision provisions the infrastructure of the project the same way `azd provision` does.
+func (s *operationsService) Provision(
+	req *azdext.ProvisionRequest,
+	stream grpc.ServerStreamingServer[azdext.OperationMessage],
+) error {
+	ctx := stream.Context()
+
+	if err := s.ensureCapability(ctx); err != nil {
+		return err
+	}
+
+	var provisioner *cmd.Provisioner
+	if err := s.serviceLocator.Resolve(&provisioner); err != nil {
+		return err
+	}
+
+	if err := sendOperationProgress(stream, "Initializing provisioning"); err != nil {
+		return err
+	}
+
+	provisionResult, err := provisioner.Provision(ctx, cmd.ProvisionOptions{
+		Layer:          req.Layer,
+		Preview:        req.Preview,
+		OverridePolicy: req.OverridePolicy,
+		OnLayer: func(ctx context.Context, layer provisioning.Options) {
+			if layer.Name != "" {
+				_ = sendOperationProgress(stream, fmt.Sprintf("Provisioning layer %s", layer.Name))
+			}
+		},
+		OnInitialized: func(ctx context.Context) {
+			if req.Preview {
+				_ = sendOperationProgress(stream, "Previewing Azure resource changes")
+			} else {
+				_ = sendOperationProgress(stream, "Provisioning Azure resources")
+			}
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if provisionResult.PreviewResult != nil {
+		return stream.Send(&azdext.OperationMessage{
+			MessageType: &azdext.OperationMessage_ProvisionPreview{
+				ProvisionPreview: createProvisioningDeploymentPreview(provisionResult.PreviewResult),
+			},
+		})
+	}
+
+	result, err := createProvisioningDeployResult(provisionResult.DeployResult)
+	if err != nil {
+		return err
+	}
+
+	return stream.Send(&azdext.OperationMessage{
+		MessageType: &azdext.OperationMessage_ProvisionResult{
+			ProvisionResult: result,
+		},
+	})
+}
+
+// Build builds the service the same way `azd build <service>` does.
+func (s *operationsService) Build(
+	req *azdext.ServiceOperationRequest,
+	stream grpc.ServerStreamingServer[azdext.OperationMessage],
+) error {
+	ctx := stream.Context()
+
+	if err := s.ensureCapability(ctx); err != nil {
+		return err
+	}
+
+	projectConfig, serviceConfig, serviceManager, err := s.initializeService(ctx, req.ServiceName)
+	if err != nil {
+		return err
+	}
+
+	if err := s.ensureTools(ctx, projectConfig, serviceConfig, project.ProjectManager.EnsureFrameworkTools); err != nil {
+		return err
+	}
+
+	buildResult, err := async.RunWithProgress(
+		operationProgressObserver(stream),
+		func(progress *async.Progress[project.ServiceProgress]) (*project.ServiceBuildResult, error) {
+			return serviceManager.Build(ctx, serviceConfig, nil, progress)
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return stream.Send(&azdext.OperationMessage{
+		MessageType: &azdext.OperationMessage_BuildResult{
+			BuildResult: createServiceBuildResult(buildResult),
+		},
+	})
+}
+
+// Package packages the service the same way `azd package <service>` does.
+func (s *operationsService) Package(
+	req *azdext.ServiceOperationRequest,
+	stream grpc.ServerStreamingServer[azdext.OperationMessage],
+) error {
+	ctx := stream.Context()
+
+	if err := s.ensureCapability(ctx); err != nil {
+		return err
+	}
+
+	projectConfig, serviceConfig, serviceManager, err := s.initializeService(ctx, req.ServiceName)
+	if err != nil {
+		return err
+	}
+
+	if err := s.ensureTools(ctx, projectConfig, serviceConfig, project.ProjectManager.EnsureAllTools); err != nil {
+		return err
+	}
+
+	packageResult, err := async.RunWithProgress(
+		operationProgressObserver(stream),
+		func(progress *async.Progress[project.ServiceProgress]) (*project.ServicePackageResult, error) {
+			return serviceManager.Package(ctx, serviceConfig, nil, progress, nil)
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return stream.Send(&azdext.OperationMessage{
+		MessageType: &azdext.OperationMessage_PackageResult{
+			PackageResult: createServicePackageResult(packageResult),
+		},
+	})
+}
+
+// Deploy packages and deploys the service the same way `azd deploy <service>` does. The environment is locked while
+// the service is deployed, unless the current azd command already holds the lock.
+func (s *operationsService) Deploy(
+	req *azdext.ServiceOperationRequest,
+	stream grpc.ServerStreamingServer[azdext.OperationMessage],
+) error {
+	ctx := stream.Context()
+
+	if err := s.ensureCapability(ctx); err != nil {
+		return err
+	}
+
+	projectConfig, serviceConfig, serviceManager, err := s.initializeService(ctx, req.ServiceName)
+	if err != nil {
+		return err
+	}
+
+	release, err := s.acquireEnvLock(ctx, "azd deploy")
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if err := s.ensureTools(ctx, projectConfig, serviceConfig, project.ProjectManager.EnsureServiceTargetTools); err != nil {
+		return err
+	}
+
+	onProgress := operationProgressObserver(stream)
+	packageResult, err := async.RunWithProgress(
+		onProgress,
+		func(progress *async.Progress[project.ServiceProgress]) (*project.ServicePackageResult, error) {
+			return serviceManager.Package(ctx, serviceConfig, nil, progress, nil)
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	deployResult, err := async.RunWithProgress(
+		onProgress,
+		func(progress *async.Progress[project.ServiceProgress]) (*project.ServiceDeployResult, error) {
+			return serviceManager.Deploy(ctx, serviceConfig, packageResult, progress)
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return stream.Send(&azdext.OperationMessage{
+		MessageType: &azdext.OperationMessage_DeployResult{
+			DeployResult: createServiceDeployResult(deployResult),
+		},
+	})
+}
+
+// GetServiceDeployResults gets the results of the services deployed by the current azd command,
+// either by azd itself or by an extension through the Deploy operation.
+func (s *operationsService) GetServiceDeployResults(
+	ctx context.Context,
+	req *azdext.EmptyRequest,
+) (*azdext.GetServiceDeployResultsResponse, error) {
+	projectConfig, err := s.lazyProjectConfig.GetValue()
+	if err != nil {
+		return nil, err
+	}
+
+	serviceManager, err := s.lazyServiceManager.GetValue()
+	if err != nil {
+		return nil, err
+	}
+
+	var importManager *project.ImportManager
+	if err := s.serviceLocator.Resolve(&importManager); err != nil {
+		return nil, err
+	}
+
+	stableServices, err := importManager.ServiceStable(ctx, projectConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	results := map[string]*azdext.ServiceDeployResult{}
+	for _, svc := range stableServices {
+		if deployResult, has := serviceManager.GetDeployResult(svc); has {
+			results[svc.Name] = createServiceDeployResult(deployResult)
+		}
+	}
+
+	return &azdext.GetServiceDeployResultsResponse{
+		Results: results,
+	}, nil
+}
+
+// acquireEnvLock acquires the lock of the environment for the operation, the same way the environment lock middleware
+// does for azd commands
+func (s *operationsService) acquireEnvLock(ctx context.Context, operation string) (func(), error) {
+	env, err := s.lazyEnv.GetValue()
+	if err != nil {
+		return nil, err
+	}
+
+	envManager, err := s.lazyEnvManager.GetValue()
+	if err != nil {
+		return nil, err
+	}
+
+	return environment.AcquireLock(ctx, envManager, env.Name(), operation)
+}
+
+// initializeService finds the service of the project and initializes the project for the operation
+func (s *operationsService) initializeService(
+	ctx context.Context,
+	serviceName string,
+) (*project.ProjectConfig, *project.ServiceConfig, project.ServiceManager, error) {
+	projectConfig, err := s.lazyProjectConfig.GetValue()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var importManager *project.ImportManager
+	if err := s.serviceLocator.Resolve(&importManager); err != nil {
+		return nil, nil, nil, err
+	}
+
+	stableServices, err := importManager.ServiceStable(ctx, projectConfig)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var serviceConfig *project.ServiceConfig
+	for _, svc := range stableServices {
+		if svc.Name == serviceName {
+			serviceConfig = svc
+			break
+		}
+	}
+
+	if serviceConfig == nil {
+		return nil, nil, nil, status.Errorf(codes.NotFound, "service '%s' not found in project", serviceName)
+	}
+
+	var projectManager project.ProjectManager
+	if err := s.serviceLocator.Resolve(&projectManager); err != nil {
+		return nil, nil, nil, err
+	}
+
+	if err := projectManager.Initialize(ctx, projectConfig); err != nil {
+		return nil, nil, nil, err
+	}
+
+	serviceManager, err := s.lazyServiceManager.GetValue()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return projectConfig, serviceConfig, serviceManager, nil
+}
+
+// ensureTools ensures the tools required by the operation on the service are installed
+func (s *operationsService) ensureTools(
+	ctx context.Context,
+	projectConfig *project.ProjectConfig,
+	serviceConfig *project.ServiceConfig,
+	ensure func(
+		project.ProjectManager,
+		context.Context,
+		*project.ProjectConfig,
+		project.ServiceFilterPredicate,
+	) error,
+) error {
+	var projectManager project.ProjectManager
+	if err := s.serviceLocator.Resolve(&projectManager); err != nil {
+		return err
+	}
+
+	return ensure(projectManager, ctx, projectConfig, func(svc *project.ServiceConfig) bool {
+		return svc.Name == serviceConfig.Name
+	})
+}
+
+// operationProgressObserver streams the progress reported by a service operation to the extension
+func operationProgressObserver(
+	stream grpc.ServerStreamingServer[azdext.OperationMessage],
+) func(project.ServiceProgress) {
+	return func(progress project.ServiceProgress) {
+		// Progress is best effort, the result of the operation reports whether the stream is broken
+		_ = sendOperationProgress(stream, progress.Message)
+	}
+}
+
+func sendOperationProgress(stream grpc.ServerStreamingServer[azdext.OperationMessage], message string) error {
+	return stream.Send(&azdext.OperationMessage{
+		MessageType: &azdext.OperationMessage_Progress{
+			Progress: &azdext.OperationProgress{
+				Message: message,
+			},
+		},
+	})
+}
+
+// createServiceDeployResult converts a project.ServiceDeployResult into the azdext.ServiceDeployResult wire format.
+func createServiceDeployResult(deployResult *project.ServiceDeployResult) *azdext.ServiceDeployResult {
+	if deployResult == nil {
+		return nil
+	}
+
+	result := &azdext.ServiceDeployResult{
+		TargetResourceId: deployResult.TargetResourceId,
+		Endpoints:        deployResult.Endpoints,
+	}
+
+	if details, ok := deployResult.Details.(map[string]string); ok {
+		result.Details = details
+	}
+
+	return result
+}
+
+// createProvisioningDeploymentPreview converts a provisioning.DeployPreviewResult into the
+// azdext.ProvisioningDeploymentPreview wire format.
+func createProvisioningDeploymentPreview(
+	previewResult *provisioning.DeployPreviewResult,
+) *azdext.ProvisioningDeploymentPreview {
+	preview := &azdext.ProvisioningDeploymentPreview{
+		Status: previewResult.Preview.Status,
+	}
+
+	for _, change := range previewResult.Preview.Properties.Changes {
+		preview.Changes = append(preview.Changes, &azdext.ProvisioningPreviewChange{
+			ChangeType:   string(change.ChangeType),
+			ResourceId:   change.ResourceId.Id,
+			ResourceType: change.ResourceType,
+			Name:         change.Name,
+		})
+	}
+
+	return preview
+}
+
+// createProvisioningDeployResult converts a provisioning.DeployResult into the azdext.ProvisioningDeployResult wire
+// format. Values of secure parameters are not sent to extensions.
+func createProvisioningDeployResult(
+	deployResult *provisioning.DeployResult,
+) (*azdext.ProvisioningDeployResult, error) {
+	result := &azdext.ProvisioningDeployResult{
+		SkippedReason: string(deployResult.SkippedReason),
+	}
+
+	if deployResult.Deployment == nil {
+		return result, nil
+	}
+
+	result.Deployment = &azdext.ProvisioningDeployment{
+		Parameters: map[string]*azdext.ProvisioningInputParameter{},
+		Outputs:    map[string]*azdext.ProvisioningOutputParameter{},
+	}
+
+	for name, parameter := range deployResult.Deployment.Parameters {
+		inputParameter := &azdext.ProvisioningInputParameter{
+			Type: parameter.Type,
+		}
+
+		if !strings.HasPrefix(strings.ToLower(parameter.Type), "secure") {
+			defaultValue, err := marshalParameterValue(parameter.DefaultValue)
+			if err != nil {
+				return nil, fmt.Errorf("marshalling parameter '%s': %w", name, err)
+			}
+
+			value, err := marshalParameterValue(parameter.Value)
+			if err != nil {
+				return nil, fmt.Errorf("marshalling parameter '%s': %w", name, err)
+			}
+
+			inputParameter.DefaultValue = defaultValue
+			inputParameter.Value = value
+		}
+
+		result.Deployment.Parameters[name] = inputParameter
+	}
+
+	for name, output := range deployResult.Deployment.Outputs {
+		value, err := marshalParameterValue(output.Value)
+		if err != nil {
+			return nil, fmt.Errorf("marshalling output '%s': %w", name, err)
+		}
+
+		result.Deployment.Outputs[name] = &azdext.ProvisioningOutputParameter{
+			Type:  string(output.Type),
+			Value: value,
+		}
+	}
+
+	return result, nil
+}
+
+// marshalParameterValue JSON encodes a parameter value, leaving the value empty when the parameter has no value
+func marshalParameterValue(value any) ([]byte, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(value)
+}
@@ -0,0 +1,254 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockenv"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Test_OperationsService_GetServiceDeployResults validates only the services deployed by the
+// current command are returned.
+func Test_OperationsService_GetServiceDeployResults(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.Container.MustRegisterSingleton(func() *project.ImportManager {
+		return project.NewImportManager(nil)
+	})
+
+	projectConfig := newOperationsTestProject()
+	serviceManager := &testServiceManager{
+		deployResults: map[string]*project.ServiceDeployResult{
+			"api": {
+				TargetResourceId: "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Web/sites/api",
+				Endpoints:        []string{"https://api.azurewebsites.net/"},
+				Details:          map[string]string{"slot": "production"},
+			},
+		},
+	}
+
+	extensionManager, _ := newExtensionManagerForTest(t, mockContext, "test.ops", extensions.OperationsCapability)
+	service := NewOperationsService(extensionManager, lazy.From(projectConfig),
+		lazy.From[project.ServiceManager](serviceManager), lazy.From(environment.New("dev")),
+		lazy.From[environment.Manager](&mockenv.MockEnvManager{}), mockContext.Container)
+
+	response, err := service.GetServiceDeployResults(*mockContext.Context, &azdext.EmptyRequest{})
+	require.NoError(t, err)
+	require.Len(t, response.Results, 1)
+
+	result := response.Results["api"]
+	require.NotNil(t, result)
+	require.Equal(t, "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Web/sites/api", result.TargetResourceId)
+	require.Equal(t, []string{"https://api.azurewebsites.net/"}, result.Endpoints)
+	require.Equal(t, map[string]string{"slot": "production"}, result.Details)
+}
+
+// Test_OperationsService_ServiceNotFound ensures operations on unknown services fail without running.
+func Test_OperationsService_ServiceNotFound(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.Container.MustRegisterSingleton(func() *project.ImportManager {
+		return project.NewImportManager(nil)
+	})
+
+	extensionManager, extension := newExtensionManagerForTest(
+		t, mockContext, "test.ops", extensions.OperationsCapability)
+	service := NewOperationsService(
+		extensionManager,
+		lazy.From(newOperationsTestProject()),
+		lazy.From[project.ServiceManager](&testServiceManager{}),
+		lazy.From(environment.New("dev")),
+		lazy.From[environment.Manager](&mockenv.MockEnvManager{}),
+		mockContext.Container,
+	)
+
+	stream := &testOperationStream{ctx: newExtensionContextForTest(t, *mockContext.Context, extension)}
+	err := service.Deploy(&azdext.ServiceOperationRequest{ServiceName: "missing"}, stream)
+	require.Error(t, err)
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.Empty(t, stream.messages)
+}
+
+// Test_OperationsService_DeployLocked ensures services aren't deployed while another operation holds the lock of the
+// environment.
+func Test_OperationsService_DeployLocked(t *testing.T) {
+	t.Setenv(environment.LockIdEnvVarName, "")
+
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.Container.MustRegisterSingleton(func() *project.ImportManager {
+		return project.NewImportManager(nil)
+	})
+	mockContext.Container.MustRegisterSingleton(func() project.ProjectManager {
+		return &testProjectManager{}
+	})
+
+	heldLock := environment.NewLockInfo("azd provision")
+	envManager := &mockenv.MockEnvManager{}
+	envManager.On("Lock", mock.Anything, "dev", "azd deploy").
+		Return((*environment.LockInfo)(nil), &environment.LockedError{EnvName: "dev", Lock: heldLock})
+
+	extensionManager, extension := newExtensionManagerForTest(
+		t, mockContext, "test.ops", extensions.OperationsCapability)
+	service := NewOperationsService(
+		extensionManager,
+		lazy.From(newOperationsTestProject()),
+		lazy.From[project.ServiceManager](&testServiceManager{}),
+		lazy.From(environment.New("dev")),
+		lazy.From[environment.Manager](envManager),
+		mockContext.Container,
+	)
+
+	stream := &testOperationStream{ctx: newExtensionContextForTest(t, *mockContext.Context, extension)}
+	err := service.Deploy(&azdext.ServiceOperationRequest{ServiceName: "api"}, stream)
+
+	var lockedErr *environment.LockedError
+	require.ErrorAs(t, err, &lockedErr)
+	require.Equal(t, heldLock.Id, lockedErr.Lock.Id)
+	require.Empty(t, stream.messages)
+	envManager.AssertExpectations(t)
+}
+
+// Test_OperationsService_PermissionDenied ensures extensions without the operations capability can't run operations.
+func Test_OperationsService_PermissionDenied(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	extensionManager, extension := newExtensionManagerForTest(
+		t, mockContext, "test.vm", extensions.ServiceTargetProviderCapability)
+	service := NewOperationsService(
+		extensionManager,
+		lazy.From(newOperationsTestProject()),
+		lazy.From[project.ServiceManager](&testServiceManager{}),
+		lazy.From(environment.New("dev")),
+		lazy.From[environment.Manager](&mockenv.MockEnvManager{}),
+		mockContext.Container,
+	)
+
+	ctx := newExtensionContextForTest(t, *mockContext.Context, extension)
+	request := &azdext.ServiceOperationRequest{ServiceName: "api"}
+
+	operations := map[string]func(stream *testOperationStream) error{
+		"Provision": func(stream *testOperationStream) error {
+			return service.Provision(&azdext.ProvisionRequest{}, stream)
+		},
+		"Build":   func(stream *testOperationStream) error { return service.Build(request, stream) },
+		"Package": func(stream *testOperationStream) error { return service.Package(request, stream) },
+		"Deploy":  func(stream *testOperationStream) error { return service.Deploy(request, stream) },
+	}
+
+	for name, operation := range operations {
+		t.Run(name, func(t *testing.T) {
+			stream := &testOperationStream{ctx: ctx}
+			err := operation(stream)
+			require.Equal(t, codes.PermissionDenied, status.Code(err))
+			require.Empty(t, stream.messages)
+		})
+	}
+}
+
+func Test_CreateProvisioningDeployResult(t *testing.T) {
+	deployResult := &provisioning.DeployResult{
+		Deployment: &provisioning.Deployment{
+			Parameters: map[string]provisioning.InputParameter{
+				"location": {Type: "string", Value: "eastus2"},
+				"password": {Type: "secureString", Value: "secret"},
+			},
+			Outputs: map[string]provisioning.OutputParameter{
+				"WEBSITE_URL": {Type: provisioning.ParameterTypeString, Value: "https://contoso.com"},
+			},
+		},
+	}
+
+	result, err := createProvisioningDeployResult(deployResult)
+	require.NoError(t, err)
+	require.Equal(t, []byte(`"eastus2"`), result.Deployment.Parameters["location"].Value)
+	require.Equal(t, "secureString", result.Deployment.Parameters["password"].Type)
+	require.Nil(t, result.Deployment.Parameters["password"].Value)
+	require.Equal(t, []byte(`"https://contoso.com"`), result.Deployment.Outputs["WEBSITE_URL"].Value)
+
+	skipped, err := createProvisioningDeployResult(&provisioning.DeployResult{
+		SkippedReason: provisioning.DeploymentStateSkipped,
+	})
+	require.NoError(t, err)
+	require.Equal(t, string(provisioning.DeploymentStateSkipped), skipped.SkippedReason)
+	require.Nil(t, skipped.Deployment)
+}
+
+func newOperationsTestProject() *project.ProjectConfig {
+	projectConfig := &project.ProjectConfig{
+		Name: "test",
+		Path: "/tmp/test",
+		Services: map[string]*project.ServiceConfig{
+			"api": {Name: "api", Language: project.ServiceLanguagePython, Host: project.AppServiceTarget},
+			"web": {Name: "web", Language: project.ServiceLanguageJavaScript, Host: project.StaticWebAppTarget},
+		},
+	}
+
+	for _, svc := range projectConfig.Services {
+		svc.Project = projectConfig
+	}
+
+	return projectConfig
+}
+
+// newExtensionContextForTest returns an incoming gRPC context authorized with the token of the extension
+func newExtensionContextForTest(
+	t *testing.T,
+	ctx context.Context,
+	extension *extensions.Extension,
+) context.Context {
+	accessToken, err := GenerateExtensionToken(extension, &ServerInfo{SigningKey: []byte("test")})
+	require.NoError(t, err)
+
+	return metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", accessToken))
+}
+
+// testServiceManager is a project.ServiceManager returning the configured deploy results
+type testServiceManager struct {
+	project.ServiceManager
+	deployResults map[string]*project.ServiceDeployResult
+}
+
+func (m *testServiceManager) GetDeployResult(
+	serviceConfig *project.ServiceConfig,
+) (*project.ServiceDeployResult, bool) {
+	result, has := m.deployResults[serviceConfig.Name]
+	return result, has
+}
+
+// testProjectManager is a project.ProjectManager that initializes projects without running any of their hooks
+type testProjectManager struct {
+	project.ProjectManager
+}
+
+func (m *testProjectManager) Initialize(ctx context.Context, projectConfig *project.ProjectConfig) error {
+	return nil
+}
+
+// testOperationStream records the messages sent by an operation
+type testOperationStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	messages []*azdext.OperationMessage
+}
+
+func (s *testOperationStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testOperationStream) Send(message *azdext.OperationMessage) error {
+	s.messages = append(s.messages, message)
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// provisioningService implements azdext.ProvisioningServiceServer.
+type provisioningService struct {
+	azdext.UnimplementedProvisioningServiceServer
+	extensionManager *extensions.Manager
+	registry         *provisioning.ExternalProviderRegistry
+	console          input.Console
+}
+
+func NewProvisioningService(
+	extensionManager *extensions.Manager,
+	registry *provisioning.ExternalProviderRegistry,
+	console input.Console,
+) azdext.ProvisioningServiceServer {
+	return &provisioningService{
+		extensionManager: extensionManager,
+		registry:         registry,
+		console:          console,
+	}
+}
+
+// Stream handles bidirectional streaming.
+// The provisioning provider registered by the extension is available until the stream is closed.
+func (s *provisioningService) Stream(
+	stream grpc.BidiStreamingServer[azdext.ProvisioningMessage, azdext.ProvisioningMessage],
+) error {
+	ctx := stream.Context()
+	extensionClaims, err := GetExtensionClaims(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get extension claims: %w", err)
+	}
+
+	options := extensions.LookupOptions{
+		Id: extensionClaims.Subject,
+	}
+
+	extension, err := s.extensionManager.GetInstalled(options)
+	if err != nil {
+		return status.Errorf(codes.FailedPrecondition, "failed to get extension: %s", err.Error())
+	}
+
+	if !extension.HasCapability(extensions.ProvisioningProviderCapability) {
+		return status.Errorf(codes.PermissionDenied, "extension does not support provisioning providers")
+	}
+
+	// Projects select the provider by setting `infra.provider` to the id of the extension
+	providerKind := provisioning.ProviderKind(extension.Id)
+	provider := newExtensionProvisioningProvider(extension, stream, s.console)
+	registered := false
+
+	defer func() {
+		if registered {
+			s.registry.Unregister(providerKind)
+		}
+
+		provider.close()
+	}()
+
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			log.Println("Stream closed by extension")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch msg.MessageType.(type) {
+		case *azdext.ProvisioningMessage_RegisterProvisioningProviderRequest:
+			response := &azdext.ProvisioningMessage{
+				RequestId: msg.RequestId,
+				MessageType: &azdext.ProvisioningMessage_RegisterProvisioningProviderResponse{
+					RegisterProvisioningProviderResponse: &azdext.RegisterProvisioningProviderResponse{},
+				},
+			}
+
+			if err := s.registry.Register(providerKind, provider); err != nil {
+				response.Error = &azdext.ProvisioningErrorMessage{Message: err.Error()}
+			} else {
+				registered = true
+				provider.name = msg.GetRegisterProvisioningProviderRequest().Name
+				log.Printf("extension '%s' registered provisioning provider '%s'", extension.Id, provider.Name())
+			}
+
+			if err := provider.send(response); err != nil {
+				return err
+			}
+		case *azdext.ProvisioningMessage_ExtensionReadyEvent:
+			extension.Initialize()
+		default:
+			provider.handleResponse(msg)
+		}
+	}
+}
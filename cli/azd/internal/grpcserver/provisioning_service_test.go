@@ -0,0 +1,195 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// Test_ProvisioningService_Flow validates that the provisioning provider registered by an extension
+// proxies the provisioning operations to the extension.
+func Test_ProvisioningService_Flow(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	extensionManager, extension := newExtensionManagerForTest(
+		t,
+		mockContext,
+		"contoso.cdktf",
+		extensions.ProvisioningProviderCapability,
+	)
+	registry := provisioning.NewExternalProviderRegistry()
+
+	server := NewServer(
+		azdext.UnimplementedProjectServiceServer{},
+		azdext.UnimplementedEnvironmentServiceServer{},
+		azdext.UnimplementedPromptServiceServer{},
+		azdext.UnimplementedUserConfigServiceServer{},
+		azdext.UnimplementedDeploymentServiceServer{},
+		azdext.UnimplementedEventServiceServer{},
+		azdext.UnimplementedServiceTargetServiceServer{},
+		azdext.UnimplementedFrameworkServiceServer{},
+		NewProvisioningService(extensionManager, registry, mockContext.Console),
+		azdext.UnimplementedOperationsServiceServer{},
+		azdext.UnimplementedRemoteDataStoreServiceServer{},
+	)
+
+	serverInfo, err := server.Start()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, server.Stop())
+	}()
+
+	accessToken, err := GenerateExtensionToken(extension, serverInfo)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(azdext.WithAccessToken(*mockContext.Context, accessToken))
+	defer cancel()
+
+	client, err := azdext.NewAzdClient(azdext.WithAddress(serverInfo.Address))
+	require.NoError(t, err)
+	defer client.Close()
+
+	provisioningProviderManager := azdext.NewProvisioningProviderManager(client)
+	defer provisioningProviderManager.Close()
+
+	fakeProvider := &fakeProvisioningProvider{}
+	require.NoError(t, provisioningProviderManager.Register(ctx, "CDK for Terraform", fakeProvider))
+
+	// An extension can only provide a single provisioning provider
+	err = provisioningProviderManager.Register(ctx, "CDK for Terraform", fakeProvider)
+	require.ErrorContains(t, err, "has already been registered")
+
+	go func() {
+		_ = provisioningProviderManager.Receive(ctx)
+	}()
+	require.NoError(t, extension.WaitUntilReady(ctx))
+
+	provider, has := registry.Get("contoso.cdktf")
+	require.True(t, has)
+	require.Equal(t, "CDK for Terraform", provider.Name())
+
+	options := provisioning.Options{Provider: "contoso.cdktf", Path: "infra", Module: "main"}
+	require.NoError(t, provider.Initialize(ctx, "/projects/todo", options))
+	require.Equal(t, "/projects/todo", fakeProvider.projectPath)
+	require.Equal(t, "infra", fakeProvider.options.Path)
+
+	require.NoError(t, provider.EnsureEnv(ctx))
+
+	deployResult, err := provider.Deploy(ctx)
+	require.NoError(t, err)
+	require.Equal(t, provisioning.OutputParameter{
+		Type:  provisioning.ParameterTypeString,
+		Value: "https://todo.contoso.com",
+	}, deployResult.Deployment.Outputs["WEBSITE_URL"])
+	require.Equal(t, float64(3), deployResult.Deployment.Outputs["REPLICAS"].Value)
+	require.Equal(t, "eastus2", deployResult.Deployment.Parameters["location"].Value)
+	require.Nil(t, deployResult.Deployment.Parameters["location"].DefaultValue)
+
+	stateResult, err := provider.State(ctx, provisioning.NewStateOptions(""))
+	require.NoError(t, err)
+	require.Equal(t, []provisioning.Resource{{Id: "/subscriptions/sub/resourceGroups/rg-todo"}}, stateResult.State.Resources)
+
+	previewResult, err := provider.Preview(ctx)
+	require.NoError(t, err)
+	require.Len(t, previewResult.Preview.Properties.Changes, 1)
+	require.Equal(t, provisioning.ChangeTypeCreate, previewResult.Preview.Properties.Changes[0].ChangeType)
+
+	destroyResult, err := provider.Destroy(ctx, provisioning.NewDestroyOptions(true, false))
+	require.NoError(t, err)
+	require.Equal(t, []string{"WEBSITE_URL"}, destroyResult.InvalidatedEnvKeys)
+
+	// Outputs must use one of the parameter types supported by azd
+	fakeProvider.outputType = "map"
+	_, err = provider.State(ctx, provisioning.NewStateOptions(""))
+	require.ErrorContains(t, err, "unsupported type 'map'")
+}
+
+type fakeProvisioningProvider struct {
+	projectPath string
+	options     *azdext.ProvisioningOptions
+	outputType  string
+}
+
+func (p *fakeProvisioningProvider) Initialize(
+	ctx context.Context,
+	projectPath string,
+	options *azdext.ProvisioningOptions,
+) error {
+	p.projectPath = projectPath
+	p.options = options
+	return nil
+}
+
+func (p *fakeProvisioningProvider) State(
+	ctx context.Context,
+	hint string,
+	progress azdext.ProgressReporter,
+) (*azdext.ProvisioningState, error) {
+	outputType := p.outputType
+	if outputType == "" {
+		outputType = "string"
+	}
+
+	return &azdext.ProvisioningState{
+		Outputs: map[string]*azdext.ProvisioningOutputParameter{
+			"WEBSITE_URL": {Type: outputType, Value: []byte(`"https://todo.contoso.com"`)},
+		},
+		ResourceIds: []string{"/subscriptions/sub/resourceGroups/rg-todo"},
+	}, nil
+}
+
+func (p *fakeProvisioningProvider) Deploy(
+	ctx context.Context,
+	progress azdext.ProgressReporter,
+) (*azdext.ProvisioningDeployResult, error) {
+	progress("Applying stack")
+
+	return &azdext.ProvisioningDeployResult{
+		Deployment: &azdext.ProvisioningDeployment{
+			Parameters: map[string]*azdext.ProvisioningInputParameter{
+				"location": {Type: "string", Value: []byte(`"eastus2"`)},
+			},
+			Outputs: map[string]*azdext.ProvisioningOutputParameter{
+				"WEBSITE_URL": {Type: "string", Value: []byte(`"https://todo.contoso.com"`)},
+				"REPLICAS":    {Type: "number", Value: []byte(`3`)},
+			},
+		},
+	}, nil
+}
+
+func (p *fakeProvisioningProvider) Preview(
+	ctx context.Context,
+	progress azdext.ProgressReporter,
+) (*azdext.ProvisioningDeploymentPreview, error) {
+	return &azdext.ProvisioningDeploymentPreview{
+		Status: "Succeeded",
+		Changes: []*azdext.ProvisioningPreviewChange{
+			{
+				ChangeType:   "Create",
+				ResourceId:   "/subscriptions/sub/resourceGroups/rg-todo",
+				ResourceType: "Microsoft.Resources/resourceGroups",
+				Name:         "rg-todo",
+			},
+		},
+	}, nil
+}
+
+func (p *fakeProvisioningProvider) Destroy(
+	ctx context.Context,
+	force bool,
+	purge bool,
+	progress azdext.ProgressReporter,
+) ([]string, error) {
+	return []string{"WEBSITE_URL"}, nil
+}
+
+func (p *fakeProvisioningProvider) EnsureEnv(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,135 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"slices"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// remoteDataStoreService implements azdext.RemoteDataStoreServiceServer.
+type remoteDataStoreService struct {
+	azdext.UnimplementedRemoteDataStoreServiceServer
+	extensionManager *extensions.Manager
+	registry         *environment.ExternalDataStoreRegistry
+}
+
+func NewRemoteDataStoreService(
+	extensionManager *extensions.Manager,
+	registry *environment.ExternalDataStoreRegistry,
+) azdext.RemoteDataStoreServiceServer {
+	return &remoteDataStoreService{
+		extensionManager: extensionManager,
+		registry:         registry,
+	}
+}
+
+// Stream handles bidirectional streaming.
+// The remote data store registered by the extension is available until the stream is closed.
+func (s *remoteDataStoreService) Stream(
+	stream grpc.BidiStreamingServer[azdext.RemoteDataStoreMessage, azdext.RemoteDataStoreMessage],
+) error {
+	ctx := stream.Context()
+	extensionClaims, err := GetExtensionClaims(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get extension claims: %w", err)
+	}
+
+	options := extensions.LookupOptions{
+		Id: extensionClaims.Subject,
+	}
+
+	extension, err := s.extensionManager.GetInstalled(options)
+	if err != nil {
+		return status.Errorf(codes.FailedPrecondition, "failed to get extension: %s", err.Error())
+	}
+
+	if !extension.HasCapability(extensions.RemoteDataStoreProviderCapability) {
+		return status.Errorf(codes.PermissionDenied, "extension does not support remote data store providers")
+	}
+
+	dataStore := newExtensionRemoteDataStore(extension, stream)
+	var registered environment.RemoteKind
+
+	defer func() {
+		if registered != "" {
+			s.registry.Unregister(registered)
+		}
+
+		dataStore.close()
+	}()
+
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			log.Println("Stream closed by extension")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch msg.MessageType.(type) {
+		case *azdext.RemoteDataStoreMessage_RegisterRemoteDataStoreRequest:
+			backend := environment.RemoteKind(msg.GetRegisterRemoteDataStoreRequest().Backend)
+			response := &azdext.RemoteDataStoreMessage{
+				RequestId: msg.RequestId,
+				MessageType: &azdext.RemoteDataStoreMessage_RegisterRemoteDataStoreResponse{
+					RegisterRemoteDataStoreResponse: &azdext.RegisterRemoteDataStoreResponse{},
+				},
+			}
+
+			if err := s.register(extension, backend, registered, dataStore); err != nil {
+				response.Error = &azdext.RemoteDataStoreErrorMessage{Message: err.Error()}
+			} else {
+				registered = backend
+				log.Printf("extension '%s' registered remote state backend '%s'", extension.Id, backend)
+			}
+
+			if err := dataStore.send(response); err != nil {
+				return err
+			}
+		case *azdext.RemoteDataStoreMessage_ExtensionReadyEvent:
+			extension.Initialize()
+		default:
+			dataStore.handleResponse(msg)
+		}
+	}
+}
+
+// register registers the data store of the extension for a backend declared as a provider of the extension.
+// Each extension can register a single remote data store.
+func (s *remoteDataStoreService) register(
+	extension *extensions.Extension,
+	backend environment.RemoteKind,
+	registered environment.RemoteKind,
+	dataStore environment.ExternalDataStore,
+) error {
+	if registered != "" {
+		return fmt.Errorf("extension '%s' has already registered remote state backend '%s'", extension.Id, registered)
+	}
+
+	if !slices.ContainsFunc(extension.Providers, func(provider extensions.Provider) bool {
+		return provider.Type == extensions.RemoteDataStoreProviderType && provider.Name == string(backend)
+	}) {
+		return fmt.Errorf(
+			"remote state backend '%s' is not declared as a '%s' provider of extension '%s'",
+			backend,
+			extensions.RemoteDataStoreProviderType,
+			extension.Id,
+		)
+	}
+
+	return s.registry.Register(backend, dataStore)
+}
@@ -0,0 +1,249 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// Test_RemoteDataStoreService_Flow validates that the remote data store registered by an extension
+// proxies the data store operations to the extension.
+func Test_RemoteDataStoreService_Flow(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	extensionManager, extension := newExtensionManagerForTest(
+		t,
+		mockContext,
+		"test.s3",
+		extensions.RemoteDataStoreProviderCapability,
+	)
+	extension.Providers = []extensions.Provider{
+		{Name: "s3", Type: extensions.RemoteDataStoreProviderType},
+		{Name: string(environment.RemoteKindAzureBlobStorage), Type: extensions.RemoteDataStoreProviderType},
+	}
+	environment.MustRegisterRemoteDataStore(
+		mockContext.Container,
+		environment.RemoteKindAzureBlobStorage,
+		func() environment.RemoteDataStore { return nil },
+	)
+	for _, provider := range extension.Providers {
+		environment.RegisterExternalRemoteKind(environment.RemoteKind(provider.Name))
+	}
+	registry := environment.NewExternalDataStoreRegistry()
+
+	server := NewServer(
+		azdext.UnimplementedProjectServiceServer{},
+		azdext.UnimplementedEnvironmentServiceServer{},
+		azdext.UnimplementedPromptServiceServer{},
+		azdext.UnimplementedUserConfigServiceServer{},
+		azdext.UnimplementedDeploymentServiceServer{},
+		azdext.UnimplementedEventServiceServer{},
+		azdext.UnimplementedServiceTargetServiceServer{},
+		azdext.UnimplementedFrameworkServiceServer{},
+		azdext.UnimplementedProvisioningServiceServer{},
+		azdext.UnimplementedOperationsServiceServer{},
+		NewRemoteDataStoreService(extensionManager, registry),
+	)
+
+	serverInfo, err := server.Start()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, server.Stop())
+	}()
+
+	accessToken, err := GenerateExtensionToken(extension, serverInfo)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(azdext.WithAccessToken(*mockContext.Context, accessToken))
+	defer cancel()
+
+	client, err := azdext.NewAzdClient(azdext.WithAddress(serverInfo.Address))
+	require.NoError(t, err)
+	defer client.Close()
+
+	remoteDataStoreManager := azdext.NewRemoteDataStoreManager(client)
+	defer remoteDataStoreManager.Close()
+
+	provider := &fakeRemoteDataStoreProvider{environments: map[string]*azdext.RemoteEnvironment{}}
+
+	// Built-in backends cannot be overridden by extensions
+	err = remoteDataStoreManager.Register(ctx, string(environment.RemoteKindAzureBlobStorage), provider)
+	require.ErrorContains(t, err, "cannot be overridden")
+
+	// Backends must be declared as providers of the extension
+	err = remoteDataStoreManager.Register(ctx, "gcs", provider)
+	require.ErrorContains(t, err, "is not declared as a 'remote-data-store' provider")
+
+	require.NoError(t, remoteDataStoreManager.Register(ctx, "s3", provider))
+
+	go func() {
+		_ = remoteDataStoreManager.Receive(ctx)
+	}()
+	require.NoError(t, extension.WaitUntilReady(ctx))
+
+	dataStore, has := registry.Get("s3")
+	require.True(t, has)
+
+	require.NoError(t, dataStore.Initialize(ctx, map[string]any{"bucket": "environments"}))
+	require.Equal(t, map[string]any{"bucket": "environments"}, provider.config)
+
+	_, err = dataStore.Get(ctx, "dev")
+	require.ErrorIs(t, err, environment.ErrNotFound)
+
+	version, err := dataStore.Save(ctx, &environment.EnvironmentState{
+		Name:   "dev",
+		Dotenv: map[string]string{"AZURE_LOCATION": "westus2"},
+		Config: []byte(`{"infra":{"parameters":{}}}`),
+	}, &environment.SaveOptions{IsNew: true})
+	require.NoError(t, err)
+	require.Equal(t, "1", version)
+
+	state, err := dataStore.Get(ctx, "dev")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"AZURE_LOCATION": "westus2"}, state.Dotenv)
+	require.JSONEq(t, `{"infra":{"parameters":{}}}`, string(state.Config))
+	require.Equal(t, "1", state.Version)
+
+	// Saving a stale version of the environment conflicts with the stored environment
+	_, err = dataStore.Save(ctx, &environment.EnvironmentState{Name: "dev", Version: "0"}, nil)
+	require.ErrorIs(t, err, environment.ErrConflict)
+
+	envs, err := dataStore.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, envs, 1)
+	require.Equal(t, "dev", envs[0].Name)
+	require.Equal(t, "dev/.env", envs[0].DotEnvPath)
+
+	acquiredAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	require.NoError(t, dataStore.Lock(ctx, "dev", &environment.LockInfo{
+		Id:         "lock-1",
+		Owner:      "alice@contoso",
+		Operation:  "azd provision",
+		AcquiredAt: acquiredAt,
+	}))
+
+	err = dataStore.Lock(ctx, "dev", &environment.LockInfo{Id: "lock-2", Operation: "azd deploy"})
+	var lockedErr *environment.LockedError
+	require.ErrorAs(t, err, &lockedErr)
+	require.Equal(t, "dev", lockedErr.EnvName)
+	require.Equal(t, "lock-1", lockedErr.Lock.Id)
+	require.Equal(t, "azd provision", lockedErr.Lock.Operation)
+	require.Equal(t, acquiredAt, lockedErr.Lock.AcquiredAt)
+
+	require.NoError(t, dataStore.Unlock(ctx, "dev", "lock-1"))
+	require.NoError(t, dataStore.Delete(ctx, "dev"))
+
+	err = dataStore.Delete(ctx, "dev")
+	require.ErrorIs(t, err, environment.ErrNotFound)
+
+	// Failures that azd doesn't handle are reported as failures of the extension
+	err = dataStore.Unlock(ctx, "prod", "lock-1")
+	require.ErrorContains(t, err, "extension 'test.s3' failed: bucket is read-only")
+}
+
+type fakeRemoteDataStoreProvider struct {
+	mu           sync.Mutex
+	config       map[string]any
+	environments map[string]*azdext.RemoteEnvironment
+	lock         *azdext.EnvironmentLock
+}
+
+func (p *fakeRemoteDataStoreProvider) Initialize(ctx context.Context, config map[string]any) error {
+	p.config = config
+	return nil
+}
+
+func (p *fakeRemoteDataStoreProvider) List(ctx context.Context) ([]*azdext.RemoteEnvironmentDescription, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	envs := []*azdext.RemoteEnvironmentDescription{}
+	for name := range p.environments {
+		envs = append(envs, &azdext.RemoteEnvironmentDescription{
+			Name:       name,
+			DotEnvPath: name + "/.env",
+			ConfigPath: name + "/config.json",
+		})
+	}
+
+	return envs, nil
+}
+
+func (p *fakeRemoteDataStoreProvider) Get(ctx context.Context, name string) (*azdext.RemoteEnvironment, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	env, has := p.environments[name]
+	if !has {
+		return nil, azdext.ErrEnvironmentNotFound
+	}
+
+	return env, nil
+}
+
+func (p *fakeRemoteDataStoreProvider) Save(
+	ctx context.Context,
+	env *azdext.RemoteEnvironment,
+	isNew bool,
+) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if stored, has := p.environments[env.Name]; has && stored.Version != env.Version {
+		return "", azdext.ErrEnvironmentConflict
+	}
+
+	env.Version += "1"
+	p.environments[env.Name] = env
+
+	return env.Version, nil
+}
+
+func (p *fakeRemoteDataStoreProvider) Delete(ctx context.Context, name string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, has := p.environments[name]; !has {
+		return azdext.ErrEnvironmentNotFound
+	}
+
+	delete(p.environments, name)
+	return nil
+}
+
+func (p *fakeRemoteDataStoreProvider) Lock(ctx context.Context, name string, lock *azdext.EnvironmentLock) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.lock != nil {
+		return &azdext.EnvironmentLockedError{Lock: p.lock}
+	}
+
+	p.lock = lock
+	return nil
+}
+
+func (p *fakeRemoteDataStoreProvider) Unlock(ctx context.Context, name string, lockId string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if name != "dev" {
+		return errors.New("bucket is read-only")
+	}
+
+	if p.lock != nil && p.lock.Id == lockId {
+		p.lock = nil
+	}
+
+	return nil
+}
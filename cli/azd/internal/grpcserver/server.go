@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"sync"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
 	"google.golang.org/grpc"
@@ -24,13 +25,24 @@ type ServerInfo struct {
 }
 
 type Server struct {
-	grpcServer         *grpc.Server
-	projectService     azdext.ProjectServiceServer
-	environmentService azdext.EnvironmentServiceServer
-	promptService      azdext.PromptServiceServer
-	userConfigService  azdext.UserConfigServiceServer
-	deploymentService  azdext.DeploymentServiceServer
-	eventService       azdext.EventServiceServer
+	grpcServer             *grpc.Server
+	projectService         azdext.ProjectServiceServer
+	environmentService     azdext.EnvironmentServiceServer
+	promptService          azdext.PromptServiceServer
+	userConfigService      azdext.UserConfigServiceServer
+	deploymentService      azdext.DeploymentServiceServer
+	eventService           azdext.EventServiceServer
+	serviceTargetService   azdext.ServiceTargetServiceServer
+	frameworkService       azdext.FrameworkServiceServer
+	provisioningService    azdext.ProvisioningServiceServer
+	operationsService      azdext.OperationsServiceServer
+	remoteDataStoreService azdext.RemoteDataStoreServiceServer
+
+	mu sync.Mutex
+	// serverInfo is the info of the running server
+	serverInfo *ServerInfo
+	// starts is the number of Start calls that have not been matched by a Stop call
+	starts int
 }
 
 func NewServer(
@@ -40,18 +52,39 @@ func NewServer(
 	userConfigService azdext.UserConfigServiceServer,
 	deploymentService azdext.DeploymentServiceServer,
 	eventService azdext.EventServiceServer,
+	serviceTargetService azdext.ServiceTargetServiceServer,
+	frameworkService azdext.FrameworkServiceServer,
+	provisioningService azdext.ProvisioningServiceServer,
+	operationsService azdext.OperationsServiceServer,
+	remoteDataStoreService azdext.RemoteDataStoreServiceServer,
 ) *Server {
 	return &Server{
-		projectService:     projectService,
-		environmentService: environmentService,
-		promptService:      promptService,
-		userConfigService:  userConfigService,
-		deploymentService:  deploymentService,
-		eventService:       eventService,
+		projectService:         projectService,
+		environmentService:     environmentService,
+		promptService:          promptService,
+		userConfigService:      userConfigService,
+		deploymentService:      deploymentService,
+		eventService:           eventService,
+		serviceTargetService:   serviceTargetService,
+		frameworkService:       frameworkService,
+		provisioningService:    provisioningService,
+		operationsService:      operationsService,
+		remoteDataStoreService: remoteDataStoreService,
 	}
 }
 
+// Start starts the server. When the server is already running, ex) started for the extensions providing the remote
+// state backend before the command runs, the info of the running server is returned.
+// Each call to Start must be matched by a call to Stop.
 func (s *Server) Start() (*ServerInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.serverInfo != nil {
+		s.starts++
+		return s.serverInfo, nil
+	}
+
 	signingKey, err := generateSigningKey()
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
@@ -61,6 +94,7 @@ func (s *Server) Start() (*ServerInfo, error) {
 
 	s.grpcServer = grpc.NewServer(
 		grpc.UnaryInterceptor(s.tokenAuthInterceptor(&serverInfo)),
+		grpc.StreamInterceptor(s.streamTokenAuthInterceptor(&serverInfo)),
 	)
 
 	// Use ":0" to let the system assign an available random port
@@ -79,6 +113,11 @@ func (s *Server) Start() (*ServerInfo, error) {
 	azdext.RegisterUserConfigServiceServer(s.grpcServer, s.userConfigService)
 	azdext.RegisterDeploymentServiceServer(s.grpcServer, s.deploymentService)
 	azdext.RegisterEventServiceServer(s.grpcServer, s.eventService)
+	azdext.RegisterServiceTargetServiceServer(s.grpcServer, s.serviceTargetService)
+	azdext.RegisterFrameworkServiceServer(s.grpcServer, s.frameworkService)
+	azdext.RegisterProvisioningServiceServer(s.grpcServer, s.provisioningService)
+	azdext.RegisterOperationsServiceServer(s.grpcServer, s.operationsService)
+	azdext.RegisterRemoteDataStoreServiceServer(s.grpcServer, s.remoteDataStoreService)
 
 	serverInfo.Address = fmt.Sprintf("localhost:%d", randomPort)
 	serverInfo.Port = randomPort
@@ -93,19 +132,32 @@ func (s *Server) Start() (*ServerInfo, error) {
 
 	log.Printf("AZD Server listening on port %d", randomPort)
 
-	return &ServerInfo{
+	s.serverInfo = &ServerInfo{
 		Address:    fmt.Sprintf("localhost:%d", randomPort),
 		Port:       randomPort,
 		SigningKey: signingKey,
-	}, nil
+	}
+	s.starts = 1
+
+	return s.serverInfo, nil
 }
 
+// Stop stops the server once all the callers that started the server have stopped it.
 func (s *Server) Stop() error {
-	if s.grpcServer == nil {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.grpcServer == nil || s.serverInfo == nil {
 		return fmt.Errorf("server is not running")
 	}
 
+	s.starts--
+	if s.starts > 0 {
+		return nil
+	}
+
 	s.grpcServer.Stop()
+	s.serverInfo = nil
 	log.Println("AZD Server stopped")
 
 	return nil
@@ -139,6 +191,34 @@ func (s *Server) tokenAuthInterceptor(serverInfo *ServerInfo) grpc.UnaryServerIn
 	}
 }
 
+func (s *Server) streamTokenAuthInterceptor(serverInfo *ServerInfo) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		md, ok := metadata.FromIncomingContext(stream.Context())
+		if !ok {
+			return status.Error(codes.Unauthenticated, "metadata missing")
+		}
+
+		// Extract the authorization token from metadata
+		token := md["authorization"]
+		if len(token) == 0 {
+			return status.Error(codes.Unauthenticated, "invalid token")
+		}
+
+		_, err := ParseExtensionToken(token[0], serverInfo)
+		if err != nil {
+			return status.Error(codes.Unauthenticated, "invalid token")
+		}
+
+		// Proceed to the handler
+		return handler(srv, stream)
+	}
+}
+
 func generateSigningKey() ([]byte, error) {
 	bytes := make([]byte, 16) // 128-bit token
 	if _, err := rand.Read(bytes); err != nil {
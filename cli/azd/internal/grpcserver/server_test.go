@@ -28,6 +28,11 @@ func Test_Server_Start(t *testing.T) {
 		azdext.UnimplementedUserConfigServiceServer{},
 		azdext.UnimplementedDeploymentServiceServer{},
 		azdext.UnimplementedEventServiceServer{},
+		azdext.UnimplementedServiceTargetServiceServer{},
+		azdext.UnimplementedFrameworkServiceServer{},
+		azdext.UnimplementedProvisioningServiceServer{},
+		azdext.UnimplementedOperationsServiceServer{},
+		azdext.UnimplementedRemoteDataStoreServiceServer{},
 	)
 
 	serverInfo, err := server.Start()
@@ -94,4 +99,24 @@ func Test_Server_Start(t *testing.T) {
 		require.True(t, ok)
 		require.Equal(t, codes.Unauthenticated, st.Code())
 	})
+
+	t.Run("NestedStart", func(t *testing.T) {
+		// Starting a running server returns the running server, which keeps running until stopped by every start.
+		nestedServerInfo, err := server.Start()
+		require.NoError(t, err)
+		require.Same(t, serverInfo, nestedServerInfo)
+		require.NoError(t, server.Stop())
+
+		accessToken, err := GenerateExtensionToken(extension, serverInfo)
+		require.NoError(t, err)
+
+		ctx := azdext.WithAccessToken(context.Background(), accessToken)
+		client, err := azdext.NewAzdClient(azdext.WithAddress(serverInfo.Address))
+		require.NoError(t, err)
+
+		_, err = client.Project().Get(ctx, &azdext.EmptyRequest{})
+		st, ok := status.FromError(err)
+		require.True(t, ok)
+		require.Equal(t, codes.Unimplemented, st.Code())
+	})
 }
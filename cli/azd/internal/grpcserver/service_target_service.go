@@ -0,0 +1,113 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// serviceTargetService implements azdext.ServiceTargetServiceServer.
+type serviceTargetService struct {
+	azdext.UnimplementedServiceTargetServiceServer
+	extensionManager *extensions.Manager
+	registry         *project.ExternalServiceTargetRegistry
+	lazyEnv          *lazy.Lazy[*environment.Environment]
+}
+
+func NewServiceTargetService(
+	extensionManager *extensions.Manager,
+	registry *project.ExternalServiceTargetRegistry,
+	lazyEnv *lazy.Lazy[*environment.Environment],
+) azdext.ServiceTargetServiceServer {
+	return &serviceTargetService{
+		extensionManager: extensionManager,
+		registry:         registry,
+		lazyEnv:          lazyEnv,
+	}
+}
+
+// Stream handles bidirectional streaming.
+// The service targets registered by the extension are available until the stream is closed.
+func (s *serviceTargetService) Stream(
+	stream grpc.BidiStreamingServer[azdext.ServiceTargetMessage, azdext.ServiceTargetMessage],
+) error {
+	ctx := stream.Context()
+	extensionClaims, err := GetExtensionClaims(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get extension claims: %w", err)
+	}
+
+	options := extensions.LookupOptions{
+		Id: extensionClaims.Subject,
+	}
+
+	extension, err := s.extensionManager.GetInstalled(options)
+	if err != nil {
+		return status.Errorf(codes.FailedPrecondition, "failed to get extension: %s", err.Error())
+	}
+
+	if !extension.HasCapability(extensions.ServiceTargetProviderCapability) {
+		return status.Errorf(codes.PermissionDenied, "extension does not support service target providers")
+	}
+
+	serviceTarget := newExtensionServiceTarget(extension, stream, s.lazyEnv)
+	registeredHosts := []project.ServiceTargetKind{}
+
+	defer func() {
+		for _, host := range registeredHosts {
+			s.registry.Unregister(host)
+		}
+
+		serviceTarget.close()
+	}()
+
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			log.Println("Stream closed by extension")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch msg.MessageType.(type) {
+		case *azdext.ServiceTargetMessage_RegisterServiceTargetRequest:
+			host := project.ServiceTargetKind(msg.GetRegisterServiceTargetRequest().Host)
+			response := &azdext.ServiceTargetMessage{
+				RequestId: msg.RequestId,
+				MessageType: &azdext.ServiceTargetMessage_RegisterServiceTargetResponse{
+					RegisterServiceTargetResponse: &azdext.RegisterServiceTargetResponse{},
+				},
+			}
+
+			if err := s.registry.Register(host, serviceTarget); err != nil {
+				response.Error = &azdext.ServiceTargetErrorMessage{Message: err.Error()}
+			} else {
+				registeredHosts = append(registeredHosts, host)
+				log.Printf("extension '%s' registered service host '%s'", extension.Id, host)
+			}
+
+			if err := serviceTarget.send(response); err != nil {
+				return err
+			}
+		case *azdext.ServiceTargetMessage_ExtensionReadyEvent:
+			extension.Initialize()
+		default:
+			serviceTarget.handleResponse(msg)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// Test_ServiceTargetService_Flow validates that service targets registered by an extension
+// proxy the service target operations to the extension.
+func Test_ServiceTargetService_Flow(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	extensionManager, extension := newExtensionManagerForTest(
+		t,
+		mockContext,
+		"test.vm",
+		extensions.ServiceTargetProviderCapability,
+	)
+	registry := project.NewExternalServiceTargetRegistry()
+
+	server := NewServer(
+		azdext.UnimplementedProjectServiceServer{},
+		azdext.UnimplementedEnvironmentServiceServer{},
+		azdext.UnimplementedPromptServiceServer{},
+		azdext.UnimplementedUserConfigServiceServer{},
+		azdext.UnimplementedDeploymentServiceServer{},
+		azdext.UnimplementedEventServiceServer{},
+		NewServiceTargetService(extensionManager, registry, lazy.From(environment.New("dev"))),
+		azdext.UnimplementedFrameworkServiceServer{},
+		azdext.UnimplementedProvisioningServiceServer{},
+		azdext.UnimplementedOperationsServiceServer{},
+		azdext.UnimplementedRemoteDataStoreServiceServer{},
+	)
+
+	serverInfo, err := server.Start()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, server.Stop())
+	}()
+
+	accessToken, err := GenerateExtensionToken(extension, serverInfo)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(azdext.WithAccessToken(*mockContext.Context, accessToken))
+	defer cancel()
+
+	client, err := azdext.NewAzdClient(azdext.WithAddress(serverInfo.Address))
+	require.NoError(t, err)
+	defer client.Close()
+
+	serviceTargetManager := azdext.NewServiceTargetManager(client)
+	defer serviceTargetManager.Close()
+
+	// Built-in hosts cannot be overridden by extensions
+	err = serviceTargetManager.Register(ctx, string(project.ContainerAppTarget), &fakeServiceTargetProvider{})
+	require.ErrorContains(t, err, "cannot be overridden")
+
+	require.NoError(t, serviceTargetManager.Register(ctx, "vm", &fakeServiceTargetProvider{}))
+
+	go func() {
+		_ = serviceTargetManager.Receive(ctx)
+	}()
+	require.NoError(t, extension.WaitUntilReady(ctx))
+
+	serviceTarget, has := registry.Get("vm")
+	require.True(t, has)
+
+	serviceConfig := &project.ServiceConfig{Name: "api", Host: "vm"}
+	targetResource := environment.NewTargetResource("sub", "rg", "vm-api", "Microsoft.Compute/virtualMachines")
+	require.NoError(t, serviceTarget.Initialize(ctx, serviceConfig))
+
+	packageResult, err := serviceTarget.Package(
+		ctx,
+		serviceConfig,
+		&project.ServicePackageResult{PackagePath: "dist"},
+		async.NewProgress[project.ServiceProgress](),
+	)
+	require.NoError(t, err)
+	require.Equal(t, "dist.tar.gz", packageResult.PackagePath)
+
+	progress := async.NewProgress[project.ServiceProgress]()
+	progressMessages := []string{}
+	progressDone := make(chan struct{})
+	go func() {
+		defer close(progressDone)
+		for p := range progress.Progress() {
+			progressMessages = append(progressMessages, p.Message)
+		}
+	}()
+
+	deployResult, err := serviceTarget.Deploy(ctx, serviceConfig, packageResult, targetResource, progress)
+	progress.Done()
+	<-progressDone
+
+	require.NoError(t, err)
+	require.Equal(t, project.ServiceTargetKind("vm"), deployResult.Kind)
+	require.Equal(t, "/subscriptions/sub/resourceGroups/rg/vm-api", deployResult.TargetResourceId)
+	require.Equal(t, []string{"https://vm-api.contoso.com"}, deployResult.Endpoints)
+	require.Equal(t, []string{"Copying dist.tar.gz to vm-api"}, progressMessages)
+
+	_, err = serviceTarget.Endpoints(ctx, &project.ServiceConfig{Name: "web", Host: "vm"}, targetResource)
+	require.ErrorContains(t, err, "extension 'test.vm' failed: service 'web' is not running")
+}
+
+func newExtensionManagerForTest(
+	t *testing.T,
+	mockContext *mocks.MockContext,
+	extensionId string,
+	capability extensions.CapabilityType,
+) (*extensions.Manager, *extensions.Extension) {
+	userConfig := config.NewEmptyConfig()
+	err := userConfig.Set("extension.installed", map[string]any{
+		extensionId: map[string]any{
+			"id":           extensionId,
+			"capabilities": []string{string(capability)},
+		},
+	})
+	require.NoError(t, err)
+	mockContext.ConfigManager.WithConfig(userConfig)
+
+	userConfigManager := config.NewUserConfigManager(mockContext.ConfigManager)
+	sourceManager := extensions.NewSourceManager(mockContext.Container, userConfigManager, mockContext.HttpClient)
+	extensionManager, err := extensions.NewManager(userConfigManager, sourceManager, mockContext.HttpClient)
+	require.NoError(t, err)
+
+	extension, err := extensionManager.GetInstalled(extensions.LookupOptions{Id: extensionId})
+	require.NoError(t, err)
+
+	return extensionManager, extension
+}
+
+type fakeServiceTargetProvider struct{}
+
+func (p *fakeServiceTargetProvider) Initialize(ctx context.Context, serviceConfig *azdext.ServiceConfig) error {
+	return nil
+}
+
+func (p *fakeServiceTargetProvider) Package(
+	ctx context.Context,
+	serviceConfig *azdext.ServiceConfig,
+	frameworkPackage *azdext.ServicePackageResult,
+	progress azdext.ProgressReporter,
+) (*azdext.ServicePackageResult, error) {
+	return &azdext.ServicePackageResult{PackagePath: frameworkPackage.PackagePath + ".tar.gz"}, nil
+}
+
+func (p *fakeServiceTargetProvider) Deploy(
+	ctx context.Context,
+	serviceConfig *azdext.ServiceConfig,
+	servicePackage *azdext.ServicePackageResult,
+	targetResource *azdext.TargetResource,
+	progress azdext.ProgressReporter,
+) (*azdext.ServiceDeployResult, error) {
+	progress("Copying " + servicePackage.PackagePath + " to " + targetResource.ResourceName)
+
+	return &azdext.ServiceDeployResult{
+		TargetResourceId: "/subscriptions/" + targetResource.SubscriptionId +
+			"/resourceGroups/" + targetResource.ResourceGroupName + "/" + targetResource.ResourceName,
+		Endpoints: []string{"https://" + targetResource.ResourceName + ".contoso.com"},
+	}, nil
+}
+
+func (p *fakeServiceTargetProvider) Endpoints(
+	ctx context.Context,
+	serviceConfig *azdext.ServiceConfig,
+	targetResource *azdext.TargetResource,
+) ([]string, error) {
+	return nil, errors.New("service '" + serviceConfig.Name + "' is not running")
+}
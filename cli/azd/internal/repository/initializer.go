@@ -21,6 +21,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/pkg/oci"
 	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
@@ -40,6 +41,7 @@ type Initializer struct {
 	dotnetCli      *dotnet.Cli
 	features       *alpha.FeatureManager
 	lazyEnvManager *lazy.Lazy[environment.Manager]
+	ociClient      *oci.Client
 }
 
 func NewInitializer(
@@ -48,6 +50,7 @@ func NewInitializer(
 	dotnetCli *dotnet.Cli,
 	features *alpha.FeatureManager,
 	lazyEnvManager *lazy.Lazy[environment.Manager],
+	ociClient *oci.Client,
 ) *Initializer {
 	return &Initializer{
 		console:        console,
@@ -55,6 +58,7 @@ func NewInitializer(
 		lazyEnvManager: lazyEnvManager,
 		dotnetCli:      dotnetCli,
 		features:       features,
+		ociClient:      ociClient,
 	}
 }
 
@@ -90,7 +94,13 @@ func (i *Initializer) Initialize(
 		return err
 	}
 
-	filesWithExecPerms, err := i.fetchCode(ctx, templateUrl, templateBranch, staging)
+	var filesWithExecPerms []string
+	var pinnedTemplatePath string
+	if oci.IsReference(templateUrl) {
+		filesWithExecPerms, pinnedTemplatePath, err = i.fetchOciCode(ctx, templateUrl, templateBranch, staging)
+	} else {
+		filesWithExecPerms, err = i.fetchCode(ctx, templateUrl, templateBranch, staging)
+	}
 	if err != nil {
 		return err
 	}
@@ -136,9 +146,38 @@ func (i *Initializer) Initialize(
 
 	i.console.StopSpinner(ctx, stepMessage+"\n", input.GetStepResultFormat(err))
 
+	if pinnedTemplatePath != "" {
+		// OCI artifacts are content-addressed, the pinned reference initializes the exact same template again
+		i.console.Message(ctx, fmt.Sprintf("Initialized from template %s", output.WithHighLightFormat(pinnedTemplatePath)))
+	}
+
 	return nil
 }
 
+// fetchOciCode downloads the template published as an OCI artifact and returns the executable files of the template and
+// the reference to the artifact pinned to its digest.
+func (i *Initializer) fetchOciCode(
+	ctx context.Context,
+	templateUrl string,
+	templateBranch string,
+	destination string) (executableFilePaths []string, pinnedTemplatePath string, err error) {
+	if templateBranch != "" {
+		return nil, "", fmt.Errorf(
+			"branch '%s' can't be used with template '%s', use a tag or digest of the OCI artifact instead",
+			templateBranch,
+			templateUrl)
+	}
+
+	ociTemplate, err := templates.FetchOciTemplate(ctx, i.ociClient, templateUrl, destination)
+	if err != nil {
+		return nil, "", fmt.Errorf("fetching template: %w", err)
+	}
+
+	log.Printf("fetched template '%s' as '%s'", templateUrl, ociTemplate.Path)
+
+	return ociTemplate.ExecutableFiles, ociTemplate.Path, nil
+}
+
 func (i *Initializer) fetchCode(
 	ctx context.Context,
 	templateUrl string,
@@ -4,6 +4,9 @@
 package repository
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"context"
 	"fmt"
 	"io/fs"
@@ -22,6 +25,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/pkg/oci"
 	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 	"github.com/azure/azure-dev/cli/azd/pkg/platform"
 	"github.com/azure/azure-dev/cli/azd/pkg/project"
@@ -32,6 +36,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/test/mocks/mockenv"
 	"github.com/azure/azure-dev/cli/azd/test/mocks/mockexec"
 	"github.com/azure/azure-dev/cli/azd/test/mocks/mockinput"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockoci"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -66,6 +71,7 @@ func Test_Initializer_Initialize(t *testing.T) {
 				dotnet.NewCli(mockContext.CommandRunner),
 				mockContext.AlphaFeaturesManager,
 				lazy.From[environment.Manager](mockEnv),
+				nil,
 			)
 			err := i.Initialize(*mockContext.Context, azdCtx, &templates.Template{RepositoryPath: "local"}, "")
 			require.NoError(t, err)
@@ -111,6 +117,7 @@ func Test_Initializer_DevCenter(t *testing.T) {
 		dotnet.NewCli(mockContext.CommandRunner),
 		mockContext.AlphaFeaturesManager,
 		lazy.From[environment.Manager](mockEnv),
+		nil,
 	)
 	err := i.Initialize(*mockContext.Context, azdCtx, template, "")
 	require.NoError(t, err)
@@ -123,6 +130,51 @@ func Test_Initializer_DevCenter(t *testing.T) {
 	require.Equal(t, prj.Platform.Config["environmentDefinition"], "DEVCENTER_ENV_DEFINITION")
 }
 
+func Test_Initializer_InitializeOci(t *testing.T) {
+	registry := mockoci.NewRegistry(t)
+	digest := pushTemplateArtifact(t, registry, "templates/local", "1.0", testCase{
+		templateDir:     "template",
+		executableFiles: []string{"script/test.sh"},
+	})
+
+	mockEnv := &mockenv.MockEnvManager{}
+	mockEnv.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	mockContext := mocks.NewMockContext(context.Background())
+	i := NewInitializer(
+		mockContext.Console,
+		git.NewCli(exec.NewCommandRunner(nil)),
+		dotnet.NewCli(mockContext.CommandRunner),
+		mockContext.AlphaFeaturesManager,
+		lazy.From[environment.Manager](mockEnv),
+		oci.NewClient(registry.Client(), nil, nil),
+	)
+
+	templatePath := fmt.Sprintf("oci://%s/templates/local:1.0", registry.Host)
+
+	t.Run("tag", func(t *testing.T) {
+		projectDir := t.TempDir()
+		azdCtx := azdcontext.NewAzdContextWithDirectory(projectDir)
+
+		err := i.Initialize(*mockContext.Context, azdCtx, &templates.Template{RepositoryPath: templatePath}, "")
+		require.NoError(t, err)
+
+		verifyTemplateCopied(t, testDataPath("template"), projectDir, verifyOptions{})
+		verifyExecutableFilePermissions(t, *mockContext.Context, i.gitCli, projectDir, []string{"script/test.sh"})
+		require.FileExists(t, azdCtx.ProjectPath())
+
+		// the reference pinned to the digest of the artifact is displayed to initialize the same template again
+		require.Contains(t, mockContext.Console.Output(), "Initialized from template "+templatePath+"@"+digest)
+	})
+
+	t.Run("branch", func(t *testing.T) {
+		azdCtx := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+
+		err := i.Initialize(*mockContext.Context, azdCtx, &templates.Template{RepositoryPath: templatePath}, "main")
+		require.ErrorContains(t, err, "use a tag or digest of the OCI artifact instead")
+	})
+}
+
 func Test_Initializer_InitializeWithOverwritePrompt(t *testing.T) {
 	templateDir := "template"
 	tests := []struct {
@@ -182,6 +234,7 @@ func Test_Initializer_InitializeWithOverwritePrompt(t *testing.T) {
 				dotnet.NewCli(mockRunner),
 				alpha.NewFeaturesManagerWithConfig(config.NewEmptyConfig()),
 				lazy.From[environment.Manager](mockEnv),
+				nil,
 			)
 			err = i.Initialize(context.Background(), azdCtx, &templates.Template{RepositoryPath: "local"}, "")
 			require.NoError(t, err)
@@ -248,6 +301,63 @@ func copyTemplate(t *testing.T, source string, target string) {
 	require.NoError(t, os.MkdirAll(filepath.Join(target, ".git"), 0755))
 }
 
+// pushTemplateArtifact publishes the files of the test case template to the registry as an azd template artifact and
+// returns the digest of its manifest.
+func pushTemplateArtifact(
+	t *testing.T, registry *mockoci.Registry, repository string, tag string, testCase testCase) string {
+	var content bytes.Buffer
+	gzWriter := gzip.NewWriter(&content)
+	tarWriter := tar.NewWriter(gzWriter)
+
+	source := testDataPath(testCase.templateDir)
+	err := filepath.WalkDir(source, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(source, path)
+		if err != nil {
+			return fmt.Errorf("computing relative path: %w", err)
+		}
+
+		name := filepath.ToSlash(strings.TrimSuffix(rel, ".txt"))
+		body := readFile(t, path)
+		mode := int64(0644)
+		if slices.Contains(testCase.executableFiles, name) {
+			mode = 0755
+		}
+
+		if err := tarWriter.WriteHeader(&tar.Header{
+			Name: name, Mode: mode, Size: int64(len(body)), Typeflag: tar.TypeReg,
+		}); err != nil {
+			return err
+		}
+
+		_, err = tarWriter.Write([]byte(body))
+		return err
+	})
+	require.NoError(t, err)
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzWriter.Close())
+
+	config := []byte(`{"name":"local"}`)
+	return registry.PushManifest(repository, tag, oci.Manifest{
+		SchemaVersion: 2,
+		MediaType:     oci.MediaTypeImageManifest,
+		ArtifactType:  templates.OciArtifactType,
+		Config: oci.Descriptor{
+			MediaType: templates.OciConfigMediaType,
+			Digest:    registry.PushBlob(config),
+			Size:      int64(len(config)),
+		},
+		Layers: []oci.Descriptor{{
+			MediaType: templates.OciContentMediaType,
+			Digest:    registry.PushBlob(content.Bytes()),
+			Size:      int64(content.Len()),
+		}},
+	})
+}
+
 type verifyOptions struct {
 	// skip verification for a given file.
 	Skip func(src string) (bool, error)
@@ -386,7 +496,7 @@ func Test_Initializer_WriteCoreAssets(t *testing.T) {
 			i := NewInitializer(
 				console, git.NewCli(realRunner), nil,
 				alpha.NewFeaturesManagerWithConfig(config.NewEmptyConfig()),
-				lazy.From[environment.Manager](envManager))
+				lazy.From[environment.Manager](envManager), nil)
 			err := i.writeCoreAssets(context.Background(), azdCtx)
 			require.NoError(t, err)
 
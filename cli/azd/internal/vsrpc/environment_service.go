@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 
 	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
@@ -168,17 +169,23 @@ func (s *environmentService) DeleteEnvironmentAsync(
 		}
 		defer func() { _ = projectInfra.Cleanup() }()
 
-		if err := c.provisionManager.Initialize(ctx, c.projectConfig.Path, projectInfra.Options); err != nil {
-			return false, fmt.Errorf("initializing provisioning manager: %w", err)
-		}
-
-		// Enable force and purge options
-		destroyOptions := provisioning.NewDestroyOptions(true, true)
-		_, err = c.provisionManager.Destroy(ctx, destroyOptions)
-		if errors.Is(err, infra.ErrDeploymentsNotFound) || errors.Is(err, infra.ErrDeploymentResourcesNotFound) {
-			_ = observer.OnNext(ctx, newInfoProgressMessage("No Azure resources were found"))
-		} else if err != nil {
-			return false, fmt.Errorf("deleting infrastructure: %w", err)
+		// Layers are deleted in the reverse order of provisioning
+		layers := projectInfra.Options.GetLayers()
+		slices.Reverse(layers)
+
+		for _, layer := range layers {
+			if err := c.provisionManager.Initialize(ctx, c.projectConfig.Path, layer); err != nil {
+				return false, fmt.Errorf("initializing provisioning manager: %w", err)
+			}
+
+			// Enable force and purge options
+			destroyOptions := provisioning.NewDestroyOptions(true, true)
+			_, err = c.provisionManager.Destroy(ctx, destroyOptions)
+			if errors.Is(err, infra.ErrDeploymentsNotFound) || errors.Is(err, infra.ErrDeploymentResourcesNotFound) {
+				_ = observer.OnNext(ctx, newInfoProgressMessage("No Azure resources were found"))
+			} else if err != nil {
+				return false, fmt.Errorf("deleting infrastructure: %w", err)
+			}
 		}
 	}
 
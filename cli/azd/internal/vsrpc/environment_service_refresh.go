@@ -86,7 +86,8 @@ func (s *environmentService) refreshEnvironmentAsync(
 	}
 	defer func() { _ = infra.Cleanup() }()
 
-	if err := bicepProvider.Initialize(ctx, c.projectConfig.Path, infra.Options); err != nil {
+	// Visual Studio tracks the deployment of the first layer of the infrastructure
+	if err := bicepProvider.Initialize(ctx, c.projectConfig.Path, infra.Options.GetLayers()[0]); err != nil {
 		return nil, fmt.Errorf("initializing provisioning manager: %w", err)
 	}
 
@@ -158,6 +158,11 @@ func main() {
 	}
 
 	if cmdErr != nil {
+		var errWithExitCode *internal.ErrorWithExitCode
+		if errors.As(cmdErr, &errWithExitCode) {
+			os.Exit(errWithExitCode.ExitCode)
+		}
+
 		os.Exit(1)
 	}
 }
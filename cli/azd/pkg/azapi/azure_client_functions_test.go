@@ -6,6 +6,7 @@ package azapi
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"net/http"
 	"strings"
 	"testing"
@@ -243,3 +244,168 @@ func registerPollingMocks(mockContext *mocks.MockContext, ran *bool) {
 		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, completeStatus)
 	})
 }
+
+func Test_DeployFunctionAppSlotUsingZipFile(t *testing.T) {
+	t.Run("RemoteBuild", func(t *testing.T) {
+		ran := false
+		mockContext := mocks.NewMockContext(context.Background())
+		azCli := newAzureClientFromMockContext(mockContext)
+
+		registerInfoMocks(mockContext, &ran)
+		registerFunctionAppSlotMocks(mockContext, map[string]*string{"FUNCTIONS_WORKER_RUNTIME": to.Ptr("python")})
+		appSettings := registerSlotAppSettingsUpdateMocks(mockContext)
+		registerSlotDeployMocks(mockContext)
+
+		res, err := azCli.DeployFunctionAppSlotUsingZipFile(
+			*mockContext.Context,
+			"SUBSCRIPTION_ID",
+			"RESOURCE_GROUP_ID",
+			"FUNC_APP_NAME",
+			"staging",
+			bytes.NewReader([]byte{}),
+			true,
+		)
+
+		require.NoError(t, err)
+		require.Equal(t, "OK", *res)
+		require.Equal(t, map[string]*string{
+			"FUNCTIONS_WORKER_RUNTIME":       to.Ptr("python"),
+			"SCM_DO_BUILD_DURING_DEPLOYMENT": to.Ptr("true"),
+			"ENABLE_ORYX_BUILD":              to.Ptr("true"),
+		}, appSettings.Properties)
+	})
+
+	t.Run("RemoteBuildEnabled", func(t *testing.T) {
+		ran := false
+		mockContext := mocks.NewMockContext(context.Background())
+		azCli := newAzureClientFromMockContext(mockContext)
+
+		registerInfoMocks(mockContext, &ran)
+		registerFunctionAppSlotMocks(mockContext, map[string]*string{
+			"SCM_DO_BUILD_DURING_DEPLOYMENT": to.Ptr("true"),
+			"ENABLE_ORYX_BUILD":              to.Ptr("true"),
+		})
+		appSettings := registerSlotAppSettingsUpdateMocks(mockContext)
+		registerSlotDeployMocks(mockContext)
+
+		_, err := azCli.DeployFunctionAppSlotUsingZipFile(
+			*mockContext.Context,
+			"SUBSCRIPTION_ID",
+			"RESOURCE_GROUP_ID",
+			"FUNC_APP_NAME",
+			"staging",
+			bytes.NewReader([]byte{}),
+			true,
+		)
+
+		require.NoError(t, err)
+		require.Nil(t, appSettings.Properties, "app settings must not be updated when remote build is enabled")
+	})
+
+	t.Run("FlexConsumption", func(t *testing.T) {
+		ran := false
+		mockContext := mocks.NewMockContext(context.Background())
+		azCli := newAzureClientFromMockContext(mockContext)
+
+		registerInfoMocks(mockContext, &ran)
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.Method == http.MethodGet &&
+				strings.Contains(request.URL.Path, "/providers/Microsoft.Web/serverfarms/FUNC_APP_PLAN_NAME")
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			return mocks.CreateHttpResponseWithBody(
+				request,
+				http.StatusOK,
+				armappservice.PlansClientGetResponse{
+					Plan: armappservice.Plan{
+						SKU: &armappservice.SKUDescription{
+							Name: to.Ptr("FC1"),
+							Tier: to.Ptr("FlexConsumption"),
+						},
+					},
+				})
+		})
+
+		res, err := azCli.DeployFunctionAppSlotUsingZipFile(
+			*mockContext.Context,
+			"SUBSCRIPTION_ID",
+			"RESOURCE_GROUP_ID",
+			"FUNC_APP_NAME",
+			"staging",
+			bytes.NewReader([]byte{}),
+			true,
+		)
+
+		require.Nil(t, res)
+		require.ErrorContains(t, err, "Flex Consumption plan, which doesn't support deployment slots")
+	})
+}
+
+func registerFunctionAppSlotMocks(mockContext *mocks.MockContext, appSettings map[string]*string) {
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodGet &&
+			strings.HasSuffix(request.URL.Path, "/providers/Microsoft.Web/sites/FUNC_APP_NAME/slots/staging")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		return mocks.CreateHttpResponseWithBody(
+			request,
+			http.StatusOK,
+			armappservice.WebAppsClientGetSlotResponse{
+				Site: armappservice.Site{
+					Kind: to.Ptr("functionapp,linux"),
+					Name: to.Ptr("FUNC_APP_NAME/staging"),
+					Properties: &armappservice.SiteProperties{
+						HostNameSSLStates: []*armappservice.HostNameSSLState{
+							{
+								HostType: to.Ptr(armappservice.HostTypeRepository),
+								Name:     to.Ptr("FUNC_APP_NAME-STAGING_SCM_HOST"),
+							},
+						},
+					},
+				},
+			})
+	})
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPost &&
+			strings.HasSuffix(request.URL.Path, "/sites/FUNC_APP_NAME/slots/staging/config/appsettings/list")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		return mocks.CreateHttpResponseWithBody(
+			request,
+			http.StatusOK,
+			armappservice.StringDictionary{Properties: appSettings},
+		)
+	})
+}
+
+// registerSlotAppSettingsUpdateMocks registers the mocks for updating the app settings of the staging slot and returns
+// the app settings sent by the update
+func registerSlotAppSettingsUpdateMocks(mockContext *mocks.MockContext) *armappservice.StringDictionary {
+	updated := &armappservice.StringDictionary{}
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPut &&
+			strings.HasSuffix(request.URL.Path, "/sites/FUNC_APP_NAME/slots/staging/config/appsettings")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		if err := json.NewDecoder(request.Body).Decode(updated); err != nil {
+			return nil, err
+		}
+
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, updated)
+	})
+
+	return updated
+}
+
+func registerSlotDeployMocks(mockContext *mocks.MockContext) {
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPost &&
+			request.URL.Host == "FUNC_APP_NAME-STAGING_SCM_HOST" &&
+			strings.Contains(request.URL.Path, "/api/zipdeploy")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		response, _ := mocks.CreateEmptyHttpResponse(request, http.StatusAccepted)
+		response.Header.Set("Location", "https://FUNC_APP_NAME-STAGING_SCM_HOST/deployments/latest")
+
+		return response, nil
+	})
+
+	ran := false
+	registerPollingMocks(mockContext, &ran)
+}
@@ -0,0 +1,270 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appservice/armappservice/v2"
+	"github.com/azure/azure-dev/cli/azd/pkg/azsdk"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DeployAppServiceSlotZip(t *testing.T) {
+	ran := false
+	mockContext := mocks.NewMockContext(context.Background())
+	azCli := newAzureClientFromMockContext(mockContext)
+
+	registerWebAppSlotMocks(mockContext, &ran)
+
+	zipDeployed := false
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPost &&
+			request.URL.Host == "WEB_APP_NAME-STAGING_SCM_HOST" &&
+			strings.Contains(request.URL.Path, "/api/zipdeploy")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		zipDeployed = true
+		response, _ := mocks.CreateEmptyHttpResponse(request, http.StatusAccepted)
+		response.Header.Set("Location", "https://WEB_APP_NAME-STAGING_SCM_HOST/deployments/latest")
+
+		return response, nil
+	})
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodGet &&
+			request.URL.Host == "WEB_APP_NAME-STAGING_SCM_HOST" &&
+			strings.Contains(request.URL.Path, "/deployments/latest")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, azsdk.DeployStatusResponse{
+			DeployStatus: azsdk.DeployStatus{
+				Id:         "ID",
+				Status:     http.StatusOK,
+				StatusText: "OK",
+				Message:    "Deployment Complete",
+				Complete:   true,
+				Active:     true,
+				SiteName:   "WEB_APP_NAME",
+			},
+		})
+	})
+
+	res, err := azCli.DeployAppServiceSlotZip(
+		*mockContext.Context,
+		"SUBSCRIPTION_ID",
+		"RESOURCE_GROUP_ID",
+		"WEB_APP_NAME",
+		"staging",
+		bytes.NewReader([]byte{}),
+		func(string) {},
+	)
+
+	require.NoError(t, err)
+	require.True(t, ran)
+	require.True(t, zipDeployed)
+	require.Equal(t, "OK", *res)
+}
+
+func Test_DeployAppServiceSlotZip_Linux(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	azCli := newAzureClientFromMockContext(mockContext)
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodGet &&
+			strings.HasSuffix(
+				request.URL.Path,
+				"/resourceGroups/RESOURCE_GROUP_ID/providers/Microsoft.Web/sites/WEB_APP_NAME/slots/staging",
+			)
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		response := armappservice.WebAppsClientGetSlotResponse{
+			Site: armappservice.Site{
+				Location: to.Ptr("eastus2"),
+				Kind:     to.Ptr("app,linux"),
+				Name:     to.Ptr("WEB_APP_NAME/staging"),
+				Properties: &armappservice.SiteProperties{
+					DefaultHostName: to.Ptr("WEB_APP_NAME-staging.azurewebsites.net"),
+					SiteConfig: &armappservice.SiteConfig{
+						LinuxFxVersion: to.Ptr("PYTHON|3.12"),
+					},
+					HostNameSSLStates: []*armappservice.HostNameSSLState{
+						{
+							HostType: to.Ptr(armappservice.HostTypeRepository),
+							Name:     to.Ptr("WEB_APP_NAME-STAGING_SCM_HOST"),
+						},
+					},
+				},
+			},
+		}
+
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, response)
+	})
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPost &&
+			request.URL.Host == "WEB_APP_NAME-STAGING_SCM_HOST" &&
+			strings.Contains(request.URL.Path, "/api/zipdeploy")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		response, _ := mocks.CreateEmptyHttpResponse(request, http.StatusAccepted)
+		response.Header.Set("Scm-Deployment-Id", "00000000-0000-0000-0000-000000000000")
+
+		return response, nil
+	})
+
+	statusTracked := false
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodGet &&
+			strings.HasSuffix(
+				request.URL.Path,
+				"/sites/WEB_APP_NAME/slots/staging/deploymentStatus/00000000-0000-0000-0000-000000000000",
+			)
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		statusTracked = true
+		return mocks.CreateHttpResponseWithBody(
+			request,
+			http.StatusOK,
+			armappservice.WebAppsClientGetSlotSiteDeploymentStatusSlotResponse{
+				CsmDeploymentStatus: armappservice.CsmDeploymentStatus{
+					Properties: &armappservice.CsmDeploymentStatusProperties{
+						Status:                      to.Ptr(armappservice.DeploymentBuildStatusRuntimeSuccessful),
+						NumberOfInstancesSuccessful: to.Ptr(int32(1)),
+						NumberOfInstancesFailed:     to.Ptr(int32(0)),
+						NumberOfInstancesInProgress: to.Ptr(int32(0)),
+					},
+				},
+			},
+		)
+	})
+
+	res, err := azCli.DeployAppServiceSlotZip(
+		*mockContext.Context,
+		"SUBSCRIPTION_ID",
+		"RESOURCE_GROUP_ID",
+		"WEB_APP_NAME",
+		"staging",
+		bytes.NewReader([]byte{}),
+		func(string) {},
+	)
+
+	require.NoError(t, err)
+	require.True(t, statusTracked)
+	require.Equal(t, "OK", *res)
+}
+
+func Test_GetAppServiceSlotProperties(t *testing.T) {
+	ran := false
+	mockContext := mocks.NewMockContext(context.Background())
+	azCli := newAzureClientFromMockContext(mockContext)
+
+	registerWebAppSlotMocks(mockContext, &ran)
+
+	props, err := azCli.GetAppServiceSlotProperties(
+		*mockContext.Context,
+		"SUBSCRIPTION_ID",
+		"RESOURCE_GROUP_ID",
+		"WEB_APP_NAME",
+		"staging",
+	)
+
+	require.NoError(t, err)
+	require.True(t, ran)
+	require.Equal(t, []string{"WEB_APP_NAME-staging.azurewebsites.net"}, props.HostNames)
+
+	t.Run("MissingHostName", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		azCli := newAzureClientFromMockContext(mockContext)
+
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.Method == http.MethodGet && strings.HasSuffix(request.URL.Path, "/slots/staging")
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			response := armappservice.WebAppsClientGetSlotResponse{
+				Site: armappservice.Site{Name: to.Ptr("WEB_APP_NAME/staging")},
+			}
+
+			return mocks.CreateHttpResponseWithBody(request, http.StatusOK, response)
+		})
+
+		_, err := azCli.GetAppServiceSlotProperties(
+			*mockContext.Context,
+			"SUBSCRIPTION_ID",
+			"RESOURCE_GROUP_ID",
+			"WEB_APP_NAME",
+			"staging",
+		)
+		require.ErrorContains(t, err, "default host name not found for slot 'staging' of app 'WEB_APP_NAME'")
+	})
+}
+
+func Test_SwapAppServiceSlot(t *testing.T) {
+	var swapRequest armappservice.CsmSlotEntity
+	mockContext := mocks.NewMockContext(context.Background())
+	azCli := newAzureClientFromMockContext(mockContext)
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPost &&
+			strings.HasSuffix(
+				request.URL.Path,
+				"/resourceGroups/RESOURCE_GROUP_ID/providers/Microsoft.Web/sites/WEB_APP_NAME/slotsswap",
+			)
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(request.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(body, &swapRequest); err != nil {
+			return nil, err
+		}
+
+		return mocks.CreateEmptyHttpResponse(request, http.StatusOK)
+	})
+
+	err := azCli.SwapAppServiceSlot(
+		*mockContext.Context,
+		"SUBSCRIPTION_ID",
+		"RESOURCE_GROUP_ID",
+		"WEB_APP_NAME",
+		"staging",
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, swapRequest.TargetSlot)
+	require.Equal(t, "staging", *swapRequest.TargetSlot)
+}
+
+func registerWebAppSlotMocks(mockContext *mocks.MockContext, ran *bool) {
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodGet &&
+			strings.HasSuffix(
+				request.URL.Path,
+				"/resourceGroups/RESOURCE_GROUP_ID/providers/Microsoft.Web/sites/WEB_APP_NAME/slots/staging",
+			)
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		*ran = true
+		response := armappservice.WebAppsClientGetSlotResponse{
+			Site: armappservice.Site{
+				Location: to.Ptr("eastus2"),
+				Kind:     to.Ptr("app"),
+				Name:     to.Ptr("WEB_APP_NAME/staging"),
+				Properties: &armappservice.SiteProperties{
+					DefaultHostName: to.Ptr("WEB_APP_NAME-staging.azurewebsites.net"),
+					HostNameSSLStates: []*armappservice.HostNameSSLState{
+						{
+							HostType: to.Ptr(armappservice.HostTypeRepository),
+							Name:     to.Ptr("WEB_APP_NAME-STAGING_SCM_HOST"),
+						},
+					},
+				},
+			},
+		}
+
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, response)
+	})
+}
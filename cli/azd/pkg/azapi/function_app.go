@@ -48,32 +48,17 @@ func (cli *AzureClient) DeployFunctionAppUsingZipFile(
 		return nil, err
 	}
 
-	hostName, err := appServiceRepositoryHost(app, appName)
+	hostName, err := appServiceRepositoryHost(&app.Site, appName)
 	if err != nil {
 		return nil, err
 	}
 
-	planId, err := arm.ParseResourceID(*app.Properties.ServerFarmID)
+	flexConsumption, err := cli.isFlexConsumptionApp(ctx, &app.Site)
 	if err != nil {
 		return nil, err
 	}
 
-	plansCred, err := cli.credentialProvider.CredentialForSubscription(ctx, planId.SubscriptionID)
-	if err != nil {
-		return nil, err
-	}
-
-	plansClient, err := armappservice.NewPlansClient(planId.SubscriptionID, plansCred, cli.armClientOptions)
-	if err != nil {
-		return nil, err
-	}
-
-	plan, err := plansClient.Get(ctx, planId.ResourceGroupName, planId.Name, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if strings.ToLower(*plan.SKU.Tier) == "flexconsumption" {
+	if flexConsumption {
 		cred, err := cli.credentialProvider.CredentialForSubscription(ctx, subscriptionId)
 		if err != nil {
 			return nil, err
@@ -103,3 +88,135 @@ func (cli *AzureClient) DeployFunctionAppUsingZipFile(
 
 	return to.Ptr(response.StatusText), nil
 }
+
+// DeployFunctionAppSlotUsingZipFile deploys the zip package to a deployment slot of a Function App. When remoteBuild is
+// set, the slot is configured to build the package during the deployment. Function Apps on a Flex Consumption plan
+// don't support deployment slots.
+func (cli *AzureClient) DeployFunctionAppSlotUsingZipFile(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroup string,
+	appName string,
+	slotName string,
+	deployZipFile io.ReadSeeker,
+	remoteBuild bool,
+) (*string, error) {
+	app, err := cli.appService(ctx, subscriptionId, resourceGroup, appName)
+	if err != nil {
+		return nil, err
+	}
+
+	flexConsumption, err := cli.isFlexConsumptionApp(ctx, &app.Site)
+	if err != nil {
+		return nil, err
+	}
+
+	if flexConsumption {
+		return nil, fmt.Errorf(
+			"function app '%s' runs on a Flex Consumption plan, which doesn't support deployment slots", appName)
+	}
+
+	slot, err := cli.appServiceSlot(ctx, subscriptionId, resourceGroup, appName, slotName)
+	if err != nil {
+		return nil, err
+	}
+
+	hostName, err := appServiceRepositoryHost(&slot.Site, fmt.Sprintf("%s/%s", appName, slotName))
+	if err != nil {
+		return nil, err
+	}
+
+	if remoteBuild {
+		if err := cli.enableSlotRemoteBuild(ctx, subscriptionId, resourceGroup, appName, slotName, &slot.Site); err != nil {
+			return nil, err
+		}
+	}
+
+	client, err := cli.createZipDeployClient(ctx, subscriptionId, hostName)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := client.Deploy(ctx, deployZipFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return to.Ptr(response.StatusText), nil
+}
+
+// isFlexConsumptionApp returns whether the Function App runs on a Flex Consumption plan
+func (cli *AzureClient) isFlexConsumptionApp(ctx context.Context, site *armappservice.Site) (bool, error) {
+	planId, err := arm.ParseResourceID(*site.Properties.ServerFarmID)
+	if err != nil {
+		return false, err
+	}
+
+	plansCred, err := cli.credentialProvider.CredentialForSubscription(ctx, planId.SubscriptionID)
+	if err != nil {
+		return false, err
+	}
+
+	plansClient, err := armappservice.NewPlansClient(planId.SubscriptionID, plansCred, cli.armClientOptions)
+	if err != nil {
+		return false, err
+	}
+
+	plan, err := plansClient.Get(ctx, planId.ResourceGroupName, planId.Name, nil)
+	if err != nil {
+		return false, err
+	}
+
+	return strings.ToLower(*plan.SKU.Tier) == "flexconsumption", nil
+}
+
+// enableSlotRemoteBuild sets the application settings that make zip deployments to the slot run a remote build, the
+// same settings the Azure Functions Core Tools set for remote builds on plans other than Flex Consumption.
+func (cli *AzureClient) enableSlotRemoteBuild(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroup string,
+	appName string,
+	slotName string,
+	slot *armappservice.Site,
+) error {
+	client, err := cli.createWebAppsClient(ctx, subscriptionId)
+	if err != nil {
+		return err
+	}
+
+	appSettings, err := client.ListApplicationSettingsSlot(ctx, resourceGroup, appName, slotName, nil)
+	if err != nil {
+		return fmt.Errorf("failed retrieving application settings of deployment slot '%s': %w", slotName, err)
+	}
+
+	remoteBuildSettings := map[string]string{
+		"SCM_DO_BUILD_DURING_DEPLOYMENT": "true",
+	}
+	if slot.Kind != nil && strings.Contains(strings.ToLower(*slot.Kind), "linux") {
+		remoteBuildSettings["ENABLE_ORYX_BUILD"] = "true"
+	}
+
+	if appSettings.Properties == nil {
+		appSettings.Properties = map[string]*string{}
+	}
+
+	updated := false
+	for name, value := range remoteBuildSettings {
+		if current, has := appSettings.Properties[name]; !has || current == nil || !strings.EqualFold(*current, value) {
+			appSettings.Properties[name] = to.Ptr(value)
+			updated = true
+		}
+	}
+
+	if !updated {
+		return nil
+	}
+
+	_, err = client.UpdateApplicationSettingsSlot(ctx, resourceGroup, appName, slotName, appSettings.StringDictionary, nil)
+	if err != nil {
+		return fmt.Errorf("enabling remote build for deployment slot '%s': %w", slotName, err)
+	}
+
+	return nil
+}
@@ -56,21 +56,21 @@ func (cli *AzureClient) appService(
 	return &webApp, nil
 }
 
-func isLinuxWebApp(response *armappservice.WebAppsClientGetResponse) bool {
-	if *response.Kind == "app,linux" && response.Properties != nil && response.Properties.SiteConfig != nil &&
-		response.Properties.SiteConfig.LinuxFxVersion != nil &&
-		*response.Properties.SiteConfig.LinuxFxVersion != "" {
+func isLinuxWebApp(site *armappservice.Site) bool {
+	if site.Kind != nil && *site.Kind == "app,linux" && site.Properties != nil && site.Properties.SiteConfig != nil &&
+		site.Properties.SiteConfig.LinuxFxVersion != nil &&
+		*site.Properties.SiteConfig.LinuxFxVersion != "" {
 		return true
 	}
 	return false
 }
 
 func appServiceRepositoryHost(
-	response *armappservice.WebAppsClientGetResponse,
+	site *armappservice.Site,
 	appName string,
 ) (string, error) {
 	hostName := ""
-	for _, item := range response.Properties.HostNameSSLStates {
+	for _, item := range site.Properties.HostNameSSLStates {
 		if *item.HostType == armappservice.HostTypeRepository {
 			hostName = *item.Name
 			break
@@ -138,7 +138,7 @@ func (cli *AzureClient) DeployAppServiceZip(
 		return nil, err
 	}
 
-	hostName, err := appServiceRepositoryHost(app, appName)
+	hostName, err := appServiceRepositoryHost(&app.Site, appName)
 	if err != nil {
 		return nil, err
 	}
@@ -148,10 +148,26 @@ func (cli *AzureClient) DeployAppServiceZip(
 		return nil, err
 	}
 
+	return deployZipTrackStatus(ctx, client, &app.Site, deployZipFile, progressLog,
+		func(deployZipFile io.ReadSeeker) error {
+			return client.DeployTrackStatus(
+				ctx, deployZipFile, subscriptionId, resourceGroup, appName, progressLog)
+		})
+}
+
+// deployZipTrackStatus deploys the zip package to the site, tracking the status of the deployment with trackStatus when
+// supported by the site
+func deployZipTrackStatus(
+	ctx context.Context,
+	client *azsdk.ZipDeployClient,
+	site *armappservice.Site,
+	deployZipFile io.ReadSeeker,
+	progressLog func(string),
+	trackStatus func(deployZipFile io.ReadSeeker) error,
+) (*string, error) {
 	// Deployment Status API only support linux web app for now
-	if isLinuxWebApp(app) {
-		if err := client.DeployTrackStatus(
-			ctx, deployZipFile, subscriptionId, resourceGroup, appName, progressLog); err != nil {
+	if isLinuxWebApp(site) {
+		if err := trackStatus(deployZipFile); err != nil {
 			if !resumeDeployment(err, progressLog) {
 				return nil, err
 			}
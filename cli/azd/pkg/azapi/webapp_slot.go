@@ -0,0 +1,115 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appservice/armappservice/v2"
+)
+
+// GetAppServiceSlotProperties gets the properties of a deployment slot of an App Service or Function App
+func (cli *AzureClient) GetAppServiceSlotProperties(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroup string,
+	appName string,
+	slotName string,
+) (*AzCliAppServiceProperties, error) {
+	slot, err := cli.appServiceSlot(ctx, subscriptionId, resourceGroup, appName, slotName)
+	if err != nil {
+		return nil, err
+	}
+
+	if slot.Properties == nil || slot.Properties.DefaultHostName == nil {
+		return nil, fmt.Errorf("default host name not found for slot '%s' of app '%s'", slotName, appName)
+	}
+
+	return &AzCliAppServiceProperties{
+		HostNames: []string{*slot.Properties.DefaultHostName},
+	}, nil
+}
+
+// DeployAppServiceSlotZip deploys the zip package to a deployment slot of an App Service or Function App
+func (cli *AzureClient) DeployAppServiceSlotZip(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroup string,
+	appName string,
+	slotName string,
+	deployZipFile io.ReadSeeker,
+	progressLog func(string),
+) (*string, error) {
+	slot, err := cli.appServiceSlot(ctx, subscriptionId, resourceGroup, appName, slotName)
+	if err != nil {
+		return nil, err
+	}
+
+	hostName, err := appServiceRepositoryHost(&slot.Site, fmt.Sprintf("%s/%s", appName, slotName))
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := cli.createZipDeployClient(ctx, subscriptionId, hostName)
+	if err != nil {
+		return nil, err
+	}
+
+	return deployZipTrackStatus(ctx, client, &slot.Site, deployZipFile, progressLog,
+		func(deployZipFile io.ReadSeeker) error {
+			return client.DeployTrackSlotStatus(
+				ctx, deployZipFile, subscriptionId, resourceGroup, appName, slotName, progressLog)
+		})
+}
+
+// SwapAppServiceSlot swaps the specified deployment slot with the production slot and waits for the swap to complete
+func (cli *AzureClient) SwapAppServiceSlot(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroup string,
+	appName string,
+	slotName string,
+) error {
+	client, err := cli.createWebAppsClient(ctx, subscriptionId)
+	if err != nil {
+		return err
+	}
+
+	poller, err := client.BeginSwapSlotWithProduction(ctx, resourceGroup, appName, armappservice.CsmSlotEntity{
+		TargetSlot:   to.Ptr(slotName),
+		PreserveVnet: to.Ptr(true),
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("starting swap of slot '%s' with production: %w", slotName, err)
+	}
+
+	if _, err := poller.PollUntilDone(ctx, nil); err != nil {
+		return fmt.Errorf("swapping slot '%s' with production: %w", slotName, err)
+	}
+
+	return nil
+}
+
+func (cli *AzureClient) appServiceSlot(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroup string,
+	appName string,
+	slotName string,
+) (*armappservice.WebAppsClientGetSlotResponse, error) {
+	client, err := cli.createWebAppsClient(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	slot, err := client.GetSlot(ctx, resourceGroup, appName, slotName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed retrieving deployment slot '%s' properties: %w", slotName, err)
+	}
+
+	return &slot, nil
+}
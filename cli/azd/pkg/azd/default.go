@@ -9,10 +9,12 @@ import (
 	"strings"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/azsdk/storage"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/cosmosdb"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
 	infraBicep "github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning/bicep"
+	infraPulumi "github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning/pulumi"
 	infraTerraform "github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning/terraform"
 	"github.com/azure/azure-dev/cli/azd/pkg/ioc"
 	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
@@ -22,6 +24,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/state"
 	"github.com/azure/azure-dev/cli/azd/pkg/templates"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/bicep"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/pulumi"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/terraform"
 )
 
@@ -50,6 +53,7 @@ func (p *DefaultPlatform) IsEnabled() bool {
 func (p *DefaultPlatform) ConfigureContainer(container *ioc.NestedContainer) error {
 	// Tools
 	container.MustRegisterSingleton(terraform.NewCli)
+	container.MustRegisterSingleton(pulumi.NewCli)
 	container.MustRegisterSingleton(bicep.NewCli)
 
 	container.MustRegisterTransient(func() *lazy.Lazy[*infraBicep.BicepProvider] {
@@ -80,6 +84,7 @@ func (p *DefaultPlatform) ConfigureContainer(container *ioc.NestedContainer) err
 	provisionProviderMap := map[provisioning.ProviderKind]any{
 		provisioning.Bicep:     infraBicep.NewBicepProvider,
 		provisioning.Terraform: infraTerraform.NewTerraformProvider,
+		provisioning.Pulumi:    infraPulumi.NewPulumiProvider,
 	}
 
 	for provider, constructor := range provisionProviderMap {
@@ -96,10 +101,12 @@ func (p *DefaultPlatform) ConfigureContainer(container *ioc.NestedContainer) err
 	// Remote Environment State Providers
 	remoteStateProviderMap := map[environment.RemoteKind]any{
 		environment.RemoteKindAzureBlobStorage: environment.NewStorageBlobDataStore,
+		environment.RemoteKindAzureFiles:       environment.NewStorageFileShareDataStore,
+		environment.RemoteKindLocalDirectory:   environment.NewDirectoryDataStore,
 	}
 
 	for remoteKind, constructor := range remoteStateProviderMap {
-		container.MustRegisterNamedScoped(string(remoteKind), constructor)
+		environment.MustRegisterRemoteDataStore(container, remoteKind, constructor)
 	}
 
 	container.MustRegisterSingleton(func(
@@ -111,13 +118,8 @@ func (p *DefaultPlatform) ConfigureContainer(container *ioc.NestedContainer) err
 		}
 
 		var storageAccountConfig *storage.AccountConfig
-		jsonBytes, err := json.Marshal(remoteStateConfig.Config)
-		if err != nil {
-			return nil, fmt.Errorf("marshalling remote state config: %w", err)
-		}
-
-		if err := json.Unmarshal(jsonBytes, &storageAccountConfig); err != nil {
-			return nil, fmt.Errorf("unmarshalling remote state config: %w", err)
+		if err := decodeRemoteStateConfig(remoteStateConfig, &storageAccountConfig); err != nil {
+			return nil, err
 		}
 
 		// If a container name has not been explicitly configured
@@ -131,6 +133,45 @@ func (p *DefaultPlatform) ConfigureContainer(container *ioc.NestedContainer) err
 		return storageAccountConfig, nil
 	})
 
+	container.MustRegisterSingleton(func(
+		remoteStateConfig *state.RemoteConfig,
+		projectConfig *project.ProjectConfig,
+		cloud *cloud.Cloud,
+	) (*storage.FileShareConfig, error) {
+		if remoteStateConfig == nil {
+			return nil, nil
+		}
+
+		fileShareConfig := &storage.FileShareConfig{}
+		if err := decodeRemoteStateConfig(remoteStateConfig, fileShareConfig); err != nil {
+			return nil, err
+		}
+
+		if fileShareConfig.Endpoint == "" {
+			fileShareConfig.Endpoint = cloud.StorageEndpointSuffix
+		}
+
+		// Azure file shares must be lowercase, default to use the project name as the share name
+		if fileShareConfig.ShareName == "" {
+			fileShareConfig.ShareName = strings.ToLower(projectConfig.Name)
+		}
+
+		return fileShareConfig, nil
+	})
+
+	container.MustRegisterSingleton(func(remoteStateConfig *state.RemoteConfig) (*environment.DirectoryConfig, error) {
+		if remoteStateConfig == nil {
+			return nil, nil
+		}
+
+		directoryConfig := &environment.DirectoryConfig{}
+		if err := decodeRemoteStateConfig(remoteStateConfig, directoryConfig); err != nil {
+			return nil, err
+		}
+
+		return directoryConfig, nil
+	})
+
 	// Storage components
 	container.MustRegisterSingleton(storage.NewBlobClient)
 	container.MustRegisterSingleton(storage.NewBlobSdkClient)
@@ -153,3 +194,17 @@ func (p *DefaultPlatform) ConfigureContainer(container *ioc.NestedContainer) err
 
 	return nil
 }
+
+// decodeRemoteStateConfig decodes the backend specific configuration of the remote state into the target value
+func decodeRemoteStateConfig(remoteStateConfig *state.RemoteConfig, target any) error {
+	jsonBytes, err := json.Marshal(remoteStateConfig.Config)
+	if err != nil {
+		return fmt.Errorf("marshalling remote state config: %w", err)
+	}
+
+	if err := json.Unmarshal(jsonBytes, target); err != nil {
+		return fmt.Errorf("unmarshalling remote state config: %w", err)
+	}
+
+	return nil
+}
@@ -17,13 +17,18 @@ type AzdClientOption func(*AzdClient) error
 
 // AzdClient is the client for the `azd` gRPC server.
 type AzdClient struct {
-	connection        *grpc.ClientConn
-	projectClient     ProjectServiceClient
-	environmentClient EnvironmentServiceClient
-	userConfigClient  UserConfigServiceClient
-	promptClient      PromptServiceClient
-	deploymentClient  DeploymentServiceClient
-	eventsClient      EventServiceClient
+	connection            *grpc.ClientConn
+	projectClient         ProjectServiceClient
+	environmentClient     EnvironmentServiceClient
+	userConfigClient      UserConfigServiceClient
+	promptClient          PromptServiceClient
+	deploymentClient      DeploymentServiceClient
+	eventsClient          EventServiceClient
+	serviceTargetClient   ServiceTargetServiceClient
+	frameworkClient       FrameworkServiceClient
+	provisioningClient    ProvisioningServiceClient
+	operationsClient      OperationsServiceClient
+	remoteDataStoreClient RemoteDataStoreServiceClient
 }
 
 // WithAddress sets the address of the `azd` gRPC server.
@@ -125,3 +130,48 @@ func (c *AzdClient) Events() EventServiceClient {
 
 	return c.eventsClient
 }
+
+// ServiceTarget returns the service target service client.
+func (c *AzdClient) ServiceTarget() ServiceTargetServiceClient {
+	if c.serviceTargetClient == nil {
+		c.serviceTargetClient = NewServiceTargetServiceClient(c.connection)
+	}
+
+	return c.serviceTargetClient
+}
+
+// FrameworkService returns the framework service client.
+func (c *AzdClient) FrameworkService() FrameworkServiceClient {
+	if c.frameworkClient == nil {
+		c.frameworkClient = NewFrameworkServiceClient(c.connection)
+	}
+
+	return c.frameworkClient
+}
+
+// Provisioning returns the provisioning service client.
+func (c *AzdClient) Provisioning() ProvisioningServiceClient {
+	if c.provisioningClient == nil {
+		c.provisioningClient = NewProvisioningServiceClient(c.connection)
+	}
+
+	return c.provisioningClient
+}
+
+// Operations returns the operations service client.
+func (c *AzdClient) Operations() OperationsServiceClient {
+	if c.operationsClient == nil {
+		c.operationsClient = NewOperationsServiceClient(c.connection)
+	}
+
+	return c.operationsClient
+}
+
+// RemoteDataStore returns the remote data store service client.
+func (c *AzdClient) RemoteDataStore() RemoteDataStoreServiceClient {
+	if c.remoteDataStoreClient == nil {
+		c.remoteDataStoreClient = NewRemoteDataStoreServiceClient(c.connection)
+	}
+
+	return c.remoteDataStoreClient
+}
@@ -0,0 +1,1281 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.29.1
+// source: framework_service.proto
+
+package azdext
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Represents the different types of messages sent over the stream
+type FrameworkServiceMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Correlates the requests sent by azd with the responses sent by the extension.
+	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// Set by the extension when the request failed.
+	Error *FrameworkServiceErrorMessage `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// Types that are assignable to MessageType:
+	//
+	//	*FrameworkServiceMessage_RegisterFrameworkServiceRequest
+	//	*FrameworkServiceMessage_RegisterFrameworkServiceResponse
+	//	*FrameworkServiceMessage_InitializeRequest
+	//	*FrameworkServiceMessage_InitializeResponse
+	//	*FrameworkServiceMessage_RestoreRequest
+	//	*FrameworkServiceMessage_RestoreResponse
+	//	*FrameworkServiceMessage_BuildRequest
+	//	*FrameworkServiceMessage_BuildResponse
+	//	*FrameworkServiceMessage_PackageRequest
+	//	*FrameworkServiceMessage_PackageResponse
+	//	*FrameworkServiceMessage_ProgressMessage
+	//	*FrameworkServiceMessage_ExtensionReadyEvent
+	MessageType isFrameworkServiceMessage_MessageType `protobuf_oneof:"message_type"`
+}
+
+func (x *FrameworkServiceMessage) Reset() {
+	*x = FrameworkServiceMessage{}
+	mi := &file_framework_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameworkServiceMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameworkServiceMessage) ProtoMessage() {}
+
+func (x *FrameworkServiceMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameworkServiceMessage.ProtoReflect.Descriptor instead.
+func (*FrameworkServiceMessage) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FrameworkServiceMessage) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *FrameworkServiceMessage) GetError() *FrameworkServiceErrorMessage {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+func (m *FrameworkServiceMessage) GetMessageType() isFrameworkServiceMessage_MessageType {
+	if m != nil {
+		return m.MessageType
+	}
+	return nil
+}
+
+func (x *FrameworkServiceMessage) GetRegisterFrameworkServiceRequest() *RegisterFrameworkServiceRequest {
+	if x, ok := x.GetMessageType().(*FrameworkServiceMessage_RegisterFrameworkServiceRequest); ok {
+		return x.RegisterFrameworkServiceRequest
+	}
+	return nil
+}
+
+func (x *FrameworkServiceMessage) GetRegisterFrameworkServiceResponse() *RegisterFrameworkServiceResponse {
+	if x, ok := x.GetMessageType().(*FrameworkServiceMessage_RegisterFrameworkServiceResponse); ok {
+		return x.RegisterFrameworkServiceResponse
+	}
+	return nil
+}
+
+func (x *FrameworkServiceMessage) GetInitializeRequest() *FrameworkServiceInitializeRequest {
+	if x, ok := x.GetMessageType().(*FrameworkServiceMessage_InitializeRequest); ok {
+		return x.InitializeRequest
+	}
+	return nil
+}
+
+func (x *FrameworkServiceMessage) GetInitializeResponse() *FrameworkServiceInitializeResponse {
+	if x, ok := x.GetMessageType().(*FrameworkServiceMessage_InitializeResponse); ok {
+		return x.InitializeResponse
+	}
+	return nil
+}
+
+func (x *FrameworkServiceMessage) GetRestoreRequest() *FrameworkServiceRestoreRequest {
+	if x, ok := x.GetMessageType().(*FrameworkServiceMessage_RestoreRequest); ok {
+		return x.RestoreRequest
+	}
+	return nil
+}
+
+func (x *FrameworkServiceMessage) GetRestoreResponse() *FrameworkServiceRestoreResponse {
+	if x, ok := x.GetMessageType().(*FrameworkServiceMessage_RestoreResponse); ok {
+		return x.RestoreResponse
+	}
+	return nil
+}
+
+func (x *FrameworkServiceMessage) GetBuildRequest() *FrameworkServiceBuildRequest {
+	if x, ok := x.GetMessageType().(*FrameworkServiceMessage_BuildRequest); ok {
+		return x.BuildRequest
+	}
+	return nil
+}
+
+func (x *FrameworkServiceMessage) GetBuildResponse() *FrameworkServiceBuildResponse {
+	if x, ok := x.GetMessageType().(*FrameworkServiceMessage_BuildResponse); ok {
+		return x.BuildResponse
+	}
+	return nil
+}
+
+func (x *FrameworkServiceMessage) GetPackageRequest() *FrameworkServicePackageRequest {
+	if x, ok := x.GetMessageType().(*FrameworkServiceMessage_PackageRequest); ok {
+		return x.PackageRequest
+	}
+	return nil
+}
+
+func (x *FrameworkServiceMessage) GetPackageResponse() *FrameworkServicePackageResponse {
+	if x, ok := x.GetMessageType().(*FrameworkServiceMessage_PackageResponse); ok {
+		return x.PackageResponse
+	}
+	return nil
+}
+
+func (x *FrameworkServiceMessage) GetProgressMessage() *FrameworkServiceProgressMessage {
+	if x, ok := x.GetMessageType().(*FrameworkServiceMessage_ProgressMessage); ok {
+		return x.ProgressMessage
+	}
+	return nil
+}
+
+func (x *FrameworkServiceMessage) GetExtensionReadyEvent() *ExtensionReadyEvent {
+	if x, ok := x.GetMessageType().(*FrameworkServiceMessage_ExtensionReadyEvent); ok {
+		return x.ExtensionReadyEvent
+	}
+	return nil
+}
+
+type isFrameworkServiceMessage_MessageType interface {
+	isFrameworkServiceMessage_MessageType()
+}
+
+type FrameworkServiceMessage_RegisterFrameworkServiceRequest struct {
+	RegisterFrameworkServiceRequest *RegisterFrameworkServiceRequest `protobuf:"bytes,3,opt,name=register_framework_service_request,json=registerFrameworkServiceRequest,proto3,oneof"`
+}
+
+type FrameworkServiceMessage_RegisterFrameworkServiceResponse struct {
+	RegisterFrameworkServiceResponse *RegisterFrameworkServiceResponse `protobuf:"bytes,4,opt,name=register_framework_service_response,json=registerFrameworkServiceResponse,proto3,oneof"`
+}
+
+type FrameworkServiceMessage_InitializeRequest struct {
+	InitializeRequest *FrameworkServiceInitializeRequest `protobuf:"bytes,5,opt,name=initialize_request,json=initializeRequest,proto3,oneof"`
+}
+
+type FrameworkServiceMessage_InitializeResponse struct {
+	InitializeResponse *FrameworkServiceInitializeResponse `protobuf:"bytes,6,opt,name=initialize_response,json=initializeResponse,proto3,oneof"`
+}
+
+type FrameworkServiceMessage_RestoreRequest struct {
+	RestoreRequest *FrameworkServiceRestoreRequest `protobuf:"bytes,7,opt,name=restore_request,json=restoreRequest,proto3,oneof"`
+}
+
+type FrameworkServiceMessage_RestoreResponse struct {
+	RestoreResponse *FrameworkServiceRestoreResponse `protobuf:"bytes,8,opt,name=restore_response,json=restoreResponse,proto3,oneof"`
+}
+
+type FrameworkServiceMessage_BuildRequest struct {
+	BuildRequest *FrameworkServiceBuildRequest `protobuf:"bytes,9,opt,name=build_request,json=buildRequest,proto3,oneof"`
+}
+
+type FrameworkServiceMessage_BuildResponse struct {
+	BuildResponse *FrameworkServiceBuildResponse `protobuf:"bytes,10,opt,name=build_response,json=buildResponse,proto3,oneof"`
+}
+
+type FrameworkServiceMessage_PackageRequest struct {
+	PackageRequest *FrameworkServicePackageRequest `protobuf:"bytes,11,opt,name=package_request,json=packageRequest,proto3,oneof"`
+}
+
+type FrameworkServiceMessage_PackageResponse struct {
+	PackageResponse *FrameworkServicePackageResponse `protobuf:"bytes,12,opt,name=package_response,json=packageResponse,proto3,oneof"`
+}
+
+type FrameworkServiceMessage_ProgressMessage struct {
+	ProgressMessage *FrameworkServiceProgressMessage `protobuf:"bytes,13,opt,name=progress_message,json=progressMessage,proto3,oneof"`
+}
+
+type FrameworkServiceMessage_ExtensionReadyEvent struct {
+	ExtensionReadyEvent *ExtensionReadyEvent `protobuf:"bytes,14,opt,name=extension_ready_event,json=extensionReadyEvent,proto3,oneof"`
+}
+
+func (*FrameworkServiceMessage_RegisterFrameworkServiceRequest) isFrameworkServiceMessage_MessageType() {
+}
+
+func (*FrameworkServiceMessage_RegisterFrameworkServiceResponse) isFrameworkServiceMessage_MessageType() {
+}
+
+func (*FrameworkServiceMessage_InitializeRequest) isFrameworkServiceMessage_MessageType() {}
+
+func (*FrameworkServiceMessage_InitializeResponse) isFrameworkServiceMessage_MessageType() {}
+
+func (*FrameworkServiceMessage_RestoreRequest) isFrameworkServiceMessage_MessageType() {}
+
+func (*FrameworkServiceMessage_RestoreResponse) isFrameworkServiceMessage_MessageType() {}
+
+func (*FrameworkServiceMessage_BuildRequest) isFrameworkServiceMessage_MessageType() {}
+
+func (*FrameworkServiceMessage_BuildResponse) isFrameworkServiceMessage_MessageType() {}
+
+func (*FrameworkServiceMessage_PackageRequest) isFrameworkServiceMessage_MessageType() {}
+
+func (*FrameworkServiceMessage_PackageResponse) isFrameworkServiceMessage_MessageType() {}
+
+func (*FrameworkServiceMessage_ProgressMessage) isFrameworkServiceMessage_MessageType() {}
+
+func (*FrameworkServiceMessage_ExtensionReadyEvent) isFrameworkServiceMessage_MessageType() {}
+
+// Error returned by the extension for a failed request
+type FrameworkServiceErrorMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *FrameworkServiceErrorMessage) Reset() {
+	*x = FrameworkServiceErrorMessage{}
+	mi := &file_framework_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameworkServiceErrorMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameworkServiceErrorMessage) ProtoMessage() {}
+
+func (x *FrameworkServiceErrorMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameworkServiceErrorMessage.ProtoReflect.Descriptor instead.
+func (*FrameworkServiceErrorMessage) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FrameworkServiceErrorMessage) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Client registers a framework service for a service language, ex) language: go
+type RegisterFrameworkServiceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Language string `protobuf:"bytes,1,opt,name=language,proto3" json:"language,omitempty"`
+	// The lifecycle commands the framework service requires before packaging.
+	Requirements *FrameworkRequirements `protobuf:"bytes,2,opt,name=requirements,proto3" json:"requirements,omitempty"`
+}
+
+func (x *RegisterFrameworkServiceRequest) Reset() {
+	*x = RegisterFrameworkServiceRequest{}
+	mi := &file_framework_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterFrameworkServiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterFrameworkServiceRequest) ProtoMessage() {}
+
+func (x *RegisterFrameworkServiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterFrameworkServiceRequest.ProtoReflect.Descriptor instead.
+func (*RegisterFrameworkServiceRequest) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RegisterFrameworkServiceRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *RegisterFrameworkServiceRequest) GetRequirements() *FrameworkRequirements {
+	if x != nil {
+		return x.Requirements
+	}
+	return nil
+}
+
+// Server confirms the registration of a framework service
+type RegisterFrameworkServiceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RegisterFrameworkServiceResponse) Reset() {
+	*x = RegisterFrameworkServiceResponse{}
+	mi := &file_framework_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterFrameworkServiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterFrameworkServiceResponse) ProtoMessage() {}
+
+func (x *RegisterFrameworkServiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterFrameworkServiceResponse.ProtoReflect.Descriptor instead.
+func (*RegisterFrameworkServiceResponse) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{3}
+}
+
+// Describes whether restore and build must run before the framework service packages a service
+type FrameworkRequirements struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RequireRestore bool `protobuf:"varint,1,opt,name=require_restore,json=requireRestore,proto3" json:"require_restore,omitempty"`
+	RequireBuild   bool `protobuf:"varint,2,opt,name=require_build,json=requireBuild,proto3" json:"require_build,omitempty"`
+}
+
+func (x *FrameworkRequirements) Reset() {
+	*x = FrameworkRequirements{}
+	mi := &file_framework_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameworkRequirements) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameworkRequirements) ProtoMessage() {}
+
+func (x *FrameworkRequirements) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameworkRequirements.ProtoReflect.Descriptor instead.
+func (*FrameworkRequirements) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FrameworkRequirements) GetRequireRestore() bool {
+	if x != nil {
+		return x.RequireRestore
+	}
+	return false
+}
+
+func (x *FrameworkRequirements) GetRequireBuild() bool {
+	if x != nil {
+		return x.RequireBuild
+	}
+	return false
+}
+
+// Server requests the framework service to initialize for a service
+type FrameworkServiceInitializeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServiceConfig *ServiceConfig `protobuf:"bytes,1,opt,name=service_config,json=serviceConfig,proto3" json:"service_config,omitempty"`
+}
+
+func (x *FrameworkServiceInitializeRequest) Reset() {
+	*x = FrameworkServiceInitializeRequest{}
+	mi := &file_framework_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameworkServiceInitializeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameworkServiceInitializeRequest) ProtoMessage() {}
+
+func (x *FrameworkServiceInitializeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameworkServiceInitializeRequest.ProtoReflect.Descriptor instead.
+func (*FrameworkServiceInitializeRequest) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FrameworkServiceInitializeRequest) GetServiceConfig() *ServiceConfig {
+	if x != nil {
+		return x.ServiceConfig
+	}
+	return nil
+}
+
+type FrameworkServiceInitializeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *FrameworkServiceInitializeResponse) Reset() {
+	*x = FrameworkServiceInitializeResponse{}
+	mi := &file_framework_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameworkServiceInitializeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameworkServiceInitializeResponse) ProtoMessage() {}
+
+func (x *FrameworkServiceInitializeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameworkServiceInitializeResponse.ProtoReflect.Descriptor instead.
+func (*FrameworkServiceInitializeResponse) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{6}
+}
+
+// Server requests the framework service to restore the dependencies of a service
+type FrameworkServiceRestoreRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServiceConfig *ServiceConfig `protobuf:"bytes,1,opt,name=service_config,json=serviceConfig,proto3" json:"service_config,omitempty"`
+}
+
+func (x *FrameworkServiceRestoreRequest) Reset() {
+	*x = FrameworkServiceRestoreRequest{}
+	mi := &file_framework_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameworkServiceRestoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameworkServiceRestoreRequest) ProtoMessage() {}
+
+func (x *FrameworkServiceRestoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameworkServiceRestoreRequest.ProtoReflect.Descriptor instead.
+func (*FrameworkServiceRestoreRequest) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FrameworkServiceRestoreRequest) GetServiceConfig() *ServiceConfig {
+	if x != nil {
+		return x.ServiceConfig
+	}
+	return nil
+}
+
+type FrameworkServiceRestoreResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result *ServiceRestoreResult `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *FrameworkServiceRestoreResponse) Reset() {
+	*x = FrameworkServiceRestoreResponse{}
+	mi := &file_framework_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameworkServiceRestoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameworkServiceRestoreResponse) ProtoMessage() {}
+
+func (x *FrameworkServiceRestoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameworkServiceRestoreResponse.ProtoReflect.Descriptor instead.
+func (*FrameworkServiceRestoreResponse) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FrameworkServiceRestoreResponse) GetResult() *ServiceRestoreResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+// Server requests the framework service to build the source of a service
+type FrameworkServiceBuildRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServiceConfig *ServiceConfig        `protobuf:"bytes,1,opt,name=service_config,json=serviceConfig,proto3" json:"service_config,omitempty"`
+	RestoreOutput *ServiceRestoreResult `protobuf:"bytes,2,opt,name=restore_output,json=restoreOutput,proto3" json:"restore_output,omitempty"`
+}
+
+func (x *FrameworkServiceBuildRequest) Reset() {
+	*x = FrameworkServiceBuildRequest{}
+	mi := &file_framework_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameworkServiceBuildRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameworkServiceBuildRequest) ProtoMessage() {}
+
+func (x *FrameworkServiceBuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameworkServiceBuildRequest.ProtoReflect.Descriptor instead.
+func (*FrameworkServiceBuildRequest) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FrameworkServiceBuildRequest) GetServiceConfig() *ServiceConfig {
+	if x != nil {
+		return x.ServiceConfig
+	}
+	return nil
+}
+
+func (x *FrameworkServiceBuildRequest) GetRestoreOutput() *ServiceRestoreResult {
+	if x != nil {
+		return x.RestoreOutput
+	}
+	return nil
+}
+
+type FrameworkServiceBuildResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result *ServiceBuildResult `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *FrameworkServiceBuildResponse) Reset() {
+	*x = FrameworkServiceBuildResponse{}
+	mi := &file_framework_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameworkServiceBuildResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameworkServiceBuildResponse) ProtoMessage() {}
+
+func (x *FrameworkServiceBuildResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameworkServiceBuildResponse.ProtoReflect.Descriptor instead.
+func (*FrameworkServiceBuildResponse) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FrameworkServiceBuildResponse) GetResult() *ServiceBuildResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+// Server requests the framework service to package the build output of a service
+type FrameworkServicePackageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServiceConfig *ServiceConfig      `protobuf:"bytes,1,opt,name=service_config,json=serviceConfig,proto3" json:"service_config,omitempty"`
+	BuildOutput   *ServiceBuildResult `protobuf:"bytes,2,opt,name=build_output,json=buildOutput,proto3" json:"build_output,omitempty"`
+}
+
+func (x *FrameworkServicePackageRequest) Reset() {
+	*x = FrameworkServicePackageRequest{}
+	mi := &file_framework_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameworkServicePackageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameworkServicePackageRequest) ProtoMessage() {}
+
+func (x *FrameworkServicePackageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameworkServicePackageRequest.ProtoReflect.Descriptor instead.
+func (*FrameworkServicePackageRequest) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *FrameworkServicePackageRequest) GetServiceConfig() *ServiceConfig {
+	if x != nil {
+		return x.ServiceConfig
+	}
+	return nil
+}
+
+func (x *FrameworkServicePackageRequest) GetBuildOutput() *ServiceBuildResult {
+	if x != nil {
+		return x.BuildOutput
+	}
+	return nil
+}
+
+type FrameworkServicePackageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result *ServicePackageResult `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *FrameworkServicePackageResponse) Reset() {
+	*x = FrameworkServicePackageResponse{}
+	mi := &file_framework_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameworkServicePackageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameworkServicePackageResponse) ProtoMessage() {}
+
+func (x *FrameworkServicePackageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameworkServicePackageResponse.ProtoReflect.Descriptor instead.
+func (*FrameworkServicePackageResponse) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *FrameworkServicePackageResponse) GetResult() *ServicePackageResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+// Client reports the progress of a restore, build or package request
+type FrameworkServiceProgressMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *FrameworkServiceProgressMessage) Reset() {
+	*x = FrameworkServiceProgressMessage{}
+	mi := &file_framework_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameworkServiceProgressMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameworkServiceProgressMessage) ProtoMessage() {}
+
+func (x *FrameworkServiceProgressMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameworkServiceProgressMessage.ProtoReflect.Descriptor instead.
+func (*FrameworkServiceProgressMessage) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *FrameworkServiceProgressMessage) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// The result of restoring the dependencies of a service
+type ServiceRestoreResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Details map[string]string `protobuf:"bytes,1,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ServiceRestoreResult) Reset() {
+	*x = ServiceRestoreResult{}
+	mi := &file_framework_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceRestoreResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceRestoreResult) ProtoMessage() {}
+
+func (x *ServiceRestoreResult) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceRestoreResult.ProtoReflect.Descriptor instead.
+func (*ServiceRestoreResult) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ServiceRestoreResult) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+// The result of building a service
+type ServiceBuildResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BuildOutputPath string            `protobuf:"bytes,1,opt,name=build_output_path,json=buildOutputPath,proto3" json:"build_output_path,omitempty"`
+	Details         map[string]string `protobuf:"bytes,2,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ServiceBuildResult) Reset() {
+	*x = ServiceBuildResult{}
+	mi := &file_framework_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceBuildResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceBuildResult) ProtoMessage() {}
+
+func (x *ServiceBuildResult) ProtoReflect() protoreflect.Message {
+	mi := &file_framework_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceBuildResult.ProtoReflect.Descriptor instead.
+func (*ServiceBuildResult) Descriptor() ([]byte, []int) {
+	return file_framework_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ServiceBuildResult) GetBuildOutputPath() string {
+	if x != nil {
+		return x.BuildOutputPath
+	}
+	return ""
+}
+
+func (x *ServiceBuildResult) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+var File_framework_service_proto protoreflect.FileDescriptor
+
+var file_framework_service_proto_rawDesc = []byte{
+	0x0a, 0x17, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x61, 0x7a, 0x64, 0x65, 0x78,
+	0x74, 0x1a, 0x0c, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x0b, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x14, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0xca, 0x09, 0x0a, 0x17, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x3a, 0x0a,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x61,
+	0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x76, 0x0a, 0x22, 0x72, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x5f,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x52,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00,
+	0x52, 0x1f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77,
+	0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x79, 0x0a, 0x23, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x66, 0x72,
+	0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f,
+	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28,
+	0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
+	0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x20, 0x72, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x65, 0x72, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5a, 0x0a, 0x12,
+	0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78,
+	0x74, 0x2e, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x11, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x5d, 0x0a, 0x13, 0x69, 0x6e, 0x69, 0x74,
+	0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x46,
+	0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49,
+	0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x48, 0x00, 0x52, 0x12, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x26, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77,
+	0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0e, 0x72, 0x65, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x54, 0x0a, 0x10, 0x72, 0x65,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x46, 0x72,
+	0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52,
+	0x0f, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x4b, 0x0a, 0x0d, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74,
+	0x2e, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52,
+	0x0c, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4e, 0x0a,
+	0x0e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x46,
+	0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x42,
+	0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0d,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a,
+	0x0f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e,
+	0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00,
+	0x52, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x54, 0x0a, 0x10, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x61, 0x7a, 0x64,
+	0x65, 0x78, 0x74, 0x2e, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x54, 0x0a, 0x10, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x27, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77,
+	0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x70, 0x72, 0x6f,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x51, 0x0a, 0x15,
+	0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x79, 0x5f,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x61, 0x7a,
+	0x64, 0x65, 0x78, 0x74, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x61, 0x64, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x13, 0x65, 0x78, 0x74, 0x65,
+	0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x61, 0x64, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x42,
+	0x0e, 0x0a, 0x0c, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x22,
+	0x38, 0x0a, 0x1c, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x80, 0x01, 0x0a, 0x1f, 0x52, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x41, 0x0a, 0x0c, 0x72, 0x65, 0x71,
+	0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f,
+	0x72, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x0c,
+	0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x22, 0x0a, 0x20,
+	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72,
+	0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x65, 0x0a, 0x15, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x71,
+	0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x71,
+	0x75, 0x69, 0x72, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0e, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x52, 0x65, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x5f, 0x62, 0x75,
+	0x69, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x69,
+	0x72, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x22, 0x61, 0x0a, 0x21, 0x46, 0x72, 0x61, 0x6d, 0x65,
+	0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x69, 0x74, 0x69,
+	0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x0e,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0d, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x24, 0x0a, 0x22, 0x46, 0x72,
+	0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e,
+	0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x5e, 0x0a, 0x1e, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x3c, 0x0a, 0x0e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x61, 0x7a, 0x64,
+	0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x52, 0x0d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x22, 0x57, 0x0a, 0x1f, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0xa1, 0x01, 0x0a, 0x1c, 0x46, 0x72,
+	0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x0e, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0d, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x43, 0x0a, 0x0e, 0x72, 0x65, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x0d,
+	0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0x53, 0x0a,
+	0x1d, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32,
+	0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x42,
+	0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x22, 0x9d, 0x01, 0x0a, 0x1e, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x0e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x52, 0x0d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x12, 0x3d, 0x0a, 0x0c, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x6f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x61, 0x7a, 0x64, 0x65,
+	0x78, 0x74, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x4f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x22, 0x57, 0x0a, 0x1f, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x3b, 0x0a, 0x1f, 0x46,
+	0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50,
+	0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x97, 0x01, 0x0a, 0x14, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x43, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x29, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x64,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0xbf, 0x01, 0x0a, 0x12, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x4f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x41, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x44, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x32, 0x62, 0x0a, 0x10, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72,
+	0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4e, 0x0a, 0x06, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x12, 0x1f, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x46, 0x72, 0x61, 0x6d,
+	0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x1a, 0x1f, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x46, 0x72, 0x61,
+	0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x28, 0x01, 0x30, 0x01, 0x42, 0x36, 0x5a, 0x34, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x7a, 0x75, 0x72, 0x65, 0x2f, 0x61, 0x7a, 0x75,
+	0x72, 0x65, 0x2d, 0x64, 0x65, 0x76, 0x2f, 0x63, 0x6c, 0x69, 0x2f, 0x61, 0x7a, 0x64, 0x2f, 0x70,
+	0x6b, 0x67, 0x2f, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x3b, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_framework_service_proto_rawDescOnce sync.Once
+	file_framework_service_proto_rawDescData = file_framework_service_proto_rawDesc
+)
+
+func file_framework_service_proto_rawDescGZIP() []byte {
+	file_framework_service_proto_rawDescOnce.Do(func() {
+		file_framework_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_framework_service_proto_rawDescData)
+	})
+	return file_framework_service_proto_rawDescData
+}
+
+var file_framework_service_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_framework_service_proto_goTypes = []any{
+	(*FrameworkServiceMessage)(nil),            // 0: azdext.FrameworkServiceMessage
+	(*FrameworkServiceErrorMessage)(nil),       // 1: azdext.FrameworkServiceErrorMessage
+	(*RegisterFrameworkServiceRequest)(nil),    // 2: azdext.RegisterFrameworkServiceRequest
+	(*RegisterFrameworkServiceResponse)(nil),   // 3: azdext.RegisterFrameworkServiceResponse
+	(*FrameworkRequirements)(nil),              // 4: azdext.FrameworkRequirements
+	(*FrameworkServiceInitializeRequest)(nil),  // 5: azdext.FrameworkServiceInitializeRequest
+	(*FrameworkServiceInitializeResponse)(nil), // 6: azdext.FrameworkServiceInitializeResponse
+	(*FrameworkServiceRestoreRequest)(nil),     // 7: azdext.FrameworkServiceRestoreRequest
+	(*FrameworkServiceRestoreResponse)(nil),    // 8: azdext.FrameworkServiceRestoreResponse
+	(*FrameworkServiceBuildRequest)(nil),       // 9: azdext.FrameworkServiceBuildRequest
+	(*FrameworkServiceBuildResponse)(nil),      // 10: azdext.FrameworkServiceBuildResponse
+	(*FrameworkServicePackageRequest)(nil),     // 11: azdext.FrameworkServicePackageRequest
+	(*FrameworkServicePackageResponse)(nil),    // 12: azdext.FrameworkServicePackageResponse
+	(*FrameworkServiceProgressMessage)(nil),    // 13: azdext.FrameworkServiceProgressMessage
+	(*ServiceRestoreResult)(nil),               // 14: azdext.ServiceRestoreResult
+	(*ServiceBuildResult)(nil),                 // 15: azdext.ServiceBuildResult
+	nil,                                        // 16: azdext.ServiceRestoreResult.DetailsEntry
+	nil,                                        // 17: azdext.ServiceBuildResult.DetailsEntry
+	(*ExtensionReadyEvent)(nil),                // 18: azdext.ExtensionReadyEvent
+	(*ServiceConfig)(nil),                      // 19: azdext.ServiceConfig
+	(*ServicePackageResult)(nil),               // 20: azdext.ServicePackageResult
+}
+var file_framework_service_proto_depIdxs = []int32{
+	1,  // 0: azdext.FrameworkServiceMessage.error:type_name -> azdext.FrameworkServiceErrorMessage
+	2,  // 1: azdext.FrameworkServiceMessage.register_framework_service_request:type_name -> azdext.RegisterFrameworkServiceRequest
+	3,  // 2: azdext.FrameworkServiceMessage.register_framework_service_response:type_name -> azdext.RegisterFrameworkServiceResponse
+	5,  // 3: azdext.FrameworkServiceMessage.initialize_request:type_name -> azdext.FrameworkServiceInitializeRequest
+	6,  // 4: azdext.FrameworkServiceMessage.initialize_response:type_name -> azdext.FrameworkServiceInitializeResponse
+	7,  // 5: azdext.FrameworkServiceMessage.restore_request:type_name -> azdext.FrameworkServiceRestoreRequest
+	8,  // 6: azdext.FrameworkServiceMessage.restore_response:type_name -> azdext.FrameworkServiceRestoreResponse
+	9,  // 7: azdext.FrameworkServiceMessage.build_request:type_name -> azdext.FrameworkServiceBuildRequest
+	10, // 8: azdext.FrameworkServiceMessage.build_response:type_name -> azdext.FrameworkServiceBuildResponse
+	11, // 9: azdext.FrameworkServiceMessage.package_request:type_name -> azdext.FrameworkServicePackageRequest
+	12, // 10: azdext.FrameworkServiceMessage.package_response:type_name -> azdext.FrameworkServicePackageResponse
+	13, // 11: azdext.FrameworkServiceMessage.progress_message:type_name -> azdext.FrameworkServiceProgressMessage
+	18, // 12: azdext.FrameworkServiceMessage.extension_ready_event:type_name -> azdext.ExtensionReadyEvent
+	4,  // 13: azdext.RegisterFrameworkServiceRequest.requirements:type_name -> azdext.FrameworkRequirements
+	19, // 14: azdext.FrameworkServiceInitializeRequest.service_config:type_name -> azdext.ServiceConfig
+	19, // 15: azdext.FrameworkServiceRestoreRequest.service_config:type_name -> azdext.ServiceConfig
+	14, // 16: azdext.FrameworkServiceRestoreResponse.result:type_name -> azdext.ServiceRestoreResult
+	19, // 17: azdext.FrameworkServiceBuildRequest.service_config:type_name -> azdext.ServiceConfig
+	14, // 18: azdext.FrameworkServiceBuildRequest.restore_output:type_name -> azdext.ServiceRestoreResult
+	15, // 19: azdext.FrameworkServiceBuildResponse.result:type_name -> azdext.ServiceBuildResult
+	19, // 20: azdext.FrameworkServicePackageRequest.service_config:type_name -> azdext.ServiceConfig
+	15, // 21: azdext.FrameworkServicePackageRequest.build_output:type_name -> azdext.ServiceBuildResult
+	20, // 22: azdext.FrameworkServicePackageResponse.result:type_name -> azdext.ServicePackageResult
+	16, // 23: azdext.ServiceRestoreResult.details:type_name -> azdext.ServiceRestoreResult.DetailsEntry
+	17, // 24: azdext.ServiceBuildResult.details:type_name -> azdext.ServiceBuildResult.DetailsEntry
+	0,  // 25: azdext.FrameworkService.Stream:input_type -> azdext.FrameworkServiceMessage
+	0,  // 26: azdext.FrameworkService.Stream:output_type -> azdext.FrameworkServiceMessage
+	26, // [26:27] is the sub-list for method output_type
+	25, // [25:26] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
+}
+
+func init() { file_framework_service_proto_init() }
+func file_framework_service_proto_init() {
+	if File_framework_service_proto != nil {
+		return
+	}
+	file_models_proto_init()
+	file_event_proto_init()
+	file_service_target_proto_init()
+	file_framework_service_proto_msgTypes[0].OneofWrappers = []any{
+		(*FrameworkServiceMessage_RegisterFrameworkServiceRequest)(nil),
+		(*FrameworkServiceMessage_RegisterFrameworkServiceResponse)(nil),
+		(*FrameworkServiceMessage_InitializeRequest)(nil),
+		(*FrameworkServiceMessage_InitializeResponse)(nil),
+		(*FrameworkServiceMessage_RestoreRequest)(nil),
+		(*FrameworkServiceMessage_RestoreResponse)(nil),
+		(*FrameworkServiceMessage_BuildRequest)(nil),
+		(*FrameworkServiceMessage_BuildResponse)(nil),
+		(*FrameworkServiceMessage_PackageRequest)(nil),
+		(*FrameworkServiceMessage_PackageResponse)(nil),
+		(*FrameworkServiceMessage_ProgressMessage)(nil),
+		(*FrameworkServiceMessage_ExtensionReadyEvent)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_framework_service_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_framework_service_proto_goTypes,
+		DependencyIndexes: file_framework_service_proto_depIdxs,
+		MessageInfos:      file_framework_service_proto_msgTypes,
+	}.Build()
+	File_framework_service_proto = out.File
+	file_framework_service_proto_rawDesc = nil
+	file_framework_service_proto_goTypes = nil
+	file_framework_service_proto_depIdxs = nil
+}
@@ -0,0 +1,162 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azdext
+
+import (
+	"context"
+	"fmt"
+)
+
+// FrameworkServiceProvider is implemented by extensions to provide the framework service of a custom service language.
+type FrameworkServiceProvider interface {
+	// Requirements gets the lifecycle commands that must run before the service is packaged.
+	Requirements() *FrameworkRequirements
+
+	// Initializes the framework service for the specified service configuration.
+	Initialize(ctx context.Context, serviceConfig *ServiceConfig) error
+
+	// Restore restores the dependencies of the service.
+	Restore(ctx context.Context, serviceConfig *ServiceConfig, progress ProgressReporter) (*ServiceRestoreResult, error)
+
+	// Build builds the source of the service.
+	Build(
+		ctx context.Context,
+		serviceConfig *ServiceConfig,
+		restoreOutput *ServiceRestoreResult,
+		progress ProgressReporter,
+	) (*ServiceBuildResult, error)
+
+	// Package packages the build output of the service so it can be deployed by the service target.
+	Package(
+		ctx context.Context,
+		serviceConfig *ServiceConfig,
+		buildOutput *ServiceBuildResult,
+		progress ProgressReporter,
+	) (*ServicePackageResult, error)
+}
+
+// FrameworkServiceManager registers framework service providers with azd and handles the framework service requests
+// sent by azd.
+type FrameworkServiceManager struct {
+	stream    *providerStream[FrameworkServiceMessage, *FrameworkServiceMessage]
+	providers map[string]FrameworkServiceProvider
+}
+
+func NewFrameworkServiceManager(azdClient *AzdClient) *FrameworkServiceManager {
+	return &FrameworkServiceManager{
+		stream:    newProviderStream[FrameworkServiceMessage](azdClient.FrameworkService().Stream),
+		providers: make(map[string]FrameworkServiceProvider),
+	}
+}
+
+func (m *FrameworkServiceManager) Close() error {
+	return m.stream.close()
+}
+
+// Register registers the provider as the framework service for the specified service language, ex) go
+// Services declaring the language in azure.yaml are restored, built and packaged by the provider.
+// All providers must be registered before calling Receive.
+func (m *FrameworkServiceManager) Register(ctx context.Context, language string, provider FrameworkServiceProvider) error {
+	err := m.stream.register(ctx, &FrameworkServiceMessage{
+		MessageType: &FrameworkServiceMessage_RegisterFrameworkServiceRequest{
+			RegisterFrameworkServiceRequest: &RegisterFrameworkServiceRequest{
+				Language:     language,
+				Requirements: provider.Requirements(),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register service language '%s': %w", language, err)
+	}
+
+	m.providers[language] = provider
+
+	return nil
+}
+
+// Receive signals azd that the extension is ready and handles the framework service requests sent by azd.
+// This is a blocking call and will not return until the server connection is closed.
+func (m *FrameworkServiceManager) Receive(ctx context.Context) error {
+	return m.stream.receive(ctx, m.handleRequest)
+}
+
+// handleRequest invokes the provider for the request and returns the response to send back to azd
+func (m *FrameworkServiceManager) handleRequest(
+	ctx context.Context,
+	msg *FrameworkServiceMessage,
+	progress ProgressReporter,
+) *FrameworkServiceMessage {
+	response := &FrameworkServiceMessage{}
+
+	var err error
+	switch request := msg.MessageType.(type) {
+	case *FrameworkServiceMessage_InitializeRequest:
+		var provider FrameworkServiceProvider
+		if provider, err = m.provider(request.InitializeRequest.ServiceConfig); err == nil {
+			err = provider.Initialize(ctx, request.InitializeRequest.ServiceConfig)
+		}
+
+		response.MessageType = &FrameworkServiceMessage_InitializeResponse{
+			InitializeResponse: &FrameworkServiceInitializeResponse{},
+		}
+	case *FrameworkServiceMessage_RestoreRequest:
+		var provider FrameworkServiceProvider
+		var result *ServiceRestoreResult
+		if provider, err = m.provider(request.RestoreRequest.ServiceConfig); err == nil {
+			result, err = provider.Restore(ctx, request.RestoreRequest.ServiceConfig, progress)
+		}
+
+		response.MessageType = &FrameworkServiceMessage_RestoreResponse{
+			RestoreResponse: &FrameworkServiceRestoreResponse{Result: result},
+		}
+	case *FrameworkServiceMessage_BuildRequest:
+		var provider FrameworkServiceProvider
+		var result *ServiceBuildResult
+		if provider, err = m.provider(request.BuildRequest.ServiceConfig); err == nil {
+			result, err = provider.Build(
+				ctx,
+				request.BuildRequest.ServiceConfig,
+				request.BuildRequest.RestoreOutput,
+				progress,
+			)
+		}
+
+		response.MessageType = &FrameworkServiceMessage_BuildResponse{
+			BuildResponse: &FrameworkServiceBuildResponse{Result: result},
+		}
+	case *FrameworkServiceMessage_PackageRequest:
+		var provider FrameworkServiceProvider
+		var result *ServicePackageResult
+		if provider, err = m.provider(request.PackageRequest.ServiceConfig); err == nil {
+			result, err = provider.Package(
+				ctx,
+				request.PackageRequest.ServiceConfig,
+				request.PackageRequest.BuildOutput,
+				progress,
+			)
+		}
+
+		response.MessageType = &FrameworkServiceMessage_PackageResponse{
+			PackageResponse: &FrameworkServicePackageResponse{Result: result},
+		}
+	default:
+		err = fmt.Errorf("unsupported framework service message type %T", msg.MessageType)
+	}
+
+	if err != nil {
+		response.Error = &FrameworkServiceErrorMessage{Message: err.Error()}
+	}
+
+	return response
+}
+
+// provider returns the provider registered for the language of the service
+func (m *FrameworkServiceManager) provider(serviceConfig *ServiceConfig) (FrameworkServiceProvider, error) {
+	provider, has := m.providers[serviceConfig.GetLanguage()]
+	if !has {
+		return nil, fmt.Errorf("no framework service provider registered for language '%s'", serviceConfig.GetLanguage())
+	}
+
+	return provider, nil
+}
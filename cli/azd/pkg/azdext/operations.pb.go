@@ -0,0 +1,549 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.29.1
+// source: operations.proto
+
+package azdext
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Request to provision the infrastructure of the project
+type ProvisionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Previews the changes to the Azure resources without applying them
+	Preview bool `protobuf:"varint,1,opt,name=preview,proto3" json:"preview,omitempty"`
+	// Provisions the Azure resources even when they violate the policy rules of the infrastructure
+	OverridePolicy bool `protobuf:"varint,2,opt,name=override_policy,json=overridePolicy,proto3" json:"override_policy,omitempty"`
+	// The layer of the infrastructure to provision, all the layers when empty
+	Layer string `protobuf:"bytes,3,opt,name=layer,proto3" json:"layer,omitempty"`
+}
+
+func (x *ProvisionRequest) Reset() {
+	*x = ProvisionRequest{}
+	mi := &file_operations_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProvisionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProvisionRequest) ProtoMessage() {}
+
+func (x *ProvisionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operations_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProvisionRequest.ProtoReflect.Descriptor instead.
+func (*ProvisionRequest) Descriptor() ([]byte, []int) {
+	return file_operations_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ProvisionRequest) GetPreview() bool {
+	if x != nil {
+		return x.Preview
+	}
+	return false
+}
+
+func (x *ProvisionRequest) GetOverridePolicy() bool {
+	if x != nil {
+		return x.OverridePolicy
+	}
+	return false
+}
+
+func (x *ProvisionRequest) GetLayer() string {
+	if x != nil {
+		return x.Layer
+	}
+	return ""
+}
+
+// Request to run an operation on a service of the project
+type ServiceOperationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServiceName string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+}
+
+func (x *ServiceOperationRequest) Reset() {
+	*x = ServiceOperationRequest{}
+	mi := &file_operations_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceOperationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceOperationRequest) ProtoMessage() {}
+
+func (x *ServiceOperationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operations_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceOperationRequest.ProtoReflect.Descriptor instead.
+func (*ServiceOperationRequest) Descriptor() ([]byte, []int) {
+	return file_operations_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ServiceOperationRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+// Message streamed while an operation is running. The last message contains the result of the operation.
+type OperationMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to MessageType:
+	//
+	//	*OperationMessage_Progress
+	//	*OperationMessage_ProvisionResult
+	//	*OperationMessage_BuildResult
+	//	*OperationMessage_PackageResult
+	//	*OperationMessage_DeployResult
+	//	*OperationMessage_ProvisionPreview
+	MessageType isOperationMessage_MessageType `protobuf_oneof:"message_type"`
+}
+
+func (x *OperationMessage) Reset() {
+	*x = OperationMessage{}
+	mi := &file_operations_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationMessage) ProtoMessage() {}
+
+func (x *OperationMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_operations_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationMessage.ProtoReflect.Descriptor instead.
+func (*OperationMessage) Descriptor() ([]byte, []int) {
+	return file_operations_proto_rawDescGZIP(), []int{2}
+}
+
+func (m *OperationMessage) GetMessageType() isOperationMessage_MessageType {
+	if m != nil {
+		return m.MessageType
+	}
+	return nil
+}
+
+func (x *OperationMessage) GetProgress() *OperationProgress {
+	if x, ok := x.GetMessageType().(*OperationMessage_Progress); ok {
+		return x.Progress
+	}
+	return nil
+}
+
+func (x *OperationMessage) GetProvisionResult() *ProvisioningDeployResult {
+	if x, ok := x.GetMessageType().(*OperationMessage_ProvisionResult); ok {
+		return x.ProvisionResult
+	}
+	return nil
+}
+
+func (x *OperationMessage) GetBuildResult() *ServiceBuildResult {
+	if x, ok := x.GetMessageType().(*OperationMessage_BuildResult); ok {
+		return x.BuildResult
+	}
+	return nil
+}
+
+func (x *OperationMessage) GetPackageResult() *ServicePackageResult {
+	if x, ok := x.GetMessageType().(*OperationMessage_PackageResult); ok {
+		return x.PackageResult
+	}
+	return nil
+}
+
+func (x *OperationMessage) GetDeployResult() *ServiceDeployResult {
+	if x, ok := x.GetMessageType().(*OperationMessage_DeployResult); ok {
+		return x.DeployResult
+	}
+	return nil
+}
+
+func (x *OperationMessage) GetProvisionPreview() *ProvisioningDeploymentPreview {
+	if x, ok := x.GetMessageType().(*OperationMessage_ProvisionPreview); ok {
+		return x.ProvisionPreview
+	}
+	return nil
+}
+
+type isOperationMessage_MessageType interface {
+	isOperationMessage_MessageType()
+}
+
+type OperationMessage_Progress struct {
+	Progress *OperationProgress `protobuf:"bytes,1,opt,name=progress,proto3,oneof"`
+}
+
+type OperationMessage_ProvisionResult struct {
+	ProvisionResult *ProvisioningDeployResult `protobuf:"bytes,2,opt,name=provision_result,json=provisionResult,proto3,oneof"`
+}
+
+type OperationMessage_BuildResult struct {
+	BuildResult *ServiceBuildResult `protobuf:"bytes,3,opt,name=build_result,json=buildResult,proto3,oneof"`
+}
+
+type OperationMessage_PackageResult struct {
+	PackageResult *ServicePackageResult `protobuf:"bytes,4,opt,name=package_result,json=packageResult,proto3,oneof"`
+}
+
+type OperationMessage_DeployResult struct {
+	DeployResult *ServiceDeployResult `protobuf:"bytes,5,opt,name=deploy_result,json=deployResult,proto3,oneof"`
+}
+
+type OperationMessage_ProvisionPreview struct {
+	ProvisionPreview *ProvisioningDeploymentPreview `protobuf:"bytes,6,opt,name=provision_preview,json=provisionPreview,proto3,oneof"`
+}
+
+func (*OperationMessage_Progress) isOperationMessage_MessageType() {}
+
+func (*OperationMessage_ProvisionResult) isOperationMessage_MessageType() {}
+
+func (*OperationMessage_BuildResult) isOperationMessage_MessageType() {}
+
+func (*OperationMessage_PackageResult) isOperationMessage_MessageType() {}
+
+func (*OperationMessage_DeployResult) isOperationMessage_MessageType() {}
+
+func (*OperationMessage_ProvisionPreview) isOperationMessage_MessageType() {}
+
+// Progress reported by a running operation
+type OperationProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *OperationProgress) Reset() {
+	*x = OperationProgress{}
+	mi := &file_operations_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationProgress) ProtoMessage() {}
+
+func (x *OperationProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_operations_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationProgress.ProtoReflect.Descriptor instead.
+func (*OperationProgress) Descriptor() ([]byte, []int) {
+	return file_operations_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *OperationProgress) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GetServiceDeployResultsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The deploy results keyed by service name
+	Results map[string]*ServiceDeployResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *GetServiceDeployResultsResponse) Reset() {
+	*x = GetServiceDeployResultsResponse{}
+	mi := &file_operations_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServiceDeployResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServiceDeployResultsResponse) ProtoMessage() {}
+
+func (x *GetServiceDeployResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_operations_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServiceDeployResultsResponse.ProtoReflect.Descriptor instead.
+func (*GetServiceDeployResultsResponse) Descriptor() ([]byte, []int) {
+	return file_operations_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetServiceDeployResultsResponse) GetResults() map[string]*ServiceDeployResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+var File_operations_proto protoreflect.FileDescriptor
+
+var file_operations_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x06, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x1a, 0x0c, 0x6d, 0x6f, 0x64, 0x65,
+	0x6c, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x17, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x77,
+	0x6f, 0x72, 0x6b, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x14, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x12, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x6b, 0x0a, 0x10, 0x50,
+	0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x70, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x70, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x12, 0x27, 0x0a, 0x0f, 0x6f, 0x76, 0x65,
+	0x72, 0x72, 0x69, 0x64, 0x65, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0e, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x22, 0x3c, 0x0a, 0x17, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0xcc, 0x03, 0x0a, 0x10, 0x4f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x37, 0x0a, 0x08, 0x70,
+	0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x48, 0x00, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x67,
+	0x72, 0x65, 0x73, 0x73, 0x12, 0x4d, 0x0a, 0x10, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20,
+	0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x48, 0x00, 0x52, 0x0f, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x12, 0x3f, 0x0a, 0x0c, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x61, 0x7a, 0x64, 0x65,
+	0x78, 0x74, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x12, 0x45, 0x0a, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x61,
+	0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x70, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x42, 0x0a, 0x0d, 0x64,
+	0x65, 0x70, 0x6c, 0x6f, 0x79, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48,
+	0x00, 0x52, 0x0c, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12,
+	0x54, 0x0a, 0x11, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x72, 0x65,
+	0x76, 0x69, 0x65, 0x77, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x61, 0x7a, 0x64,
+	0x65, 0x78, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67,
+	0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65,
+	0x77, 0x48, 0x00, 0x52, 0x10, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72,
+	0x65, 0x76, 0x69, 0x65, 0x77, 0x42, 0x0e, 0x0a, 0x0c, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x22, 0x2d, 0x0a, 0x11, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x22, 0xca, 0x01, 0x0a, 0x1f, 0x47, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x61, 0x7a, 0x64, 0x65,
+	0x78, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x70,
+	0x6c, 0x6f, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x1a, 0x57, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x31, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x61, 0x7a, 0x64, 0x65,
+	0x78, 0x74, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x32, 0x85, 0x03, 0x0a, 0x11, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x41, 0x0a, 0x09, 0x50, 0x72, 0x6f, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x50, 0x72,
+	0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18,
+	0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x30, 0x01, 0x12, 0x44, 0x0a, 0x05, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x12, 0x1f, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x4f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x30, 0x01,
+	0x12, 0x46, 0x0a, 0x07, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x12, 0x1f, 0x2e, 0x61, 0x7a,
+	0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x61,
+	0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x30, 0x01, 0x12, 0x45, 0x0a, 0x06, 0x44, 0x65, 0x70, 0x6c,
+	0x6f, 0x79, 0x12, 0x1f, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x4f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x30, 0x01, 0x12,
+	0x58, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x70,
+	0x6c, 0x6f, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x14, 0x2e, 0x61, 0x7a, 0x64,
+	0x65, 0x78, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x27, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x36, 0x5a, 0x34, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x7a, 0x75, 0x72, 0x65, 0x2f, 0x61, 0x7a,
+	0x75, 0x72, 0x65, 0x2d, 0x64, 0x65, 0x76, 0x2f, 0x63, 0x6c, 0x69, 0x2f, 0x61, 0x7a, 0x64, 0x2f,
+	0x70, 0x6b, 0x67, 0x2f, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x3b, 0x61, 0x7a, 0x64, 0x65, 0x78,
+	0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_operations_proto_rawDescOnce sync.Once
+	file_operations_proto_rawDescData = file_operations_proto_rawDesc
+)
+
+func file_operations_proto_rawDescGZIP() []byte {
+	file_operations_proto_rawDescOnce.Do(func() {
+		file_operations_proto_rawDescData = protoimpl.X.CompressGZIP(file_operations_proto_rawDescData)
+	})
+	return file_operations_proto_rawDescData
+}
+
+var file_operations_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_operations_proto_goTypes = []any{
+	(*ProvisionRequest)(nil),                // 0: azdext.ProvisionRequest
+	(*ServiceOperationRequest)(nil),         // 1: azdext.ServiceOperationRequest
+	(*OperationMessage)(nil),                // 2: azdext.OperationMessage
+	(*OperationProgress)(nil),               // 3: azdext.OperationProgress
+	(*GetServiceDeployResultsResponse)(nil), // 4: azdext.GetServiceDeployResultsResponse
+	nil,                                     // 5: azdext.GetServiceDeployResultsResponse.ResultsEntry
+	(*ProvisioningDeployResult)(nil),        // 6: azdext.ProvisioningDeployResult
+	(*ServiceBuildResult)(nil),              // 7: azdext.ServiceBuildResult
+	(*ServicePackageResult)(nil),            // 8: azdext.ServicePackageResult
+	(*ServiceDeployResult)(nil),             // 9: azdext.ServiceDeployResult
+	(*ProvisioningDeploymentPreview)(nil),   // 10: azdext.ProvisioningDeploymentPreview
+	(*EmptyRequest)(nil),                    // 11: azdext.EmptyRequest
+}
+var file_operations_proto_depIdxs = []int32{
+	3,  // 0: azdext.OperationMessage.progress:type_name -> azdext.OperationProgress
+	6,  // 1: azdext.OperationMessage.provision_result:type_name -> azdext.ProvisioningDeployResult
+	7,  // 2: azdext.OperationMessage.build_result:type_name -> azdext.ServiceBuildResult
+	8,  // 3: azdext.OperationMessage.package_result:type_name -> azdext.ServicePackageResult
+	9,  // 4: azdext.OperationMessage.deploy_result:type_name -> azdext.ServiceDeployResult
+	10, // 5: azdext.OperationMessage.provision_preview:type_name -> azdext.ProvisioningDeploymentPreview
+	5,  // 6: azdext.GetServiceDeployResultsResponse.results:type_name -> azdext.GetServiceDeployResultsResponse.ResultsEntry
+	9,  // 7: azdext.GetServiceDeployResultsResponse.ResultsEntry.value:type_name -> azdext.ServiceDeployResult
+	0,  // 8: azdext.OperationsService.Provision:input_type -> azdext.ProvisionRequest
+	1,  // 9: azdext.OperationsService.Build:input_type -> azdext.ServiceOperationRequest
+	1,  // 10: azdext.OperationsService.Package:input_type -> azdext.ServiceOperationRequest
+	1,  // 11: azdext.OperationsService.Deploy:input_type -> azdext.ServiceOperationRequest
+	11, // 12: azdext.OperationsService.GetServiceDeployResults:input_type -> azdext.EmptyRequest
+	2,  // 13: azdext.OperationsService.Provision:output_type -> azdext.OperationMessage
+	2,  // 14: azdext.OperationsService.Build:output_type -> azdext.OperationMessage
+	2,  // 15: azdext.OperationsService.Package:output_type -> azdext.OperationMessage
+	2,  // 16: azdext.OperationsService.Deploy:output_type -> azdext.OperationMessage
+	4,  // 17: azdext.OperationsService.GetServiceDeployResults:output_type -> azdext.GetServiceDeployResultsResponse
+	13, // [13:18] is the sub-list for method output_type
+	8,  // [8:13] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_operations_proto_init() }
+func file_operations_proto_init() {
+	if File_operations_proto != nil {
+		return
+	}
+	file_models_proto_init()
+	file_framework_service_proto_init()
+	file_service_target_proto_init()
+	file_provisioning_proto_init()
+	file_operations_proto_msgTypes[2].OneofWrappers = []any{
+		(*OperationMessage_Progress)(nil),
+		(*OperationMessage_ProvisionResult)(nil),
+		(*OperationMessage_BuildResult)(nil),
+		(*OperationMessage_PackageResult)(nil),
+		(*OperationMessage_DeployResult)(nil),
+		(*OperationMessage_ProvisionPreview)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_operations_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_operations_proto_goTypes,
+		DependencyIndexes: file_operations_proto_depIdxs,
+		MessageInfos:      file_operations_proto_msgTypes,
+	}.Build()
+	File_operations_proto = out.File
+	file_operations_proto_rawDesc = nil
+	file_operations_proto_goTypes = nil
+	file_operations_proto_depIdxs = nil
+}
@@ -0,0 +1,305 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.1
+// source: operations.proto
+
+package azdext
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	OperationsService_Provision_FullMethodName               = "/azdext.OperationsService/Provision"
+	OperationsService_Build_FullMethodName                   = "/azdext.OperationsService/Build"
+	OperationsService_Package_FullMethodName                 = "/azdext.OperationsService/Package"
+	OperationsService_Deploy_FullMethodName                  = "/azdext.OperationsService/Deploy"
+	OperationsService_GetServiceDeployResults_FullMethodName = "/azdext.OperationsService/GetServiceDeployResults"
+)
+
+// OperationsServiceClient is the client API for OperationsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// OperationsService enables extensions to orchestrate the provisioning and deployment of the current project.
+// Long running operations stream their progress and complete with a final result message.
+type OperationsServiceClient interface {
+	// Provision provisions the infrastructure of the project and updates the environment with the outputs.
+	Provision(ctx context.Context, in *ProvisionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OperationMessage], error)
+	// Build builds the specified service.
+	Build(ctx context.Context, in *ServiceOperationRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OperationMessage], error)
+	// Package packages the specified service.
+	Package(ctx context.Context, in *ServiceOperationRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OperationMessage], error)
+	// Deploy packages and deploys the specified service.
+	Deploy(ctx context.Context, in *ServiceOperationRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OperationMessage], error)
+	// GetServiceDeployResults gets the results of the services deployed by the current azd command.
+	GetServiceDeployResults(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GetServiceDeployResultsResponse, error)
+}
+
+type operationsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOperationsServiceClient(cc grpc.ClientConnInterface) OperationsServiceClient {
+	return &operationsServiceClient{cc}
+}
+
+func (c *operationsServiceClient) Provision(ctx context.Context, in *ProvisionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OperationMessage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &OperationsService_ServiceDesc.Streams[0], OperationsService_Provision_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ProvisionRequest, OperationMessage]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OperationsService_ProvisionClient = grpc.ServerStreamingClient[OperationMessage]
+
+func (c *operationsServiceClient) Build(ctx context.Context, in *ServiceOperationRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OperationMessage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &OperationsService_ServiceDesc.Streams[1], OperationsService_Build_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ServiceOperationRequest, OperationMessage]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OperationsService_BuildClient = grpc.ServerStreamingClient[OperationMessage]
+
+func (c *operationsServiceClient) Package(ctx context.Context, in *ServiceOperationRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OperationMessage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &OperationsService_ServiceDesc.Streams[2], OperationsService_Package_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ServiceOperationRequest, OperationMessage]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OperationsService_PackageClient = grpc.ServerStreamingClient[OperationMessage]
+
+func (c *operationsServiceClient) Deploy(ctx context.Context, in *ServiceOperationRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OperationMessage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &OperationsService_ServiceDesc.Streams[3], OperationsService_Deploy_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ServiceOperationRequest, OperationMessage]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OperationsService_DeployClient = grpc.ServerStreamingClient[OperationMessage]
+
+func (c *operationsServiceClient) GetServiceDeployResults(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GetServiceDeployResultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetServiceDeployResultsResponse)
+	err := c.cc.Invoke(ctx, OperationsService_GetServiceDeployResults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OperationsServiceServer is the server API for OperationsService service.
+// All implementations must embed UnimplementedOperationsServiceServer
+// for forward compatibility.
+//
+// OperationsService enables extensions to orchestrate the provisioning and deployment of the current project.
+// Long running operations stream their progress and complete with a final result message.
+type OperationsServiceServer interface {
+	// Provision provisions the infrastructure of the project and updates the environment with the outputs.
+	Provision(*ProvisionRequest, grpc.ServerStreamingServer[OperationMessage]) error
+	// Build builds the specified service.
+	Build(*ServiceOperationRequest, grpc.ServerStreamingServer[OperationMessage]) error
+	// Package packages the specified service.
+	Package(*ServiceOperationRequest, grpc.ServerStreamingServer[OperationMessage]) error
+	// Deploy packages and deploys the specified service.
+	Deploy(*ServiceOperationRequest, grpc.ServerStreamingServer[OperationMessage]) error
+	// GetServiceDeployResults gets the results of the services deployed by the current azd command.
+	GetServiceDeployResults(context.Context, *EmptyRequest) (*GetServiceDeployResultsResponse, error)
+	mustEmbedUnimplementedOperationsServiceServer()
+}
+
+// UnimplementedOperationsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedOperationsServiceServer struct{}
+
+func (UnimplementedOperationsServiceServer) Provision(*ProvisionRequest, grpc.ServerStreamingServer[OperationMessage]) error {
+	return status.Errorf(codes.Unimplemented, "method Provision not implemented")
+}
+func (UnimplementedOperationsServiceServer) Build(*ServiceOperationRequest, grpc.ServerStreamingServer[OperationMessage]) error {
+	return status.Errorf(codes.Unimplemented, "method Build not implemented")
+}
+func (UnimplementedOperationsServiceServer) Package(*ServiceOperationRequest, grpc.ServerStreamingServer[OperationMessage]) error {
+	return status.Errorf(codes.Unimplemented, "method Package not implemented")
+}
+func (UnimplementedOperationsServiceServer) Deploy(*ServiceOperationRequest, grpc.ServerStreamingServer[OperationMessage]) error {
+	return status.Errorf(codes.Unimplemented, "method Deploy not implemented")
+}
+func (UnimplementedOperationsServiceServer) GetServiceDeployResults(context.Context, *EmptyRequest) (*GetServiceDeployResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServiceDeployResults not implemented")
+}
+func (UnimplementedOperationsServiceServer) mustEmbedUnimplementedOperationsServiceServer() {}
+func (UnimplementedOperationsServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeOperationsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OperationsServiceServer will
+// result in compilation errors.
+type UnsafeOperationsServiceServer interface {
+	mustEmbedUnimplementedOperationsServiceServer()
+}
+
+func RegisterOperationsServiceServer(s grpc.ServiceRegistrar, srv OperationsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedOperationsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&OperationsService_ServiceDesc, srv)
+}
+
+func _OperationsService_Provision_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ProvisionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OperationsServiceServer).Provision(m, &grpc.GenericServerStream[ProvisionRequest, OperationMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OperationsService_ProvisionServer = grpc.ServerStreamingServer[OperationMessage]
+
+func _OperationsService_Build_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ServiceOperationRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OperationsServiceServer).Build(m, &grpc.GenericServerStream[ServiceOperationRequest, OperationMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OperationsService_BuildServer = grpc.ServerStreamingServer[OperationMessage]
+
+func _OperationsService_Package_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ServiceOperationRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OperationsServiceServer).Package(m, &grpc.GenericServerStream[ServiceOperationRequest, OperationMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OperationsService_PackageServer = grpc.ServerStreamingServer[OperationMessage]
+
+func _OperationsService_Deploy_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ServiceOperationRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OperationsServiceServer).Deploy(m, &grpc.GenericServerStream[ServiceOperationRequest, OperationMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OperationsService_DeployServer = grpc.ServerStreamingServer[OperationMessage]
+
+func _OperationsService_GetServiceDeployResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OperationsServiceServer).GetServiceDeployResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OperationsService_GetServiceDeployResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OperationsServiceServer).GetServiceDeployResults(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OperationsService_ServiceDesc is the grpc.ServiceDesc for OperationsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OperationsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "azdext.OperationsService",
+	HandlerType: (*OperationsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetServiceDeployResults",
+			Handler:    _OperationsService_GetServiceDeployResults_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Provision",
+			Handler:       _OperationsService_Provision_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Build",
+			Handler:       _OperationsService_Build_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Package",
+			Handler:       _OperationsService_Package_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Deploy",
+			Handler:       _OperationsService_Deploy_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "operations.proto",
+}
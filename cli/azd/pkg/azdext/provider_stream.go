@@ -0,0 +1,148 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azdext
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// providerStream is the stream azd sends the requests for the providers registered by an extension on,
+// ex) the service target stream. The stream is opened on first use.
+type providerStream[M any, P interface {
+	*M
+	StreamMessage
+}] struct {
+	open      func(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[M, M], error)
+	stream    grpc.BidiStreamingClient[M, M]
+	sendMutex sync.Mutex
+}
+
+func newProviderStream[M any, P interface {
+	*M
+	StreamMessage
+}](
+	open func(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[M, M], error),
+) *providerStream[M, P] {
+	return &providerStream[M, P]{
+		open: open,
+	}
+}
+
+func (s *providerStream[M, P]) init(ctx context.Context) error {
+	if s.stream == nil {
+		stream, err := s.open(ctx)
+		if err != nil {
+			return err
+		}
+
+		s.stream = stream
+	}
+
+	return nil
+}
+
+func (s *providerStream[M, P]) close() error {
+	if s.stream != nil {
+		return s.stream.CloseSend()
+	}
+
+	return nil
+}
+
+// register sends the registration request of a provider and waits for azd to accept it
+func (s *providerStream[M, P]) register(ctx context.Context, request *M) error {
+	if err := s.init(ctx); err != nil {
+		return err
+	}
+
+	if err := s.send(request); err != nil {
+		return err
+	}
+
+	response, err := s.stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	if message, failed := P(response).Failure(); failed {
+		return errors.New(message)
+	}
+
+	return nil
+}
+
+// receive signals azd that the extension is ready and handles the requests sent by azd until the stream is closed.
+// Requests are handled concurrently since azd may run the same operation for multiple services at the same time.
+func (s *providerStream[M, P]) receive(
+	ctx context.Context,
+	handle func(ctx context.Context, msg *M, progress ProgressReporter) *M,
+) error {
+	if err := s.init(ctx); err != nil {
+		return err
+	}
+
+	ready := new(M)
+	P(ready).SetExtensionReady()
+	if err := s.send(ready); err != nil {
+		return err
+	}
+
+	for {
+		msg, err := s.stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				log.Println("Stream closed by server (EOF), treating as expected")
+				return nil
+			}
+
+			if st, ok := status.FromError(err); ok {
+				if st.Code() == codes.Unavailable || st.Code() == codes.Canceled {
+					log.Println("Stream closed by server, treating as expected")
+					return nil
+				}
+			}
+
+			return err
+		}
+
+		go func() {
+			requestId := P(msg).GetRequestId()
+			response := handle(ctx, msg, s.progressReporter(requestId))
+			P(response).SetRequestId(requestId)
+
+			if err := s.send(response); err != nil {
+				log.Printf("failed to send response for request %s: %v", requestId, err)
+			}
+		}()
+	}
+}
+
+// progressReporter returns the reporter sending the progress of a request to azd
+func (s *providerStream[M, P]) progressReporter(requestId string) ProgressReporter {
+	return func(message string) {
+		progress := new(M)
+		P(progress).SetRequestId(requestId)
+		P(progress).SetProgress(message)
+
+		if err := s.send(progress); err != nil {
+			log.Printf("failed to send progress for request %s: %v", requestId, err)
+		}
+	}
+}
+
+// send sends a message to azd. Messages can be sent from concurrent requests.
+func (s *providerStream[M, P]) send(msg *M) error {
+	s.sendMutex.Lock()
+	defer s.sendMutex.Unlock()
+
+	return s.stream.Send(msg)
+}
@@ -0,0 +1,139 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azdext
+
+import (
+	"context"
+	"fmt"
+)
+
+// ProvisioningProvider is implemented by extensions to provision the infrastructure of projects
+// that set `infra.provider` to the id of the extension.
+type ProvisioningProvider interface {
+	// Initializes the provider for the project.
+	Initialize(ctx context.Context, projectPath string, options *ProvisioningOptions) error
+
+	// State gets the state of the most recent deployment.
+	State(ctx context.Context, hint string, progress ProgressReporter) (*ProvisioningState, error)
+
+	// Deploy deploys the infrastructure.
+	Deploy(ctx context.Context, progress ProgressReporter) (*ProvisioningDeployResult, error)
+
+	// Preview gets the changes a deployment would perform without applying them.
+	Preview(ctx context.Context, progress ProgressReporter) (*ProvisioningDeploymentPreview, error)
+
+	// Destroy deletes the infrastructure and returns the environment keys that are no longer valid.
+	Destroy(ctx context.Context, force bool, purge bool, progress ProgressReporter) ([]string, error)
+
+	// EnsureEnv ensures the environment contains the values required by the provider, ex) subscription & location.
+	EnsureEnv(ctx context.Context) error
+}
+
+// ProvisioningProviderManager registers the provisioning provider of an extension with azd and handles the
+// provisioning requests sent by azd.
+type ProvisioningProviderManager struct {
+	stream   *providerStream[ProvisioningMessage, *ProvisioningMessage]
+	provider ProvisioningProvider
+}
+
+func NewProvisioningProviderManager(azdClient *AzdClient) *ProvisioningProviderManager {
+	return &ProvisioningProviderManager{
+		stream: newProviderStream[ProvisioningMessage](azdClient.Provisioning().Stream),
+	}
+}
+
+func (m *ProvisioningProviderManager) Close() error {
+	return m.stream.close()
+}
+
+// Register registers the provisioning provider of the extension with the specified display name.
+// Projects setting `infra.provider` to the id of the extension are provisioned by the provider.
+// The provider must be registered before calling Receive.
+func (m *ProvisioningProviderManager) Register(ctx context.Context, name string, provider ProvisioningProvider) error {
+	err := m.stream.register(ctx, &ProvisioningMessage{
+		MessageType: &ProvisioningMessage_RegisterProvisioningProviderRequest{
+			RegisterProvisioningProviderRequest: &RegisterProvisioningProviderRequest{
+				Name: name,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register provisioning provider '%s': %w", name, err)
+	}
+
+	m.provider = provider
+
+	return nil
+}
+
+// Receive signals azd that the extension is ready and handles the provisioning requests sent by azd.
+// This is a blocking call and will not return until the server connection is closed.
+func (m *ProvisioningProviderManager) Receive(ctx context.Context) error {
+	return m.stream.receive(ctx, m.handleRequest)
+}
+
+// handleRequest invokes the provider for the request and returns the response to send back to azd
+func (m *ProvisioningProviderManager) handleRequest(
+	ctx context.Context,
+	msg *ProvisioningMessage,
+	progress ProgressReporter,
+) *ProvisioningMessage {
+	response := &ProvisioningMessage{}
+
+	if m.provider == nil {
+		response.Error = &ProvisioningErrorMessage{Message: "no provisioning provider has been registered"}
+		return response
+	}
+
+	var err error
+	switch request := msg.MessageType.(type) {
+	case *ProvisioningMessage_InitializeRequest:
+		err = m.provider.Initialize(ctx, request.InitializeRequest.ProjectPath, request.InitializeRequest.Options)
+		response.MessageType = &ProvisioningMessage_InitializeResponse{
+			InitializeResponse: &ProvisioningInitializeResponse{},
+		}
+	case *ProvisioningMessage_StateRequest:
+		var state *ProvisioningState
+		state, err = m.provider.State(ctx, request.StateRequest.Hint, progress)
+		response.MessageType = &ProvisioningMessage_StateResponse{
+			StateResponse: &ProvisioningStateResponse{State: state},
+		}
+	case *ProvisioningMessage_DeployRequest:
+		var result *ProvisioningDeployResult
+		result, err = m.provider.Deploy(ctx, progress)
+		response.MessageType = &ProvisioningMessage_DeployResponse{
+			DeployResponse: &ProvisioningDeployResponse{Result: result},
+		}
+	case *ProvisioningMessage_PreviewRequest:
+		var preview *ProvisioningDeploymentPreview
+		preview, err = m.provider.Preview(ctx, progress)
+		response.MessageType = &ProvisioningMessage_PreviewResponse{
+			PreviewResponse: &ProvisioningPreviewResponse{Preview: preview},
+		}
+	case *ProvisioningMessage_DestroyRequest:
+		var invalidatedEnvKeys []string
+		invalidatedEnvKeys, err = m.provider.Destroy(
+			ctx,
+			request.DestroyRequest.Force,
+			request.DestroyRequest.Purge,
+			progress,
+		)
+		response.MessageType = &ProvisioningMessage_DestroyResponse{
+			DestroyResponse: &ProvisioningDestroyResponse{InvalidatedEnvKeys: invalidatedEnvKeys},
+		}
+	case *ProvisioningMessage_EnsureEnvRequest:
+		err = m.provider.EnsureEnv(ctx)
+		response.MessageType = &ProvisioningMessage_EnsureEnvResponse{
+			EnsureEnvResponse: &ProvisioningEnsureEnvResponse{},
+		}
+	default:
+		err = fmt.Errorf("unsupported provisioning message type %T", msg.MessageType)
+	}
+
+	if err != nil {
+		response.Error = &ProvisioningErrorMessage{Message: err.Error()}
+	}
+
+	return response
+}
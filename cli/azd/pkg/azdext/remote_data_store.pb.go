@@ -0,0 +1,1797 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.29.1
+// source: remote_data_store.proto
+
+package azdext
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Identifies the failures azd handles, ex) an environment that doesn't exist
+type RemoteDataStoreErrorCode int32
+
+const (
+	RemoteDataStoreErrorCode_REMOTE_DATA_STORE_ERROR_CODE_UNSPECIFIED RemoteDataStoreErrorCode = 0
+	// The environment doesn't exist within the data store.
+	RemoteDataStoreErrorCode_REMOTE_DATA_STORE_ERROR_CODE_NOT_FOUND RemoteDataStoreErrorCode = 1
+	// The environment has been modified by another operation since it was last read or written.
+	RemoteDataStoreErrorCode_REMOTE_DATA_STORE_ERROR_CODE_CONFLICT RemoteDataStoreErrorCode = 2
+	// The environment is locked by another operation.
+	RemoteDataStoreErrorCode_REMOTE_DATA_STORE_ERROR_CODE_LOCKED RemoteDataStoreErrorCode = 3
+)
+
+// Enum value maps for RemoteDataStoreErrorCode.
+var (
+	RemoteDataStoreErrorCode_name = map[int32]string{
+		0: "REMOTE_DATA_STORE_ERROR_CODE_UNSPECIFIED",
+		1: "REMOTE_DATA_STORE_ERROR_CODE_NOT_FOUND",
+		2: "REMOTE_DATA_STORE_ERROR_CODE_CONFLICT",
+		3: "REMOTE_DATA_STORE_ERROR_CODE_LOCKED",
+	}
+	RemoteDataStoreErrorCode_value = map[string]int32{
+		"REMOTE_DATA_STORE_ERROR_CODE_UNSPECIFIED": 0,
+		"REMOTE_DATA_STORE_ERROR_CODE_NOT_FOUND":   1,
+		"REMOTE_DATA_STORE_ERROR_CODE_CONFLICT":    2,
+		"REMOTE_DATA_STORE_ERROR_CODE_LOCKED":      3,
+	}
+)
+
+func (x RemoteDataStoreErrorCode) Enum() *RemoteDataStoreErrorCode {
+	p := new(RemoteDataStoreErrorCode)
+	*p = x
+	return p
+}
+
+func (x RemoteDataStoreErrorCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RemoteDataStoreErrorCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_remote_data_store_proto_enumTypes[0].Descriptor()
+}
+
+func (RemoteDataStoreErrorCode) Type() protoreflect.EnumType {
+	return &file_remote_data_store_proto_enumTypes[0]
+}
+
+func (x RemoteDataStoreErrorCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RemoteDataStoreErrorCode.Descriptor instead.
+func (RemoteDataStoreErrorCode) EnumDescriptor() ([]byte, []int) {
+	return file_remote_data_store_proto_rawDescGZIP(), []int{0}
+}
+
+// Represents the different types of messages sent over the stream
+type RemoteDataStoreMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Correlates the requests sent by azd with the responses sent by the extension.
+	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// Set by the extension when the request failed.
+	Error *RemoteDataStoreErrorMessage `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// Types that are assignable to MessageType:
+	//	*RemoteDataStoreMessage_RegisterRemoteDataStoreRequest
+	//	*RemoteDataStoreMessage_RegisterRemoteDataStoreResponse
+	//	*RemoteDataStoreMessage_InitializeRequest
+	//	*RemoteDataStoreMessage_InitializeResponse
+	//	*RemoteDataStoreMessage_ListRequest
+	//	*RemoteDataStoreMessage_ListResponse
+	//	*RemoteDataStoreMessage_GetRequest
+	//	*RemoteDataStoreMessage_GetResponse
+	//	*RemoteDataStoreMessage_SaveRequest
+	//	*RemoteDataStoreMessage_SaveResponse
+	//	*RemoteDataStoreMessage_DeleteRequest
+	//	*RemoteDataStoreMessage_DeleteResponse
+	//	*RemoteDataStoreMessage_LockRequest
+	//	*RemoteDataStoreMessage_LockResponse
+	//	*RemoteDataStoreMessage_UnlockRequest
+	//	*RemoteDataStoreMessage_UnlockResponse
+	//	*RemoteDataStoreMessage_ProgressMessage
+	//	*RemoteDataStoreMessage_ExtensionReadyEvent
+	MessageType isRemoteDataStoreMessage_MessageType `protobuf_oneof:"message_type"`
+}
+
+func (x *RemoteDataStoreMessage) Reset() {
+	*x = RemoteDataStoreMessage{}
+	mi := &file_remote_data_store_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoteDataStoreMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteDataStoreMessage) ProtoMessage() {}
+
+func (x *RemoteDataStoreMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_data_store_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteDataStoreMessage.ProtoReflect.Descriptor instead.
+func (*RemoteDataStoreMessage) Descriptor() ([]byte, []int) {
+	return file_remote_data_store_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RemoteDataStoreMessage) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *RemoteDataStoreMessage) GetError() *RemoteDataStoreErrorMessage {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+func (m *RemoteDataStoreMessage) GetMessageType() isRemoteDataStoreMessage_MessageType {
+	if m != nil {
+		return m.MessageType
+	}
+	return nil
+}
+
+func (x *RemoteDataStoreMessage) GetRegisterRemoteDataStoreRequest() *RegisterRemoteDataStoreRequest {
+	if x, ok := x.GetMessageType().(*RemoteDataStoreMessage_RegisterRemoteDataStoreRequest); ok {
+		return x.RegisterRemoteDataStoreRequest
+	}
+	return nil
+}
+
+func (x *RemoteDataStoreMessage) GetRegisterRemoteDataStoreResponse() *RegisterRemoteDataStoreResponse {
+	if x, ok := x.GetMessageType().(*RemoteDataStoreMessage_RegisterRemoteDataStoreResponse); ok {
+		return x.RegisterRemoteDataStoreResponse
+	}
+	return nil
+}
+
+func (x *RemoteDataStoreMessage) GetInitializeRequest() *RemoteDataStoreInitializeRequest {
+	if x, ok := x.GetMessageType().(*RemoteDataStoreMessage_InitializeRequest); ok {
+		return x.InitializeRequest
+	}
+	return nil
+}
+
+func (x *RemoteDataStoreMessage) GetInitializeResponse() *RemoteDataStoreInitializeResponse {
+	if x, ok := x.GetMessageType().(*RemoteDataStoreMessage_InitializeResponse); ok {
+		return x.InitializeResponse
+	}
+	return nil
+}
+
+func (x *RemoteDataStoreMessage) GetListRequest() *RemoteDataStoreListRequest {
+	if x, ok := x.GetMessageType().(*RemoteDataStoreMessage_ListRequest); ok {
+		return x.ListRequest
+	}
+	return nil
+}
+
+func (x *RemoteDataStoreMessage) GetListResponse() *RemoteDataStoreListResponse {
+	if x, ok := x.GetMessageType().(*RemoteDataStoreMessage_ListResponse); ok {
+		return x.ListResponse
+	}
+	return nil
+}
+
+func (x *RemoteDataStoreMessage) GetGetRequest() *RemoteDataStoreGetRequest {
+	if x, ok := x.GetMessageType().(*RemoteDataStoreMessage_GetRequest); ok {
+		return x.GetRequest
+	}
+	return nil
+}
+
+func (x *RemoteDataStoreMessage) GetGetResponse() *RemoteDataStoreGetResponse {
+	if x, ok := x.GetMessageType().(*RemoteDataStoreMessage_GetResponse); ok {
+		return x.GetResponse
+	}
+	return nil
+}
+
+func (x *RemoteDataStoreMessage) GetSaveRequest() *RemoteDataStoreSaveRequest {
+	if x, ok := x.GetMessageType().(*RemoteDataStoreMessage_SaveRequest); ok {
+		return x.SaveRequest
+	}
+	return nil
+}
+
+func (x *RemoteDataStoreMessage) GetSaveResponse() *RemoteDataStoreSaveResponse {
+	if x, ok := x.GetMessageType().(*RemoteDataStoreMessage_SaveResponse); ok {
+		return x.SaveResponse
+	}
+	return nil
+}
+
+func (x *RemoteDataStoreMessage) GetDeleteRequest() *RemoteDataStoreDeleteRequest {
+	if x, ok := x.GetMessageType().(*RemoteDataStoreMessage_DeleteRequest); ok {
+		return x.DeleteRequest
+	}
+	return nil
+}
+
+func (x *RemoteDataStoreMessage) GetDeleteResponse() *RemoteDataStoreDeleteResponse {
+	if x, ok := x.GetMessageType().(*RemoteDataStoreMessage_DeleteResponse); ok {
+		return x.DeleteResponse
+	}
+	return nil
+}
+
+func (x *RemoteDataStoreMessage) GetLockRequest() *RemoteDataStoreLockRequest {
+	if x, ok := x.GetMessageType().(*RemoteDataStoreMessage_LockRequest); ok {
+		return x.LockRequest
+	}
+	return nil
+}
+
+func (x *RemoteDataStoreMessage) GetLockResponse() *RemoteDataStoreLockResponse {
+	if x, ok := x.GetMessageType().(*RemoteDataStoreMessage_LockResponse); ok {
+		return x.LockResponse
+	}
+	return nil
+}
+
+func (x *RemoteDataStoreMessage) GetUnlockRequest() *RemoteDataStoreUnlockRequest {
+	if x, ok := x.GetMessageType().(*RemoteDataStoreMessage_UnlockRequest); ok {
+		return x.UnlockRequest
+	}
+	return nil
+}
+
+func (x *RemoteDataStoreMessage) GetUnlockResponse() *RemoteDataStoreUnlockResponse {
+	if x, ok := x.GetMessageType().(*RemoteDataStoreMessage_UnlockResponse); ok {
+		return x.UnlockResponse
+	}
+	return nil
+}
+
+func (x *RemoteDataStoreMessage) GetProgressMessage() *RemoteDataStoreProgressMessage {
+	if x, ok := x.GetMessageType().(*RemoteDataStoreMessage_ProgressMessage); ok {
+		return x.ProgressMessage
+	}
+	return nil
+}
+
+func (x *RemoteDataStoreMessage) GetExtensionReadyEvent() *ExtensionReadyEvent {
+	if x, ok := x.GetMessageType().(*RemoteDataStoreMessage_ExtensionReadyEvent); ok {
+		return x.ExtensionReadyEvent
+	}
+	return nil
+}
+
+type isRemoteDataStoreMessage_MessageType interface {
+	isRemoteDataStoreMessage_MessageType()
+}
+
+type RemoteDataStoreMessage_RegisterRemoteDataStoreRequest struct {
+	RegisterRemoteDataStoreRequest *RegisterRemoteDataStoreRequest `protobuf:"bytes,3,opt,name=register_remote_data_store_request,json=registerRemoteDataStoreRequest,proto3,oneof"`
+}
+
+type RemoteDataStoreMessage_RegisterRemoteDataStoreResponse struct {
+	RegisterRemoteDataStoreResponse *RegisterRemoteDataStoreResponse `protobuf:"bytes,4,opt,name=register_remote_data_store_response,json=registerRemoteDataStoreResponse,proto3,oneof"`
+}
+
+type RemoteDataStoreMessage_InitializeRequest struct {
+	InitializeRequest *RemoteDataStoreInitializeRequest `protobuf:"bytes,5,opt,name=initialize_request,json=initializeRequest,proto3,oneof"`
+}
+
+type RemoteDataStoreMessage_InitializeResponse struct {
+	InitializeResponse *RemoteDataStoreInitializeResponse `protobuf:"bytes,6,opt,name=initialize_response,json=initializeResponse,proto3,oneof"`
+}
+
+type RemoteDataStoreMessage_ListRequest struct {
+	ListRequest *RemoteDataStoreListRequest `protobuf:"bytes,7,opt,name=list_request,json=listRequest,proto3,oneof"`
+}
+
+type RemoteDataStoreMessage_ListResponse struct {
+	ListResponse *RemoteDataStoreListResponse `protobuf:"bytes,8,opt,name=list_response,json=listResponse,proto3,oneof"`
+}
+
+type RemoteDataStoreMessage_GetRequest struct {
+	GetRequest *RemoteDataStoreGetRequest `protobuf:"bytes,9,opt,name=get_request,json=getRequest,proto3,oneof"`
+}
+
+type RemoteDataStoreMessage_GetResponse struct {
+	GetResponse *RemoteDataStoreGetResponse `protobuf:"bytes,10,opt,name=get_response,json=getResponse,proto3,oneof"`
+}
+
+type RemoteDataStoreMessage_SaveRequest struct {
+	SaveRequest *RemoteDataStoreSaveRequest `protobuf:"bytes,11,opt,name=save_request,json=saveRequest,proto3,oneof"`
+}
+
+type RemoteDataStoreMessage_SaveResponse struct {
+	SaveResponse *RemoteDataStoreSaveResponse `protobuf:"bytes,12,opt,name=save_response,json=saveResponse,proto3,oneof"`
+}
+
+type RemoteDataStoreMessage_DeleteRequest struct {
+	DeleteRequest *RemoteDataStoreDeleteRequest `protobuf:"bytes,13,opt,name=delete_request,json=deleteRequest,proto3,oneof"`
+}
+
+type RemoteDataStoreMessage_DeleteResponse struct {
+	DeleteResponse *RemoteDataStoreDeleteResponse `protobuf:"bytes,14,opt,name=delete_response,json=deleteResponse,proto3,oneof"`
+}
+
+type RemoteDataStoreMessage_LockRequest struct {
+	LockRequest *RemoteDataStoreLockRequest `protobuf:"bytes,15,opt,name=lock_request,json=lockRequest,proto3,oneof"`
+}
+
+type RemoteDataStoreMessage_LockResponse struct {
+	LockResponse *RemoteDataStoreLockResponse `protobuf:"bytes,16,opt,name=lock_response,json=lockResponse,proto3,oneof"`
+}
+
+type RemoteDataStoreMessage_UnlockRequest struct {
+	UnlockRequest *RemoteDataStoreUnlockRequest `protobuf:"bytes,17,opt,name=unlock_request,json=unlockRequest,proto3,oneof"`
+}
+
+type RemoteDataStoreMessage_UnlockResponse struct {
+	UnlockResponse *RemoteDataStoreUnlockResponse `protobuf:"bytes,18,opt,name=unlock_response,json=unlockResponse,proto3,oneof"`
+}
+
+type RemoteDataStoreMessage_ProgressMessage struct {
+	ProgressMessage *RemoteDataStoreProgressMessage `protobuf:"bytes,19,opt,name=progress_message,json=progressMessage,proto3,oneof"`
+}
+
+type RemoteDataStoreMessage_ExtensionReadyEvent struct {
+	ExtensionReadyEvent *ExtensionReadyEvent `protobuf:"bytes,20,opt,name=extension_ready_event,json=extensionReadyEvent,proto3,oneof"`
+}
+
+func (*RemoteDataStoreMessage_RegisterRemoteDataStoreRequest) isRemoteDataStoreMessage_MessageType() {
+}
+
+func (*RemoteDataStoreMessage_RegisterRemoteDataStoreResponse) isRemoteDataStoreMessage_MessageType() {
+}
+
+func (*RemoteDataStoreMessage_InitializeRequest) isRemoteDataStoreMessage_MessageType() {}
+
+func (*RemoteDataStoreMessage_InitializeResponse) isRemoteDataStoreMessage_MessageType() {}
+
+func (*RemoteDataStoreMessage_ListRequest) isRemoteDataStoreMessage_MessageType() {}
+
+func (*RemoteDataStoreMessage_ListResponse) isRemoteDataStoreMessage_MessageType() {}
+
+func (*RemoteDataStoreMessage_GetRequest) isRemoteDataStoreMessage_MessageType() {}
+
+func (*RemoteDataStoreMessage_GetResponse) isRemoteDataStoreMessage_MessageType() {}
+
+func (*RemoteDataStoreMessage_SaveRequest) isRemoteDataStoreMessage_MessageType() {}
+
+func (*RemoteDataStoreMessage_SaveResponse) isRemoteDataStoreMessage_MessageType() {}
+
+func (*RemoteDataStoreMessage_DeleteRequest) isRemoteDataStoreMessage_MessageType() {}
+
+func (*RemoteDataStoreMessage_DeleteResponse) isRemoteDataStoreMessage_MessageType() {}
+
+func (*RemoteDataStoreMessage_LockRequest) isRemoteDataStoreMessage_MessageType() {}
+
+func (*RemoteDataStoreMessage_LockResponse) isRemoteDataStoreMessage_MessageType() {}
+
+func (*RemoteDataStoreMessage_UnlockRequest) isRemoteDataStoreMessage_MessageType() {}
+
+func (*RemoteDataStoreMessage_UnlockResponse) isRemoteDataStoreMessage_MessageType() {}
+
+func (*RemoteDataStoreMessage_ProgressMessage) isRemoteDataStoreMessage_MessageType() {}
+
+func (*RemoteDataStoreMessage_ExtensionReadyEvent) isRemoteDataStoreMessage_MessageType() {}
+
+// Error returned by the extension for a failed request
+type RemoteDataStoreErrorMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string                   `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Code    RemoteDataStoreErrorCode `protobuf:"varint,2,opt,name=code,proto3,enum=azdext.RemoteDataStoreErrorCode" json:"code,omitempty"`
+	// The holder of the environment lock when the environment is locked.
+	Lock *EnvironmentLock `protobuf:"bytes,3,opt,name=lock,proto3" json:"lock,omitempty"`
+}
+
+func (x *RemoteDataStoreErrorMessage) Reset() {
+	*x = RemoteDataStoreErrorMessage{}
+	mi := &file_remote_data_store_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoteDataStoreErrorMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteDataStoreErrorMessage) ProtoMessage() {}
+
+func (x *RemoteDataStoreErrorMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_data_store_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteDataStoreErrorMessage.ProtoReflect.Descriptor instead.
+func (*RemoteDataStoreErrorMessage) Descriptor() ([]byte, []int) {
+	return file_remote_data_store_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RemoteDataStoreErrorMessage) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RemoteDataStoreErrorMessage) GetCode() RemoteDataStoreErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return RemoteDataStoreErrorCode_REMOTE_DATA_STORE_ERROR_CODE_UNSPECIFIED
+}
+
+func (x *RemoteDataStoreErrorMessage) GetLock() *EnvironmentLock {
+	if x != nil {
+		return x.Lock
+	}
+	return nil
+}
+
+// Client registers the remote data store of the extension for a backend, ex) backend: s3
+type RegisterRemoteDataStoreRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Backend string `protobuf:"bytes,1,opt,name=backend,proto3" json:"backend,omitempty"`
+}
+
+func (x *RegisterRemoteDataStoreRequest) Reset() {
+	*x = RegisterRemoteDataStoreRequest{}
+	mi := &file_remote_data_store_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterRemoteDataStoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterRemoteDataStoreRequest) ProtoMessage() {}
+
+func (x *RegisterRemoteDataStoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_data_store_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterRemoteDataStoreRequest.ProtoReflect.Descriptor instead.
+func (*RegisterRemoteDataStoreRequest) Descriptor() ([]byte, []int) {
+	return file_remote_data_store_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RegisterRemoteDataStoreRequest) GetBackend() string {
+	if x != nil {
+		return x.Backend
+	}
+	return ""
+}
+
+// Server confirms the registration of the remote data store
+type RegisterRemoteDataStoreResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RegisterRemoteDataStoreResponse) Reset() {
+	*x = RegisterRemoteDataStoreResponse{}
+	mi := &file_remote_data_store_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterRemoteDataStoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterRemoteDataStoreResponse) ProtoMessage() {}
+
+func (x *RegisterRemoteDataStoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_data_store_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterRemoteDataStoreResponse.ProtoReflect.Descriptor instead.
+func (*RegisterRemoteDataStoreResponse) Descriptor() ([]byte, []int) {
+	return file_remote_data_store_proto_rawDescGZIP(), []int{3}
+}
+
+// Server requests the data store to initialize with the remote state configuration of the project
+type RemoteDataStoreInitializeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The JSON encoded `state.remote.config` section.
+	Config []byte `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+}
+
+func (x *RemoteDataStoreInitializeRequest) Reset() {
+	*x = RemoteDataStoreInitializeRequest{}
+	mi := &file_remote_data_store_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoteDataStoreInitializeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteDataStoreInitializeRequest) ProtoMessage() {}
+
+func (x *RemoteDataStoreInitializeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_data_store_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteDataStoreInitializeRequest.ProtoReflect.Descriptor instead.
+func (*RemoteDataStoreInitializeRequest) Descriptor() ([]byte, []int) {
+	return file_remote_data_store_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RemoteDataStoreInitializeRequest) GetConfig() []byte {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+type RemoteDataStoreInitializeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RemoteDataStoreInitializeResponse) Reset() {
+	*x = RemoteDataStoreInitializeResponse{}
+	mi := &file_remote_data_store_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoteDataStoreInitializeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteDataStoreInitializeResponse) ProtoMessage() {}
+
+func (x *RemoteDataStoreInitializeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_data_store_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteDataStoreInitializeResponse.ProtoReflect.Descriptor instead.
+func (*RemoteDataStoreInitializeResponse) Descriptor() ([]byte, []int) {
+	return file_remote_data_store_proto_rawDescGZIP(), []int{5}
+}
+
+// Server requests the environments within the data store
+type RemoteDataStoreListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RemoteDataStoreListRequest) Reset() {
+	*x = RemoteDataStoreListRequest{}
+	mi := &file_remote_data_store_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoteDataStoreListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteDataStoreListRequest) ProtoMessage() {}
+
+func (x *RemoteDataStoreListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_data_store_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteDataStoreListRequest.ProtoReflect.Descriptor instead.
+func (*RemoteDataStoreListRequest) Descriptor() ([]byte, []int) {
+	return file_remote_data_store_proto_rawDescGZIP(), []int{6}
+}
+
+type RemoteDataStoreListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Environments []*RemoteEnvironmentDescription `protobuf:"bytes,1,rep,name=environments,proto3" json:"environments,omitempty"`
+}
+
+func (x *RemoteDataStoreListResponse) Reset() {
+	*x = RemoteDataStoreListResponse{}
+	mi := &file_remote_data_store_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoteDataStoreListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteDataStoreListResponse) ProtoMessage() {}
+
+func (x *RemoteDataStoreListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_data_store_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteDataStoreListResponse.ProtoReflect.Descriptor instead.
+func (*RemoteDataStoreListResponse) Descriptor() ([]byte, []int) {
+	return file_remote_data_store_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RemoteDataStoreListResponse) GetEnvironments() []*RemoteEnvironmentDescription {
+	if x != nil {
+		return x.Environments
+	}
+	return nil
+}
+
+// Server requests the state of an environment
+type RemoteDataStoreGetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *RemoteDataStoreGetRequest) Reset() {
+	*x = RemoteDataStoreGetRequest{}
+	mi := &file_remote_data_store_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoteDataStoreGetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteDataStoreGetRequest) ProtoMessage() {}
+
+func (x *RemoteDataStoreGetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_data_store_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteDataStoreGetRequest.ProtoReflect.Descriptor instead.
+func (*RemoteDataStoreGetRequest) Descriptor() ([]byte, []int) {
+	return file_remote_data_store_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RemoteDataStoreGetRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type RemoteDataStoreGetResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Environment *RemoteEnvironment `protobuf:"bytes,1,opt,name=environment,proto3" json:"environment,omitempty"`
+}
+
+func (x *RemoteDataStoreGetResponse) Reset() {
+	*x = RemoteDataStoreGetResponse{}
+	mi := &file_remote_data_store_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoteDataStoreGetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteDataStoreGetResponse) ProtoMessage() {}
+
+func (x *RemoteDataStoreGetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_data_store_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteDataStoreGetResponse.ProtoReflect.Descriptor instead.
+func (*RemoteDataStoreGetResponse) Descriptor() ([]byte, []int) {
+	return file_remote_data_store_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RemoteDataStoreGetResponse) GetEnvironment() *RemoteEnvironment {
+	if x != nil {
+		return x.Environment
+	}
+	return nil
+}
+
+// Server requests the data store to save the state of an environment
+type RemoteDataStoreSaveRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Environment *RemoteEnvironment `protobuf:"bytes,1,opt,name=environment,proto3" json:"environment,omitempty"`
+	// Whether the environment is new.
+	IsNew bool `protobuf:"varint,2,opt,name=is_new,json=isNew,proto3" json:"is_new,omitempty"`
+}
+
+func (x *RemoteDataStoreSaveRequest) Reset() {
+	*x = RemoteDataStoreSaveRequest{}
+	mi := &file_remote_data_store_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoteDataStoreSaveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteDataStoreSaveRequest) ProtoMessage() {}
+
+func (x *RemoteDataStoreSaveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_data_store_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteDataStoreSaveRequest.ProtoReflect.Descriptor instead.
+func (*RemoteDataStoreSaveRequest) Descriptor() ([]byte, []int) {
+	return file_remote_data_store_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RemoteDataStoreSaveRequest) GetEnvironment() *RemoteEnvironment {
+	if x != nil {
+		return x.Environment
+	}
+	return nil
+}
+
+func (x *RemoteDataStoreSaveRequest) GetIsNew() bool {
+	if x != nil {
+		return x.IsNew
+	}
+	return false
+}
+
+type RemoteDataStoreSaveResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The version of the saved environment.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *RemoteDataStoreSaveResponse) Reset() {
+	*x = RemoteDataStoreSaveResponse{}
+	mi := &file_remote_data_store_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoteDataStoreSaveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteDataStoreSaveResponse) ProtoMessage() {}
+
+func (x *RemoteDataStoreSaveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_data_store_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteDataStoreSaveResponse.ProtoReflect.Descriptor instead.
+func (*RemoteDataStoreSaveResponse) Descriptor() ([]byte, []int) {
+	return file_remote_data_store_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RemoteDataStoreSaveResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+// Server requests the data store to delete an environment
+type RemoteDataStoreDeleteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *RemoteDataStoreDeleteRequest) Reset() {
+	*x = RemoteDataStoreDeleteRequest{}
+	mi := &file_remote_data_store_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoteDataStoreDeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteDataStoreDeleteRequest) ProtoMessage() {}
+
+func (x *RemoteDataStoreDeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_data_store_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteDataStoreDeleteRequest.ProtoReflect.Descriptor instead.
+func (*RemoteDataStoreDeleteRequest) Descriptor() ([]byte, []int) {
+	return file_remote_data_store_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RemoteDataStoreDeleteRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type RemoteDataStoreDeleteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RemoteDataStoreDeleteResponse) Reset() {
+	*x = RemoteDataStoreDeleteResponse{}
+	mi := &file_remote_data_store_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoteDataStoreDeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteDataStoreDeleteResponse) ProtoMessage() {}
+
+func (x *RemoteDataStoreDeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_data_store_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteDataStoreDeleteResponse.ProtoReflect.Descriptor instead.
+func (*RemoteDataStoreDeleteResponse) Descriptor() ([]byte, []int) {
+	return file_remote_data_store_proto_rawDescGZIP(), []int{13}
+}
+
+// Server requests the data store to acquire the lock of an environment
+type RemoteDataStoreLockRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string           `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Lock *EnvironmentLock `protobuf:"bytes,2,opt,name=lock,proto3" json:"lock,omitempty"`
+}
+
+func (x *RemoteDataStoreLockRequest) Reset() {
+	*x = RemoteDataStoreLockRequest{}
+	mi := &file_remote_data_store_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoteDataStoreLockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteDataStoreLockRequest) ProtoMessage() {}
+
+func (x *RemoteDataStoreLockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_data_store_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteDataStoreLockRequest.ProtoReflect.Descriptor instead.
+func (*RemoteDataStoreLockRequest) Descriptor() ([]byte, []int) {
+	return file_remote_data_store_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RemoteDataStoreLockRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RemoteDataStoreLockRequest) GetLock() *EnvironmentLock {
+	if x != nil {
+		return x.Lock
+	}
+	return nil
+}
+
+type RemoteDataStoreLockResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RemoteDataStoreLockResponse) Reset() {
+	*x = RemoteDataStoreLockResponse{}
+	mi := &file_remote_data_store_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoteDataStoreLockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteDataStoreLockResponse) ProtoMessage() {}
+
+func (x *RemoteDataStoreLockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_data_store_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteDataStoreLockResponse.ProtoReflect.Descriptor instead.
+func (*RemoteDataStoreLockResponse) Descriptor() ([]byte, []int) {
+	return file_remote_data_store_proto_rawDescGZIP(), []int{15}
+}
+
+// Server requests the data store to release the lock of an environment.
+// When the lock id is empty the lock is released regardless of its holder.
+type RemoteDataStoreUnlockRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	LockId string `protobuf:"bytes,2,opt,name=lock_id,json=lockId,proto3" json:"lock_id,omitempty"`
+}
+
+func (x *RemoteDataStoreUnlockRequest) Reset() {
+	*x = RemoteDataStoreUnlockRequest{}
+	mi := &file_remote_data_store_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoteDataStoreUnlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteDataStoreUnlockRequest) ProtoMessage() {}
+
+func (x *RemoteDataStoreUnlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_data_store_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteDataStoreUnlockRequest.ProtoReflect.Descriptor instead.
+func (*RemoteDataStoreUnlockRequest) Descriptor() ([]byte, []int) {
+	return file_remote_data_store_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RemoteDataStoreUnlockRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RemoteDataStoreUnlockRequest) GetLockId() string {
+	if x != nil {
+		return x.LockId
+	}
+	return ""
+}
+
+type RemoteDataStoreUnlockResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RemoteDataStoreUnlockResponse) Reset() {
+	*x = RemoteDataStoreUnlockResponse{}
+	mi := &file_remote_data_store_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoteDataStoreUnlockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteDataStoreUnlockResponse) ProtoMessage() {}
+
+func (x *RemoteDataStoreUnlockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_data_store_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteDataStoreUnlockResponse.ProtoReflect.Descriptor instead.
+func (*RemoteDataStoreUnlockResponse) Descriptor() ([]byte, []int) {
+	return file_remote_data_store_proto_rawDescGZIP(), []int{17}
+}
+
+// Client reports the progress of a request
+type RemoteDataStoreProgressMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *RemoteDataStoreProgressMessage) Reset() {
+	*x = RemoteDataStoreProgressMessage{}
+	mi := &file_remote_data_store_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoteDataStoreProgressMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteDataStoreProgressMessage) ProtoMessage() {}
+
+func (x *RemoteDataStoreProgressMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_data_store_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteDataStoreProgressMessage.ProtoReflect.Descriptor instead.
+func (*RemoteDataStoreProgressMessage) Descriptor() ([]byte, []int) {
+	return file_remote_data_store_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RemoteDataStoreProgressMessage) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// An environment within a remote data store
+type RemoteEnvironmentDescription struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The location of the .env file within the data store.
+	DotEnvPath string `protobuf:"bytes,2,opt,name=dot_env_path,json=dotEnvPath,proto3" json:"dot_env_path,omitempty"`
+	// The location of the config.json file within the data store.
+	ConfigPath string `protobuf:"bytes,3,opt,name=config_path,json=configPath,proto3" json:"config_path,omitempty"`
+}
+
+func (x *RemoteEnvironmentDescription) Reset() {
+	*x = RemoteEnvironmentDescription{}
+	mi := &file_remote_data_store_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoteEnvironmentDescription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteEnvironmentDescription) ProtoMessage() {}
+
+func (x *RemoteEnvironmentDescription) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_data_store_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteEnvironmentDescription.ProtoReflect.Descriptor instead.
+func (*RemoteEnvironmentDescription) Descriptor() ([]byte, []int) {
+	return file_remote_data_store_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RemoteEnvironmentDescription) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RemoteEnvironmentDescription) GetDotEnvPath() string {
+	if x != nil {
+		return x.DotEnvPath
+	}
+	return ""
+}
+
+func (x *RemoteEnvironmentDescription) GetConfigPath() string {
+	if x != nil {
+		return x.ConfigPath
+	}
+	return ""
+}
+
+// The state of an environment stored within a remote data store
+type RemoteEnvironment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The values of the .env file.
+	Dotenv map[string]string `protobuf:"bytes,2,rep,name=dotenv,proto3" json:"dotenv,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The JSON encoded content of the config.json file.
+	Config []byte `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
+	// The version of the environment last read or written, used to detect concurrent modifications.
+	// Empty when the environment has not been read or written.
+	Version string `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *RemoteEnvironment) Reset() {
+	*x = RemoteEnvironment{}
+	mi := &file_remote_data_store_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoteEnvironment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteEnvironment) ProtoMessage() {}
+
+func (x *RemoteEnvironment) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_data_store_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteEnvironment.ProtoReflect.Descriptor instead.
+func (*RemoteEnvironment) Descriptor() ([]byte, []int) {
+	return file_remote_data_store_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RemoteEnvironment) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RemoteEnvironment) GetDotenv() map[string]string {
+	if x != nil {
+		return x.Dotenv
+	}
+	return nil
+}
+
+func (x *RemoteEnvironment) GetConfig() []byte {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *RemoteEnvironment) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+// The holder of an environment lock
+type EnvironmentLock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The user and host that acquired the lock, ex) alice@workstation
+	Owner string `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	// The operation that acquired the lock, ex) azd provision
+	Operation string `protobuf:"bytes,3,opt,name=operation,proto3" json:"operation,omitempty"`
+	// The time the lock was acquired, in RFC 3339 format.
+	AcquiredAt string `protobuf:"bytes,4,opt,name=acquired_at,json=acquiredAt,proto3" json:"acquired_at,omitempty"`
+	// The time the lock expires, in RFC 3339 format. Locks without an expiration time never expire.
+	ExpiresAt string `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+}
+
+func (x *EnvironmentLock) Reset() {
+	*x = EnvironmentLock{}
+	mi := &file_remote_data_store_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnvironmentLock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnvironmentLock) ProtoMessage() {}
+
+func (x *EnvironmentLock) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_data_store_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnvironmentLock.ProtoReflect.Descriptor instead.
+func (*EnvironmentLock) Descriptor() ([]byte, []int) {
+	return file_remote_data_store_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *EnvironmentLock) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EnvironmentLock) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *EnvironmentLock) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *EnvironmentLock) GetAcquiredAt() string {
+	if x != nil {
+		return x.AcquiredAt
+	}
+	return ""
+}
+
+func (x *EnvironmentLock) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+var File_remote_data_store_proto protoreflect.FileDescriptor
+
+var file_remote_data_store_proto_rawDesc = []byte{
+	0x0a, 0x17, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x61, 0x7a, 0x64, 0x65, 0x78,
+	0x74, 0x1a, 0x0b, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe2,
+	0x0c, 0x0a, 0x16, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x6f,
+	0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74,
+	0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x65,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x12, 0x74, 0x0a, 0x22, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x5f,
+	0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x26, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
+	0x72, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x1e, 0x72, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x65, 0x72, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x6f,
+	0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x77, 0x0a, 0x23, 0x72, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x64, 0x61, 0x74,
+	0x61, 0x5f, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e,
+	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61,
+	0x74, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48,
+	0x00, 0x52, 0x1f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x6d, 0x6f, 0x74,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x59, 0x0a, 0x12, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65,
+	0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28,
+	0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61,
+	0x74, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x11, 0x69, 0x6e, 0x69, 0x74,
+	0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x5c, 0x0a,
+	0x13, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x61, 0x7a, 0x64,
+	0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74,
+	0x6f, 0x72, 0x65, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x12, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c,
+	0x69, 0x7a, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0c, 0x6c,
+	0x69, 0x73, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x22, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x4a, 0x0a, 0x0d, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x72, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x61, 0x7a,
+	0x64, 0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x53,
+	0x74, 0x6f, 0x72, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x48, 0x00, 0x52, 0x0c, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x44, 0x0a, 0x0b, 0x67, 0x65, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x52,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x47, 0x65,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x67, 0x65, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x47, 0x0a, 0x0c, 0x67, 0x65, 0x74, 0x5f, 0x72, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x61,
+	0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61,
+	0x53, 0x74, 0x6f, 0x72, 0x65, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x48, 0x00, 0x52, 0x0b, 0x67, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x47, 0x0a, 0x0c, 0x73, 0x61, 0x76, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x52,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x61,
+	0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x61, 0x76,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4a, 0x0a, 0x0d, 0x73, 0x61, 0x76, 0x65,
+	0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x23, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44,
+	0x61, 0x74, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x61, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x73, 0x61, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0e, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x61,
+	0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61,
+	0x53, 0x74, 0x6f, 0x72, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x50, 0x0a, 0x0f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x72, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x61,
+	0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61,
+	0x53, 0x74, 0x6f, 0x72, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0e, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0c, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x61, 0x7a,
+	0x64, 0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x53,
+	0x74, 0x6f, 0x72, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
+	0x00, 0x52, 0x0b, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4a,
+	0x0a, 0x0d, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18,
+	0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x52,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4c, 0x6f,
+	0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x6c, 0x6f,
+	0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0e, 0x75, 0x6e,
+	0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x11, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x24, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x55, 0x6e, 0x6c, 0x6f, 0x63,
+	0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x75, 0x6e, 0x6c, 0x6f,
+	0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x50, 0x0a, 0x0f, 0x75, 0x6e, 0x6c,
+	0x6f, 0x63, 0x6b, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x12, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x25, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x55, 0x6e, 0x6c, 0x6f, 0x63,
+	0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0e, 0x75, 0x6e, 0x6c,
+	0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x10, 0x70,
+	0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x52,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x50, 0x72,
+	0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52,
+	0x0f, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x51, 0x0a, 0x15, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65,
+	0x61, 0x64, 0x79, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x61, 0x64, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x13,
+	0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x61, 0x64, 0x79, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x42, 0x0e, 0x0a, 0x0c, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x74,
+	0x79, 0x70, 0x65, 0x22, 0x9a, 0x01, 0x0a, 0x1b, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61,
+	0x74, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x34, 0x0a,
+	0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x61, 0x7a,
+	0x64, 0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x53,
+	0x74, 0x6f, 0x72, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63,
+	0x6f, 0x64, 0x65, 0x12, 0x2b, 0x0a, 0x04, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x45, 0x6e, 0x76, 0x69, 0x72,
+	0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x04, 0x6c, 0x6f, 0x63, 0x6b,
+	0x22, 0x3a, 0x0a, 0x1e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x22, 0x21, 0x0a, 0x1f,
+	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61,
+	0x74, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x3a, 0x0a, 0x20, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x6f,
+	0x72, 0x65, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x23, 0x0a, 0x21, 0x52,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x49, 0x6e,
+	0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x1c, 0x0a, 0x1a, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74,
+	0x6f, 0x72, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x67,
+	0x0a, 0x1b, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x6f, 0x72,
+	0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a,
+	0x0c, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x6d,
+	0x6f, 0x74, 0x65, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x44, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x65, 0x6e, 0x76, 0x69, 0x72,
+	0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x2f, 0x0a, 0x19, 0x52, 0x65, 0x6d, 0x6f, 0x74,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x59, 0x0a, 0x1a, 0x52, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x47, 0x65, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f,
+	0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x61, 0x7a,
+	0x64, 0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x45, 0x6e, 0x76, 0x69, 0x72,
+	0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x0b, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d,
+	0x65, 0x6e, 0x74, 0x22, 0x70, 0x0a, 0x1a, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74,
+	0x61, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x61, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x3b, 0x0a, 0x0b, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e,
+	0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e,
+	0x74, 0x52, 0x0b, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x15,
+	0x0a, 0x06, 0x69, 0x73, 0x5f, 0x6e, 0x65, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05,
+	0x69, 0x73, 0x4e, 0x65, 0x77, 0x22, 0x37, 0x0a, 0x1b, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44,
+	0x61, 0x74, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x61, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x32,
+	0x0a, 0x1c, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x6f, 0x72,
+	0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x22, 0x1f, 0x0a, 0x1d, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61,
+	0x53, 0x74, 0x6f, 0x72, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x5d, 0x0a, 0x1a, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74,
+	0x61, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2b, 0x0a, 0x04, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x45, 0x6e, 0x76,
+	0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x04, 0x6c, 0x6f,
+	0x63, 0x6b, 0x22, 0x1d, 0x0a, 0x1b, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61,
+	0x53, 0x74, 0x6f, 0x72, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x4b, 0x0a, 0x1c, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x53,
+	0x74, 0x6f, 0x72, 0x65, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x22, 0x1f,
+	0x0a, 0x1d, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x6f, 0x72,
+	0x65, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x3a, 0x0a, 0x1e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x6f,
+	0x72, 0x65, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x75, 0x0a, 0x1c, 0x52,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74,
+	0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x20, 0x0a, 0x0c, 0x64, 0x6f, 0x74, 0x5f, 0x65, 0x6e, 0x76, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x6f, 0x74, 0x45, 0x6e, 0x76, 0x50, 0x61, 0x74,
+	0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x70, 0x61, 0x74, 0x68,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x50, 0x61,
+	0x74, 0x68, 0x22, 0xd3, 0x01, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x45, 0x6e, 0x76,
+	0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3d, 0x0a, 0x06,
+	0x64, 0x6f, 0x74, 0x65, 0x6e, 0x76, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x61,
+	0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x45, 0x6e, 0x76, 0x69,
+	0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x44, 0x6f, 0x74, 0x65, 0x6e, 0x76, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x06, 0x64, 0x6f, 0x74, 0x65, 0x6e, 0x76, 0x12, 0x16, 0x0a, 0x06, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x1a, 0x39, 0x0a,
+	0x0b, 0x44, 0x6f, 0x74, 0x65, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x95, 0x01, 0x0a, 0x0f, 0x45, 0x6e, 0x76,
+	0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x63, 0x6b, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e,
+	0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x63, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x63, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x41,
+	0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74,
+	0x2a, 0xc8, 0x01, 0x0a, 0x18, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x53,
+	0x74, 0x6f, 0x72, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x2c, 0x0a,
+	0x28, 0x52, 0x45, 0x4d, 0x4f, 0x54, 0x45, 0x5f, 0x44, 0x41, 0x54, 0x41, 0x5f, 0x53, 0x54, 0x4f,
+	0x52, 0x45, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x2a, 0x0a, 0x26, 0x52,
+	0x45, 0x4d, 0x4f, 0x54, 0x45, 0x5f, 0x44, 0x41, 0x54, 0x41, 0x5f, 0x53, 0x54, 0x4f, 0x52, 0x45,
+	0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x4e, 0x4f, 0x54, 0x5f,
+	0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x01, 0x12, 0x29, 0x0a, 0x25, 0x52, 0x45, 0x4d, 0x4f, 0x54,
+	0x45, 0x5f, 0x44, 0x41, 0x54, 0x41, 0x5f, 0x53, 0x54, 0x4f, 0x52, 0x45, 0x5f, 0x45, 0x52, 0x52,
+	0x4f, 0x52, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x4c, 0x49, 0x43, 0x54,
+	0x10, 0x02, 0x12, 0x27, 0x0a, 0x23, 0x52, 0x45, 0x4d, 0x4f, 0x54, 0x45, 0x5f, 0x44, 0x41, 0x54,
+	0x41, 0x5f, 0x53, 0x54, 0x4f, 0x52, 0x45, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4f,
+	0x44, 0x45, 0x5f, 0x4c, 0x4f, 0x43, 0x4b, 0x45, 0x44, 0x10, 0x03, 0x32, 0x66, 0x0a, 0x16, 0x52,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4c, 0x0a, 0x06, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12,
+	0x1e, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44,
+	0x61, 0x74, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a,
+	0x1e, 0x2e, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44,
+	0x61, 0x74, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x28,
+	0x01, 0x30, 0x01, 0x42, 0x36, 0x5a, 0x34, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x61, 0x7a, 0x75, 0x72, 0x65, 0x2f, 0x61, 0x7a, 0x75, 0x72, 0x65, 0x2d, 0x64, 0x65,
+	0x76, 0x2f, 0x63, 0x6c, 0x69, 0x2f, 0x61, 0x7a, 0x64, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x7a,
+	0x64, 0x65, 0x78, 0x74, 0x3b, 0x61, 0x7a, 0x64, 0x65, 0x78, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_remote_data_store_proto_rawDescOnce sync.Once
+	file_remote_data_store_proto_rawDescData = file_remote_data_store_proto_rawDesc
+)
+
+func file_remote_data_store_proto_rawDescGZIP() []byte {
+	file_remote_data_store_proto_rawDescOnce.Do(func() {
+		file_remote_data_store_proto_rawDescData = protoimpl.X.CompressGZIP(file_remote_data_store_proto_rawDescData)
+	})
+	return file_remote_data_store_proto_rawDescData
+}
+
+var file_remote_data_store_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_remote_data_store_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_remote_data_store_proto_goTypes = []any{
+	(RemoteDataStoreErrorCode)(0),             // 0: azdext.RemoteDataStoreErrorCode
+	(*RemoteDataStoreMessage)(nil),            // 1: azdext.RemoteDataStoreMessage
+	(*RemoteDataStoreErrorMessage)(nil),       // 2: azdext.RemoteDataStoreErrorMessage
+	(*RegisterRemoteDataStoreRequest)(nil),    // 3: azdext.RegisterRemoteDataStoreRequest
+	(*RegisterRemoteDataStoreResponse)(nil),   // 4: azdext.RegisterRemoteDataStoreResponse
+	(*RemoteDataStoreInitializeRequest)(nil),  // 5: azdext.RemoteDataStoreInitializeRequest
+	(*RemoteDataStoreInitializeResponse)(nil), // 6: azdext.RemoteDataStoreInitializeResponse
+	(*RemoteDataStoreListRequest)(nil),        // 7: azdext.RemoteDataStoreListRequest
+	(*RemoteDataStoreListResponse)(nil),       // 8: azdext.RemoteDataStoreListResponse
+	(*RemoteDataStoreGetRequest)(nil),         // 9: azdext.RemoteDataStoreGetRequest
+	(*RemoteDataStoreGetResponse)(nil),        // 10: azdext.RemoteDataStoreGetResponse
+	(*RemoteDataStoreSaveRequest)(nil),        // 11: azdext.RemoteDataStoreSaveRequest
+	(*RemoteDataStoreSaveResponse)(nil),       // 12: azdext.RemoteDataStoreSaveResponse
+	(*RemoteDataStoreDeleteRequest)(nil),      // 13: azdext.RemoteDataStoreDeleteRequest
+	(*RemoteDataStoreDeleteResponse)(nil),     // 14: azdext.RemoteDataStoreDeleteResponse
+	(*RemoteDataStoreLockRequest)(nil),        // 15: azdext.RemoteDataStoreLockRequest
+	(*RemoteDataStoreLockResponse)(nil),       // 16: azdext.RemoteDataStoreLockResponse
+	(*RemoteDataStoreUnlockRequest)(nil),      // 17: azdext.RemoteDataStoreUnlockRequest
+	(*RemoteDataStoreUnlockResponse)(nil),     // 18: azdext.RemoteDataStoreUnlockResponse
+	(*RemoteDataStoreProgressMessage)(nil),    // 19: azdext.RemoteDataStoreProgressMessage
+	(*RemoteEnvironmentDescription)(nil),      // 20: azdext.RemoteEnvironmentDescription
+	(*RemoteEnvironment)(nil),                 // 21: azdext.RemoteEnvironment
+	(*EnvironmentLock)(nil),                   // 22: azdext.EnvironmentLock
+	nil,                                       // 23: azdext.RemoteEnvironment.DotenvEntry
+	(*ExtensionReadyEvent)(nil),               // 24: azdext.ExtensionReadyEvent
+}
+var file_remote_data_store_proto_depIdxs = []int32{
+	2,  // 0: azdext.RemoteDataStoreMessage.error:type_name -> azdext.RemoteDataStoreErrorMessage
+	3,  // 1: azdext.RemoteDataStoreMessage.register_remote_data_store_request:type_name -> azdext.RegisterRemoteDataStoreRequest
+	4,  // 2: azdext.RemoteDataStoreMessage.register_remote_data_store_response:type_name -> azdext.RegisterRemoteDataStoreResponse
+	5,  // 3: azdext.RemoteDataStoreMessage.initialize_request:type_name -> azdext.RemoteDataStoreInitializeRequest
+	6,  // 4: azdext.RemoteDataStoreMessage.initialize_response:type_name -> azdext.RemoteDataStoreInitializeResponse
+	7,  // 5: azdext.RemoteDataStoreMessage.list_request:type_name -> azdext.RemoteDataStoreListRequest
+	8,  // 6: azdext.RemoteDataStoreMessage.list_response:type_name -> azdext.RemoteDataStoreListResponse
+	9,  // 7: azdext.RemoteDataStoreMessage.get_request:type_name -> azdext.RemoteDataStoreGetRequest
+	10, // 8: azdext.RemoteDataStoreMessage.get_response:type_name -> azdext.RemoteDataStoreGetResponse
+	11, // 9: azdext.RemoteDataStoreMessage.save_request:type_name -> azdext.RemoteDataStoreSaveRequest
+	12, // 10: azdext.RemoteDataStoreMessage.save_response:type_name -> azdext.RemoteDataStoreSaveResponse
+	13, // 11: azdext.RemoteDataStoreMessage.delete_request:type_name -> azdext.RemoteDataStoreDeleteRequest
+	14, // 12: azdext.RemoteDataStoreMessage.delete_response:type_name -> azdext.RemoteDataStoreDeleteResponse
+	15, // 13: azdext.RemoteDataStoreMessage.lock_request:type_name -> azdext.RemoteDataStoreLockRequest
+	16, // 14: azdext.RemoteDataStoreMessage.lock_response:type_name -> azdext.RemoteDataStoreLockResponse
+	17, // 15: azdext.RemoteDataStoreMessage.unlock_request:type_name -> azdext.RemoteDataStoreUnlockRequest
+	18, // 16: azdext.RemoteDataStoreMessage.unlock_response:type_name -> azdext.RemoteDataStoreUnlockResponse
+	19, // 17: azdext.RemoteDataStoreMessage.progress_message:type_name -> azdext.RemoteDataStoreProgressMessage
+	24, // 18: azdext.RemoteDataStoreMessage.extension_ready_event:type_name -> azdext.ExtensionReadyEvent
+	0,  // 19: azdext.RemoteDataStoreErrorMessage.code:type_name -> azdext.RemoteDataStoreErrorCode
+	22, // 20: azdext.RemoteDataStoreErrorMessage.lock:type_name -> azdext.EnvironmentLock
+	20, // 21: azdext.RemoteDataStoreListResponse.environments:type_name -> azdext.RemoteEnvironmentDescription
+	21, // 22: azdext.RemoteDataStoreGetResponse.environment:type_name -> azdext.RemoteEnvironment
+	21, // 23: azdext.RemoteDataStoreSaveRequest.environment:type_name -> azdext.RemoteEnvironment
+	22, // 24: azdext.RemoteDataStoreLockRequest.lock:type_name -> azdext.EnvironmentLock
+	23, // 25: azdext.RemoteEnvironment.dotenv:type_name -> azdext.RemoteEnvironment.DotenvEntry
+	1,  // 26: azdext.RemoteDataStoreService.Stream:input_type -> azdext.RemoteDataStoreMessage
+	1,  // 27: azdext.RemoteDataStoreService.Stream:output_type -> azdext.RemoteDataStoreMessage
+	27, // [27:28] is the sub-list for method output_type
+	26, // [26:27] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
+}
+
+func init() { file_remote_data_store_proto_init() }
+func file_remote_data_store_proto_init() {
+	if File_remote_data_store_proto != nil {
+		return
+	}
+	file_event_proto_init()
+	file_remote_data_store_proto_msgTypes[0].OneofWrappers = []any{
+		(*RemoteDataStoreMessage_RegisterRemoteDataStoreRequest)(nil),
+		(*RemoteDataStoreMessage_RegisterRemoteDataStoreResponse)(nil),
+		(*RemoteDataStoreMessage_InitializeRequest)(nil),
+		(*RemoteDataStoreMessage_InitializeResponse)(nil),
+		(*RemoteDataStoreMessage_ListRequest)(nil),
+		(*RemoteDataStoreMessage_ListResponse)(nil),
+		(*RemoteDataStoreMessage_GetRequest)(nil),
+		(*RemoteDataStoreMessage_GetResponse)(nil),
+		(*RemoteDataStoreMessage_SaveRequest)(nil),
+		(*RemoteDataStoreMessage_SaveResponse)(nil),
+		(*RemoteDataStoreMessage_DeleteRequest)(nil),
+		(*RemoteDataStoreMessage_DeleteResponse)(nil),
+		(*RemoteDataStoreMessage_LockRequest)(nil),
+		(*RemoteDataStoreMessage_LockResponse)(nil),
+		(*RemoteDataStoreMessage_UnlockRequest)(nil),
+		(*RemoteDataStoreMessage_UnlockResponse)(nil),
+		(*RemoteDataStoreMessage_ProgressMessage)(nil),
+		(*RemoteDataStoreMessage_ExtensionReadyEvent)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_remote_data_store_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   23,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_remote_data_store_proto_goTypes,
+		DependencyIndexes: file_remote_data_store_proto_depIdxs,
+		EnumInfos:         file_remote_data_store_proto_enumTypes,
+		MessageInfos:      file_remote_data_store_proto_msgTypes,
+	}.Build()
+	File_remote_data_store_proto = out.File
+	file_remote_data_store_proto_rawDesc = nil
+	file_remote_data_store_proto_goTypes = nil
+	file_remote_data_store_proto_depIdxs = nil
+}
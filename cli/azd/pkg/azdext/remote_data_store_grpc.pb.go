@@ -0,0 +1,129 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.1
+// source: remote_data_store.proto
+
+package azdext
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RemoteDataStoreService_Stream_FullMethodName = "/azdext.RemoteDataStoreService/Stream"
+)
+
+// RemoteDataStoreServiceClient is the client API for RemoteDataStoreService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// RemoteDataStoreService enables extensions to provide remote state backends that store the environments of projects.
+// Projects select the backend of an extension by setting `state.remote.backend` to the name of the backend in azure.yaml.
+// The extension handles the data store requests sent by azd over a bidirectional stream.
+type RemoteDataStoreServiceClient interface {
+	// Bidirectional stream for remote data store registration, requests and responses.
+	Stream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[RemoteDataStoreMessage, RemoteDataStoreMessage], error)
+}
+
+type remoteDataStoreServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRemoteDataStoreServiceClient(cc grpc.ClientConnInterface) RemoteDataStoreServiceClient {
+	return &remoteDataStoreServiceClient{cc}
+}
+
+func (c *remoteDataStoreServiceClient) Stream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[RemoteDataStoreMessage, RemoteDataStoreMessage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RemoteDataStoreService_ServiceDesc.Streams[0], RemoteDataStoreService_Stream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RemoteDataStoreMessage, RemoteDataStoreMessage]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RemoteDataStoreService_StreamClient = grpc.BidiStreamingClient[RemoteDataStoreMessage, RemoteDataStoreMessage]
+
+// RemoteDataStoreServiceServer is the server API for RemoteDataStoreService service.
+// All implementations must embed UnimplementedRemoteDataStoreServiceServer
+// for forward compatibility.
+//
+// RemoteDataStoreService enables extensions to provide remote state backends that store the environments of projects.
+// Projects select the backend of an extension by setting `state.remote.backend` to the name of the backend in azure.yaml.
+// The extension handles the data store requests sent by azd over a bidirectional stream.
+type RemoteDataStoreServiceServer interface {
+	// Bidirectional stream for remote data store registration, requests and responses.
+	Stream(grpc.BidiStreamingServer[RemoteDataStoreMessage, RemoteDataStoreMessage]) error
+	mustEmbedUnimplementedRemoteDataStoreServiceServer()
+}
+
+// UnimplementedRemoteDataStoreServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRemoteDataStoreServiceServer struct{}
+
+func (UnimplementedRemoteDataStoreServiceServer) Stream(grpc.BidiStreamingServer[RemoteDataStoreMessage, RemoteDataStoreMessage]) error {
+	return status.Errorf(codes.Unimplemented, "method Stream not implemented")
+}
+func (UnimplementedRemoteDataStoreServiceServer) mustEmbedUnimplementedRemoteDataStoreServiceServer() {
+}
+func (UnimplementedRemoteDataStoreServiceServer) testEmbeddedByValue() {}
+
+// UnsafeRemoteDataStoreServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RemoteDataStoreServiceServer will
+// result in compilation errors.
+type UnsafeRemoteDataStoreServiceServer interface {
+	mustEmbedUnimplementedRemoteDataStoreServiceServer()
+}
+
+func RegisterRemoteDataStoreServiceServer(s grpc.ServiceRegistrar, srv RemoteDataStoreServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRemoteDataStoreServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RemoteDataStoreService_ServiceDesc, srv)
+}
+
+func _RemoteDataStoreService_Stream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RemoteDataStoreServiceServer).Stream(&grpc.GenericServerStream[RemoteDataStoreMessage, RemoteDataStoreMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RemoteDataStoreService_StreamServer = grpc.BidiStreamingServer[RemoteDataStoreMessage, RemoteDataStoreMessage]
+
+// RemoteDataStoreService_ServiceDesc is the grpc.ServiceDesc for RemoteDataStoreService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RemoteDataStoreService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "azdext.RemoteDataStoreService",
+	HandlerType: (*RemoteDataStoreServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Stream",
+			Handler:       _RemoteDataStoreService_Stream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "remote_data_store.proto",
+}
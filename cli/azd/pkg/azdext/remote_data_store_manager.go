@@ -0,0 +1,200 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azdext
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrEnvironmentNotFound is returned by remote data stores for environments that don't exist
+	ErrEnvironmentNotFound = errors.New("environment not found")
+
+	// ErrEnvironmentConflict is returned by remote data stores when an environment has been modified by another
+	// operation since it was last read or written
+	ErrEnvironmentConflict = errors.New("environment has been modified by another operation")
+)
+
+// EnvironmentLockedError is returned by remote data stores when an environment is locked by another operation
+type EnvironmentLockedError struct {
+	// The holder of the lock, if known
+	Lock *EnvironmentLock
+}
+
+func (e *EnvironmentLockedError) Error() string {
+	if e.Lock == nil {
+		return "environment is locked"
+	}
+
+	return fmt.Sprintf("environment is locked by '%s' (%s)", e.Lock.Operation, e.Lock.Owner)
+}
+
+// RemoteDataStoreProvider is implemented by extensions to store the environments of projects that set
+// `state.remote.backend` to the backend of the extension.
+type RemoteDataStoreProvider interface {
+	// Initializes the data store with the `state.remote.config` section of the project.
+	Initialize(ctx context.Context, config map[string]any) error
+
+	// List gets the environments within the data store.
+	List(ctx context.Context) ([]*RemoteEnvironmentDescription, error)
+
+	// Get gets the state of the environment. Returns ErrEnvironmentNotFound when the environment doesn't exist.
+	Get(ctx context.Context, name string) (*RemoteEnvironment, error)
+
+	// Save saves the state of the environment and returns its new version.
+	// Returns ErrEnvironmentConflict when the stored environment doesn't match the version of the environment.
+	Save(ctx context.Context, env *RemoteEnvironment, isNew bool) (string, error)
+
+	// Delete deletes the environment. Returns ErrEnvironmentNotFound when the environment doesn't exist.
+	Delete(ctx context.Context, name string) error
+
+	// Lock acquires the lock of the environment.
+	// Returns an *EnvironmentLockedError when the environment is already locked.
+	Lock(ctx context.Context, name string, lock *EnvironmentLock) error
+
+	// Unlock releases the lock of the environment when held by the lock with the specified id.
+	// When the id is empty the lock is released regardless of its holder.
+	Unlock(ctx context.Context, name string, lockId string) error
+}
+
+// RemoteDataStoreManager registers the remote data store of an extension with azd and handles the data store requests
+// sent by azd.
+type RemoteDataStoreManager struct {
+	stream   *providerStream[RemoteDataStoreMessage, *RemoteDataStoreMessage]
+	provider RemoteDataStoreProvider
+}
+
+func NewRemoteDataStoreManager(azdClient *AzdClient) *RemoteDataStoreManager {
+	return &RemoteDataStoreManager{
+		stream: newProviderStream[RemoteDataStoreMessage](azdClient.RemoteDataStore().Stream),
+	}
+}
+
+func (m *RemoteDataStoreManager) Close() error {
+	return m.stream.close()
+}
+
+// Register registers the data store of the extension for the specified backend, ex) s3
+// The backend must be declared as a `remote-data-store` provider of the extension.
+// The data store must be registered before calling Receive.
+func (m *RemoteDataStoreManager) Register(
+	ctx context.Context,
+	backend string,
+	provider RemoteDataStoreProvider,
+) error {
+	err := m.stream.register(ctx, &RemoteDataStoreMessage{
+		MessageType: &RemoteDataStoreMessage_RegisterRemoteDataStoreRequest{
+			RegisterRemoteDataStoreRequest: &RegisterRemoteDataStoreRequest{
+				Backend: backend,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register remote data store '%s': %w", backend, err)
+	}
+
+	m.provider = provider
+
+	return nil
+}
+
+// Receive signals azd that the extension is ready and handles the data store requests sent by azd.
+// This is a blocking call and will not return until the server connection is closed.
+func (m *RemoteDataStoreManager) Receive(ctx context.Context) error {
+	return m.stream.receive(ctx, m.handleRequest)
+}
+
+// handleRequest invokes the provider for the request and returns the response to send back to azd
+func (m *RemoteDataStoreManager) handleRequest(
+	ctx context.Context,
+	msg *RemoteDataStoreMessage,
+	progress ProgressReporter,
+) *RemoteDataStoreMessage {
+	response := &RemoteDataStoreMessage{}
+
+	if m.provider == nil {
+		response.Error = &RemoteDataStoreErrorMessage{Message: "no remote data store has been registered"}
+		return response
+	}
+
+	var err error
+	switch request := msg.MessageType.(type) {
+	case *RemoteDataStoreMessage_InitializeRequest:
+		var config map[string]any
+		if len(request.InitializeRequest.Config) > 0 {
+			err = json.Unmarshal(request.InitializeRequest.Config, &config)
+		}
+
+		if err == nil {
+			err = m.provider.Initialize(ctx, config)
+		}
+
+		response.MessageType = &RemoteDataStoreMessage_InitializeResponse{
+			InitializeResponse: &RemoteDataStoreInitializeResponse{},
+		}
+	case *RemoteDataStoreMessage_ListRequest:
+		var environments []*RemoteEnvironmentDescription
+		environments, err = m.provider.List(ctx)
+		response.MessageType = &RemoteDataStoreMessage_ListResponse{
+			ListResponse: &RemoteDataStoreListResponse{Environments: environments},
+		}
+	case *RemoteDataStoreMessage_GetRequest:
+		var env *RemoteEnvironment
+		env, err = m.provider.Get(ctx, request.GetRequest.Name)
+		response.MessageType = &RemoteDataStoreMessage_GetResponse{
+			GetResponse: &RemoteDataStoreGetResponse{Environment: env},
+		}
+	case *RemoteDataStoreMessage_SaveRequest:
+		var version string
+		version, err = m.provider.Save(ctx, request.SaveRequest.Environment, request.SaveRequest.IsNew)
+		response.MessageType = &RemoteDataStoreMessage_SaveResponse{
+			SaveResponse: &RemoteDataStoreSaveResponse{Version: version},
+		}
+	case *RemoteDataStoreMessage_DeleteRequest:
+		err = m.provider.Delete(ctx, request.DeleteRequest.Name)
+		response.MessageType = &RemoteDataStoreMessage_DeleteResponse{
+			DeleteResponse: &RemoteDataStoreDeleteResponse{},
+		}
+	case *RemoteDataStoreMessage_LockRequest:
+		err = m.provider.Lock(ctx, request.LockRequest.Name, request.LockRequest.Lock)
+		response.MessageType = &RemoteDataStoreMessage_LockResponse{
+			LockResponse: &RemoteDataStoreLockResponse{},
+		}
+	case *RemoteDataStoreMessage_UnlockRequest:
+		err = m.provider.Unlock(ctx, request.UnlockRequest.Name, request.UnlockRequest.LockId)
+		response.MessageType = &RemoteDataStoreMessage_UnlockResponse{
+			UnlockResponse: &RemoteDataStoreUnlockResponse{},
+		}
+	default:
+		err = fmt.Errorf("unsupported remote data store message type %T", msg.MessageType)
+	}
+
+	if err != nil {
+		response.Error = remoteDataStoreError(err)
+	}
+
+	return response
+}
+
+// remoteDataStoreError converts the error of a request into the error message sent to azd, with the code of the
+// failures azd handles
+func remoteDataStoreError(err error) *RemoteDataStoreErrorMessage {
+	message := &RemoteDataStoreErrorMessage{Message: err.Error()}
+
+	var lockedErr *EnvironmentLockedError
+	switch {
+	case errors.As(err, &lockedErr):
+		message.Code = RemoteDataStoreErrorCode_REMOTE_DATA_STORE_ERROR_CODE_LOCKED
+		message.Lock = lockedErr.Lock
+	case errors.Is(err, ErrEnvironmentNotFound):
+		message.Code = RemoteDataStoreErrorCode_REMOTE_DATA_STORE_ERROR_CODE_NOT_FOUND
+	case errors.Is(err, ErrEnvironmentConflict):
+		message.Code = RemoteDataStoreErrorCode_REMOTE_DATA_STORE_ERROR_CODE_CONFLICT
+	}
+
+	return message
+}
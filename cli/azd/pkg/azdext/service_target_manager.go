@@ -0,0 +1,166 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azdext
+
+import (
+	"context"
+	"fmt"
+)
+
+// ServiceTargetProvider is implemented by extensions to provide the service target of a custom service host.
+type ServiceTargetProvider interface {
+	// Initializes the service target for the specified service configuration.
+	Initialize(ctx context.Context, serviceConfig *ServiceConfig) error
+
+	// Package prepares the artifacts produced by the framework service for deployment.
+	Package(
+		ctx context.Context,
+		serviceConfig *ServiceConfig,
+		frameworkPackage *ServicePackageResult,
+		progress ProgressReporter,
+	) (*ServicePackageResult, error)
+
+	// Deploy deploys the given package to the target resource.
+	Deploy(
+		ctx context.Context,
+		serviceConfig *ServiceConfig,
+		servicePackage *ServicePackageResult,
+		targetResource *TargetResource,
+		progress ProgressReporter,
+	) (*ServiceDeployResult, error)
+
+	// Endpoints gets the endpoints a service exposes.
+	Endpoints(ctx context.Context, serviceConfig *ServiceConfig, targetResource *TargetResource) ([]string, error)
+}
+
+// ProgressReporter reports the progress of a long running operation to azd.
+type ProgressReporter func(message string)
+
+// ServiceTargetManager registers service target providers with azd and handles the service target requests sent by azd.
+type ServiceTargetManager struct {
+	stream    *providerStream[ServiceTargetMessage, *ServiceTargetMessage]
+	providers map[string]ServiceTargetProvider
+}
+
+func NewServiceTargetManager(azdClient *AzdClient) *ServiceTargetManager {
+	return &ServiceTargetManager{
+		stream:    newProviderStream[ServiceTargetMessage](azdClient.ServiceTarget().Stream),
+		providers: make(map[string]ServiceTargetProvider),
+	}
+}
+
+func (m *ServiceTargetManager) Close() error {
+	return m.stream.close()
+}
+
+// Register registers the provider as the service target for the specified service host, ex) vm
+// Services declaring the host in azure.yaml are deployed by the provider.
+// All providers must be registered before calling Receive.
+func (m *ServiceTargetManager) Register(ctx context.Context, host string, provider ServiceTargetProvider) error {
+	err := m.stream.register(ctx, &ServiceTargetMessage{
+		MessageType: &ServiceTargetMessage_RegisterServiceTargetRequest{
+			RegisterServiceTargetRequest: &RegisterServiceTargetRequest{
+				Host: host,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register service host '%s': %w", host, err)
+	}
+
+	m.providers[host] = provider
+
+	return nil
+}
+
+// Receive signals azd that the extension is ready and handles the service target requests sent by azd.
+// This is a blocking call and will not return until the server connection is closed.
+func (m *ServiceTargetManager) Receive(ctx context.Context) error {
+	return m.stream.receive(ctx, m.handleRequest)
+}
+
+// handleRequest invokes the provider for the request and returns the response to send back to azd
+func (m *ServiceTargetManager) handleRequest(
+	ctx context.Context,
+	msg *ServiceTargetMessage,
+	progress ProgressReporter,
+) *ServiceTargetMessage {
+	response := &ServiceTargetMessage{}
+
+	var err error
+	switch request := msg.MessageType.(type) {
+	case *ServiceTargetMessage_InitializeRequest:
+		var provider ServiceTargetProvider
+		if provider, err = m.provider(request.InitializeRequest.ServiceConfig); err == nil {
+			err = provider.Initialize(ctx, request.InitializeRequest.ServiceConfig)
+		}
+
+		response.MessageType = &ServiceTargetMessage_InitializeResponse{
+			InitializeResponse: &ServiceTargetInitializeResponse{},
+		}
+	case *ServiceTargetMessage_PackageRequest:
+		var provider ServiceTargetProvider
+		var result *ServicePackageResult
+		if provider, err = m.provider(request.PackageRequest.ServiceConfig); err == nil {
+			result, err = provider.Package(
+				ctx,
+				request.PackageRequest.ServiceConfig,
+				request.PackageRequest.FrameworkPackage,
+				progress,
+			)
+		}
+
+		response.MessageType = &ServiceTargetMessage_PackageResponse{
+			PackageResponse: &ServiceTargetPackageResponse{Result: result},
+		}
+	case *ServiceTargetMessage_DeployRequest:
+		var provider ServiceTargetProvider
+		var result *ServiceDeployResult
+		if provider, err = m.provider(request.DeployRequest.ServiceConfig); err == nil {
+			result, err = provider.Deploy(
+				ctx,
+				request.DeployRequest.ServiceConfig,
+				request.DeployRequest.ServicePackage,
+				request.DeployRequest.TargetResource,
+				progress,
+			)
+		}
+
+		response.MessageType = &ServiceTargetMessage_DeployResponse{
+			DeployResponse: &ServiceTargetDeployResponse{Result: result},
+		}
+	case *ServiceTargetMessage_EndpointsRequest:
+		var provider ServiceTargetProvider
+		var endpoints []string
+		if provider, err = m.provider(request.EndpointsRequest.ServiceConfig); err == nil {
+			endpoints, err = provider.Endpoints(
+				ctx,
+				request.EndpointsRequest.ServiceConfig,
+				request.EndpointsRequest.TargetResource,
+			)
+		}
+
+		response.MessageType = &ServiceTargetMessage_EndpointsResponse{
+			EndpointsResponse: &ServiceTargetEndpointsResponse{Endpoints: endpoints},
+		}
+	default:
+		err = fmt.Errorf("unsupported service target message type %T", msg.MessageType)
+	}
+
+	if err != nil {
+		response.Error = &ServiceTargetErrorMessage{Message: err.Error()}
+	}
+
+	return response
+}
+
+// provider returns the provider registered for the host of the service
+func (m *ServiceTargetManager) provider(serviceConfig *ServiceConfig) (ServiceTargetProvider, error) {
+	provider, has := m.providers[serviceConfig.GetHost()]
+	if !has {
+		return nil, fmt.Errorf("no service target provider registered for host '%s'", serviceConfig.GetHost())
+	}
+
+	return provider, nil
+}
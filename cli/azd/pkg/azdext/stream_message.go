@@ -0,0 +1,177 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azdext
+
+// StreamMessage is a message of the bidirectional streams between azd and the providers registered by an extension,
+// ex) ServiceTargetMessage. The responses and progress reports of a request carry the id of the request.
+type StreamMessage interface {
+	GetRequestId() string
+	SetRequestId(requestId string)
+
+	// Failure returns the error message of a failed response, false when the message didn't fail
+	Failure() (string, bool)
+	// SetFailure marks the response as failed with the specified error message
+	SetFailure(message string)
+
+	// Progress returns the message of a progress report, false when the message isn't a progress report
+	Progress() (string, bool)
+	// SetProgress turns the message into a progress report with the specified message
+	SetProgress(message string)
+
+	// SetExtensionReady turns the message into the event signaling azd that the extension is ready
+	SetExtensionReady()
+}
+
+var (
+	_ StreamMessage = (*ServiceTargetMessage)(nil)
+	_ StreamMessage = (*FrameworkServiceMessage)(nil)
+	_ StreamMessage = (*ProvisioningMessage)(nil)
+	_ StreamMessage = (*RemoteDataStoreMessage)(nil)
+)
+
+func (x *ServiceTargetMessage) SetRequestId(requestId string) {
+	x.RequestId = requestId
+}
+
+func (x *ServiceTargetMessage) Failure() (string, bool) {
+	if x.Error == nil {
+		return "", false
+	}
+
+	return x.Error.Message, true
+}
+
+func (x *ServiceTargetMessage) SetFailure(message string) {
+	x.Error = &ServiceTargetErrorMessage{Message: message}
+}
+
+func (x *ServiceTargetMessage) Progress() (string, bool) {
+	if progress := x.GetProgressMessage(); progress != nil {
+		return progress.Message, true
+	}
+
+	return "", false
+}
+
+func (x *ServiceTargetMessage) SetProgress(message string) {
+	x.MessageType = &ServiceTargetMessage_ProgressMessage{
+		ProgressMessage: &ServiceTargetProgressMessage{Message: message},
+	}
+}
+
+func (x *ServiceTargetMessage) SetExtensionReady() {
+	x.MessageType = &ServiceTargetMessage_ExtensionReadyEvent{
+		ExtensionReadyEvent: &ExtensionReadyEvent{Status: "ready"},
+	}
+}
+
+func (x *FrameworkServiceMessage) SetRequestId(requestId string) {
+	x.RequestId = requestId
+}
+
+func (x *FrameworkServiceMessage) Failure() (string, bool) {
+	if x.Error == nil {
+		return "", false
+	}
+
+	return x.Error.Message, true
+}
+
+func (x *FrameworkServiceMessage) SetFailure(message string) {
+	x.Error = &FrameworkServiceErrorMessage{Message: message}
+}
+
+func (x *FrameworkServiceMessage) Progress() (string, bool) {
+	if progress := x.GetProgressMessage(); progress != nil {
+		return progress.Message, true
+	}
+
+	return "", false
+}
+
+func (x *FrameworkServiceMessage) SetProgress(message string) {
+	x.MessageType = &FrameworkServiceMessage_ProgressMessage{
+		ProgressMessage: &FrameworkServiceProgressMessage{Message: message},
+	}
+}
+
+func (x *FrameworkServiceMessage) SetExtensionReady() {
+	x.MessageType = &FrameworkServiceMessage_ExtensionReadyEvent{
+		ExtensionReadyEvent: &ExtensionReadyEvent{Status: "ready"},
+	}
+}
+
+func (x *ProvisioningMessage) SetRequestId(requestId string) {
+	x.RequestId = requestId
+}
+
+func (x *ProvisioningMessage) Failure() (string, bool) {
+	if x.Error == nil {
+		return "", false
+	}
+
+	return x.Error.Message, true
+}
+
+func (x *ProvisioningMessage) SetFailure(message string) {
+	x.Error = &ProvisioningErrorMessage{Message: message}
+}
+
+func (x *ProvisioningMessage) Progress() (string, bool) {
+	if progress := x.GetProgressMessage(); progress != nil {
+		return progress.Message, true
+	}
+
+	return "", false
+}
+
+func (x *ProvisioningMessage) SetProgress(message string) {
+	x.MessageType = &ProvisioningMessage_ProgressMessage{
+		ProgressMessage: &ProvisioningProgressMessage{Message: message},
+	}
+}
+
+func (x *ProvisioningMessage) SetExtensionReady() {
+	x.MessageType = &ProvisioningMessage_ExtensionReadyEvent{
+		ExtensionReadyEvent: &ExtensionReadyEvent{Status: "ready"},
+	}
+}
+
+func (x *RemoteDataStoreMessage) SetRequestId(requestId string) {
+	x.RequestId = requestId
+}
+
+// Failure returns the error message of a failed response. Failures with an error code, ex) an environment that doesn't
+// exist, are not reported as failures since they are returned as responses to the data store.
+func (x *RemoteDataStoreMessage) Failure() (string, bool) {
+	if x.Error == nil || x.Error.Code != RemoteDataStoreErrorCode_REMOTE_DATA_STORE_ERROR_CODE_UNSPECIFIED {
+		return "", false
+	}
+
+	return x.Error.Message, true
+}
+
+func (x *RemoteDataStoreMessage) SetFailure(message string) {
+	x.Error = &RemoteDataStoreErrorMessage{Message: message}
+}
+
+func (x *RemoteDataStoreMessage) Progress() (string, bool) {
+	if progress := x.GetProgressMessage(); progress != nil {
+		return progress.Message, true
+	}
+
+	return "", false
+}
+
+func (x *RemoteDataStoreMessage) SetProgress(message string) {
+	x.MessageType = &RemoteDataStoreMessage_ProgressMessage{
+		ProgressMessage: &RemoteDataStoreProgressMessage{Message: message},
+	}
+}
+
+func (x *RemoteDataStoreMessage) SetExtensionReady() {
+	x.MessageType = &RemoteDataStoreMessage_ExtensionReadyEvent{
+		ExtensionReadyEvent: &ExtensionReadyEvent{Status: "ready"},
+	}
+}
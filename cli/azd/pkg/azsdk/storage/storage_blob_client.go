@@ -12,7 +12,10 @@ import (
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
 	"github.com/azure/azure-dev/cli/azd/pkg/auth"
 	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 )
@@ -26,17 +29,42 @@ type AccountConfig struct {
 
 var (
 	ErrContainerNotFound = errors.New("container not found")
+	ErrBlobNotFound      = errors.New("blob not found")
+	ErrConditionNotMet   = errors.New("blob access condition not met")
 )
 
+// UploadOptions contains the optional access conditions of a blob upload
+type UploadOptions struct {
+	// When set, the blob is only uploaded when the ETag of the existing blob matches
+	IfMatch string
+	// When true, the blob is only uploaded when the blob does not already exist
+	IfNoneMatch bool
+}
+
+// DeleteOptions contains the optional access conditions of a blob delete
+type DeleteOptions struct {
+	// When set, the blob is only deleted when the ETag of the existing blob matches
+	IfMatch string
+}
+
+// BlobReader is the content of a downloaded blob
+type BlobReader struct {
+	io.ReadCloser
+	// The ETag of the downloaded version of the blob
+	ETag string
+}
+
 type BlobClient interface {
 	// Download downloads a blob from the configured storage account container.
-	Download(ctx context.Context, blobPath string) (io.ReadCloser, error)
+	Download(ctx context.Context, blobPath string) (*BlobReader, error)
 
-	// Upload uploads a blob to the configured storage account container.
-	Upload(ctx context.Context, blobPath string, reader io.Reader) error
+	// Upload uploads a blob to the configured storage account container and returns the ETag of the uploaded blob.
+	// Returns ErrConditionNotMet when the access conditions of the options are not met.
+	Upload(ctx context.Context, blobPath string, reader io.Reader, options *UploadOptions) (string, error)
 
 	// Delete deletes a blob from the configured storage account container.
-	Delete(ctx context.Context, blobPath string) error
+	// Returns ErrConditionNotMet when the access conditions of the options are not met.
+	Delete(ctx context.Context, blobPath string, options *DeleteOptions) error
 
 	// Items returns a list of blobs in the configured storage account container.
 	Items(ctx context.Context) ([]*Blob, error)
@@ -81,12 +109,12 @@ func (bc *blobClient) Items(ctx context.Context) ([]*Blob, error) {
 			return nil, fmt.Errorf("failed to get next page of blobs, %w", err)
 		}
 
-		for _, blob := range page.Segment.BlobItems {
+		for _, item := range page.Segment.BlobItems {
 			blobs = append(blobs, &Blob{
-				Name:         filepath.Base(*blob.Name),
-				Path:         *blob.Name,
-				CreationTime: *blob.Properties.CreationTime,
-				LastModified: *blob.Properties.LastModified,
+				Name:         filepath.Base(*item.Name),
+				Path:         *item.Name,
+				CreationTime: *item.Properties.CreationTime,
+				LastModified: *item.Properties.LastModified,
 			})
 		}
 	}
@@ -95,47 +123,101 @@ func (bc *blobClient) Items(ctx context.Context) ([]*Blob, error) {
 }
 
 // Download downloads a blob from the configured storage account container.
-func (bc *blobClient) Download(ctx context.Context, blobPath string) (io.ReadCloser, error) {
+func (bc *blobClient) Download(ctx context.Context, blobPath string) (*BlobReader, error) {
 	if err := bc.ensureContainerExists(ctx); err != nil {
 		return nil, err
 	}
 
 	resp, err := bc.client.DownloadStream(ctx, bc.config.ContainerName, blobPath, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to download blob '%s', %w", blobPath, err)
+		return nil, fmt.Errorf("failed to download blob '%s', %w", blobPath, describeBlobError(err))
+	}
+
+	blobReader := &BlobReader{
+		ReadCloser: resp.Body,
+	}
+
+	if resp.ETag != nil {
+		blobReader.ETag = string(*resp.ETag)
 	}
 
-	return resp.Body, nil
+	return blobReader, nil
 }
 
-// Upload uploads a blob to the configured storage account container.
-func (bc *blobClient) Upload(ctx context.Context, blobPath string, reader io.Reader) error {
+// Upload uploads a blob to the configured storage account container and returns the ETag of the uploaded blob.
+func (bc *blobClient) Upload(
+	ctx context.Context,
+	blobPath string,
+	reader io.Reader,
+	options *UploadOptions,
+) (string, error) {
 	if err := bc.ensureContainerExists(ctx); err != nil {
-		return err
+		return "", err
+	}
+
+	uploadOptions := &azblob.UploadStreamOptions{}
+	if options != nil && (options.IfMatch != "" || options.IfNoneMatch) {
+		conditions := &blob.ModifiedAccessConditions{}
+		if options.IfMatch != "" {
+			conditions.IfMatch = to.Ptr(azcore.ETag(options.IfMatch))
+		}
+
+		if options.IfNoneMatch {
+			conditions.IfNoneMatch = to.Ptr(azcore.ETagAny)
+		}
+
+		uploadOptions.AccessConditions = &blob.AccessConditions{
+			ModifiedAccessConditions: conditions,
+		}
 	}
 
-	_, err := bc.client.UploadStream(ctx, bc.config.ContainerName, blobPath, reader, nil)
+	resp, err := bc.client.UploadStream(ctx, bc.config.ContainerName, blobPath, reader, uploadOptions)
 	if err != nil {
-		return fmt.Errorf("failed to upload blob '%s', %w", blobPath, err)
+		return "", fmt.Errorf("failed to upload blob '%s', %w", blobPath, describeBlobError(err))
 	}
 
-	return nil
+	if resp.ETag == nil {
+		return "", nil
+	}
+
+	return string(*resp.ETag), nil
 }
 
 // Delete deletes a blob from the configured storage account container.
-func (bc *blobClient) Delete(ctx context.Context, blobPath string) error {
+func (bc *blobClient) Delete(ctx context.Context, blobPath string, options *DeleteOptions) error {
 	if err := bc.ensureContainerExists(ctx); err != nil {
 		return err
 	}
 
-	_, err := bc.client.DeleteBlob(ctx, bc.config.ContainerName, blobPath, nil)
+	deleteOptions := &blob.DeleteOptions{}
+	if options != nil && options.IfMatch != "" {
+		deleteOptions.AccessConditions = &blob.AccessConditions{
+			ModifiedAccessConditions: &blob.ModifiedAccessConditions{
+				IfMatch: to.Ptr(azcore.ETag(options.IfMatch)),
+			},
+		}
+	}
+
+	_, err := bc.client.DeleteBlob(ctx, bc.config.ContainerName, blobPath, deleteOptions)
 	if err != nil {
-		return fmt.Errorf("failed to delete blob '%s', %w", blobPath, err)
+		return fmt.Errorf("failed to delete blob '%s', %w", blobPath, describeBlobError(err))
 	}
 
 	return nil
 }
 
+// describeBlobError wraps the error of a blob operation with the matching sentinel error, if any
+func describeBlobError(err error) error {
+	switch {
+	case bloberror.HasCode(err, bloberror.BlobNotFound):
+		return fmt.Errorf("%w: %w", ErrBlobNotFound, err)
+	case bloberror.HasCode(err, bloberror.ConditionNotMet, bloberror.BlobAlreadyExists):
+		return fmt.Errorf("%w: %w", ErrConditionNotMet, err)
+	default:
+		return err
+	}
+}
+
 // Check if the specified container exists
 // If it doesn't already exist then create it
 func (bc *blobClient) ensureContainerExists(ctx context.Context) error {
@@ -5,25 +5,78 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azfile/directory"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azfile/file"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azfile/fileerror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azfile/lease"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azfile/service"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azfile/share"
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
 )
 
+// FileShareConfig is the configuration for an existing Azure Files share
+type FileShareConfig struct {
+	SubscriptionId string
+	AccountName    string
+	ShareName      string
+	Endpoint       string
+}
+
+// ShareUrl returns the URL of the file share
+func (c *FileShareConfig) ShareUrl() string {
+	return fmt.Sprintf("https://%s.file.%s/%s", c.AccountName, c.Endpoint, c.ShareName)
+}
+
 type FileShareService interface {
 	// Upload files from source path to a file share
 	UploadPath(ctx context.Context, subId, shareUrl, source string) error
+	// Upload the content of the reader to the file at the specified path, creating any missing parent directories.
+	// Paths within the file share are separated by '/'.
+	UploadFile(ctx context.Context, subId, shareUrl, filePath string, reader io.Reader) error
+	// Download the file at the specified path
+	DownloadFile(ctx context.Context, subId, shareUrl, filePath string) (io.ReadCloser, error)
+	// List the paths of all files within the file share
+	ListFiles(ctx context.Context, subId, shareUrl string) ([]string, error)
+	// Delete the file at the specified path, returns an error wrapping os.ErrNotExist when the file does not exist
+	DeleteFile(ctx context.Context, subId, shareUrl, filePath string) error
+	// Delete the empty directory at the specified path
+	DeleteDirectory(ctx context.Context, subId, shareUrl, dirPath string) error
+	// Create the file at the specified path with the content of the reader, holding an infinite lease on the file with
+	// the specified lease id, which must be a GUID. Returns an error wrapping ErrFileLeased when the file is already
+	// leased, acquisition is atomic across concurrent callers.
+	CreateLeasedFile(ctx context.Context, subId, shareUrl, filePath, leaseId string, reader io.Reader) error
+	// Delete the file at the specified path when it is leased with the specified lease id. Returns an error wrapping
+	// ErrFileLeased when the file is not leased with the lease id, or os.ErrNotExist when the file does not exist.
+	DeleteLeasedFile(ctx context.Context, subId, shareUrl, filePath, leaseId string) error
+	// Break the lease of the file at the specified path, allowing the file to be modified or deleted. Returns an error
+	// wrapping os.ErrNotExist when the file does not exist.
+	BreakFileLease(ctx context.Context, subId, shareUrl, filePath string) error
 }
 
+// ErrFileLeased is returned when creating a file leased by another client
+var ErrFileLeased = errors.New("file is leased")
+
+// Error codes returned for leased files, not defined by the fileerror package
+const (
+	leaseAlreadyPresentCode fileerror.Code = "LeaseAlreadyPresent"
+	leaseIdMissingCode      fileerror.Code = "LeaseIdMissing"
+	leaseNotPresentCode     fileerror.Code = "LeaseNotPresentWithLeaseOperation"
+	leaseIdMismatchFileCode fileerror.Code = "LeaseIdMismatchWithFileOperation"
+	leaseNotPresentFileCode fileerror.Code = "LeaseNotPresentWithFileOperation"
+)
+
 func NewFileShareService(
 	accountCreds account.SubscriptionCredentialProvider,
 	options *arm.ClientOptions,
@@ -77,31 +130,285 @@ func (f *fileShareClient) UploadPath(ctx context.Context, subId, shareUrl, sourc
 
 }
 
-// uploadFile implements FileShareService.
-func (f *fileShareClient) uploadFile(
-	ctx context.Context, fileShareUrl, source, dest string, credential azcore.TokenCredential) error {
+func (f *fileShareClient) UploadFile(
+	ctx context.Context, subId, shareUrl, filePath string, reader io.Reader) error {
+	client, err := f.shareClient(ctx, subId, shareUrl)
+	if err != nil {
+		return err
+	}
 
-	client, err := share.NewClient(fileShareUrl, credential, &share.ClientOptions{
-		ClientOptions:     f.options.ClientOptions,
-		FileRequestIntent: to.Ptr(service.ShareTokenIntentBackup),
+	dirClient, err := ensureDirectories(ctx, client, strings.Split(filePath, "/"))
+	if err != nil {
+		return err
+	}
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	fClient := dirClient.NewFileClient(path.Base(filePath))
+	if _, err := fClient.Create(ctx, int64(len(content)), nil); err != nil {
+		return fmt.Errorf("creating file '%s': %w", filePath, err)
+	}
+
+	if len(content) == 0 {
+		return nil
+	}
+
+	if err := fClient.UploadBuffer(ctx, content, nil); err != nil {
+		return fmt.Errorf("uploading file '%s': %w", filePath, err)
+	}
+
+	return nil
+}
+
+func (f *fileShareClient) DownloadFile(
+	ctx context.Context, subId, shareUrl, filePath string) (io.ReadCloser, error) {
+	client, err := f.shareClient(ctx, subId, shareUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	fClient := client.NewRootDirectoryClient().NewFileClient(filePath)
+	res, err := fClient.DownloadStream(ctx, nil)
+	if err != nil {
+		if fileerror.HasCode(err, fileerror.ResourceNotFound, fileerror.ParentNotFound) {
+			return nil, fmt.Errorf("downloading file '%s': %w", filePath, os.ErrNotExist)
+		}
+
+		return nil, fmt.Errorf("downloading file '%s': %w", filePath, err)
+	}
+
+	return res.Body, nil
+}
+
+func (f *fileShareClient) ListFiles(ctx context.Context, subId, shareUrl string) ([]string, error) {
+	client, err := f.shareClient(ctx, subId, shareUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{}
+	dirPaths := []string{""}
+	for len(dirPaths) > 0 {
+		dirPath := dirPaths[0]
+		dirPaths = dirPaths[1:]
+
+		dirClient := client.NewRootDirectoryClient()
+		if dirPath != "" {
+			dirClient = client.NewDirectoryClient(dirPath)
+		}
+
+		pager := dirClient.NewListFilesAndDirectoriesPager(nil)
+		for pager.More() {
+			page, err := pager.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("listing files in '%s': %w", dirPath, err)
+			}
+
+			for _, dir := range page.Segment.Directories {
+				dirPaths = append(dirPaths, path.Join(dirPath, *dir.Name))
+			}
+
+			for _, file := range page.Segment.Files {
+				files = append(files, path.Join(dirPath, *file.Name))
+			}
+		}
+	}
+
+	return files, nil
+}
+
+func (f *fileShareClient) DeleteFile(ctx context.Context, subId, shareUrl, filePath string) error {
+	client, err := f.shareClient(ctx, subId, shareUrl)
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.NewRootDirectoryClient().NewFileClient(filePath).Delete(ctx, nil); err != nil {
+		if fileerror.HasCode(err, fileerror.ResourceNotFound, fileerror.ParentNotFound) {
+			return fmt.Errorf("deleting file '%s': %w", filePath, os.ErrNotExist)
+		}
+
+		return fmt.Errorf("deleting file '%s': %w", filePath, err)
+	}
+
+	return nil
+}
+
+func (f *fileShareClient) DeleteDirectory(ctx context.Context, subId, shareUrl, dirPath string) error {
+	client, err := f.shareClient(ctx, subId, shareUrl)
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.NewDirectoryClient(dirPath).Delete(ctx, nil); err != nil {
+		return fmt.Errorf("deleting directory '%s': %w", dirPath, err)
+	}
+
+	return nil
+}
+
+func (f *fileShareClient) CreateLeasedFile(
+	ctx context.Context, subId, shareUrl, filePath, leaseId string, reader io.Reader) error {
+	client, err := f.shareClient(ctx, subId, shareUrl)
+	if err != nil {
+		return err
+	}
+
+	dirClient, err := ensureDirectories(ctx, client, strings.Split(filePath, "/"))
+	if err != nil {
+		return err
+	}
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	// Creating a leased file fails, the lease is acquired once the file exists. When the file is created concurrently,
+	// only one of the callers acquires the lease.
+	fClient := dirClient.NewFileClient(path.Base(filePath))
+	if _, err := fClient.Create(ctx, int64(len(content)), nil); err != nil {
+		if fileerror.HasCode(err, leaseIdMissingCode, leaseAlreadyPresentCode) {
+			return fmt.Errorf("creating file '%s': %w", filePath, ErrFileLeased)
+		}
+
+		return fmt.Errorf("creating file '%s': %w", filePath, err)
+	}
+
+	leaseClient, err := lease.NewFileClient(fClient, &lease.FileClientOptions{LeaseID: &leaseId})
+	if err != nil {
+		return err
+	}
+
+	if _, err := leaseClient.Acquire(ctx, nil); err != nil {
+		if fileerror.HasCode(err, leaseAlreadyPresentCode) {
+			return fmt.Errorf("leasing file '%s': %w", filePath, ErrFileLeased)
+		}
+
+		return fmt.Errorf("leasing file '%s': %w", filePath, err)
+	}
+
+	// The file may have been created again by another caller before the lease was acquired
+	leaseConditions := &file.LeaseAccessConditions{LeaseID: &leaseId}
+	if _, err := fClient.Resize(
+		ctx, int64(len(content)), &file.ResizeOptions{LeaseAccessConditions: leaseConditions}); err != nil {
+		return fmt.Errorf("resizing file '%s': %w", filePath, err)
+	}
+
+	if len(content) == 0 {
+		return nil
+	}
+
+	if err := fClient.UploadBuffer(
+		ctx, content, &file.UploadBufferOptions{LeaseAccessConditions: leaseConditions}); err != nil {
+		return fmt.Errorf("uploading file '%s': %w", filePath, err)
+	}
+
+	return nil
+}
+
+func (f *fileShareClient) DeleteLeasedFile(ctx context.Context, subId, shareUrl, filePath, leaseId string) error {
+	client, err := f.shareClient(ctx, subId, shareUrl)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.NewRootDirectoryClient().NewFileClient(filePath).Delete(ctx, &file.DeleteOptions{
+		LeaseAccessConditions: &file.LeaseAccessConditions{LeaseID: &leaseId},
 	})
+	if err != nil {
+		if fileerror.HasCode(err, fileerror.ResourceNotFound, fileerror.ParentNotFound) {
+			return fmt.Errorf("deleting file '%s': %w", filePath, os.ErrNotExist)
+		}
+
+		if fileerror.HasCode(err, leaseIdMismatchFileCode, leaseNotPresentFileCode) {
+			return fmt.Errorf("deleting file '%s': %w", filePath, ErrFileLeased)
+		}
+
+		return fmt.Errorf("deleting file '%s': %w", filePath, err)
+	}
+
+	return nil
+}
+
+func (f *fileShareClient) BreakFileLease(ctx context.Context, subId, shareUrl, filePath string) error {
+	client, err := f.shareClient(ctx, subId, shareUrl)
+	if err != nil {
+		return err
+	}
+
+	leaseClient, err := lease.NewFileClient(client.NewRootDirectoryClient().NewFileClient(filePath), nil)
 	if err != nil {
 		return err
 	}
 
+	if _, err := leaseClient.Break(ctx, nil); err != nil {
+		if fileerror.HasCode(err, fileerror.ResourceNotFound, fileerror.ParentNotFound) {
+			return fmt.Errorf("breaking lease of file '%s': %w", filePath, os.ErrNotExist)
+		}
+
+		// the file is not leased
+		if fileerror.HasCode(err, leaseNotPresentCode) {
+			return nil
+		}
+
+		return fmt.Errorf("breaking lease of file '%s': %w", filePath, err)
+	}
+
+	return nil
+}
+
+func (f *fileShareClient) shareClient(ctx context.Context, subId, shareUrl string) (*share.Client, error) {
+	credential, err := f.accountCreds.CredentialForSubscription(ctx, subId)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.newShareClient(shareUrl, credential)
+}
+
+func (f *fileShareClient) newShareClient(shareUrl string, credential azcore.TokenCredential) (*share.Client, error) {
+	return share.NewClient(shareUrl, credential, &share.ClientOptions{
+		ClientOptions:     f.options.ClientOptions,
+		FileRequestIntent: to.Ptr(service.ShareTokenIntentBackup),
+	})
+}
+
+// ensureDirectories creates the parent directories of the specified path segments and returns the client of the
+// directory containing the last segment
+func ensureDirectories(ctx context.Context, client *share.Client, segments []string) (*directory.Client, error) {
 	dirClient := client.NewRootDirectoryClient()
-	dirPaths := strings.Split(dest, string(os.PathSeparator))
 	incrementPath := ""
-	for _, dirPath := range dirPaths[:len(dirPaths)-1] {
-		incrementPath = filepath.Join(incrementPath, dirPath)
+	for _, dirPath := range segments[:len(segments)-1] {
+		incrementPath = path.Join(incrementPath, dirPath)
 		dirClient = client.NewDirectoryClient(incrementPath)
 		if _, err := dirClient.Create(ctx, nil); err != nil {
 			if !strings.Contains(err.Error(), "ResourceAlreadyExists") {
-				return err
+				return nil, err
 			}
 		}
 	}
 
+	return dirClient, nil
+}
+
+// uploadFile implements FileShareService.
+func (f *fileShareClient) uploadFile(
+	ctx context.Context, fileShareUrl, source, dest string, credential azcore.TokenCredential) error {
+
+	client, err := f.newShareClient(fileShareUrl, credential)
+	if err != nil {
+		return err
+	}
+
+	dirClient, err := ensureDirectories(ctx, client, strings.Split(dest, string(os.PathSeparator)))
+	if err != nil {
+		return err
+	}
+
 	file, err := os.OpenFile(source, os.O_RDONLY, 0)
 	if err != nil {
 		return err
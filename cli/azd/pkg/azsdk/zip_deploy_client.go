@@ -134,31 +134,77 @@ func (c *ZipDeployClient) BeginDeployTrackStatus(
 	resourceGroup,
 	appName string,
 ) (*runtime.Poller[armappservice.WebAppsClientGetProductionSiteDeploymentStatusResponse], error) {
-	request, err := c.createDeployRequest(ctx, zipFile)
+	client, deploymentStatusId, retryCtx, err := c.beginDeployWithStatusId(ctx, zipFile, subscriptionId)
 	if err != nil {
 		return nil, err
 	}
 
-	response, err := c.pipeline.Do(request)
+	// nolint:lll
+	// Example definition: https://github.com/Azure/azure-rest-api-specs/tree/main/specification/web/resource-manager/Microsoft.Web/stable/2022-03-01/examples/GetSiteDeploymentStatus.json
+	poller, err := client.BeginGetProductionSiteDeploymentStatus(retryCtx, resourceGroup, appName, deploymentStatusId, nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting deployment status: %w", err)
+	}
+
+	return poller, nil
+}
+
+// Deploys the specified application zip to a deployment slot of the azure app service using deployment status api and
+// waits for completion
+func (c *ZipDeployClient) BeginDeployTrackSlotStatus(
+	ctx context.Context,
+	zipFile io.ReadSeeker,
+	subscriptionId,
+	resourceGroup,
+	appName string,
+	slotName string,
+) (*runtime.Poller[armappservice.WebAppsClientGetSlotSiteDeploymentStatusSlotResponse], error) {
+	client, deploymentStatusId, retryCtx, err := c.beginDeployWithStatusId(ctx, zipFile, subscriptionId)
 	if err != nil {
 		return nil, err
 	}
 
+	poller, err := client.BeginGetSlotSiteDeploymentStatusSlot(
+		retryCtx, resourceGroup, appName, slotName, deploymentStatusId, nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting deployment status: %w", err)
+	}
+
+	return poller, nil
+}
+
+// beginDeployWithStatusId starts the zip deployment and returns the id of its deployment status, with the web apps
+// client and the context used to get the deployment status
+func (c *ZipDeployClient) beginDeployWithStatusId(
+	ctx context.Context,
+	zipFile io.ReadSeeker,
+	subscriptionId string,
+) (*armappservice.WebAppsClient, string, context.Context, error) {
+	request, err := c.createDeployRequest(ctx, zipFile)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	response, err := c.pipeline.Do(request)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
 	defer response.Body.Close()
 
 	if !runtime.HasStatusCode(response, http.StatusAccepted) {
-		return nil, runtime.NewResponseError(response)
+		return nil, "", nil, runtime.NewResponseError(response)
 	}
 
 	client, err := armappservice.NewWebAppsClient(subscriptionId, c.cred, c.armClientOptions)
 
 	if err != nil {
-		return nil, fmt.Errorf("creating web app client: %w", err)
+		return nil, "", nil, fmt.Errorf("creating web app client: %w", err)
 	}
 
 	deploymentStatusId := response.Header.Get("Scm-Deployment-Id")
 	if deploymentStatusId == "" {
-		return nil, fmt.Errorf("empty deployment status id")
+		return nil, "", nil, fmt.Errorf("empty deployment status id")
 	}
 
 	// Add 404 to default retry errors in azure-sdk-for-go. We get temporary 404s when the KUDO API received the request
@@ -176,27 +222,19 @@ func (c *ZipDeployClient) BeginDeployTrackStatus(
 		}, http.StatusNotFound), // 404
 	})
 
-	// nolint:lll
-	// Example definition: https://github.com/Azure/azure-rest-api-specs/tree/main/specification/web/resource-manager/Microsoft.Web/stable/2022-03-01/examples/GetSiteDeploymentStatus.json
-	poller, err := client.BeginGetProductionSiteDeploymentStatus(retryCtx, resourceGroup, appName, deploymentStatusId, nil)
-	if err != nil {
-		return nil, fmt.Errorf("getting deployment status: %w", err)
-	}
-
-	return poller, nil
+	return client, deploymentStatusId, retryCtx, nil
 }
 
 func logWebAppDeploymentStatus(
-	res armappservice.WebAppsClientGetProductionSiteDeploymentStatusResponse,
+	res armappservice.CsmDeploymentStatus,
 	traceId string,
 	progressLog func(string),
 ) error {
-	if (res == armappservice.WebAppsClientGetProductionSiteDeploymentStatusResponse{} ||
-		res.CsmDeploymentStatus == armappservice.CsmDeploymentStatus{} ||
-		res.CsmDeploymentStatus.Properties == nil) {
+	if (res == armappservice.CsmDeploymentStatus{} ||
+		res.Properties == nil) {
 		return fmt.Errorf("response or its properties are empty")
 	}
-	properties := res.CsmDeploymentStatus.Properties
+	properties := res.Properties
 	inProgressNumber := int(*properties.NumberOfInstancesInProgress)
 	successNumber := int(*properties.NumberOfInstancesSuccessful)
 	failNumber := int(*properties.NumberOfInstancesFailed)
@@ -264,19 +302,55 @@ func (c *ZipDeployClient) DeployTrackStatus(
 	resourceGroup string,
 	appName string,
 	progressLog func(string)) error {
-	var response armappservice.WebAppsClientGetProductionSiteDeploymentStatusResponse
-
 	poller, err := c.BeginDeployTrackStatus(ctx, zipFile, subscriptionId, resourceGroup, appName)
 	if err != nil {
 		return err
 	}
 
+	return trackDeploymentStatus(ctx, poller, progressLog,
+		func(
+			response armappservice.WebAppsClientGetProductionSiteDeploymentStatusResponse,
+		) armappservice.CsmDeploymentStatus {
+			return response.CsmDeploymentStatus
+		})
+}
+
+// DeployTrackSlotStatus deploys the zip to a deployment slot of the app service and tracks the status of the
+// deployment, the same way DeployTrackStatus does for the production slot
+func (c *ZipDeployClient) DeployTrackSlotStatus(
+	ctx context.Context,
+	zipFile io.ReadSeeker,
+	subscriptionId string,
+	resourceGroup string,
+	appName string,
+	slotName string,
+	progressLog func(string)) error {
+	poller, err := c.BeginDeployTrackSlotStatus(ctx, zipFile, subscriptionId, resourceGroup, appName, slotName)
+	if err != nil {
+		return err
+	}
+
+	return trackDeploymentStatus(ctx, poller, progressLog,
+		func(
+			response armappservice.WebAppsClientGetSlotSiteDeploymentStatusSlotResponse,
+		) armappservice.CsmDeploymentStatus {
+			return response.CsmDeploymentStatus
+		})
+}
+
+// trackDeploymentStatus polls the deployment status until the deployment completes, logging its progress
+func trackDeploymentStatus[T any](
+	ctx context.Context,
+	poller *runtime.Poller[T],
+	progressLog func(string),
+	deploymentStatus func(T) armappservice.CsmDeploymentStatus,
+) error {
+	var response T
+
 	delay := 3 * time.Second
 	pollCount := 0
 	for {
-		var resp *http.Response
-
-		resp, err = poller.Poll(ctx)
+		resp, err := poller.Poll(ctx)
 		if err != nil {
 			return err
 		}
@@ -290,7 +364,7 @@ func (c *ZipDeployClient) DeployTrackStatus(
 		}
 
 		if poller.Done() {
-			status := *response.Properties.Status
+			status := *deploymentStatus(response).Properties.Status
 			if status != armappservice.DeploymentBuildStatusRuntimeSuccessful &&
 				status != armappservice.DeploymentBuildStatusBuildFailed &&
 				status != armappservice.DeploymentBuildStatusRuntimeFailed {
@@ -299,13 +373,13 @@ func (c *ZipDeployClient) DeployTrackStatus(
 			}
 			spanCtx := trace.SpanContextFromContext(ctx)
 			traceId := spanCtx.TraceID().String()
-			if err = logWebAppDeploymentStatus(response, traceId, progressLog); err != nil {
+			if err = logWebAppDeploymentStatus(deploymentStatus(response), traceId, progressLog); err != nil {
 				return err
 			}
 			break
 		}
 
-		if err = logWebAppDeploymentStatus(response, "", progressLog); err != nil {
+		if err = logWebAppDeploymentStatus(deploymentStatus(response), "", progressLog); err != nil {
 			return err
 		}
 
@@ -65,6 +65,15 @@ func WebsiteRID(subscriptionId, resourceGroupName, websiteName string) string {
 	return returnValue
 }
 
+func WebsiteSlotRID(subscriptionId, resourceGroupName, websiteName, slotName string) string {
+	returnValue := fmt.Sprintf(
+		"%s/slots/%s",
+		WebsiteRID(subscriptionId, resourceGroupName, websiteName),
+		slotName,
+	)
+	return returnValue
+}
+
 func ContainerAppRID(subscriptionId, resourceGroupName, containerAppName string) string {
 	returnValue := fmt.Sprintf(
 		"%s/providers/Microsoft.App/containerApps/%s",
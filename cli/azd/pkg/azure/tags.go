@@ -16,4 +16,7 @@ const (
 	// TagKeyAzdServiceName is the name of the key in the tags map of a resource
 	// used to store the azd service a resource is associated with.
 	TagKeyAzdServiceName = "azd-service-name"
+	// TagKeyAzdLayerName is the name of the key in the tags map of a deployment
+	// used to store the infrastructure layer the deployment provisions.
+	TagKeyAzdLayerName = "azd-layer-name"
 )
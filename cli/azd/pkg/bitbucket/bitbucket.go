@@ -0,0 +1,115 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+)
+
+var (
+	// hostname of the Bitbucket Cloud service.
+	BitbucketHostName = "bitbucket.org"
+	// environment variable that holds the Bitbucket repository or workspace access token
+	BitbucketTokenName = "BITBUCKET_TOKEN"
+)
+
+// ErrRemoteHostIsNotBitbucket the error used when a non Bitbucket remote is found
+var ErrRemoteHostIsNotBitbucket = errors.New("not a bitbucket host")
+
+// Remote is a git remote of a repository hosted on Bitbucket Cloud
+type Remote struct {
+	// Workspace is the workspace owning the repository
+	Workspace string
+	// RepoSlug is the URL-friendly name of the repository
+	RepoSlug string
+}
+
+// WebUrl returns the address of the repository in the Bitbucket web UI
+func (r *Remote) WebUrl() string {
+	return fmt.Sprintf("https://%s/%s/%s", BitbucketHostName, r.Workspace, r.RepoSlug)
+}
+
+// defines the structure of an scp-like ssh git remote, e.g. git@bitbucket.org:workspace/repo.git
+var bitbucketRemoteScpUrlRegex = regexp.MustCompile(`^[a-zA-Z0-9._-]+@([a-zA-Z0-9.-]+):(.+?)(?:\.git)?/?$`)
+
+// ParseRemote extracts the workspace and the repository slug from a Bitbucket Cloud remote url.
+// The url can be in the form of:
+//   - https://bitbucket.org/[workspace]/[repo].git
+//   - https://[user]@bitbucket.org/[workspace]/[repo].git
+//   - ssh://git@bitbucket.org/[workspace]/[repo].git
+//   - git@bitbucket.org:[workspace]/[repo].git
+func ParseRemote(remoteUrl string) (*Remote, error) {
+	var host, repoPath string
+
+	if captures := bitbucketRemoteScpUrlRegex.FindStringSubmatch(remoteUrl); captures != nil &&
+		!strings.Contains(remoteUrl, "://") {
+		host = captures[1]
+		repoPath = captures[2]
+	} else {
+		parsed, err := url.Parse(remoteUrl)
+		if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "ssh") {
+			return nil, fmt.Errorf("%w: %s", ErrRemoteHostIsNotBitbucket, remoteUrl)
+		}
+
+		host = parsed.Hostname()
+		repoPath = strings.TrimSuffix(strings.Trim(parsed.Path, "/"), ".git")
+	}
+
+	if !strings.EqualFold(host, BitbucketHostName) {
+		return nil, fmt.Errorf("%w: %s", ErrRemoteHostIsNotBitbucket, remoteUrl)
+	}
+
+	// Bitbucket repositories always belong to a workspace and can't be nested
+	parts := strings.Split(repoPath, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("%w: %s", ErrRemoteHostIsNotBitbucket, remoteUrl)
+	}
+
+	return &Remote{
+		Workspace: parts[0],
+		RepoSlug:  parts[1],
+	}, nil
+}
+
+// EnsureTokenExists ensures a Bitbucket access token exists either in .env or system environment variables,
+// prompting the user for the token otherwise.
+// Returns true when the token was provided by the user.
+func EnsureTokenExists(ctx context.Context, env *environment.Environment, console input.Console) (string, bool, error) {
+	if value, has := env.LookupEnv(BitbucketTokenName); has && value != "" {
+		return value, false, nil
+	}
+
+	console.Message(ctx, fmt.Sprintf(
+		"You need a %s with the %s and %s permissions. Create a token by following the instructions here %s",
+		output.WithWarningFormat("Bitbucket repository access token"),
+		output.WithHighLightFormat("Repositories: Admin"),
+		output.WithHighLightFormat("Pipelines: Edit variables"),
+		output.WithLinkFormat("https://support.atlassian.com/bitbucket-cloud/docs/create-a-repository-access-token/")))
+	console.Message(ctx, fmt.Sprintf("(%s this prompt by setting the token to env var: %s)",
+		output.WithWarningFormat("%s", "skip"),
+		output.WithHighLightFormat("%s", BitbucketTokenName)))
+
+	token, err := console.Prompt(ctx, input.ConsoleOptions{
+		Message:    "Bitbucket access token:",
+		IsPassword: true,
+	})
+	if err != nil {
+		return "", false, fmt.Errorf("asking for bitbucket token: %w", err)
+	}
+
+	// set the token as an environment variable for this cmd run
+	// note: the scope of this env var is only this shell invocation and won't be available in the caller parent shell
+	os.Setenv(BitbucketTokenName, token)
+	return token, true, nil
+}
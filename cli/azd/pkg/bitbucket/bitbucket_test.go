@@ -0,0 +1,48 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package bitbucket
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseRemote(t *testing.T) {
+	remotes := map[string]string{
+		"https":             "https://bitbucket.org/contoso/todo.git",
+		"https without git": "https://bitbucket.org/contoso/todo",
+		"https with user":   "https://user@bitbucket.org/contoso/todo.git",
+		"ssh":               "ssh://git@bitbucket.org/contoso/todo.git",
+		"scp":               "git@bitbucket.org:contoso/todo.git",
+	}
+
+	for name, remoteUrl := range remotes {
+		t.Run(name, func(t *testing.T) {
+			remote, err := ParseRemote(remoteUrl)
+			require.NoError(t, err)
+			require.Equal(t, "contoso", remote.Workspace)
+			require.Equal(t, "todo", remote.RepoSlug)
+			require.Equal(t, "https://bitbucket.org/contoso/todo", remote.WebUrl())
+		})
+	}
+}
+
+func Test_ParseRemote_NotBitbucket(t *testing.T) {
+	remotes := []string{
+		"https://github.com/owner/repo.git",
+		"git@gitlab.com:group/project.git",
+		"https://bitbucket.org/contoso",
+		"https://bitbucket.org/contoso/nested/todo.git",
+		"http://bitbucket.org/contoso/todo.git",
+		"not a url",
+	}
+
+	for _, remoteUrl := range remotes {
+		t.Run(remoteUrl, func(t *testing.T) {
+			_, err := ParseRemote(remoteUrl)
+			require.ErrorIs(t, err, ErrRemoteHostIsNotBitbucket)
+		})
+	}
+}
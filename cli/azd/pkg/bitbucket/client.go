@@ -0,0 +1,182 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/azure/azure-dev/cli/azd/pkg/httputil"
+)
+
+// ApiUrl is the base address of the Bitbucket Cloud REST API
+const ApiUrl = "https://api.bitbucket.org/2.0"
+
+// ErrNotFound is returned when the requested Bitbucket resource does not exist
+var ErrNotFound = errors.New("bitbucket resource not found")
+
+// Variable is a repository variable of Bitbucket Pipelines
+type Variable struct {
+	// Uuid identifies an existing variable. It is assigned by Bitbucket when the variable is created.
+	Uuid  string `json:"uuid,omitempty"`
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	// Secured variables are write-only and hidden in pipeline logs
+	Secured bool `json:"secured"`
+}
+
+type variablesPage struct {
+	Values []Variable `json:"values"`
+	Next   string     `json:"next"`
+}
+
+// Client is a client for the Bitbucket Cloud REST API
+type Client struct {
+	apiUrl   string
+	pipeline runtime.Pipeline
+}
+
+// NewClient creates a client for the Bitbucket Cloud REST API at the specified address (e.g. ApiUrl),
+// authenticating requests with the access token.
+func NewClient(apiUrl string, token string, options *azcore.ClientOptions) *Client {
+	pipeline := runtime.NewPipeline("bitbucket", "1.0.0", runtime.PipelineOptions{
+		PerRetry: []policy.Policy{
+			&tokenAuthPolicy{token: token},
+		},
+	}, options)
+
+	return &Client{
+		apiUrl:   apiUrl,
+		pipeline: pipeline,
+	}
+}
+
+// EnablePipelines enables Bitbucket Pipelines for the repository
+func (c *Client) EnablePipelines(ctx context.Context, workspace string, repoSlug string) error {
+	body := map[string]bool{"enabled": true}
+	res, err := c.send(ctx, http.MethodPut, c.repositoryUrl(workspace, repoSlug)+"/pipelines_config", body)
+	if err != nil {
+		return fmt.Errorf("enabling pipelines for repository %s/%s: %w", workspace, repoSlug, err)
+	}
+	defer res.Body.Close()
+
+	return nil
+}
+
+// ListVariables lists the repository variables of Bitbucket Pipelines. The values of secured variables are not
+// returned.
+func (c *Client) ListVariables(ctx context.Context, workspace string, repoSlug string) ([]Variable, error) {
+	variables := []Variable{}
+	requestUrl := c.repositoryUrl(workspace, repoSlug) + "/pipelines_config/variables?pagelen=100"
+
+	for requestUrl != "" {
+		res, err := c.send(ctx, http.MethodGet, requestUrl, nil)
+		if err != nil {
+			return nil, fmt.Errorf("listing variables of repository %s/%s: %w", workspace, repoSlug, err)
+		}
+
+		page, err := httputil.ReadRawResponse[variablesPage](res)
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		variables = append(variables, page.Values...)
+		requestUrl = page.Next
+	}
+
+	return variables, nil
+}
+
+// CreateVariable creates a repository variable of Bitbucket Pipelines
+func (c *Client) CreateVariable(ctx context.Context, workspace string, repoSlug string, variable Variable) error {
+	variableUrl := c.repositoryUrl(workspace, repoSlug) + "/pipelines_config/variables"
+	res, err := c.send(ctx, http.MethodPost, variableUrl, variable)
+	if err != nil {
+		return fmt.Errorf("creating variable %s: %w", variable.Key, err)
+	}
+	defer res.Body.Close()
+
+	return nil
+}
+
+// UpdateVariable updates the repository variable of Bitbucket Pipelines identified by variable.Uuid
+func (c *Client) UpdateVariable(ctx context.Context, workspace string, repoSlug string, variable Variable) error {
+	variableUrl := fmt.Sprintf(
+		"%s/pipelines_config/variables/%s", c.repositoryUrl(workspace, repoSlug), url.PathEscape(variable.Uuid))
+	res, err := c.send(ctx, http.MethodPut, variableUrl, variable)
+	if err != nil {
+		return fmt.Errorf("updating variable %s: %w", variable.Key, err)
+	}
+	defer res.Body.Close()
+
+	return nil
+}
+
+// DeleteVariable deletes the repository variable of Bitbucket Pipelines with the specified uuid
+func (c *Client) DeleteVariable(ctx context.Context, workspace string, repoSlug string, uuid string) error {
+	variableUrl := fmt.Sprintf(
+		"%s/pipelines_config/variables/%s", c.repositoryUrl(workspace, repoSlug), url.PathEscape(uuid))
+	res, err := c.send(ctx, http.MethodDelete, variableUrl, nil)
+	if err != nil {
+		return fmt.Errorf("deleting variable %s: %w", uuid, err)
+	}
+	defer res.Body.Close()
+
+	return nil
+}
+
+// repositoryUrl returns the address of the repository resource
+func (c *Client) repositoryUrl(workspace string, repoSlug string) string {
+	return fmt.Sprintf("%s/repositories/%s/%s", c.apiUrl, url.PathEscape(workspace), url.PathEscape(repoSlug))
+}
+
+// send sends the request and returns an error when the response is not successful
+func (c *Client) send(ctx context.Context, method string, requestUrl string, body any) (*http.Response, error) {
+	req, err := runtime.NewRequest(ctx, method, requestUrl)
+	if err != nil {
+		return nil, fmt.Errorf("building request: %w", err)
+	}
+
+	if body != nil {
+		if err := runtime.MarshalAsJSON(req, body); err != nil {
+			return nil, fmt.Errorf("marshalling request: %w", err)
+		}
+	}
+
+	res, err := c.pipeline.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("sending request: %w", err)
+	}
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return res, nil
+	}
+
+	defer res.Body.Close()
+	message, _ := io.ReadAll(res.Body)
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, message)
+	}
+
+	return nil, fmt.Errorf("unexpected status code %d: %s", res.StatusCode, message)
+}
+
+type tokenAuthPolicy struct {
+	token string
+}
+
+// Do authorizes a request with the Bitbucket access token
+func (p *tokenAuthPolicy) Do(req *policy.Request) (*http.Response, error) {
+	req.Raw().Header.Set("Authorization", "Bearer "+p.token)
+	return req.Next()
+}
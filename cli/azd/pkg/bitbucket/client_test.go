@@ -0,0 +1,100 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Client_EnablePipelines(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		require.Equal(t, http.MethodPut, r.Method)
+		require.Equal(t, "/2.0/repositories/contoso/todo/pipelines_config", r.URL.Path)
+
+		var body map[string]bool
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.True(t, body["enabled"])
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/2.0", "token", nil)
+	require.NoError(t, client.EnablePipelines(context.Background(), "contoso", "todo"))
+}
+
+func Test_Client_ListVariables(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/2.0/repositories/contoso/todo/pipelines_config/variables", r.URL.Path)
+
+		switch r.URL.Query().Get("page") {
+		case "":
+			_ = json.NewEncoder(w).Encode(variablesPage{
+				Values: []Variable{{Uuid: "{1}", Key: "A", Value: "a"}, {Uuid: "{2}", Key: "B", Secured: true}},
+				Next:   server.URL + "/2.0/repositories/contoso/todo/pipelines_config/variables?page=2",
+			})
+		case "2":
+			_ = json.NewEncoder(w).Encode(variablesPage{
+				Values: []Variable{{Uuid: "{3}", Key: "C", Value: "c"}},
+			})
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/2.0", "token", nil)
+	variables, err := client.ListVariables(context.Background(), "contoso", "todo")
+	require.NoError(t, err)
+	require.Equal(t, []Variable{
+		{Uuid: "{1}", Key: "A", Value: "a"},
+		{Uuid: "{2}", Key: "B", Secured: true},
+		{Uuid: "{3}", Key: "C", Value: "c"},
+	}, variables)
+}
+
+func Test_Client_Variables(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+
+		if r.Method == http.MethodPost || r.Method == http.MethodPut {
+			var variable Variable
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&variable))
+			require.Equal(t, "AZURE_CLIENT_SECRET", variable.Key)
+			require.True(t, variable.Secured)
+		}
+
+		missingPath := "/2.0/repositories/contoso/todo/pipelines_config/variables/{missing}"
+		if r.Method == http.MethodDelete && r.URL.Path == missingPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClient(server.URL+"/2.0", "token", nil)
+	variable := Variable{Key: "AZURE_CLIENT_SECRET", Value: "secret", Secured: true}
+
+	require.NoError(t, client.CreateVariable(ctx, "contoso", "todo", variable))
+
+	variable.Uuid = "{1234}"
+	require.NoError(t, client.UpdateVariable(ctx, "contoso", "todo", variable))
+	require.NoError(t, client.DeleteVariable(ctx, "contoso", "todo", "{1234}"))
+	require.ErrorIs(t, client.DeleteVariable(ctx, "contoso", "todo", "{missing}"), ErrNotFound)
+
+	require.Equal(t, []string{
+		"POST /2.0/repositories/contoso/todo/pipelines_config/variables",
+		"PUT /2.0/repositories/contoso/todo/pipelines_config/variables/%7B1234%7D",
+		"DELETE /2.0/repositories/contoso/todo/pipelines_config/variables/%7B1234%7D",
+		"DELETE /2.0/repositories/contoso/todo/pipelines_config/variables/%7Bmissing%7D",
+	}, requests)
+}
@@ -23,6 +23,12 @@ var vaultPattern = regexp.MustCompile(
 	`^vault://[a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[a-fA-F0-9]{4}-[a-fA-F0-9]{4}-[a-fA-F0-9]{12}/[a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[a-fA-F0-9]{4}-[a-fA-F0-9]{4}-[a-fA-F0-9]{12}$`,
 )
 
+// IsVaultReference returns true when the value references a secret stored in the vault of a config, ex) the value of
+// a secure infrastructure parameter set with SetSecret
+func IsVaultReference(value string) bool {
+	return vaultPattern.MatchString(value)
+}
+
 // Azd configuration for the current user
 // Configuration data is stored in user's home directory @ ~/.azd/config.json
 type Config interface {
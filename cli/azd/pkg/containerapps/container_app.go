@@ -7,11 +7,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"slices"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
@@ -28,6 +30,7 @@ import (
 
 const (
 	pathLatestRevisionName                 = "properties.latestRevisionName"
+	pathLatestRevisionFqdn                 = "properties.latestRevisionFqdn"
 	pathTemplate                           = "properties.template"
 	pathTemplateRevisionSuffix             = "properties.template.revisionSuffix"
 	pathTemplateContainers                 = "properties.template.containers"
@@ -66,6 +69,43 @@ type ContainerAppService interface {
 		imageName string,
 		options *ContainerAppOptions,
 	) error
+	// Gets the latest revision of the specified container app
+	GetLatestRevision(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		appName string,
+		options *ContainerAppOptions,
+	) (*ContainerAppRevision, error)
+	// Shifts all traffic of the specified container app to an existing revision, activating the revision when needed.
+	// Returns ErrSingleRevisionMode when the container app is not in multiple revision mode and ErrRevisionNotFound
+	// when the revision no longer exists.
+	ShiftTrafficToRevision(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		appName string,
+		revisionName string,
+		options *ContainerAppOptions,
+	) error
+	// Gets the ingress traffic weights of the specified container app. Traffic routed to the latest revision is
+	// reported with the name of the latest revision.
+	GetTrafficWeights(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		appName string,
+		options *ContainerAppOptions,
+	) ([]RevisionWeight, error)
+	// Sets the ingress traffic weights of the specified container app
+	SetTrafficWeights(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		appName string,
+		weights []RevisionWeight,
+		options *ContainerAppOptions,
+	) error
 }
 
 // NewContainerAppService creates a new ContainerAppService
@@ -92,12 +132,38 @@ type containerAppService struct {
 
 type ContainerAppOptions struct {
 	ApiVersion string
+	// When set, AddRevision keeps the traffic on the revisions currently receiving traffic instead of shifting all
+	// traffic to the new revision. Requires the container app to be in multiple revision mode.
+	KeepTraffic bool
+}
+
+// RevisionWeight is the percentage of ingress traffic routed to a revision
+type RevisionWeight struct {
+	RevisionName string
+	Weight       int32
+	Label        string
 }
 
 type ContainerAppIngressConfiguration struct {
 	HostNames []string
 }
 
+// ContainerAppRevision is a revision of a container app
+type ContainerAppRevision struct {
+	Name string
+	// The image of the first container of the revision
+	Image string
+	// The fully qualified domain name of the revision, empty when ingress is disabled
+	Fqdn string
+}
+
+var (
+	// ErrSingleRevisionMode is returned when an operation requires the container app to be in multiple revision mode
+	ErrSingleRevisionMode = errors.New("container app is not in multiple revision mode")
+	// ErrRevisionNotFound is returned when the requested revision does not exist
+	ErrRevisionNotFound = errors.New("revision not found")
+)
+
 // Gets the ingress configuration for the specified container app
 func (cas *containerAppService) GetIngressConfiguration(
 	ctx context.Context,
@@ -288,6 +354,26 @@ func (cas *containerAppService) AddRevision(
 		return fmt.Errorf("getting latest revision name: %w", err)
 	}
 
+	revisionMode, ok := containerApp.GetString(pathConfigurationActiveRevisionsMode)
+	if !ok {
+		return fmt.Errorf("getting active revisions mode: %w", err)
+	}
+
+	multipleRevisionMode := revisionMode == string(armappcontainers.ActiveRevisionsModeMultiple)
+	keepTraffic := options != nil && options.KeepTraffic
+	if keepTraffic {
+		if !multipleRevisionMode {
+			return ErrSingleRevisionMode
+		}
+
+		// Pin the traffic routed to the latest revision to the current revision name, otherwise the traffic would
+		// move to the new revision as soon as it is created.
+		weights := trafficWeights(containerApp, currentRevisionName)
+		if err := setTrafficWeightsConfig(containerApp, weights); err != nil {
+			return err
+		}
+	}
+
 	apiVersionPolicy := createApiVersionPolicy(options)
 	revisionsClient, err := cas.createRevisionsClient(ctx, subscriptionId, apiVersionPolicy)
 	if err != nil {
@@ -344,20 +430,17 @@ func (cas *containerAppService) AddRevision(
 		return fmt.Errorf("updating container app revision: %w", err)
 	}
 
-	revisionMode, ok := containerApp.GetString(pathConfigurationActiveRevisionsMode)
-	if !ok {
-		return fmt.Errorf("getting active revisions mode: %w", err)
-	}
-
 	// If the container app is in multiple revision mode, update the traffic to point to the new revision
-	if revisionMode == string(armappcontainers.ActiveRevisionsModeMultiple) {
+	if multipleRevisionMode && !keepTraffic {
 		revisionSuffix, ok := revision.GetString(pathTemplateRevisionSuffix)
 		if !ok {
 			return fmt.Errorf("getting revision suffix: %w", err)
 		}
 		newRevisionName := fmt.Sprintf("%s--%s", appName, revisionSuffix)
 
-		err = cas.setTrafficWeights(ctx, subscriptionId, resourceGroupName, appName, containerApp, newRevisionName, options)
+		err = cas.setTrafficWeights(ctx, subscriptionId, resourceGroupName, appName, containerApp, []RevisionWeight{
+			{RevisionName: newRevisionName, Weight: 100},
+		}, options)
 		if err != nil {
 			return fmt.Errorf("setting traffic weights: %w", err)
 		}
@@ -366,6 +449,95 @@ func (cas *containerAppService) AddRevision(
 	return nil
 }
 
+// Gets the latest revision of the specified container app
+func (cas *containerAppService) GetLatestRevision(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	options *ContainerAppOptions,
+) (*ContainerAppRevision, error) {
+	containerApp, err := cas.getContainerApp(ctx, subscriptionId, resourceGroupName, appName, options)
+	if err != nil {
+		return nil, fmt.Errorf("getting container app: %w", err)
+	}
+
+	revisionName, has := containerApp.GetString(pathLatestRevisionName)
+	if !has {
+		return nil, fmt.Errorf("getting latest revision name for container app '%s'", appName)
+	}
+
+	var containers []map[string]any
+	if ok, err := containerApp.GetSection(pathTemplateContainers, &containers); !ok || err != nil || len(containers) == 0 {
+		return nil, fmt.Errorf("getting containers: %w", err)
+	}
+
+	image, _ := containers[0]["image"].(string)
+	fqdn, _ := containerApp.GetString(pathLatestRevisionFqdn)
+
+	return &ContainerAppRevision{
+		Name:  revisionName,
+		Image: image,
+		Fqdn:  fqdn,
+	}, nil
+}
+
+// Shifts all traffic of the specified container app to an existing revision, activating the revision when needed.
+func (cas *containerAppService) ShiftTrafficToRevision(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	revisionName string,
+	options *ContainerAppOptions,
+) error {
+	containerApp, err := cas.getContainerApp(ctx, subscriptionId, resourceGroupName, appName, options)
+	if err != nil {
+		return fmt.Errorf("getting container app: %w", err)
+	}
+
+	revisionMode, _ := containerApp.GetString(pathConfigurationActiveRevisionsMode)
+	if revisionMode != string(armappcontainers.ActiveRevisionsModeMultiple) {
+		return ErrSingleRevisionMode
+	}
+
+	apiVersionPolicy := createApiVersionPolicy(options)
+	revisionsClient, err := cas.createRevisionsClient(ctx, subscriptionId, apiVersionPolicy)
+	if err != nil {
+		return err
+	}
+
+	revision, err := revisionsClient.GetRevision(ctx, resourceGroupName, appName, revisionName, nil)
+	if err != nil {
+		var respErr *azcore.ResponseError
+		if errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("revision '%s': %w", revisionName, ErrRevisionNotFound)
+		}
+
+		return fmt.Errorf("getting revision '%s': %w", revisionName, err)
+	}
+
+	if revision.Properties == nil || revision.Properties.Active == nil || !*revision.Properties.Active {
+		if _, err := revisionsClient.ActivateRevision(ctx, resourceGroupName, appName, revisionName, nil); err != nil {
+			return fmt.Errorf("activating revision '%s': %w", revisionName, err)
+		}
+	}
+
+	containerApp, err = cas.syncSecrets(ctx, subscriptionId, resourceGroupName, appName, containerApp)
+	if err != nil {
+		return fmt.Errorf("syncing secrets: %w", err)
+	}
+
+	err = cas.setTrafficWeights(ctx, subscriptionId, resourceGroupName, appName, containerApp, []RevisionWeight{
+		{RevisionName: revisionName, Weight: 100},
+	}, options)
+	if err != nil {
+		return fmt.Errorf("setting traffic weights: %w", err)
+	}
+
+	return nil
+}
+
 func (cas *containerAppService) syncSecrets(
 	ctx context.Context,
 	subscriptionId string,
@@ -406,20 +578,79 @@ func (cas *containerAppService) syncSecrets(
 	return containerApp, nil
 }
 
+// Gets the ingress traffic weights of the specified container app
+func (cas *containerAppService) GetTrafficWeights(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	options *ContainerAppOptions,
+) ([]RevisionWeight, error) {
+	containerApp, err := cas.getContainerApp(ctx, subscriptionId, resourceGroupName, appName, options)
+	if err != nil {
+		return nil, fmt.Errorf("getting container app: %w", err)
+	}
+
+	latestRevisionName, _ := containerApp.GetString(pathLatestRevisionName)
+	return trafficWeights(containerApp, latestRevisionName), nil
+}
+
+// Sets the ingress traffic weights of the specified container app
+func (cas *containerAppService) SetTrafficWeights(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	weights []RevisionWeight,
+	options *ContainerAppOptions,
+) error {
+	containerApp, err := cas.getContainerApp(ctx, subscriptionId, resourceGroupName, appName, options)
+	if err != nil {
+		return fmt.Errorf("getting container app: %w", err)
+	}
+
+	containerApp, err = cas.syncSecrets(ctx, subscriptionId, resourceGroupName, appName, containerApp)
+	if err != nil {
+		return fmt.Errorf("syncing secrets: %w", err)
+	}
+
+	return cas.setTrafficWeights(ctx, subscriptionId, resourceGroupName, appName, containerApp, weights, options)
+}
+
 func (cas *containerAppService) setTrafficWeights(
 	ctx context.Context,
 	subscriptionId string,
 	resourceGroupName string,
 	appName string,
 	containerApp config.Config,
-	revisionName string,
+	weights []RevisionWeight,
 	options *ContainerAppOptions,
 ) error {
-	trafficWeights := []*armappcontainers.TrafficWeight{
-		{
-			RevisionName: &revisionName,
-			Weight:       to.Ptr[int32](100),
-		},
+	if err := setTrafficWeightsConfig(containerApp, weights); err != nil {
+		return err
+	}
+
+	err := cas.updateContainerApp(ctx, subscriptionId, resourceGroupName, appName, containerApp, options)
+	if err != nil {
+		return fmt.Errorf("updating traffic weights: %w", err)
+	}
+
+	return nil
+}
+
+// setTrafficWeightsConfig sets the ingress traffic weights within the container app configuration
+func setTrafficWeightsConfig(containerApp config.Config, weights []RevisionWeight) error {
+	trafficWeights := make([]*armappcontainers.TrafficWeight, 0, len(weights))
+	for _, weight := range weights {
+		trafficWeight := &armappcontainers.TrafficWeight{
+			RevisionName: to.Ptr(weight.RevisionName),
+			Weight:       to.Ptr(weight.Weight),
+		}
+		if weight.Label != "" {
+			trafficWeight.Label = to.Ptr(weight.Label)
+		}
+
+		trafficWeights = append(trafficWeights, trafficWeight)
 	}
 
 	trafficWeightsJson, err := convert.ToJsonArray(trafficWeights)
@@ -431,12 +662,33 @@ func (cas *containerAppService) setTrafficWeights(
 		return fmt.Errorf("setting traffic weights: %w", err)
 	}
 
-	err = cas.updateContainerApp(ctx, subscriptionId, resourceGroupName, appName, containerApp, options)
-	if err != nil {
-		return fmt.Errorf("updating traffic weights: %w", err)
+	return nil
+}
+
+// trafficWeights reads the ingress traffic weights from the container app configuration. Traffic routed to the latest
+// revision is reported with the specified latest revision name.
+func trafficWeights(containerApp config.Config, latestRevisionName string) []RevisionWeight {
+	var traffic []armappcontainers.TrafficWeight
+	if ok, err := containerApp.GetSection(pathConfigurationIngressTraffic, &traffic); !ok || err != nil {
+		return []RevisionWeight{}
 	}
 
-	return nil
+	weights := []RevisionWeight{}
+	for _, trafficWeight := range traffic {
+		weight := RevisionWeight{
+			RevisionName: convert.ToValueWithDefault(trafficWeight.RevisionName, ""),
+			Weight:       convert.ToValueWithDefault(trafficWeight.Weight, 0),
+			Label:        convert.ToValueWithDefault(trafficWeight.Label, ""),
+		}
+
+		if convert.ToValueWithDefault(trafficWeight.LatestRevision, false) {
+			weight.RevisionName = latestRevisionName
+		}
+
+		weights = append(weights, weight)
+	}
+
+	return weights
 }
 
 func (cas *containerAppService) getContainerApp(
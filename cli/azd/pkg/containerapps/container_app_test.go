@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
@@ -252,3 +253,195 @@ properties:
 	require.Equal(t, expected.Properties.Configuration, actual.Properties.Configuration)
 	require.Equal(t, expected.Properties.Template, actual.Properties.Template)
 }
+
+func Test_ContainerApp_ShiftTrafficToRevision(t *testing.T) {
+	subscriptionId := "SUBSCRIPTION_ID"
+	location := "eastus2"
+	resourceGroup := "RESOURCE_GROUP"
+	appName := "APP_NAME"
+	previousRevisionName := "APP_NAME--azd-1"
+
+	newContainerApp := func(mode armappcontainers.ActiveRevisionsMode) *armappcontainers.ContainerApp {
+		return &armappcontainers.ContainerApp{
+			Location: &location,
+			Name:     &appName,
+			Properties: &armappcontainers.ContainerAppProperties{
+				LatestRevisionName: to.Ptr("APP_NAME--azd-2"),
+				Configuration: &armappcontainers.Configuration{
+					ActiveRevisionsMode: to.Ptr(mode),
+				},
+			},
+		}
+	}
+
+	t.Run("MultipleRevisionMode", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		containerApp := newContainerApp(armappcontainers.ActiveRevisionsModeMultiple)
+
+		_ = mockazsdk.MockContainerAppGet(mockContext, subscriptionId, resourceGroup, appName, containerApp)
+		_ = mockazsdk.MockContainerAppRevisionGet(
+			mockContext,
+			subscriptionId,
+			resourceGroup,
+			appName,
+			previousRevisionName,
+			&armappcontainers.Revision{
+				Properties: &armappcontainers.RevisionProperties{
+					Active: to.Ptr(false),
+				},
+			},
+		)
+		activateRequest := mockazsdk.MockContainerAppRevisionActivate(
+			mockContext,
+			subscriptionId,
+			resourceGroup,
+			appName,
+			previousRevisionName,
+		)
+		updateRequest := mockazsdk.MockContainerAppUpdate(mockContext, subscriptionId, resourceGroup, appName, containerApp)
+
+		cas := NewContainerAppService(
+			mockContext.SubscriptionCredentialProvider,
+			clock.NewMock(),
+			mockContext.ArmClientOptions,
+			mockContext.AlphaFeaturesManager,
+		)
+		err := cas.ShiftTrafficToRevision(
+			*mockContext.Context, subscriptionId, resourceGroup, appName, previousRevisionName, nil)
+		require.NoError(t, err)
+		require.Equal(t, http.MethodPost, activateRequest.Method)
+
+		var updatedContainerApp *armappcontainers.ContainerApp
+		err = mocks.ReadHttpBody(updateRequest.Body, &updatedContainerApp)
+		require.NoError(t, err)
+
+		traffic := updatedContainerApp.Properties.Configuration.Ingress.Traffic
+		require.Len(t, traffic, 1)
+		require.Equal(t, previousRevisionName, *traffic[0].RevisionName)
+		require.Equal(t, int32(100), *traffic[0].Weight)
+	})
+
+	t.Run("SingleRevisionMode", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		containerApp := newContainerApp(armappcontainers.ActiveRevisionsModeSingle)
+		_ = mockazsdk.MockContainerAppGet(mockContext, subscriptionId, resourceGroup, appName, containerApp)
+
+		cas := NewContainerAppService(
+			mockContext.SubscriptionCredentialProvider,
+			clock.NewMock(),
+			mockContext.ArmClientOptions,
+			mockContext.AlphaFeaturesManager,
+		)
+		err := cas.ShiftTrafficToRevision(
+			*mockContext.Context, subscriptionId, resourceGroup, appName, previousRevisionName, nil)
+		require.ErrorIs(t, err, ErrSingleRevisionMode)
+	})
+}
+
+func Test_ContainerApp_AddRevision_KeepTraffic(t *testing.T) {
+	subscriptionId := "SUBSCRIPTION_ID"
+	location := "eastus2"
+	resourceGroup := "RESOURCE_GROUP"
+	appName := "APP_NAME"
+	originalRevisionName := "APP_NAME--azd-1"
+
+	newContainerApp := func(mode armappcontainers.ActiveRevisionsMode) *armappcontainers.ContainerApp {
+		return &armappcontainers.ContainerApp{
+			Location: &location,
+			Name:     &appName,
+			Properties: &armappcontainers.ContainerAppProperties{
+				LatestRevisionName: &originalRevisionName,
+				Configuration: &armappcontainers.Configuration{
+					ActiveRevisionsMode: to.Ptr(mode),
+					Ingress: &armappcontainers.Ingress{
+						Traffic: []*armappcontainers.TrafficWeight{
+							{LatestRevision: to.Ptr(true), Weight: to.Ptr(int32(100))},
+						},
+					},
+				},
+				Template: &armappcontainers.Template{
+					Containers: []*armappcontainers.Container{
+						{Image: to.Ptr("ORIGINAL_IMAGE_NAME")},
+					},
+				},
+			},
+		}
+	}
+
+	revision := &armappcontainers.Revision{
+		Properties: &armappcontainers.RevisionProperties{
+			Template: &armappcontainers.Template{
+				Containers: []*armappcontainers.Container{
+					{Image: to.Ptr("ORIGINAL_IMAGE_NAME")},
+				},
+			},
+		},
+	}
+
+	t.Run("MultipleRevisionMode", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		containerApp := newContainerApp(armappcontainers.ActiveRevisionsModeMultiple)
+
+		_ = mockazsdk.MockContainerAppGet(mockContext, subscriptionId, resourceGroup, appName, containerApp)
+		_ = mockazsdk.MockContainerAppRevisionGet(
+			mockContext, subscriptionId, resourceGroup, appName, originalRevisionName, revision)
+		_ = mockazsdk.MockContainerAppSecretsList(
+			mockContext, subscriptionId, resourceGroup, appName, &armappcontainers.SecretsCollection{})
+		updateRequest := mockazsdk.MockContainerAppUpdate(mockContext, subscriptionId, resourceGroup, appName, containerApp)
+
+		cas := NewContainerAppService(
+			mockContext.SubscriptionCredentialProvider,
+			clock.NewMock(),
+			mockContext.ArmClientOptions,
+			mockContext.AlphaFeaturesManager,
+		)
+
+		weights, err := cas.GetTrafficWeights(*mockContext.Context, subscriptionId, resourceGroup, appName, nil)
+		require.NoError(t, err)
+		require.Equal(t, []RevisionWeight{{RevisionName: originalRevisionName, Weight: 100}}, weights)
+
+		err = cas.AddRevision(
+			*mockContext.Context,
+			subscriptionId,
+			resourceGroup,
+			appName,
+			"UPDATED_IMAGE_NAME",
+			&ContainerAppOptions{KeepTraffic: true},
+		)
+		require.NoError(t, err)
+
+		var updatedContainerApp *armappcontainers.ContainerApp
+		err = mocks.ReadHttpBody(updateRequest.Body, &updatedContainerApp)
+		require.NoError(t, err)
+
+		// The traffic stays pinned to the original revision
+		traffic := updatedContainerApp.Properties.Configuration.Ingress.Traffic
+		require.Len(t, traffic, 1)
+		require.Equal(t, originalRevisionName, *traffic[0].RevisionName)
+		require.Nil(t, traffic[0].LatestRevision)
+		require.Equal(t, int32(100), *traffic[0].Weight)
+		require.Equal(t, "UPDATED_IMAGE_NAME", *updatedContainerApp.Properties.Template.Containers[0].Image)
+	})
+
+	t.Run("SingleRevisionMode", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		containerApp := newContainerApp(armappcontainers.ActiveRevisionsModeSingle)
+		_ = mockazsdk.MockContainerAppGet(mockContext, subscriptionId, resourceGroup, appName, containerApp)
+
+		cas := NewContainerAppService(
+			mockContext.SubscriptionCredentialProvider,
+			clock.NewMock(),
+			mockContext.ArmClientOptions,
+			mockContext.AlphaFeaturesManager,
+		)
+		err := cas.AddRevision(
+			*mockContext.Context,
+			subscriptionId,
+			resourceGroup,
+			appName,
+			"UPDATED_IMAGE_NAME",
+			&ContainerAppOptions{KeepTraffic: true},
+		)
+		require.ErrorIs(t, err, ErrSingleRevisionMode)
+	})
+}
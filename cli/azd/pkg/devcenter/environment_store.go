@@ -183,6 +183,17 @@ func (s *EnvironmentStore) Delete(ctx context.Context, name string) error {
 	return s.local.Delete(ctx, name)
 }
 
+// Lock implements environment.RemoteDataStore.
+// Dev center environments are owned by the current user, the lock is held within the local storage.
+func (s *EnvironmentStore) Lock(ctx context.Context, name string, lock *environment.LockInfo) error {
+	return s.local.Lock(ctx, name, lock)
+}
+
+// Unlock implements environment.RemoteDataStore.
+func (s *EnvironmentStore) Unlock(ctx context.Context, name string, lockId string) error {
+	return s.local.Unlock(ctx, name, lockId)
+}
+
 // matchingEnvironments returns a list of environments matching the configured environment definition
 func (s *EnvironmentStore) matchingEnvironments(
 	ctx context.Context,
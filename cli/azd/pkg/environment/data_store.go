@@ -5,8 +5,11 @@ package environment
 
 import (
 	"context"
+	"slices"
+	"sync"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/azure/azure-dev/cli/azd/pkg/ioc"
 )
 
 // DataStore is the interface for the interacting with the persistent storage of environments.
@@ -15,10 +18,39 @@ type RemoteKind string
 
 const (
 	RemoteKindAzureBlobStorage RemoteKind = "AzureBlobStorage"
+	RemoteKindAzureFiles       RemoteKind = "AzureFiles"
+	RemoteKindLocalDirectory   RemoteKind = "LocalDirectory"
 )
 
-var ValidRemoteKinds = []string{
-	string(RemoteKindAzureBlobStorage),
+var (
+	remoteKindsMu sync.Mutex
+	remoteKinds   = []string{}
+)
+
+// MustRegisterRemoteDataStore registers the constructor of the remote data store used when the remote state
+// configuration specifies the backend kind. The constructor must return a RemoteDataStore.
+// The remote data stores built into azd are registered when azd builds its container, the remote data stores of
+// extensions are registered at runtime with the ExternalDataStoreRegistry.
+// Panics if the constructor cannot be registered.
+func MustRegisterRemoteDataStore(container *ioc.NestedContainer, kind RemoteKind, constructor any) {
+	container.MustRegisterNamedScoped(string(kind), constructor)
+
+	remoteKindsMu.Lock()
+	defer remoteKindsMu.Unlock()
+
+	// Backends registered with a constructor take precedence over the backends declared by extensions
+	delete(externalRemoteKinds, kind)
+	if !slices.Contains(remoteKinds, string(kind)) {
+		remoteKinds = append(remoteKinds, string(kind))
+	}
+}
+
+// ValidRemoteKinds returns the kinds of all registered remote data stores
+func ValidRemoteKinds() []string {
+	remoteKindsMu.Lock()
+	defer remoteKindsMu.Unlock()
+
+	return slices.Sorted(slices.Values(remoteKinds))
 }
 
 // SaveOptions provide additional metadata for the save operation
@@ -48,6 +80,14 @@ type DataStore interface {
 
 	// Deletes the environment from the persistent data store
 	Delete(ctx context.Context, name string) error
+
+	// Acquires the lock of the environment with the specified name.
+	// Returns a *LockedError when the environment is already locked.
+	Lock(ctx context.Context, name string, lock *LockInfo) error
+
+	// Releases the lock of the environment with the specified name when held by the lock with the specified id.
+	// When the id is empty the lock is released regardless of its holder.
+	Unlock(ctx context.Context, name string, lockId string) error
 }
 
 type LocalDataStore DataStore
@@ -0,0 +1,273 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package environment
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/keyvault"
+)
+
+// SecretMask is displayed in place of values that reference secrets
+const SecretMask = "*****"
+
+// configVaultKey is the config key holding the id of the vault that stores the secrets of the config, ex) the values
+// of secure infrastructure parameters
+const configVaultKey = "vault"
+
+// ChangeKind is the kind of difference of a value between two environments
+type ChangeKind string
+
+const (
+	ChangeAdded   ChangeKind = "added"
+	ChangeRemoved ChangeKind = "removed"
+	ChangeChanged ChangeKind = "changed"
+)
+
+// Change is the difference of a single value between two environments.
+// Values referencing Azure Key Vault secrets (akvs://) or secrets stored in the vault of the environment config, ex)
+// secure infrastructure parameters, are masked. Other values, including secure values stored in plain text, are not.
+type Change struct {
+	Kind ChangeKind `json:"kind"`
+	// The .env key or the config.json path of the value, ex) infra.parameters.location
+	Key string `json:"key"`
+	// The value in the environment being compared from, empty when the value has been added
+	OldValue string `json:"oldValue,omitempty"`
+	// The value in the environment being compared to, empty when the value has been removed
+	NewValue string `json:"newValue,omitempty"`
+}
+
+// Diff contains the differences between two environments
+type Diff struct {
+	// The name of the environment being compared from
+	From string `json:"from"`
+	// The name of the environment being compared to
+	To string `json:"to"`
+	// Differences between the .env values
+	Dotenv []*Change `json:"dotenv"`
+	// Differences between the config.json values
+	Config []*Change `json:"config"`
+}
+
+// HasChanges returns true when the environments are different
+func (d *Diff) HasChanges() bool {
+	return len(d.Dotenv) > 0 || len(d.Config) > 0
+}
+
+// Compare returns the differences between the .env and config.json values of two environments.
+// The AZURE_ENV_NAME value and the vault id of the config are ignored since they are always different.
+func Compare(from *Environment, to *Environment) (*Diff, error) {
+	fromDotenv := from.Dotenv()
+	toDotenv := to.Dotenv()
+	delete(fromDotenv, EnvNameEnvVarName)
+	delete(toDotenv, EnvNameEnvVarName)
+
+	fromConfig, err := flattenConfig(from)
+	if err != nil {
+		return nil, fmt.Errorf("reading config of environment '%s': %w", from.Name(), err)
+	}
+
+	toConfig, err := flattenConfig(to)
+	if err != nil {
+		return nil, fmt.Errorf("reading config of environment '%s': %w", to.Name(), err)
+	}
+
+	return &Diff{
+		From:   from.Name(),
+		To:     to.Name(),
+		Dotenv: compareValues(fromDotenv, toDotenv),
+		Config: compareValues(fromConfig, toConfig),
+	}, nil
+}
+
+// compareValues returns the changes between two sets of values, sorted by key
+func compareValues(from map[string]string, to map[string]string) []*Change {
+	changes := []*Change{}
+	keys := slices.Collect(maps.Keys(from))
+	for key := range to {
+		if _, has := from[key]; !has {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		fromValue, inFrom := from[key]
+		toValue, inTo := to[key]
+
+		switch {
+		case !inFrom:
+			changes = append(changes, &Change{Kind: ChangeAdded, Key: key, NewValue: maskSecret(toValue)})
+		case !inTo:
+			changes = append(changes, &Change{Kind: ChangeRemoved, Key: key, OldValue: maskSecret(fromValue)})
+		case fromValue != toValue:
+			changes = append(changes, &Change{
+				Kind:     ChangeChanged,
+				Key:      key,
+				OldValue: maskSecret(fromValue),
+				NewValue: maskSecret(toValue),
+			})
+		}
+	}
+
+	return changes
+}
+
+// flattenConfig returns the leaf values of the environment config keyed by their dotted path.
+// Non string values are JSON encoded.
+func flattenConfig(env *Environment) (map[string]string, error) {
+	values := map[string]string{}
+	err := walkConfig(env, func(path string, value any) error {
+		if path == configVaultKey {
+			return nil
+		}
+
+		if stringValue, ok := value.(string); ok {
+			values[path] = stringValue
+			return nil
+		}
+
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("encoding config value '%s': %w", path, err)
+		}
+
+		values[path] = string(encoded)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// walkConfig invokes the callback with the dotted path of each leaf value of the environment config
+func walkConfig(env *Environment, callback func(path string, value any) error) error {
+	if env.Config == nil {
+		return nil
+	}
+
+	return walkConfigSection("", env.Config.Raw(), callback)
+}
+
+func walkConfigSection(prefix string, section map[string]any, callback func(path string, value any) error) error {
+	for key, value := range section {
+		path := key
+		if prefix != "" {
+			path = fmt.Sprintf("%s.%s", prefix, key)
+		}
+
+		if child, ok := value.(map[string]any); ok {
+			if err := walkConfigSection(path, child, callback); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := callback(path, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func maskSecret(value string) string {
+	if keyvault.IsAzureKeyVaultSecret(value) || config.IsVaultReference(value) {
+		return SecretMask
+	}
+
+	return value
+}
+
+// resourceEnvVarNames are the .env keys azd sets to the resources and identities of an environment
+var resourceEnvVarNames = []string{
+	ResourceGroupEnvVarName,
+	PrincipalIdEnvVarName,
+	ContainerRegistryEndpointEnvVarName,
+	AksClusterEnvVarName,
+}
+
+// isResourceEnvVar returns true for the .env keys referencing the resources and identities of an environment, ex)
+// AZURE_RESOURCE_GROUP, SERVICE_API_RESOURCE_NAME or the outputs of the provisioned infrastructure, which must not be
+// shared by other environments.
+func isResourceEnvVar(key string, outputNames []string) bool {
+	return slices.Contains(resourceEnvVarNames, key) ||
+		strings.HasPrefix(key, "SERVICE_") ||
+		slices.Contains(outputNames, key)
+}
+
+// resourceConfigSections are the config sections azd sets to the state of the resources of an environment
+var resourceConfigSections = []string{
+	// the deployment history of the services
+	"deployments",
+	// the names of the outputs of the provisioned infrastructure
+	"provision",
+}
+
+// CopyValues copies the values of the source environment into the target environment.
+// When keys are specified only the matching .env values are copied and config values aren't, otherwise all .env values
+// except AZURE_ENV_NAME and all config values are copied. Secret config values are stored in the vault of the target
+// environment. Values referencing the resources and identities of the source environment, ex) AZURE_RESOURCE_GROUP,
+// SERVICE_<NAME>_*, the outputs of the provisioned infrastructure or the deployment history, are only copied when
+// includeResources is true. Existing values of the target environment are overwritten.
+func CopyValues(source *Environment, target *Environment, keys []string, includeResources bool) error {
+	sourceDotenv := source.Dotenv()
+
+	if len(keys) > 0 {
+		for _, key := range keys {
+			value, has := sourceDotenv[key]
+			if !has {
+				return fmt.Errorf("key '%s' not found in environment '%s'", key, source.Name())
+			}
+
+			target.DotenvSet(key, value)
+		}
+
+		return nil
+	}
+
+	outputNames := source.OutputNames()
+	for key, value := range sourceDotenv {
+		if key == EnvNameEnvVarName || (!includeResources && isResourceEnvVar(key, outputNames)) {
+			continue
+		}
+
+		target.DotenvSet(key, value)
+	}
+
+	return walkConfig(source, func(path string, value any) error {
+		section, _, _ := strings.Cut(path, ".")
+		if path == configVaultKey || (!includeResources && slices.Contains(resourceConfigSections, section)) {
+			return nil
+		}
+
+		// Secrets are stored in the vault of the target environment
+		if vaultRef, ok := value.(string); ok && config.IsVaultReference(vaultRef) {
+			secret, has := source.Config.GetString(path)
+			if !has {
+				return fmt.Errorf("reading secret config value '%s': the secret was not found", path)
+			}
+
+			if err := target.Config.SetSecret(path, secret); err != nil {
+				return fmt.Errorf("setting secret config value '%s': %w", path, err)
+			}
+
+			return nil
+		}
+
+		if err := target.Config.Set(path, value); err != nil {
+			return fmt.Errorf("setting config value '%s': %w", path, err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,129 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package environment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Compare(t *testing.T) {
+	dev := NewWithValues("dev", map[string]string{
+		"AZURE_LOCATION": "westus2",
+		"API_URL":        "https://dev.contoso.com",
+		"DB_PASSWORD":    "akvs://sub/vault/dev-password",
+		"DEBUG":          "true",
+	})
+	require.NoError(t, dev.Config.Set("infra.parameters.sku", "B1"))
+	require.NoError(t, dev.Config.Set("infra.parameters.replicas", 1))
+	require.NoError(t, dev.Config.SetSecret("infra.parameters.adminPassword", "dev-secret"))
+
+	staging := NewWithValues("staging", map[string]string{
+		"AZURE_LOCATION": "eastus2",
+		"API_URL":        "https://dev.contoso.com",
+		"DB_PASSWORD":    "akvs://sub/vault/staging-password",
+		"CACHE_URL":      "https://cache.contoso.com",
+	})
+	require.NoError(t, staging.Config.Set("infra.parameters.sku", "P1v3"))
+	require.NoError(t, staging.Config.SetSecret("infra.parameters.adminPassword", "staging-secret"))
+
+	diff, err := Compare(dev, staging)
+	require.NoError(t, err)
+	require.True(t, diff.HasChanges())
+	require.Equal(t, "dev", diff.From)
+	require.Equal(t, "staging", diff.To)
+
+	require.Equal(t, []*Change{
+		{Kind: ChangeChanged, Key: "AZURE_LOCATION", OldValue: "westus2", NewValue: "eastus2"},
+		{Kind: ChangeAdded, Key: "CACHE_URL", NewValue: "https://cache.contoso.com"},
+		{Kind: ChangeChanged, Key: "DB_PASSWORD", OldValue: SecretMask, NewValue: SecretMask},
+		{Kind: ChangeRemoved, Key: "DEBUG", OldValue: "true"},
+	}, diff.Dotenv)
+
+	// secure parameters are masked and the vault ids of the configs aren't compared
+	require.Equal(t, []*Change{
+		{Kind: ChangeChanged, Key: "infra.parameters.adminPassword", OldValue: SecretMask, NewValue: SecretMask},
+		{Kind: ChangeRemoved, Key: "infra.parameters.replicas", OldValue: "1"},
+		{Kind: ChangeChanged, Key: "infra.parameters.sku", OldValue: "B1", NewValue: "P1v3"},
+	}, diff.Config)
+
+	diff, err = Compare(dev, dev)
+	require.NoError(t, err)
+	require.False(t, diff.HasChanges())
+}
+
+func Test_CopyValues(t *testing.T) {
+	source := NewWithValues("dev", map[string]string{
+		"AZURE_LOCATION":            "westus2",
+		"API_URL":                   "https://dev.contoso.com",
+		"AZURE_RESOURCE_GROUP":      "rg-dev",
+		"SERVICE_API_RESOURCE_NAME": "ca-api-dev",
+		"WEBSITE_URL":               "https://app-dev.azurewebsites.net",
+	})
+	require.NoError(t, source.Config.Set("infra.parameters.sku", "B1"))
+	require.NoError(t, source.Config.SetSecret("infra.parameters.adminPassword", "dev-secret"))
+	require.NoError(t, source.Config.Set("deployments.api", []any{map[string]any{"image": "api:1"}}))
+	require.NoError(t, source.AddOutputNames("WEBSITE_URL"))
+
+	t.Run("All", func(t *testing.T) {
+		target := New("staging")
+		require.NoError(t, CopyValues(source, target, nil, false))
+
+		require.Equal(t, "staging", target.Name())
+		require.Equal(t, "staging", target.Getenv(EnvNameEnvVarName))
+		require.Equal(t, "westus2", target.Getenv("AZURE_LOCATION"))
+		require.Equal(t, "https://dev.contoso.com", target.Getenv("API_URL"))
+
+		sku, has := target.Config.GetString("infra.parameters.sku")
+		require.True(t, has)
+		require.Equal(t, "B1", sku)
+
+		// secrets are stored in the vault of the target environment
+		password, has := target.Config.GetString("infra.parameters.adminPassword")
+		require.True(t, has)
+		require.Equal(t, "dev-secret", password)
+		sourceVault, _ := source.Config.GetString(configVaultKey)
+		targetVault, _ := target.Config.GetString(configVaultKey)
+		require.NotEmpty(t, targetVault)
+		require.NotEqual(t, sourceVault, targetVault)
+
+		// the resources of the source environment are not shared
+		require.Empty(t, target.Getenv(ResourceGroupEnvVarName))
+		require.Empty(t, target.Getenv("SERVICE_API_RESOURCE_NAME"))
+		require.Empty(t, target.Getenv("WEBSITE_URL"))
+		require.Empty(t, target.OutputNames())
+		_, has = target.Config.Get("deployments.api")
+		require.False(t, has)
+	})
+
+	t.Run("IncludeResources", func(t *testing.T) {
+		target := New("staging")
+		require.NoError(t, CopyValues(source, target, nil, true))
+
+		require.Equal(t, "staging", target.Getenv(EnvNameEnvVarName))
+		require.Equal(t, "rg-dev", target.Getenv(ResourceGroupEnvVarName))
+		require.Equal(t, "ca-api-dev", target.Getenv("SERVICE_API_RESOURCE_NAME"))
+		require.Equal(t, "https://app-dev.azurewebsites.net", target.Getenv("WEBSITE_URL"))
+		require.Equal(t, []string{"WEBSITE_URL"}, target.OutputNames())
+		_, has := target.Config.Get("deployments.api")
+		require.True(t, has)
+	})
+
+	t.Run("Keys", func(t *testing.T) {
+		target := New("staging")
+		require.NoError(t, CopyValues(source, target, []string{"API_URL"}, false))
+
+		require.Equal(t, "https://dev.contoso.com", target.Getenv("API_URL"))
+		require.Empty(t, target.Getenv("AZURE_LOCATION"))
+
+		_, has := target.Config.Get("infra.parameters.sku")
+		require.False(t, has)
+	})
+
+	t.Run("MissingKey", func(t *testing.T) {
+		err := CopyValues(source, New("staging"), []string{"MISSING"}, false)
+		require.ErrorContains(t, err, "key 'MISSING' not found in environment 'dev'")
+	})
+}
@@ -0,0 +1,192 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package environment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/internal/tracing"
+	"github.com/azure/azure-dev/cli/azd/internal/tracing/fields"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/joho/godotenv"
+)
+
+// DirectoryConfig is the remote state configuration for the LocalDirectory backend
+type DirectoryConfig struct {
+	// The directory that stores the environments, ex) a mounted network file share.
+	// Relative paths are resolved from the project directory.
+	Path string `json:"path"`
+}
+
+// DirectoryDataStore is a RemoteDataStore implementation that stores environments within a shared directory such as a
+// mounted NFS or SMB share. Each environment is stored within a sub directory named after the environment.
+type DirectoryDataStore struct {
+	root          string
+	configManager config.FileConfigManager
+}
+
+// NewDirectoryDataStore creates a new DirectoryDataStore instance
+func NewDirectoryDataStore(
+	azdContext *azdcontext.AzdContext,
+	configManager config.FileConfigManager,
+	directoryConfig *DirectoryConfig,
+) (RemoteDataStore, error) {
+	if directoryConfig == nil || directoryConfig.Path == "" {
+		return nil, fmt.Errorf("remote state configuration for '%s' requires a 'path'", RemoteKindLocalDirectory)
+	}
+
+	root := directoryConfig.Path
+	if !filepath.IsAbs(root) {
+		root = filepath.Join(azdContext.ProjectDirectory(), root)
+	}
+
+	return &DirectoryDataStore{
+		root:          root,
+		configManager: configManager,
+	}, nil
+}
+
+// EnvPath returns the path to the .env file for the given environment
+func (ds *DirectoryDataStore) EnvPath(env *Environment) string {
+	return filepath.Join(ds.root, env.name, DotEnvFileName)
+}
+
+// ConfigPath returns the path to the config.json file for the given environment
+func (ds *DirectoryDataStore) ConfigPath(env *Environment) string {
+	return filepath.Join(ds.root, env.name, ConfigFileName)
+}
+
+// List returns a list of all environments within the directory
+func (ds *DirectoryDataStore) List(ctx context.Context) ([]*contracts.EnvListEnvironment, error) {
+	entries, err := os.ReadDir(ds.root)
+	if errors.Is(err, os.ErrNotExist) {
+		return []*contracts.EnvListEnvironment{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("listing entries: %w", err)
+	}
+
+	envs := []*contracts.EnvListEnvironment{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		env := &Environment{name: entry.Name()}
+		envs = append(envs, &contracts.EnvListEnvironment{
+			Name:       entry.Name(),
+			DotEnvPath: ds.EnvPath(env),
+			ConfigPath: ds.ConfigPath(env),
+		})
+	}
+
+	slices.SortFunc(envs, func(a, b *contracts.EnvListEnvironment) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return envs, nil
+}
+
+// Get returns the environment instance for the specified environment name
+func (ds *DirectoryDataStore) Get(ctx context.Context, name string) (*Environment, error) {
+	_, err := os.Stat(filepath.Join(ds.root, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("'%s': %w", name, ErrNotFound)
+	} else if err != nil {
+		return nil, fmt.Errorf("listing env root: %w", err)
+	}
+
+	env := New(name)
+	if err := ds.Reload(ctx, env); err != nil {
+		return nil, err
+	}
+
+	return env, nil
+}
+
+// Reload reloads the environment from the directory
+func (ds *DirectoryDataStore) Reload(ctx context.Context, env *Environment) error {
+	if envMap, err := godotenv.Read(ds.EnvPath(env)); errors.Is(err, os.ErrNotExist) {
+		env.setDotenv(make(map[string]string))
+	} else if err != nil {
+		return fmt.Errorf("loading .env: %w", err)
+	} else {
+		env.setDotenv(envMap)
+	}
+
+	if cfg, err := ds.configManager.Load(ds.ConfigPath(env)); errors.Is(err, os.ErrNotExist) {
+		env.setConfig(config.NewEmptyConfig())
+	} else if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	} else {
+		env.setConfig(cfg)
+	}
+
+	if env.Name() != "" {
+		tracing.SetUsageAttributes(fields.StringHashed(fields.EnvNameKey, env.Name()))
+	}
+
+	return nil
+}
+
+// Save saves the environment to the directory
+func (ds *DirectoryDataStore) Save(ctx context.Context, env *Environment, options *SaveOptions) error {
+	if err := os.MkdirAll(filepath.Join(ds.root, env.name), osutil.PermissionDirectory); err != nil {
+		return fmt.Errorf("creating environment directory: %w", err)
+	}
+
+	if err := env.saveConfig(func(cfg config.Config) error {
+		return ds.configManager.Save(cfg, ds.ConfigPath(env))
+	}); err != nil {
+		return fmt.Errorf("saving config: %w", err)
+	}
+
+	marshalled, err := marshallDotEnv(env)
+	if err != nil {
+		return fmt.Errorf("marshalling .env: %w", err)
+	}
+
+	if err := os.WriteFile(ds.EnvPath(env), []byte(marshalled+"\n"), osutil.PermissionFile); err != nil {
+		return fmt.Errorf("saving .env: %w", err)
+	}
+
+	tracing.SetUsageAttributes(fields.StringHashed(fields.EnvNameKey, env.Name()))
+	return nil
+}
+
+// Delete removes the environment from the directory
+func (ds *DirectoryDataStore) Delete(ctx context.Context, name string) error {
+	envRoot := filepath.Join(ds.root, name)
+	_, err := os.Stat(envRoot)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("'%s': %w", name, ErrNotFound)
+	} else if err != nil {
+		return fmt.Errorf("listing env root: %w", err)
+	}
+
+	if err := os.RemoveAll(envRoot); err != nil {
+		return fmt.Errorf("removing env root: %w", err)
+	}
+
+	return nil
+}
+
+// Lock acquires the lock of the environment by creating a lock file within the environment directory
+func (ds *DirectoryDataStore) Lock(ctx context.Context, name string, lock *LockInfo) error {
+	return acquireLockFile(name, filepath.Join(ds.root, name, LockFileName), lock)
+}
+
+// Unlock releases the lock of the environment by removing the lock file within the environment directory
+func (ds *DirectoryDataStore) Unlock(ctx context.Context, name string, lockId string) error {
+	return releaseLockFile(filepath.Join(ds.root, name, LockFileName), lockId)
+}
@@ -0,0 +1,63 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package environment
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DirectoryDataStore_SaveGetListDelete(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+	fileConfigManager := config.NewFileConfigManager(config.NewManager())
+	dataStore, err := NewDirectoryDataStore(azdContext, fileConfigManager, &DirectoryConfig{Path: "shared"})
+	require.NoError(t, err)
+
+	envList, err := dataStore.List(*mockContext.Context)
+	require.NoError(t, err)
+	require.Empty(t, envList)
+
+	env1 := New("env1")
+	env1.DotenvSet("key1", "value1")
+	require.NoError(t, env1.Config.Set("infra.parameters.location", "westus2"))
+	require.NoError(t, dataStore.Save(*mockContext.Context, env1, nil))
+	require.NoError(t, dataStore.Save(*mockContext.Context, New("env2"), nil))
+
+	envList, err = dataStore.List(*mockContext.Context)
+	require.NoError(t, err)
+	require.Len(t, envList, 2)
+	require.Equal(t, "env1", envList[0].Name)
+	require.Equal(t, filepath.Join(azdContext.ProjectDirectory(), "shared", "env1", DotEnvFileName), envList[0].DotEnvPath)
+
+	env, err := dataStore.Get(*mockContext.Context, "env1")
+	require.NoError(t, err)
+	require.Equal(t, "value1", env.Getenv("key1"))
+	location, has := env.Config.Get("infra.parameters.location")
+	require.True(t, has)
+	require.Equal(t, "westus2", location)
+
+	require.NoError(t, dataStore.Delete(*mockContext.Context, "env1"))
+
+	_, err = dataStore.Get(*mockContext.Context, "env1")
+	require.True(t, errors.Is(err, ErrNotFound))
+
+	err = dataStore.Delete(*mockContext.Context, "env1")
+	require.True(t, errors.Is(err, ErrNotFound))
+}
+
+func Test_DirectoryDataStore_RequiresPath(t *testing.T) {
+	azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+	fileConfigManager := config.NewFileConfigManager(config.NewManager())
+
+	_, err := NewDirectoryDataStore(azdContext, fileConfigManager, &DirectoryConfig{})
+	require.Error(t, err)
+}
@@ -10,7 +10,9 @@ import (
 	"log"
 	"os"
 	"regexp"
+	"slices"
 	"strings"
+	"sync"
 
 	"maps"
 
@@ -51,6 +53,12 @@ const PlatformTypeEnvVarName = "AZD_PLATFORM_TYPE"
 type Environment struct {
 	name string
 
+	// mu guards dotenv, deletedKeys and the replacement of Config, allowing services to be deployed concurrently
+	mu sync.RWMutex
+
+	// saveMu serializes the saves of the environment by services deployed concurrently, which write the same files
+	saveMu sync.Mutex
+
 	// dotenv is a map of keys to values, persisted to the `.env` file stored in this environment's [Root].
 	dotenv map[string]string
 
@@ -58,8 +66,12 @@ type Environment struct {
 	// happens in Save
 	deletedKeys map[string]struct{}
 
-	// Config is environment specific config
+	// Config is environment specific config, guarded against concurrent access while services are deployed
 	Config config.Config
+
+	// remoteETags tracks the ETags of the remote files last read or written by a remote data store, keyed by path.
+	// Guarded by mu.
+	remoteETags map[string]string
 }
 
 const AzdInitialEnvironmentConfigName = "AZD_INITIAL_ENVIRONMENT_CONFIG"
@@ -70,7 +82,7 @@ func New(name string) *Environment {
 		name:        name,
 		dotenv:      make(map[string]string),
 		deletedKeys: make(map[string]struct{}),
-		Config:      getInitialConfig(),
+		Config:      newSyncConfig(getInitialConfig()),
 	}
 
 	env.DotenvSet(EnvNameEnvVarName, name)
@@ -146,6 +158,9 @@ func CleanName(name string) string {
 // Getenv behaves like os.Getenv, except that any keys in the `.env` file associated with this environment are considered
 // first.
 func (e *Environment) Getenv(key string) string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
 	if v, has := e.dotenv[key]; has {
 		return v
 	}
@@ -156,6 +171,9 @@ func (e *Environment) Getenv(key string) string {
 // LookupEnv behaves like os.LookupEnv, except that any keys in the `.env` file associated with this environment are
 // considered first.
 func (e *Environment) LookupEnv(key string) (string, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
 	if v, has := e.dotenv[key]; has {
 		return v, true
 	}
@@ -166,18 +184,27 @@ func (e *Environment) LookupEnv(key string) (string, bool) {
 // DotenvDelete removes the given key from the .env file in the environment, it is a no-op if the key
 // does not exist. [Save] should be called to ensure this change is persisted.
 func (e *Environment) DotenvDelete(key string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
 	delete(e.dotenv, key)
 	e.deletedKeys[key] = struct{}{}
 }
 
 // Dotenv returns a copy of the key value pairs from the .env file in the environment.
 func (e *Environment) Dotenv() map[string]string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
 	return maps.Clone(e.dotenv)
 }
 
 // DotenvSet sets the value of [key] to [value] in the .env file associated with the environment. [Save] should be
 // called to ensure this change is persisted.
 func (e *Environment) DotenvSet(key string, value string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
 	e.dotenv[key] = value
 	delete(e.deletedKeys, key)
 }
@@ -232,9 +259,48 @@ func (e *Environment) SetServiceProperty(serviceName string, propertyName string
 	e.DotenvSet(fmt.Sprintf("SERVICE_%s_%s", Key(serviceName), propertyName), value)
 }
 
+// outputsConfigPath is the config path of the names of the .env keys set to the outputs of the provisioned
+// infrastructure
+const outputsConfigPath = "provision.outputs"
+
+// AddOutputNames records the names of the .env keys set to the outputs of the provisioned infrastructure, which
+// reference the resources of the environment, ex) to exclude them when the environment is copied.
+func (e *Environment) AddOutputNames(names ...string) error {
+	outputNames := append(e.OutputNames(), names...)
+	slices.Sort(outputNames)
+
+	return e.Config.Set(outputsConfigPath, slices.Compact(outputNames))
+}
+
+// OutputNames returns the names of the .env keys set to the outputs of the provisioned infrastructure. The names are
+// recorded when the infrastructure is provisioned or the environment is refreshed.
+func (e *Environment) OutputNames() []string {
+	value, has := e.Config.Get(outputsConfigPath)
+	if !has {
+		return nil
+	}
+
+	var outputNames []string
+	switch values := value.(type) {
+	case []string:
+		outputNames = slices.Clone(values)
+	case []any:
+		for _, name := range values {
+			if name, ok := name.(string); ok {
+				outputNames = append(outputNames, name)
+			}
+		}
+	}
+
+	return outputNames
+}
+
 // Creates a slice of key value pairs, based on the entries in the `.env` file like `KEY=VALUE` that
 // can be used to pass into command runner or similar constructs.
 func (e *Environment) Environ() []string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
 	envVars := []string{}
 	for k, v := range e.dotenv {
 		envVars = append(envVars, fmt.Sprintf("%s=%s", k, v))
@@ -278,14 +344,50 @@ func fixupUnquotedDotenv(values map[string]string, dotenv string) string {
 	return strings.Join(entries, "\n")
 }
 
+// remoteETag returns the ETag of the remote file last read or written at the specified path, if any
+func (e *Environment) remoteETag(path string) string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.remoteETags[path]
+}
+
+// setRemoteETag records the ETag of the remote file last read or written at the specified path
+func (e *Environment) setRemoteETag(path string, etag string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.remoteETags == nil {
+		e.remoteETags = map[string]string{}
+	}
+
+	e.remoteETags[path] = etag
+}
+
 // Prepare dotenv for saving and returns a marshalled string that can be save to the underlying data store
 // Instead of calling `godotenv.Write` directly, we need to save the file ourselves, so we can fixup any numeric values
 // that were incorrectly unquoted.
 func marshallDotEnv(env *Environment) (string, error) {
-	marshalled, err := godotenv.Marshal(env.dotenv)
+	return marshallDotEnvValues(env.Dotenv())
+}
+
+// marshallDotEnvValues marshals the values of a dotenv. Callers reading the values of an environment directly must hold
+// its lock.
+func marshallDotEnvValues(values map[string]string) (string, error) {
+	marshalled, err := godotenv.Marshal(values)
 	if err != nil {
 		return "", fmt.Errorf("marshalling .env: %w", err)
 	}
 
-	return fixupUnquotedDotenv(env.dotenv, marshalled), nil
+	return fixupUnquotedDotenv(values, marshalled), nil
+}
+
+// setDotenv replaces the values of the environment with the values loaded from a data store, discarding pending
+// deletions.
+func (e *Environment) setDotenv(values map[string]string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.dotenv = values
+	e.deletedKeys = make(map[string]struct{})
 }
@@ -0,0 +1,167 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package environment
+
+import (
+	"sync"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+)
+
+// syncConfig guards the config of an environment, which is updated by services deployed concurrently while the
+// environment is being saved. Values returned by the config are copies that can't be modified concurrently.
+type syncConfig struct {
+	mu     sync.RWMutex
+	config config.Config
+}
+
+func newSyncConfig(cfg config.Config) *syncConfig {
+	return &syncConfig{config: cfg}
+}
+
+func (c *syncConfig) Raw() map[string]any {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return copyConfigValue(c.config.Raw()).(map[string]any)
+}
+
+func (c *syncConfig) ResolvedRaw() map[string]any {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return copyConfigValue(c.config.ResolvedRaw()).(map[string]any)
+}
+
+func (c *syncConfig) Get(path string) (any, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	value, has := c.config.Get(path)
+	return copyConfigValue(value), has
+}
+
+func (c *syncConfig) GetString(path string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.config.GetString(path)
+}
+
+func (c *syncConfig) GetSection(path string, section any) (bool, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.config.GetSection(path, section)
+}
+
+func (c *syncConfig) GetMap(path string) (map[string]any, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	value, has := c.config.GetMap(path)
+	if value == nil {
+		return value, has
+	}
+
+	return copyConfigValue(value).(map[string]any), has
+}
+
+func (c *syncConfig) GetSlice(path string) ([]any, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	value, has := c.config.GetSlice(path)
+	if value == nil {
+		return value, has
+	}
+
+	return copyConfigValue(value).([]any), has
+}
+
+func (c *syncConfig) Set(path string, value any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.config.Set(path, value)
+}
+
+func (c *syncConfig) SetSecret(path string, value string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.config.SetSecret(path, value)
+}
+
+func (c *syncConfig) Unset(path string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.config.Unset(path)
+}
+
+func (c *syncConfig) IsEmpty() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.config.IsEmpty()
+}
+
+// copyConfigValue returns a deep copy of the maps and slices of a config value
+func copyConfigValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		values := make(map[string]any, len(v))
+		for key, item := range v {
+			values[key] = copyConfigValue(item)
+		}
+
+		return values
+	case []any:
+		values := make([]any, len(v))
+		for index, item := range v {
+			values[index] = copyConfigValue(item)
+		}
+
+		return values
+	default:
+		return v
+	}
+}
+
+// setConfig replaces the config of the environment, ex) when the environment is reloaded. The config is replaced
+// inside the existing guard, so services reading the config while the environment is reloaded keep using the same guard.
+func (e *Environment) setConfig(cfg config.Config) {
+	e.mu.Lock()
+	synced, ok := e.Config.(*syncConfig)
+	if !ok {
+		e.Config = newSyncConfig(cfg)
+	}
+	e.mu.Unlock()
+
+	if ok {
+		synced.mu.Lock()
+		defer synced.mu.Unlock()
+
+		synced.config = cfg
+	}
+}
+
+// saveConfig invokes save with the config of the environment, which can't be modified until save returns.
+// The config passed to save is the config loaded by the data store, as expected by config.FileConfigManager.
+func (e *Environment) saveConfig(save func(cfg config.Config) error) error {
+	e.mu.RLock()
+	cfg := e.Config
+	e.mu.RUnlock()
+
+	synced, ok := cfg.(*syncConfig)
+	if !ok {
+		return save(cfg)
+	}
+
+	synced.mu.RLock()
+	defer synced.mu.RUnlock()
+
+	return save(synced.config)
+}
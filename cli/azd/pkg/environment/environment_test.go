@@ -6,8 +6,10 @@ package environment
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
@@ -273,3 +275,69 @@ func createEnvManager(mockContext *mocks.MockContext, root string) (Manager, *az
 
 	return newManagerForTest(azdCtx, mockContext.Console, localDataStore, nil), azdCtx
 }
+
+// Test_ConcurrentConfigSave validates that the config can be updated by services deployed concurrently while the
+// environment is being saved.
+func Test_ConcurrentConfigSave(t *testing.T) {
+	azdCtx := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+	dataStore := NewLocalFileDataStore(azdCtx, config.NewFileConfigManager(config.NewManager()))
+
+	env := New("test")
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			path := fmt.Sprintf("services.api%d.deployments", i)
+			assert.NoError(t, env.Config.Set(path, []any{map[string]any{"image": "api"}}))
+			assert.NoError(t, dataStore.Save(context.Background(), env, nil))
+		}()
+	}
+	wg.Wait()
+
+	require.NoError(t, dataStore.Save(context.Background(), env, nil))
+	require.NoError(t, dataStore.Reload(context.Background(), env))
+	for i := range 10 {
+		deployments, has := env.Config.GetSlice(fmt.Sprintf("services.api%d.deployments", i))
+		require.True(t, has)
+		require.Len(t, deployments, 1)
+	}
+}
+
+func Test_ConcurrentConfigReload(t *testing.T) {
+	azdCtx := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+	dataStore := NewLocalFileDataStore(azdCtx, config.NewFileConfigManager(config.NewManager()))
+
+	env := New("test")
+	require.NoError(t, env.Config.Set("services.api.deployments", []any{map[string]any{"image": "api"}}))
+	require.NoError(t, dataStore.Save(context.Background(), env, nil))
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+
+			assert.NoError(t, dataStore.Reload(context.Background(), env))
+		}()
+		go func() {
+			defer wg.Done()
+
+			_, has := env.Config.GetSlice("services.api.deployments")
+			assert.True(t, has)
+			assert.NoError(t, env.Config.Set(fmt.Sprintf("services.web%d.deployments", i), []any{}))
+			assert.Equal(t, "test", env.Getenv(EnvNameEnvVarName))
+		}()
+	}
+	wg.Wait()
+}
+
+func Test_AddOutputNames(t *testing.T) {
+	env := New("dev")
+	require.Empty(t, env.OutputNames())
+
+	require.NoError(t, env.AddOutputNames("WEBSITE_URL", "AZURE_KEY_VAULT_NAME"))
+	require.NoError(t, env.AddOutputNames("WEBSITE_URL", "API_URL"))
+	require.Equal(t, []string{"API_URL", "AZURE_KEY_VAULT_NAME", "WEBSITE_URL"}, env.OutputNames())
+}
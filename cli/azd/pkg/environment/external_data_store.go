@@ -0,0 +1,306 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package environment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/internal/tracing"
+	"github.com/azure/azure-dev/cli/azd/internal/tracing/fields"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+)
+
+var externalRemoteKinds = map[RemoteKind]bool{}
+
+// RegisterExternalRemoteKind registers a remote state backend that is not built into azd, ex) a backend declared by an
+// installed extension. The remote state configuration of projects is validated against the built-in and the registered
+// backends.
+func RegisterExternalRemoteKind(kind RemoteKind) {
+	remoteKindsMu.Lock()
+	defer remoteKindsMu.Unlock()
+
+	// Built-in backends cannot be overridden
+	if slices.Contains(remoteKinds, string(kind)) {
+		return
+	}
+
+	externalRemoteKinds[kind] = true
+	remoteKinds = append(remoteKinds, string(kind))
+}
+
+// isExternalRemoteKind returns true if the backend has been registered by RegisterExternalRemoteKind
+func isExternalRemoteKind(kind RemoteKind) bool {
+	remoteKindsMu.Lock()
+	defer remoteKindsMu.Unlock()
+
+	return externalRemoteKinds[kind]
+}
+
+// EnvironmentState is the state of an environment exchanged with an ExternalDataStore
+type EnvironmentState struct {
+	Name string
+	// The values of the .env file
+	Dotenv map[string]string
+	// The JSON encoded content of the config.json file
+	Config []byte
+	// The version of the environment last read or written, used to detect concurrent modifications.
+	// Empty when the environment has not been read or written.
+	Version string
+}
+
+// ExternalDataStore is a remote data store that is provided at runtime for a backend that is not built into azd,
+// ex) a remote data store provided by an extension. Environments are exchanged as their state.
+type ExternalDataStore interface {
+	// Initializes the data store with the backend specific configuration of the remote state
+	Initialize(ctx context.Context, config map[string]any) error
+
+	// Gets a list of all environments within the data store
+	List(ctx context.Context) ([]*contracts.EnvListEnvironment, error)
+
+	// Gets the state of the environment with the specified name. Returns ErrNotFound when the environment doesn't exist.
+	Get(ctx context.Context, name string) (*EnvironmentState, error)
+
+	// Saves the state of the environment and returns its new version.
+	// Returns ErrConflict when the stored environment doesn't match the version of the state.
+	Save(ctx context.Context, state *EnvironmentState, options *SaveOptions) (string, error)
+
+	// Deletes the environment from the data store
+	Delete(ctx context.Context, name string) error
+
+	// Acquires the lock of the environment with the specified name.
+	// Returns a *LockedError when the environment is already locked.
+	Lock(ctx context.Context, name string, lock *LockInfo) error
+
+	// Releases the lock of the environment with the specified name when held by the lock with the specified id.
+	Unlock(ctx context.Context, name string, lockId string) error
+}
+
+// ExternalDataStoreRegistry contains the remote data stores that are provided at runtime for backends that are not
+// built into azd, ex) remote data stores provided by extensions.
+type ExternalDataStoreRegistry struct {
+	stores sync.Map // key: RemoteKind, value: ExternalDataStore
+}
+
+// NewExternalDataStoreRegistry creates a new empty ExternalDataStoreRegistry
+func NewExternalDataStoreRegistry() *ExternalDataStoreRegistry {
+	return &ExternalDataStoreRegistry{}
+}
+
+// Register registers the data store for the specified backend.
+// Only registered external backends can be provided and a backend can only be registered once.
+func (r *ExternalDataStoreRegistry) Register(kind RemoteKind, store ExternalDataStore) error {
+	if kind == "" {
+		return fmt.Errorf("remote state backend is required")
+	}
+
+	if !isExternalRemoteKind(kind) {
+		if slices.Contains(ValidRemoteKinds(), string(kind)) {
+			return fmt.Errorf("remote state backend '%s' is built into azd and cannot be overridden", kind)
+		}
+
+		return fmt.Errorf("remote state backend '%s' has not been declared by an installed extension", kind)
+	}
+
+	if _, loaded := r.stores.LoadOrStore(kind, store); loaded {
+		return fmt.Errorf("remote state backend '%s' has already been registered", kind)
+	}
+
+	return nil
+}
+
+// Unregister removes the data store registered for the specified backend
+func (r *ExternalDataStoreRegistry) Unregister(kind RemoteKind) {
+	r.stores.Delete(kind)
+}
+
+// Get returns the data store registered for the specified backend
+func (r *ExternalDataStoreRegistry) Get(kind RemoteKind) (ExternalDataStore, bool) {
+	store, has := r.stores.Load(kind)
+	if !has {
+		return nil, false
+	}
+
+	return store.(ExternalDataStore), true
+}
+
+// externalRemoteDataStore is the RemoteDataStore of a backend that is not built into azd. The operations are
+// forwarded to the data store registered for the backend when they run, since extensions register their data stores
+// once they have been started.
+type externalRemoteDataStore struct {
+	kind     RemoteKind
+	config   map[string]any
+	registry *ExternalDataStoreRegistry
+
+	mu sync.Mutex
+	// initialized is the registered data store that has been initialized with the configuration
+	initialized ExternalDataStore
+}
+
+func newExternalRemoteDataStore(
+	kind RemoteKind,
+	config map[string]any,
+	registry *ExternalDataStoreRegistry,
+) RemoteDataStore {
+	return &externalRemoteDataStore{
+		kind:     kind,
+		config:   config,
+		registry: registry,
+	}
+}
+
+// EnvPath returns the path to the .env file for the given environment
+func (ds *externalRemoteDataStore) EnvPath(env *Environment) string {
+	return fmt.Sprintf("%s/%s", env.name, DotEnvFileName)
+}
+
+// ConfigPath returns the path to the config.json file for the given environment
+func (ds *externalRemoteDataStore) ConfigPath(env *Environment) string {
+	return fmt.Sprintf("%s/%s", env.name, ConfigFileName)
+}
+
+func (ds *externalRemoteDataStore) List(ctx context.Context) ([]*contracts.EnvListEnvironment, error) {
+	store, err := ds.store(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return store.List(ctx)
+}
+
+func (ds *externalRemoteDataStore) Get(ctx context.Context, name string) (*Environment, error) {
+	env := &Environment{
+		name: name,
+	}
+
+	if err := ds.Reload(ctx, env); err != nil {
+		return nil, err
+	}
+
+	return env, nil
+}
+
+func (ds *externalRemoteDataStore) Reload(ctx context.Context, env *Environment) error {
+	store, err := ds.store(ctx)
+	if err != nil {
+		return err
+	}
+
+	state, err := store.Get(ctx, env.name)
+	if err != nil {
+		return err
+	}
+
+	dotenv := state.Dotenv
+	if dotenv == nil {
+		dotenv = make(map[string]string)
+	}
+
+	cfg := config.NewEmptyConfig()
+	if len(state.Config) > 0 {
+		if cfg, err = config.Parse(state.Config); err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+	}
+
+	env.setDotenv(dotenv)
+	env.setConfig(cfg)
+	env.setRemoteETag(ds.EnvPath(env), state.Version)
+
+	if env.Name() != "" {
+		tracing.SetUsageAttributes(fields.StringHashed(fields.EnvNameKey, env.Name()))
+	}
+
+	return nil
+}
+
+func (ds *externalRemoteDataStore) Save(ctx context.Context, env *Environment, options *SaveOptions) error {
+	store, err := ds.store(ctx)
+	if err != nil {
+		return err
+	}
+
+	state := &EnvironmentState{
+		Name:    env.name,
+		Dotenv:  env.Dotenv(),
+		Version: env.remoteETag(ds.EnvPath(env)),
+	}
+
+	if err := env.saveConfig(func(cfg config.Config) error {
+		state.Config, err = json.Marshal(cfg.Raw())
+		return err
+	}); err != nil {
+		return fmt.Errorf("saving config: %w", err)
+	}
+
+	version, err := store.Save(ctx, state, options)
+	if err != nil {
+		return err
+	}
+
+	env.setRemoteETag(ds.EnvPath(env), version)
+
+	tracing.SetUsageAttributes(fields.StringHashed(fields.EnvNameKey, env.Name()))
+	return nil
+}
+
+func (ds *externalRemoteDataStore) Delete(ctx context.Context, name string) error {
+	store, err := ds.store(ctx)
+	if err != nil {
+		return err
+	}
+
+	return store.Delete(ctx, name)
+}
+
+func (ds *externalRemoteDataStore) Lock(ctx context.Context, name string, lock *LockInfo) error {
+	store, err := ds.store(ctx)
+	if err != nil {
+		return err
+	}
+
+	return store.Lock(ctx, name, lock)
+}
+
+func (ds *externalRemoteDataStore) Unlock(ctx context.Context, name string, lockId string) error {
+	store, err := ds.store(ctx)
+	if err != nil {
+		return err
+	}
+
+	return store.Unlock(ctx, name, lockId)
+}
+
+// store returns the data store registered for the backend, initializing it with the configuration on first use
+func (ds *externalRemoteDataStore) store(ctx context.Context) (ExternalDataStore, error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	store, has := ds.registry.Get(ds.kind)
+	if !has {
+		return nil, &internal.ErrorWithSuggestion{
+			Err: fmt.Errorf("remote state backend '%s' is not available", ds.kind),
+			Suggestion: fmt.Sprintf(
+				"Ensure the extension providing the '%s' backend is installed and enabled with "+
+					"'azd config set alpha.extensions on'.",
+				ds.kind,
+			),
+		}
+	}
+
+	if store != ds.initialized {
+		if err := store.Initialize(ctx, ds.config); err != nil {
+			return nil, fmt.Errorf("initializing remote state backend '%s': %w", ds.kind, err)
+		}
+
+		ds.initialized = store
+	}
+
+	return store, nil
+}
@@ -0,0 +1,169 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package environment
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ExternalDataStoreRegistry_Register(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	MustRegisterRemoteDataStore(mockContext.Container, RemoteKindAzureBlobStorage, NewStorageBlobDataStore)
+	RegisterExternalRemoteKind(RemoteKindAzureBlobStorage)
+	RegisterExternalRemoteKind("external.registry")
+
+	registry := NewExternalDataStoreRegistry()
+
+	require.ErrorContains(t, registry.Register("", &fakeExternalDataStore{}), "is required")
+	require.ErrorContains(
+		t,
+		registry.Register(RemoteKindAzureBlobStorage, &fakeExternalDataStore{}),
+		"is built into azd and cannot be overridden",
+	)
+	require.ErrorContains(
+		t,
+		registry.Register("external.undeclared", &fakeExternalDataStore{}),
+		"has not been declared by an installed extension",
+	)
+
+	require.NoError(t, registry.Register("external.registry", &fakeExternalDataStore{}))
+	require.ErrorContains(
+		t,
+		registry.Register("external.registry", &fakeExternalDataStore{}),
+		"has already been registered",
+	)
+	require.Contains(t, ValidRemoteKinds(), "external.registry")
+
+	registry.Unregister("external.registry")
+	_, has := registry.Get("external.registry")
+	require.False(t, has)
+	require.NoError(t, registry.Register("external.registry", &fakeExternalDataStore{}))
+}
+
+func Test_ExternalRemoteDataStore_SaveReload(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	RegisterExternalRemoteKind("external.forward")
+
+	registry := NewExternalDataStoreRegistry()
+	config := map[string]any{"bucket": "environments"}
+	dataStore := newExternalRemoteDataStore("external.forward", config, registry)
+
+	// The data store of the backend is resolved when the operations run
+	_, err := dataStore.Get(*mockContext.Context, "dev")
+	var suggestionErr *internal.ErrorWithSuggestion
+	require.ErrorAs(t, err, &suggestionErr)
+	require.ErrorContains(t, err, "remote state backend 'external.forward' is not available")
+
+	externalStore := &fakeExternalDataStore{environments: map[string]*EnvironmentState{}}
+	require.NoError(t, registry.Register("external.forward", externalStore))
+
+	env := New("dev")
+	env.DotenvSet("AZURE_LOCATION", "westus2")
+	require.NoError(t, env.Config.Set("infra.parameters.location", "westus2"))
+	require.NoError(t, dataStore.Save(*mockContext.Context, env, &SaveOptions{IsNew: true}))
+	require.Equal(t, config, externalStore.config)
+	require.Equal(t, "1", env.remoteETag(dataStore.EnvPath(env)))
+
+	// Saving sends the version of the environment last read or written
+	env.DotenvSet("AZURE_LOCATION", "eastus2")
+	require.NoError(t, dataStore.Save(*mockContext.Context, env, nil))
+	require.Equal(t, "2", env.remoteETag(dataStore.EnvPath(env)))
+
+	stale := New("dev")
+	stale.setRemoteETag(dataStore.EnvPath(stale), "1")
+	require.ErrorIs(t, dataStore.Save(*mockContext.Context, stale, nil), ErrConflict)
+
+	reloaded, err := dataStore.Get(*mockContext.Context, "dev")
+	require.NoError(t, err)
+	require.Equal(t, "eastus2", reloaded.Getenv("AZURE_LOCATION"))
+	location, has := reloaded.Config.Get("infra.parameters.location")
+	require.True(t, has)
+	require.Equal(t, "westus2", location)
+	require.Equal(t, "2", reloaded.remoteETag(dataStore.EnvPath(reloaded)))
+
+	_, err = dataStore.Get(*mockContext.Context, "prod")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// Data stores are only initialized again when a different data store has been registered
+	require.Equal(t, 1, externalStore.initialized)
+	registry.Unregister("external.forward")
+	require.NoError(t, registry.Register("external.forward", externalStore))
+	require.NoError(t, dataStore.Delete(*mockContext.Context, "dev"))
+	require.Equal(t, 1, externalStore.initialized)
+
+	replacement := &fakeExternalDataStore{environments: map[string]*EnvironmentState{}}
+	registry.Unregister("external.forward")
+	require.NoError(t, registry.Register("external.forward", replacement))
+	_, err = dataStore.List(*mockContext.Context)
+	require.NoError(t, err)
+	require.Equal(t, 1, replacement.initialized)
+}
+
+type fakeExternalDataStore struct {
+	config       map[string]any
+	initialized  int
+	environments map[string]*EnvironmentState
+}
+
+func (ds *fakeExternalDataStore) Initialize(ctx context.Context, config map[string]any) error {
+	ds.config = config
+	ds.initialized++
+	return nil
+}
+
+func (ds *fakeExternalDataStore) List(ctx context.Context) ([]*contracts.EnvListEnvironment, error) {
+	envs := []*contracts.EnvListEnvironment{}
+	for name := range ds.environments {
+		envs = append(envs, &contracts.EnvListEnvironment{Name: name})
+	}
+
+	return envs, nil
+}
+
+func (ds *fakeExternalDataStore) Get(ctx context.Context, name string) (*EnvironmentState, error) {
+	state, has := ds.environments[name]
+	if !has {
+		return nil, fmt.Errorf("'%s': %w", name, ErrNotFound)
+	}
+
+	return state, nil
+}
+
+func (ds *fakeExternalDataStore) Save(ctx context.Context, state *EnvironmentState, options *SaveOptions) (string, error) {
+	version := 0
+	if stored, has := ds.environments[state.Name]; has {
+		if stored.Version != state.Version {
+			return "", fmt.Errorf("'%s': %w", state.Name, ErrConflict)
+		}
+
+		version, _ = strconv.Atoi(stored.Version)
+	}
+
+	saved := *state
+	saved.Version = strconv.Itoa(version + 1)
+	ds.environments[state.Name] = &saved
+
+	return saved.Version, nil
+}
+
+func (ds *fakeExternalDataStore) Delete(ctx context.Context, name string) error {
+	delete(ds.environments, name)
+	return nil
+}
+
+func (ds *fakeExternalDataStore) Lock(ctx context.Context, name string, lock *LockInfo) error {
+	return nil
+}
+
+func (ds *fakeExternalDataStore) Unlock(ctx context.Context, name string, lockId string) error {
+	return nil
+}
@@ -104,22 +104,20 @@ func (fs *LocalFileDataStore) Get(ctx context.Context, name string) (*Environmen
 func (fs *LocalFileDataStore) Reload(ctx context.Context, env *Environment) error {
 	// Reload env values
 	if envMap, err := godotenv.Read(fs.EnvPath(env)); errors.Is(err, os.ErrNotExist) {
-		env.dotenv = make(map[string]string)
-		env.deletedKeys = make(map[string]struct{})
+		env.setDotenv(make(map[string]string))
 	} else if err != nil {
 		return fmt.Errorf("loading .env: %w", err)
 	} else {
-		env.dotenv = envMap
-		env.deletedKeys = make(map[string]struct{})
+		env.setDotenv(envMap)
 	}
 
 	// Reload env config
 	if cfg, err := fs.configManager.Load(fs.ConfigPath(env)); errors.Is(err, os.ErrNotExist) {
-		env.Config = config.NewEmptyConfig()
+		env.setConfig(config.NewEmptyConfig())
 	} else if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	} else {
-		env.Config = cfg
+		env.setConfig(cfg)
 	}
 
 	if env.Name() != "" {
@@ -138,28 +136,48 @@ func (fs *LocalFileDataStore) Reload(ctx context.Context, env *Environment) erro
 // Save saves the environment to the persistent data store
 func (fs *LocalFileDataStore) Save(ctx context.Context, env *Environment, options *SaveOptions) error {
 	// Update configuration
-	if err := fs.configManager.Save(env.Config, fs.ConfigPath(env)); err != nil {
+	if err := env.saveConfig(func(cfg config.Config) error {
+		return fs.configManager.Save(cfg, fs.ConfigPath(env))
+	}); err != nil {
 		return fmt.Errorf("saving config: %w", err)
 	}
 
-	// Cache current values & reload to get any new env vars
-	currentValues := env.dotenv
-	deletedValues := env.deletedKeys
-	if err := fs.Reload(ctx, env); err != nil {
-		return fmt.Errorf("failed reloading env vars, %w", err)
+	if err := fs.saveDotenv(env); err != nil {
+		return err
+	}
+
+	tracing.SetUsageAttributes(fields.StringHashed(fields.EnvNameKey, env.Name()))
+	return nil
+}
+
+// saveDotenv reloads the .env file to get any new env vars, overlays the current values, replays the deletions and
+// writes the file. The environment stays locked until the file is written, since services deployed concurrently may set
+// values and save the environment at the same time.
+func (fs *LocalFileDataStore) saveDotenv(env *Environment) error {
+	env.mu.Lock()
+	defer env.mu.Unlock()
+
+	envMap, err := godotenv.Read(fs.EnvPath(env))
+	if errors.Is(err, os.ErrNotExist) {
+		envMap = make(map[string]string)
+	} else if err != nil {
+		return fmt.Errorf("failed reloading env vars, loading .env: %w", err)
 	}
 
 	// Overlay current values before saving
-	for key, value := range currentValues {
-		env.dotenv[key] = value
+	for key, value := range env.dotenv {
+		envMap[key] = value
 	}
 
 	// Replay deletion
-	for key := range deletedValues {
-		delete(env.dotenv, key)
+	for key := range env.deletedKeys {
+		delete(envMap, key)
 	}
 
-	marshalled, err := marshallDotEnv(env)
+	env.dotenv = envMap
+	env.deletedKeys = make(map[string]struct{})
+
+	marshalled, err := marshallDotEnvValues(env.dotenv)
 	if err != nil {
 		return fmt.Errorf("marshalling .env: %w", err)
 	}
@@ -179,7 +197,6 @@ func (fs *LocalFileDataStore) Save(ctx context.Context, env *Environment, option
 		return fmt.Errorf("saving .env: %w", err)
 	}
 
-	tracing.SetUsageAttributes(fields.StringHashed(fields.EnvNameKey, env.Name()))
 	return nil
 }
 
@@ -198,3 +215,17 @@ func (fs *LocalFileDataStore) Delete(ctx context.Context, name string) error {
 
 	return nil
 }
+
+// Lock acquires the lock of the environment by creating a lock file within the environment directory
+func (fs *LocalFileDataStore) Lock(ctx context.Context, name string, lock *LockInfo) error {
+	return acquireLockFile(name, fs.lockPath(name), lock)
+}
+
+// Unlock releases the lock of the environment by removing the lock file within the environment directory
+func (fs *LocalFileDataStore) Unlock(ctx context.Context, name string, lockId string) error {
+	return releaseLockFile(fs.lockPath(name), lockId)
+}
+
+func (fs *LocalFileDataStore) lockPath(name string) string {
+	return filepath.Join(fs.azdContext.EnvironmentRoot(name), LockFileName)
+}
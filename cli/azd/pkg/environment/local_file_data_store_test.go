@@ -5,7 +5,9 @@ package environment
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
@@ -61,6 +63,27 @@ func Test_LocalFileDataStore_SaveAndGet(t *testing.T) {
 		actual := env1.Getenv("key1")
 		require.Equal(t, "value1", actual)
 	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		env := New("env2")
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				env.DotenvSet(fmt.Sprintf("KEY_%d", i), "value")
+				require.NoError(t, dataStore.Save(*mockContext.Context, env, nil))
+			}()
+		}
+		wg.Wait()
+
+		saved, err := dataStore.Get(*mockContext.Context, "env2")
+		require.NoError(t, err)
+		for i := 0; i < 10; i++ {
+			require.Equal(t, "value", saved.Getenv(fmt.Sprintf("KEY_%d", i)))
+		}
+	})
 }
 
 func Test_LocalFileDataStore_Path(t *testing.T) {
@@ -86,3 +109,33 @@ func Test_LocalFileDataStore_ConfigPath(t *testing.T) {
 
 	require.Equal(t, expected, actual)
 }
+
+func Test_LocalFileDataStore_Lock(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+	fileConfigManager := config.NewFileConfigManager(config.NewManager())
+	dataStore := NewLocalFileDataStore(azdContext, fileConfigManager)
+
+	lock := NewLockInfo("provision")
+	err := dataStore.Lock(*mockContext.Context, "env1", lock)
+	require.NoError(t, err)
+
+	err = dataStore.Lock(*mockContext.Context, "env1", NewLockInfo("deploy"))
+	var lockedErr *LockedError
+	require.ErrorAs(t, err, &lockedErr)
+	require.Equal(t, lock.Id, lockedErr.Lock.Id)
+	require.Equal(t, "provision", lockedErr.Lock.Operation)
+
+	// Releasing with the id of another lock keeps the current lock
+	err = dataStore.Unlock(*mockContext.Context, "env1", "other")
+	require.NoError(t, err)
+	require.ErrorIs(t, dataStore.Lock(*mockContext.Context, "env1", NewLockInfo("deploy")), ErrLocked)
+
+	err = dataStore.Unlock(*mockContext.Context, "env1", lock.Id)
+	require.NoError(t, err)
+	require.NoError(t, dataStore.Lock(*mockContext.Context, "env1", NewLockInfo("deploy")))
+
+	// Forced release
+	require.NoError(t, dataStore.Unlock(*mockContext.Context, "env1", ""))
+	require.NoError(t, dataStore.Unlock(*mockContext.Context, "env1", ""))
+}
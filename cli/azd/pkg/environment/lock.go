@@ -0,0 +1,244 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package environment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/user"
+	"path/filepath"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/google/uuid"
+)
+
+// LockFileName is the name of the file that holds the lock of an environment within a data store
+const LockFileName = ".lock"
+
+// LockIdEnvVarName is the name of the environment variable that contains the id of the environment lock held by the
+// current azd process. Nested azd processes, ex) azd commands invoked from hooks, run under the lock of the parent.
+const LockIdEnvVarName = "AZD_ENV_LOCK_ID"
+
+// LockTimeoutEnvVarName is the name of the environment variable that overrides how long an environment lock is held
+// before it expires, ex) 30m. Expired locks, ex) held by a crashed operation, are released by the next operation.
+const LockTimeoutEnvVarName = "AZD_ENV_LOCK_TIMEOUT"
+
+// DefaultLockTimeout is the duration an environment lock is held before it expires
+const DefaultLockTimeout = 2 * time.Hour
+
+var (
+	// Error returned when an environment is locked by another operation
+	ErrLocked = errors.New("environment is locked")
+
+	// Error returned when a remote environment has been modified since it was last read or written
+	ErrConflict = errors.New("environment has been modified by another operation")
+)
+
+// LockInfo describes the holder of an environment lock
+type LockInfo struct {
+	// The unique id of the lock
+	Id string `json:"id"`
+	// The user and host that acquired the lock, ex) alice@workstation
+	Owner string `json:"owner"`
+	// The operation that acquired the lock, ex) azd provision
+	Operation string `json:"operation"`
+	// The time the lock was acquired
+	AcquiredAt time.Time `json:"acquiredAt"`
+	// The time the lock expires, locks without an expiration time never expire
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// NewLockInfo creates a new lock for the specified operation owned by the current user
+func NewLockInfo(operation string) *LockInfo {
+	acquiredAt := time.Now().UTC()
+
+	return &LockInfo{
+		Id:         uuid.NewString(),
+		Owner:      lockOwner(),
+		Operation:  operation,
+		AcquiredAt: acquiredAt,
+		ExpiresAt:  acquiredAt.Add(lockTimeout()),
+	}
+}
+
+// Expired returns true when the lock has expired and can be released by another operation
+func (l *LockInfo) Expired() bool {
+	return !l.ExpiresAt.IsZero() && time.Now().After(l.ExpiresAt)
+}
+
+// lockTimeout returns the duration a lock is held before it expires
+func lockTimeout() time.Duration {
+	if value := os.Getenv(LockTimeoutEnvVarName); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err == nil && timeout > 0 {
+			return timeout
+		}
+
+		log.Printf("ignoring invalid %s '%s', expected a positive duration, ex) 30m", LockTimeoutEnvVarName, value)
+	}
+
+	return DefaultLockTimeout
+}
+
+// LockedError is returned when acquiring the lock of an environment that is already locked
+type LockedError struct {
+	EnvName string
+	// The current holder of the lock, nil when unknown
+	Lock *LockInfo
+}
+
+func (e *LockedError) Error() string {
+	if e.Lock == nil {
+		return fmt.Sprintf("environment '%s' is locked by another operation", e.EnvName)
+	}
+
+	msg := fmt.Sprintf(
+		"environment '%s' is locked by %s since %s",
+		e.EnvName,
+		e.Lock.Owner,
+		e.Lock.AcquiredAt.Local().Format(time.RFC1123),
+	)
+
+	if e.Lock.Operation != "" {
+		msg += fmt.Sprintf(" (%s)", e.Lock.Operation)
+	}
+
+	return msg
+}
+
+func (e *LockedError) Unwrap() error {
+	return ErrLocked
+}
+
+// AcquireLock acquires the lock of the environment for the operation and shares it with nested azd processes through
+// LockIdEnvVarName. The lock isn't acquired again when the current azd process, or its parent, already holds it.
+// The returned func releases the acquired lock, even when the operation has been canceled.
+func AcquireLock(ctx context.Context, manager Manager, name string, operation string) (func(), error) {
+	lock, err := manager.Lock(ctx, name, operation)
+	if err != nil {
+		var lockedErr *LockedError
+		if heldLockId := os.Getenv(LockIdEnvVarName); heldLockId != "" &&
+			errors.As(err, &lockedErr) && lockedErr.Lock != nil && lockedErr.Lock.Id == heldLockId {
+			return func() {}, nil
+		}
+
+		if errors.Is(err, ErrLocked) {
+			return nil, &internal.ErrorWithSuggestion{
+				Err: err,
+				Suggestion: fmt.Sprintf(
+					"If the operation holding the lock is no longer running, run 'azd env unlock -e %s' to release it.",
+					name,
+				),
+			}
+		}
+
+		return nil, fmt.Errorf("failed locking environment, %w", err)
+	}
+
+	if err := os.Setenv(LockIdEnvVarName, lock.Id); err != nil {
+		log.Printf("failed setting %s: %v", LockIdEnvVarName, err)
+	}
+
+	return func() {
+		os.Unsetenv(LockIdEnvVarName)
+
+		if err := manager.Unlock(context.WithoutCancel(ctx), name, lock); err != nil {
+			log.Printf("failed releasing lock of environment '%s': %v", name, err)
+		}
+	}, nil
+}
+
+// lockOwner returns the user and host name of the current user, ex) alice@workstation
+func lockOwner() string {
+	owner := "unknown"
+	if currentUser, err := user.Current(); err == nil {
+		owner = currentUser.Username
+	}
+
+	if hostName, err := os.Hostname(); err == nil {
+		owner = fmt.Sprintf("%s@%s", owner, hostName)
+	}
+
+	return owner
+}
+
+// parseLock parses the content of a lock file
+func parseLock(reader io.Reader) (*LockInfo, error) {
+	var lock *LockInfo
+	if err := json.NewDecoder(reader).Decode(&lock); err != nil {
+		return nil, fmt.Errorf("parsing environment lock: %w", err)
+	}
+
+	return lock, nil
+}
+
+// acquireLockFile atomically creates the lock file at the specified path.
+// Returns a *LockedError when the lock file already exists.
+func acquireLockFile(envName string, lockPath string, lock *LockInfo) error {
+	content, err := json.Marshal(lock)
+	if err != nil {
+		return fmt.Errorf("marshalling environment lock: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(lockPath), osutil.PermissionDirectory); err != nil {
+		return fmt.Errorf("creating environment directory: %w", err)
+	}
+
+	file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, osutil.PermissionFile)
+	if errors.Is(err, os.ErrExist) {
+		// The holder is unknown when the lock file is being written or has been removed in the meantime
+		holder, _ := readLockFile(lockPath)
+		return &LockedError{EnvName: envName, Lock: holder}
+	} else if err != nil {
+		return fmt.Errorf("creating environment lock: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(content); err != nil {
+		return fmt.Errorf("writing environment lock: %w", err)
+	}
+
+	return nil
+}
+
+// releaseLockFile removes the lock file at the specified path when it is held by the lock with the specified id.
+// When the id is empty the lock file is removed regardless of its holder.
+func releaseLockFile(lockPath string, lockId string) error {
+	if lockId != "" {
+		holder, err := readLockFile(lockPath)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		// The lock has been released and acquired by another operation in the meantime
+		if holder.Id != lockId {
+			return nil
+		}
+	}
+
+	if err := os.Remove(lockPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing environment lock: %w", err)
+	}
+
+	return nil
+}
+
+func readLockFile(lockPath string) (*LockInfo, error) {
+	file, err := os.Open(lockPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return parseLock(file)
+}
@@ -7,8 +7,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
@@ -41,6 +43,12 @@ type Spec struct {
 	Examples []string
 }
 
+// DeleteOptions provide additional options for the delete operation
+type DeleteOptions struct {
+	// Whether the environment is also deleted from the remote data store
+	Remote bool
+}
+
 const DotEnvFileName = ".env"
 const ConfigFileName = "config.json"
 
@@ -56,6 +64,9 @@ var (
 
 	// Error returned when the default environment cannot be found
 	ErrDefaultEnvironmentNotFound = errors.New("default environment not found")
+
+	// Error returned when an operation requires remote state that is not configured for the project
+	ErrRemoteNotConfigured = errors.New("remote state is not configured for the project")
 )
 
 // Manager is the interface used for managing instances of environments
@@ -79,6 +90,20 @@ type Manager interface {
 	// Delete deletes the environment from local storage.
 	Delete(ctx context.Context, name string) error
 
+	// DeleteWithOptions deletes the environment from local storage and, when specified, from remote storage.
+	DeleteWithOptions(ctx context.Context, name string, options *DeleteOptions) error
+
+	// HasRemote returns true when remote state is configured for the project.
+	HasRemote() bool
+
+	// Lock acquires the lock of the environment for the duration of an operation that modifies the environment.
+	// The lock is held within the remote data store when configured, otherwise within the local data store.
+	// Returns a *LockedError when the environment is already locked.
+	Lock(ctx context.Context, name string, operation string) (*LockInfo, error)
+
+	// Unlock releases the lock of the environment. When lock is nil the lock is released regardless of its holder.
+	Unlock(ctx context.Context, name string, lock *LockInfo) error
+
 	EnvPath(env *Environment) string
 	ConfigPath(env *Environment) string
 }
@@ -105,12 +130,20 @@ func NewManager(
 	// container doesn't support optional interface based dependencies.
 	if remoteConfig != nil {
 		err := serviceLocator.ResolveNamed(remoteConfig.Backend, &remote)
+		if errors.Is(err, ioc.ErrResolveInstance) && isExternalRemoteKind(RemoteKind(remoteConfig.Backend)) {
+			// Backends declared by extensions are registered once the extension has been started
+			var registry *ExternalDataStoreRegistry
+			if err = serviceLocator.Resolve(&registry); err == nil {
+				remote = newExternalRemoteDataStore(RemoteKind(remoteConfig.Backend), remoteConfig.Config, registry)
+			}
+		}
+
 		if err != nil {
 			if errors.Is(err, ioc.ErrResolveInstance) {
 				return nil, fmt.Errorf(
 					"remote state configuration is invalid. The specified backend '%s' is not valid. Valid values are '%s'.",
 					remoteConfig.Backend,
-					ux.ListAsText(ValidRemoteKinds),
+					ux.ListAsText(ValidRemoteKinds()),
 				)
 			}
 
@@ -396,6 +429,9 @@ func (m *manager) SaveWithOptions(ctx context.Context, env *Environment, options
 		options = &SaveOptions{}
 	}
 
+	env.saveMu.Lock()
+	defer env.saveMu.Unlock()
+
 	if err := m.local.Save(ctx, env, options); err != nil {
 		return fmt.Errorf("saving local environment, %w", err)
 	}
@@ -417,13 +453,37 @@ func (m *manager) Reload(ctx context.Context, env *Environment) error {
 }
 
 func (m *manager) Delete(ctx context.Context, name string) error {
+	return m.DeleteWithOptions(ctx, name, nil)
+}
+
+// DeleteWithOptions deletes the environment from local storage and, when specified, from remote storage
+func (m *manager) DeleteWithOptions(ctx context.Context, name string, options *DeleteOptions) error {
 	if name == "" {
 		return ErrNameNotSpecified
 	}
 
-	err := m.local.Delete(ctx, name)
-	if err != nil {
-		return err
+	if options == nil {
+		options = &DeleteOptions{}
+	}
+
+	if options.Remote && m.remote == nil {
+		return ErrRemoteNotConfigured
+	}
+
+	// When deleting remotely, the environment may not exist locally
+	localErr := m.local.Delete(ctx, name)
+	if localErr != nil && (!options.Remote || !errors.Is(localErr, ErrNotFound)) {
+		return localErr
+	}
+
+	if options.Remote {
+		err := m.remote.Delete(ctx, name)
+		switch {
+		case errors.Is(err, ErrNotFound) && localErr == nil:
+			// The environment only existed locally
+		case err != nil:
+			return fmt.Errorf("deleting remote environment: %w", err)
+		}
 	}
 
 	defaultEnvName, err := m.azdContext.GetDefaultEnvironmentName()
@@ -441,6 +501,65 @@ func (m *manager) Delete(ctx context.Context, name string) error {
 	return nil
 }
 
+// HasRemote returns true when remote state is configured for the project
+func (m *manager) HasRemote() bool {
+	return m.remote != nil
+}
+
+// Lock acquires the lock of the environment for the specified operation
+func (m *manager) Lock(ctx context.Context, name string, operation string) (*LockInfo, error) {
+	if name == "" {
+		return nil, ErrNameNotSpecified
+	}
+
+	lock := NewLockInfo(operation)
+	err := m.lockStore().Lock(ctx, name, lock)
+
+	// Locks held by operations that didn't release them, ex) a crashed CI run, are released once expired
+	var lockedErr *LockedError
+	if errors.As(err, &lockedErr) && lockedErr.Lock != nil && lockedErr.Lock.Expired() {
+		log.Printf(
+			"releasing expired lock of environment '%s' held by %s since %s",
+			name, lockedErr.Lock.Owner, lockedErr.Lock.AcquiredAt.Format(time.RFC3339))
+
+		if err := m.lockStore().Unlock(ctx, name, lockedErr.Lock.Id); err != nil {
+			return nil, fmt.Errorf("releasing expired environment lock: %w", err)
+		}
+
+		err = m.lockStore().Lock(ctx, name, lock)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return lock, nil
+}
+
+// Unlock releases the lock of the environment
+func (m *manager) Unlock(ctx context.Context, name string, lock *LockInfo) error {
+	if name == "" {
+		return ErrNameNotSpecified
+	}
+
+	lockId := ""
+	if lock != nil {
+		lockId = lock.Id
+	}
+
+	return m.lockStore().Unlock(ctx, name, lockId)
+}
+
+// lockStore returns the data store that holds environment locks. Remote environments are shared between users and
+// are locked remotely.
+func (m *manager) lockStore() DataStore {
+	if m.remote != nil {
+		return m.remote
+	}
+
+	return m.local
+}
+
 // ensureValidEnvironmentName ensures the environment name is valid, if it is not, an error is printed
 // and the user is prompted for a new name.
 func (m *manager) ensureValidEnvironmentName(ctx context.Context, spec *Spec) error {
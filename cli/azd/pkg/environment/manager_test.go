@@ -7,8 +7,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/azure/azure-dev/cli/azd/pkg/auth"
@@ -316,6 +318,67 @@ func Test_EnvManager_Save(t *testing.T) {
 	})
 }
 
+func Test_EnvManager_DeleteWithOptions(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+
+	t.Run("LocalAndRemote", func(t *testing.T) {
+		localDataStore := &MockDataStore{}
+		remoteDataStore := &MockDataStore{}
+		localDataStore.On("Delete", *mockContext.Context, "env1").Return(nil)
+		remoteDataStore.On("Delete", *mockContext.Context, "env1").Return(nil)
+
+		manager := newManagerForTest(azdContext, mockContext.Console, localDataStore, remoteDataStore)
+		err := manager.DeleteWithOptions(*mockContext.Context, "env1", &DeleteOptions{Remote: true})
+		require.NoError(t, err)
+
+		remoteDataStore.AssertCalled(t, "Delete", *mockContext.Context, "env1")
+	})
+
+	t.Run("LocalOnly", func(t *testing.T) {
+		localDataStore := &MockDataStore{}
+		remoteDataStore := &MockDataStore{}
+		localDataStore.On("Delete", *mockContext.Context, "env1").Return(nil)
+
+		manager := newManagerForTest(azdContext, mockContext.Console, localDataStore, remoteDataStore)
+		err := manager.DeleteWithOptions(*mockContext.Context, "env1", nil)
+		require.NoError(t, err)
+
+		remoteDataStore.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("RemoteOnly", func(t *testing.T) {
+		localDataStore := &MockDataStore{}
+		remoteDataStore := &MockDataStore{}
+		localDataStore.On("Delete", *mockContext.Context, "env1").Return(ErrNotFound)
+		remoteDataStore.On("Delete", *mockContext.Context, "env1").Return(nil)
+
+		manager := newManagerForTest(azdContext, mockContext.Console, localDataStore, remoteDataStore)
+		err := manager.DeleteWithOptions(*mockContext.Context, "env1", &DeleteOptions{Remote: true})
+		require.NoError(t, err)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		localDataStore := &MockDataStore{}
+		remoteDataStore := &MockDataStore{}
+		localDataStore.On("Delete", *mockContext.Context, "env1").Return(ErrNotFound)
+		remoteDataStore.On("Delete", *mockContext.Context, "env1").Return(ErrNotFound)
+
+		manager := newManagerForTest(azdContext, mockContext.Console, localDataStore, remoteDataStore)
+		err := manager.DeleteWithOptions(*mockContext.Context, "env1", &DeleteOptions{Remote: true})
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("RemoteNotConfigured", func(t *testing.T) {
+		localDataStore := &MockDataStore{}
+
+		manager := newManagerForTest(azdContext, mockContext.Console, localDataStore, nil)
+		err := manager.DeleteWithOptions(*mockContext.Context, "env1", &DeleteOptions{Remote: true})
+		require.ErrorContains(t, err, "remote state is not configured")
+		localDataStore.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
+
 func Test_EnvManager_CreateFromContainer(t *testing.T) {
 	t.Run("WithRemoteConfig", func(t *testing.T) {
 		mockContext := mocks.NewMockContext(context.Background())
@@ -356,6 +419,61 @@ func Test_EnvManager_CreateFromContainer(t *testing.T) {
 	})
 }
 
+func Test_EnvManager_Lock(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	azdCtx := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+	localDataStore := NewLocalFileDataStore(azdCtx, config.NewFileConfigManager(config.NewManager()))
+	envManager := newManagerForTest(azdCtx, mockContext.Console, localDataStore, nil)
+
+	t.Run("Locked", func(t *testing.T) {
+		lock, err := envManager.Lock(*mockContext.Context, "env1", "provision")
+		require.NoError(t, err)
+
+		_, err = envManager.Lock(*mockContext.Context, "env1", "deploy")
+		require.ErrorIs(t, err, ErrLocked)
+
+		require.NoError(t, envManager.Unlock(*mockContext.Context, "env1", lock))
+	})
+
+	t.Run("ReleasesExpiredLock", func(t *testing.T) {
+		expiredLock := NewLockInfo("provision")
+		expiredLock.ExpiresAt = time.Now().Add(-time.Minute)
+		require.True(t, expiredLock.Expired())
+		require.NoError(t, localDataStore.Lock(*mockContext.Context, "env2", expiredLock))
+
+		lock, err := envManager.Lock(*mockContext.Context, "env2", "deploy")
+		require.NoError(t, err)
+		require.NotEqual(t, expiredLock.Id, lock.Id)
+		require.False(t, lock.Expired())
+
+		_, err = envManager.Lock(*mockContext.Context, "env2", "deploy")
+		require.ErrorIs(t, err, ErrLocked)
+	})
+
+	t.Run("AcquireLockHeldByCurrentProcess", func(t *testing.T) {
+		t.Setenv(LockIdEnvVarName, "")
+
+		release, err := AcquireLock(*mockContext.Context, envManager, "env3", "azd provision")
+		require.NoError(t, err)
+		require.NotEmpty(t, os.Getenv(LockIdEnvVarName))
+
+		// the operations of the current azd command run under the lock it holds
+		releaseNested, err := AcquireLock(*mockContext.Context, envManager, "env3", "azd provision")
+		require.NoError(t, err)
+		releaseNested()
+
+		_, err = envManager.Lock(*mockContext.Context, "env3", "deploy")
+		require.ErrorIs(t, err, ErrLocked)
+
+		release()
+		require.Empty(t, os.Getenv(LockIdEnvVarName))
+
+		lock, err := envManager.Lock(*mockContext.Context, "env3", "deploy")
+		require.NoError(t, err)
+		require.NoError(t, envManager.Unlock(*mockContext.Context, "env3", lock))
+	})
+}
+
 func registerContainerComponents(t *testing.T, mockContext *mocks.MockContext) {
 	mockContext.Container.MustRegisterSingleton(func() context.Context {
 		return *mockContext.Context
@@ -440,3 +558,13 @@ func (m *MockDataStore) Delete(ctx context.Context, name string) error {
 	args := m.Called(ctx, name)
 	return args.Error(0)
 }
+
+func (m *MockDataStore) Lock(ctx context.Context, name string, lock *LockInfo) error {
+	args := m.Called(ctx, name, lock)
+	return args.Error(0)
+}
+
+func (m *MockDataStore) Unlock(ctx context.Context, name string, lockId string) error {
+	args := m.Called(ctx, name, lockId)
+	return args.Error(0)
+}
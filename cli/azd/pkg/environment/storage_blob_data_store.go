@@ -6,8 +6,10 @@ package environment
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"slices"
@@ -65,6 +67,11 @@ func (sbd *StorageBlobDataStore) List(ctx context.Context) ([]*contracts.EnvList
 	envMap := map[string]*contracts.EnvListEnvironment{}
 
 	for _, blob := range blobs {
+		// Lock blobs are not part of the environment state
+		if blob.Name == LockFileName {
+			continue
+		}
+
 		envName := filepath.Base(filepath.Dir(blob.Path))
 		env, has := envMap[envName]
 		if !has {
@@ -124,12 +131,14 @@ func (sbd *StorageBlobDataStore) Save(ctx context.Context, env *Environment, opt
 	// Update configuration
 	cfgWriter := new(bytes.Buffer)
 
-	if err := sbd.configManager.Save(env.Config, cfgWriter); err != nil {
+	if err := env.saveConfig(func(cfg config.Config) error {
+		return sbd.configManager.Save(cfg, cfgWriter)
+	}); err != nil {
 		return fmt.Errorf("saving config: %w", err)
 	}
 
-	if err := sbd.blobClient.Upload(ctx, sbd.ConfigPath(env), cfgWriter); err != nil {
-		return fmt.Errorf("uploading config: %w", describeError(err))
+	if err := sbd.upload(ctx, env, sbd.ConfigPath(env), cfgWriter, options); err != nil {
+		return fmt.Errorf("uploading config: %w", err)
 	}
 
 	marshalled, err := marshallDotEnv(env)
@@ -139,8 +148,8 @@ func (sbd *StorageBlobDataStore) Save(ctx context.Context, env *Environment, opt
 
 	buffer := bytes.NewBuffer([]byte(marshalled))
 
-	if err := sbd.blobClient.Upload(ctx, sbd.EnvPath(env), buffer); err != nil {
-		return fmt.Errorf("uploading .env: %w", describeError(err))
+	if err := sbd.upload(ctx, env, sbd.EnvPath(env), buffer, options); err != nil {
+		return fmt.Errorf("uploading .env: %w", err)
 	}
 
 	tracing.SetUsageAttributes(fields.StringHashed(fields.EnvNameKey, env.Name()))
@@ -155,14 +164,13 @@ func (sbd *StorageBlobDataStore) Reload(ctx context.Context, env *Environment) e
 	}
 
 	defer dotEnvBuffer.Close()
+	env.setRemoteETag(sbd.EnvPath(env), dotEnvBuffer.ETag)
 
 	envMap, err := godotenv.Parse(dotEnvBuffer)
 	if err != nil {
-		env.dotenv = make(map[string]string)
-		env.deletedKeys = make(map[string]struct{})
+		env.setDotenv(make(map[string]string))
 	} else {
-		env.dotenv = envMap
-		env.deletedKeys = make(map[string]struct{})
+		env.setDotenv(envMap)
 	}
 
 	// Reload config file
@@ -172,13 +180,14 @@ func (sbd *StorageBlobDataStore) Reload(ctx context.Context, env *Environment) e
 	}
 
 	defer configBuffer.Close()
+	env.setRemoteETag(sbd.ConfigPath(env), configBuffer.ETag)
 
 	if cfg, err := sbd.configManager.Load(configBuffer); errors.Is(err, os.ErrNotExist) {
-		env.Config = config.NewEmptyConfig()
+		env.setConfig(config.NewEmptyConfig())
 	} else if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	} else {
-		env.Config = cfg
+		env.setConfig(cfg)
 	}
 
 	if env.Name() != "" {
@@ -210,14 +219,14 @@ func (sbd *StorageBlobDataStore) Delete(ctx context.Context, name string) error
 
 	env := envs[matchingIndex]
 	if env.ConfigPath != "" {
-		err := sbd.blobClient.Delete(ctx, env.ConfigPath)
+		err := sbd.blobClient.Delete(ctx, env.ConfigPath, nil)
 		if err != nil {
 			return fmt.Errorf("deleting remote config: %w", describeError(err))
 		}
 	}
 
 	if env.DotEnvPath != "" {
-		err := sbd.blobClient.Delete(ctx, env.DotEnvPath)
+		err := sbd.blobClient.Delete(ctx, env.DotEnvPath, nil)
 		if err != nil {
 			return fmt.Errorf("deleting remote .env: %w", describeError(err))
 		}
@@ -226,6 +235,110 @@ func (sbd *StorageBlobDataStore) Delete(ctx context.Context, name string) error
 	return nil
 }
 
+// Lock acquires the lock of the environment by creating a lock blob within the environment directory.
+// The lock blob is only created when it does not already exist.
+func (sbd *StorageBlobDataStore) Lock(ctx context.Context, name string, lock *LockInfo) error {
+	content, err := json.Marshal(lock)
+	if err != nil {
+		return fmt.Errorf("marshalling environment lock: %w", err)
+	}
+
+	_, err = sbd.blobClient.Upload(ctx, sbd.lockPath(name), bytes.NewReader(content), &storage.UploadOptions{
+		IfNoneMatch: true,
+	})
+	if errors.Is(err, storage.ErrConditionNotMet) {
+		holder, _, err := sbd.lockHolder(ctx, name)
+		if err != nil && !errors.Is(err, storage.ErrBlobNotFound) {
+			return err
+		}
+
+		return &LockedError{EnvName: name, Lock: holder}
+	} else if err != nil {
+		return fmt.Errorf("uploading environment lock: %w", describeError(err))
+	}
+
+	return nil
+}
+
+// Unlock releases the lock of the environment by deleting the lock blob within the environment directory.
+// The lock blob is only deleted when it has not been replaced since it was read.
+func (sbd *StorageBlobDataStore) Unlock(ctx context.Context, name string, lockId string) error {
+	var deleteOptions *storage.DeleteOptions
+	if lockId != "" {
+		holder, etag, err := sbd.lockHolder(ctx, name)
+		if errors.Is(err, storage.ErrBlobNotFound) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		// The lock has been released and acquired by another operation in the meantime
+		if holder.Id != lockId {
+			return nil
+		}
+
+		deleteOptions = &storage.DeleteOptions{IfMatch: etag}
+	}
+
+	err := sbd.blobClient.Delete(ctx, sbd.lockPath(name), deleteOptions)
+	if errors.Is(err, storage.ErrConditionNotMet) {
+		// The lock has been released and acquired by another operation since it was read
+		return nil
+	} else if err != nil && !errors.Is(err, storage.ErrBlobNotFound) {
+		return fmt.Errorf("deleting environment lock: %w", describeError(err))
+	}
+
+	return nil
+}
+
+func (sbd *StorageBlobDataStore) lockPath(name string) string {
+	return fmt.Sprintf("%s/%s", name, LockFileName)
+}
+
+// lockHolder returns the current holder of the environment lock and the ETag of the lock blob
+func (sbd *StorageBlobDataStore) lockHolder(ctx context.Context, name string) (*LockInfo, string, error) {
+	reader, err := sbd.blobClient.Download(ctx, sbd.lockPath(name))
+	if err != nil {
+		return nil, "", describeError(err)
+	}
+	defer reader.Close()
+
+	lock, err := parseLock(reader)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return lock, reader.ETag, nil
+}
+
+// upload uploads the blob of the environment. When the environment has previously been read or written, the blob is
+// only uploaded when it has not been modified by another operation in the meantime.
+func (sbd *StorageBlobDataStore) upload(
+	ctx context.Context,
+	env *Environment,
+	blobPath string,
+	reader io.Reader,
+	options *SaveOptions,
+) error {
+	uploadOptions := &storage.UploadOptions{
+		IfMatch: env.remoteETag(blobPath),
+	}
+
+	if uploadOptions.IfMatch == "" && options != nil && options.IsNew {
+		uploadOptions.IfNoneMatch = true
+	}
+
+	etag, err := sbd.blobClient.Upload(ctx, blobPath, reader, uploadOptions)
+	if errors.Is(err, storage.ErrConditionNotMet) {
+		return fmt.Errorf("'%s': %w", env.name, ErrConflict)
+	} else if err != nil {
+		return describeError(err)
+	}
+
+	env.setRemoteETag(blobPath, etag)
+	return nil
+}
+
 func describeError(err error) error {
 	var responseErr *azcore.ResponseError
 
@@ -6,6 +6,7 @@ package environment
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"testing"
@@ -72,12 +73,14 @@ func Test_StorageBlobDataStore_SaveAndGet(t *testing.T) {
 	dataStore := NewStorageBlobDataStore(configManager, blobClient)
 
 	t.Run("Success", func(t *testing.T) {
-		envReader := io.NopCloser(bytes.NewReader([]byte("key1=value1")))
-		configReader := io.NopCloser(bytes.NewReader([]byte("{}")))
+		envReader := &storage.BlobReader{ReadCloser: io.NopCloser(bytes.NewReader([]byte("key1=value1")))}
+		configReader := &storage.BlobReader{ReadCloser: io.NopCloser(bytes.NewReader([]byte("{}")))}
 		blobClient.On("Items", *mockContext.Context).Return(validBlobItems, nil)
 		blobClient.On("Download", *mockContext.Context, "env1/.env").Return(envReader, nil)
 		blobClient.On("Download", *mockContext.Context, "env1/config.json").Return(configReader, nil)
-		blobClient.On("Upload", *mockContext.Context, mock.AnythingOfType("string"), mock.Anything).Return(nil)
+		blobClient.
+			On("Upload", *mockContext.Context, mock.AnythingOfType("string"), mock.Anything, mock.Anything).
+			Return("", nil)
 
 		env1 := New("env1")
 		env1.DotenvSet("key1", "value1")
@@ -93,6 +96,125 @@ func Test_StorageBlobDataStore_SaveAndGet(t *testing.T) {
 	})
 }
 
+func Test_StorageBlobDataStore_SaveConflict(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	configManager := config.NewManager()
+	blobClient := &MockBlobClient{}
+	dataStore := NewStorageBlobDataStore(configManager, blobClient)
+
+	envReader := &storage.BlobReader{ReadCloser: io.NopCloser(bytes.NewReader([]byte("key1=value1"))), ETag: "env-v1"}
+	configReader := &storage.BlobReader{ReadCloser: io.NopCloser(bytes.NewReader([]byte("{}"))), ETag: "config-v1"}
+	blobClient.On("Items", *mockContext.Context).Return(validBlobItems, nil)
+	blobClient.On("Download", *mockContext.Context, "env1/.env").Return(envReader, nil)
+	blobClient.On("Download", *mockContext.Context, "env1/config.json").Return(configReader, nil)
+
+	blobClient.
+		On("Upload", *mockContext.Context, "env1/config.json", mock.Anything, &storage.UploadOptions{IfMatch: "config-v1"}).
+		Return("config-v2", nil)
+	blobClient.
+		On("Upload", *mockContext.Context, "env1/.env", mock.Anything, &storage.UploadOptions{IfMatch: "env-v1"}).
+		Return("", storage.ErrConditionNotMet)
+
+	env, err := dataStore.Get(*mockContext.Context, "env1")
+	require.NoError(t, err)
+
+	err = dataStore.Save(*mockContext.Context, env, nil)
+	require.ErrorIs(t, err, ErrConflict)
+	require.Equal(t, "config-v2", env.remoteETag("env1/config.json"))
+}
+
+func Test_StorageBlobDataStore_Lock(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	configManager := config.NewManager()
+
+	t.Run("Acquire", func(t *testing.T) {
+		blobClient := &MockBlobClient{}
+		blobClient.
+			On("Upload", *mockContext.Context, "env1/.lock", mock.Anything, &storage.UploadOptions{IfNoneMatch: true}).
+			Return("lock-v1", nil)
+		dataStore := NewStorageBlobDataStore(configManager, blobClient)
+
+		err := dataStore.Lock(*mockContext.Context, "env1", NewLockInfo("provision"))
+		require.NoError(t, err)
+	})
+
+	t.Run("Locked", func(t *testing.T) {
+		holder := &LockInfo{Id: "other", Owner: "alice@workstation", Operation: "deploy"}
+		holderJson, err := json.Marshal(holder)
+		require.NoError(t, err)
+
+		blobClient := &MockBlobClient{}
+		blobClient.
+			On("Upload", *mockContext.Context, "env1/.lock", mock.Anything, &storage.UploadOptions{IfNoneMatch: true}).
+			Return("", storage.ErrConditionNotMet)
+		for range 2 {
+			blobClient.
+				On("Download", *mockContext.Context, "env1/.lock").
+				Return(&storage.BlobReader{ReadCloser: io.NopCloser(bytes.NewReader(holderJson))}, nil).
+				Once()
+		}
+		dataStore := NewStorageBlobDataStore(configManager, blobClient)
+
+		err = dataStore.Lock(*mockContext.Context, "env1", NewLockInfo("provision"))
+		require.ErrorIs(t, err, ErrLocked)
+
+		var lockedErr *LockedError
+		require.ErrorAs(t, err, &lockedErr)
+		require.Equal(t, "alice@workstation", lockedErr.Lock.Owner)
+		require.Contains(t, err.Error(), "locked by alice@workstation")
+
+		// Releasing a lock held by another operation is a no-op unless forced
+		err = dataStore.Unlock(*mockContext.Context, "env1", "mine")
+		require.NoError(t, err)
+		blobClient.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+
+		blobClient.On("Delete", *mockContext.Context, "env1/.lock", (*storage.DeleteOptions)(nil)).Return(nil)
+		err = dataStore.Unlock(*mockContext.Context, "env1", "")
+		require.NoError(t, err)
+		blobClient.AssertCalled(t, "Delete", *mockContext.Context, "env1/.lock", (*storage.DeleteOptions)(nil))
+	})
+}
+
+func Test_StorageBlobDataStore_Unlock(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	configManager := config.NewManager()
+
+	holder := &LockInfo{Id: "mine", Owner: "alice@workstation", Operation: "deploy"}
+	holderJson, err := json.Marshal(holder)
+	require.NoError(t, err)
+
+	t.Run("Release", func(t *testing.T) {
+		blobClient := &MockBlobClient{}
+		blobClient.
+			On("Download", *mockContext.Context, "env1/.lock").
+			Return(&storage.BlobReader{ReadCloser: io.NopCloser(bytes.NewReader(holderJson)), ETag: "lock-v1"}, nil)
+		blobClient.
+			On("Delete", *mockContext.Context, "env1/.lock", &storage.DeleteOptions{IfMatch: "lock-v1"}).
+			Return(nil)
+		dataStore := NewStorageBlobDataStore(configManager, blobClient)
+
+		err := dataStore.Unlock(*mockContext.Context, "env1", "mine")
+		require.NoError(t, err)
+		blobClient.AssertExpectations(t)
+	})
+
+	t.Run("AcquiredInTheMeantime", func(t *testing.T) {
+		// The lock is released and acquired by another operation between reading and deleting the lock blob
+		blobClient := &MockBlobClient{}
+		blobClient.
+			On("Download", *mockContext.Context, "env1/.lock").
+			Return(&storage.BlobReader{ReadCloser: io.NopCloser(bytes.NewReader(holderJson)), ETag: "lock-v1"}, nil)
+		blobClient.
+			On("Delete", *mockContext.Context, "env1/.lock", &storage.DeleteOptions{IfMatch: "lock-v1"}).
+			Return(storage.ErrConditionNotMet)
+		dataStore := NewStorageBlobDataStore(configManager, blobClient)
+
+		err := dataStore.Unlock(*mockContext.Context, "env1", "mine")
+		require.NoError(t, err)
+		blobClient.AssertExpectations(t)
+	})
+}
+
 func Test_StorageBlobDataStore_Path(t *testing.T) {
 	configManager := config.NewManager()
 	blobClient := &MockBlobClient{}
@@ -121,18 +243,29 @@ type MockBlobClient struct {
 	mock.Mock
 }
 
-func (m *MockBlobClient) Download(ctx context.Context, blobPath string) (io.ReadCloser, error) {
+func (m *MockBlobClient) Download(ctx context.Context, blobPath string) (*storage.BlobReader, error) {
 	args := m.Called(ctx, blobPath)
-	return args.Get(0).(io.ReadCloser), args.Error(1)
+
+	value, ok := args.Get(0).(*storage.BlobReader)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return value, args.Error(1)
 }
 
-func (m *MockBlobClient) Upload(ctx context.Context, blobPath string, reader io.Reader) error {
-	args := m.Called(ctx, blobPath, reader)
-	return args.Error(0)
+func (m *MockBlobClient) Upload(
+	ctx context.Context,
+	blobPath string,
+	reader io.Reader,
+	options *storage.UploadOptions,
+) (string, error) {
+	args := m.Called(ctx, blobPath, reader, options)
+	return args.String(0), args.Error(1)
 }
 
-func (m *MockBlobClient) Delete(ctx context.Context, blobPath string) error {
-	args := m.Called(ctx, blobPath)
+func (m *MockBlobClient) Delete(ctx context.Context, blobPath string, options *storage.DeleteOptions) error {
+	args := m.Called(ctx, blobPath, options)
 	return args.Error(0)
 }
 
@@ -0,0 +1,318 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package environment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/internal/tracing"
+	"github.com/azure/azure-dev/cli/azd/internal/tracing/fields"
+	"github.com/azure/azure-dev/cli/azd/pkg/azsdk/storage"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/joho/godotenv"
+)
+
+// StorageFileShareDataStore is a RemoteDataStore implementation that stores environments within an existing
+// Azure Files share. Each environment is stored within a directory named after the environment.
+type StorageFileShareDataStore struct {
+	configManager    config.Manager
+	fileShareService storage.FileShareService
+	shareConfig      *storage.FileShareConfig
+}
+
+// NewStorageFileShareDataStore creates a new StorageFileShareDataStore instance
+func NewStorageFileShareDataStore(
+	configManager config.Manager,
+	fileShareService storage.FileShareService,
+	shareConfig *storage.FileShareConfig,
+) (RemoteDataStore, error) {
+	if shareConfig == nil || shareConfig.AccountName == "" || shareConfig.SubscriptionId == "" {
+		return nil, fmt.Errorf(
+			"remote state configuration for '%s' requires an 'accountName' and 'subscriptionId'",
+			RemoteKindAzureFiles,
+		)
+	}
+
+	return &StorageFileShareDataStore{
+		configManager:    configManager,
+		fileShareService: fileShareService,
+		shareConfig:      shareConfig,
+	}, nil
+}
+
+// EnvPath returns the path to the .env file for the given environment
+func (fs *StorageFileShareDataStore) EnvPath(env *Environment) string {
+	return path.Join(env.name, DotEnvFileName)
+}
+
+// ConfigPath returns the path to the config.json file for the given environment
+func (fs *StorageFileShareDataStore) ConfigPath(env *Environment) string {
+	return path.Join(env.name, ConfigFileName)
+}
+
+// List returns a list of all environments within the file share
+func (fs *StorageFileShareDataStore) List(ctx context.Context) ([]*contracts.EnvListEnvironment, error) {
+	files, err := fs.fileShareService.ListFiles(ctx, fs.shareConfig.SubscriptionId, fs.shareConfig.ShareUrl())
+	if err != nil {
+		return nil, fmt.Errorf("listing files: %w", err)
+	}
+
+	envMap := map[string]*contracts.EnvListEnvironment{}
+	for _, filePath := range files {
+		envName := path.Dir(filePath)
+		if envName == "." || strings.Contains(envName, "/") || path.Base(filePath) == LockFileName {
+			continue
+		}
+
+		env, has := envMap[envName]
+		if !has {
+			env = &contracts.EnvListEnvironment{
+				Name: envName,
+			}
+			envMap[envName] = env
+		}
+
+		switch path.Base(filePath) {
+		case ConfigFileName:
+			env.ConfigPath = filePath
+		case DotEnvFileName:
+			env.DotEnvPath = filePath
+		}
+	}
+
+	envs := []*contracts.EnvListEnvironment{}
+	for _, env := range envMap {
+		envs = append(envs, env)
+	}
+
+	slices.SortFunc(envs, func(a, b *contracts.EnvListEnvironment) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return envs, nil
+}
+
+// Get returns the environment instance for the specified environment name
+func (fs *StorageFileShareDataStore) Get(ctx context.Context, name string) (*Environment, error) {
+	envs, err := fs.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.ContainsFunc(envs, func(env *contracts.EnvListEnvironment) bool { return env.Name == name }) {
+		return nil, fmt.Errorf("'%s': %w", name, ErrNotFound)
+	}
+
+	env := &Environment{
+		name: name,
+	}
+
+	if err := fs.Reload(ctx, env); err != nil {
+		return nil, err
+	}
+
+	return env, nil
+}
+
+// Save uploads the environment to the file share
+func (fs *StorageFileShareDataStore) Save(ctx context.Context, env *Environment, options *SaveOptions) error {
+	cfgWriter := new(bytes.Buffer)
+	if err := env.saveConfig(func(cfg config.Config) error {
+		return fs.configManager.Save(cfg, cfgWriter)
+	}); err != nil {
+		return fmt.Errorf("saving config: %w", err)
+	}
+
+	if err := fs.upload(ctx, fs.ConfigPath(env), cfgWriter); err != nil {
+		return fmt.Errorf("uploading config: %w", err)
+	}
+
+	marshalled, err := marshallDotEnv(env)
+	if err != nil {
+		return fmt.Errorf("marshalling .env: %w", err)
+	}
+
+	if err := fs.upload(ctx, fs.EnvPath(env), bytes.NewBufferString(marshalled)); err != nil {
+		return fmt.Errorf("uploading .env: %w", err)
+	}
+
+	tracing.SetUsageAttributes(fields.StringHashed(fields.EnvNameKey, env.Name()))
+	return nil
+}
+
+// Reload downloads the environment from the file share
+func (fs *StorageFileShareDataStore) Reload(ctx context.Context, env *Environment) error {
+	dotEnvReader, err := fs.fileShareService.DownloadFile(
+		ctx, fs.shareConfig.SubscriptionId, fs.shareConfig.ShareUrl(), fs.EnvPath(env))
+	if errors.Is(err, os.ErrNotExist) {
+		env.setDotenv(make(map[string]string))
+	} else if err != nil {
+		return fmt.Errorf("downloading .env: %w", err)
+	} else {
+		defer dotEnvReader.Close()
+
+		if envMap, err := godotenv.Parse(dotEnvReader); err != nil {
+			env.setDotenv(make(map[string]string))
+		} else {
+			env.setDotenv(envMap)
+		}
+	}
+
+	configReader, err := fs.fileShareService.DownloadFile(
+		ctx, fs.shareConfig.SubscriptionId, fs.shareConfig.ShareUrl(), fs.ConfigPath(env))
+	if errors.Is(err, os.ErrNotExist) {
+		env.setConfig(config.NewEmptyConfig())
+	} else if err != nil {
+		return fmt.Errorf("downloading config: %w", err)
+	} else {
+		defer configReader.Close()
+
+		cfg, err := fs.configManager.Load(configReader)
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+
+		env.setConfig(cfg)
+	}
+
+	if env.Name() != "" {
+		tracing.SetUsageAttributes(fields.StringHashed(fields.EnvNameKey, env.Name()))
+	}
+
+	return nil
+}
+
+// Delete removes the environment from the file share
+func (fs *StorageFileShareDataStore) Delete(ctx context.Context, name string) error {
+	envs, err := fs.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	matchingIndex := slices.IndexFunc(envs, func(env *contracts.EnvListEnvironment) bool {
+		return env.Name == name
+	})
+
+	if matchingIndex < 0 {
+		return fmt.Errorf("'%s': %w", name, ErrNotFound)
+	}
+
+	subscriptionId := fs.shareConfig.SubscriptionId
+	shareUrl := fs.shareConfig.ShareUrl()
+
+	// The lease of the lock file prevents its deletion
+	err = fs.fileShareService.BreakFileLease(ctx, subscriptionId, shareUrl, path.Join(name, LockFileName))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("breaking environment lock: %w", err)
+	}
+
+	env := envs[matchingIndex]
+	for _, filePath := range []string{env.ConfigPath, env.DotEnvPath, path.Join(name, LockFileName)} {
+		if filePath == "" {
+			continue
+		}
+
+		err := fs.fileShareService.DeleteFile(ctx, subscriptionId, shareUrl, filePath)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("deleting remote file: %w", err)
+		}
+	}
+
+	if err := fs.fileShareService.DeleteDirectory(ctx, subscriptionId, shareUrl, name); err != nil {
+		return fmt.Errorf("deleting remote environment directory: %w", err)
+	}
+
+	return nil
+}
+
+// Lock acquires the lock of the environment by creating a lock file within the environment directory, leased with the
+// id of the lock. Only one operation acquires the lease of the lock file when the lock is acquired concurrently.
+func (fs *StorageFileShareDataStore) Lock(ctx context.Context, name string, lock *LockInfo) error {
+	content, err := json.Marshal(lock)
+	if err != nil {
+		return fmt.Errorf("marshalling environment lock: %w", err)
+	}
+
+	err = fs.fileShareService.CreateLeasedFile(
+		ctx,
+		fs.shareConfig.SubscriptionId,
+		fs.shareConfig.ShareUrl(),
+		path.Join(name, LockFileName),
+		lock.Id,
+		bytes.NewReader(content),
+	)
+	if errors.Is(err, storage.ErrFileLeased) {
+		// The holder is unknown when the lock file is being written
+		holder, err := fs.lockHolder(ctx, name)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+
+		return &LockedError{EnvName: name, Lock: holder}
+	} else if err != nil {
+		return fmt.Errorf("uploading environment lock: %w", err)
+	}
+
+	return nil
+}
+
+// Unlock releases the lock of the environment by deleting the lock file within the environment directory. The lock
+// file is only deleted while it is leased with the id of the lock. When the id is empty, the lease of the lock file is
+// broken before deleting it regardless of its holder.
+func (fs *StorageFileShareDataStore) Unlock(ctx context.Context, name string, lockId string) error {
+	lockPath := path.Join(name, LockFileName)
+
+	if lockId != "" {
+		err := fs.fileShareService.DeleteLeasedFile(
+			ctx, fs.shareConfig.SubscriptionId, fs.shareConfig.ShareUrl(), lockPath, lockId)
+		if errors.Is(err, os.ErrNotExist) || errors.Is(err, storage.ErrFileLeased) {
+			// The lock has been released, and possibly acquired by another operation, in the meantime
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("deleting environment lock: %w", err)
+		}
+
+		return nil
+	}
+
+	err := fs.fileShareService.BreakFileLease(ctx, fs.shareConfig.SubscriptionId, fs.shareConfig.ShareUrl(), lockPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("breaking environment lock: %w", err)
+	}
+
+	err = fs.fileShareService.DeleteFile(ctx, fs.shareConfig.SubscriptionId, fs.shareConfig.ShareUrl(), lockPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("deleting environment lock: %w", err)
+	}
+
+	return nil
+}
+
+// lockHolder returns the current holder of the environment lock
+func (fs *StorageFileShareDataStore) lockHolder(ctx context.Context, name string) (*LockInfo, error) {
+	reader, err := fs.fileShareService.DownloadFile(
+		ctx, fs.shareConfig.SubscriptionId, fs.shareConfig.ShareUrl(), path.Join(name, LockFileName))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return parseLock(reader)
+}
+
+func (fs *StorageFileShareDataStore) upload(ctx context.Context, filePath string, buffer *bytes.Buffer) error {
+	return fs.fileShareService.UploadFile(
+		ctx, fs.shareConfig.SubscriptionId, fs.shareConfig.ShareUrl(), filePath, buffer)
+}
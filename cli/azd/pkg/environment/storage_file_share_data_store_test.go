@@ -0,0 +1,147 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package environment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azsdk/storage"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_StorageFileShareDataStore_Reload(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	configManager := config.NewManager()
+	shareConfig := &storage.FileShareConfig{
+		SubscriptionId: "SUBSCRIPTION_ID",
+		AccountName:    "ACCOUNT_NAME",
+		ShareName:      "SHARE_NAME",
+		Endpoint:       "core.windows.net",
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		fileShareService := &MockFileShareService{}
+		fileShareService.On("DownloadFile", mock.Anything, "env1/.env").
+			Return(io.NopCloser(strings.NewReader("KEY1=VALUE1\n")), nil)
+		fileShareService.On("DownloadFile", mock.Anything, "env1/config.json").
+			Return(io.NopCloser(strings.NewReader(`{"key":"value"}`)), nil)
+
+		dataStore, err := NewStorageFileShareDataStore(configManager, fileShareService, shareConfig)
+		require.NoError(t, err)
+
+		env := New("env1")
+		err = dataStore.Reload(*mockContext.Context, env)
+		require.NoError(t, err)
+		require.Equal(t, "VALUE1", env.Getenv("KEY1"))
+
+		value, has := env.Config.Get("key")
+		require.True(t, has)
+		require.Equal(t, "value", value)
+	})
+
+	t.Run("MissingFiles", func(t *testing.T) {
+		fileShareService := &MockFileShareService{}
+		fileShareService.On("DownloadFile", mock.Anything, mock.Anything).
+			Return(nil, fmt.Errorf("downloading file: %w", os.ErrNotExist))
+
+		dataStore, err := NewStorageFileShareDataStore(configManager, fileShareService, shareConfig)
+		require.NoError(t, err)
+
+		env := New("env1")
+		err = dataStore.Reload(*mockContext.Context, env)
+		require.NoError(t, err)
+		require.Empty(t, env.Dotenv())
+		require.True(t, env.Config.IsEmpty())
+	})
+
+	t.Run("DownloadError", func(t *testing.T) {
+		fileShareService := &MockFileShareService{}
+		fileShareService.On("DownloadFile", mock.Anything, "env1/.env").
+			Return(nil, errors.New("authorization failed"))
+
+		dataStore, err := NewStorageFileShareDataStore(configManager, fileShareService, shareConfig)
+		require.NoError(t, err)
+
+		err = dataStore.Reload(*mockContext.Context, New("env1"))
+		require.ErrorContains(t, err, "downloading .env: authorization failed")
+	})
+}
+
+func Test_StorageFileShareDataStore_Unlock(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	configManager := config.NewManager()
+	shareConfig := &storage.FileShareConfig{
+		SubscriptionId: "SUBSCRIPTION_ID",
+		AccountName:    "ACCOUNT_NAME",
+		ShareName:      "SHARE_NAME",
+		Endpoint:       "core.windows.net",
+	}
+
+	t.Run("Release", func(t *testing.T) {
+		fileShareService := &MockFileShareService{}
+		fileShareService.On("DeleteLeasedFile", mock.Anything, "env1/.lock", "mine").Return(nil)
+
+		dataStore, err := NewStorageFileShareDataStore(configManager, fileShareService, shareConfig)
+		require.NoError(t, err)
+
+		err = dataStore.Unlock(*mockContext.Context, "env1", "mine")
+		require.NoError(t, err)
+		fileShareService.AssertExpectations(t)
+	})
+
+	t.Run("AcquiredInTheMeantime", func(t *testing.T) {
+		// The lock file is leased by another operation, it is left in place
+		fileShareService := &MockFileShareService{}
+		fileShareService.On("DeleteLeasedFile", mock.Anything, "env1/.lock", "mine").
+			Return(fmt.Errorf("deleting file 'env1/.lock': %w", storage.ErrFileLeased))
+
+		dataStore, err := NewStorageFileShareDataStore(configManager, fileShareService, shareConfig)
+		require.NoError(t, err)
+
+		err = dataStore.Unlock(*mockContext.Context, "env1", "mine")
+		require.NoError(t, err)
+		fileShareService.AssertExpectations(t)
+	})
+}
+
+type MockFileShareService struct {
+	storage.FileShareService
+	mock.Mock
+}
+
+func (m *MockFileShareService) DownloadFile(
+	ctx context.Context,
+	subId string,
+	shareUrl string,
+	filePath string,
+) (io.ReadCloser, error) {
+	args := m.Called(ctx, filePath)
+
+	value, ok := args.Get(0).(io.ReadCloser)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return value, args.Error(1)
+}
+
+func (m *MockFileShareService) DeleteLeasedFile(
+	ctx context.Context,
+	subId string,
+	shareUrl string,
+	filePath string,
+	leaseId string,
+) error {
+	args := m.Called(ctx, filePath, leaseId)
+	return args.Error(0)
+}
@@ -104,7 +104,7 @@ func (h *HooksRunner) RunHooks(
 			return fmt.Errorf("reloading environment before running hook: %w", err)
 		}
 
-		err := h.execHook(ctx, hookConfig, options)
+		err := h.execHook(ctx, hookConfig, nil, options)
 		if err != nil {
 			return err
 		}
@@ -117,6 +117,37 @@ func (h *HooksRunner) RunHooks(
 	return nil
 }
 
+// Runs a single script hook with the specified name.
+// The environment variables in envVars are set for the script in addition to the azd environment values.
+func (h *HooksRunner) RunHook(
+	ctx context.Context,
+	name string,
+	hookConfig *HookConfig,
+	envVars []string,
+	options *tools.ExecOptions,
+) error {
+	hooks, err := h.hooksManager.filterConfigs(map[string][]*HookConfig{name: {hookConfig}}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, hook := range hooks {
+		if err := h.envManager.Reload(ctx, h.env); err != nil {
+			return fmt.Errorf("reloading environment before running hook: %w", err)
+		}
+
+		if err := h.execHook(ctx, hook, envVars, options); err != nil {
+			return err
+		}
+
+		if err := h.envManager.Reload(ctx, h.env); err != nil {
+			return fmt.Errorf("reloading environment after running hook: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // Gets the script to execute based on the hook configuration values
 // For inline scripts this will also create a temporary script file to execute
 func (h *HooksRunner) GetScript(hookConfig *HookConfig, envVars []string) (tools.Script, error) {
@@ -137,7 +168,12 @@ func (h *HooksRunner) GetScript(hookConfig *HookConfig, envVars []string) (tools
 	}
 }
 
-func (h *HooksRunner) execHook(ctx context.Context, hookConfig *HookConfig, options *tools.ExecOptions) error {
+func (h *HooksRunner) execHook(
+	ctx context.Context,
+	hookConfig *HookConfig,
+	envVars []string,
+	options *tools.ExecOptions,
+) error {
 	if options == nil {
 		options = &tools.ExecOptions{}
 	}
@@ -166,7 +202,7 @@ func (h *HooksRunner) execHook(ctx context.Context, hookConfig *HookConfig, opti
 		}
 	}
 
-	script, err := h.GetScript(hookConfig, hookEnv.Environ())
+	script, err := h.GetScript(hookConfig, append(hookEnv.Environ(), envVars...))
 	if err != nil {
 		return err
 	}
@@ -8,21 +8,25 @@ import (
 	"context"
 	"io"
 	"sync"
+	"sync/atomic"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 )
 
 // Extension represents an installed extension.
 type Extension struct {
-	Id           string           `json:"id"`
-	Namespace    string           `json:"namespace"`
-	Capabilities []CapabilityType `json:"capabilities,omitempty"`
-	DisplayName  string           `json:"displayName"`
-	Description  string           `json:"description"`
-	Version      string           `json:"version"`
-	Usage        string           `json:"usage"`
-	Path         string           `json:"path"`
-	Source       string           `json:"source"`
+	Id           string             `json:"id"`
+	Namespace    string             `json:"namespace"`
+	Capabilities []CapabilityType   `json:"capabilities,omitempty"`
+	Providers    []Provider         `json:"providers,omitempty"`
+	DisplayName  string             `json:"displayName"`
+	Description  string             `json:"description"`
+	Version      string             `json:"version"`
+	Usage        string             `json:"usage"`
+	Path         string             `json:"path"`
+	Source       string             `json:"source"`
+	Publisher    *Publisher         `json:"publisher,omitempty"`
+	Checksum     *ExtensionChecksum `json:"checksum,omitempty"`
 
 	stdin  *bytes.Buffer
 	stdout *output.DynamicMultiWriter
@@ -30,6 +34,7 @@ type Extension struct {
 
 	readySignal chan error // consolidated channel, buffered with capacity 1
 	readyOnce   sync.Once  // ensures signal is sent only once
+	started     atomic.Bool
 }
 
 // init initializes the extension's buffers and signals.
@@ -54,6 +59,12 @@ func (e *Extension) Fail(err error) {
 	})
 }
 
+// MarkStarted marks the extension as started to communicate with azd while a command is running.
+// Returns false when the extension has already been started, ex) for the remote state backend it provides.
+func (e *Extension) MarkStarted() bool {
+	return e.started.CompareAndSwap(false, true)
+}
+
 // WaitUntilReady blocks until the extension signals readiness or failure.
 func (e *Extension) WaitUntilReady(ctx context.Context) error {
 	select {
@@ -0,0 +1,200 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package extensions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+)
+
+// LockFileName is the name of the file that pins the extensions required by a project
+const LockFileName = "azd-extensions.lock"
+
+// LockFile pins the resolved versions and artifact checksums of the extensions required by a project
+// and their transitive dependencies.
+type LockFile struct {
+	// Extensions is a map of the locked extensions keyed by extension id
+	Extensions map[string]*LockedExtension `json:"extensions"`
+}
+
+// LockedExtension is the resolved version of an extension
+type LockedExtension struct {
+	// Version is the resolved version of the extension
+	Version string `json:"version"`
+	// Source is the extension source the extension was resolved from
+	Source string `json:"source,omitempty"`
+	// Dependencies is a list of the ids of the extensions the extension depends on
+	Dependencies []string `json:"dependencies,omitempty"`
+	// Checksums is a map of the artifact checksums keyed on platform (os & architecture)
+	Checksums map[string]ExtensionChecksum `json:"checksums,omitempty"`
+}
+
+// NewLockFile creates a lock file from the extensions selected by the dependency resolver
+func NewLockFile(resolved []*ResolvedExtension) *LockFile {
+	lockFile := &LockFile{
+		Extensions: map[string]*LockedExtension{},
+	}
+
+	for _, extension := range resolved {
+		locked := &LockedExtension{
+			Version: extension.Version.Version,
+			Source:  extension.Metadata.Source,
+		}
+
+		for _, dependency := range extension.Version.Dependencies {
+			locked.Dependencies = append(locked.Dependencies, dependency.Id)
+		}
+		slices.Sort(locked.Dependencies)
+
+		if len(extension.Version.Artifacts) > 0 {
+			locked.Checksums = map[string]ExtensionChecksum{}
+			for platform, artifact := range extension.Version.Artifacts {
+				locked.Checksums[platform] = artifact.Checksum
+			}
+		}
+
+		lockFile.Extensions[extension.Metadata.Id] = locked
+	}
+
+	return lockFile
+}
+
+// LoadLockFile loads the lock file from the specified path.
+// Returns an error wrapping os.ErrNotExist when the lock file does not exist.
+func LoadLockFile(path string) (*LockFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading lock file: %w", err)
+	}
+
+	lockFile := &LockFile{}
+	if err := json.Unmarshal(data, lockFile); err != nil {
+		return nil, fmt.Errorf("parsing lock file '%s': %w", path, err)
+	}
+
+	if lockFile.Extensions == nil {
+		lockFile.Extensions = map[string]*LockedExtension{}
+	}
+
+	return lockFile, nil
+}
+
+// Save writes the lock file to the specified path
+func (l *LockFile) Save(path string) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling lock file: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), osutil.PermissionFile); err != nil {
+		return fmt.Errorf("writing lock file: %w", err)
+	}
+
+	return nil
+}
+
+// Versions returns the locked versions keyed by extension id
+func (l *LockFile) Versions() map[string]string {
+	versions := map[string]string{}
+	for id, extension := range l.Extensions {
+		versions[id] = extension.Version
+	}
+
+	return versions
+}
+
+// Update records the extensions resolved for an upgrade of the specified extension. When the upgraded extension is
+// locked, the extension and its dependencies are locked, otherwise only the extensions already locked are updated.
+func (l *LockFile) Update(extensionId string, resolved []*ResolvedExtension) {
+	_, isLocked := l.Extensions[extensionId]
+	for id, locked := range NewLockFile(resolved).Extensions {
+		if _, has := l.Extensions[id]; has || isLocked {
+			l.Extensions[id] = locked
+		}
+	}
+}
+
+// Verify validates that the extension version matches the locked version and the locked artifact checksums
+func (e *LockedExtension) Verify(version *ExtensionVersion) error {
+	if e.Version != version.Version {
+		return fmt.Errorf("resolved version '%s' does not match locked version '%s'", version.Version, e.Version)
+	}
+
+	for platform, checksum := range e.Checksums {
+		artifact, has := version.Artifacts[platform]
+		if !has {
+			return fmt.Errorf("artifact for platform '%s' is no longer available", platform)
+		}
+
+		if !strings.EqualFold(artifact.Checksum.Algorithm, checksum.Algorithm) ||
+			!strings.EqualFold(artifact.Checksum.Value, checksum.Value) {
+			return fmt.Errorf("checksum of the artifact for platform '%s' has changed", platform)
+		}
+	}
+
+	return nil
+}
+
+// VerifyInstalled validates that the installed extension matches the locked version and was installed from an artifact
+// with the checksum locked for the current platform
+func (e *LockedExtension) VerifyInstalled(installed *Extension) error {
+	if e.Version != installed.Version {
+		return fmt.Errorf("installed version '%s' does not match locked version '%s'", installed.Version, e.Version)
+	}
+
+	for _, platform := range currentPlatforms() {
+		checksum, has := e.Checksums[platform]
+		if !has {
+			continue
+		}
+
+		if installed.Checksum == nil {
+			return fmt.Errorf("checksum of the installed artifact is unknown")
+		}
+
+		if !strings.EqualFold(installed.Checksum.Algorithm, checksum.Algorithm) ||
+			!strings.EqualFold(installed.Checksum.Value, checksum.Value) {
+			return fmt.Errorf("checksum of the installed artifact does not match the locked checksum")
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+// Verify validates that the extensions selected by the dependency resolver are the locked extensions, with the locked
+// versions
+func (l *LockFile) Verify(resolved []*ResolvedExtension) error {
+	var errs []error
+	resolvedIds := map[string]struct{}{}
+	for _, extension := range resolved {
+		id := extension.Metadata.Id
+		resolvedIds[id] = struct{}{}
+
+		locked, has := l.Extensions[id]
+		if !has {
+			errs = append(errs, fmt.Errorf("extension '%s' is not locked", id))
+		} else if locked.Version != extension.Version.Version {
+			errs = append(errs, fmt.Errorf(
+				"extension '%s' resolved to version '%s' instead of locked version '%s'",
+				id, extension.Version.Version, locked.Version))
+		}
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(l.Extensions)) {
+		if _, has := resolvedIds[id]; !has {
+			errs = append(errs, fmt.Errorf("locked extension '%s' is no longer required", id))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,151 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package extensions
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LockFile_SaveAndLoad(t *testing.T) {
+	checksum := ExtensionChecksum{Algorithm: "sha256", Value: "abc123"}
+	resolved := []*ResolvedExtension{
+		{
+			Metadata: &ExtensionMetadata{Id: "test.lib", Source: "azd"},
+			Version: &ExtensionVersion{
+				Version: "1.5.0",
+				Artifacts: map[string]ExtensionArtifact{
+					"linux/amd64": {URL: "https://example.com/test.lib", Checksum: checksum},
+				},
+			},
+		},
+		{
+			Metadata: &ExtensionMetadata{Id: "test.pack", Source: "azd"},
+			Version: &ExtensionVersion{
+				Version:      "1.0.0",
+				Dependencies: []ExtensionDependency{{Id: "test.lib", Version: "^1.0.0"}},
+			},
+		},
+	}
+
+	lockFilePath := filepath.Join(t.TempDir(), LockFileName)
+
+	_, err := LoadLockFile(lockFilePath)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	require.NoError(t, NewLockFile(resolved).Save(lockFilePath))
+
+	lockFile, err := LoadLockFile(lockFilePath)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"test.lib": "1.5.0", "test.pack": "1.0.0"}, lockFile.Versions())
+	require.Equal(t, []string{"test.lib"}, lockFile.Extensions["test.pack"].Dependencies)
+	require.Equal(t, checksum, lockFile.Extensions["test.lib"].Checksums["linux/amd64"])
+	require.Nil(t, lockFile.Extensions["test.pack"].Checksums)
+}
+
+func Test_LockedExtension_Verify(t *testing.T) {
+	locked := &LockedExtension{
+		Version: "1.5.0",
+		Checksums: map[string]ExtensionChecksum{
+			"linux/amd64": {Algorithm: "sha256", Value: "abc123"},
+		},
+	}
+
+	newVersion := func(version string, checksum string) *ExtensionVersion {
+		return &ExtensionVersion{
+			Version: version,
+			Artifacts: map[string]ExtensionArtifact{
+				"linux/amd64": {Checksum: ExtensionChecksum{Algorithm: "sha256", Value: checksum}},
+			},
+		}
+	}
+
+	require.NoError(t, locked.Verify(newVersion("1.5.0", "ABC123")))
+	require.ErrorContains(t, locked.Verify(newVersion("1.6.0", "abc123")), "does not match locked version")
+	require.ErrorContains(t, locked.Verify(newVersion("1.5.0", "def456")), "checksum of the artifact")
+	require.ErrorContains(t, locked.Verify(&ExtensionVersion{Version: "1.5.0"}), "no longer available")
+}
+
+func Test_LockFile_Update(t *testing.T) {
+	resolved := []*ResolvedExtension{
+		{Metadata: &ExtensionMetadata{Id: "test.lib"}, Version: &ExtensionVersion{Version: "2.0.0"}},
+		{
+			Metadata: &ExtensionMetadata{Id: "test.pack"},
+			Version: &ExtensionVersion{
+				Version:      "2.0.0",
+				Dependencies: []ExtensionDependency{{Id: "test.lib", Version: "^2.0.0"}},
+			},
+		},
+	}
+
+	t.Run("LockedExtension", func(t *testing.T) {
+		lockFile := &LockFile{Extensions: map[string]*LockedExtension{"test.pack": {Version: "1.0.0"}}}
+		lockFile.Update("test.pack", resolved)
+		require.Equal(t, map[string]string{"test.lib": "2.0.0", "test.pack": "2.0.0"}, lockFile.Versions())
+	})
+
+	t.Run("UnlockedExtension", func(t *testing.T) {
+		lockFile := &LockFile{Extensions: map[string]*LockedExtension{"test.lib": {Version: "1.0.0"}}}
+		lockFile.Update("test.pack", resolved)
+		require.Equal(t, map[string]string{"test.lib": "2.0.0"}, lockFile.Versions())
+	})
+}
+
+func Test_LockedExtension_VerifyInstalled(t *testing.T) {
+	checksum := ExtensionChecksum{Algorithm: "sha256", Value: "abc123"}
+	locked := &LockedExtension{
+		Version:   "1.5.0",
+		Checksums: map[string]ExtensionChecksum{},
+	}
+	for _, platform := range currentPlatforms() {
+		locked.Checksums[platform] = checksum
+	}
+
+	require.NoError(t, locked.VerifyInstalled(&Extension{
+		Version:  "1.5.0",
+		Checksum: &ExtensionChecksum{Algorithm: "SHA256", Value: "ABC123"},
+	}))
+	require.ErrorContains(t,
+		locked.VerifyInstalled(&Extension{Version: "1.4.0", Checksum: &checksum}), "does not match locked version")
+	require.ErrorContains(t, locked.VerifyInstalled(&Extension{Version: "1.5.0"}), "is unknown")
+	require.ErrorContains(t, locked.VerifyInstalled(&Extension{
+		Version:  "1.5.0",
+		Checksum: &ExtensionChecksum{Algorithm: "sha256", Value: "def456"},
+	}), "does not match the locked checksum")
+
+	// extension packs don't install any artifact
+	pack := &LockedExtension{Version: "1.0.0"}
+	require.NoError(t, pack.VerifyInstalled(&Extension{Version: "1.0.0"}))
+}
+
+func Test_LockFile_Verify(t *testing.T) {
+	lockFile := &LockFile{
+		Extensions: map[string]*LockedExtension{
+			"test.lib":  {Version: "1.5.0"},
+			"test.pack": {Version: "1.0.0"},
+		},
+	}
+
+	resolve := func(versions map[string]string) []*ResolvedExtension {
+		resolved := []*ResolvedExtension{}
+		for id, version := range versions {
+			resolved = append(resolved, &ResolvedExtension{
+				Metadata: &ExtensionMetadata{Id: id},
+				Version:  &ExtensionVersion{Version: version},
+			})
+		}
+
+		return resolved
+	}
+
+	require.NoError(t, lockFile.Verify(resolve(map[string]string{"test.lib": "1.5.0", "test.pack": "1.0.0"})))
+
+	err := lockFile.Verify(resolve(map[string]string{"test.lib": "1.6.0", "test.other": "1.0.0"}))
+	require.ErrorContains(t, err, "extension 'test.lib' resolved to version '1.6.0' instead of locked version '1.5.0'")
+	require.ErrorContains(t, err, "extension 'test.other' is not locked")
+	require.ErrorContains(t, err, "locked extension 'test.pack' is no longer required")
+}
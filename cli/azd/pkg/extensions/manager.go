@@ -13,17 +13,16 @@ import (
 	"hash"
 	"io"
 	"log"
+	"maps"
 	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
 	"slices"
-	"sort"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	azruntime "github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
-	"github.com/Masterminds/semver/v3"
 	"github.com/azure/azure-dev/cli/azd/pkg/alpha"
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
@@ -272,8 +271,8 @@ func (m *Manager) ListFromRegistry(ctx context.Context, options *ListOptions) ([
 }
 
 // Install an extension by name and optional version
-// If no version is provided, the latest version is installed
-// Latest version is determined by the last element in the Versions slice
+// If no version is provided, the highest version compatible with the installed extensions is installed
+// Dependencies are resolved transitively and installed before the extension
 func (m *Manager) Install(ctx context.Context, id string, options *FilterOptions) (*ExtensionVersion, error) {
 	if options == nil {
 		options = &FilterOptions{}
@@ -284,131 +283,226 @@ func (m *Manager) Install(ctx context.Context, id string, options *FilterOptions
 		return nil, fmt.Errorf("%s %w", id, ErrExtensionInstalled)
 	}
 
-	// Step 1: Find the extension by name
-	extension, err := m.GetFromRegistry(ctx, id, options)
+	installedExtensions, err := m.ListInstalled()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list installed extensions: %w", err)
+	}
+
+	// Installed extensions keep their version, the dependencies must be compatible with them
+	installedVersions := map[string]string{}
+	for installedId, installedExtension := range installedExtensions {
+		installedVersions[installedId] = installedExtension.Version
+	}
+
+	// Step 1: Resolve the version of the extension and the versions of its transitive dependencies
+	resolved, err := m.Resolve(
+		ctx,
+		[]ExtensionDependency{{Id: id, Version: options.Version}},
+		&ResolveOptions{
+			Source:     options.Source,
+			RequiredBy: "install request",
+			Fixed:      installedVersions,
+		},
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	// Step 2: Determine the version to install
+	// Step 2: Install the dependencies before the extension that depends on them
 	var selectedVersion *ExtensionVersion
+	for _, resolvedExtension := range resolved {
+		if resolvedExtension.Metadata.Id == id {
+			selectedVersion = resolvedExtension.Version
+		} else if _, has := installedExtensions[resolvedExtension.Metadata.Id]; has {
+			continue
+		}
 
-	availableVersions := []*semver.Version{}
-	availableVersionMap := map[*semver.Version]*ExtensionVersion{}
+		if err := m.installVersion(ctx, resolvedExtension.Metadata, resolvedExtension.Version); err != nil {
+			if resolvedExtension.Metadata.Id != id {
+				return nil, fmt.Errorf("failed to install dependency: %w", err)
+			}
 
-	// Create a map of available versions and sort them
-	// This sorts the version from lowest to highest
-	for _, extensionVersion := range extension.Versions {
-		version, err := semver.NewVersion(extensionVersion.Version)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse version: %w", err)
+			return nil, err
 		}
-
-		availableVersionMap[version] = &extensionVersion
-		availableVersions = append(availableVersions, version)
 	}
 
-	sort.Sort(semver.Collection(availableVersions))
+	return selectedVersion, nil
+}
 
-	if options.Version == "" || options.Version == "latest" {
-		latestVersion := availableVersions[len(availableVersions)-1]
-		selectedVersion = availableVersionMap[latestVersion]
-	} else {
-		// Find the best match for the version constraint
-		constraint, err := semver.NewConstraint(options.Version)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse version constraint: %w", err)
+// InstallResolved installs an extension version selected by Resolve, replacing an installed extension with a different
+// version. When the extension is locked, the artifact checksums of the registry must match the checksums of the lock,
+// and an installed extension with the same version is replaced unless it was installed from the locked artifact.
+func (m *Manager) InstallResolved(ctx context.Context, resolved *ResolvedExtension, locked *LockedExtension) error {
+	if locked != nil {
+		if err := locked.Verify(resolved.Version); err != nil {
+			return fmt.Errorf("extension '%s' does not match the lock file: %w", resolved.Metadata.Id, err)
 		}
+	}
+
+	installed, err := m.GetInstalled(LookupOptions{Id: resolved.Metadata.Id})
+	if err == nil && installed != nil {
+		if installed.Version == resolved.Version.Version {
+			if locked == nil {
+				return nil
+			}
 
-		var bestMatch *semver.Version
-		for _, v := range availableVersions {
-			// Find the highest version that satisfies the constraint
-			if constraint.Check(v) {
-				bestMatch = v
+			// The installed extension is replaced when its artifact can't be verified against the lock
+			verifyErr := locked.VerifyInstalled(installed)
+			if verifyErr == nil {
+				return nil
 			}
+
+			log.Printf("reinstalling extension '%s': %v", resolved.Metadata.Id, verifyErr)
 		}
+	}
+
+	// The new version is downloaded and verified before the installed extension is removed, so a failed download or
+	// a rejected artifact leaves the installed extension in place
+	staged, err := m.stageVersion(ctx, resolved.Metadata, resolved.Version)
+	if err != nil {
+		return err
+	}
+	defer staged.cleanup()
 
-		if bestMatch == nil {
-			return nil, fmt.Errorf(
-				"no matching version found for extension: %s and constraint: %s",
-				id, options.Version,
-			)
+	if installed != nil {
+		if err := m.Uninstall(resolved.Metadata.Id); err != nil {
+			return fmt.Errorf("failed to uninstall extension: %w", err)
 		}
+	}
 
-		selectedVersion = availableVersionMap[bestMatch]
+	return m.installStaged(resolved.Metadata, resolved.Version, staged)
+}
+
+// installVersion installs the artifacts of the extension version without resolving its dependencies
+func (m *Manager) installVersion(
+	ctx context.Context,
+	extension *ExtensionMetadata,
+	selectedVersion *ExtensionVersion,
+) error {
+	staged, err := m.stageVersion(ctx, extension, selectedVersion)
+	if err != nil {
+		return err
 	}
+	defer staged.cleanup()
 
-	if selectedVersion == nil {
-		return nil, fmt.Errorf("no compatible version found for extension: %s", id)
+	return m.installStaged(extension, selectedVersion, staged)
+}
+
+// stagedVersion is an extension version whose artifact is downloaded and verified but not installed yet
+type stagedVersion struct {
+	// The artifact for the current OS, nil for extension packs without artifacts
+	artifact *ExtensionArtifact
+	// The path of the downloaded artifact
+	tempFilePath string
+	publisher    *Publisher
+	checksum     *ExtensionChecksum
+}
+
+// cleanup removes the downloaded artifact
+func (s *stagedVersion) cleanup() {
+	if s.tempFilePath != "" {
+		os.Remove(s.tempFilePath)
 	}
+}
 
+// stageVersion downloads the artifact of the extension version to a temp location and validates its checksum and
+// signature as required by the trust policy
+func (m *Manager) stageVersion(
+	ctx context.Context,
+	extension *ExtensionMetadata,
+	selectedVersion *ExtensionVersion,
+) (*stagedVersion, error) {
 	// Binaries are optional as long as dependencies are provided
 	// This allows for extensions that are just extension packs
 	if len(selectedVersion.Artifacts) == 0 && len(selectedVersion.Dependencies) == 0 {
 		return nil, fmt.Errorf("no binaries or dependencies available for this version")
 	}
 
-	// Install dependencies
-	if len(selectedVersion.Dependencies) > 0 {
-		for _, dependency := range selectedVersion.Dependencies {
-			dependencyInstallOptions := &FilterOptions{
-				Version: dependency.Version,
-				Source:  options.Source,
-			}
-			if _, err := m.Install(ctx, dependency.Id, dependencyInstallOptions); err != nil {
-				if !errors.Is(err, ErrExtensionInstalled) {
-					return nil, fmt.Errorf("failed to install dependency: %w", err)
-				}
-			}
-		}
+	trustPolicy, err := LoadTrustPolicy(m.userConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load extension trust policy: %w", err)
 	}
 
-	hasArtifact := len(selectedVersion.Artifacts) > 0
-	var relativeExtensionPath string
-	var targetPath string
+	if err := trustPolicy.CheckSource(extension.Source); err != nil {
+		return nil, err
+	}
 
-	// Install the artifacts
-	if hasArtifact {
-		// Step 3: Find the artifact for the current OS
-		artifact, err := findArtifactForCurrentOS(selectedVersion)
-		if err != nil {
-			return nil, fmt.Errorf("failed to find artifact for current OS: %w", err)
-		}
+	staged := &stagedVersion{}
+	if len(selectedVersion.Artifacts) == 0 {
+		return staged, nil
+	}
 
-		// Step 4: Download the artifact to a temp location
-		tempFilePath, err := m.downloadArtifact(ctx, artifact.URL)
-		if err != nil {
-			return nil, fmt.Errorf("failed to download artifact: %w", err)
-		}
+	// Step 3: Find the artifact for the current OS
+	artifact, err := findArtifactForCurrentOS(selectedVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find artifact for current OS: %w", err)
+	}
 
-		// Clean up the temp file after all scenarios
-		defer os.Remove(tempFilePath)
+	// Step 4: Download the artifact to a temp location
+	tempFilePath, err := m.downloadArtifact(ctx, artifact.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download artifact: %w", err)
+	}
 
-		// Step 5: Validate the checksum if provided
-		if err := validateChecksum(tempFilePath, artifact.Checksum); err != nil {
-			return nil, fmt.Errorf("checksum validation failed: %w", err)
-		}
+	staged.artifact = artifact
+	staged.tempFilePath = tempFilePath
+
+	// Step 5: Validate the checksum if provided
+	if err := validateChecksum(tempFilePath, artifact.Checksum); err != nil {
+		staged.cleanup()
+		return nil, fmt.Errorf("checksum validation failed: %w", err)
+	}
+
+	if artifact.Checksum.Value != "" {
+		staged.checksum = &artifact.Checksum
+	}
+
+	// Step 6: Verify the signature of the publisher as required by the trust policy
+	staged.publisher, err = trustPolicy.Verify(extension.Source, artifact, tempFilePath)
+	if err != nil {
+		staged.cleanup()
+		return nil, fmt.Errorf("signature validation failed: %w", err)
+	}
+
+	return staged, nil
+}
+
+// installStaged installs the staged artifact of the extension version and records the installed extension
+func (m *Manager) installStaged(
+	extension *ExtensionMetadata,
+	selectedVersion *ExtensionVersion,
+	staged *stagedVersion,
+) error {
+	id := extension.Id
+
+	var relativeExtensionPath string
+	var targetPath string
+
+	// Install the artifacts
+	if staged.artifact != nil {
+		artifact := staged.artifact
+		tempFilePath := staged.tempFilePath
 
 		userConfigDir, err := config.GetUserConfigDir()
 		if err != nil {
-			return nil, fmt.Errorf("failed to get user config directory: %w", err)
+			return fmt.Errorf("failed to get user config directory: %w", err)
 		}
 
 		targetDir := filepath.Join(userConfigDir, "extensions", extension.Id)
 		if err := os.MkdirAll(targetDir, os.ModePerm); err != nil {
-			return nil, fmt.Errorf("failed to create target directory: %w", err)
+			return fmt.Errorf("failed to create target directory: %w", err)
 		}
 
-		// Step 6: Copy the artifact to the target directory
+		// Step 7: Copy the artifact to the target directory
 		// Check if artifact is a zip file, if so extract it to the target directory
 		if strings.HasSuffix(tempFilePath, ".zip") {
 			if err := rzip.ExtractToDirectory(tempFilePath, targetDir); err != nil {
-				return nil, fmt.Errorf("failed to extract zip file: %w", err)
+				return fmt.Errorf("failed to extract zip file: %w", err)
 			}
 		} else {
 			targetPath = filepath.Join(targetDir, filepath.Base(tempFilePath))
 			if err := copyFile(tempFilePath, targetPath); err != nil {
-				return nil, fmt.Errorf("failed to copy artifact to target location: %w", err)
+				return fmt.Errorf("failed to copy artifact to target location: %w", err)
 			}
 		}
 
@@ -430,24 +524,25 @@ func (m *Manager) Install(ctx context.Context, id string, options *FilterOptions
 		// Need to set the executable permission for the binary
 		// This change is specifically required for Linux but will apply consistently across all platforms
 		if err := os.Chmod(targetPath, osutil.PermissionExecutableFile); err != nil {
-			return nil, fmt.Errorf("failed to set executable permission: %w", err)
+			return fmt.Errorf("failed to set executable permission: %w", err)
 		}
 
 		relativeExtensionPath, err = filepath.Rel(userConfigDir, targetPath)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get relative path: %w", err)
+			return fmt.Errorf("failed to get relative path: %w", err)
 		}
 	}
 
-	// Step 7: Update the user config with the installed extension
+	// Step 8: Update the user config with the installed extension
 	extensions, err := m.ListInstalled()
 	if err != nil {
-		return nil, fmt.Errorf("failed to list installed extensions: %w", err)
+		return fmt.Errorf("failed to list installed extensions: %w", err)
 	}
 
 	extensions[id] = &Extension{
 		Id:           id,
 		Capabilities: selectedVersion.Capabilities,
+		Providers:    selectedVersion.Providers,
 		Namespace:    extension.Namespace,
 		DisplayName:  extension.DisplayName,
 		Description:  extension.Description,
@@ -455,19 +550,21 @@ func (m *Manager) Install(ctx context.Context, id string, options *FilterOptions
 		Usage:        selectedVersion.Usage,
 		Path:         relativeExtensionPath,
 		Source:       extension.Source,
+		Publisher:    staged.publisher,
+		Checksum:     staged.checksum,
 	}
 
 	if err := m.userConfig.Set(installedConfigKey, extensions); err != nil {
-		return nil, fmt.Errorf("failed to set extensions section: %w", err)
+		return fmt.Errorf("failed to set extensions section: %w", err)
 	}
 
 	if err := m.configManager.Save(m.userConfig); err != nil {
-		return nil, fmt.Errorf("failed to save user config: %w", err)
+		return fmt.Errorf("failed to save user config: %w", err)
 	}
 
 	log.Printf("Extension '%s' (version %s) installed successfully to %s\n", id, selectedVersion.Version, targetPath)
 
-	return selectedVersion, nil
+	return nil
 }
 
 // Uninstall an extension by name
@@ -516,24 +613,79 @@ func (m *Manager) Uninstall(id string) error {
 	return nil
 }
 
-// Upgrade upgrades the extension to the specified version
-// This is a convenience method that uninstalls the existing extension and installs the new version
-// If the version is not specified, the latest version is installed
-func (m *Manager) Upgrade(ctx context.Context, extensionId string, options *FilterOptions) (*ExtensionVersion, error) {
+// Upgrade upgrades an installed extension to the highest version matching the options, upgrading its dependencies
+// when required. The versions are resolved before the installed extension is replaced. When the lock file of a project
+// is specified, the locked versions of the other extensions are preferred and verified, and the lock file is updated
+// with the upgraded versions.
+func (m *Manager) Upgrade(
+	ctx context.Context,
+	extensionId string,
+	options *FilterOptions,
+	lockFile *LockFile,
+) (*ExtensionVersion, error) {
 	if options == nil {
 		options = &FilterOptions{}
 	}
 
-	if err := m.Uninstall(extensionId); err != nil {
-		return nil, fmt.Errorf("failed to uninstall extension: %w", err)
+	installedExtensions, err := m.ListInstalled()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list installed extensions: %w", err)
+	}
+
+	if _, has := installedExtensions[extensionId]; !has {
+		return nil, fmt.Errorf("%s %w", extensionId, ErrInstalledExtensionNotFound)
 	}
 
-	extensionVersion, err := m.Install(ctx, extensionId, options)
+	// The other extensions keep their installed or locked versions when they satisfy the constraints
+	preferredVersions := map[string]string{}
+	for installedId, installed := range installedExtensions {
+		preferredVersions[installedId] = installed.Version
+	}
+
+	if lockFile != nil {
+		maps.Copy(preferredVersions, lockFile.Versions())
+	}
+
+	delete(preferredVersions, extensionId)
+
+	resolved, err := m.Resolve(
+		ctx,
+		[]ExtensionDependency{{Id: extensionId, Version: options.Version}},
+		&ResolveOptions{
+			Source:     options.Source,
+			RequiredBy: "upgrade request",
+			Preferred:  preferredVersions,
+		},
+	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to install extension: %w", err)
+		return nil, err
 	}
 
-	return extensionVersion, nil
+	var selectedVersion *ExtensionVersion
+	for _, resolvedExtension := range resolved {
+		if resolvedExtension.Metadata.Id == extensionId {
+			selectedVersion = resolvedExtension.Version
+		}
+
+		var locked *LockedExtension
+		if lockFile != nil {
+			locked = lockFile.Extensions[resolvedExtension.Metadata.Id]
+			if locked != nil && locked.Version != resolvedExtension.Version.Version {
+				// The extension is upgraded past its locked version, the lock file is updated below
+				locked = nil
+			}
+		}
+
+		if err := m.InstallResolved(ctx, resolvedExtension, locked); err != nil {
+			return nil, fmt.Errorf("failed to install extension: %w", err)
+		}
+	}
+
+	if lockFile != nil {
+		lockFile.Update(extensionId, resolved)
+	}
+
+	return selectedVersion, nil
 }
 
 // Helper function to find the artifact for the current OS
@@ -542,11 +694,7 @@ func findArtifactForCurrentOS(version *ExtensionVersion) (*ExtensionArtifact, er
 		return nil, fmt.Errorf("no binaries available for this version")
 	}
 
-	artifactVersions := []string{
-		fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
-		runtime.GOOS,
-	}
-
+	artifactVersions := currentPlatforms()
 	for _, artifactVersion := range artifactVersions {
 		artifact, exists := version.Artifacts[artifactVersion]
 		if exists {
@@ -561,6 +709,14 @@ func findArtifactForCurrentOS(version *ExtensionVersion) (*ExtensionArtifact, er
 	return nil, fmt.Errorf("no artifact available for platform: %s", artifactVersions)
 }
 
+// currentPlatforms returns the keys of the artifacts for the current platform, from the most specific
+func currentPlatforms() []string {
+	return []string{
+		fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
+		runtime.GOOS,
+	}
+}
+
 // downloadFile downloads a file from the given URL and saves it to a temporary directory using the filename from the URL.
 func (m *Manager) downloadArtifact(ctx context.Context, artifactUrl string) (string, error) {
 	req, err := azruntime.NewRequest(ctx, http.MethodGet, artifactUrl)
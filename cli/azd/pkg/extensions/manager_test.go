@@ -10,6 +10,7 @@ import (
 	"encoding/hex"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -281,6 +282,93 @@ func Test_Install_With_SemverConstraints(t *testing.T) {
 	}
 }
 
+func Test_Upgrade(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+
+	createRegistryMocks(mockContext)
+
+	userConfigManager := config.NewUserConfigManager(mockContext.ConfigManager)
+	sourceManager := NewSourceManager(mockContext.Container, userConfigManager, mockContext.HttpClient)
+	manager, err := NewManager(userConfigManager, sourceManager, mockContext.HttpClient)
+	require.NoError(t, err)
+
+	_, err = manager.Upgrade(*mockContext.Context, "test.extension", nil, nil)
+	require.ErrorIs(t, err, ErrInstalledExtensionNotFound)
+
+	_, err = manager.Install(*mockContext.Context, "test.extension", &FilterOptions{Version: "1.0.0"})
+	require.NoError(t, err)
+
+	// The installed extension is kept when the upgrade can't be resolved
+	_, err = manager.Upgrade(*mockContext.Context, "test.extension", &FilterOptions{Version: "9.x"}, nil)
+	require.Error(t, err)
+	installed, err := manager.GetInstalled(LookupOptions{Id: "test.extension"})
+	require.NoError(t, err)
+	require.Equal(t, "1.0.0", installed.Version)
+
+	lockFile := &LockFile{Extensions: map[string]*LockedExtension{"test.extension": {Version: "1.0.0"}}}
+	extensionVersion, err := manager.Upgrade(*mockContext.Context, "test.extension", nil, lockFile)
+	require.NoError(t, err)
+	require.Equal(t, "3.1.0", extensionVersion.Version)
+	require.Equal(t, "3.1.0", lockFile.Extensions["test.extension"].Version)
+
+	installed, err = manager.GetInstalled(LookupOptions{Id: "test.extension"})
+	require.NoError(t, err)
+	require.Equal(t, "3.1.0", installed.Version)
+}
+
+func Test_Install_TrustPolicy(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+
+	createRegistryMocks(mockContext)
+
+	userConfigManager := config.NewUserConfigManager(mockContext.ConfigManager)
+	sourceManager := NewSourceManager(mockContext.Container, userConfigManager, mockContext.HttpClient)
+	manager, err := NewManager(userConfigManager, sourceManager, mockContext.HttpClient)
+	require.NoError(t, err)
+
+	// Extensions can only be installed from trusted sources
+	require.NoError(t, manager.userConfig.Set(trustedSourcesConfigKey+".contoso", "*"))
+	_, err = manager.Install(*mockContext.Context, "test.extension", nil)
+	require.ErrorIs(t, err, ErrUntrustedSource)
+
+	// Unsigned artifacts are rejected when signatures are required
+	require.NoError(t, manager.userConfig.Set(trustedSourcesConfigKey+".azd", "*"))
+	require.NoError(t, manager.userConfig.Set(requireSignatureConfigKey, true))
+	_, err = manager.Install(*mockContext.Context, "test.extension", nil)
+	require.ErrorIs(t, err, ErrSignatureVerificationFailed)
+
+	installed, err := manager.ListInstalled()
+	require.NoError(t, err)
+	require.Empty(t, installed)
+}
+
+func Test_Upgrade_TrustPolicy(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+
+	createRegistryMocks(mockContext)
+
+	userConfigManager := config.NewUserConfigManager(mockContext.ConfigManager)
+	sourceManager := NewSourceManager(mockContext.Container, userConfigManager, mockContext.HttpClient)
+	manager, err := NewManager(userConfigManager, sourceManager, mockContext.HttpClient)
+	require.NoError(t, err)
+
+	_, err = manager.Install(*mockContext.Context, "test.extension", &FilterOptions{Version: "1.0.0"})
+	require.NoError(t, err)
+
+	// The installed extension is kept when the artifact of the new version is rejected
+	require.NoError(t, manager.userConfig.Set(requireSignatureConfigKey, true))
+	_, err = manager.Upgrade(*mockContext.Context, "test.extension", nil, nil)
+	require.ErrorIs(t, err, ErrSignatureVerificationFailed)
+
+	installed, err := manager.GetInstalled(LookupOptions{Id: "test.extension"})
+	require.NoError(t, err)
+	require.Equal(t, "1.0.0", installed.Version)
+
+	userConfigDir, err := config.GetUserConfigDir()
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(userConfigDir, installed.Path))
+}
+
 func createRegistryMocks(mockContext *mocks.MockContext) {
 	// Create a mock source
 	mockContext.HttpClient.When(func(request *http.Request) bool {
@@ -24,8 +24,40 @@ const (
 	CustomCommandCapability CapabilityType = "custom-commands"
 	// Lifecycle events enable extensions to subscribe to AZD project & service lifecycle events
 	LifecycleEventsCapability CapabilityType = "lifecycle-events"
+	// Service target providers enable extensions to provide service targets for custom service hosts
+	ServiceTargetProviderCapability CapabilityType = "service-target-provider"
+	// Framework service providers enable extensions to provide framework services for custom service languages
+	FrameworkServiceProviderCapability CapabilityType = "framework-service-provider"
+	// Provisioning providers enable extensions to provide infrastructure provisioning providers
+	ProvisioningProviderCapability CapabilityType = "provisioning-provider"
+	// Operations enable extensions to provision and deploy the project on behalf of the user
+	OperationsCapability CapabilityType = "operations"
+	// Remote data store providers enable extensions to provide remote state backends for environments
+	RemoteDataStoreProviderCapability CapabilityType = "remote-data-store-provider"
 )
 
+type ProviderType string
+
+const (
+	// Service target providers provide the service target of a custom service host
+	ServiceTargetProviderType ProviderType = "service-target"
+	// Framework service providers provide the framework service of a custom service language
+	FrameworkServiceProviderType ProviderType = "framework-service"
+	// Remote data store providers provide the remote state backend of a custom `state.remote.backend`
+	RemoteDataStoreProviderType ProviderType = "remote-data-store"
+)
+
+// Provider represents a provider declared by an extension, ex) a service target for a custom service host. Projects
+// using the provider are validated against the providers of the installed extensions when they are loaded.
+type Provider struct {
+	// Name is the name of the provider, ex) the service host, language or remote state backend in azure.yaml
+	Name string `json:"name"`
+	// Type is the type of the provider
+	Type ProviderType `json:"type"`
+	// Description is the description of the provider
+	Description string `json:"description,omitempty"`
+}
+
 // Extension represents an extension in the registry
 type ExtensionMetadata struct {
 	// Id is a unique identifier for the extension
@@ -58,6 +90,8 @@ type ExtensionDependency struct {
 type ExtensionVersion struct {
 	// Capabilities is a list of capabilities that the extension provides
 	Capabilities []CapabilityType `json:"capabilities,omitempty"`
+	// Providers is a list of the providers that the extension provides
+	Providers []Provider `json:"providers,omitempty"`
 	// Version is the version of the extension
 	Version string `json:"version"`
 	// Usage is show how to use the extension
@@ -82,6 +116,8 @@ type ExtensionArtifact struct {
 	URL string `json:"url"`
 	// Checksum is the checksum of the artifact
 	Checksum ExtensionChecksum `json:"checksum"`
+	// Signature is the signature of the artifact created by the publisher
+	Signature *ExtensionSignature `json:"signature,omitempty"`
 	// AdditionalMetadata is a map of additional metadata for the artifact
 	AdditionalMetadata map[string]any `json:"-"`
 }
@@ -137,6 +173,7 @@ func (c *ExtensionArtifact) UnmarshalJSON(data []byte) error {
 	// Remove known fields from the temp map
 	delete(temp, "url")
 	delete(temp, "checksum")
+	delete(temp, "signature")
 
 	// Convert the remaining fields to Extras
 	c.AdditionalMetadata = map[string]any{}
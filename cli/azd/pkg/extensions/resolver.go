@@ -0,0 +1,357 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package extensions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// ErrConflictingConstraints is returned when no set of extension versions satisfies all the version constraints
+var ErrConflictingConstraints = errors.New("conflicting extension version constraints")
+
+// ResolveOptions is used to control how the versions of extensions are resolved
+type ResolveOptions struct {
+	// Source is used to specify the source of the extensions to resolve
+	Source string
+	// RequiredBy describes where the requirements come from, ex) azure.yaml
+	RequiredBy string
+	// Preferred versions are selected first when they satisfy the constraints, ex) the versions of a lock file
+	Preferred map[string]string
+	// Fixed versions must be selected, ex) the versions of extensions that are already installed
+	Fixed map[string]string
+}
+
+// ResolvedExtension is an extension version selected by the dependency resolver
+type ResolvedExtension struct {
+	// Metadata is the registry metadata of the extension
+	Metadata *ExtensionMetadata
+	// Version is the selected version of the extension
+	Version *ExtensionVersion
+}
+
+// versionConstraint is a version constraint placed on an extension by a requirement or a dependency
+type versionConstraint struct {
+	expression string
+	constraint *semver.Constraints
+	requiredBy string
+}
+
+func (c versionConstraint) String() string {
+	return fmt.Sprintf("'%s' (required by %s)", c.expression, c.requiredBy)
+}
+
+// resolverState is a partial solution of the dependency resolver
+type resolverState struct {
+	selected    map[string]*semver.Version
+	constraints map[string][]versionConstraint
+	pending     []string
+}
+
+// dependencyResolver selects a version of every required extension and their transitive dependencies that satisfies
+// all the version constraints. The highest matching versions are preferred and conflicts are resolved by backtracking.
+type dependencyResolver struct {
+	lookup   func(ctx context.Context, id string) (*ExtensionMetadata, error)
+	options  *ResolveOptions
+	metadata map[string]*ExtensionMetadata
+	versions map[string][]*semver.Version
+	conflict error
+}
+
+// Resolve selects the versions of the required extensions and their transitive dependencies.
+// The resolved extensions are ordered so that dependencies come before the extensions that depend on them.
+func (m *Manager) Resolve(
+	ctx context.Context,
+	requirements []ExtensionDependency,
+	options *ResolveOptions,
+) ([]*ResolvedExtension, error) {
+	resolveOptions := ResolveOptions{}
+	if options != nil {
+		resolveOptions = *options
+	}
+
+	if resolveOptions.RequiredBy == "" {
+		resolveOptions.RequiredBy = "azure.yaml"
+	}
+
+	resolver := &dependencyResolver{
+		lookup: func(ctx context.Context, id string) (*ExtensionMetadata, error) {
+			return m.GetFromRegistry(ctx, id, &FilterOptions{Source: resolveOptions.Source})
+		},
+		options:  &resolveOptions,
+		metadata: map[string]*ExtensionMetadata{},
+		versions: map[string][]*semver.Version{},
+	}
+
+	return resolver.resolve(ctx, requirements)
+}
+
+func (r *dependencyResolver) resolve(
+	ctx context.Context,
+	requirements []ExtensionDependency,
+) ([]*ResolvedExtension, error) {
+	state := &resolverState{
+		selected:    map[string]*semver.Version{},
+		constraints: map[string][]versionConstraint{},
+	}
+
+	for _, requirement := range requirements {
+		constraint, err := newVersionConstraint(requirement.Version, r.options.RequiredBy)
+		if err != nil {
+			return nil, fmt.Errorf("extension '%s': %w", requirement.Id, err)
+		}
+
+		state.constraints[requirement.Id] = append(state.constraints[requirement.Id], constraint)
+		if !slices.Contains(state.pending, requirement.Id) {
+			state.pending = append(state.pending, requirement.Id)
+		}
+	}
+
+	solution, err := r.solve(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+
+	// Order the extensions so that dependencies are installed before the extensions that depend on them
+	resolved := []*ResolvedExtension{}
+	visited := map[string]bool{}
+
+	var visit func(id string)
+	visit = func(id string) {
+		if visited[id] {
+			return
+		}
+
+		visited[id] = true
+		extensionVersion := r.extensionVersion(id, solution.selected[id])
+		for _, dependency := range extensionVersion.Dependencies {
+			visit(dependency.Id)
+		}
+
+		resolved = append(resolved, &ResolvedExtension{
+			Metadata: r.metadata[id],
+			Version:  extensionVersion,
+		})
+	}
+
+	for _, requirement := range requirements {
+		visit(requirement.Id)
+	}
+
+	return resolved, nil
+}
+
+// solve selects a version for the next pending extension and recursively solves the remaining extensions
+func (r *dependencyResolver) solve(ctx context.Context, state *resolverState) (*resolverState, error) {
+	if len(state.pending) == 0 {
+		return state, nil
+	}
+
+	id := state.pending[0]
+	candidates, err := r.candidates(ctx, id, state.constraints[id])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(candidates) == 0 {
+		r.conflict = r.newConflictError(id, state.constraints[id])
+		return nil, r.conflict
+	}
+
+	for _, candidate := range candidates {
+		next, err := r.selectVersion(id, candidate, state)
+		if err != nil {
+			if errors.Is(err, ErrConflictingConstraints) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		solution, err := r.solve(ctx, next)
+		if err == nil {
+			return solution, nil
+		}
+
+		if !errors.Is(err, ErrConflictingConstraints) {
+			return nil, err
+		}
+	}
+
+	return nil, r.conflict
+}
+
+// selectVersion returns a new state with the version selected for the extension and the constraints of its dependencies
+func (r *dependencyResolver) selectVersion(
+	id string,
+	version *semver.Version,
+	state *resolverState,
+) (*resolverState, error) {
+	next := &resolverState{
+		selected:    maps.Clone(state.selected),
+		constraints: maps.Clone(state.constraints),
+		pending:     slices.Clone(state.pending[1:]),
+	}
+	next.selected[id] = version
+
+	extensionVersion := r.extensionVersion(id, version)
+	requiredBy := fmt.Sprintf("%s %s", id, extensionVersion.Version)
+
+	for _, dependency := range extensionVersion.Dependencies {
+		constraint, err := newVersionConstraint(dependency.Version, requiredBy)
+		if err != nil {
+			return nil, fmt.Errorf("dependency '%s' of extension '%s': %w", dependency.Id, requiredBy, err)
+		}
+
+		dependencyConstraints := append(slices.Clone(next.constraints[dependency.Id]), constraint)
+		next.constraints[dependency.Id] = dependencyConstraints
+
+		if selected, has := next.selected[dependency.Id]; has {
+			// The dependency was already selected, the selected version must satisfy the new constraint
+			if !constraint.check(selected) {
+				r.conflict = r.newConflictError(dependency.Id, dependencyConstraints)
+				return nil, r.conflict
+			}
+		} else if !slices.Contains(next.pending, dependency.Id) {
+			next.pending = append(next.pending, dependency.Id)
+		}
+	}
+
+	return next, nil
+}
+
+// candidates returns the versions of the extension that satisfy the constraints in the order they should be tried
+func (r *dependencyResolver) candidates(
+	ctx context.Context,
+	id string,
+	constraints []versionConstraint,
+) ([]*semver.Version, error) {
+	versions, err := r.availableVersions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []*semver.Version{}
+	// Available versions are sorted from highest to lowest
+	for _, version := range versions {
+		if fixed, has := r.options.Fixed[id]; has && !versionEquals(version, fixed) {
+			continue
+		}
+
+		if !slices.ContainsFunc(constraints, func(c versionConstraint) bool { return !c.check(version) }) {
+			candidates = append(candidates, version)
+		}
+	}
+
+	if preferred, has := r.options.Preferred[id]; has {
+		if index := slices.IndexFunc(candidates, func(v *semver.Version) bool {
+			return versionEquals(v, preferred)
+		}); index > 0 {
+			candidate := candidates[index]
+			candidates = slices.Delete(candidates, index, index+1)
+			candidates = slices.Insert(candidates, 0, candidate)
+		}
+	}
+
+	return candidates, nil
+}
+
+// availableVersions returns the versions of the extension in the registry sorted from highest to lowest
+func (r *dependencyResolver) availableVersions(ctx context.Context, id string) ([]*semver.Version, error) {
+	if versions, has := r.versions[id]; has {
+		return versions, nil
+	}
+
+	extension, err := r.lookup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []*semver.Version{}
+	for _, extensionVersion := range extension.Versions {
+		version, err := semver.NewVersion(extensionVersion.Version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse version of extension '%s': %w", id, err)
+		}
+
+		versions = append(versions, version)
+	}
+
+	slices.SortFunc(versions, func(a, b *semver.Version) int {
+		return b.Compare(a)
+	})
+
+	r.metadata[id] = extension
+	r.versions[id] = versions
+
+	return versions, nil
+}
+
+// extensionVersion returns the registry metadata of the specified version of the extension
+func (r *dependencyResolver) extensionVersion(id string, version *semver.Version) *ExtensionVersion {
+	extension := r.metadata[id]
+	for i := range extension.Versions {
+		if versionEquals(version, extension.Versions[i].Version) {
+			return &extension.Versions[i]
+		}
+	}
+
+	return nil
+}
+
+func (r *dependencyResolver) newConflictError(id string, constraints []versionConstraint) error {
+	descriptions := make([]string, len(constraints))
+	for i, constraint := range constraints {
+		descriptions[i] = constraint.String()
+	}
+
+	if fixed, has := r.options.Fixed[id]; has {
+		descriptions = append(descriptions, fmt.Sprintf("'%s' (installed)", fixed))
+	}
+
+	return fmt.Errorf(
+		"%w: no version of extension '%s' satisfies %s",
+		ErrConflictingConstraints,
+		id,
+		strings.Join(descriptions, ", "),
+	)
+}
+
+// newVersionConstraint parses a semantic versioning expression. An empty expression or 'latest' matches any version.
+func newVersionConstraint(expression string, requiredBy string) (versionConstraint, error) {
+	if expression == "" || expression == "latest" {
+		expression = "*"
+	}
+
+	constraint, err := semver.NewConstraint(expression)
+	if err != nil {
+		return versionConstraint{}, fmt.Errorf("failed to parse version constraint '%s': %w", expression, err)
+	}
+
+	return versionConstraint{
+		expression: expression,
+		constraint: constraint,
+		requiredBy: requiredBy,
+	}, nil
+}
+
+func (c versionConstraint) check(version *semver.Version) bool {
+	return c.constraint.Check(version)
+}
+
+// versionEquals returns true when the version string represents the specified version
+func versionEquals(version *semver.Version, value string) bool {
+	other, err := semver.NewVersion(value)
+	if err != nil {
+		return false
+	}
+
+	return version.Equal(other)
+}
@@ -0,0 +1,182 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package extensions
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DependencyResolver(t *testing.T) {
+	tests := []struct {
+		name         string
+		requirements []ExtensionDependency
+		options      *ResolveOptions
+		expected     []string
+		expectedErr  string
+	}{
+		{
+			name:         "Transitive",
+			requirements: []ExtensionDependency{{Id: "test.app"}},
+			// test.lib 2.1.0 is excluded by the constraint of test.tools
+			expected: []string{"test.lib 2.0.0", "test.tools 1.0.0", "test.app 2.0.0"},
+		},
+		{
+			name:         "Backtracking",
+			requirements: []ExtensionDependency{{Id: "test.app"}, {Id: "test.lib", Version: "^1.0.0"}},
+			expected:     []string{"test.lib 1.5.0", "test.app 1.0.0"},
+		},
+		{
+			name:         "Preferred",
+			requirements: []ExtensionDependency{{Id: "test.lib", Version: "^1.0.0"}},
+			options:      &ResolveOptions{Preferred: map[string]string{"test.lib": "1.0.0"}},
+			expected:     []string{"test.lib 1.0.0"},
+		},
+		{
+			name:         "PreferredNotSatisfied",
+			requirements: []ExtensionDependency{{Id: "test.lib", Version: "^2.0.0"}},
+			options:      &ResolveOptions{Preferred: map[string]string{"test.lib": "1.0.0"}},
+			expected:     []string{"test.lib 2.1.0"},
+		},
+		{
+			name:         "Fixed",
+			requirements: []ExtensionDependency{{Id: "test.app"}},
+			options:      &ResolveOptions{Fixed: map[string]string{"test.lib": "1.5.0"}},
+			expected:     []string{"test.lib 1.5.0", "test.app 1.0.0"},
+		},
+		{
+			name:         "ConflictWithFixed",
+			requirements: []ExtensionDependency{{Id: "test.app", Version: "^2.0.0"}},
+			options:      &ResolveOptions{Fixed: map[string]string{"test.lib": "1.5.0"}},
+			expectedErr: "no version of extension 'test.lib' satisfies '^2.0.0' (required by test.app 2.0.0), " +
+				"'1.5.0' (installed)",
+		},
+		{
+			name: "Conflict",
+			requirements: []ExtensionDependency{
+				{Id: "test.app", Version: "^2.0.0"},
+				{Id: "test.lib", Version: "^1.0.0"},
+			},
+			expectedErr: "no version of extension 'test.lib' satisfies '^1.0.0' (required by azure.yaml), " +
+				"'^2.0.0' (required by test.app 2.0.0)",
+		},
+		{
+			name:         "NoMatchingVersion",
+			requirements: []ExtensionDependency{{Id: "test.lib", Version: "3.x"}},
+			expectedErr:  "no version of extension 'test.lib' satisfies '3.x' (required by azure.yaml)",
+		},
+		{
+			name:         "InvalidConstraint",
+			requirements: []ExtensionDependency{{Id: "test.lib", Version: "invalid"}},
+			expectedErr:  "failed to parse version constraint 'invalid'",
+		},
+		{
+			name:         "NotFound",
+			requirements: []ExtensionDependency{{Id: "test.missing"}},
+			expectedErr:  "test.missing extension not found in registry",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := tt.options
+			if options == nil {
+				options = &ResolveOptions{}
+			}
+			options.RequiredBy = "azure.yaml"
+
+			resolver := &dependencyResolver{
+				lookup:   lookupFromRegistry(resolverTestRegistry),
+				options:  options,
+				metadata: map[string]*ExtensionMetadata{},
+				versions: map[string][]*semver.Version{},
+			}
+
+			resolved, err := resolver.resolve(context.Background(), tt.requirements)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+
+			actual := []string{}
+			for _, extension := range resolved {
+				actual = append(actual, fmt.Sprintf("%s %s", extension.Metadata.Id, extension.Version.Version))
+			}
+
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func Test_DependencyResolver_ConflictError(t *testing.T) {
+	resolver := &dependencyResolver{
+		lookup:   lookupFromRegistry(resolverTestRegistry),
+		options:  &ResolveOptions{RequiredBy: "azure.yaml"},
+		metadata: map[string]*ExtensionMetadata{},
+		versions: map[string][]*semver.Version{},
+	}
+
+	_, err := resolver.resolve(context.Background(), []ExtensionDependency{{Id: "test.lib", Version: "9.x"}})
+	require.ErrorIs(t, err, ErrConflictingConstraints)
+}
+
+func lookupFromRegistry(registry Registry) func(ctx context.Context, id string) (*ExtensionMetadata, error) {
+	return func(ctx context.Context, id string) (*ExtensionMetadata, error) {
+		for _, extension := range registry.Extensions {
+			if extension.Id == id {
+				return extension, nil
+			}
+		}
+
+		return nil, fmt.Errorf("%s %w", id, ErrRegistryExtensionNotFound)
+	}
+}
+
+var resolverTestRegistry = Registry{
+	Extensions: []*ExtensionMetadata{
+		{
+			Id: "test.app",
+			Versions: []ExtensionVersion{
+				{
+					Version:      "1.0.0",
+					Artifacts:    sampleArtifacts,
+					Dependencies: []ExtensionDependency{{Id: "test.lib", Version: "^1.0.0"}},
+				},
+				{
+					Version:   "2.0.0",
+					Artifacts: sampleArtifacts,
+					Dependencies: []ExtensionDependency{
+						{Id: "test.lib", Version: "^2.0.0"},
+						{Id: "test.tools"},
+					},
+				},
+			},
+		},
+		{
+			Id: "test.lib",
+			Versions: []ExtensionVersion{
+				{Version: "1.0.0", Artifacts: sampleArtifacts},
+				{Version: "1.5.0", Artifacts: sampleArtifacts},
+				{Version: "2.0.0", Artifacts: sampleArtifacts},
+				{Version: "2.1.0", Artifacts: sampleArtifacts},
+			},
+		},
+		{
+			Id: "test.tools",
+			Versions: []ExtensionVersion{
+				{
+					Version:      "1.0.0",
+					Artifacts:    sampleArtifacts,
+					Dependencies: []ExtensionDependency{{Id: "test.lib", Version: "<2.1.0"}},
+				},
+			},
+		},
+	},
+}
@@ -0,0 +1,141 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package extensions
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var ErrSignatureVerificationFailed = errors.New("artifact signature verification failed")
+
+// ExtensionSignature is the signature of an extension artifact created with the signing key of the publisher.
+// Ed25519 keys sign the artifact content, ECDSA and RSA keys sign the SHA-256 digest of the artifact content.
+type ExtensionSignature struct {
+	// Publisher is the identity of the publisher that signed the artifact
+	Publisher string `json:"publisher"`
+	// PublicKey is the public key of the publisher, either PEM or base64 encoded (PKIX, ASN.1 DER form)
+	PublicKey string `json:"publicKey,omitempty"`
+	// Value is the base64 encoded signature of the artifact
+	Value string `json:"value"`
+}
+
+// Publisher is the verified identity of the publisher that signed an extension artifact
+type Publisher struct {
+	// Name is the name configured for the trusted key that verified the artifact, or the unverified identity of the
+	// publisher listed in the artifact signature when the key is not trusted
+	Name string `json:"name"`
+	// KeyFingerprint is the SHA-256 fingerprint of the key that signed the artifact
+	KeyFingerprint string `json:"keyFingerprint"`
+	// Trusted is true when the signing key is pinned by the trust policy of the user
+	Trusted bool `json:"trusted"`
+}
+
+// SignArtifact signs the artifact at the specified path with the signing key of the publisher
+func SignArtifact(signer crypto.Signer, publisher string, artifactPath string) (*ExtensionSignature, error) {
+	data, err := os.ReadFile(artifactPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading artifact: %w", err)
+	}
+
+	var signature []byte
+	switch signer.Public().(type) {
+	case ed25519.PublicKey:
+		signature, err = signer.Sign(rand.Reader, data, crypto.Hash(0))
+	case *ecdsa.PublicKey, *rsa.PublicKey:
+		digest := sha256.Sum256(data)
+		signature, err = signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	default:
+		return nil, fmt.Errorf("unsupported signing key type %T", signer.Public())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("signing artifact: %w", err)
+	}
+
+	publicKey, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return nil, fmt.Errorf("marshalling public key: %w", err)
+	}
+
+	return &ExtensionSignature{
+		Publisher: publisher,
+		PublicKey: base64.StdEncoding.EncodeToString(publicKey),
+		Value:     base64.StdEncoding.EncodeToString(signature),
+	}, nil
+}
+
+// ParsePublicKey parses a public key either PEM or base64 encoded in PKIX, ASN.1 DER form
+func ParsePublicKey(value string) (crypto.PublicKey, error) {
+	value = strings.TrimSpace(value)
+
+	var der []byte
+	if block, _ := pem.Decode([]byte(value)); block != nil {
+		der = block.Bytes
+	} else {
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("public key is neither PEM nor base64 encoded: %w", err)
+		}
+
+		der = decoded
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("parsing public key: %w", err)
+	}
+
+	switch publicKey.(type) {
+	case ed25519.PublicKey, *ecdsa.PublicKey, *rsa.PublicKey:
+		return publicKey, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+}
+
+// KeyFingerprint returns the SHA-256 fingerprint of the public key
+func KeyFingerprint(publicKey crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return ""
+	}
+
+	digest := sha256.Sum256(der)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(digest[:])
+}
+
+// verifySignature verifies the signature of the artifact content with the public key
+func verifySignature(publicKey crypto.PublicKey, data []byte, signature []byte) error {
+	switch key := publicKey.(type) {
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, data, signature) {
+			return ErrSignatureVerificationFailed
+		}
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(data)
+		if !ecdsa.VerifyASN1(key, digest[:], signature) {
+			return ErrSignatureVerificationFailed
+		}
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(data)
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return ErrSignatureVerificationFailed
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+
+	return nil
+}
@@ -0,0 +1,250 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package extensions
+
+import (
+	"crypto"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+)
+
+const (
+	trustedSourcesConfigKey   string = "extension.trustedSources"
+	requireSignatureConfigKey string = "extension.requireSignature"
+
+	// anyKey trusts a source without pinning the signing keys of its publishers
+	anyKey string = "*"
+)
+
+var ErrUntrustedSource = errors.New("extension source is not trusted")
+
+// TrustPolicy controls the extension sources and artifact signatures trusted when installing extensions.
+//
+// The policy is read from the user config:
+//   - `extension.trustedSources.<source>` trusts the source. The value is the public key of the publisher signing the
+//     artifacts of the source, a list of public keys, a map of publisher names to their public keys, or `*` to trust
+//     the source without pinning signing keys. When no sources are configured, extensions can be installed from any
+//     source.
+//   - `extension.requireSignature` requires artifacts to be signed with a public key pinned for the source.
+type TrustPolicy struct {
+	// TrustedSources maps the names of the trusted sources to the signing keys pinned for the source
+	TrustedSources map[string][]TrustedKey
+	// RequireSignature requires artifacts to be signed with a pinned signing key
+	RequireSignature bool
+}
+
+// TrustedKey is a signing key pinned for a trusted source
+type TrustedKey struct {
+	// Name is the name of the publisher configured for the key, empty when the key is configured without a name
+	Name string
+	// PublicKey is the public key of the publisher
+	PublicKey crypto.PublicKey
+}
+
+// pinnedKeyConfig is a public key configured for a trusted source with the name of its publisher
+type pinnedKeyConfig struct {
+	name string
+	key  string
+}
+
+// LoadTrustPolicy loads the extension trust policy from the user config
+func LoadTrustPolicy(userConfig config.Config) (*TrustPolicy, error) {
+	policy := &TrustPolicy{
+		TrustedSources: map[string][]TrustedKey{},
+	}
+
+	if value, has := userConfig.Get(requireSignatureConfigKey); has {
+		switch requireSignature := value.(type) {
+		case bool:
+			policy.RequireSignature = requireSignature
+		case string:
+			parsed, err := strconv.ParseBool(requireSignature)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for '%s': %w", requireSignatureConfigKey, err)
+			}
+
+			policy.RequireSignature = parsed
+		default:
+			return nil, fmt.Errorf("invalid value for '%s': expected a boolean", requireSignatureConfigKey)
+		}
+	}
+
+	trustedSources, _ := userConfig.GetMap(trustedSourcesConfigKey)
+	for sourceName, value := range trustedSources {
+		// The configured keys with the names of their publishers
+		configuredKeys := []pinnedKeyConfig{}
+		switch sourceKeys := value.(type) {
+		case string:
+			configuredKeys = append(configuredKeys, pinnedKeyConfig{key: sourceKeys})
+		case []any:
+			for _, key := range sourceKeys {
+				configuredKeys = append(configuredKeys, pinnedKeyConfig{key: fmt.Sprint(key)})
+			}
+		case map[string]any:
+			for _, publisherName := range slices.Sorted(maps.Keys(sourceKeys)) {
+				configuredKeys = append(
+					configuredKeys,
+					pinnedKeyConfig{name: publisherName, key: fmt.Sprint(sourceKeys[publisherName])},
+				)
+			}
+		default:
+			return nil, fmt.Errorf(
+				"invalid value for '%s.%s': expected a public key, a list of public keys or a map of publisher names "+
+					"to public keys",
+				trustedSourcesConfigKey,
+				sourceName,
+			)
+		}
+
+		trustedKeys := []TrustedKey{}
+		// Maps the fingerprints of the parsed keys to the names of their publishers
+		publishers := map[string]string{}
+		for _, configuredKey := range configuredKeys {
+			key := strings.TrimSpace(configuredKey.key)
+			if key == anyKey || key == "" {
+				continue
+			}
+
+			publicKey, err := ParsePublicKey(key)
+			if err != nil {
+				return nil, fmt.Errorf("invalid public key for trusted source '%s': %w", sourceName, err)
+			}
+
+			fingerprint := KeyFingerprint(publicKey)
+			if publisherName, has := publishers[fingerprint]; has {
+				if publisherName == configuredKey.name {
+					continue
+				}
+
+				// The publisher of an artifact is identified by the key that signed it
+				return nil, fmt.Errorf(
+					"invalid value for '%s.%s': publishers '%s' and '%s' are configured with the same public key",
+					trustedSourcesConfigKey,
+					sourceName,
+					publisherName,
+					configuredKey.name,
+				)
+			}
+
+			publishers[fingerprint] = configuredKey.name
+			trustedKeys = append(trustedKeys, TrustedKey{Name: configuredKey.name, PublicKey: publicKey})
+		}
+
+		policy.TrustedSources[strings.ToLower(sourceName)] = trustedKeys
+	}
+
+	return policy, nil
+}
+
+// CheckSource validates extensions can be installed from the source
+func (p *TrustPolicy) CheckSource(source string) error {
+	if len(p.TrustedSources) == 0 {
+		return nil
+	}
+
+	if _, has := p.TrustedSources[strings.ToLower(source)]; !has {
+		return fmt.Errorf(
+			"%w: '%s' is not listed in '%s'",
+			ErrUntrustedSource,
+			source,
+			trustedSourcesConfigKey,
+		)
+	}
+
+	return nil
+}
+
+// Verify verifies the signature of the downloaded artifact and returns the identity of the publisher.
+// Returns a nil publisher when the artifact is not signed and the policy does not require a signature.
+//
+// Artifacts of sources with pinned keys must be signed with one of the pinned keys, and the publisher is identified by
+// the name configured for the key, or by the name of the source when the key is configured without a name. Otherwise,
+// the signature is verified with the public key listed by the source, which detects tampered artifacts but does not
+// establish trust, and the publisher is identified by the name listed in the signature.
+func (p *TrustPolicy) Verify(source string, artifact *ExtensionArtifact, artifactPath string) (*Publisher, error) {
+	if err := p.CheckSource(source); err != nil {
+		return nil, err
+	}
+
+	pinnedKeys := p.TrustedSources[strings.ToLower(source)]
+	signature := artifact.Signature
+
+	if signature == nil {
+		if p.RequireSignature || len(pinnedKeys) > 0 {
+			return nil, fmt.Errorf("%w: the artifact is not signed", ErrSignatureVerificationFailed)
+		}
+
+		return nil, nil
+	}
+
+	signatureValue, err := base64.StdEncoding.DecodeString(signature.Value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature is not base64 encoded: %w", ErrSignatureVerificationFailed, err)
+	}
+
+	data, err := os.ReadFile(artifactPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading artifact: %w", err)
+	}
+
+	if len(pinnedKeys) > 0 {
+		for _, pinnedKey := range pinnedKeys {
+			if err := verifySignature(pinnedKey.PublicKey, data, signatureValue); err == nil {
+				// The publisher listed in the signature is not bound to the pinned key
+				name := pinnedKey.Name
+				if name == "" {
+					name = source
+				}
+
+				return &Publisher{
+					Name:           name,
+					KeyFingerprint: KeyFingerprint(pinnedKey.PublicKey),
+					Trusted:        true,
+				}, nil
+			}
+		}
+
+		return nil, fmt.Errorf(
+			"%w: the artifact is not signed with a key trusted for source '%s'",
+			ErrSignatureVerificationFailed,
+			source,
+		)
+	}
+
+	if p.RequireSignature {
+		return nil, fmt.Errorf(
+			"%w: no signing keys are trusted for source '%s', set '%s.%s' to the public key of the publisher",
+			ErrSignatureVerificationFailed,
+			source,
+			trustedSourcesConfigKey,
+			source,
+		)
+	}
+
+	if signature.PublicKey == "" {
+		return nil, fmt.Errorf("%w: the signature does not include a public key", ErrSignatureVerificationFailed)
+	}
+
+	publicKey, err := ParsePublicKey(signature.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSignatureVerificationFailed, err)
+	}
+
+	if err := verifySignature(publicKey, data, signatureValue); err != nil {
+		return nil, err
+	}
+
+	return &Publisher{
+		Name:           signature.Publisher,
+		KeyFingerprint: KeyFingerprint(publicKey),
+	}, nil
+}
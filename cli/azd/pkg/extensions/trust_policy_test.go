@@ -0,0 +1,184 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package extensions
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LoadTrustPolicy(t *testing.T) {
+	_, ecdsaKey := newSigningKey(t, "ecdsa")
+	der, err := x509.MarshalPKIXPublicKey(ecdsaKey.Public())
+	require.NoError(t, err)
+	pemKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	t.Run("Default", func(t *testing.T) {
+		policy, err := LoadTrustPolicy(config.NewEmptyConfig())
+		require.NoError(t, err)
+		require.False(t, policy.RequireSignature)
+		require.NoError(t, policy.CheckSource("any"))
+	})
+
+	t.Run("TrustedSources", func(t *testing.T) {
+		userConfig := config.NewEmptyConfig()
+		require.NoError(t, userConfig.Set(requireSignatureConfigKey, "true"))
+		require.NoError(t, userConfig.Set(trustedSourcesConfigKey+".azd", []any{pemKey}))
+		require.NoError(t, userConfig.Set(trustedSourcesConfigKey+".dev", "*"))
+
+		policy, err := LoadTrustPolicy(userConfig)
+		require.NoError(t, err)
+		require.True(t, policy.RequireSignature)
+		require.Len(t, policy.TrustedSources["azd"], 1)
+		require.Empty(t, policy.TrustedSources["azd"][0].Name)
+		require.Empty(t, policy.TrustedSources["dev"])
+
+		require.NoError(t, policy.CheckSource("AZD"))
+		require.NoError(t, policy.CheckSource("dev"))
+		require.ErrorIs(t, policy.CheckSource("contoso"), ErrUntrustedSource)
+	})
+
+	t.Run("NamedKeys", func(t *testing.T) {
+		userConfig := config.NewEmptyConfig()
+		require.NoError(t, userConfig.Set(trustedSourcesConfigKey+".contoso.Contoso Ltd", pemKey))
+
+		policy, err := LoadTrustPolicy(userConfig)
+		require.NoError(t, err)
+		require.Equal(t, []TrustedKey{{Name: "Contoso Ltd", PublicKey: ecdsaKey.Public()}}, policy.TrustedSources["contoso"])
+	})
+
+	t.Run("SharedKey", func(t *testing.T) {
+		userConfig := config.NewEmptyConfig()
+		require.NoError(t, userConfig.Set(trustedSourcesConfigKey+".contoso.Contoso Ltd", pemKey))
+		require.NoError(t, userConfig.Set(trustedSourcesConfigKey+".contoso.Fabrikam", pemKey))
+
+		_, err := LoadTrustPolicy(userConfig)
+		require.ErrorContains(t, err, "publishers 'Contoso Ltd' and 'Fabrikam' are configured with the same public key")
+	})
+
+	t.Run("InvalidKey", func(t *testing.T) {
+		userConfig := config.NewEmptyConfig()
+		require.NoError(t, userConfig.Set(trustedSourcesConfigKey+".azd", "not-a-key"))
+
+		_, err := LoadTrustPolicy(userConfig)
+		require.ErrorContains(t, err, "invalid public key for trusted source 'azd'")
+	})
+}
+
+func Test_TrustPolicy_Verify(t *testing.T) {
+	artifactPath := filepath.Join(t.TempDir(), "azd-ext-test")
+	require.NoError(t, os.WriteFile(artifactPath, []byte("test data"), 0600))
+
+	publisherKey, publisherSigner := newSigningKey(t, "ed25519")
+	otherKey, otherSigner := newSigningKey(t, "ecdsa")
+
+	signature, err := SignArtifact(publisherSigner, "Contoso", artifactPath)
+	require.NoError(t, err)
+	otherSignature, err := SignArtifact(otherSigner, "Contoso", artifactPath)
+	require.NoError(t, err)
+
+	signed := &ExtensionArtifact{Signature: signature}
+	unsigned := &ExtensionArtifact{}
+
+	t.Run("Unsigned", func(t *testing.T) {
+		policy := &TrustPolicy{}
+		publisher, err := policy.Verify("azd", unsigned, artifactPath)
+		require.NoError(t, err)
+		require.Nil(t, publisher)
+
+		policy.RequireSignature = true
+		_, err = policy.Verify("azd", unsigned, artifactPath)
+		require.ErrorIs(t, err, ErrSignatureVerificationFailed)
+	})
+
+	t.Run("SourceKey", func(t *testing.T) {
+		policy := &TrustPolicy{}
+		publisher, err := policy.Verify("azd", signed, artifactPath)
+		require.NoError(t, err)
+		require.Equal(t, "Contoso", publisher.Name)
+		require.Equal(t, KeyFingerprint(publisherKey), publisher.KeyFingerprint)
+		require.False(t, publisher.Trusted)
+
+		// The key listed by the source is never trusted when a signature is required
+		policy.RequireSignature = true
+		_, err = policy.Verify("azd", signed, artifactPath)
+		require.ErrorContains(t, err, "no signing keys are trusted for source 'azd'")
+	})
+
+	t.Run("PinnedKey", func(t *testing.T) {
+		policy := &TrustPolicy{
+			TrustedSources: map[string][]TrustedKey{
+				"azd": {{PublicKey: otherKey}, {Name: "Microsoft", PublicKey: publisherKey}},
+			},
+		}
+		publisher, err := policy.Verify("azd", signed, artifactPath)
+		require.NoError(t, err)
+		require.True(t, publisher.Trusted)
+		require.Equal(t, KeyFingerprint(publisherKey), publisher.KeyFingerprint)
+		// The publisher listed in the signature is not trusted
+		require.Equal(t, "Microsoft", publisher.Name)
+
+		publisher, err = policy.Verify("azd", &ExtensionArtifact{Signature: otherSignature}, artifactPath)
+		require.NoError(t, err)
+		require.Equal(t, KeyFingerprint(otherKey), publisher.KeyFingerprint)
+		require.Equal(t, "azd", publisher.Name)
+
+		// Artifacts of sources with pinned keys must be signed
+		_, err = policy.Verify("azd", unsigned, artifactPath)
+		require.ErrorIs(t, err, ErrSignatureVerificationFailed)
+	})
+
+	t.Run("UntrustedKey", func(t *testing.T) {
+		policy := &TrustPolicy{
+			TrustedSources: map[string][]TrustedKey{"azd": {{PublicKey: otherKey}}},
+		}
+		_, err := policy.Verify("azd", signed, artifactPath)
+		require.ErrorContains(t, err, "not signed with a key trusted for source 'azd'")
+	})
+
+	t.Run("UntrustedSource", func(t *testing.T) {
+		policy := &TrustPolicy{
+			TrustedSources: map[string][]TrustedKey{"azd": {{PublicKey: publisherKey}}},
+		}
+		_, err := policy.Verify("contoso", signed, artifactPath)
+		require.ErrorIs(t, err, ErrUntrustedSource)
+	})
+
+	t.Run("TamperedArtifact", func(t *testing.T) {
+		tamperedPath := filepath.Join(t.TempDir(), "azd-ext-test")
+		require.NoError(t, os.WriteFile(tamperedPath, []byte("malicious data"), 0600))
+
+		policy := &TrustPolicy{}
+		_, err := policy.Verify("azd", signed, tamperedPath)
+		require.ErrorIs(t, err, ErrSignatureVerificationFailed)
+
+		policy.TrustedSources = map[string][]TrustedKey{"azd": {{PublicKey: publisherKey}}}
+		_, err = policy.Verify("azd", signed, tamperedPath)
+		require.ErrorIs(t, err, ErrSignatureVerificationFailed)
+	})
+}
+
+func newSigningKey(t *testing.T, keyType string) (crypto.PublicKey, crypto.Signer) {
+	switch keyType {
+	case "ed25519":
+		publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		return publicKey, privateKey
+	default:
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		return privateKey.Public(), privateKey
+	}
+}
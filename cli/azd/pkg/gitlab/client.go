@@ -0,0 +1,187 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/azure/azure-dev/cli/azd/pkg/httputil"
+)
+
+// ErrNotFound is returned when the requested GitLab resource does not exist
+var ErrNotFound = errors.New("gitlab resource not found")
+
+// Project is a GitLab project
+type Project struct {
+	Id                int    `json:"id"`
+	Name              string `json:"name"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	WebUrl            string `json:"web_url"`
+	DefaultBranch     string `json:"default_branch"`
+}
+
+// Variable is a CI/CD variable of a GitLab project
+type Variable struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	// Protected variables are only exported to pipelines running on protected branches and tags
+	Protected bool `json:"protected"`
+	// Masked variables are hidden in job logs
+	Masked bool `json:"masked"`
+	// Raw variables are not expanded by GitLab, which keeps values containing `$` unchanged
+	Raw bool `json:"raw"`
+}
+
+// maskableValueRegex defines the values GitLab accepts for masked variables
+var maskableValueRegex = regexp.MustCompile(`^[a-zA-Z0-9+/=@:.~_-]{8,}$`)
+
+// CanMask returns true when GitLab accepts masking the value of a variable.
+// Masked values must be at least 8 characters long, fit in a single line and only contain characters of the
+// base64 alphabet (RFC4648) or `@`, `:`, `.`, `~`, `-` and `_`.
+func CanMask(value string) bool {
+	return maskableValueRegex.MatchString(value)
+}
+
+// Client is a client for the GitLab REST API
+type Client struct {
+	apiUrl   string
+	pipeline runtime.Pipeline
+}
+
+// NewClient creates a client for the GitLab REST API at the specified address (e.g. https://gitlab.com/api/v4),
+// authenticating requests with the access token.
+func NewClient(apiUrl string, token string, options *azcore.ClientOptions) *Client {
+	pipeline := runtime.NewPipeline("gitlab", "1.0.0", runtime.PipelineOptions{
+		PerRetry: []policy.Policy{
+			&tokenAuthPolicy{token: token},
+		},
+	}, options)
+
+	return &Client{
+		apiUrl:   apiUrl,
+		pipeline: pipeline,
+	}
+}
+
+// GetProject gets the project with the specified full path (e.g. `group/subgroup/project`)
+func (c *Client) GetProject(ctx context.Context, projectPath string) (*Project, error) {
+	res, err := c.send(ctx, http.MethodGet, c.projectUrl(projectPath), nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting project %s: %w", projectPath, err)
+	}
+	defer res.Body.Close()
+
+	return httputil.ReadRawResponse[Project](res)
+}
+
+// ListVariables lists the keys of the CI/CD variables of the project
+func (c *Client) ListVariables(ctx context.Context, projectPath string) ([]string, error) {
+	keys := []string{}
+	page := "1"
+
+	for page != "" {
+		requestUrl := fmt.Sprintf("%s/variables?per_page=100&page=%s", c.projectUrl(projectPath), page)
+		res, err := c.send(ctx, http.MethodGet, requestUrl, nil)
+		if err != nil {
+			return nil, fmt.Errorf("listing variables of project %s: %w", projectPath, err)
+		}
+
+		variables, err := httputil.ReadRawResponse[[]Variable](res)
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, variable := range *variables {
+			keys = append(keys, variable.Key)
+		}
+
+		page = res.Header.Get("X-Next-Page")
+	}
+
+	return keys, nil
+}
+
+// SetVariable creates or updates a CI/CD variable of the project
+func (c *Client) SetVariable(ctx context.Context, projectPath string, variable Variable) error {
+	variableUrl := fmt.Sprintf("%s/variables/%s", c.projectUrl(projectPath), url.PathEscape(variable.Key))
+	res, err := c.send(ctx, http.MethodPut, variableUrl, variable)
+	if errors.Is(err, ErrNotFound) {
+		res, err = c.send(ctx, http.MethodPost, c.projectUrl(projectPath)+"/variables", variable)
+	}
+	if err != nil {
+		return fmt.Errorf("setting variable %s: %w", variable.Key, err)
+	}
+	defer res.Body.Close()
+
+	return nil
+}
+
+// DeleteVariable deletes a CI/CD variable of the project
+func (c *Client) DeleteVariable(ctx context.Context, projectPath string, key string) error {
+	variableUrl := fmt.Sprintf("%s/variables/%s", c.projectUrl(projectPath), url.PathEscape(key))
+	res, err := c.send(ctx, http.MethodDelete, variableUrl, nil)
+	if err != nil {
+		return fmt.Errorf("deleting variable %s: %w", key, err)
+	}
+	defer res.Body.Close()
+
+	return nil
+}
+
+// projectUrl returns the address of the project resource. GitLab accepts the URL-encoded path of the project as id.
+func (c *Client) projectUrl(projectPath string) string {
+	return fmt.Sprintf("%s/projects/%s", c.apiUrl, url.PathEscape(projectPath))
+}
+
+// send sends the request and returns an error when the response is not successful
+func (c *Client) send(ctx context.Context, method string, requestUrl string, body any) (*http.Response, error) {
+	req, err := runtime.NewRequest(ctx, method, requestUrl)
+	if err != nil {
+		return nil, fmt.Errorf("building request: %w", err)
+	}
+
+	if body != nil {
+		if err := runtime.MarshalAsJSON(req, body); err != nil {
+			return nil, fmt.Errorf("marshalling request: %w", err)
+		}
+	}
+
+	res, err := c.pipeline.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("sending request: %w", err)
+	}
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return res, nil
+	}
+
+	defer res.Body.Close()
+	message, _ := io.ReadAll(res.Body)
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, message)
+	}
+
+	return nil, fmt.Errorf("unexpected status code %d: %s", res.StatusCode, message)
+}
+
+type tokenAuthPolicy struct {
+	token string
+}
+
+// Do authorizes a request with the GitLab access token
+func (p *tokenAuthPolicy) Do(req *policy.Request) (*http.Response, error) {
+	req.Raw().Header.Set("PRIVATE-TOKEN", p.token)
+	return req.Next()
+}
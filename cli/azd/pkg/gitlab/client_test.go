@@ -0,0 +1,115 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Client_GetProject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "token", r.Header.Get("PRIVATE-TOKEN"))
+		require.Equal(t, "/api/v4/projects/group%2Fproject", r.URL.EscapedPath())
+
+		_ = json.NewEncoder(w).Encode(Project{Id: 42, Name: "project", PathWithNamespace: "group/project"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/api/v4", "token", nil)
+	project, err := client.GetProject(context.Background(), "group/project")
+	require.NoError(t, err)
+	require.Equal(t, 42, project.Id)
+	require.Equal(t, "group/project", project.PathWithNamespace)
+}
+
+func Test_Client_ListVariables(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "1":
+			w.Header().Set("X-Next-Page", "2")
+			_ = json.NewEncoder(w).Encode([]Variable{{Key: "A"}, {Key: "B"}})
+		case "2":
+			_ = json.NewEncoder(w).Encode([]Variable{{Key: "C"}})
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/api/v4", "token", nil)
+	keys, err := client.ListVariables(context.Background(), "group/project")
+	require.NoError(t, err)
+	require.Equal(t, []string{"A", "B", "C"}, keys)
+}
+
+func Test_Client_SetVariable(t *testing.T) {
+	var requests []string
+	var created Variable
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+
+		switch r.Method {
+		case http.MethodPut:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"404 Variable Not Found"}`))
+		case http.MethodPost:
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(created)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/api/v4", "token", nil)
+	err := client.SetVariable(context.Background(), "group/project", Variable{
+		Key:    "AZURE_CLIENT_SECRET",
+		Value:  "c2VjcmV0LXZhbHVl",
+		Masked: true,
+		Raw:    true,
+	})
+	require.NoError(t, err)
+
+	// the variable is created when it doesn't exist yet
+	require.Equal(t, []string{
+		"PUT /api/v4/projects/group%2Fproject/variables/AZURE_CLIENT_SECRET",
+		"POST /api/v4/projects/group%2Fproject/variables",
+	}, requests)
+	require.Equal(t, "AZURE_CLIENT_SECRET", created.Key)
+	require.True(t, created.Masked)
+	require.True(t, created.Raw)
+}
+
+func Test_Client_DeleteVariable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodDelete, r.Method)
+
+		if r.URL.EscapedPath() == "/api/v4/projects/group%2Fproject/variables/MISSING" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.URL.EscapedPath() == "/api/v4/projects/group%2Fproject/variables/FORBIDDEN" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("403 Forbidden"))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/api/v4", "token", nil)
+	require.NoError(t, client.DeleteVariable(context.Background(), "group/project", "EXISTING"))
+	require.ErrorIs(t, client.DeleteVariable(context.Background(), "group/project", "MISSING"), ErrNotFound)
+
+	err := client.DeleteVariable(context.Background(), "group/project", "FORBIDDEN")
+	require.ErrorContains(t, err, "unexpected status code 403: 403 Forbidden")
+}
@@ -0,0 +1,138 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
+)
+
+var (
+	// hostname of the GitLab SaaS service.
+	GitLabHostName = "gitlab.com"
+	// environment variable that holds the GitLab personal, group or project access token
+	GitLabTokenName = "GITLAB_TOKEN"
+	// environment variable that holds the hostname of a self-managed GitLab instance
+	GitLabHostEnvVarName = "GITLAB_HOST"
+)
+
+// ErrRemoteHostIsNotGitLab the error used when a non GitLab remote is found
+var ErrRemoteHostIsNotGitLab = errors.New("not a gitlab host")
+
+// Remote is a git remote of a project hosted on GitLab
+type Remote struct {
+	// Host is the hostname of the GitLab instance
+	Host string
+	// ProjectPath is the full path of the project, including the group and subgroups (e.g. `group/subgroup/project`)
+	ProjectPath string
+}
+
+// Namespace returns the group or user namespace of the project
+func (r *Remote) Namespace() string {
+	idx := strings.LastIndex(r.ProjectPath, "/")
+	return r.ProjectPath[:idx]
+}
+
+// Name returns the name of the project
+func (r *Remote) Name() string {
+	idx := strings.LastIndex(r.ProjectPath, "/")
+	return r.ProjectPath[idx+1:]
+}
+
+// WebUrl returns the address of the project in the GitLab web UI
+func (r *Remote) WebUrl() string {
+	return fmt.Sprintf("https://%s/%s", r.Host, r.ProjectPath)
+}
+
+// ApiUrl returns the base address of the GitLab REST API for the instance hosting the project
+func (r *Remote) ApiUrl() string {
+	return fmt.Sprintf("https://%s/api/v4", r.Host)
+}
+
+// ParseRemote extracts the host and the project path from a GitLab remote url.
+// The url can be in the form of:
+//   - https://gitlab.com/[group]/[subgroup]/[project].git
+//   - https://[user]@gitlab.com/[group]/[project].git
+//   - ssh://git@gitlab.com[:port]/[group]/[project].git
+//   - git@gitlab.com:[group]/[project].git
+//
+// Remotes of self-managed instances are supported when the host name contains `gitlab` or matches the
+// GITLAB_HOST environment variable.
+func ParseRemote(remoteUrl string) (*Remote, error) {
+	parsed, err := git.ParseRemoteUrl(remoteUrl)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrRemoteHostIsNotGitLab, remoteUrl)
+	}
+
+	if parsed.Scheme != "https" && parsed.Scheme != "http" && parsed.Scheme != "ssh" {
+		return nil, fmt.Errorf("%w: %s", ErrRemoteHostIsNotGitLab, remoteUrl)
+	}
+
+	host, projectPath := parsed.Host, parsed.Path
+	if !isGitLabHost(host) {
+		return nil, fmt.Errorf("%w: %s", ErrRemoteHostIsNotGitLab, remoteUrl)
+	}
+
+	// GitLab projects always belong to a user or group namespace
+	if !strings.Contains(projectPath, "/") {
+		return nil, fmt.Errorf("%w: %s", ErrRemoteHostIsNotGitLab, remoteUrl)
+	}
+
+	return &Remote{
+		Host:        host,
+		ProjectPath: projectPath,
+	}, nil
+}
+
+// isGitLabHost returns true when the host is gitlab.com, the configured self-managed instance or looks like
+// a self-managed GitLab instance
+func isGitLabHost(host string) bool {
+	host = strings.ToLower(host)
+	if host == GitLabHostName || strings.Contains(host, "gitlab") {
+		return true
+	}
+
+	customHost := strings.ToLower(os.Getenv(GitLabHostEnvVarName))
+	customHost = strings.TrimPrefix(strings.TrimPrefix(customHost, "https://"), "http://")
+	return customHost != "" && host == strings.TrimSuffix(customHost, "/")
+}
+
+// EnsureTokenExists ensures a GitLab access token exists either in .env or system environment variables,
+// prompting the user for the token otherwise.
+// Returns true when the token was provided by the user.
+func EnsureTokenExists(ctx context.Context, env *environment.Environment, console input.Console) (string, bool, error) {
+	if value, has := env.LookupEnv(GitLabTokenName); has && value != "" {
+		return value, false, nil
+	}
+
+	console.Message(ctx, fmt.Sprintf(
+		"You need a %s with the %s scope. Create a token by following the instructions here %s",
+		output.WithWarningFormat("GitLab access token"),
+		output.WithHighLightFormat("api"),
+		output.WithLinkFormat("https://docs.gitlab.com/user/profile/personal_access_tokens/")))
+	console.Message(ctx, fmt.Sprintf("(%s this prompt by setting the token to env var: %s)",
+		output.WithWarningFormat("%s", "skip"),
+		output.WithHighLightFormat("%s", GitLabTokenName)))
+
+	token, err := console.Prompt(ctx, input.ConsoleOptions{
+		Message:    "GitLab access token:",
+		IsPassword: true,
+	})
+	if err != nil {
+		return "", false, fmt.Errorf("asking for gitlab token: %w", err)
+	}
+
+	// set the token as an environment variable for this cmd run
+	// note: the scope of this env var is only this shell invocation and won't be available in the caller parent shell
+	os.Setenv(GitLabTokenName, token)
+	return token, true, nil
+}
@@ -0,0 +1,83 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package gitlab
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseRemote(t *testing.T) {
+	tests := []struct {
+		name        string
+		remoteUrl   string
+		host        string
+		projectPath string
+	}{
+		{"https", "https://gitlab.com/group/project.git", "gitlab.com", "group/project"},
+		{"https without .git", "https://gitlab.com/group/project", "gitlab.com", "group/project"},
+		{"https with user", "https://user@gitlab.com/group/project.git", "gitlab.com", "group/project"},
+		{"https with subgroups", "https://gitlab.com/group/sub/project.git", "gitlab.com", "group/sub/project"},
+		{"scp", "git@gitlab.com:group/project.git", "gitlab.com", "group/project"},
+		{"scp with subgroups", "git@gitlab.com:group/sub/project.git", "gitlab.com", "group/sub/project"},
+		{"ssh with port", "ssh://git@gitlab.com:2222/group/project.git", "gitlab.com", "group/project"},
+		{"self-managed", "https://gitlab.contoso.com/group/project.git", "gitlab.contoso.com", "group/project"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remote, err := ParseRemote(tt.remoteUrl)
+			require.NoError(t, err)
+			require.Equal(t, tt.host, remote.Host)
+			require.Equal(t, tt.projectPath, remote.ProjectPath)
+		})
+	}
+}
+
+func Test_ParseRemote_NotGitLab(t *testing.T) {
+	remotes := []string{
+		"https://github.com/owner/repo.git",
+		"git@github.com:owner/repo.git",
+		"https://dev.azure.com/org/project/_git/repo",
+		"https://gitlab.com/project",
+		"file:///tmp/gitlab/group/project",
+		"not a url",
+	}
+
+	for _, remoteUrl := range remotes {
+		t.Run(remoteUrl, func(t *testing.T) {
+			_, err := ParseRemote(remoteUrl)
+			require.True(t, errors.Is(err, ErrRemoteHostIsNotGitLab))
+		})
+	}
+}
+
+func Test_ParseRemote_CustomHost(t *testing.T) {
+	_, err := ParseRemote("https://git.contoso.com/group/project.git")
+	require.ErrorIs(t, err, ErrRemoteHostIsNotGitLab)
+
+	t.Setenv(GitLabHostEnvVarName, "https://git.contoso.com/")
+
+	remote, err := ParseRemote("https://git.contoso.com/group/project.git")
+	require.NoError(t, err)
+	require.Equal(t, "git.contoso.com", remote.Host)
+	require.Equal(t, "https://git.contoso.com/group/project", remote.WebUrl())
+	require.Equal(t, "https://git.contoso.com/api/v4", remote.ApiUrl())
+}
+
+func Test_Remote_NamespaceAndName(t *testing.T) {
+	remote := &Remote{Host: "gitlab.com", ProjectPath: "group/sub/project"}
+	require.Equal(t, "group/sub", remote.Namespace())
+	require.Equal(t, "project", remote.Name())
+}
+
+func Test_CanMask(t *testing.T) {
+	require.True(t, CanMask("c2VjcmV0LXZhbHVl"))
+	require.True(t, CanMask("my.secret~value_1"))
+	require.False(t, CanMask("short"))
+	require.False(t, CanMask("has spaces in it"))
+	require.False(t, CanMask(`{"json":"value"}`))
+}
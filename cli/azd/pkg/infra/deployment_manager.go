@@ -80,6 +80,7 @@ func (dm *DeploymentManager) CompletedDeployments(
 	ctx context.Context,
 	scope Scope,
 	envName string,
+	layerName string,
 	hint string,
 ) ([]*azapi.ResourceDeployment, error) {
 	deployments, err := scope.ListDeployments(ctx)
@@ -109,6 +110,12 @@ func (dm *DeploymentManager) CompletedDeployments(
 			continue
 		}
 
+		// Deployments of other infrastructure layers are never considered. Deployments of projects without layers
+		// don't have a layer tag.
+		if deploymentLayer(deployment) != layerName {
+			continue
+		}
+
 		// Match on current azd strategy (tags) or old azd strategy (deployment name)
 		if v, has := deployment.Tags[azure.TagKeyAzdEnvName]; has && *v == envName || deployment.Name == envName {
 			return []*azapi.ResourceDeployment{deployment}, nil
@@ -126,3 +133,13 @@ func (dm *DeploymentManager) CompletedDeployments(
 
 	return matchingDeployments, nil
 }
+
+// deploymentLayer returns the name of the infrastructure layer provisioned by the deployment, empty when the deployment
+// doesn't belong to a layer.
+func deploymentLayer(deployment *azapi.ResourceDeployment) string {
+	if layer, has := deployment.Tags[azure.TagKeyAzdLayerName]; has && layer != nil {
+		return *layer
+	}
+
+	return ""
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cognitiveservices/armcognitiveservices"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
 	"github.com/azure/azure-dev/cli/azd/pkg/async"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
@@ -36,6 +37,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/pkg/keyvault"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
 	"github.com/azure/azure-dev/cli/azd/pkg/password"
@@ -173,7 +175,12 @@ func (p *BicepProvider) EnsureEnv(ctx context.Context) error {
 	}
 
 	if scope == azure.DeploymentScopeResourceGroup {
-		if p.env.Getenv(environment.ResourceGroupEnvVarName) == "" {
+		resourceGroup, err := p.resourceGroupName()
+		if err != nil {
+			return err
+		}
+
+		if resourceGroup == "" {
 			rgName, err := p.prompters.PromptResourceGroup(ctx, prompt.PromptResourceOptions{})
 			if err != nil {
 				return err
@@ -283,7 +290,8 @@ func (p *BicepProvider) State(ctx context.Context, options *provisioning.StateOp
 
 	var deployment *azapi.ResourceDeployment
 
-	deployments, err := p.deploymentManager.CompletedDeployments(ctx, scope, p.env.Name(), options.Hint())
+	deployments, err := p.deploymentManager.CompletedDeployments(
+		ctx, scope, p.env.Name(), p.options.Name, options.Hint())
 	p.console.StopSpinner(ctx, "", input.StepDone)
 
 	if err != nil {
@@ -420,7 +428,7 @@ func (p *BicepProvider) plan(ctx context.Context) (*deploymentDetails, error) {
 }
 
 func (p *BicepProvider) deploymentFromScopeType(deploymentScopeType azure.DeploymentScope) (infra.Deployment, error) {
-	deploymentName := p.deploymentManager.GenerateDeploymentName(p.env.Name())
+	deploymentName := p.deploymentManager.GenerateDeploymentName(p.deploymentBaseName())
 
 	if deploymentScopeType == azure.DeploymentScopeSubscription {
 		scope := p.deploymentManager.SubscriptionScope(p.env.GetSubscriptionId(), p.env.GetLocation())
@@ -429,10 +437,12 @@ func (p *BicepProvider) deploymentFromScopeType(deploymentScopeType azure.Deploy
 			deploymentName,
 		), nil
 	} else if deploymentScopeType == azure.DeploymentScopeResourceGroup {
-		scope := p.deploymentManager.ResourceGroupScope(
-			p.env.GetSubscriptionId(),
-			p.env.Getenv(environment.ResourceGroupEnvVarName),
-		)
+		resourceGroup, err := p.resourceGroupName()
+		if err != nil {
+			return nil, err
+		}
+
+		scope := p.deploymentManager.ResourceGroupScope(p.env.GetSubscriptionId(), resourceGroup)
 		return infra.NewResourceGroupDeployment(scope, deploymentName), nil
 	}
 	return nil, fmt.Errorf("unsupported scope: %s", deploymentScopeType)
@@ -480,7 +490,7 @@ func (p *BicepProvider) latestDeploymentResult(
 	ctx context.Context,
 	scope infra.Scope,
 ) (*azapi.ResourceDeployment, error) {
-	deployments, err := p.deploymentManager.CompletedDeployments(ctx, scope, p.env.Name(), "")
+	deployments, err := p.deploymentManager.CompletedDeployments(ctx, scope, p.env.Name(), p.options.Name, "")
 	// findCompletedDeployments returns error if no deployments are found
 	// No need to check for empty list
 	if err != nil {
@@ -602,6 +612,9 @@ func (p *BicepProvider) Deploy(ctx context.Context) (*provisioning.DeployResult,
 	deploymentTags := map[string]*string{
 		azure.TagKeyAzdEnvName: to.Ptr(p.env.Name()),
 	}
+	if p.options.Name != "" {
+		deploymentTags[azure.TagKeyAzdLayerName] = to.Ptr(p.options.Name)
+	}
 	if parametersHashErr == nil {
 		deploymentTags[azure.TagKeyAzdDeploymentStateParamHashName] = to.Ptr(currentParamsHash)
 	}
@@ -735,19 +748,50 @@ func (p *BicepProvider) Preview(ctx context.Context) (*provisioning.DeployPrevie
 			},
 			ResourceType: resourceAfter["type"].(string),
 			Name:         resourceAfter["name"].(string),
+			Before:       change.Before,
+			After:        change.After,
+			Delta:        convertPropertyChanges(change.Delta),
 		})
 	}
 
+	outputs := make(map[string]provisioning.OutputParameter, len(bicepDeploymentData.CompiledBicep.Template.Outputs))
+	for key, output := range bicepDeploymentData.CompiledBicep.Template.Outputs {
+		outputs[key] = provisioning.OutputParameter{
+			Type: p.mapBicepTypeToInterfaceType(output.Type),
+		}
+	}
+
 	return &provisioning.DeployPreviewResult{
 		Preview: &provisioning.DeploymentPreview{
 			Status: *deployPreviewResult.Status,
 			Properties: &provisioning.DeploymentPreviewProperties{
 				Changes: changes,
 			},
+			Outputs: outputs,
 		},
 	}, nil
 }
 
+// convertPropertyChanges converts the property changes of a what-if result to the property changes of a preview.
+func convertPropertyChanges(changes []*armresources.WhatIfPropertyChange) []provisioning.DeploymentPreviewPropertyChange {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	result := make([]provisioning.DeploymentPreviewPropertyChange, len(changes))
+	for index, change := range changes {
+		result[index] = provisioning.DeploymentPreviewPropertyChange{
+			ChangeType: provisioning.PropertyChangeType(convert.ToValueWithDefault(change.PropertyChangeType, "")),
+			Path:       convert.ToValueWithDefault(change.Path, ""),
+			Before:     change.Before,
+			After:      change.After,
+			Children:   convertPropertyChanges(change.Children),
+		}
+	}
+
+	return result
+}
+
 type itemToPurge struct {
 	resourceType      string
 	count             int
@@ -764,16 +808,52 @@ func (p *BicepProvider) scopeForTemplate(t azure.ArmTemplate) (infra.Scope, erro
 	if deploymentScope == azure.DeploymentScopeSubscription {
 		return p.deploymentManager.SubscriptionScope(p.env.GetSubscriptionId(), p.env.GetLocation()), nil
 	} else if deploymentScope == azure.DeploymentScopeResourceGroup {
-		return p.deploymentManager.ResourceGroupScope(
-			p.env.GetSubscriptionId(),
-			p.env.Getenv(environment.ResourceGroupEnvVarName),
-		), nil
+		resourceGroup, err := p.resourceGroupName()
+		if err != nil {
+			return nil, err
+		}
+
+		return p.deploymentManager.ResourceGroupScope(p.env.GetSubscriptionId(), resourceGroup), nil
 	} else {
 		return nil, fmt.Errorf("unsupported deployment scope: %s", deploymentScope)
 	}
 }
 
+// resourceGroupName returns the resource group of resource group scoped deployments, configured by the layer or the
+// AZURE_RESOURCE_GROUP environment variable.
+func (p *BicepProvider) resourceGroupName() (string, error) {
+	if p.options.ResourceGroup == "" {
+		return p.env.Getenv(environment.ResourceGroupEnvVarName), nil
+	}
+
+	resourceGroup, err := osutil.NewExpandableString(p.options.ResourceGroup).Envsubst(p.env.Getenv)
+	if err != nil {
+		return "", fmt.Errorf("resolving resource group of layer '%s': %w", p.options.Name, err)
+	}
+
+	return resourceGroup, nil
+}
+
+// deploymentBaseName returns the base name of the deployments, which includes the name of the layer for the
+// deployments of infrastructure layers.
+func (p *BicepProvider) deploymentBaseName() string {
+	if p.options.Name == "" {
+		return p.env.Name()
+	}
+
+	return fmt.Sprintf("%s-%s", p.env.Name(), p.options.Name)
+}
+
 func (p *BicepProvider) inferScopeFromEnv() (infra.Scope, error) {
+	if p.options.ResourceGroup != "" {
+		resourceGroup, err := p.resourceGroupName()
+		if err != nil {
+			return nil, err
+		}
+
+		return p.deploymentManager.ResourceGroupScope(p.env.GetSubscriptionId(), resourceGroup), nil
+	}
+
 	if resourceGroup, has := p.env.LookupEnv(environment.ResourceGroupEnvVarName); has {
 		return p.deploymentManager.ResourceGroupScope(p.env.GetSubscriptionId(), resourceGroup), nil
 	} else {
@@ -799,7 +879,7 @@ func (p *BicepProvider) Destroy(
 		return nil, fmt.Errorf("computing deployment scope: %w", err)
 	}
 
-	completedDeployments, err := p.deploymentManager.CompletedDeployments(ctx, scope, p.env.Name(), "")
+	completedDeployments, err := p.deploymentManager.CompletedDeployments(ctx, scope, p.env.Name(), p.options.Name, "")
 	if err != nil {
 		return nil, fmt.Errorf("finding completed deployments: %w", err)
 	}
@@ -923,8 +1003,8 @@ func (p *BicepProvider) Destroy(
 	}
 
 	// Since we have deleted the resource group, add AZURE_RESOURCE_GROUP to the list of invalidated env vars
-	// so it will be removed from the .env file.
-	if _, ok := scope.(*infra.ResourceGroupScope); ok {
+	// so it will be removed from the .env file, unless the resource group is configured by the layer.
+	if _, ok := scope.(*infra.ResourceGroupScope); ok && p.options.ResourceGroup == "" {
 		destroyResult.InvalidatedEnvKeys = append(
 			destroyResult.InvalidatedEnvKeys, environment.ResourceGroupEnvVarName,
 		)
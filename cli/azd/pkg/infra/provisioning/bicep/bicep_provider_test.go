@@ -890,7 +890,7 @@ func TestFindCompletedDeployments(t *testing.T) {
 		*mockContext.Context, &mockedScope{
 			baseDate: baseDate,
 			envTag:   envTag,
-		}, envTag, "")
+		}, envTag, "", "")
 	require.NoError(t, err)
 	require.Equal(t, 1, len(deployments))
 	// should take the base date + 2 years
@@ -900,11 +900,38 @@ func TestFindCompletedDeployments(t *testing.T) {
 
 	deploymentDate := deployments[0].Timestamp
 	require.Equal(t, expectedDate, deploymentDate)
+
+	t.Run("Layers", func(t *testing.T) {
+		scope := &mockedScope{
+			baseDate:  baseDate,
+			envTag:    envTag,
+			layerName: "app",
+		}
+
+		// the most recent deployment belongs to the 'app' layer
+		deployments, err := bicepProvider.deploymentManager.CompletedDeployments(
+			*mockContext.Context, scope, envTag, "app", "")
+		require.NoError(t, err)
+		require.Len(t, deployments, 1)
+		require.Equal(t, expectedDate, deployments[0].Timestamp)
+
+		deployments, err = bicepProvider.deploymentManager.CompletedDeployments(
+			*mockContext.Context, scope, envTag, "", "")
+		require.NoError(t, err)
+		require.Len(t, deployments, 1)
+		require.Equal(t, expectedDate.Add(-time.Hour*24*365), deployments[0].Timestamp)
+
+		_, err = bicepProvider.deploymentManager.CompletedDeployments(
+			*mockContext.Context, scope, envTag, "network", "")
+		require.ErrorIs(t, err, infra.ErrDeploymentsNotFound)
+	})
 }
 
 type mockedScope struct {
 	envTag   string
 	baseDate string
+	// layerName, when set, is the layer of the most recent deployment
+	layerName string
 }
 
 func (m *mockedScope) SubscriptionId() string {
@@ -928,6 +955,14 @@ func (m *mockedScope) ListDeployments(ctx context.Context) ([]*azapi.ResourceDep
 	secondDate := baseDate.Add(time.Hour * 24 * 365)
 	thirdDate := secondDate.Add(time.Hour * 24 * 365)
 
+	thirdTags := tags
+	if m.layerName != "" {
+		thirdTags = map[string]*string{
+			azure.TagKeyAzdEnvName:   &m.envTag,
+			azure.TagKeyAzdLayerName: &m.layerName,
+		}
+	}
+
 	return []*azapi.ResourceDeployment{
 		{
 			Tags:              tags,
@@ -940,7 +975,7 @@ func (m *mockedScope) ListDeployments(ctx context.Context) ([]*azapi.ResourceDep
 			Timestamp:         secondDate,
 		},
 		{
-			Tags:              tags,
+			Tags:              thirdTags,
 			ProvisioningState: azapi.DeploymentProvisioningStateSucceeded,
 			Timestamp:         thirdDate,
 		},
@@ -1429,3 +1464,43 @@ func TestDefaultLocationToSelectFn(t *testing.T) {
 		require.Nil(t, result)
 	})
 }
+
+func TestConvertPropertyChanges(t *testing.T) {
+	changes := convertPropertyChanges([]*armresources.WhatIfPropertyChange{
+		{
+			Path:               to.Ptr("properties.minimumTlsVersion"),
+			PropertyChangeType: to.Ptr(armresources.PropertyChangeTypeModify),
+			Before:             "TLS1_0",
+			After:              "TLS1_2",
+		},
+		{
+			Path:               to.Ptr("tags"),
+			PropertyChangeType: to.Ptr(armresources.PropertyChangeTypeModify),
+			Children: []*armresources.WhatIfPropertyChange{
+				{
+					Path:               to.Ptr("owner"),
+					PropertyChangeType: to.Ptr(armresources.PropertyChangeTypeDelete),
+					Before:             "me",
+				},
+			},
+		},
+	})
+
+	require.Equal(t, []provisioning.DeploymentPreviewPropertyChange{
+		{
+			ChangeType: provisioning.PropertyChangeTypeModify,
+			Path:       "properties.minimumTlsVersion",
+			Before:     "TLS1_0",
+			After:      "TLS1_2",
+		},
+		{
+			ChangeType: provisioning.PropertyChangeTypeModify,
+			Path:       "tags",
+			Children: []provisioning.DeploymentPreviewPropertyChange{
+				{ChangeType: provisioning.PropertyChangeTypeDelete, Path: "owner", Before: "me"},
+			},
+		},
+	}, changes)
+
+	require.Nil(t, convertPropertyChanges(nil))
+}
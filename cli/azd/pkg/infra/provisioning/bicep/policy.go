@@ -0,0 +1,232 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package bicep
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning/policy"
+)
+
+// PolicyResources lists the resources of the compiled ARM template, including the resources of the nested templates of
+// modules. Parameters and variables are resolved from the parameters of the deployment, other template expressions are
+// only evaluated during the deployment and their values are unknown.
+func (p *BicepProvider) PolicyResources(ctx context.Context) ([]policy.Resource, error) {
+	bicepDeploymentData, err := p.plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var template map[string]any
+	if err := json.Unmarshal(bicepDeploymentData.CompiledBicep.RawArmTemplate, &template); err != nil {
+		return nil, fmt.Errorf("parsing compiled template: %w", err)
+	}
+
+	parameters := map[string]any{}
+	for name, parameter := range bicepDeploymentData.CompiledBicep.Parameters {
+		parameters[name] = armParameterValue(parameter)
+	}
+
+	return templatePolicyResources(template, parameters), nil
+}
+
+// armParameterValue returns the value of a deployment parameter. Key Vault references are only resolved during the
+// deployment.
+func armParameterValue(parameter azure.ArmParameter) any {
+	if parameter.KeyVaultReference != nil {
+		return policy.Unknown
+	}
+
+	return parameter.Value
+}
+
+// templatePolicyResources returns the resources of an ARM template deployed with the specified parameter values.
+func templatePolicyResources(template map[string]any, parameters map[string]any) []policy.Resource {
+	return newTemplateScope(template, parameters).resources(template)
+}
+
+// templateScope resolves the parameters and variables referenced by the expressions of a template.
+type templateScope struct {
+	parameters    map[string]any
+	defaultValues map[string]any
+	variables     map[string]any
+	// resolved are the default values and variables already resolved, keyed by expression function and name. Values
+	// being resolved are Unknown, which guards against values referencing themselves.
+	resolved map[string]any
+}
+
+func newTemplateScope(template map[string]any, parameters map[string]any) *templateScope {
+	scope := &templateScope{
+		parameters:    map[string]any{},
+		defaultValues: map[string]any{},
+		resolved:      map[string]any{},
+	}
+	scope.variables, _ = template["variables"].(map[string]any)
+
+	definitions, _ := template["parameters"].(map[string]any)
+	for name, definition := range definitions {
+		if value, has := parameters[name]; has && value != nil {
+			scope.parameters[name] = value
+		} else if definition, ok := definition.(map[string]any); ok && definition["defaultValue"] != nil {
+			// default values are template values, which may reference the other parameters
+			scope.defaultValues[name] = definition["defaultValue"]
+		}
+	}
+
+	return scope
+}
+
+// resources returns the resources of a template evaluated in the scope. Resources are either an array, or an object
+// keyed by symbolic names for templates using language version 2.0.
+func (s *templateScope) resources(template map[string]any) []policy.Resource {
+	var definitions []any
+	switch templateResources := template["resources"].(type) {
+	case []any:
+		definitions = templateResources
+	case map[string]any:
+		for _, symbolicName := range slices.Sorted(maps.Keys(templateResources)) {
+			definitions = append(definitions, templateResources[symbolicName])
+		}
+	}
+
+	var resources []policy.Resource
+	for _, definition := range definitions {
+		resource, ok := definition.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		// existing resources are referenced, not deployed
+		if existing, _ := resource["existing"].(bool); existing {
+			continue
+		}
+
+		resourceType, _ := resource["type"].(string)
+		if strings.EqualFold(resourceType, "Microsoft.Resources/deployments") {
+			// modules are nested deployments, the resources are the resources of the nested template
+			properties, _ := resource["properties"].(map[string]any)
+			if nestedTemplate, ok := properties["template"].(map[string]any); ok {
+				resources = append(resources, s.nestedScope(nestedTemplate, properties).resources(nestedTemplate)...)
+			}
+
+			continue
+		}
+
+		name, _ := resource["name"].(string)
+		if resolvedName, ok := s.value(name).(string); ok {
+			name = resolvedName
+		}
+
+		resources = append(resources, policy.Resource{
+			Type:       resourceType,
+			Name:       name,
+			Properties: s.value(resource).(map[string]any),
+		})
+	}
+
+	return resources
+}
+
+// nestedScope returns the scope of the nested template of a deployment. The parameters of the nested template are
+// resolved in the scope of the parent template.
+func (s *templateScope) nestedScope(template map[string]any, properties map[string]any) *templateScope {
+	options, _ := properties["expressionEvaluationOptions"].(map[string]any)
+	if evaluationScope, _ := options["scope"].(string); !strings.EqualFold(evaluationScope, "inner") {
+		// the expressions of the nested template are evaluated in the scope of the parent template
+		return s
+	}
+
+	parameters := map[string]any{}
+	values, _ := properties["parameters"].(map[string]any)
+	for name, value := range values {
+		parameter, ok := value.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if _, has := parameter["reference"]; has {
+			parameters[name] = policy.Unknown
+			continue
+		}
+
+		parameters[name] = s.value(parameter["value"])
+	}
+
+	return newTemplateScope(template, parameters)
+}
+
+// referenceExpression matches the template expressions only referencing a parameter or a variable
+var referenceExpression = regexp.MustCompile(`^\[\s*(parameters|variables)\(\s*'([^']*)'\s*\)\s*\]$`)
+
+// value resolves the template expressions of a template value. Expressions referencing a parameter or a variable are
+// replaced with their values, other expressions are replaced with unknown values.
+func (s *templateScope) value(value any) any {
+	switch v := value.(type) {
+	case string:
+		// strings starting with [[ are escaped literals starting with [
+		if strings.HasPrefix(v, "[[") {
+			return v[1:]
+		}
+
+		if !strings.HasPrefix(v, "[") || !strings.HasSuffix(v, "]") {
+			return v
+		}
+
+		matches := referenceExpression.FindStringSubmatch(v)
+		if matches == nil {
+			return policy.Unknown
+		}
+
+		if matches[1] == "parameters" {
+			if parameter, has := s.parameters[matches[2]]; has {
+				return parameter
+			}
+
+			return s.resolve(matches[1], matches[2], s.defaultValues)
+		}
+
+		return s.resolve(matches[1], matches[2], s.variables)
+	case map[string]any:
+		values := make(map[string]any, len(v))
+		for key, item := range v {
+			values[key] = s.value(item)
+		}
+
+		return values
+	case []any:
+		values := make([]any, len(v))
+		for index, item := range v {
+			values[index] = s.value(item)
+		}
+
+		return values
+	default:
+		return v
+	}
+}
+
+// resolve returns the resolved value of a default value or a variable of the template.
+func (s *templateScope) resolve(function string, name string, definitions map[string]any) any {
+	key := function + "/" + name
+	if resolved, has := s.resolved[key]; has {
+		return resolved
+	}
+
+	definition, has := definitions[name]
+	if !has {
+		return policy.Unknown
+	}
+
+	s.resolved[key] = policy.Unknown
+	s.resolved[key] = s.value(definition)
+
+	return s.resolved[key]
+}
@@ -0,0 +1,119 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package bicep
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning/policy"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplatePolicyResources(t *testing.T) {
+	const template = `{
+  "$schema": "https://schema.management.azure.com/schemas/2018-05-01/subscriptionDeploymentTemplate.json#",
+  "parameters": {
+    "environmentName": { "type": "string" },
+    "location": { "type": "string" },
+    "allowPublicAccess": { "type": "bool", "defaultValue": "[variables('defaultPublicAccess')]" },
+    "secret": { "type": "securestring" }
+  },
+  "variables": {
+    "defaultPublicAccess": true,
+    "tags": { "azd-env-name": "[parameters('environmentName')]" }
+  },
+  "resources": [
+    {
+      "type": "Microsoft.Resources/resourceGroups",
+      "name": "[format('rg-{0}', parameters('environmentName'))]",
+      "location": "[parameters('location')]",
+      "tags": "[variables('tags')]"
+    },
+    {
+      "type": "Microsoft.Resources/deployments",
+      "name": "resources",
+      "properties": {
+        "expressionEvaluationOptions": { "scope": "inner" },
+        "parameters": {
+          "allowBlobPublicAccess": { "value": "[parameters('allowPublicAccess')]" },
+          "sku": { "value": "[if(true(), 'Standard_LRS', 'Standard_GRS')]" },
+          "secret": { "reference": { "keyVault": { "id": "kv" }, "secretName": "secret" } }
+        },
+        "template": {
+          "languageVersion": "2.0",
+          "parameters": {
+            "allowBlobPublicAccess": { "type": "bool" },
+            "sku": { "type": "string" },
+            "secret": { "type": "securestring" },
+            "kind": { "type": "string", "defaultValue": "StorageV2" }
+          },
+          "resources": {
+            "storage": {
+              "type": "Microsoft.Storage/storageAccounts",
+              "name": "sttestenv",
+              "kind": "[parameters('kind')]",
+              "sku": { "name": "[parameters('sku')]" },
+              "properties": {
+                "allowBlobPublicAccess": "[parameters('allowBlobPublicAccess')]",
+                "description": "[[literal]"
+              }
+            },
+            "vault": {
+              "existing": true,
+              "type": "Microsoft.KeyVault/vaults",
+              "name": "kv-shared"
+            }
+          }
+        }
+      }
+    },
+    {
+      "type": "Microsoft.Resources/deployments",
+      "name": "outer",
+      "properties": {
+        "template": {
+          "resources": [
+            {
+              "type": "Microsoft.Web/serverfarms",
+              "name": "plan",
+              "location": "[parameters('location')]"
+            }
+          ]
+        }
+      }
+    }
+  ]
+}`
+
+	var parsed map[string]any
+	require.NoError(t, json.Unmarshal([]byte(template), &parsed))
+
+	resources := templatePolicyResources(parsed, map[string]any{
+		"environmentName": "test-env",
+		"location":        "eastus2",
+		"secret":          policy.Unknown,
+	})
+	require.Len(t, resources, 3)
+
+	// parameters and variables are resolved, other expressions are unknown
+	require.Equal(t, "Microsoft.Resources/resourceGroups", resources[0].Type)
+	require.Equal(t, "[format('rg-{0}', parameters('environmentName'))]", resources[0].Name)
+	require.Equal(t, "eastus2", resources[0].Properties["location"])
+	require.Equal(t, map[string]any{"azd-env-name": "test-env"}, resources[0].Properties["tags"])
+
+	// resources of modules are listed with the parameters of the module, existing resources are not deployed
+	require.Equal(t, "Microsoft.Storage/storageAccounts", resources[1].Type)
+	require.Equal(t, "sttestenv", resources[1].Name)
+	require.Equal(t, "StorageV2", resources[1].Properties["kind"])
+	require.Equal(t, map[string]any{"name": policy.Unknown}, resources[1].Properties["sku"])
+	require.Equal(t, map[string]any{
+		"allowBlobPublicAccess": true,
+		"description":           "[literal]",
+	}, resources[1].Properties["properties"])
+
+	// nested templates without the inner scope are evaluated in the scope of the parent template
+	require.Equal(t, "plan", resources[2].Name)
+	require.Equal(t, "eastus2", resources[2].Properties["location"])
+}
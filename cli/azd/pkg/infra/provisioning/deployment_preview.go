@@ -4,9 +4,11 @@
 package provisioning
 
 // DeploymentPreview defines the general structure for a deployment preview regardless of the deployment provider.
+// Outputs are the outputs declared by the previewed deployment, without values, when the provider knows them.
 type DeploymentPreview struct {
 	Status     string
 	Properties *DeploymentPreviewProperties
+	Outputs    map[string]OutputParameter
 }
 
 // DeploymentPreviewProperties holds the changes for the deployment preview.
@@ -58,3 +60,56 @@ const (
 	PropertyChangeTypeModify   PropertyChangeType = "Modify"
 	PropertyChangeTypeNoEffect PropertyChangeType = "NoEffect"
 )
+
+// DriftedChanges returns the changes of the resources whose current state differs from the infrastructure, which are
+// the resources modified outside of the provisioning. Modifications where every property change has no effect are not
+// considered drift. Resources that would be created haven't been deployed yet and resources that would be deleted are
+// no longer declared by the infrastructure, neither are considered drift, see UndeployedChanges and RemovedChanges.
+func DriftedChanges(changes []*DeploymentPreviewChange) []*DeploymentPreviewChange {
+	var drifted []*DeploymentPreviewChange
+	for _, change := range changes {
+		if change.ChangeType == ChangeTypeModify && hasEffect(change.Delta) {
+			drifted = append(drifted, change)
+		}
+	}
+
+	return drifted
+}
+
+// UndeployedChanges returns the changes of the resources defined by the infrastructure that haven't been deployed yet.
+func UndeployedChanges(changes []*DeploymentPreviewChange) []*DeploymentPreviewChange {
+	return changesOfType(changes, ChangeTypeCreate)
+}
+
+// RemovedChanges returns the changes of the deployed resources that are no longer declared by the infrastructure, ex)
+// resources removed from a Terraform configuration, or from a Bicep template deployed in complete mode.
+func RemovedChanges(changes []*DeploymentPreviewChange) []*DeploymentPreviewChange {
+	return changesOfType(changes, ChangeTypeDelete)
+}
+
+func changesOfType(changes []*DeploymentPreviewChange, changeType ChangeType) []*DeploymentPreviewChange {
+	var matching []*DeploymentPreviewChange
+	for _, change := range changes {
+		if change.ChangeType == changeType {
+			matching = append(matching, change)
+		}
+	}
+
+	return matching
+}
+
+// hasEffect returns true when a property change has an effect on the resource. Changes without properties details are
+// assumed to have an effect.
+func hasEffect(delta []DeploymentPreviewPropertyChange) bool {
+	if len(delta) == 0 {
+		return true
+	}
+
+	for _, propertyChange := range delta {
+		if propertyChange.ChangeType != PropertyChangeTypeNoEffect {
+			return true
+		}
+	}
+
+	return false
+}
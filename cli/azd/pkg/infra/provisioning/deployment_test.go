@@ -52,3 +52,71 @@ func TestParameterHasValue(t *testing.T) {
 		require.True(t, actual)
 	})
 }
+
+func TestMergeStates(t *testing.T) {
+	network := &State{
+		Outputs: map[string]OutputParameter{
+			"VNET_ID":  {Type: ParameterTypeString, Value: "vnet"},
+			"LOCATION": {Type: ParameterTypeString, Value: "westus"},
+		},
+		Resources: []Resource{{Id: "network-rg"}},
+	}
+	app := &State{
+		Outputs: map[string]OutputParameter{
+			"LOCATION": {Type: ParameterTypeString, Value: "eastus"},
+		},
+		Resources: []Resource{{Id: "app-rg"}},
+	}
+
+	require.Equal(t, &State{
+		Outputs: map[string]OutputParameter{
+			"VNET_ID":  {Type: ParameterTypeString, Value: "vnet"},
+			"LOCATION": {Type: ParameterTypeString, Value: "eastus"},
+		},
+		Resources: []Resource{{Id: "network-rg"}, {Id: "app-rg"}},
+	}, MergeStates(network, app))
+}
+
+func TestDriftedChanges(t *testing.T) {
+	changes := []*DeploymentPreviewChange{
+		{ChangeType: ChangeTypeCreate, Name: "created"},
+		{ChangeType: ChangeTypeDelete, Name: "deleted"},
+		{ChangeType: ChangeTypeModify, Name: "modified"},
+		{
+			ChangeType: ChangeTypeModify,
+			Name:       "modified-properties",
+			Delta: []DeploymentPreviewPropertyChange{
+				{ChangeType: PropertyChangeTypeNoEffect, Path: "properties.provisioningState"},
+				{ChangeType: PropertyChangeTypeModify, Path: "properties.minimumTlsVersion"},
+			},
+		},
+		{
+			ChangeType: ChangeTypeModify,
+			Name:       "no-effect",
+			Delta: []DeploymentPreviewPropertyChange{
+				{ChangeType: PropertyChangeTypeNoEffect, Path: "properties.provisioningState"},
+			},
+		},
+		{ChangeType: ChangeTypeNoChange, Name: "unchanged"},
+		{ChangeType: ChangeTypeIgnore, Name: "ignored"},
+		{ChangeType: ChangeTypeDeploy, Name: "deployed"},
+	}
+
+	var names []string
+	for _, change := range DriftedChanges(changes) {
+		names = append(names, change.Name)
+	}
+
+	require.Equal(t, []string{"modified", "modified-properties"}, names)
+	require.Empty(t, DriftedChanges(changes[5:]))
+
+	// resources that haven't been deployed yet aren't drift
+	undeployed := UndeployedChanges(changes)
+	require.Len(t, undeployed, 1)
+	require.Equal(t, "created", undeployed[0].Name)
+
+	// resources removed from the infrastructure aren't drift
+	removed := RemovedChanges(changes)
+	require.Len(t, removed, 1)
+	require.Equal(t, "deleted", removed[0].Name)
+}
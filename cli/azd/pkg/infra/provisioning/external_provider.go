@@ -0,0 +1,53 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package provisioning
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ExternalProviderRegistry contains the provisioning providers that are provided at runtime
+// by extensions. Providers are registered with the id of the extension that provides them.
+type ExternalProviderRegistry struct {
+	providers sync.Map // key: ProviderKind, value: Provider
+}
+
+// NewExternalProviderRegistry creates a new empty ExternalProviderRegistry
+func NewExternalProviderRegistry() *ExternalProviderRegistry {
+	return &ExternalProviderRegistry{}
+}
+
+// Register registers the provisioning provider for the specified provider kind.
+// Built-in providers cannot be overridden and a provider kind can only be registered once.
+func (r *ExternalProviderRegistry) Register(kind ProviderKind, provider Provider) error {
+	if kind == NotSpecified {
+		return fmt.Errorf("provider kind is required")
+	}
+
+	if kind.IsBuiltIn() {
+		return fmt.Errorf("IaC provider '%s' is built into azd and cannot be overridden", kind)
+	}
+
+	if _, loaded := r.providers.LoadOrStore(kind, provider); loaded {
+		return fmt.Errorf("IaC provider '%s' has already been registered", kind)
+	}
+
+	return nil
+}
+
+// Unregister removes the provisioning provider registered for the specified provider kind
+func (r *ExternalProviderRegistry) Unregister(kind ProviderKind) {
+	r.providers.Delete(kind)
+}
+
+// Get returns the provisioning provider registered for the specified provider kind
+func (r *ExternalProviderRegistry) Get(kind ProviderKind) (Provider, bool) {
+	provider, has := r.providers.Load(kind)
+	if !has {
+		return nil, false
+	}
+
+	return provider.(Provider), true
+}
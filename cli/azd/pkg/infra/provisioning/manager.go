@@ -8,14 +8,19 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 
+	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/alpha"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
 	"github.com/azure/azure-dev/cli/azd/pkg/azsdk/storage"
 	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning/policy"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/pkg/ioc"
 	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
@@ -83,6 +88,10 @@ var AzdOperationsFeatureKey = alpha.MustFeatureKey("azd.operations")
 
 // Deploys the Azure infrastructure for the specified project
 func (m *Manager) Deploy(ctx context.Context) (*DeployResult, error) {
+	if err := m.evaluatePolicies(ctx); err != nil {
+		return nil, err
+	}
+
 	// Apply the infrastructure deployment
 	deployResult, err := m.provider.Deploy(ctx)
 	if err != nil {
@@ -103,7 +112,7 @@ func (m *Manager) Deploy(ctx context.Context) (*DeployResult, error) {
 	if !filepath.IsAbs(infraRoot) {
 		infraRoot = filepath.Join(m.projectPath, m.options.Path)
 	}
-	bindMountOperations, err := azdFileShareUploadOperations(infraRoot, *m.env)
+	bindMountOperations, err := azdFileShareUploadOperations(infraRoot, m.env)
 	azdOperationsEnabled := m.alphaFeatureManager.IsEnabled(AzdOperationsFeatureKey)
 	if !azdOperationsEnabled && len(bindMountOperations) > 0 {
 		m.console.Message(ctx, ErrBindMountOperationDisabled.Error())
@@ -113,7 +122,7 @@ func (m *Manager) Deploy(ctx context.Context) (*DeployResult, error) {
 			return nil, fmt.Errorf("looking for azd fileShare upload operations: %w", err)
 		}
 		if err := doBindMountOperation(
-			ctx, bindMountOperations, *m.env, m.console, m.fileShareService, m.cloud.StorageEndpointSuffix); err != nil {
+			ctx, bindMountOperations, m.env, m.console, m.fileShareService, m.cloud.StorageEndpointSuffix); err != nil {
 			return nil, fmt.Errorf("error running bind mount operation: %w", err)
 		}
 	}
@@ -124,6 +133,93 @@ func (m *Manager) Deploy(ctx context.Context) (*DeployResult, error) {
 	return deployResult, nil
 }
 
+// evaluatePolicies evaluates the policy rules of the infrastructure against the resources to deploy. Policy errors block
+// the deployment unless the policy is overridden.
+func (m *Manager) evaluatePolicies(ctx context.Context) error {
+	if m.options.Policies == "" {
+		return nil
+	}
+
+	policyPath := filepath.FromSlash(m.options.Policies)
+	if !filepath.IsAbs(policyPath) {
+		policyPath = filepath.Join(m.projectPath, policyPath)
+	}
+
+	rules, err := policy.LoadRules(policyPath)
+	if err != nil {
+		return fmt.Errorf("loading policy rules: %w", err)
+	}
+
+	if len(rules) == 0 {
+		log.Printf("no policy rules found in %s", policyPath)
+		return nil
+	}
+
+	resourceProvider, ok := m.provider.(PolicyResourceProvider)
+	if !ok {
+		err := fmt.Errorf("the %s provider doesn't support policy evaluation", m.provider.Name())
+		if !m.options.OverridePolicy {
+			return err
+		}
+
+		m.console.Message(ctx, output.WithWarningFormat("WARNING: %s, the policy is overridden.", err.Error()))
+		return nil
+	}
+
+	resources, err := resourceProvider.PolicyResources(ctx)
+	if err != nil {
+		return fmt.Errorf("listing resources for policy evaluation: %w", err)
+	}
+
+	evaluation := policy.Evaluate(rules, resources)
+
+	// make sure any spinner is stopped before reporting the violations
+	m.console.StopSpinner(ctx, "", input.Step)
+
+	var policyErrors int
+	for _, violation := range evaluation.Violations {
+		if violation.Rule.Severity == policy.SeverityError {
+			policyErrors++
+			m.console.Message(ctx, fmt.Sprintf("%s %s", output.WithErrorFormat("(x) Policy error"), violation.Message()))
+		} else {
+			m.console.Message(
+				ctx, fmt.Sprintf("%s %s", output.WithWarningFormat("(!) Policy warning"), violation.Message()))
+		}
+	}
+
+	// error rules that can't be evaluated before the deployment can't be enforced, they block the deployment as well
+	var unevaluatedErrors int
+	for _, unevaluated := range evaluation.Unevaluated {
+		if unevaluated.Rule.Severity == policy.SeverityError {
+			unevaluatedErrors++
+			m.console.Message(
+				ctx, fmt.Sprintf("%s %s", output.WithErrorFormat("(?) Policy not evaluated"), unevaluated.Message()))
+		} else {
+			log.Printf("policy warning not evaluated: %s", unevaluated.Message())
+		}
+	}
+
+	if policyErrors == 0 && unevaluatedErrors == 0 {
+		return nil
+	}
+
+	if m.options.OverridePolicy {
+		m.console.Message(ctx, output.WithWarningFormat(
+			"WARNING: Deploying with %d policy error(s) and %d policy rule(s) not evaluated, the policy is overridden.",
+			policyErrors, unevaluatedErrors))
+		return nil
+	}
+
+	return &internal.ErrorWithSuggestion{
+		Err: fmt.Errorf(
+			"%d policy error(s) and %d policy rule(s) not evaluated block the deployment",
+			policyErrors, unevaluatedErrors),
+		Suggestion: "Suggested Action: Update the infrastructure to comply with the policy rules in " +
+			output.WithHighLightFormat(m.options.Policies) + ", use values known before the deployment for the " +
+			"properties of the rules, or run with --override-policy to deploy anyway.",
+	}
+}
+
 const (
 	fileShareUploadOperation string = "FileShareUpload"
 	azdOperationsFileName    string = "azd.operations.yaml"
@@ -146,7 +242,7 @@ type azdOperationsModel struct {
 	Operations []azdOperation
 }
 
-func azdOperations(infraPath string, env environment.Environment) (azdOperationsModel, error) {
+func azdOperations(infraPath string, env *environment.Environment) (azdOperationsModel, error) {
 	path := filepath.Join(infraPath, azdOperationsFileName)
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -175,7 +271,7 @@ func azdOperations(infraPath string, env environment.Environment) (azdOperations
 	return operations, nil
 }
 
-func azdFileShareUploadOperations(infraPath string, env environment.Environment) ([]azdOperationFileShareUpload, error) {
+func azdFileShareUploadOperations(infraPath string, env *environment.Environment) ([]azdOperationFileShareUpload, error) {
 	model, err := azdOperations(infraPath, env)
 	if err != nil {
 		return nil, err
@@ -214,7 +310,7 @@ var ErrBindMountOperationDisabled = fmt.Errorf(
 func doBindMountOperation(
 	ctx context.Context,
 	fileShareUploadOperations []azdOperationFileShareUpload,
-	env environment.Environment,
+	env *environment.Environment,
 	console input.Console,
 	fileShareService storage.FileShareService,
 	cloudStorageEndpointSuffix string,
@@ -265,6 +361,7 @@ func (m *Manager) Preview(ctx context.Context) (*DeployPreviewResult, error) {
 		Preview: &DeploymentPreview{
 			Status:     deployResult.Preview.Status,
 			Properties: &DeploymentPreviewProperties{},
+			Outputs:    deployResult.Preview.Outputs,
 		},
 	}
 
@@ -324,6 +421,10 @@ func (m *Manager) UpdateEnvironment(
 			}
 		}
 
+		if err := m.env.AddOutputNames(slices.Collect(maps.Keys(outputs))...); err != nil {
+			return fmt.Errorf("recording output names: %w", err)
+		}
+
 		if err := m.envManager.Save(ctx, m.env); err != nil {
 			return fmt.Errorf("writing environment: %w", err)
 		}
@@ -467,8 +568,30 @@ func (m *Manager) newProvider(ctx context.Context) (Provider, error) {
 	var provider Provider
 	err = m.serviceLocator.ResolveNamed(string(providerKey), &provider)
 	if err != nil {
+		if !providerKey.IsBuiltIn() {
+			return m.externalProvider(providerKey)
+		}
+
 		return nil, fmt.Errorf("failed resolving IaC provider '%s': %w", providerKey, err)
 	}
 
 	return provider, nil
 }
+
+// externalProvider returns the provisioning provider registered by the extension with the specified id
+func (m *Manager) externalProvider(providerKey ProviderKind) (Provider, error) {
+	var registry *ExternalProviderRegistry
+	if err := m.serviceLocator.Resolve(&registry); err == nil {
+		if provider, has := registry.Get(providerKey); has {
+			return provider, nil
+		}
+	}
+
+	return nil, &internal.ErrorWithSuggestion{
+		Err: fmt.Errorf("unsupported IaC provider '%s'", providerKey),
+		Suggestion: fmt.Sprintf(
+			"Install the '%s' extension to provision with its provider, or use one of the built-in providers.",
+			providerKey,
+		),
+	}
+}
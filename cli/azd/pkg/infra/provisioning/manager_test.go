@@ -5,6 +5,8 @@ package provisioning_test
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -14,6 +16,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	azdpolicy "github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning/policy"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning/test"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/pkg/prompt"
@@ -149,6 +152,183 @@ func TestManagerDeploy(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestManagerDeployPolicy(t *testing.T) {
+	policyPath := t.TempDir()
+	rules := "rules:\n  - id: owner-tag\n    property: tags.owner\n    exists: true\n"
+	require.NoError(t, os.WriteFile(filepath.Join(policyPath, "rules.yaml"), []byte(rules), 0600))
+
+	tests := map[string]struct {
+		overridePolicy bool
+		expectedErr    string
+	}{
+		"Unsupported": {expectedErr: "the Test provider doesn't support policy evaluation"},
+		"Overridden":  {overridePolicy: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			env := environment.NewWithValues("test-env", map[string]string{
+				"AZURE_SUBSCRIPTION_ID": "SUBSCRIPTION_ID",
+				"AZURE_LOCATION":        "eastus2",
+			})
+
+			mockContext := mocks.NewMockContext(context.Background())
+			registerContainerDependencies(mockContext, env)
+
+			mgr := provisioning.NewManager(
+				mockContext.Container,
+				defaultProvider,
+				&mockenv.MockEnvManager{},
+				env,
+				mockContext.Console,
+				mockContext.AlphaFeaturesManager,
+				nil,
+				cloud.AzurePublic(),
+			)
+			err := mgr.Initialize(*mockContext.Context, "", provisioning.Options{
+				Provider:       "test",
+				Policies:       policyPath,
+				OverridePolicy: test.overridePolicy,
+			})
+			require.NoError(t, err)
+
+			deployResult, err := mgr.Deploy(*mockContext.Context)
+			if test.expectedErr != "" {
+				require.ErrorContains(t, err, test.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, deployResult)
+		})
+	}
+
+	t.Run("InvalidRules", func(t *testing.T) {
+		invalidPolicyPath := t.TempDir()
+		require.NoError(t, os.WriteFile(
+			filepath.Join(invalidPolicyPath, "rules.yaml"), []byte("rules:\n  - id: owner-tag\n"), 0600))
+
+		env := environment.NewWithValues("test-env", map[string]string{
+			"AZURE_SUBSCRIPTION_ID": "SUBSCRIPTION_ID",
+			"AZURE_LOCATION":        "eastus2",
+		})
+
+		mockContext := mocks.NewMockContext(context.Background())
+		registerContainerDependencies(mockContext, env)
+
+		mgr := provisioning.NewManager(
+			mockContext.Container,
+			defaultProvider,
+			&mockenv.MockEnvManager{},
+			env,
+			mockContext.Console,
+			mockContext.AlphaFeaturesManager,
+			nil,
+			cloud.AzurePublic(),
+		)
+		err := mgr.Initialize(*mockContext.Context, "", provisioning.Options{
+			Provider: "test",
+			Policies: invalidPolicyPath,
+		})
+		require.NoError(t, err)
+
+		_, err = mgr.Deploy(*mockContext.Context)
+		require.ErrorContains(t, err, "loading policy rules: parsing policy file")
+	})
+
+	resourceTests := map[string]struct {
+		tags           any
+		overridePolicy bool
+		expectedErr    string
+		expectedOutput string
+	}{
+		"Compliant": {tags: map[string]any{"owner": "me"}},
+		"Violation": {
+			tags:        map[string]any{},
+			expectedErr: "1 policy error(s) and 0 policy rule(s) not evaluated block the deployment",
+			expectedOutput: "owner-tag: Microsoft.Storage/storageAccounts 'st': tags.owner must be set " +
+				"(tags.owner: not set)",
+		},
+		"Unevaluated": {
+			tags:           azdpolicy.Unknown,
+			expectedErr:    "0 policy error(s) and 1 policy rule(s) not evaluated block the deployment",
+			expectedOutput: "(tags.owner: unknown until deployed)",
+		},
+		"UnevaluatedOverridden": {
+			tags:           azdpolicy.Unknown,
+			overridePolicy: true,
+			expectedOutput: "the policy is overridden",
+		},
+	}
+
+	for name, resourceTest := range resourceTests {
+		t.Run(name, func(t *testing.T) {
+			env := environment.NewWithValues("test-env", map[string]string{
+				"AZURE_SUBSCRIPTION_ID": "SUBSCRIPTION_ID",
+				"AZURE_LOCATION":        "eastus2",
+			})
+
+			mockContext := mocks.NewMockContext(context.Background())
+			registerContainerDependencies(mockContext, env)
+			mockContext.Container.MustRegisterNamedTransient("policy-test", func(
+				envManager environment.Manager,
+				env *environment.Environment,
+				console input.Console,
+				prompters prompt.Prompter,
+			) provisioning.Provider {
+				return &policyTestProvider{
+					Provider: test.NewTestProvider(envManager, env, console, prompters),
+					resources: []azdpolicy.Resource{
+						{
+							Type:       "Microsoft.Storage/storageAccounts",
+							Name:       "st",
+							Properties: map[string]any{"tags": resourceTest.tags},
+						},
+					},
+				}
+			})
+
+			mgr := provisioning.NewManager(
+				mockContext.Container,
+				defaultProvider,
+				&mockenv.MockEnvManager{},
+				env,
+				mockContext.Console,
+				mockContext.AlphaFeaturesManager,
+				nil,
+				cloud.AzurePublic(),
+			)
+			err := mgr.Initialize(*mockContext.Context, "", provisioning.Options{
+				Provider:       "policy-test",
+				Policies:       policyPath,
+				OverridePolicy: resourceTest.overridePolicy,
+			})
+			require.NoError(t, err)
+
+			_, err = mgr.Deploy(*mockContext.Context)
+			if resourceTest.expectedErr != "" {
+				require.ErrorContains(t, err, resourceTest.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			if resourceTest.expectedOutput != "" {
+				require.Contains(t, strings.Join(mockContext.Console.Output(), "\n"), resourceTest.expectedOutput)
+			}
+		})
+	}
+}
+
+// policyTestProvider is a test provider listing the resources to evaluate policy rules against.
+type policyTestProvider struct {
+	provisioning.Provider
+	resources []azdpolicy.Resource
+}
+
+func (p *policyTestProvider) PolicyResources(ctx context.Context) ([]azdpolicy.Resource, error) {
+	return p.resources, nil
+}
+
 func TestManagerDestroyWithPositiveConfirmation(t *testing.T) {
 	env := environment.NewWithValues("test-env", map[string]string{
 		"AZURE_SUBSCRIPTION_ID": "SUBSCRIPTION_ID",
@@ -222,6 +402,52 @@ func TestManagerDestroyWithNegativeConfirmation(t *testing.T) {
 	require.Contains(t, mockContext.Console.Output(), "Are you sure you want to destroy?")
 }
 
+func TestManagerExternalProvider(t *testing.T) {
+	env := environment.NewWithValues("test-env", map[string]string{
+		"AZURE_SUBSCRIPTION_ID": "SUBSCRIPTION_ID",
+		"AZURE_LOCATION":        "eastus2",
+	})
+
+	mockContext := mocks.NewMockContext(context.Background())
+	registerContainerDependencies(mockContext, env)
+
+	registry := provisioning.NewExternalProviderRegistry()
+	mockContext.Container.MustRegisterSingleton(func() *provisioning.ExternalProviderRegistry {
+		return registry
+	})
+
+	envManager := &mockenv.MockEnvManager{}
+	newManager := func() *provisioning.Manager {
+		return provisioning.NewManager(
+			mockContext.Container,
+			defaultProvider,
+			envManager,
+			env,
+			mockContext.Console,
+			mockContext.AlphaFeaturesManager,
+			nil,
+			cloud.AzurePublic(),
+		)
+	}
+
+	options := provisioning.Options{Provider: "contoso.cdktf"}
+	err := newManager().Initialize(*mockContext.Context, "", options)
+	require.ErrorContains(t, err, "unsupported IaC provider 'contoso.cdktf'")
+
+	var externalProvider provisioning.Provider
+	require.NoError(t, mockContext.Container.ResolveNamed(string(provisioning.Test), &externalProvider))
+	require.NoError(t, registry.Register("contoso.cdktf", externalProvider))
+	require.Error(t, registry.Register("contoso.cdktf", externalProvider))
+	require.Error(t, registry.Register(provisioning.Bicep, externalProvider))
+
+	mgr := newManager()
+	require.NoError(t, mgr.Initialize(*mockContext.Context, "", options))
+
+	deployResult, err := mgr.Deploy(*mockContext.Context)
+	require.NoError(t, err)
+	require.NotNil(t, deployResult)
+}
+
 func registerContainerDependencies(mockContext *mocks.MockContext, env *environment.Environment) {
 	envManager := &mockenv.MockEnvManager{}
 	envManager.On("Save", *mockContext.Context, env).Return(nil)
@@ -0,0 +1,357 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+// Package policy evaluates the resources of a deployment against the policy rules of a team, before the resources are
+// provisioned. The rules are defined in YAML files of a local policy directory:
+//
+//	rules:
+//	  - id: storage-no-public-access
+//	    description: Storage accounts must not allow public blob access
+//	    resourceTypes: [Microsoft.Storage/storageAccounts]
+//	    property: properties.allowBlobPublicAccess
+//	    equals: false
+//	  - id: owner-tag
+//	    severity: warning
+//	    property: tags.owner
+//	    exists: true
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/braydonk/yaml"
+)
+
+// Severity defines the severity of the violations of a rule.
+type Severity string
+
+const (
+	// SeverityError violations block the deployment, unless the policy is overridden.
+	SeverityError Severity = "error"
+	// SeverityWarning violations are reported without blocking the deployment.
+	SeverityWarning Severity = "warning"
+)
+
+// Rule is a condition on a property of the resources of a deployment. The conditions of a rule are combined, the
+// resource violates the rule when any of them is not met.
+type Rule struct {
+	Id          string   `yaml:"id"`
+	Description string   `yaml:"description,omitempty"`
+	Severity    Severity `yaml:"severity,omitempty"`
+	// ResourceTypes are the types of the resources the rule applies to, or all the resources when empty. Resource types
+	// are Azure resource types for Bicep, like Microsoft.Storage/storageAccounts, or resource types for Terraform, like
+	// azurerm_storage_account.
+	ResourceTypes []string `yaml:"resourceTypes,omitempty"`
+	// Property is the path of the property, with the names of the nested properties separated by dots, like
+	// properties.allowBlobPublicAccess for Bicep or tags.owner.
+	Property string `yaml:"property"`
+
+	// Equals is the value the property must have.
+	Equals any `yaml:"equals,omitempty"`
+	// NotEquals is a value the property must not have.
+	NotEquals any `yaml:"notEquals,omitempty"`
+	// In are the allowed values of the property.
+	In []any `yaml:"in,omitempty"`
+	// NotIn are the values the property must not have.
+	NotIn []any `yaml:"notIn,omitempty"`
+	// Exists defines whether the property must be set, or must not be set.
+	Exists *bool `yaml:"exists,omitempty"`
+}
+
+// policyFile is the content of a policy file.
+type policyFile struct {
+	Rules []*Rule `yaml:"rules"`
+}
+
+// unknown is the type of Unknown.
+type unknown struct{}
+
+// Unknown is the value of the properties only known once the resources are deployed, like the values computed from
+// parameters or from other resources. Rules can't be evaluated on unknown values.
+var Unknown any = unknown{}
+
+// Resource is a resource of a deployment, with the properties it is deployed with.
+type Resource struct {
+	Type string
+	Name string
+	// Properties are the values of the resource definition, where Unknown marks the values that can't be known before
+	// the deployment.
+	Properties map[string]any
+}
+
+// Violation is a resource that doesn't meet the conditions of a rule.
+type Violation struct {
+	Rule     *Rule
+	Resource Resource
+	// Value is the value of the property, nil when the property is not set, or Unknown when the rule couldn't be
+	// evaluated.
+	Value any
+}
+
+// Evaluation is the result of the evaluation of policy rules against the resources of a deployment.
+type Evaluation struct {
+	Violations []Violation
+	// Unevaluated are the rules that couldn't be evaluated against a resource, as the value of the property is only
+	// known once the resource is deployed.
+	Unevaluated []Violation
+}
+
+// Message describes the violation.
+func (v Violation) Message() string {
+	description := v.Rule.Description
+	if description == "" {
+		description = v.Rule.conditions()
+	}
+
+	value := "not set"
+	if v.Value == Unknown {
+		value = "unknown until deployed"
+	} else if v.Value != nil {
+		value = fmt.Sprintf("%v", v.Value)
+	}
+
+	return fmt.Sprintf(
+		"%s: %s '%s': %s (%s: %s)", v.Rule.Id, v.Resource.Type, v.Resource.Name, description, v.Rule.Property, value)
+}
+
+// LoadRules loads the rules of the YAML policy files, with the .yaml or .yml extension, of the policy directory.
+func LoadRules(policyPath string) ([]*Rule, error) {
+	entries, err := os.ReadDir(policyPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading policy directory: %w", err)
+	}
+
+	var rules []*Rule
+	ruleIds := map[string]bool{}
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+
+		filePath := filepath.Join(policyPath, entry.Name())
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("reading policy file: %w", err)
+		}
+
+		var file policyFile
+		if err := yaml.Unmarshal(content, &file); err != nil {
+			return nil, fmt.Errorf("parsing policy file %s: %w", filePath, err)
+		}
+
+		for _, rule := range file.Rules {
+			if err := rule.validate(); err != nil {
+				return nil, fmt.Errorf("parsing policy file %s: %w", filePath, err)
+			}
+
+			if ruleIds[rule.Id] {
+				return nil, fmt.Errorf("parsing policy file %s: duplicate rule '%s'", filePath, rule.Id)
+			}
+			ruleIds[rule.Id] = true
+
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules, nil
+}
+
+// validate ensures the rule is well defined, and applies the default severity.
+func (r *Rule) validate() error {
+	if r.Id == "" {
+		return errors.New("rule must specify an id")
+	}
+
+	if r.Property == "" {
+		return fmt.Errorf("rule '%s' must specify a property", r.Id)
+	}
+
+	if r.Equals == nil && r.NotEquals == nil && r.In == nil && r.NotIn == nil && r.Exists == nil {
+		return fmt.Errorf(
+			"rule '%s' must specify a condition: equals, notEquals, in, notIn or exists", r.Id)
+	}
+
+	switch r.Severity {
+	case "":
+		r.Severity = SeverityError
+	case SeverityError, SeverityWarning:
+	default:
+		return fmt.Errorf("rule '%s' has an invalid severity '%s', expected error or warning", r.Id, r.Severity)
+	}
+
+	return nil
+}
+
+// conditions describes the conditions of the rule.
+func (r *Rule) conditions() string {
+	var conditions []string
+	if r.Exists != nil {
+		if *r.Exists {
+			conditions = append(conditions, "must be set")
+		} else {
+			conditions = append(conditions, "must not be set")
+		}
+	}
+	if r.Equals != nil {
+		conditions = append(conditions, fmt.Sprintf("must equal %v", r.Equals))
+	}
+	if r.NotEquals != nil {
+		conditions = append(conditions, fmt.Sprintf("must not equal %v", r.NotEquals))
+	}
+	if r.In != nil {
+		conditions = append(conditions, fmt.Sprintf("must be one of %v", r.In))
+	}
+	if r.NotIn != nil {
+		conditions = append(conditions, fmt.Sprintf("must not be one of %v", r.NotIn))
+	}
+
+	return fmt.Sprintf("%s %s", r.Property, strings.Join(conditions, " and "))
+}
+
+// appliesTo returns true when the rule applies to the type of the resource.
+func (r *Rule) appliesTo(resource Resource) bool {
+	if len(r.ResourceTypes) == 0 {
+		return true
+	}
+
+	return slices.ContainsFunc(r.ResourceTypes, func(resourceType string) bool {
+		return strings.EqualFold(resourceType, resource.Type)
+	})
+}
+
+// Evaluate evaluates the rules against the resources, returning the violations of the rules and the rules that couldn't
+// be evaluated.
+func Evaluate(rules []*Rule, resources []Resource) *Evaluation {
+	evaluation := &Evaluation{}
+	for _, resource := range resources {
+		for _, rule := range rules {
+			if !rule.appliesTo(resource) {
+				continue
+			}
+
+			value, found := propertyValue(resource.Properties, rule.Property)
+			if value == Unknown {
+				evaluation.Unevaluated = append(evaluation.Unevaluated, Violation{
+					Rule:     rule,
+					Resource: resource,
+					Value:    Unknown,
+				})
+				continue
+			}
+
+			if rule.violatedBy(value, found) {
+				evaluation.Violations = append(evaluation.Violations, Violation{
+					Rule:     rule,
+					Resource: resource,
+					Value:    value,
+				})
+			}
+		}
+	}
+
+	return evaluation
+}
+
+// violatedBy returns true when the value of the property doesn't meet the conditions of the rule.
+func (r *Rule) violatedBy(value any, found bool) bool {
+	if r.Exists != nil && *r.Exists != found {
+		return true
+	}
+
+	if r.Equals != nil && (!found || !equalValues(value, r.Equals)) {
+		return true
+	}
+
+	if r.NotEquals != nil && found && equalValues(value, r.NotEquals) {
+		return true
+	}
+
+	if r.In != nil && (!found || !containsValue(r.In, value)) {
+		return true
+	}
+
+	if r.NotIn != nil && found && containsValue(r.NotIn, value) {
+		return true
+	}
+
+	return false
+}
+
+// propertyValue returns the value of the property at the path. The value is Unknown when the property, or one of its
+// parents, is unknown.
+func propertyValue(properties map[string]any, path string) (any, bool) {
+	var value any = properties
+	for _, name := range strings.Split(path, ".") {
+		switch current := value.(type) {
+		case unknown:
+			return Unknown, false
+		case map[string]any:
+			property, has := current[name]
+			if !has {
+				return nil, false
+			}
+
+			value = property
+		case []any:
+			index, err := strconv.Atoi(name)
+			if err != nil || index < 0 || index >= len(current) {
+				return nil, false
+			}
+
+			value = current[index]
+		default:
+			return nil, false
+		}
+	}
+
+	if value == nil {
+		return nil, false
+	}
+
+	return value, true
+}
+
+// equalValues compares a property value with a value of a rule. Strings are compared ignoring case, like Azure resource
+// properties, and numbers are compared regardless of their type.
+func equalValues(value any, expected any) bool {
+	if valueString, ok := value.(string); ok {
+		expectedString, ok := expected.(string)
+		return ok && strings.EqualFold(valueString, expectedString)
+	}
+
+	if valueNumber, ok := toFloat(value); ok {
+		expectedNumber, ok := toFloat(expected)
+		return ok && valueNumber == expectedNumber
+	}
+
+	return reflect.DeepEqual(value, expected)
+}
+
+func containsValue(values []any, value any) bool {
+	return slices.ContainsFunc(values, func(expected any) bool {
+		return equalValues(value, expected)
+	})
+}
+
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
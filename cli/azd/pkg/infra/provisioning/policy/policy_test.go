@@ -0,0 +1,183 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testRules = `rules:
+  - id: storage-no-public-access
+    description: Storage accounts must not allow public blob access
+    resourceTypes: [Microsoft.Storage/storageAccounts]
+    property: properties.allowBlobPublicAccess
+    equals: false
+  - id: allowed-skus
+    resourceTypes: [Microsoft.Web/serverfarms]
+    property: sku.name
+    in: [B1, P1v3]
+  - id: owner-tag
+    severity: warning
+    property: tags.owner
+    exists: true
+`
+
+func TestLoadRules(t *testing.T) {
+	policyPath := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(policyPath, "rules.yaml"), []byte(testRules), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(policyPath, "README.md"), []byte("# Policies"), 0600))
+
+	rules, err := LoadRules(policyPath)
+	require.NoError(t, err)
+	require.Len(t, rules, 3)
+
+	require.Equal(t, "storage-no-public-access", rules[0].Id)
+	require.Equal(t, SeverityError, rules[0].Severity)
+	require.Equal(t, false, rules[0].Equals)
+	require.Equal(t, []any{"B1", "P1v3"}, rules[1].In)
+	require.Equal(t, SeverityWarning, rules[2].Severity)
+	require.True(t, *rules[2].Exists)
+
+	t.Run("Invalid", func(t *testing.T) {
+		invalidRules := map[string]string{
+			"rules:\n  - property: sku.name\n    equals: B1\n": "rule must specify an id",
+			"rules:\n  - id: sku\n    equals: B1\n":            "rule 'sku' must specify a property",
+			"rules:\n  - id: sku\n    property: sku.name\n":    "rule 'sku' must specify a condition",
+			"rules:\n  - id: sku\n    property: sku.name\n    equals: B1\n" +
+				"    severity: info\n": "rule 'sku' has an invalid severity 'info'",
+			"rules:\n  - id: sku\n    property: sku.name\n    equals: B1\n" +
+				"  - id: sku\n    property: sku.tier\n    equals: Basic\n": "duplicate rule 'sku'",
+		}
+
+		for content, expectedErr := range invalidRules {
+			policyPath := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(policyPath, "rules.yml"), []byte(content), 0600))
+
+			_, err := LoadRules(policyPath)
+			require.ErrorContains(t, err, expectedErr)
+		}
+	})
+}
+
+func TestEvaluate(t *testing.T) {
+	policyPath := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(policyPath, "rules.yaml"), []byte(testRules), 0600))
+
+	rules, err := LoadRules(policyPath)
+	require.NoError(t, err)
+
+	resources := []Resource{
+		{
+			Type: "Microsoft.Storage/storageAccounts",
+			Name: "stpublic",
+			Properties: map[string]any{
+				"properties": map[string]any{"allowBlobPublicAccess": true},
+				"tags":       map[string]any{"owner": "me"},
+			},
+		},
+		{
+			Type: "Microsoft.Storage/storageAccounts",
+			Name: "stprivate",
+			Properties: map[string]any{
+				"properties": map[string]any{"allowBlobPublicAccess": false},
+				"tags":       map[string]any{"owner": "me"},
+			},
+		},
+		{
+			// values computed during the deployment can't be evaluated
+			Type: "microsoft.storage/storageaccounts",
+			Name: "stcomputed",
+			Properties: map[string]any{
+				"properties": map[string]any{"allowBlobPublicAccess": Unknown},
+				"tags":       Unknown,
+			},
+		},
+		{
+			Type: "Microsoft.Web/serverfarms",
+			Name: "plan",
+			Properties: map[string]any{
+				"sku":  map[string]any{"name": "p1v3"},
+				"tags": map[string]any{"owner": "me"},
+			},
+		},
+		{
+			Type: "Microsoft.Web/serverfarms",
+			Name: "plan-premium",
+			Properties: map[string]any{
+				"sku": map[string]any{"name": "P3v3"},
+			},
+		},
+	}
+
+	evaluation := Evaluate(rules, resources)
+	violations := evaluation.Violations
+
+	var messages []string
+	for _, violation := range violations {
+		messages = append(messages, violation.Message())
+	}
+
+	require.Equal(t, []string{
+		"storage-no-public-access: Microsoft.Storage/storageAccounts 'stpublic': Storage accounts must not allow " +
+			"public blob access (properties.allowBlobPublicAccess: true)",
+		"allowed-skus: Microsoft.Web/serverfarms 'plan-premium': sku.name must be one of [B1 P1v3] (sku.name: P3v3)",
+		"owner-tag: Microsoft.Web/serverfarms 'plan-premium': tags.owner must be set (tags.owner: not set)",
+	}, messages)
+	require.Equal(t, SeverityWarning, violations[2].Rule.Severity)
+
+	var unevaluated []string
+	for _, violation := range evaluation.Unevaluated {
+		unevaluated = append(unevaluated, violation.Message())
+	}
+
+	require.Equal(t, []string{
+		"storage-no-public-access: microsoft.storage/storageaccounts 'stcomputed': Storage accounts must not allow " +
+			"public blob access (properties.allowBlobPublicAccess: unknown until deployed)",
+		"owner-tag: microsoft.storage/storageaccounts 'stcomputed': tags.owner must be set (tags.owner: unknown until " +
+			"deployed)",
+	}, unevaluated)
+}
+
+func TestEvaluateConditions(t *testing.T) {
+	resource := Resource{
+		Type: "azurerm_storage_account",
+		Name: "st",
+		Properties: map[string]any{
+			"min_tls_version":          "TLS1_0",
+			"account_replication_type": "LRS",
+			"network_rules":            []any{map[string]any{"default_action": "Allow"}},
+			"retention_in_days":        float64(30),
+		},
+	}
+
+	notSet := false
+	tests := map[string]struct {
+		rule     Rule
+		violated bool
+	}{
+		"NotEquals":     {Rule{Property: "min_tls_version", NotEquals: "tls1_0"}, true},
+		"NotIn":         {Rule{Property: "account_replication_type", NotIn: []any{"GRS"}}, false},
+		"NotInMissing":  {Rule{Property: "missing", NotIn: []any{"GRS"}}, false},
+		"EqualsMissing": {Rule{Property: "missing", Equals: "value"}, true},
+		"ExistsFalse":   {Rule{Property: "min_tls_version", Exists: &notSet}, true},
+		"ArrayIndex":    {Rule{Property: "network_rules.0.default_action", Equals: "Deny"}, true},
+		"Number":        {Rule{Property: "retention_in_days", In: []any{30, 90}}, false},
+		"OtherResourceType": {
+			Rule{Property: "min_tls_version", Equals: "TLS1_2", ResourceTypes: []string{"azurerm_key_vault"}},
+			false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.rule.Id = name
+			evaluation := Evaluate([]*Rule{&test.rule}, []Resource{resource})
+			require.Equal(t, test.violated, len(evaluation.Violations) > 0)
+		})
+	}
+}
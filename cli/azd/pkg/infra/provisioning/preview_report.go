@@ -0,0 +1,395 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package provisioning
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/resources"
+)
+
+// PreviewFormat defines the format of the report of a provisioning preview.
+type PreviewFormat string
+
+const (
+	// PreviewFormatMarkdown renders the report as GitHub flavored markdown, ready to be posted as a PR comment.
+	PreviewFormatMarkdown PreviewFormat = "markdown"
+	// PreviewFormatHtml renders the report as an HTML fragment.
+	PreviewFormatHtml PreviewFormat = "html"
+	// PreviewFormatSarif renders the report as a SARIF 2.1.0 log, where each change is a result.
+	PreviewFormatSarif PreviewFormat = "sarif"
+)
+
+// PreviewFormats are the supported formats of the report of a provisioning preview.
+var PreviewFormats = []PreviewFormat{PreviewFormatMarkdown, PreviewFormatHtml, PreviewFormatSarif}
+
+// ParsePreviewFormat returns the preview format with the specified name.
+func ParsePreviewFormat(value string) (PreviewFormat, error) {
+	format := PreviewFormat(strings.ToLower(value))
+	if !slices.Contains(PreviewFormats, format) {
+		names := make([]string, len(PreviewFormats))
+		for i, format := range PreviewFormats {
+			names[i] = string(format)
+		}
+
+		return "", fmt.Errorf(
+			"unsupported preview format '%s', supported formats: %s", value, strings.Join(names, ", "))
+	}
+
+	return format, nil
+}
+
+// WritePreviewReport writes the report of the changes of a provisioning preview in the specified format. The report
+// groups the changes by resource type, with a summary of the changes and the property changes of each resource.
+func WritePreviewReport(writer io.Writer, format PreviewFormat, preview *DeploymentPreview) error {
+	report := newPreviewReport(preview)
+
+	switch format {
+	case PreviewFormatMarkdown:
+		return report.writeMarkdown(writer)
+	case PreviewFormatHtml:
+		return previewReportHtmlTemplate.Execute(writer, report)
+	case PreviewFormatSarif:
+		return report.writeSarif(writer)
+	default:
+		return fmt.Errorf("unsupported preview format '%s'", format)
+	}
+}
+
+// changeTypesOrder is the order of the change types in the summary of a report
+var changeTypesOrder = []ChangeType{
+	ChangeTypeCreate,
+	ChangeTypeModify,
+	ChangeTypeDelete,
+	ChangeTypeDeploy,
+	ChangeTypeUnsupported,
+	ChangeTypeNoChange,
+	ChangeTypeIgnore,
+}
+
+// previewReport is the preview changes, grouped for reporting.
+type previewReport struct {
+	Summary []previewReportCount
+	Total   int
+	Groups  []previewReportGroup
+}
+
+// previewReportCount is the number of resources with a type of change.
+type previewReportCount struct {
+	ChangeType ChangeType
+	Count      int
+}
+
+// previewReportGroup is the changes to the resources of a resource type.
+type previewReportGroup struct {
+	ResourceType string
+	Changes      []previewReportChange
+}
+
+// previewReportChange is the change to a resource, with its property changes flattened.
+type previewReportChange struct {
+	ChangeType ChangeType
+	Name       string
+	ResourceId string
+	Properties []previewReportProperty
+}
+
+// previewReportProperty is the change to a property of a resource.
+type previewReportProperty struct {
+	Path       string             `json:"path"`
+	ChangeType PropertyChangeType `json:"changeType"`
+	Before     string             `json:"before,omitempty"`
+	After      string             `json:"after,omitempty"`
+}
+
+func newPreviewReport(preview *DeploymentPreview) *previewReport {
+	report := &previewReport{}
+	if preview == nil || preview.Properties == nil {
+		return report
+	}
+
+	counts := map[ChangeType]int{}
+	groups := map[string]*previewReportGroup{}
+	for _, change := range preview.Properties.Changes {
+		counts[change.ChangeType]++
+		report.Total++
+
+		group, has := groups[change.ResourceType]
+		if !has {
+			group = &previewReportGroup{ResourceType: change.ResourceType}
+			groups[change.ResourceType] = group
+		}
+
+		group.Changes = append(group.Changes, previewReportChange{
+			ChangeType: change.ChangeType,
+			Name:       change.Name,
+			ResourceId: change.ResourceId.Id,
+			Properties: flattenPropertyChanges("", change.Delta),
+		})
+	}
+
+	for _, changeType := range changeTypesOrder {
+		if count := counts[changeType]; count > 0 {
+			report.Summary = append(report.Summary, previewReportCount{ChangeType: changeType, Count: count})
+		}
+	}
+
+	for _, group := range groups {
+		report.Groups = append(report.Groups, *group)
+	}
+
+	slices.SortFunc(report.Groups, func(x, y previewReportGroup) int {
+		return strings.Compare(x.ResourceType, y.ResourceType)
+	})
+
+	return report
+}
+
+// flattenPropertyChanges returns the property changes without nested changes. The path of a nested change is joined to
+// the path of its parent.
+func flattenPropertyChanges(parentPath string, changes []DeploymentPreviewPropertyChange) []previewReportProperty {
+	var properties []previewReportProperty
+	for _, change := range changes {
+		path := change.Path
+		if parentPath != "" {
+			path = parentPath + "." + change.Path
+		}
+
+		if len(change.Children) > 0 {
+			properties = append(properties, flattenPropertyChanges(path, change.Children)...)
+			continue
+		}
+
+		properties = append(properties, previewReportProperty{
+			Path:       path,
+			ChangeType: change.ChangeType,
+			Before:     formatPreviewValue(change.Before),
+			After:      formatPreviewValue(change.After),
+		})
+	}
+
+	return properties
+}
+
+// formatPreviewValue returns the text of a property value, where values other than strings are formatted as JSON.
+func formatPreviewValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		formatted, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+
+		return string(formatted)
+	}
+}
+
+func (r *previewReport) writeMarkdown(writer io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString("## Azure resource changes\n\n")
+
+	if r.Total == 0 {
+		sb.WriteString("No changes to Azure resources.\n")
+		_, err := io.WriteString(writer, sb.String())
+		return err
+	}
+
+	sb.WriteString("| Change | Resources |\n| --- | --- |\n")
+	for _, count := range r.Summary {
+		sb.WriteString(fmt.Sprintf("| %s | %d |\n", count.ChangeType, count.Count))
+	}
+	sb.WriteString(fmt.Sprintf("| **Total** | **%d** |\n", r.Total))
+
+	for _, group := range r.Groups {
+		sb.WriteString(fmt.Sprintf("\n### %s\n\n", markdownCell(group.ResourceType)))
+		sb.WriteString("| Change | Name |\n| --- | --- |\n")
+		for _, change := range group.Changes {
+			sb.WriteString(fmt.Sprintf("| %s | %s |\n", change.ChangeType, markdownCell(change.Name)))
+		}
+
+		for _, change := range group.Changes {
+			if len(change.Properties) == 0 {
+				continue
+			}
+
+			sb.WriteString(fmt.Sprintf(
+				"\n<details>\n<summary>%s %s</summary>\n\n",
+				change.ChangeType, template.HTMLEscapeString(change.Name)))
+			sb.WriteString("| Property | Change | Before | After |\n| --- | --- | --- | --- |\n")
+			for _, property := range change.Properties {
+				sb.WriteString(fmt.Sprintf(
+					"| %s | %s | %s | %s |\n",
+					markdownCode(property.Path),
+					property.ChangeType,
+					markdownCode(property.Before),
+					markdownCode(property.After),
+				))
+			}
+			sb.WriteString("\n</details>\n")
+		}
+	}
+
+	_, err := io.WriteString(writer, sb.String())
+	return err
+}
+
+// markdownCell escapes the text of a markdown table cell.
+func markdownCell(text string) string {
+	text = strings.ReplaceAll(text, "|", "\\|")
+	return strings.ReplaceAll(text, "\n", " ")
+}
+
+// markdownCode formats the text of a markdown table cell as inline code.
+func markdownCode(text string) string {
+	if text == "" {
+		return ""
+	}
+
+	text = markdownCell(text)
+	if strings.Contains(text, "`") {
+		return "`` " + text + " ``"
+	}
+
+	return "`" + text + "`"
+}
+
+var previewReportHtmlTemplate = template.Must(
+	template.New("preview_report").Parse(string(resources.PreviewReportHtml)))
+
+// sarifLog is the subset of the SARIF 2.1.0 log format used by the report of a preview.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationUri string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	Id               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleId     string          `json:"ruleId"`
+	Level      string          `json:"level"`
+	Message    sarifMessage    `json:"message"`
+	Locations  []sarifLocation `json:"locations"`
+	Properties map[string]any  `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName,omitempty"`
+	Kind               string `json:"kind"`
+}
+
+// sarifRules are the SARIF rules of the changes reported as results. Resources without changes are not reported.
+var sarifRules = []struct {
+	changeType  ChangeType
+	description string
+	level       string
+}{
+	{ChangeTypeCreate, "The resource is created.", "note"},
+	{ChangeTypeModify, "The resource is modified.", "note"},
+	{ChangeTypeDelete, "The resource is deleted.", "warning"},
+	{ChangeTypeDeploy, "The resource is deployed, the changes can't be predicted.", "note"},
+	{ChangeTypeUnsupported, "The changes to the resource can't be previewed.", "warning"},
+}
+
+func (r *previewReport) writeSarif(writer io.Writer) error {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           "azd",
+				InformationUri: "https://aka.ms/azd",
+			},
+		},
+		Results: []sarifResult{},
+	}
+
+	levels := map[ChangeType]string{}
+	for _, rule := range sarifRules {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			Id:               string(rule.changeType),
+			ShortDescription: sarifMessage{Text: rule.description},
+		})
+		levels[rule.changeType] = rule.level
+	}
+
+	for _, group := range r.Groups {
+		for _, change := range group.Changes {
+			level, has := levels[change.ChangeType]
+			if !has {
+				continue
+			}
+
+			result := sarifResult{
+				RuleId: string(change.ChangeType),
+				Level:  level,
+				Message: sarifMessage{
+					Text: fmt.Sprintf("%s %s %s", change.ChangeType, group.ResourceType, change.Name),
+				},
+				Locations: []sarifLocation{
+					{
+						LogicalLocations: []sarifLogicalLocation{
+							{
+								Name:               change.Name,
+								FullyQualifiedName: change.ResourceId,
+								Kind:               "resource",
+							},
+						},
+					},
+				},
+				Properties: map[string]any{
+					"resourceType": group.ResourceType,
+				},
+			}
+
+			if len(change.Properties) > 0 {
+				result.Properties["propertyChanges"] = change.Properties
+			}
+
+			run.Results = append(run.Results, result)
+		}
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
@@ -0,0 +1,115 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package provisioning
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/test/snapshot"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWritePreviewReport(t *testing.T) {
+	preview := &DeploymentPreview{
+		Status: "done",
+		Properties: &DeploymentPreviewProperties{
+			Changes: []*DeploymentPreviewChange{
+				{
+					ChangeType:   ChangeTypeModify,
+					ResourceType: "Storage account",
+					Name:         "sttestenv",
+					ResourceId: Resource{
+						Id: "/subscriptions/SUBSCRIPTION_ID/resourceGroups/rg-test-env/providers/" +
+							"Microsoft.Storage/storageAccounts/sttestenv",
+					},
+					Delta: []DeploymentPreviewPropertyChange{
+						{
+							ChangeType: PropertyChangeTypeModify,
+							Path:       "properties.minimumTlsVersion",
+							Before:     "TLS1_0",
+							After:      "TLS1_2",
+						},
+						{
+							ChangeType: PropertyChangeTypeModify,
+							Path:       "tags",
+							Children: []DeploymentPreviewPropertyChange{
+								{ChangeType: PropertyChangeTypeDelete, Path: "owner", Before: "me|you"},
+								{ChangeType: PropertyChangeTypeCreate, Path: "team", After: "<azd>"},
+							},
+						},
+						{
+							ChangeType: PropertyChangeTypeArray,
+							Path:       "properties.networkAcls.ipRules",
+							Before:     []any{},
+							After:      []any{map[string]any{"value": "10.0.0.0/24"}},
+						},
+					},
+				},
+				{
+					ChangeType:   ChangeTypeCreate,
+					ResourceType: "Container app",
+					Name:         "ca-api",
+					ResourceId: Resource{
+						Id: "/subscriptions/SUBSCRIPTION_ID/resourceGroups/rg-test-env/providers/" +
+							"Microsoft.App/containerApps/ca-api",
+					},
+				},
+				{
+					ChangeType:   ChangeTypeCreate,
+					ResourceType: "Container app",
+					Name:         "ca-web",
+				},
+				{
+					ChangeType:   ChangeTypeNoChange,
+					ResourceType: "Log Analytics workspace",
+					Name:         "log-test-env",
+				},
+			},
+		},
+	}
+
+	for _, format := range PreviewFormats {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, WritePreviewReport(&buf, format, preview))
+			snapshot.SnapshotT(t, buf.String())
+		})
+	}
+
+	t.Run("SarifResults", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, WritePreviewReport(&buf, PreviewFormatSarif, preview))
+
+		var log sarifLog
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+		require.Equal(t, "2.1.0", log.Version)
+		require.Len(t, log.Runs, 1)
+
+		// resources without changes are not reported
+		var ruleIds []string
+		for _, result := range log.Runs[0].Results {
+			ruleIds = append(ruleIds, result.RuleId)
+		}
+		require.Equal(t, []string{"Create", "Create", "Modify"}, ruleIds)
+	})
+
+	t.Run("NoChanges", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, WritePreviewReport(&buf, PreviewFormatMarkdown, &DeploymentPreview{
+			Properties: &DeploymentPreviewProperties{},
+		}))
+		require.Equal(t, "## Azure resource changes\n\nNo changes to Azure resources.\n", buf.String())
+	})
+}
+
+func TestParsePreviewFormat(t *testing.T) {
+	format, err := ParsePreviewFormat("Markdown")
+	require.NoError(t, err)
+	require.Equal(t, PreviewFormatMarkdown, format)
+
+	_, err = ParsePreviewFormat("pdf")
+	require.ErrorContains(t, err, "unsupported preview format 'pdf', supported formats: markdown, html, sarif")
+}
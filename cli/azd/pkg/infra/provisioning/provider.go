@@ -5,6 +5,10 @@ package provisioning
 
 import (
 	"context"
+	"fmt"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning/policy"
 )
 
 type ProviderKind string
@@ -18,13 +22,87 @@ const (
 	Test         ProviderKind = "test"
 )
 
+// IsBuiltIn returns true if the provider kind is implemented by azd.
+// Other providers may be provided by extensions, in which case the provider kind is the id of the extension.
+func (pk ProviderKind) IsBuiltIn() bool {
+	switch pk {
+	case NotSpecified, Bicep, Arm, Terraform, Pulumi, Test:
+		return true
+	}
+
+	return false
+}
+
 type Options struct {
+	// Name of the layer, when the options configure one of the layers of the infrastructure.
+	Name             string         `yaml:"name,omitempty"`
 	Provider         ProviderKind   `yaml:"provider,omitempty"`
 	Path             string         `yaml:"path,omitempty"`
 	Module           string         `yaml:"module,omitempty"`
 	DeploymentStacks map[string]any `yaml:"deploymentStacks,omitempty"`
+	// ResourceGroup is the resource group targeted by resource group scoped deployments of the layer, instead of the
+	// AZURE_RESOURCE_GROUP environment variable. Supports environment variable substitution.
+	ResourceGroup string `yaml:"resourceGroup,omitempty"`
+	// Layers are the infrastructure layers provisioned in order, each with its own deployment. The outputs of a layer
+	// are stored in the environment, where the parameters of the following layers can reference them.
+	Layers []Options `yaml:"layers,omitempty"`
+	// Policies is the path of the directory with the policy rules evaluated against the resources before they are
+	// deployed, relative to the project.
+	Policies string `yaml:"policies,omitempty"`
 	// Not expected to be defined at azure.yaml
 	IgnoreDeploymentState bool `yaml:"-"`
+	// CheckDrift makes the preview compare the infrastructure with the current state of the resources, to detect the
+	// resources changed outside of the provisioning. Not expected to be defined at azure.yaml
+	CheckDrift bool `yaml:"-"`
+	// OverridePolicy deploys the resources regardless of the policy violations. Not expected to be defined at azure.yaml
+	OverridePolicy bool `yaml:"-"`
+}
+
+// GetLayers returns the layers of the infrastructure in provisioning order. When no layers are defined, the options
+// are the only layer.
+func (o Options) GetLayers() []Options {
+	if len(o.Layers) == 0 {
+		return []Options{o}
+	}
+
+	layers := make([]Options, len(o.Layers))
+	for i, layer := range o.Layers {
+		if layer.Provider == NotSpecified {
+			layer.Provider = o.Provider
+		}
+		if layer.Policies == "" {
+			layer.Policies = o.Policies
+		}
+		layer.IgnoreDeploymentState = o.IgnoreDeploymentState
+		layer.CheckDrift = o.CheckDrift
+		layer.OverridePolicy = o.OverridePolicy
+		layers[i] = layer
+	}
+
+	return layers
+}
+
+// GetLayer returns the layer with the specified name.
+func (o Options) GetLayer(name string) (Options, error) {
+	layers := o.GetLayers()
+	for _, layer := range layers {
+		if layer.Name == name {
+			return layer, nil
+		}
+	}
+
+	names := make([]string, 0, len(layers))
+	for _, layer := range layers {
+		if layer.Name != "" {
+			names = append(names, layer.Name)
+		}
+	}
+
+	if len(names) == 0 {
+		return Options{}, fmt.Errorf("layer '%s' not found, the infrastructure has no layers", name)
+	}
+
+	return Options{}, fmt.Errorf("layer '%s' not found, available layers: %s", name, strings.Join(names, ", "))
 }
 
 type SkippedReasonType string
@@ -60,3 +138,9 @@ type Provider interface {
 	Destroy(ctx context.Context, options DestroyOptions) (*DestroyResult, error)
 	EnsureEnv(ctx context.Context) error
 }
+
+// PolicyResourceProvider is implemented by the providers that support policy evaluation, to list the resources of the
+// deployment, with the properties they are deployed with, before they are deployed.
+type PolicyResourceProvider interface {
+	PolicyResources(ctx context.Context) ([]policy.Resource, error)
+}
@@ -0,0 +1,64 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package provisioning
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptionsGetLayers(t *testing.T) {
+	t.Run("NoLayers", func(t *testing.T) {
+		options := Options{Provider: Bicep, Path: "infra", Module: "main"}
+
+		require.Equal(t, []Options{options}, options.GetLayers())
+
+		_, err := options.GetLayer("app")
+		require.ErrorContains(t, err, "layer 'app' not found, the infrastructure has no layers")
+	})
+
+	t.Run("Layers", func(t *testing.T) {
+		options := Options{
+			Provider:              Terraform,
+			IgnoreDeploymentState: true,
+			CheckDrift:            true,
+			OverridePolicy:        true,
+			Policies:              "policies",
+			Layers: []Options{
+				{Name: "network", Path: "infra/network"},
+				{Name: "app", Path: "infra/app", Provider: Bicep, Policies: "infra/app/policies"},
+			},
+		}
+
+		layers := options.GetLayers()
+		require.Equal(t, []Options{
+			{
+				Name:                  "network",
+				Path:                  "infra/network",
+				Provider:              Terraform,
+				Policies:              "policies",
+				IgnoreDeploymentState: true,
+				CheckDrift:            true,
+				OverridePolicy:        true,
+			},
+			{
+				Name:                  "app",
+				Path:                  "infra/app",
+				Provider:              Bicep,
+				Policies:              "infra/app/policies",
+				IgnoreDeploymentState: true,
+				CheckDrift:            true,
+				OverridePolicy:        true,
+			},
+		}, layers)
+
+		layer, err := options.GetLayer("app")
+		require.NoError(t, err)
+		require.Equal(t, layers[1], layer)
+
+		_, err = options.GetLayer("data")
+		require.ErrorContains(t, err, "layer 'data' not found, available layers: network, app")
+	})
+}
@@ -5,6 +5,7 @@ package provisioning
 
 import (
 	"fmt"
+	"maps"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
 )
@@ -50,15 +51,35 @@ func NewEnvRefreshResultFromState(state *State) contracts.EnvRefreshResult {
 	return result
 }
 
+// MergeStates combines the states of the layers of the infrastructure. Outputs of the latest layers take precedence.
+func MergeStates(states ...*State) *State {
+	merged := &State{
+		Outputs:   map[string]OutputParameter{},
+		Resources: []Resource{},
+	}
+
+	for _, state := range states {
+		maps.Copy(merged.Outputs, state.Outputs)
+		merged.Resources = append(merged.Resources, state.Resources...)
+	}
+
+	return merged
+}
+
 // Parses the specified IaC Provider to ensure whether it is valid or not
 // Defaults to `Bicep` if no provider is specified
 func ParseProvider(kind ProviderKind) (ProviderKind, error) {
 	switch kind {
 	// For the time being we need to include `Test` here for the unit tests to work as expected
 	// App builds will pass this test but fail resolving the provider since `Test` won't be registered in the container
-	case NotSpecified, Bicep, Terraform, Test:
+	case NotSpecified, Bicep, Terraform, Pulumi, Test:
 		return kind, nil
 	}
 
-	return ProviderKind(""), fmt.Errorf("unsupported IaC provider '%s'", kind)
+	if kind.IsBuiltIn() {
+		return ProviderKind(""), fmt.Errorf("unsupported IaC provider '%s'", kind)
+	}
+
+	// Providers that are not built-in may be provided by extensions and are validated when the provider is resolved
+	return kind, nil
 }
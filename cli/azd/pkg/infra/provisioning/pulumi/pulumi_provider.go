@@ -0,0 +1,719 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package pulumi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
+	"github.com/azure/azure-dev/cli/azd/pkg/prompt"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/pulumi"
+	"github.com/braydonk/yaml"
+	"github.com/drone/envsubst"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	defaultModule = "main"
+	defaultPath   = "infra"
+
+	// BackendUrlEnvVarName is the name of the environment variable used to configure the pulumi state backend.
+	// When not set in either the azd environment or the process environment, a local file based backend stored
+	// within the .azure/<env> directory is used.
+	BackendUrlEnvVarName = "PULUMI_BACKEND_URL"
+
+	configPassphraseEnvVarName     = "PULUMI_CONFIG_PASSPHRASE"
+	configPassphraseFileEnvVarName = "PULUMI_CONFIG_PASSPHRASE_FILE"
+)
+
+// PulumiProvider exposes infrastructure provisioning using Pulumi programs
+type PulumiProvider struct {
+	envManager   environment.Manager
+	env          *environment.Environment
+	prompters    prompt.Prompter
+	console      input.Console
+	cli          *pulumi.Cli
+	curPrincipal provisioning.CurrentPrincipalIdProvider
+	projectPath  string
+	options      provisioning.Options
+}
+
+// Name gets the name of the infra provider
+func (p *PulumiProvider) Name() string {
+	return "Pulumi"
+}
+
+func (p *PulumiProvider) RequiredExternalTools() []tools.ExternalTool {
+	return []tools.ExternalTool{p.cli}
+}
+
+// NewPulumiProvider creates a new instance of a Pulumi Infra provider
+func NewPulumiProvider(
+	cli *pulumi.Cli,
+	envManager environment.Manager,
+	env *environment.Environment,
+	console input.Console,
+	curPrincipal provisioning.CurrentPrincipalIdProvider,
+	prompters prompt.Prompter,
+) provisioning.Provider {
+	return &PulumiProvider{
+		envManager:   envManager,
+		env:          env,
+		console:      console,
+		cli:          cli,
+		curPrincipal: curPrincipal,
+		prompters:    prompters,
+	}
+}
+
+func (p *PulumiProvider) Initialize(ctx context.Context, projectPath string, options provisioning.Options) error {
+	p.projectPath = projectPath
+	p.options = options
+	if p.options.Module == "" {
+		p.options.Module = defaultModule
+	}
+	if p.options.Path == "" {
+		p.options.Path = defaultPath
+	}
+
+	requiredTools := p.RequiredExternalTools()
+	if err := tools.EnsureInstalled(ctx, requiredTools...); err != nil {
+		return err
+	}
+
+	if err := p.EnsureEnv(ctx); err != nil {
+		return err
+	}
+
+	return p.configureCliEnv(ctx)
+}
+
+// EnsureEnv ensures that the environment is in a provision-ready state with required values set, prompting the user if
+// values are unset.
+//
+// An environment is considered to be in a provision-ready state if it contains both an AZURE_SUBSCRIPTION_ID and
+// AZURE_LOCATION value.
+func (p *PulumiProvider) EnsureEnv(ctx context.Context) error {
+	return provisioning.EnsureSubscriptionAndLocation(
+		ctx,
+		p.envManager,
+		p.env,
+		p.prompters,
+		provisioning.EnsureSubscriptionAndLocationOptions{},
+	)
+}
+
+// configureCliEnv sets the environment variables used for all pulumi CLI commands
+func (p *PulumiProvider) configureCliEnv(ctx context.Context) error {
+	envVars := []string{
+		// Required when using service principal login
+		fmt.Sprintf("ARM_TENANT_ID=%s", os.Getenv("ARM_TENANT_ID")),
+		fmt.Sprintf("ARM_SUBSCRIPTION_ID=%s", p.env.GetSubscriptionId()),
+		fmt.Sprintf("ARM_CLIENT_ID=%s", os.Getenv("ARM_CLIENT_ID")),
+		fmt.Sprintf("ARM_CLIENT_SECRET=%s", os.Getenv("ARM_CLIENT_SECRET")),
+		fmt.Sprintf("ARM_LOCATION=%s", p.env.GetLocation()),
+		// Include azd in user agent
+		fmt.Sprintf("AZURE_HTTP_USER_AGENT=%s", internal.UserAgent()),
+		"PULUMI_SKIP_UPDATE_CHECK=true",
+	}
+
+	backendUrl := p.lookupEnv(BackendUrlEnvVarName)
+	if backendUrl == "" {
+		backendPath := p.localBackendPath()
+		if err := os.MkdirAll(backendPath, osutil.PermissionDirectory); err != nil {
+			return fmt.Errorf("creating pulumi local backend directory: %w", err)
+		}
+
+		backendUrl = fmt.Sprintf("file://%s", filepath.ToSlash(backendPath))
+	}
+	envVars = append(envVars, fmt.Sprintf("%s=%s", BackendUrlEnvVarName, backendUrl))
+
+	// Stacks using the default passphrase secrets provider require a passphrase to be set when running
+	// non-interactively. When one has not been configured, an empty passphrase is used and the user is warned.
+	if passphrase := p.lookupEnv(configPassphraseEnvVarName); passphrase != "" {
+		envVars = append(envVars, fmt.Sprintf("%s=%s", configPassphraseEnvVarName, passphrase))
+	} else if p.lookupEnv(configPassphraseFileEnvVarName) == "" {
+		envVars = append(envVars, fmt.Sprintf("%s=", configPassphraseEnvVarName))
+		p.console.MessageUxItem(ctx, &ux.WarningMessage{
+			Description: fmt.Sprintf(
+				"No pulumi config passphrase is configured, the secrets of the stack are encrypted with an empty "+
+					"passphrase. Set %s or %s in the environment to use a passphrase.",
+				configPassphraseEnvVarName,
+				configPassphraseFileEnvVarName,
+			),
+		})
+	}
+
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if spanCtx.HasTraceID() {
+		envVars = append(envVars, fmt.Sprintf("ARM_CORRELATION_REQUEST_ID=%s", spanCtx.TraceID().String()))
+	}
+
+	p.cli.SetEnv(envVars)
+	return nil
+}
+
+// prepareStack selects (or creates) the stack for the current environment and sets the stack configuration from the
+// azd environment and the optional parameters file.
+func (p *PulumiProvider) prepareStack(ctx context.Context) (*provisioning.Deployment, error) {
+	if err := p.cli.SelectStack(ctx, p.modulePath(), p.stackName()); err != nil {
+		return nil, fmt.Errorf("selecting pulumi stack: %w", err)
+	}
+
+	config, err := p.stackConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	secretKeys, err := p.secretConfigKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	configValues := map[string]pulumi.ConfigValue{}
+	for key, value := range config {
+		configValue, err := configString(value)
+		if err != nil {
+			return nil, fmt.Errorf("converting config value '%s': %w", key, err)
+		}
+
+		configValues[key] = pulumi.ConfigValue{
+			Value:  configValue,
+			Secret: secretKeys[key],
+		}
+	}
+
+	if err := p.cli.SetConfig(ctx, p.modulePath(), p.stackName(), configValues); err != nil {
+		return nil, fmt.Errorf("setting pulumi stack config: %w", err)
+	}
+
+	parameters := make(map[string]provisioning.InputParameter, len(config))
+	for key, value := range config {
+		parameters[key] = provisioning.InputParameter{
+			Type:  string(parameterType(value)),
+			Value: value,
+		}
+	}
+
+	return &provisioning.Deployment{
+		Parameters: parameters,
+	}, nil
+}
+
+// Deploy the infrastructure within the specified pulumi program through pulumi up
+func (p *PulumiProvider) Deploy(ctx context.Context) (*provisioning.DeployResult, error) {
+	deployment, err := p.prepareStack(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.cli.Up(ctx, p.modulePath(), p.stackName())
+	if err != nil {
+		return nil, fmt.Errorf("deploying pulumi stack: %w", err)
+	}
+
+	outputs, err := p.createOutputParameters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading pulumi stack outputs: %w", err)
+	}
+
+	deployment.Outputs = outputs
+	return &provisioning.DeployResult{
+		Deployment: deployment,
+	}, nil
+}
+
+// Preview the changes to the infrastructure through pulumi preview
+func (p *PulumiProvider) Preview(ctx context.Context) (*provisioning.DeployPreviewResult, error) {
+	if _, err := p.prepareStack(ctx); err != nil {
+		return nil, err
+	}
+
+	runResult, err := p.cli.Preview(ctx, p.modulePath(), p.stackName())
+	if err != nil {
+		return nil, err
+	}
+
+	var previewOutput pulumiPreviewOutput
+	if err := json.Unmarshal([]byte(runResult), &previewOutput); err != nil {
+		return nil, fmt.Errorf("parsing pulumi preview output: %w", err)
+	}
+
+	return &provisioning.DeployPreviewResult{
+		Preview: &provisioning.DeploymentPreview{
+			Status: "done",
+			Properties: &provisioning.DeploymentPreviewProperties{
+				Changes: convertPreviewSteps(previewOutput.Steps),
+			},
+		},
+	}, nil
+}
+
+// Destroys the resources of the stack through pulumi destroy
+func (p *PulumiProvider) Destroy(
+	ctx context.Context,
+	options provisioning.DestroyOptions,
+) (*provisioning.DestroyResult, error) {
+	if _, err := p.prepareStack(ctx); err != nil {
+		return nil, err
+	}
+
+	outputs, err := p.createOutputParameters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading pulumi stack outputs: %w", err)
+	}
+
+	p.console.Message(ctx, "Deleting pulumi stack resources...")
+	// pulumi doesn't use the `p.console`, we must ensure no spinner is running before calling Destroy
+	// as it could be an interactive operation if it needs confirmation
+	p.console.StopSpinner(ctx, "", input.Step)
+	runResult, err := p.cli.Destroy(ctx, p.modulePath(), p.stackName(), options.Force())
+	if err != nil {
+		return nil, fmt.Errorf("template Destroy failed: %s, err: %w", runResult, err)
+	}
+
+	return &provisioning.DestroyResult{
+		InvalidatedEnvKeys: slices.Collect(maps.Keys(outputs)),
+	}, nil
+}
+
+func (p *PulumiProvider) State(
+	ctx context.Context,
+	options *provisioning.StateOptions,
+) (*provisioning.StateResult, error) {
+	p.console.Message(ctx, "Retrieving pulumi state...")
+
+	if err := p.cli.SelectStack(ctx, p.modulePath(), p.stackName()); err != nil {
+		return nil, fmt.Errorf("selecting pulumi stack: %w", err)
+	}
+
+	outputs, err := p.createOutputParameters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading pulumi stack outputs: %w", err)
+	}
+
+	runResult, err := p.cli.StackExport(ctx, p.modulePath(), p.stackName())
+	if err != nil {
+		return nil, fmt.Errorf("fetching pulumi state failed: %w", err)
+	}
+
+	var stackExport pulumiStackExport
+	if err := json.Unmarshal([]byte(runResult), &stackExport); err != nil {
+		return nil, fmt.Errorf("parsing pulumi state: %w", err)
+	}
+
+	return &provisioning.StateResult{
+		State: &provisioning.State{
+			Outputs:   outputs,
+			Resources: collectAzureResources(stackExport.Deployment.Resources),
+		},
+	}, nil
+}
+
+// Creates a normalized view of the pulumi stack outputs
+func (p *PulumiProvider) createOutputParameters(ctx context.Context) (map[string]provisioning.OutputParameter, error) {
+	runResult, err := p.cli.StackOutput(ctx, p.modulePath(), p.stackName())
+	if err != nil {
+		return nil, err
+	}
+
+	var outputMap map[string]any
+	if err := json.Unmarshal([]byte(runResult), &outputMap); err != nil {
+		return nil, err
+	}
+
+	return convertOutputs(outputMap), nil
+}
+
+// stackConfig returns the configuration values for the stack. The azure-native provider is configured from the
+// azd environment, and the well-known environment values are made available to the program. Additional values may be
+// specified within the `<module>.parameters.json` file, which supports environment variable references.
+func (p *PulumiProvider) stackConfig(ctx context.Context) (map[string]any, error) {
+	principalId, err := p.curPrincipal.CurrentPrincipalId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching current principal id: %w", err)
+	}
+
+	config := map[string]any{
+		"azure-native:subscriptionId": p.env.GetSubscriptionId(),
+		"azure-native:location":       p.env.GetLocation(),
+		"environmentName":             p.env.Name(),
+		"location":                    p.env.GetLocation(),
+		"principalId":                 principalId,
+	}
+
+	templateFilePath := p.parametersTemplateFilePath()
+	parametersBytes, err := os.ReadFile(templateFilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("reading parameter file template: %w", err)
+	}
+
+	log.Printf("Reading parameters template file from: %s", templateFilePath)
+	replaced, err := envsubst.Eval(string(parametersBytes), func(name string) string {
+		if name == environment.PrincipalIdEnvVarName {
+			return principalId
+		}
+
+		return p.env.Getenv(name)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("substituting parameter file: %w", err)
+	}
+
+	parametersFilePath := p.parametersFilePath()
+	if err := os.MkdirAll(filepath.Dir(parametersFilePath), osutil.PermissionDirectory); err != nil {
+		return nil, fmt.Errorf("creating directory structure: %w", err)
+	}
+
+	log.Printf("Writing parameters file to: %s", parametersFilePath)
+	if err := os.WriteFile(parametersFilePath, []byte(replaced), osutil.PermissionFileOwnerOnly); err != nil {
+		return nil, fmt.Errorf("writing parameter file: %w", err)
+	}
+
+	var parameters map[string]any
+	if err := json.Unmarshal([]byte(replaced), &parameters); err != nil {
+		return nil, fmt.Errorf("error unmarshalling template parameters: %w", err)
+	}
+
+	maps.Copy(config, parameters)
+	return config, nil
+}
+
+// pulumiProject is the subset of the Pulumi.yaml project file used to find the secret configuration values
+type pulumiProject struct {
+	Name   string         `yaml:"name"`
+	Config map[string]any `yaml:"config"`
+}
+
+// secretConfigKeys returns the keys of the configuration values declared with `secret: true` in the config section of
+// the pulumi project file. Keys are returned both with and without the project name namespace.
+func (p *PulumiProvider) secretConfigKeys() (map[string]bool, error) {
+	secretKeys := map[string]bool{}
+	for _, fileName := range []string{"Pulumi.yaml", "Pulumi.yml"} {
+		projectBytes, err := os.ReadFile(filepath.Join(p.modulePath(), fileName))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("reading pulumi project file: %w", err)
+		}
+
+		var project pulumiProject
+		if err := yaml.Unmarshal(projectBytes, &project); err != nil {
+			return nil, fmt.Errorf("parsing pulumi project file: %w", err)
+		}
+
+		for key, declaration := range project.Config {
+			declarationMap, ok := declaration.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			if secret, _ := declarationMap["secret"].(bool); secret {
+				secretKeys[key] = true
+				secretKeys[strings.TrimPrefix(key, project.Name+":")] = true
+			}
+		}
+
+		break
+	}
+
+	return secretKeys, nil
+}
+
+// lookupEnv returns the value of the environment variable from the azd environment, falling back to the process
+// environment.
+func (p *PulumiProvider) lookupEnv(name string) string {
+	if value, has := p.env.LookupEnv(name); has {
+		return value
+	}
+
+	return os.Getenv(name)
+}
+
+// The pulumi stack name for the current environment
+func (p *PulumiProvider) stackName() string {
+	return p.env.Name()
+}
+
+// Gets the folder path to the pulumi project
+func (p *PulumiProvider) modulePath() string {
+	return filepath.Join(p.projectPath, p.options.Path)
+}
+
+// Gets the path to the project parameters file path
+func (p *PulumiProvider) parametersTemplateFilePath() string {
+	parametersFilename := fmt.Sprintf("%s.parameters.json", p.options.Module)
+	return filepath.Join(p.projectPath, p.options.Path, parametersFilename)
+}
+
+// Gets the path to the staging .azure parameters file path
+func (p *PulumiProvider) parametersFilePath() string {
+	parametersFilename := fmt.Sprintf("%s.parameters.json", p.options.Module)
+	return filepath.Join(p.projectPath, ".azure", p.env.Name(), p.options.Path, parametersFilename)
+}
+
+// Gets the path to the local file based pulumi backend for the current env.
+func (p *PulumiProvider) localBackendPath() string {
+	absProjectPath, err := filepath.Abs(p.projectPath)
+	if err != nil {
+		absProjectPath = p.projectPath
+	}
+
+	return filepath.Join(absProjectPath, ".azure", p.env.Name(), p.options.Path, ".pulumi")
+}
+
+// configString converts a config value to the string representation expected by `pulumi config`.
+// Complex values are serialized as JSON which can be read by pulumi programs as structured config.
+func configString(value any) (string, error) {
+	if str, ok := value.(string); ok {
+		return str, nil
+	}
+
+	jsonBytes, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+// parameterType returns the parameter type for a JSON value
+func parameterType(value any) provisioning.ParameterType {
+	switch value.(type) {
+	case bool:
+		return provisioning.ParameterTypeBoolean
+	case float64, int, int64:
+		return provisioning.ParameterTypeNumber
+	case []any:
+		return provisioning.ParameterTypeArray
+	case map[string]any:
+		return provisioning.ParameterTypeObject
+	default:
+		return provisioning.ParameterTypeString
+	}
+}
+
+// convertOutputs converts the pulumi stack outputs to the canonical format shared by all provider implementations.
+func convertOutputs(outputMap map[string]any) map[string]provisioning.OutputParameter {
+	outputParameters := make(map[string]provisioning.OutputParameter)
+	for key, value := range outputMap {
+		if value == nil {
+			// omit null
+			continue
+		}
+
+		outputParameters[key] = provisioning.OutputParameter{
+			Type:  parameterType(value),
+			Value: value,
+		}
+	}
+
+	return outputParameters
+}
+
+// collectAzureResources collects the Azure resources from the resources of a pulumi stack.
+// Only custom resources with an Azure resource id are considered.
+func collectAzureResources(stackResources []pulumiResource) []provisioning.Resource {
+	resources := []provisioning.Resource{}
+	seen := map[string]struct{}{}
+
+	for _, resource := range stackResources {
+		if !resource.Custom || !strings.HasPrefix(strings.ToLower(resource.ID), "/subscriptions/") {
+			continue
+		}
+
+		if _, has := seen[resource.ID]; has {
+			continue
+		}
+
+		seen[resource.ID] = struct{}{}
+		resources = append(resources, provisioning.Resource{
+			Id: resource.ID,
+		})
+	}
+
+	return resources
+}
+
+// convertPreviewSteps converts the steps of a pulumi preview to the changes of a deployment preview.
+func convertPreviewSteps(steps []pulumiPreviewStep) []*provisioning.DeploymentPreviewChange {
+	changes := []*provisioning.DeploymentPreviewChange{}
+
+	for _, step := range steps {
+		changeType, supported := previewChangeType(step.Op)
+		if !supported {
+			continue
+		}
+
+		state := step.NewState
+		if state == nil {
+			state = step.OldState
+		}
+
+		// The stack itself and the provider resources are pulumi internals and not Azure resources
+		resourceType := ""
+		if state != nil {
+			resourceType = state.Type
+		}
+		if resourceType == "pulumi:pulumi:Stack" || strings.HasPrefix(resourceType, "pulumi:providers:") {
+			continue
+		}
+
+		change := &provisioning.DeploymentPreviewChange{
+			ChangeType:   changeType,
+			ResourceType: resourceType,
+			Name:         urnName(step.Urn),
+		}
+
+		var before, after map[string]any
+		if step.OldState != nil {
+			change.ResourceId = provisioning.Resource{Id: step.OldState.ID}
+			change.Before = step.OldState.Inputs
+			before = step.OldState.Inputs
+		}
+		if step.NewState != nil {
+			change.After = step.NewState.Inputs
+			after = step.NewState.Inputs
+		}
+
+		if changeType == provisioning.ChangeTypeModify {
+			change.Delta = propertyChanges(step, before, after)
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+// previewChangeType maps a pulumi step operation to a change type. Operations that are part of a replacement are
+// reported through the `replace` operation and are not supported on their own.
+func previewChangeType(op string) (provisioning.ChangeType, bool) {
+	switch op {
+	case "create":
+		return provisioning.ChangeTypeCreate, true
+	case "update", "replace":
+		return provisioning.ChangeTypeModify, true
+	case "delete":
+		return provisioning.ChangeTypeDelete, true
+	case "same":
+		return provisioning.ChangeTypeNoChange, true
+	case "read", "refresh", "import":
+		return provisioning.ChangeTypeIgnore, true
+	default:
+		return "", false
+	}
+}
+
+// propertyChanges returns the property level changes of a step, using the detailed diff when available and falling back
+// to the diff reasons reported by the provider.
+func propertyChanges(
+	step pulumiPreviewStep,
+	before map[string]any,
+	after map[string]any,
+) []provisioning.DeploymentPreviewPropertyChange {
+	delta := []provisioning.DeploymentPreviewPropertyChange{}
+
+	if len(step.DetailedDiff) > 0 {
+		for _, path := range slices.Sorted(maps.Keys(step.DetailedDiff)) {
+			delta = append(delta, provisioning.DeploymentPreviewPropertyChange{
+				ChangeType: propertyChangeType(step.DetailedDiff[path].Kind),
+				Path:       path,
+				Before:     before[path],
+				After:      after[path],
+			})
+		}
+
+		return delta
+	}
+
+	for _, path := range step.DiffReasons {
+		delta = append(delta, provisioning.DeploymentPreviewPropertyChange{
+			ChangeType: provisioning.PropertyChangeTypeModify,
+			Path:       path,
+			Before:     before[path],
+			After:      after[path],
+		})
+	}
+
+	return delta
+}
+
+func propertyChangeType(kind string) provisioning.PropertyChangeType {
+	switch kind {
+	case "add", "add-replace":
+		return provisioning.PropertyChangeTypeCreate
+	case "delete", "delete-replace":
+		return provisioning.PropertyChangeTypeDelete
+	default:
+		return provisioning.PropertyChangeTypeModify
+	}
+}
+
+// urnName returns the name of the resource from a pulumi URN, ex) urn:pulumi:<stack>::<project>::<type>::<name>
+func urnName(urn string) string {
+	if index := strings.LastIndex(urn, "::"); index >= 0 {
+		return urn[index+2:]
+	}
+
+	return urn
+}
+
+// pulumiPreviewOutput is a model type for the output of `pulumi preview --json`
+type pulumiPreviewOutput struct {
+	Steps         []pulumiPreviewStep `json:"steps"`
+	ChangeSummary map[string]int      `json:"changeSummary"`
+}
+
+// pulumiPreviewStep is a model type for a single resource step of a pulumi preview
+type pulumiPreviewStep struct {
+	Op           string                        `json:"op"`
+	Urn          string                        `json:"urn"`
+	OldState     *pulumiResource               `json:"oldState"`
+	NewState     *pulumiResource               `json:"newState"`
+	DiffReasons  []string                      `json:"diffReasons"`
+	DetailedDiff map[string]pulumiPropertyDiff `json:"detailedDiff"`
+}
+
+// pulumiPropertyDiff is a model type for the diff of a single property of a resource
+type pulumiPropertyDiff struct {
+	Kind      string `json:"kind"`
+	InputDiff bool   `json:"inputDiff"`
+}
+
+// pulumiStackExport is a model type for the output of `pulumi stack export`
+type pulumiStackExport struct {
+	Version    int `json:"version"`
+	Deployment struct {
+		Resources []pulumiResource `json:"resources"`
+	} `json:"deployment"`
+}
+
+// pulumiResource is a model type for a resource within the pulumi state. For resources of the azure-native
+// provider, the id is the Azure resource id.
+type pulumiResource struct {
+	Urn     string         `json:"urn"`
+	Type    string         `json:"type"`
+	ID      string         `json:"id"`
+	Custom  bool           `json:"custom"`
+	Inputs  map[string]any `json:"inputs"`
+	Outputs map[string]any `json:"outputs"`
+}
@@ -0,0 +1,321 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package pulumi
+
+import (
+	"context"
+	_ "embed"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/account"
+	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/prompt"
+	pulumiTools "github.com/azure/azure-dev/cli/azd/pkg/tools/pulumi"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockaccount"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockenv"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockexec"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed testdata/pulumi_preview_mock.json
+var pulumiPreviewMockOutput string
+
+//go:embed testdata/pulumi_stack_export_mock.json
+var pulumiStackExportMockOutput string
+
+const pulumiStackOutputMock = `{"AZURE_LOCATION": "westus2", "RG_NAME": "rg-test-env", "SKIPPED": null}`
+
+func TestPulumiDeploy(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	commands := prepareMocks(mockContext.CommandRunner)
+
+	infraProvider := createPulumiProvider(t, mockContext)
+	deployResult, err := infraProvider.Deploy(*mockContext.Context)
+
+	require.NoError(t, err)
+	require.NotNil(t, deployResult)
+
+	deployment := deployResult.Deployment
+	require.Equal(t, "westus2", deployment.Parameters["location"].Value)
+	require.Equal(t, "test-env", deployment.Parameters["environmentName"].Value)
+	require.Equal(t, "rg-test-env", deployment.Parameters["resourceGroupName"].Value)
+	require.Equal(t, string(provisioning.ParameterTypeObject), deployment.Parameters["tags"].Type)
+
+	require.Equal(t, "westus2", deployment.Outputs["AZURE_LOCATION"].Value)
+	require.Equal(t, provisioning.ParameterTypeString, deployment.Outputs["RG_NAME"].Type)
+	require.NotContains(t, deployment.Outputs, "SKIPPED")
+
+	require.FileExists(t, infraProvider.parametersFilePath())
+
+	configArgs := commands["config"]
+	require.Contains(t, configArgs, "azure-native:location=westus2")
+	require.Contains(t, configArgs, "azure-native:subscriptionId=00000000-0000-0000-0000-000000000000")
+	require.Contains(t, configArgs, `tags={"owner":"azd"}`)
+	require.Contains(t, commands, "up")
+}
+
+func TestPulumiDeploySecretConfig(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	commands := prepareMocks(mockContext.CommandRunner)
+	var secretArgs []string
+	var secretValue string
+	mockContext.CommandRunner.When(func(args exec.RunArgs, _ string) bool {
+		return args.Cmd == "pulumi" && strings.HasPrefix(strings.Join(args.Args, " "), "config set ")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		secretArgs = slices.Clone(args.Args)
+		value, err := io.ReadAll(args.StdIn)
+		require.NoError(t, err)
+		secretValue = string(value)
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
+	infraProvider := createPulumiProvider(t, mockContext)
+	infraProvider.env.DotenvSet("DB_PASSWORD", "p@ssw0rd")
+	require.NoError(t, os.WriteFile(
+		filepath.Join(infraProvider.modulePath(), "Pulumi.yaml"),
+		[]byte("name: azd-pulumi\nruntime: yaml\nconfig:\n  azd-pulumi:dbPassword:\n    type: string\n    secret: true\n"),
+		osutil.PermissionFile,
+	))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(infraProvider.modulePath(), "main.parameters.json"),
+		[]byte(`{"dbPassword": "${DB_PASSWORD}"}`),
+		osutil.PermissionFile,
+	))
+
+	_, err := infraProvider.Deploy(*mockContext.Context)
+	require.NoError(t, err)
+
+	require.Contains(t, commands["config"], "location=westus2")
+	require.NotContains(t, strings.Join(commands["config"], " "), "p@ssw0rd")
+	require.Equal(t, []string{"config", "set", "dbPassword", "--secret"}, secretArgs[:4])
+	require.NotContains(t, strings.Join(secretArgs, " "), "p@ssw0rd")
+	require.Equal(t, "p@ssw0rd", secretValue)
+}
+
+func TestPulumiPreview(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareMocks(mockContext.CommandRunner)
+
+	infraProvider := createPulumiProvider(t, mockContext)
+	previewResult, err := infraProvider.Preview(*mockContext.Context)
+
+	require.NoError(t, err)
+	require.NotNil(t, previewResult.Preview)
+
+	changes := previewResult.Preview.Properties.Changes
+	require.Len(t, changes, 3)
+
+	require.Equal(t, provisioning.ChangeTypeModify, changes[0].ChangeType)
+	require.Equal(t, "azure-native:resources:ResourceGroup", changes[0].ResourceType)
+	require.Equal(t, "rg", changes[0].Name)
+	require.Equal(t,
+		"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg-test-env", changes[0].ResourceId.Id)
+	require.Len(t, changes[0].Delta, 1)
+	require.Equal(t, "tags", changes[0].Delta[0].Path)
+	require.Equal(t, provisioning.PropertyChangeTypeModify, changes[0].Delta[0].ChangeType)
+	require.NotNil(t, changes[0].Delta[0].Before)
+	require.NotNil(t, changes[0].Delta[0].After)
+
+	require.Equal(t, provisioning.ChangeTypeCreate, changes[1].ChangeType)
+	require.Equal(t, "storage", changes[1].Name)
+
+	require.Equal(t, provisioning.ChangeTypeDelete, changes[2].ChangeType)
+	require.Equal(t, "web", changes[2].Name)
+}
+
+func TestPulumiState(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareMocks(mockContext.CommandRunner)
+
+	infraProvider := createPulumiProvider(t, mockContext)
+	stateResult, err := infraProvider.State(*mockContext.Context, nil)
+
+	require.NoError(t, err)
+	require.NotNil(t, stateResult.State)
+
+	require.Equal(t, "rg-test-env", stateResult.State.Outputs["RG_NAME"].Value)
+	require.Len(t, stateResult.State.Resources, 1)
+	require.Equal(t,
+		"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg-test-env",
+		stateResult.State.Resources[0].Id,
+	)
+}
+
+func TestPulumiDestroy(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	commands := prepareMocks(mockContext.CommandRunner)
+
+	infraProvider := createPulumiProvider(t, mockContext)
+	destroyResult, err := infraProvider.Destroy(*mockContext.Context, provisioning.NewDestroyOptions(true, false))
+
+	require.NoError(t, err)
+	require.NotNil(t, destroyResult)
+
+	require.Contains(t, destroyResult.InvalidatedEnvKeys, "AZURE_LOCATION")
+	require.Contains(t, destroyResult.InvalidatedEnvKeys, "RG_NAME")
+	require.Contains(t, commands["destroy"], "--yes")
+}
+
+func TestPulumiLocalBackend(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		t.Setenv(BackendUrlEnvVarName, "")
+		t.Setenv(configPassphraseEnvVarName, "")
+		t.Setenv(configPassphraseFileEnvVarName, "")
+
+		mockContext := mocks.NewMockContext(context.Background())
+		var env []string
+		mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+			return args.Cmd == "pulumi"
+		}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+			env = args.Env
+			return exec.NewRunResult(0, "", ""), nil
+		})
+
+		infraProvider := createPulumiProvider(t, mockContext)
+		err := infraProvider.cli.SelectStack(*mockContext.Context, infraProvider.modulePath(), "test-env")
+		require.NoError(t, err)
+
+		backendPath := filepath.ToSlash(infraProvider.localBackendPath())
+		require.Contains(t, env, "PULUMI_BACKEND_URL=file://"+backendPath)
+		require.Contains(t, env, "PULUMI_CONFIG_PASSPHRASE=")
+		require.Contains(t, strings.Join(mockContext.Console.Output(), "\n"), "No pulumi config passphrase is configured")
+		require.DirExists(t, infraProvider.localBackendPath())
+	})
+
+	t.Run("Passphrase", func(t *testing.T) {
+		t.Setenv(configPassphraseEnvVarName, "passphrase")
+
+		mockContext := mocks.NewMockContext(context.Background())
+		var env []string
+		mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+			return args.Cmd == "pulumi"
+		}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+			env = args.Env
+			return exec.NewRunResult(0, "", ""), nil
+		})
+
+		infraProvider := createPulumiProvider(t, mockContext)
+		err := infraProvider.cli.SelectStack(*mockContext.Context, infraProvider.modulePath(), "test-env")
+		require.NoError(t, err)
+		require.Contains(t, env, "PULUMI_CONFIG_PASSPHRASE=passphrase")
+		require.NotContains(t, strings.Join(mockContext.Console.Output(), "\n"), "No pulumi config passphrase")
+	})
+
+	t.Run("FromEnvironment", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		var env []string
+		mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+			return args.Cmd == "pulumi"
+		}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+			env = args.Env
+			return exec.NewRunResult(0, "", ""), nil
+		})
+
+		infraProvider := createPulumiProvider(t, mockContext)
+		infraProvider.env.DotenvSet(BackendUrlEnvVarName, "azblob://state")
+		require.NoError(t, infraProvider.configureCliEnv(*mockContext.Context))
+
+		err := infraProvider.cli.SelectStack(*mockContext.Context, infraProvider.modulePath(), "test-env")
+		require.NoError(t, err)
+		require.Contains(t, env, "PULUMI_BACKEND_URL=azblob://state")
+	})
+}
+
+// createPulumiProvider creates a pulumi provider for a pulumi project within a temporary directory.
+// The provider is configured the same way as Initialize, without checking that the pulumi CLI is installed.
+func createPulumiProvider(t *testing.T, mockContext *mocks.MockContext) *PulumiProvider {
+	projectDir := t.TempDir()
+	infraDir := filepath.Join(projectDir, defaultPath)
+	require.NoError(t, os.MkdirAll(infraDir, osutil.PermissionDirectory))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(infraDir, "Pulumi.yaml"),
+		[]byte("name: azd-pulumi\nruntime: yaml\n"),
+		osutil.PermissionFile,
+	))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(infraDir, "main.parameters.json"),
+		[]byte(`{"resourceGroupName": "rg-${AZURE_ENV_NAME}", "tags": {"owner": "azd"}}`),
+		osutil.PermissionFile,
+	))
+
+	env := environment.NewWithValues("test-env", map[string]string{
+		"AZURE_ENV_NAME":        "test-env",
+		"AZURE_LOCATION":        "westus2",
+		"AZURE_SUBSCRIPTION_ID": "00000000-0000-0000-0000-000000000000",
+	})
+
+	resourceService := azapi.NewResourceService(mockContext.SubscriptionCredentialProvider, mockContext.ArmClientOptions)
+	accountManager := &mockaccount.MockAccountManager{
+		Subscriptions: []account.Subscription{
+			{
+				Id:   "00000000-0000-0000-0000-000000000000",
+				Name: "test",
+			},
+		},
+	}
+
+	envManager := &mockenv.MockEnvManager{}
+	envManager.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	provider := NewPulumiProvider(
+		pulumiTools.NewCli(mockContext.CommandRunner),
+		envManager,
+		env,
+		mockContext.Console,
+		&mockCurrentPrincipal{},
+		prompt.NewDefaultPrompter(env, mockContext.Console, accountManager, resourceService, cloud.AzurePublic()),
+	).(*PulumiProvider)
+
+	provider.projectPath = projectDir
+	provider.options = provisioning.Options{Module: defaultModule, Path: defaultPath}
+
+	require.NoError(t, provider.EnsureEnv(*mockContext.Context))
+	require.NoError(t, provider.configureCliEnv(*mockContext.Context))
+
+	return provider
+}
+
+// prepareMocks registers the responses for the pulumi CLI commands and returns the arguments of each command
+// that was run, keyed by the pulumi sub command.
+func prepareMocks(commandRunner *mockexec.MockCommandRunner) map[string][]string {
+	commands := map[string][]string{}
+	responses := map[string]string{
+		"stack select": "",
+		"config":       "",
+		"preview":      pulumiPreviewMockOutput,
+		"up":           "Update succeeded",
+		"stack output": pulumiStackOutputMock,
+		"stack export": pulumiStackExportMockOutput,
+		"destroy":      "Destroy succeeded",
+	}
+
+	for command, stdout := range responses {
+		commandRunner.When(func(args exec.RunArgs, _ string) bool {
+			return args.Cmd == "pulumi" && strings.HasPrefix(strings.Join(args.Args, " "), command)
+		}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+			commands[strings.Fields(command)[0]] = slices.Clone(args.Args)
+			return exec.NewRunResult(0, stdout, ""), nil
+		})
+	}
+
+	return commands
+}
+
+type mockCurrentPrincipal struct{}
+
+func (m *mockCurrentPrincipal) CurrentPrincipalId(_ context.Context) (string, error) {
+	return "11111111-1111-1111-1111-111111111111", nil
+}
@@ -0,0 +1,62 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package terraform
+
+import (
+	"context"
+	"slices"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning/policy"
+)
+
+// PolicyResources lists the resources of the terraform plan, with the attributes they are planned with. The plan is
+// applied by the following deployment. Attributes only known once the plan is applied are unknown values, and the
+// resources planned for deletion or only read by data sources are not listed.
+func (t *TerraformProvider) PolicyResources(ctx context.Context) ([]policy.Resource, error) {
+	deployment, deploymentDetails, err := t.plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	planOutput, err := t.showPlan(ctx, t.modulePath(), deploymentDetails.PlanFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	t.policyPlan = &terraformPlan{
+		deployment: deployment,
+		details:    deploymentDetails,
+	}
+
+	return planPolicyResources(planOutput.ResourceChanges), nil
+}
+
+// planPolicyResources returns the resources of the resource changes of a terraform plan.
+func planPolicyResources(resourceChanges []terraformResourceChange) []policy.Resource {
+	var resources []policy.Resource
+	for _, resourceChange := range resourceChanges {
+		if resourceChange.Mode == "data" || slices.Equal(resourceChange.Change.Actions, []string{"delete"}) {
+			continue
+		}
+
+		attributes, ok := mergeValues(
+			resourceChange.Change.After, resourceChange.Change.AfterUnknown, policy.Unknown).(map[string]any)
+		if !ok {
+			continue
+		}
+
+		name, _ := attributes["name"].(string)
+		if name == "" {
+			name = resourceChange.Address
+		}
+
+		resources = append(resources, policy.Resource{
+			Type:       resourceChange.Type,
+			Name:       name,
+			Properties: attributes,
+		})
+	}
+
+	return resources
+}
@@ -0,0 +1,39 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package terraform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning/policy"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTerraformPolicyResources(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareGenericMocks(mockContext.CommandRunner)
+	preparePlanningMocks(mockContext.CommandRunner)
+	preparePlanShowMocks(mockContext.CommandRunner)
+
+	infraProvider := createTerraformProvider(t, mockContext)
+	resources, err := infraProvider.PolicyResources(*mockContext.Context)
+	require.NoError(t, err)
+
+	// data resources and deleted resources are not listed
+	var names []string
+	for _, resource := range resources {
+		names = append(names, resource.Name)
+	}
+	require.Equal(t, []string{"rg-test-env", "sttestenv", "id-new", "log-test-env"}, names)
+
+	require.Equal(t, "azurerm_resource_group", resources[0].Type)
+	require.Equal(t, policy.Unknown, resources[0].Properties["id"])
+	require.Equal(t, "TLS1_2", resources[1].Properties["min_tls_version"])
+	require.Equal(t, policy.Unknown, resources[2].Properties["principal_id"])
+
+	// the deployment applies the evaluated plan
+	require.NotNil(t, infraProvider.policyPlan)
+}
@@ -0,0 +1,304 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package terraform
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+)
+
+const (
+	// Displayed in place of the values of sensitive attributes, like terraform does in its plan output.
+	sensitiveValue = "(sensitive value)"
+	// Displayed in place of the values only known once the plan is applied, like terraform does in its plan output.
+	unknownValue = "(known after apply)"
+)
+
+// terraformResourceTypes maps the types of azurerm resources to the Azure resource types they manage, for the resources
+// that have a display name in the provisioning preview. Resource types of other resources are resolved from their
+// resource id, when known.
+var terraformResourceTypes = map[string]azapi.AzureResourceType{
+	"azurerm_api_management":                             azapi.AzureResourceTypeApim,
+	"azurerm_app_configuration":                          azapi.AzureResourceTypeAppConfig,
+	"azurerm_app_service":                                azapi.AzureResourceTypeWebSite,
+	"azurerm_app_service_plan":                           azapi.AzureResourceTypeServicePlan,
+	"azurerm_application_insights":                       azapi.AzureResourceTypeAppInsightComponent,
+	"azurerm_cdn_frontdoor_profile":                      azapi.AzureResourceTypeCDNProfile,
+	"azurerm_cdn_profile":                                azapi.AzureResourceTypeCDNProfile,
+	"azurerm_cognitive_account":                          azapi.AzureResourceTypeCognitiveServiceAccount,
+	"azurerm_cognitive_deployment":                       azapi.AzureResourceTypeCognitiveServiceAccountDeployment,
+	"azurerm_container_app":                              azapi.AzureResourceTypeContainerApp,
+	"azurerm_container_app_environment":                  azapi.AzureResourceTypeContainerAppEnvironment,
+	"azurerm_container_registry":                         azapi.AzureResourceTypeContainerRegistry,
+	"azurerm_cosmosdb_account":                           azapi.AzureResourceTypeCosmosDb,
+	"azurerm_dev_center":                                 azapi.AzureResourceTypeDevCenter,
+	"azurerm_dev_center_project":                         azapi.AzureResourceTypeDevCenterProject,
+	"azurerm_eventhub_namespace":                         azapi.AzureResourceTypeEventHubsNamespace,
+	"azurerm_function_app":                               azapi.AzureResourceTypeWebSite,
+	"azurerm_key_vault":                                  azapi.AzureResourceTypeKeyVault,
+	"azurerm_key_vault_managed_hardware_security_module": azapi.AzureResourceTypeManagedHSM,
+	"azurerm_kubernetes_cluster":                         azapi.AzureResourceTypeManagedCluster,
+	"azurerm_kubernetes_cluster_node_pool":               azapi.AzureResourceTypeAgentPool,
+	"azurerm_linux_function_app":                         azapi.AzureResourceTypeWebSite,
+	"azurerm_linux_web_app":                              azapi.AzureResourceTypeWebSite,
+	"azurerm_load_test":                                  azapi.AzureResourceTypeLoadTest,
+	"azurerm_log_analytics_workspace":                    azapi.AzureResourceTypeLogAnalyticsWorkspace,
+	"azurerm_machine_learning_workspace":                 azapi.AzureResourceTypeMachineLearningWorkspace,
+	"azurerm_mssql_server":                               azapi.AzureResourceTypeSqlServer,
+	"azurerm_mysql_flexible_server":                      azapi.AzureResourceTypeMySqlServer,
+	"azurerm_portal_dashboard":                           azapi.AzureResourceTypePortalDashboard,
+	"azurerm_postgresql_flexible_server":                 azapi.AzureResourceTypePostgreSqlServer,
+	"azurerm_private_endpoint":                           azapi.AzureResourceTypePrivateEndpoint,
+	"azurerm_redis_cache":                                azapi.AzureResourceTypeCacheForRedis,
+	"azurerm_resource_group":                             azapi.AzureResourceTypeResourceGroup,
+	"azurerm_search_service":                             azapi.AzureResourceTypeSearchService,
+	"azurerm_service_plan":                               azapi.AzureResourceTypeServicePlan,
+	"azurerm_servicebus_namespace":                       azapi.AzureResourceTypeServiceBusNamespace,
+	"azurerm_spring_cloud_service":                       azapi.AzureResourceTypeSpringApp,
+	"azurerm_static_site":                                azapi.AzureResourceTypeStaticWebSite,
+	"azurerm_static_web_app":                             azapi.AzureResourceTypeStaticWebSite,
+	"azurerm_storage_account":                            azapi.AzureResourceTypeStorageAccount,
+	"azurerm_virtual_network":                            azapi.AzureResourceTypeVirtualNetwork,
+	"azurerm_windows_function_app":                       azapi.AzureResourceTypeWebSite,
+	"azurerm_windows_web_app":                            azapi.AzureResourceTypeWebSite,
+}
+
+// terraformPlanOutput is a model type for the output of `terraform show` for a saved plan file.
+// see https://developer.hashicorp.com/terraform/internals/json-format#plan-representation for more information on the
+// shape of the JSON data
+type terraformPlanOutput struct {
+	FormatVersion   string                    `json:"format_version"`
+	ResourceChanges []terraformResourceChange `json:"resource_changes"`
+}
+
+// terraformResourceChange is a model type for the planned change of a single resource instance.
+type terraformResourceChange struct {
+	Address string `json:"address"`
+	// "mode" can be "managed", for resources, or "data", for data resources
+	Mode   string          `json:"mode"`
+	Type   string          `json:"type"`
+	Name   string          `json:"name"`
+	Change terraformChange `json:"change"`
+}
+
+// terraformChange is a model type for the change-representation of a resource. The values of unknown and sensitive
+// attributes are described by the "after_unknown", "before_sensitive" and "after_sensitive" objects, mirroring the
+// structure of the values with `true` for the unknown or sensitive attributes.
+type terraformChange struct {
+	Actions         []string `json:"actions"`
+	Before          any      `json:"before"`
+	After           any      `json:"after"`
+	AfterUnknown    any      `json:"after_unknown"`
+	BeforeSensitive any      `json:"before_sensitive"`
+	AfterSensitive  any      `json:"after_sensitive"`
+}
+
+// convertResourceChanges converts the resource changes of a terraform plan to the changes of a deployment preview.
+// Data resources are only read by terraform and are not reported.
+func convertResourceChanges(resourceChanges []terraformResourceChange) []*provisioning.DeploymentPreviewChange {
+	changes := []*provisioning.DeploymentPreviewChange{}
+
+	for _, resourceChange := range resourceChanges {
+		if resourceChange.Mode != terraformModeManaged {
+			continue
+		}
+
+		changeType, supported := planChangeType(resourceChange.Change.Actions)
+		if !supported {
+			continue
+		}
+
+		before := maskValue(resourceChange.Change.Before, resourceChange.Change.BeforeSensitive)
+		after := maskValue(
+			unknownValues(resourceChange.Change.After, resourceChange.Change.AfterUnknown),
+			resourceChange.Change.AfterSensitive,
+		)
+
+		change := &provisioning.DeploymentPreviewChange{
+			ChangeType:   changeType,
+			ResourceType: resourceChange.Type,
+			Name:         resourceChange.Address,
+			Before:       before,
+			After:        after,
+		}
+
+		beforeValues, _ := before.(map[string]any)
+		afterValues, _ := after.(map[string]any)
+
+		// the values of the resource are the latest known, after the change unless the resource is deleted
+		values := afterValues
+		if values == nil {
+			values = beforeValues
+		}
+
+		if name, ok := values["name"].(string); ok && name != "" {
+			change.Name = name
+		}
+
+		if id, ok := beforeValues["id"].(string); ok {
+			change.ResourceId = provisioning.Resource{Id: id}
+		} else if id, ok := afterValues["id"].(string); ok && id != unknownValue {
+			change.ResourceId = provisioning.Resource{Id: id}
+		}
+
+		if resourceType, has := terraformResourceTypes[resourceChange.Type]; has {
+			change.ResourceType = string(resourceType)
+		} else if resourceId, err := arm.ParseResourceID(change.ResourceId.Id); err == nil {
+			change.ResourceType = resourceId.ResourceType.String()
+		}
+
+		if changeType == provisioning.ChangeTypeModify {
+			change.Delta = propertyChanges(beforeValues, afterValues)
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+// planChangeType maps the actions planned for a resource to a change type. Replacements, planned as a delete and a
+// create, are reported as modifications.
+func planChangeType(actions []string) (provisioning.ChangeType, bool) {
+	switch {
+	case slices.Equal(actions, []string{"create"}):
+		return provisioning.ChangeTypeCreate, true
+	case slices.Equal(actions, []string{"update"}),
+		slices.Equal(actions, []string{"delete", "create"}),
+		slices.Equal(actions, []string{"create", "delete"}):
+		return provisioning.ChangeTypeModify, true
+	case slices.Equal(actions, []string{"delete"}):
+		return provisioning.ChangeTypeDelete, true
+	case slices.Equal(actions, []string{"no-op"}):
+		return provisioning.ChangeTypeNoChange, true
+	case slices.Equal(actions, []string{"read"}), slices.Equal(actions, []string{"forget"}):
+		return provisioning.ChangeTypeIgnore, true
+	default:
+		return "", false
+	}
+}
+
+// propertyChanges returns the changes between the attributes of a resource before and after the change. Changes of
+// nested objects are reported as children of the change of the attribute.
+func propertyChanges(before map[string]any, after map[string]any) []provisioning.DeploymentPreviewPropertyChange {
+	delta := []provisioning.DeploymentPreviewPropertyChange{}
+
+	keys := slices.Concat(slices.Collect(maps.Keys(before)), slices.Collect(maps.Keys(after)))
+	slices.Sort(keys)
+	keys = slices.Compact(keys)
+
+	for _, key := range keys {
+		beforeValue, hasBefore := before[key]
+		afterValue, hasAfter := after[key]
+
+		// null attributes are the same as unset attributes
+		hasBefore = hasBefore && beforeValue != nil
+		hasAfter = hasAfter && afterValue != nil
+
+		propertyChange := provisioning.DeploymentPreviewPropertyChange{
+			Path:   key,
+			Before: beforeValue,
+			After:  afterValue,
+		}
+
+		switch {
+		case !hasBefore && !hasAfter, reflect.DeepEqual(beforeValue, afterValue):
+			continue
+		case !hasBefore:
+			propertyChange.ChangeType = provisioning.PropertyChangeTypeCreate
+		case !hasAfter:
+			propertyChange.ChangeType = provisioning.PropertyChangeTypeDelete
+		default:
+			beforeObject, beforeIsObject := beforeValue.(map[string]any)
+			afterObject, afterIsObject := afterValue.(map[string]any)
+			_, beforeIsArray := beforeValue.([]any)
+			_, afterIsArray := afterValue.([]any)
+
+			switch {
+			case beforeIsObject && afterIsObject:
+				propertyChange.ChangeType = provisioning.PropertyChangeTypeModify
+				propertyChange.Children = propertyChanges(beforeObject, afterObject)
+			case beforeIsArray && afterIsArray:
+				propertyChange.ChangeType = provisioning.PropertyChangeTypeArray
+			default:
+				propertyChange.ChangeType = provisioning.PropertyChangeTypeModify
+			}
+		}
+
+		delta = append(delta, propertyChange)
+	}
+
+	return delta
+}
+
+// unknownValues replaces the values of the attributes only known once the plan is applied.
+func unknownValues(value any, unknown any) any {
+	if unknown == true {
+		return unknownValue
+	}
+
+	return mergeValues(value, unknown, unknownValue)
+}
+
+// maskValue replaces the values of the sensitive attributes.
+func maskValue(value any, sensitive any) any {
+	if sensitive == true {
+		return sensitiveValue
+	}
+
+	return mergeValues(value, sensitive, sensitiveValue)
+}
+
+// mergeValues replaces the values marked with `true` in the marks, an object mirroring the structure of the value.
+// Attributes missing from the value, like unknown attributes, are added when marked.
+func mergeValues(value any, marks any, replacement any) any {
+	switch marks := marks.(type) {
+	case bool:
+		if marks {
+			return replacement
+		}
+	case map[string]any:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return value
+		}
+
+		merged := make(map[string]any, len(object))
+		for key, attribute := range object {
+			merged[key] = mergeValues(attribute, marks[key], replacement)
+		}
+
+		for key, mark := range marks {
+			if _, has := object[key]; !has && mark == true {
+				merged[key] = replacement
+			}
+		}
+
+		return merged
+	case []any:
+		array, ok := value.([]any)
+		if !ok {
+			return value
+		}
+
+		merged := make([]any, len(array))
+		for index, item := range array {
+			var mark any
+			if index < len(marks) {
+				mark = marks[index]
+			}
+
+			merged[index] = mergeValues(item, mark, replacement)
+		}
+
+		return merged
+	}
+
+	return value
+}
@@ -0,0 +1,72 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package terraform
+
+import (
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_planChangeType(t *testing.T) {
+	tests := []struct {
+		actions  []string
+		expected provisioning.ChangeType
+	}{
+		{actions: []string{"create"}, expected: provisioning.ChangeTypeCreate},
+		{actions: []string{"update"}, expected: provisioning.ChangeTypeModify},
+		{actions: []string{"delete", "create"}, expected: provisioning.ChangeTypeModify},
+		{actions: []string{"create", "delete"}, expected: provisioning.ChangeTypeModify},
+		{actions: []string{"delete"}, expected: provisioning.ChangeTypeDelete},
+		{actions: []string{"no-op"}, expected: provisioning.ChangeTypeNoChange},
+		{actions: []string{"read"}, expected: provisioning.ChangeTypeIgnore},
+	}
+
+	for _, tt := range tests {
+		changeType, supported := planChangeType(tt.actions)
+		require.True(t, supported)
+		require.Equal(t, tt.expected, changeType)
+	}
+
+	_, supported := planChangeType([]string{"unknown"})
+	require.False(t, supported)
+}
+
+func Test_maskValue(t *testing.T) {
+	value := map[string]any{
+		"name":     "app",
+		"password": "secret",
+		"settings": []any{
+			map[string]any{"name": "KEY", "value": "secret"},
+		},
+	}
+
+	require.Equal(t, map[string]any{
+		"name":     "app",
+		"password": sensitiveValue,
+		"settings": []any{
+			map[string]any{"name": "KEY", "value": sensitiveValue},
+		},
+	}, maskValue(value, map[string]any{
+		"password": true,
+		"settings": []any{map[string]any{"value": true}},
+	}))
+
+	require.Equal(t, sensitiveValue, maskValue(value, true))
+	require.Equal(t, value, maskValue(value, false))
+	require.Nil(t, maskValue(nil, map[string]any{}))
+}
+
+func Test_unknownValues(t *testing.T) {
+	// unknown attributes are missing from the planned values
+	require.Equal(t, map[string]any{
+		"id":   unknownValue,
+		"name": "app",
+		"tags": map[string]any{},
+	}, unknownValues(map[string]any{
+		"name": "app",
+		"tags": map[string]any{},
+	}, map[string]any{"id": true, "tags": map[string]any{}}))
+}
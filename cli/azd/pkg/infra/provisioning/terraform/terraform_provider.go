@@ -6,6 +6,7 @@ package terraform
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"maps"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
@@ -29,6 +31,9 @@ import (
 const (
 	defaultModule = "main"
 	defaultPath   = "infra"
+
+	// planChangesExitCode is the exit code of terraform plan -detailed-exitcode when the plan has changes
+	planChangesExitCode = 2
 )
 
 // TerraformProvider exposes infrastructure provisioning using Azure Terraform templates
@@ -41,12 +46,22 @@ type TerraformProvider struct {
 	curPrincipal provisioning.CurrentPrincipalIdProvider
 	projectPath  string
 	options      provisioning.Options
+	// policyPlan is the plan of the policy evaluation, applied by the following deployment
+	policyPlan *terraformPlan
+}
+
+// terraformPlan is the result of planning the deployment.
+type terraformPlan struct {
+	deployment *provisioning.Deployment
+	details    *terraformDeploymentDetails
 }
 
 type terraformDeploymentDetails struct {
 	ParameterFilePath  string
 	PlanFilePath       string
 	localStateFilePath string
+	// noChanges is set when the plan, run with -detailed-exitcode, found no changes
+	noChanges bool
 }
 
 // Name gets the name of the infra provider
@@ -160,7 +175,11 @@ func (t *TerraformProvider) plan(ctx context.Context) (*provisioning.Deployment,
 
 	planArgs := t.createPlanArgs(isRemoteBackendConfig)
 	runResult, err := t.cli.Plan(ctx, modulePath, t.planFilePath(), planArgs...)
-	if err != nil {
+
+	// with -detailed-exitcode, terraform plan exits with code 2 when the plan has changes
+	var exitErr *exec.ExitError
+	planHasChanges := t.options.CheckDrift && errors.As(err, &exitErr) && exitErr.ExitCode == planChangesExitCode
+	if err != nil && !planHasChanges {
 		return nil, nil, fmt.Errorf("terraform plan failed:%s err %w", runResult, err)
 	}
 
@@ -173,6 +192,7 @@ func (t *TerraformProvider) plan(ctx context.Context) (*provisioning.Deployment,
 	deploymentDetails := terraformDeploymentDetails{
 		ParameterFilePath: t.parametersFilePath(),
 		PlanFilePath:      t.planFilePath(),
+		noChanges:         t.options.CheckDrift && !planHasChanges,
 	}
 	if !isRemoteBackendConfig {
 		deploymentDetails.localStateFilePath = t.localStateFilePath()
@@ -186,9 +206,18 @@ func (t *TerraformProvider) Deploy(ctx context.Context) (*provisioning.DeployRes
 	t.console.Message(ctx, "Locating plan file...")
 
 	modulePath := t.modulePath()
-	deployment, terraformDeploymentData, err := t.plan(ctx)
-	if err != nil {
-		return nil, err
+	var deployment *provisioning.Deployment
+	var terraformDeploymentData *terraformDeploymentDetails
+	if t.policyPlan != nil {
+		// the deployment applies the plan evaluated by the policy
+		deployment, terraformDeploymentData = t.policyPlan.deployment, t.policyPlan.details
+		t.policyPlan = nil
+	} else {
+		var err error
+		deployment, terraformDeploymentData, err = t.plan(ctx)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	isRemoteBackendConfig, err := t.isRemoteBackendConfig()
@@ -220,16 +249,32 @@ func (t *TerraformProvider) Deploy(ctx context.Context) (*provisioning.DeployRes
 
 func (t *TerraformProvider) Preview(ctx context.Context) (*provisioning.DeployPreviewResult, error) {
 	// terraform uses plan() to display the what-if output
-	// no changes are added to the properties
-	_, _, err := t.plan(ctx)
+	// the changes are read from the saved plan file
+	_, deploymentDetails, err := t.plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if deploymentDetails.noChanges {
+		return &provisioning.DeployPreviewResult{
+			Preview: &provisioning.DeploymentPreview{
+				Status:     "done",
+				Properties: &provisioning.DeploymentPreviewProperties{},
+			},
+		}, nil
+	}
+
+	planOutput, err := t.showPlan(ctx, t.modulePath(), deploymentDetails.PlanFilePath)
 	if err != nil {
 		return nil, err
 	}
 
 	return &provisioning.DeployPreviewResult{
 		Preview: &provisioning.DeploymentPreview{
-			Status:     "done",
-			Properties: &provisioning.DeploymentPreviewProperties{},
+			Status: "done",
+			Properties: &provisioning.DeploymentPreviewProperties{
+				Changes: convertResourceChanges(planOutput.ResourceChanges),
+			},
 		},
 	}, nil
 }
@@ -308,6 +353,10 @@ func (t *TerraformProvider) createPlanArgs(isRemoteBackendConfig bool) []string
 		args = append(args, fmt.Sprintf("-state=%s", t.localStateFilePath()))
 	}
 
+	if t.options.CheckDrift {
+		args = append(args, "-detailed-exitcode")
+	}
+
 	return args
 }
 
@@ -479,6 +528,25 @@ func (t *TerraformProvider) showCurrentState(
 	return &showOutput, nil
 }
 
+// Shows the changes of the saved plan file
+func (t *TerraformProvider) showPlan(
+	ctx context.Context,
+	modulePath string,
+	planFilePath string,
+) (*terraformPlanOutput, error) {
+	runResult, err := t.cli.Show(ctx, modulePath, planFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("showing plan failed: %s, err:%w", runResult, err)
+	}
+
+	var planOutput terraformPlanOutput
+	if err := json.Unmarshal([]byte(runResult), &planOutput); err != nil {
+		return nil, fmt.Errorf("parsing plan: %w", err)
+	}
+
+	return &planOutput, nil
+}
+
 // Creates the deployment object from the specified module path
 func (t *TerraformProvider) createDeployment(ctx context.Context) (*provisioning.Deployment, error) {
 	templateParameters := make(map[string]provisioning.InputParameter)
@@ -59,6 +59,133 @@ func TestTerraformPlan(t *testing.T) {
 	require.NotEmpty(t, deploymentPlan.localStateFilePath)
 }
 
+func TestTerraformPreview(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareGenericMocks(mockContext.CommandRunner)
+	preparePlanningMocks(mockContext.CommandRunner)
+	preparePlanShowMocks(mockContext.CommandRunner)
+
+	infraProvider := createTerraformProvider(t, mockContext)
+	previewResult, err := infraProvider.Preview(*mockContext.Context)
+
+	require.NoError(t, err)
+	require.Equal(t, "done", previewResult.Preview.Status)
+
+	changes := previewResult.Preview.Properties.Changes
+	require.Len(t, changes, 5)
+
+	require.Equal(t, provisioning.ChangeTypeCreate, changes[0].ChangeType)
+	require.Equal(t, string(azapi.AzureResourceTypeResourceGroup), changes[0].ResourceType)
+	require.Equal(t, "rg-test-env", changes[0].Name)
+	require.Empty(t, changes[0].ResourceId.Id)
+	require.Equal(t, "(known after apply)", changes[0].After.(map[string]any)["id"])
+
+	require.Equal(t, provisioning.ChangeTypeModify, changes[1].ChangeType)
+	require.Equal(t, string(azapi.AzureResourceTypeStorageAccount), changes[1].ResourceType)
+	require.Equal(t, "sttestenv", changes[1].Name)
+	require.Equal(t, "(sensitive value)", changes[1].Before.(map[string]any)["primary_access_key"])
+	require.Equal(t, []provisioning.DeploymentPreviewPropertyChange{
+		{
+			ChangeType: provisioning.PropertyChangeTypeModify,
+			Path:       "min_tls_version",
+			Before:     "TLS1_0",
+			After:      "TLS1_2",
+		},
+		{
+			ChangeType: provisioning.PropertyChangeTypeArray,
+			Path:       "network_rules",
+			Before:     []any{map[string]any{"default_action": "Allow"}},
+			After:      []any{map[string]any{"default_action": "Deny"}},
+		},
+		{
+			ChangeType: provisioning.PropertyChangeTypeModify,
+			Path:       "tags",
+			Before:     map[string]any{"azd-env-name": "test-env", "owner": "me"},
+			After:      map[string]any{"azd-env-name": "test-env", "team": "azd"},
+			Children: []provisioning.DeploymentPreviewPropertyChange{
+				{ChangeType: provisioning.PropertyChangeTypeDelete, Path: "owner", Before: "me"},
+				{ChangeType: provisioning.PropertyChangeTypeCreate, Path: "team", After: "azd"},
+			},
+		},
+	}, changes[1].Delta)
+
+	// replaced resources are modified, the resource type is read from the resource id
+	require.Equal(t, provisioning.ChangeTypeModify, changes[2].ChangeType)
+	require.Equal(t, "Microsoft.ManagedIdentity/userAssignedIdentities", changes[2].ResourceType)
+	require.Equal(t, "id-new", changes[2].Name)
+	require.Equal(t,
+		"/subscriptions/SUBSCRIPTION_ID/resourceGroups/rg-test-env/providers/Microsoft.ManagedIdentity/"+
+			"userAssignedIdentities/id-old",
+		changes[2].ResourceId.Id)
+	require.Len(t, changes[2].Delta, 3)
+
+	require.Equal(t, provisioning.ChangeTypeDelete, changes[3].ChangeType)
+	require.Equal(t, string(azapi.AzureResourceTypeKeyVault), changes[3].ResourceType)
+	require.Equal(t, "kv-test-env", changes[3].Name)
+	require.Nil(t, changes[3].After)
+
+	require.Equal(t, provisioning.ChangeTypeNoChange, changes[4].ChangeType)
+	require.Equal(t, "log-test-env", changes[4].Name)
+	require.Empty(t, changes[4].Delta)
+}
+
+func TestTerraformPreviewCheckDrift(t *testing.T) {
+	t.Run("Drift", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		prepareGenericMocks(mockContext.CommandRunner)
+		preparePlanningMocks(mockContext.CommandRunner)
+		prepareDetailedPlanMocks(mockContext.CommandRunner, planChangesExitCode)
+		preparePlanShowMocks(mockContext.CommandRunner)
+
+		infraProvider := createTerraformProvider(t, mockContext)
+		infraProvider.options.CheckDrift = true
+
+		previewResult, err := infraProvider.Preview(*mockContext.Context)
+		require.NoError(t, err)
+		require.Len(t, previewResult.Preview.Properties.Changes, 5)
+
+		// The key vault removed from the configuration is planned for deletion, which isn't drift
+		changes := previewResult.Preview.Properties.Changes
+		removed := provisioning.RemovedChanges(changes)
+		require.Len(t, removed, 1)
+		require.Equal(t, "kv-test-env", removed[0].Name)
+
+		var drifted []string
+		for _, change := range provisioning.DriftedChanges(changes) {
+			drifted = append(drifted, change.Name)
+		}
+		require.Equal(t, []string{"sttestenv", "id-new"}, drifted)
+	})
+
+	t.Run("NoDrift", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		prepareGenericMocks(mockContext.CommandRunner)
+		preparePlanningMocks(mockContext.CommandRunner)
+		prepareDetailedPlanMocks(mockContext.CommandRunner, 0)
+		preparePlanShowMocks(mockContext.CommandRunner)
+
+		infraProvider := createTerraformProvider(t, mockContext)
+		infraProvider.options.CheckDrift = true
+
+		previewResult, err := infraProvider.Preview(*mockContext.Context)
+		require.NoError(t, err)
+		require.Empty(t, previewResult.Preview.Properties.Changes)
+	})
+
+	t.Run("PlanFailed", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		prepareGenericMocks(mockContext.CommandRunner)
+		preparePlanningMocks(mockContext.CommandRunner)
+		prepareDetailedPlanMocks(mockContext.CommandRunner, 1)
+
+		infraProvider := createTerraformProvider(t, mockContext)
+		infraProvider.options.CheckDrift = true
+
+		_, err := infraProvider.Preview(*mockContext.Context)
+		require.Error(t, err)
+	})
+}
+
 func TestTerraformDestroy(t *testing.T) {
 	mockContext := mocks.NewMockContext(context.Background())
 	prepareGenericMocks(mockContext.CommandRunner)
@@ -176,6 +303,21 @@ func preparePlanningMocks(commandRunner *mockexec.MockCommandRunner) {
 	})
 }
 
+// prepareDetailedPlanMocks mocks terraform plan -detailed-exitcode exiting with the specified exit code.
+func prepareDetailedPlanMocks(commandRunner *mockexec.MockCommandRunner, exitCode int) {
+	commandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "terraform" && strings.Contains(command, "plan") &&
+			strings.Contains(command, "-detailed-exitcode")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		result := exec.NewRunResult(exitCode, "", "")
+		if exitCode == 0 {
+			return result, nil
+		}
+
+		return result, &exec.ExitError{Cmd: "terraform", ExitCode: exitCode}
+	})
+}
+
 //go:embed testdata/terraform_show_mock.json
 var terraformShowMockOutput string
 
@@ -188,6 +330,20 @@ func prepareShowMocks(commandRunner *mockexec.MockCommandRunner) {
 	})
 }
 
+//go:embed testdata/terraform_plan_mock.json
+var terraformPlanMockOutput string
+
+// preparePlanShowMocks mocks the output of showing the saved plan file, registered after the planning mocks which match
+// the commands with the path of the plan file.
+func preparePlanShowMocks(commandRunner *mockexec.MockCommandRunner) {
+	commandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "terraform" && strings.Contains(command, "show") && strings.Contains(command, ".tfplan")
+	}).Respond(exec.RunResult{
+		Stdout: terraformPlanMockOutput,
+		Stderr: "",
+	})
+}
+
 func prepareDestroyMocks(commandRunner *mockexec.MockCommandRunner) {
 	commandRunner.When(func(args exec.RunArgs, command string) bool {
 		return args.Cmd == "terraform" && strings.Contains(command, "init")
@@ -0,0 +1,511 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package oci
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	azcloud "github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/streaming"
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/auth"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
+	"github.com/azure/azure-dev/cli/azd/pkg/httputil"
+)
+
+const (
+	// MediaTypeImageManifest is the media type of OCI image manifests, also used for artifacts.
+	MediaTypeImageManifest = "application/vnd.oci.image.manifest.v1+json"
+
+	// Manifests are small documents, larger responses are rejected.
+	maxManifestSize = 4 * 1024 * 1024
+)
+
+var linkNextRegex = regexp.MustCompile(`<([^>]+)>;\s*rel="?next"?`)
+
+// Descriptor references content stored in a registry.
+type Descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Manifest is an OCI image manifest.
+type Manifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType,omitempty"`
+	ArtifactType  string            `json:"artifactType,omitempty"`
+	Config        Descriptor        `json:"config"`
+	Layers        []Descriptor      `json:"layers"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+type tagList struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+type catalog struct {
+	Repositories []string `json:"repositories"`
+}
+
+type tokenResponse struct {
+	Token        string `json:"token"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Client pulls artifacts from registries implementing the OCI distribution API.
+//
+// Registries requesting bearer authentication are supported. Tokens of Azure Container Registries are obtained by
+// exchanging a token of the logged in azd account, other registries are accessed anonymously.
+type Client struct {
+	pipeline           runtime.Pipeline
+	credentialProvider auth.MultiTenantCredentialProvider
+	cloud              *cloud.Cloud
+
+	// access tokens by registry and scope
+	tokens sync.Map
+}
+
+// NewClient creates a new OCI registry client.
+func NewClient(
+	transport policy.Transporter,
+	credentialProvider auth.MultiTenantCredentialProvider,
+	cloud *cloud.Cloud,
+) *Client {
+	return &Client{
+		pipeline: runtime.NewPipeline("azd-oci", internal.Version, runtime.PipelineOptions{}, &policy.ClientOptions{
+			Transport: transport,
+		}),
+		credentialProvider: credentialProvider,
+		cloud:              cloud,
+	}
+}
+
+// GetManifest fetches the manifest of the artifact identified by the reference and returns it along with its digest.
+// When the reference includes a digest, the content of the manifest is verified against it.
+func (c *Client) GetManifest(ctx context.Context, ref Reference) (*Manifest, string, error) {
+	res, err := c.send(
+		ctx,
+		ref.Registry,
+		fmt.Sprintf("/v2/%s/manifests/%s", ref.Repository, ref.Reference()),
+		MediaTypeImageManifest,
+		pullScope(ref.Repository),
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("fetching manifest of '%s': %w", ref, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("fetching manifest of '%s': %w", ref, runtime.NewResponseError(res))
+	}
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxManifestSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("reading manifest of '%s': %w", ref, err)
+	}
+
+	if len(body) > maxManifestSize {
+		return nil, "", fmt.Errorf("manifest of '%s' exceeds the maximum size of %d bytes", ref, maxManifestSize)
+	}
+
+	digest := computeDigest(body)
+	if ref.Digest != "" && ref.Digest != digest {
+		return nil, "", fmt.Errorf("manifest of '%s' doesn't match its digest, got '%s'", ref, digest)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return nil, "", fmt.Errorf("parsing manifest of '%s': %w", ref, err)
+	}
+
+	if manifest.SchemaVersion != 2 || (manifest.MediaType != "" && manifest.MediaType != MediaTypeImageManifest) {
+		return nil, "", fmt.Errorf(
+			"'%s' is not an OCI image manifest, media type '%s' is not supported", ref, manifest.MediaType)
+	}
+
+	return &manifest, digest, nil
+}
+
+// GetBlob fetches the blob described by the descriptor from the repository of the reference. The content is verified
+// against the size and digest of the descriptor.
+func (c *Client) GetBlob(ctx context.Context, ref Reference, descriptor Descriptor) ([]byte, error) {
+	if !digestRegex.MatchString(descriptor.Digest) {
+		return nil, fmt.Errorf("unsupported digest '%s' for blob of '%s'", descriptor.Digest, ref)
+	}
+
+	res, err := c.send(
+		ctx,
+		ref.Registry,
+		fmt.Sprintf("/v2/%s/blobs/%s", ref.Repository, descriptor.Digest),
+		"",
+		pullScope(ref.Repository),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("fetching blob '%s' of '%s': %w", descriptor.Digest, ref, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching blob '%s' of '%s': %w", descriptor.Digest, ref, runtime.NewResponseError(res))
+	}
+
+	content, err := io.ReadAll(io.LimitReader(res.Body, descriptor.Size+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading blob '%s' of '%s': %w", descriptor.Digest, ref, err)
+	}
+
+	if int64(len(content)) != descriptor.Size || computeDigest(content) != descriptor.Digest {
+		return nil, fmt.Errorf("blob '%s' of '%s' doesn't match its descriptor", descriptor.Digest, ref)
+	}
+
+	return content, nil
+}
+
+// ListTags lists the tags of a repository.
+func (c *Client) ListTags(ctx context.Context, registry string, repository string) ([]string, error) {
+	tags := []string{}
+	err := c.list(ctx, registry, fmt.Sprintf("/v2/%s/tags/list", repository), pullScope(repository),
+		func(body []byte) error {
+			var page tagList
+			if err := json.Unmarshal(body, &page); err != nil {
+				return err
+			}
+
+			tags = append(tags, page.Tags...)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("listing tags of '%s/%s': %w", registry, repository, err)
+	}
+
+	return tags, nil
+}
+
+// ListRepositories lists the repositories of a registry.
+func (c *Client) ListRepositories(ctx context.Context, registry string) ([]string, error) {
+	repositories := []string{}
+	err := c.list(ctx, registry, "/v2/_catalog", "registry:catalog:*", func(body []byte) error {
+		var page catalog
+		if err := json.Unmarshal(body, &page); err != nil {
+			return err
+		}
+
+		repositories = append(repositories, page.Repositories...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing repositories of '%s': %w", registry, err)
+	}
+
+	return repositories, nil
+}
+
+// list fetches all the pages of a paginated list, following the 'next' links of the responses.
+func (c *Client) list(
+	ctx context.Context,
+	registry string,
+	path string,
+	scope string,
+	handlePage func(body []byte) error,
+) error {
+	for path != "" {
+		res, err := c.send(ctx, registry, path, "application/json", scope)
+		if err != nil {
+			return err
+		}
+
+		if res.StatusCode != http.StatusOK {
+			defer res.Body.Close()
+			return runtime.NewResponseError(res)
+		}
+
+		body, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		if err := handlePage(body); err != nil {
+			return fmt.Errorf("parsing response: %w", err)
+		}
+
+		path = ""
+		if match := linkNextRegex.FindStringSubmatch(res.Header.Get("Link")); match != nil {
+			path = match[1]
+		}
+	}
+
+	return nil
+}
+
+// send sends a GET request to the registry, authenticating and retrying when the registry requests it.
+// The path may also be an absolute URL, as returned by the 'next' links of paginated lists.
+func (c *Client) send(
+	ctx context.Context,
+	registry string,
+	path string,
+	accept string,
+	scope string,
+) (*http.Response, error) {
+	endpoint := path
+	if !strings.HasPrefix(path, "http://") && !strings.HasPrefix(path, "https://") {
+		endpoint = fmt.Sprintf("%s://%s%s", registryScheme(registry), registry, path)
+	}
+
+	tokenKey := registry + " " + scope
+	token, _ := c.tokens.Load(tokenKey)
+	res, err := c.get(ctx, endpoint, accept, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusUnauthorized {
+		return res, nil
+	}
+
+	challenge := res.Header.Get("WWW-Authenticate")
+	res.Body.Close()
+
+	newToken, err := c.authenticate(ctx, registry, challenge, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	c.tokens.Store(tokenKey, newToken)
+	return c.get(ctx, endpoint, accept, newToken)
+}
+
+func (c *Client) get(ctx context.Context, endpoint string, accept string, token any) (*http.Response, error) {
+	req, err := runtime.NewRequest(ctx, http.MethodGet, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	if accept != "" {
+		req.Raw().Header.Set("Accept", accept)
+	}
+
+	if token, ok := token.(string); ok && token != "" {
+		req.Raw().Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return c.pipeline.Do(req)
+}
+
+// authenticate obtains an access token answering the bearer challenge of the registry.
+// See https://distribution.github.io/distribution/spec/auth/token/
+func (c *Client) authenticate(ctx context.Context, registry string, challenge string, scope string) (string, error) {
+	scheme, params := parseChallenge(challenge)
+	if !strings.EqualFold(scheme, "bearer") || params["realm"] == "" {
+		return "", fmt.Errorf(
+			"registry '%s' requires an unsupported authentication scheme '%s'", registry, challenge)
+	}
+
+	if params["scope"] != "" {
+		scope = params["scope"]
+	}
+
+	if c.isAzureContainerRegistry(registry) {
+		log.Printf("authenticating to Azure Container Registry '%s' for scope '%s'", registry, scope)
+		return c.acrToken(ctx, registry, params["realm"], params["service"], scope)
+	}
+
+	log.Printf("authenticating anonymously to registry '%s' for scope '%s'", registry, scope)
+
+	query := url.Values{}
+	if params["service"] != "" {
+		query.Set("service", params["service"])
+	}
+	query.Set("scope", scope)
+
+	req, err := runtime.NewRequest(ctx, http.MethodGet, params["realm"]+"?"+query.Encode())
+	if err != nil {
+		return "", fmt.Errorf("creating request: %w", err)
+	}
+
+	return c.requestToken(registry, req)
+}
+
+// acrToken exchanges a token of the logged in account for an ACR refresh token, used to request an access token.
+// Implementation based on docs @ https://azure.github.io/acr/AAD-OAuth.html
+func (c *Client) acrToken(
+	ctx context.Context,
+	registry string,
+	realm string,
+	service string,
+	scope string,
+) (string, error) {
+	credential, err := c.credentialProvider.GetTokenCredential(ctx, "")
+	if err != nil {
+		return "", fmt.Errorf("getting credentials for registry '%s': %w", registry, err)
+	}
+
+	aadToken, err := credential.GetToken(ctx, policy.TokenRequestOptions{
+		Scopes: []string{
+			fmt.Sprintf("%s//.default", c.cloud.Configuration.Services[azcloud.ResourceManager].Endpoint),
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("getting token for registry '%s': %w", registry, err)
+	}
+
+	exchangeReq, err := newFormRequest(ctx, fmt.Sprintf("https://%s/oauth2/exchange", registry), url.Values{
+		"grant_type":   {"access_token"},
+		"service":      {service},
+		"access_token": {aadToken.Token},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	refreshToken, err := c.requestToken(registry, exchangeReq)
+	if err != nil {
+		return "", err
+	}
+
+	tokenReq, err := newFormRequest(ctx, realm, url.Values{
+		"grant_type":    {"refresh_token"},
+		"service":       {service},
+		"scope":         {scope},
+		"refresh_token": {refreshToken},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return c.requestToken(registry, tokenReq)
+}
+
+// requestToken sends a token request and returns the token of the response.
+func (c *Client) requestToken(registry string, req *policy.Request) (string, error) {
+	res, err := c.pipeline.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("requesting token for registry '%s': %w", registry, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("requesting token for registry '%s': %w", registry, runtime.NewResponseError(res))
+	}
+
+	body, err := httputil.ReadRawResponse[tokenResponse](res)
+	if err != nil {
+		return "", fmt.Errorf("requesting token for registry '%s': %w", registry, err)
+	}
+
+	switch {
+	case body.AccessToken != "":
+		return body.AccessToken, nil
+	case body.Token != "":
+		return body.Token, nil
+	case body.RefreshToken != "":
+		return body.RefreshToken, nil
+	default:
+		return "", fmt.Errorf("requesting token for registry '%s': no token in response", registry)
+	}
+}
+
+func (c *Client) isAzureContainerRegistry(registry string) bool {
+	if c.cloud == nil || c.cloud.ContainerRegistryEndpointSuffix == "" {
+		return false
+	}
+
+	return strings.HasSuffix(registryHost(registry), "."+c.cloud.ContainerRegistryEndpointSuffix)
+}
+
+func newFormRequest(ctx context.Context, endpoint string, form url.Values) (*policy.Request, error) {
+	req, err := runtime.NewRequest(ctx, http.MethodPost, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	body := streaming.NopCloser(strings.NewReader(form.Encode()))
+	if err := req.SetBody(body, "application/x-www-form-urlencoded"); err != nil {
+		return nil, fmt.Errorf("setting request body: %w", err)
+	}
+
+	return req, nil
+}
+
+// parseChallenge parses a WWW-Authenticate header, e.g. Bearer realm="https://host/token",service="host"
+func parseChallenge(header string) (string, map[string]string) {
+	scheme, remainder, _ := strings.Cut(strings.TrimSpace(header), " ")
+	params := map[string]string{}
+
+	for remainder = strings.TrimSpace(remainder); remainder != ""; {
+		key, value, found := strings.Cut(remainder, "=")
+		if !found {
+			break
+		}
+
+		key = strings.ToLower(strings.TrimSpace(key))
+		if strings.HasPrefix(value, `"`) {
+			// quoted values may contain commas, e.g. scope="repository:templates/api:pull,push"
+			end := strings.Index(value[1:], `"`)
+			if end == -1 {
+				params[key] = value[1:]
+				break
+			}
+
+			params[key] = value[1 : end+1]
+			remainder = value[end+2:]
+		} else {
+			params[key], remainder, _ = strings.Cut(value, ",")
+		}
+
+		remainder = strings.TrimLeft(remainder, ", ")
+	}
+
+	return scheme, params
+}
+
+// registryScheme returns the scheme used to access the registry. Like docker, registries on the local machine, such as
+// a registry:2 container, are accessed over plain HTTP.
+func registryScheme(registry string) string {
+	host := registryHost(registry)
+	if host == "localhost" {
+		return "http"
+	}
+
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return "http"
+	}
+
+	return "https"
+}
+
+func registryHost(registry string) string {
+	if host, _, err := net.SplitHostPort(registry); err == nil {
+		return host
+	}
+
+	return registry
+}
+
+func pullScope(repository string) string {
+	return fmt.Sprintf("repository:%s:pull", repository)
+}
+
+func computeDigest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
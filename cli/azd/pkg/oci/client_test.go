@@ -0,0 +1,175 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package oci
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockoci"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Client_GetManifest(t *testing.T) {
+	registry := mockoci.NewRegistry(t)
+	registry.RequireAuth = true
+
+	content := []byte("content")
+	config := []byte("{}")
+	manifest := Manifest{
+		SchemaVersion: 2,
+		MediaType:     MediaTypeImageManifest,
+		ArtifactType:  "application/vnd.test",
+		Config:        Descriptor{MediaType: "application/vnd.test.config", Digest: registry.PushBlob(config), Size: 2},
+		Layers: []Descriptor{
+			{MediaType: "application/vnd.test.layer", Digest: registry.PushBlob(content), Size: int64(len(content))},
+		},
+	}
+	digest := registry.PushManifest("templates/api", "1.2", manifest)
+
+	client := NewClient(registry.Client(), nil, nil)
+	ref := Reference{Registry: registry.Host, Repository: "templates/api", Tag: "1.2"}
+
+	actual, actualDigest, err := client.GetManifest(context.Background(), ref)
+	require.NoError(t, err)
+	require.Equal(t, digest, actualDigest)
+	require.Equal(t, manifest, *actual)
+
+	blob, err := client.GetBlob(context.Background(), ref, actual.Layers[0])
+	require.NoError(t, err)
+	require.Equal(t, content, blob)
+
+	// the token is requested once and reused for the following requests of the same scope
+	require.Equal(t, []string{"repository:templates/api:pull"}, registry.TokenScopes)
+
+	t.Run("by digest", func(t *testing.T) {
+		_, actualDigest, err := client.GetManifest(context.Background(), Reference{
+			Registry: registry.Host, Repository: "templates/api", Tag: "ignored", Digest: digest,
+		})
+		require.NoError(t, err)
+		require.Equal(t, digest, actualDigest)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, _, err := client.GetManifest(context.Background(), Reference{
+			Registry: registry.Host, Repository: "templates/api", Tag: "2.0",
+		})
+		require.ErrorContains(t, err, "404")
+	})
+
+	t.Run("blob doesn't match descriptor", func(t *testing.T) {
+		descriptor := actual.Layers[0]
+		descriptor.Size++
+
+		_, err := client.GetBlob(context.Background(), ref, descriptor)
+		require.ErrorContains(t, err, "doesn't match its descriptor")
+	})
+}
+
+func Test_Client_List(t *testing.T) {
+	registry := mockoci.NewRegistry(t)
+	registry.PageSize = 2
+
+	for _, repository := range []string{"templates/web", "templates/api", "other", "templates/worker"} {
+		registry.PushManifest(repository, "1.0", Manifest{SchemaVersion: 2})
+	}
+	for _, tag := range []string{"1.1", "2.0", "latest"} {
+		registry.PushManifest("templates/api", tag, Manifest{SchemaVersion: 2, Annotations: map[string]string{"tag": tag}})
+	}
+
+	client := NewClient(registry.Client(), nil, nil)
+
+	repositories, err := client.ListRepositories(context.Background(), registry.Host)
+	require.NoError(t, err)
+	require.Equal(t, []string{"other", "templates/api", "templates/web", "templates/worker"}, repositories)
+
+	tags, err := client.ListTags(context.Background(), registry.Host, "templates/api")
+	require.NoError(t, err)
+	require.Equal(t, []string{"1.0", "1.1", "2.0", "latest"}, tags)
+}
+
+func Test_Client_AzureContainerRegistry(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	// the credential of the home tenant issues the 'ABC123' token
+	mockContext.MultiTenantCredentialProvider.TokenMap = map[string]mocks.MockCredentials{"": {}}
+	var requests []string
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.URL.Host == "myreg.azurecr.io"
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		requests = append(requests, request.Method+" "+request.URL.Path)
+
+		switch request.URL.Path {
+		case "/oauth2/exchange":
+			require.NoError(t, request.ParseForm())
+			require.Equal(t, "access_token", request.PostForm.Get("grant_type"))
+			require.Equal(t, "ABC123", request.PostForm.Get("access_token"))
+			return response(request, http.StatusOK, `{"refresh_token":"refresh"}`), nil
+		case "/oauth2/token":
+			require.NoError(t, request.ParseForm())
+			require.Equal(t, "refresh_token", request.PostForm.Get("grant_type"))
+			require.Equal(t, "refresh", request.PostForm.Get("refresh_token"))
+			require.Equal(t, "repository:templates/api:pull", request.PostForm.Get("scope"))
+			return response(request, http.StatusOK, `{"access_token":"access"}`), nil
+		}
+
+		if request.Header.Get("Authorization") != "Bearer access" {
+			res := response(request, http.StatusUnauthorized, "")
+			res.Header.Set("WWW-Authenticate",
+				`Bearer realm="https://myreg.azurecr.io/oauth2/token",service="myreg.azurecr.io"`)
+			return res, nil
+		}
+
+		return response(request, http.StatusOK, `{"tags":["1.2"]}`), nil
+	})
+
+	client := NewClient(mockContext.HttpClient, mockContext.MultiTenantCredentialProvider, cloud.AzurePublic())
+	tags, err := client.ListTags(*mockContext.Context, "myreg.azurecr.io", "templates/api")
+	require.NoError(t, err)
+	require.Equal(t, []string{"1.2"}, tags)
+	require.Equal(t, []string{
+		"GET /v2/templates/api/tags/list",
+		"POST /oauth2/exchange",
+		"POST /oauth2/token",
+		"GET /v2/templates/api/tags/list",
+	}, requests)
+}
+
+func Test_parseChallenge(t *testing.T) {
+	scheme, params := parseChallenge(
+		`Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:a:pull,push"`)
+	require.Equal(t, "Bearer", scheme)
+	require.Equal(t, map[string]string{
+		"realm":   "https://auth.docker.io/token",
+		"service": "registry.docker.io",
+		"scope":   "repository:a:pull,push",
+	}, params)
+
+	scheme, params = parseChallenge(`Basic realm=registry`)
+	require.Equal(t, "Basic", scheme)
+	require.Equal(t, map[string]string{"realm": "registry"}, params)
+}
+
+func Test_registryScheme(t *testing.T) {
+	require.Equal(t, "http", registryScheme("localhost:5000"))
+	require.Equal(t, "http", registryScheme("127.0.0.1:5000"))
+	require.Equal(t, "http", registryScheme("[::1]:5000"))
+	require.Equal(t, "https", registryScheme("myreg.azurecr.io"))
+	require.Equal(t, "https", registryScheme("ghcr.io"))
+}
+
+func response(request *http.Request, statusCode int, body string) *http.Response {
+	return &http.Response{
+		Request:    request,
+		StatusCode: statusCode,
+		Header:     http.Header{},
+		Body:       io.NopCloser(bytes.NewBufferString(strings.TrimSpace(body))),
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+// Package oci provides a minimal client for the OCI distribution API, used to list and pull artifacts from container
+// registries.
+package oci
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Scheme is the URI scheme used to refer to OCI artifacts, e.g. oci://myregistry.azurecr.io/templates/api:1.2
+const Scheme = "oci://"
+
+var (
+	// https://github.com/opencontainers/distribution-spec/blob/main/spec.md#pulling-manifests
+	repositoryRegex = regexp.MustCompile(`^[a-z0-9]+((\.|_|__|-+)[a-z0-9]+)*(/[a-z0-9]+((\.|_|__|-+)[a-z0-9]+)*)*$`)
+	tagRegex        = regexp.MustCompile(`^[a-zA-Z0-9_][a-zA-Z0-9._-]{0,127}$`)
+	digestRegex     = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+)
+
+// Reference identifies a repository of an OCI registry and optionally an artifact within it, by tag and/or digest.
+// When both are set, the digest takes precedence.
+type Reference struct {
+	// Registry is the host (and port) of the registry, e.g. myregistry.azurecr.io or localhost:5000
+	Registry string
+	// Repository is the name of the repository within the registry, e.g. templates/api
+	Repository string
+	// Tag is the optional tag of the artifact, e.g. 1.2
+	Tag string
+	// Digest is the optional content digest of the artifact manifest, e.g. sha256:<hex>
+	Digest string
+}
+
+// IsReference returns true when the value uses the oci:// scheme.
+func IsReference(value string) bool {
+	return strings.HasPrefix(value, Scheme)
+}
+
+// ParseReference parses a reference in the form [oci://]<registry>/<repository>[:<tag>][@<digest>].
+func ParseReference(value string) (Reference, error) {
+	remainder := strings.TrimPrefix(value, Scheme)
+
+	registry, remainder, found := strings.Cut(remainder, "/")
+	if !found || registry == "" || remainder == "" {
+		return Reference{}, fmt.Errorf(
+			"invalid OCI reference '%s', expected the form '<registry>/<repository>[:<tag>][@<digest>]'", value)
+	}
+
+	ref := Reference{Registry: registry}
+
+	if before, digest, found := strings.Cut(remainder, "@"); found {
+		if !digestRegex.MatchString(digest) {
+			return Reference{}, fmt.Errorf("invalid OCI reference '%s', unsupported digest '%s'", value, digest)
+		}
+
+		ref.Digest = digest
+		remainder = before
+	}
+
+	// A ':' after the last '/' separates the tag from the repository
+	if index := strings.LastIndex(remainder, ":"); index > strings.LastIndex(remainder, "/") {
+		ref.Tag = remainder[index+1:]
+		remainder = remainder[:index]
+
+		if !tagRegex.MatchString(ref.Tag) {
+			return Reference{}, fmt.Errorf("invalid OCI reference '%s', invalid tag '%s'", value, ref.Tag)
+		}
+	}
+
+	if !repositoryRegex.MatchString(remainder) {
+		return Reference{}, fmt.Errorf("invalid OCI reference '%s', invalid repository name '%s'", value, remainder)
+	}
+
+	ref.Repository = remainder
+
+	return ref, nil
+}
+
+// Reference returns the tag or digest identifying the artifact in its repository, preferring the digest.
+// Defaults to the 'latest' tag when neither is set.
+func (r Reference) Reference() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+
+	if r.Tag != "" {
+		return r.Tag
+	}
+
+	return "latest"
+}
+
+// String returns the reference, without scheme, in the form <registry>/<repository>[:<tag>][@<digest>].
+func (r Reference) String() string {
+	var sb strings.Builder
+	sb.WriteString(r.Registry)
+	sb.WriteString("/")
+	sb.WriteString(r.Repository)
+
+	if r.Tag != "" {
+		sb.WriteString(":")
+		sb.WriteString(r.Tag)
+	}
+
+	if r.Digest != "" {
+		sb.WriteString("@")
+		sb.WriteString(r.Digest)
+	}
+
+	return sb.String()
+}
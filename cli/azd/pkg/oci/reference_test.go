@@ -0,0 +1,74 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package oci
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseReference(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected Reference
+	}{
+		{
+			name:     "tag",
+			value:    "oci://myreg.azurecr.io/templates/api:1.2",
+			expected: Reference{Registry: "myreg.azurecr.io", Repository: "templates/api", Tag: "1.2"},
+		},
+		{
+			name:     "digest",
+			value:    "oci://myreg.azurecr.io/templates/api@" + digest,
+			expected: Reference{Registry: "myreg.azurecr.io", Repository: "templates/api", Digest: digest},
+		},
+		{
+			name:  "tag and digest",
+			value: "oci://localhost:5000/api:1.2@" + digest,
+			expected: Reference{
+				Registry: "localhost:5000", Repository: "api", Tag: "1.2", Digest: digest,
+			},
+		},
+		{
+			name:     "no scheme or tag",
+			value:    "localhost:5000/templates/api",
+			expected: Reference{Registry: "localhost:5000", Repository: "templates/api"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, err := ParseReference(tt.value)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, ref)
+			require.Equal(t, strings.TrimPrefix(tt.value, Scheme), ref.String())
+		})
+	}
+
+	invalid := map[string]string{
+		"oci://myreg.azurecr.io":             "expected the form",
+		"oci://myreg.azurecr.io/API:1.2":     "invalid repository name 'API'",
+		"oci://myreg.azurecr.io/api:1.2:3":   "invalid repository name 'api:1.2'",
+		"oci://myreg.azurecr.io/api:-1":      "invalid tag '-1'",
+		"oci://myreg.azurecr.io/api@sha1:ab": "unsupported digest 'sha1:ab'",
+	}
+
+	for value, message := range invalid {
+		t.Run(value, func(t *testing.T) {
+			_, err := ParseReference(value)
+			require.ErrorContains(t, err, message)
+		})
+	}
+}
+
+func Test_Reference_Reference(t *testing.T) {
+	require.Equal(t, "latest", Reference{Repository: "api"}.Reference())
+	require.Equal(t, "1.2", Reference{Repository: "api", Tag: "1.2"}.Reference())
+	require.Equal(t, "sha256:abc", Reference{Repository: "api", Tag: "1.2", Digest: "sha256:abc"}.Reference())
+}
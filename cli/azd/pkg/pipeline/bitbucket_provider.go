@@ -0,0 +1,383 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/azure/azure-dev/cli/azd/pkg/bitbucket"
+	"github.com/azure/azure-dev/cli/azd/pkg/entraid"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/graphsdk"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
+)
+
+// BitbucketScmProvider implements ScmProvider using Bitbucket Cloud as the provider
+// for source control manager.
+type BitbucketScmProvider struct {
+	env     *environment.Environment
+	console input.Console
+	gitCli  *git.Cli
+}
+
+func NewBitbucketScmProvider(
+	env *environment.Environment,
+	console input.Console,
+	gitCli *git.Cli,
+) ScmProvider {
+	return &BitbucketScmProvider{
+		env:     env,
+		console: console,
+		gitCli:  gitCli,
+	}
+}
+
+// bitbucketRepositoryDetails provides extra state needed for the Bitbucket provider.
+// this is stored as the details property in repoDetails
+type bitbucketRepositoryDetails struct {
+	workspace string
+	repoSlug  string
+	// apiUrl is the base address of the Bitbucket REST API
+	apiUrl string
+}
+
+// ***  subareaProvider implementation ******
+
+// requiredTools return the list of external tools required by
+// Bitbucket provider during its execution.
+func (p *BitbucketScmProvider) requiredTools(_ context.Context) ([]tools.ExternalTool, error) {
+	return []tools.ExternalTool{}, nil
+}
+
+// preConfigureCheck ensures a Bitbucket access token is available.
+func (p *BitbucketScmProvider) preConfigureCheck(
+	ctx context.Context,
+	pipelineManagerArgs PipelineManagerArgs,
+	infraOptions provisioning.Options,
+	projectPath string,
+) (bool, error) {
+	_, updated, err := bitbucket.EnsureTokenExists(ctx, p.env, p.console)
+	return updated, err
+}
+
+// name returns the name of the provider
+func (p *BitbucketScmProvider) Name() string {
+	return bitbucketDisplayName
+}
+
+// ***  scmProvider implementation ******
+
+// configureGitRemote prompts the user for the url of the Bitbucket repository to use as git remote
+func (p *BitbucketScmProvider) configureGitRemote(
+	ctx context.Context,
+	repoPath string,
+	remoteName string,
+) (string, error) {
+	for {
+		remoteUrl, err := p.console.Prompt(ctx, input.ConsoleOptions{
+			Message: fmt.Sprintf("Enter the url of the Bitbucket repository to use for remote %s:", remoteName),
+		})
+		if err != nil {
+			return "", fmt.Errorf("prompting for remote url: %w", err)
+		}
+
+		if _, err := bitbucket.ParseRemote(remoteUrl); err != nil {
+			p.console.Message(ctx, fmt.Sprintf("error: \"%s\" is not a valid Bitbucket URL.\n", remoteUrl))
+			continue
+		}
+
+		return remoteUrl, nil
+	}
+}
+
+// gitRepoDetails extracts the information from a Bitbucket remote url into general scm concepts
+// like owner, name and path
+func (p *BitbucketScmProvider) gitRepoDetails(ctx context.Context, remoteUrl string) (*gitRepositoryDetails, error) {
+	remote, err := bitbucket.ParseRemote(remoteUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gitRepositoryDetails{
+		owner:    remote.Workspace,
+		repoName: remote.RepoSlug,
+		remote:   remoteUrl,
+		url:      remote.WebUrl(),
+		details: &bitbucketRepositoryDetails{
+			workspace: remote.Workspace,
+			repoSlug:  remote.RepoSlug,
+			apiUrl:    bitbucket.ApiUrl,
+		},
+	}, nil
+}
+
+// preventGitPush is nil for Bitbucket
+func (p *BitbucketScmProvider) preventGitPush(
+	ctx context.Context,
+	gitRepo *gitRepositoryDetails,
+	remoteName string,
+	branchName string) (bool, error) {
+	return false, nil
+}
+
+func (p *BitbucketScmProvider) GitPush(
+	ctx context.Context,
+	gitRepo *gitRepositoryDetails,
+	remoteName string,
+	branchName string) error {
+	return p.gitCli.PushUpstream(ctx, gitRepo.gitProjectPath, remoteName, branchName)
+}
+
+// BitbucketCiProvider implements a CiProvider using Bitbucket Pipelines to run the pipeline defined in
+// bitbucket-pipelines.yml.
+type BitbucketCiProvider struct {
+	env           *environment.Environment
+	console       input.Console
+	clientOptions *azcore.ClientOptions
+}
+
+func NewBitbucketCiProvider(
+	env *environment.Environment,
+	console input.Console,
+	clientOptions *azcore.ClientOptions,
+) CiProvider {
+	return &BitbucketCiProvider{
+		env:           env,
+		console:       console,
+		clientOptions: clientOptions,
+	}
+}
+
+// ***  subareaProvider implementation ******
+
+// requiredTools defines the requires tools for Bitbucket to be used as CI manager
+func (p *BitbucketCiProvider) requiredTools(_ context.Context) ([]tools.ExternalTool, error) {
+	return []tools.ExternalTool{}, nil
+}
+
+// preConfigureCheck validates a Bitbucket access token is available and the authentication type is supported.
+// The subject of the OIDC tokens issued by Bitbucket Pipelines contains the unique id of the pipeline step, which
+// can't be matched by a federated identity credential, so only client credentials are supported.
+func (p *BitbucketCiProvider) preConfigureCheck(
+	ctx context.Context,
+	pipelineManagerArgs PipelineManagerArgs,
+	infraOptions provisioning.Options,
+	projectPath string,
+) (bool, error) {
+	_, updated, err := bitbucket.EnsureTokenExists(ctx, p.env, p.console)
+	if err != nil {
+		return updated, err
+	}
+
+	authType := PipelineAuthType(pipelineManagerArgs.PipelineAuthTypeName)
+	if authType == AuthTypeFederated {
+		return false, fmt.Errorf(
+			//nolint:lll
+			"Bitbucket Pipelines does not support federated authentication. To explicitly use client credentials set the %s flag. %w",
+			output.WithBackticks("--auth-type client-credentials"),
+			ErrAuthNotSupported,
+		)
+	} else if authType == "" {
+		p.console.MessageUxItem(
+			ctx,
+			&ux.WarningMessage{
+				Description: "Bitbucket Pipelines does not support federated authentication, " +
+					"defaulting to Service Principal with client ID and client secret.\n",
+			},
+		)
+	}
+
+	return updated, nil
+}
+
+// name returns the name of the provider.
+func (p *BitbucketCiProvider) Name() string {
+	return bitbucketDisplayName
+}
+
+// ***  ciProvider implementation ******
+
+// credentialOptions always enables client credentials for Bitbucket Pipelines
+func (p *BitbucketCiProvider) credentialOptions(
+	ctx context.Context,
+	repoDetails *gitRepositoryDetails,
+	infraOptions provisioning.Options,
+	authType PipelineAuthType,
+	credentials *entraid.AzureCredentials,
+) (*CredentialOptions, error) {
+	return &CredentialOptions{
+		EnableClientCredentials: true,
+	}, nil
+}
+
+// configureConnection sets the repository variables the pipeline uses to log in to Azure
+func (p *BitbucketCiProvider) configureConnection(
+	ctx context.Context,
+	repoDetails *gitRepositoryDetails,
+	infraOptions provisioning.Options,
+	servicePrincipal *graphsdk.ServicePrincipal,
+	credentialOptions *CredentialOptions,
+	credentials *entraid.AzureCredentials,
+) error {
+	client, details, err := p.bitbucketClient(ctx, repoDetails)
+	if err != nil {
+		return err
+	}
+
+	variables, secrets, err := azureConnectionValues(
+		ctx, p.console, p.env, infraOptions, servicePrincipal, credentialOptions, credentials)
+	if err != nil {
+		return err
+	}
+
+	existingVariables, err := client.ListVariables(ctx, details.workspace, details.repoSlug)
+	if err != nil {
+		return fmt.Errorf("unable to get list of repository variables: %w", err)
+	}
+
+	if err := p.setVariables(ctx, client, details, existingVariables, variables, secrets); err != nil {
+		return fmt.Errorf("failed setting pipeline variables: %w", err)
+	}
+
+	return nil
+}
+
+// configurePipeline enables Bitbucket Pipelines for the repository and sets the project variables and secrets as
+// repository variables. The pipeline itself is defined by the bitbucket-pipelines.yml file and runs when the changes
+// are pushed.
+func (p *BitbucketCiProvider) configurePipeline(
+	ctx context.Context,
+	repoDetails *gitRepositoryDetails,
+	options *configurePipelineOptions,
+) (CiPipeline, error) {
+	client, details, err := p.bitbucketClient(ctx, repoDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.EnablePipelines(ctx, details.workspace, details.repoSlug); err != nil {
+		return nil, err
+	}
+
+	existingVariables, err := client.ListVariables(ctx, details.workspace, details.repoSlug)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get list of repository variables: %w", err)
+	}
+
+	// Clean up the previous values of the project variables and secrets (azure.yaml) which are no longer set,
+	// so values unset from .env don't leak to the pipeline.
+	projectValues := slices.Concat(options.projectVariables, options.projectSecrets)
+	remainingVariables := []bitbucket.Variable{}
+	for _, existingVariable := range existingVariables {
+		_, isVariable := options.variables[existingVariable.Key]
+		_, isSecret := options.secrets[existingVariable.Key]
+		if isVariable || isSecret || !slices.Contains(projectValues, existingVariable.Key) {
+			remainingVariables = append(remainingVariables, existingVariable)
+			continue
+		}
+
+		if err := client.DeleteVariable(ctx, details.workspace, details.repoSlug, existingVariable.Uuid); err != nil {
+			return nil, fmt.Errorf("failed deleting %s variable: %w", existingVariable.Key, err)
+		}
+	}
+
+	if err := p.setVariables(
+		ctx, client, details, remainingVariables, options.variables, options.secrets); err != nil {
+		return nil, err
+	}
+
+	p.console.MessageUxItem(ctx, &ux.MultilineMessage{
+		Lines: []string{
+			"",
+			"Bitbucket repository variables are now configured. You can view the variables that were created at this link:",
+			output.WithLinkFormat("%s/admin/pipelines/repository-variables", repoDetails.url),
+			""},
+	})
+
+	return &bitbucketPipeline{
+		repoDetails: repoDetails,
+	}, nil
+}
+
+// setVariables creates or updates the repository variables. Secrets are stored as secured variables.
+func (p *BitbucketCiProvider) setVariables(
+	ctx context.Context,
+	client *bitbucket.Client,
+	details *bitbucketRepositoryDetails,
+	existingVariables []bitbucket.Variable,
+	variables map[string]string,
+	secrets map[string]string,
+) error {
+	existingUuids := map[string]string{}
+	for _, variable := range existingVariables {
+		existingUuids[variable.Key] = variable.Uuid
+	}
+
+	setVariable := func(variable bitbucket.Variable) error {
+		if uuid, has := existingUuids[variable.Key]; has {
+			variable.Uuid = uuid
+			return client.UpdateVariable(ctx, details.workspace, details.repoSlug, variable)
+		}
+
+		return client.CreateVariable(ctx, details.workspace, details.repoSlug, variable)
+	}
+
+	for key, value := range variables {
+		if err := setVariable(bitbucket.Variable{Key: key, Value: value}); err != nil {
+			return fmt.Errorf("failed setting %s variable: %w", key, err)
+		}
+		p.console.MessageUxItem(ctx, &ux.CreatedRepoValue{
+			Name: key,
+			Kind: ux.GitHubVariable,
+		})
+	}
+
+	for key, value := range secrets {
+		if err := setVariable(bitbucket.Variable{Key: key, Value: value, Secured: true}); err != nil {
+			return fmt.Errorf("failed setting %s secret: %w", key, err)
+		}
+		p.console.MessageUxItem(ctx, &ux.CreatedRepoValue{
+			Name: key,
+			Kind: ux.GitHubSecret,
+		})
+	}
+
+	return nil
+}
+
+// bitbucketClient returns a client for the Bitbucket API
+func (p *BitbucketCiProvider) bitbucketClient(
+	ctx context.Context,
+	repoDetails *gitRepositoryDetails,
+) (*bitbucket.Client, *bitbucketRepositoryDetails, error) {
+	details := repoDetails.details.(*bitbucketRepositoryDetails)
+
+	token, _, err := bitbucket.EnsureTokenExists(ctx, p.env, p.console)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return bitbucket.NewClient(details.apiUrl, token, p.clientOptions), details, nil
+}
+
+// bitbucketPipeline is the implementation for a CiPipeline for Bitbucket
+type bitbucketPipeline struct {
+	repoDetails *gitRepositoryDetails
+}
+
+func (p *bitbucketPipeline) name() string {
+	return "pipelines"
+}
+
+func (p *bitbucketPipeline) url() string {
+	return p.repoDetails.url + "/pipelines"
+}
@@ -0,0 +1,211 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/azure/azure-dev/cli/azd/pkg/bitbucket"
+	"github.com/azure/azure-dev/cli/azd/pkg/entraid"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/graphsdk"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_bitbucket_provider_getRepoDetails(t *testing.T) {
+	t.Run("https", func(t *testing.T) {
+		provider := &BitbucketScmProvider{}
+		details, err := provider.gitRepoDetails(context.Background(), "https://user@bitbucket.org/contoso/todo.git")
+		require.NoError(t, err)
+		require.Equal(t, "contoso", details.owner)
+		require.Equal(t, "todo", details.repoName)
+		require.Equal(t, "https://bitbucket.org/contoso/todo", details.url)
+		require.Equal(t, bitbucket.ApiUrl, details.details.(*bitbucketRepositoryDetails).apiUrl)
+	})
+	t.Run("error", func(t *testing.T) {
+		provider := &BitbucketScmProvider{}
+		details, err := provider.gitRepoDetails(context.Background(), "https://github.com/Azure/azure-dev.git")
+		require.ErrorIs(t, err, bitbucket.ErrRemoteHostIsNotBitbucket)
+		require.Nil(t, details)
+	})
+}
+
+func Test_bitbucket_provider_preConfigure_check(t *testing.T) {
+	env := environment.NewWithValues("test", map[string]string{
+		bitbucket.BitbucketTokenName: "token",
+	})
+
+	t.Run("fails with federated", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		provider := NewBitbucketCiProvider(env, mockContext.Console, nil)
+		_, err := provider.preConfigureCheck(
+			*mockContext.Context,
+			PipelineManagerArgs{PipelineAuthTypeName: string(AuthTypeFederated)},
+			provisioning.Options{},
+			"",
+		)
+		require.ErrorIs(t, err, ErrAuthNotSupported)
+	})
+
+	t.Run("warning with default value", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		provider := NewBitbucketCiProvider(env, mockContext.Console, nil)
+		updated, err := provider.preConfigureCheck(*mockContext.Context, PipelineManagerArgs{}, provisioning.Options{}, "")
+		require.NoError(t, err)
+		require.False(t, updated)
+
+		consoleLog := mockContext.Console.Output()
+		require.Len(t, consoleLog, 1)
+		require.Contains(t, consoleLog[0], "Bitbucket Pipelines does not support federated authentication")
+	})
+}
+
+func Test_bitbucket_provider_configurePipeline(t *testing.T) {
+	server := newFakeBitbucketServer(t)
+	server.variables["STALE_VARIABLE"] = bitbucket.Variable{Uuid: "{stale}", Key: "STALE_VARIABLE", Value: "old"}
+	server.variables["VAR_1"] = bitbucket.Variable{Uuid: "{var1}", Key: "VAR_1", Value: "old"}
+	server.variables["UNMANAGED_VARIABLE"] = bitbucket.Variable{Uuid: "{unmanaged}", Key: "UNMANAGED_VARIABLE"}
+
+	mockContext := mocks.NewMockContext(context.Background())
+	env := environment.NewWithValues("test", map[string]string{
+		bitbucket.BitbucketTokenName: "token",
+	})
+
+	provider := NewBitbucketCiProvider(env, mockContext.Console, nil)
+	repoDetails := server.repoDetails()
+
+	err := provider.configureConnection(
+		*mockContext.Context,
+		repoDetails,
+		provisioning.Options{Provider: provisioning.Bicep},
+		&graphsdk.ServicePrincipal{AppId: "CLIENT_ID", AppOwnerOrganizationId: to.Ptr("TENANT_ID")},
+		&CredentialOptions{EnableClientCredentials: true},
+		&entraid.AzureCredentials{ClientSecret: "CLIENT_SECRET"},
+	)
+	require.NoError(t, err)
+
+	pipeline, err := provider.configurePipeline(*mockContext.Context, repoDetails, &configurePipelineOptions{
+		variables:        map[string]string{"VAR_1": "value"},
+		secrets:          map[string]string{"SECRET_1": "secret"},
+		projectVariables: []string{"VAR_1", "STALE_VARIABLE"},
+		projectSecrets:   []string{"SECRET_1"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "https://bitbucket.org/contoso/todo/pipelines", pipeline.url())
+	require.True(t, server.pipelinesEnabled)
+
+	require.Equal(t, "CLIENT_ID", server.variables["AZURE_CLIENT_ID"].Value)
+	require.Equal(t, "TENANT_ID", server.variables[environment.TenantIdEnvVarName].Value)
+	require.Equal(t, "CLIENT_SECRET", server.variables["AZURE_CLIENT_SECRET"].Value)
+	require.True(t, server.variables["AZURE_CLIENT_SECRET"].Secured)
+
+	// existing variables are updated, variables of azure.yaml no longer set are removed and other variables of the
+	// repository are kept
+	require.Equal(t, bitbucket.Variable{Uuid: "{var1}", Key: "VAR_1", Value: "value"}, server.variables["VAR_1"])
+	require.NotContains(t, server.variables, "STALE_VARIABLE")
+	require.Contains(t, server.variables, "UNMANAGED_VARIABLE")
+	require.True(t, server.variables["SECRET_1"].Secured)
+}
+
+// fakeBitbucketServer implements the Bitbucket Pipelines configuration API for a single repository
+type fakeBitbucketServer struct {
+	*httptest.Server
+	mu               sync.Mutex
+	pipelinesEnabled bool
+	variables        map[string]bitbucket.Variable
+}
+
+func newFakeBitbucketServer(t *testing.T) *fakeBitbucketServer {
+	fake := &fakeBitbucketServer{
+		variables: map[string]bitbucket.Variable{},
+	}
+
+	const configPath = "/2.0/repositories/contoso/todo/pipelines_config"
+	const variablesPath = configPath + "/variables"
+
+	fake.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+		uuid := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, variablesPath), "/")
+		findKey := func() (string, bool) {
+			for key, variable := range fake.variables {
+				if variable.Uuid == uuid {
+					return key, true
+				}
+			}
+			return "", false
+		}
+
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == configPath:
+			fake.pipelinesEnabled = true
+		case r.Method == http.MethodGet && r.URL.Path == variablesPath:
+			page := struct {
+				Values []bitbucket.Variable `json:"values"`
+			}{}
+			for _, variable := range fake.variables {
+				// values of secured variables are never returned
+				if variable.Secured {
+					variable.Value = ""
+				}
+				page.Values = append(page.Values, variable)
+			}
+			_ = json.NewEncoder(w).Encode(page)
+		case r.Method == http.MethodPost && r.URL.Path == variablesPath:
+			var variable bitbucket.Variable
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&variable))
+			require.NotContains(t, fake.variables, variable.Key)
+			variable.Uuid = fmt.Sprintf("{%s}", strings.ToLower(variable.Key))
+			fake.variables[variable.Key] = variable
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodPut || r.Method == http.MethodDelete:
+			key, has := findKey()
+			if !has {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			if r.Method == http.MethodDelete {
+				delete(fake.variables, key)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			var variable bitbucket.Variable
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&variable))
+			fake.variables[key] = variable
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(fake.Close)
+
+	return fake
+}
+
+func (f *fakeBitbucketServer) repoDetails() *gitRepositoryDetails {
+	return &gitRepositoryDetails{
+		owner:    "contoso",
+		repoName: "todo",
+		url:      "https://bitbucket.org/contoso/todo",
+		details: &bitbucketRepositoryDetails{
+			workspace: "contoso",
+			repoSlug:  "todo",
+			apiUrl:    f.URL + "/2.0",
+		},
+	}
+}
@@ -0,0 +1,383 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/azure/azure-dev/cli/azd/pkg/entraid"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/graphsdk"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
+)
+
+const (
+	// environment variables holding the OIDC token claims of the CI/CD system used with the export provider.
+	// Federated credentials are created for the issuer and subject when they are set.
+	exportOidcIssuerEnvVarName   = "AZD_PIPELINE_OIDC_ISSUER"
+	exportOidcSubjectEnvVarName  = "AZD_PIPELINE_OIDC_SUBJECT"
+	exportOidcAudienceEnvVarName = "AZD_PIPELINE_OIDC_AUDIENCE"
+
+	// exportStdoutFile is the export file writing the pipeline configuration to the standard output
+	exportStdoutFile = "-"
+)
+
+// ExportScmProvider implements ScmProvider for exporting the pipeline configuration. It never calls the API of the
+// source control manager and reads the repository details from the git remote when one is available.
+type ExportScmProvider struct{}
+
+func NewExportScmProvider() ScmProvider {
+	return &ExportScmProvider{}
+}
+
+// ***  subareaProvider implementation ******
+
+// requiredTools return the list of external tools required by
+// the export provider during its execution.
+func (p *ExportScmProvider) requiredTools(_ context.Context) ([]tools.ExternalTool, error) {
+	return []tools.ExternalTool{}, nil
+}
+
+// preConfigureCheck nil for export
+func (p *ExportScmProvider) preConfigureCheck(
+	ctx context.Context,
+	pipelineManagerArgs PipelineManagerArgs,
+	infraOptions provisioning.Options,
+	projectPath string,
+) (bool, error) {
+	return false, nil
+}
+
+// name returns the name of the provider
+func (p *ExportScmProvider) Name() string {
+	return exportDisplayName
+}
+
+// ***  scmProvider implementation ******
+
+// configureGitRemote is not supported by the export provider, a git remote is not required to export the
+// pipeline configuration.
+func (p *ExportScmProvider) configureGitRemote(
+	ctx context.Context,
+	repoPath string,
+	remoteName string,
+) (string, error) {
+	return "", errors.New("the export provider doesn't configure git remotes")
+}
+
+// gitRepoDetails extracts the owner and name of the repository from a git remote of any host
+func (p *ExportScmProvider) gitRepoDetails(ctx context.Context, remoteUrl string) (*gitRepositoryDetails, error) {
+	parsed, err := git.ParseRemoteUrl(remoteUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, repoName := path.Split(parsed.Path)
+	return &gitRepositoryDetails{
+		owner:    strings.TrimSuffix(owner, "/"),
+		repoName: repoName,
+		remote:   remoteUrl,
+		url:      fmt.Sprintf("https://%s/%s", parsed.Host, parsed.Path),
+	}, nil
+}
+
+// preventGitPush always prevents pushing, the exported configuration must be applied before running the pipeline
+func (p *ExportScmProvider) preventGitPush(
+	ctx context.Context,
+	gitRepo *gitRepositoryDetails,
+	remoteName string,
+	branchName string) (bool, error) {
+	return true, nil
+}
+
+func (p *ExportScmProvider) GitPush(
+	ctx context.Context,
+	gitRepo *gitRepositoryDetails,
+	remoteName string,
+	branchName string) error {
+	return errors.New("the export provider doesn't push changes")
+}
+
+// ExportCiProvider implements a CiProvider writing the variables and secrets of the pipeline to a file or to the
+// standard output, for CI/CD systems azd can't configure.
+type ExportCiProvider struct {
+	envManager environment.Manager
+	env        *environment.Environment
+	console    input.Console
+	args       *PipelineManagerArgs
+
+	// the connection values and credentials set by configureConnection, exported with the pipeline values
+	connectionVariables  map[string]string
+	connectionSecrets    map[string]string
+	federatedCredentials []*graphsdk.FederatedIdentityCredential
+}
+
+func NewExportCiProvider(
+	envManager environment.Manager,
+	env *environment.Environment,
+	console input.Console,
+	args *PipelineManagerArgs,
+) CiProvider {
+	return &ExportCiProvider{
+		envManager: envManager,
+		env:        env,
+		console:    console,
+		args:       args,
+	}
+}
+
+// exportedPipelineConfig is the payload written by the export provider
+type exportedPipelineConfig struct {
+	AuthType             PipelineAuthType              `json:"authType"`
+	FederatedCredentials []exportedFederatedCredential `json:"federatedCredentials,omitempty"`
+	Variables            map[string]string             `json:"variables"`
+	Secrets              map[string]string             `json:"secrets"`
+}
+
+type exportedFederatedCredential struct {
+	Issuer    string   `json:"issuer"`
+	Subject   string   `json:"subject"`
+	Audiences []string `json:"audiences"`
+}
+
+// ***  subareaProvider implementation ******
+
+// requiredTools defines the requires tools for the export provider
+func (p *ExportCiProvider) requiredTools(_ context.Context) ([]tools.ExternalTool, error) {
+	return []tools.ExternalTool{}, nil
+}
+
+// preConfigureCheck validates the authentication type and ensures the OIDC issuer and subject of the CI/CD system
+// are known when federated credentials are used.
+func (p *ExportCiProvider) preConfigureCheck(
+	ctx context.Context,
+	pipelineManagerArgs PipelineManagerArgs,
+	infraOptions provisioning.Options,
+	projectPath string,
+) (bool, error) {
+	// the exported secrets are only written to the standard output when requested explicitly
+	if pipelineManagerArgs.PipelineExportFile == "" {
+		return false, fmt.Errorf(
+			"the export provider requires the file to write the pipeline variables and secrets to, set the %s flag "+
+				"to a file, or to %s to write them to the standard output",
+			output.WithBackticks("--export-file"),
+			output.WithBackticks(exportStdoutFile),
+		)
+	}
+
+	authType := p.resolveAuthType(PipelineAuthType(pipelineManagerArgs.PipelineAuthTypeName), infraOptions)
+	if authType != AuthTypeFederated {
+		return false, nil
+	}
+
+	if infraOptions.Provider == provisioning.Terraform {
+		return false, fmt.Errorf(
+			//nolint:lll
+			"Terraform does not support federated authentication. To explicitly use client credentials set the %s flag. %w",
+			output.WithBackticks("--auth-type client-credentials"),
+			ErrAuthNotSupported,
+		)
+	}
+
+	updated := false
+	prompts := []struct {
+		key     string
+		message string
+	}{
+		{exportOidcIssuerEnvVarName, "Enter the issuer of the OIDC tokens of your CI/CD system:"},
+		{exportOidcSubjectEnvVarName, "Enter the subject of the OIDC tokens of your CI/CD system:"},
+	}
+
+	for _, prompt := range prompts {
+		if value, has := p.env.LookupEnv(prompt.key); has && value != "" {
+			continue
+		}
+
+		value, err := p.console.Prompt(ctx, input.ConsoleOptions{
+			Message: prompt.message,
+		})
+		if err != nil {
+			return updated, fmt.Errorf("prompting for %s: %w", prompt.key, err)
+		}
+
+		p.env.DotenvSet(prompt.key, value)
+		if err := p.envManager.Save(ctx, p.env); err != nil {
+			return updated, fmt.Errorf("saving environment: %w", err)
+		}
+		updated = true
+	}
+
+	return updated, nil
+}
+
+// name returns the name of the provider.
+func (p *ExportCiProvider) Name() string {
+	return exportDisplayName
+}
+
+// ***  ciProvider implementation ******
+
+// credentialOptions returns a federated credential for the OIDC issuer and subject of the CI/CD system, or client
+// credentials when they are not configured.
+func (p *ExportCiProvider) credentialOptions(
+	ctx context.Context,
+	repoDetails *gitRepositoryDetails,
+	infraOptions provisioning.Options,
+	authType PipelineAuthType,
+	credentials *entraid.AzureCredentials,
+) (*CredentialOptions, error) {
+	authType = p.resolveAuthType(authType, infraOptions)
+
+	if authType == AuthTypeClientCredentials {
+		return &CredentialOptions{
+			EnableClientCredentials: true,
+		}, nil
+	}
+
+	audience := p.env.Getenv(exportOidcAudienceEnvVarName)
+	if audience == "" {
+		audience = federatedIdentityAudience
+	}
+
+	return &CredentialOptions{
+		EnableFederatedCredentials: true,
+		FederatedCredentialOptions: []*graphsdk.FederatedIdentityCredential{
+			{
+				Name:        url.PathEscape(fmt.Sprintf("azd-export-%s", p.env.Name())),
+				Issuer:      p.env.Getenv(exportOidcIssuerEnvVarName),
+				Subject:     p.env.Getenv(exportOidcSubjectEnvVarName),
+				Description: to.Ptr("Created by Azure Developer CLI"),
+				Audiences:   []string{audience},
+			},
+		},
+	}, nil
+}
+
+// configureConnection collects the values the pipeline uses to log in to Azure
+func (p *ExportCiProvider) configureConnection(
+	ctx context.Context,
+	repoDetails *gitRepositoryDetails,
+	infraOptions provisioning.Options,
+	servicePrincipal *graphsdk.ServicePrincipal,
+	credentialOptions *CredentialOptions,
+	credentials *entraid.AzureCredentials,
+) error {
+	variables, secrets, err := azureConnectionValues(
+		ctx, p.console, p.env, infraOptions, servicePrincipal, credentialOptions, credentials)
+	if err != nil {
+		return err
+	}
+
+	p.connectionVariables = variables
+	p.connectionSecrets = secrets
+	p.federatedCredentials = credentialOptions.FederatedCredentialOptions
+	return nil
+}
+
+// configurePipeline writes the variables and secrets of the pipeline to the export file, or to the standard output
+// when the export file is exportStdoutFile.
+func (p *ExportCiProvider) configurePipeline(
+	ctx context.Context,
+	repoDetails *gitRepositoryDetails,
+	options *configurePipelineOptions,
+) (CiPipeline, error) {
+	exported := exportedPipelineConfig{
+		AuthType:  AuthTypeClientCredentials,
+		Variables: maps.Clone(p.connectionVariables),
+		Secrets:   maps.Clone(p.connectionSecrets),
+	}
+	maps.Copy(exported.Variables, options.variables)
+	maps.Copy(exported.Secrets, options.secrets)
+
+	for _, credential := range p.federatedCredentials {
+		exported.AuthType = AuthTypeFederated
+		exported.FederatedCredentials = append(exported.FederatedCredentials, exportedFederatedCredential{
+			Issuer:    credential.Issuer,
+			Subject:   credential.Subject,
+			Audiences: credential.Audiences,
+		})
+	}
+
+	payload, err := json.MarshalIndent(exported, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshalling pipeline configuration: %w", err)
+	}
+	payload = append(payload, '\n')
+
+	if p.args.PipelineExportFile == exportStdoutFile {
+		if _, err := p.console.Handles().Stdout.Write(payload); err != nil {
+			return nil, fmt.Errorf("writing pipeline configuration: %w", err)
+		}
+
+		return &exportPipeline{}, nil
+	}
+
+	exportPath, err := filepath.Abs(p.args.PipelineExportFile)
+	if err != nil {
+		return nil, err
+	}
+
+	// the payload contains secrets, only the current user can read it
+	if err := os.WriteFile(exportPath, payload, osutil.PermissionFileOwnerOnly); err != nil {
+		return nil, fmt.Errorf("writing pipeline configuration: %w", err)
+	}
+
+	p.console.MessageUxItem(ctx, &ux.WarningMessage{
+		Description: fmt.Sprintf(
+			"%s contains the secrets of the pipeline. Delete it once they are set on your CI/CD system.",
+			output.WithHighLightFormat(exportPath),
+		),
+	})
+
+	return &exportPipeline{
+		path: exportPath,
+	}, nil
+}
+
+// resolveAuthType returns the authentication type to use. Unless set explicitly, federated credentials are used
+// when the OIDC issuer of the CI/CD system is configured.
+func (p *ExportCiProvider) resolveAuthType(
+	authType PipelineAuthType,
+	infraOptions provisioning.Options,
+) PipelineAuthType {
+	if authType != "" {
+		return authType
+	}
+
+	if infraOptions.Provider != provisioning.Terraform && p.env.Getenv(exportOidcIssuerEnvVarName) != "" {
+		return AuthTypeFederated
+	}
+
+	return AuthTypeClientCredentials
+}
+
+// exportPipeline is the implementation for a CiPipeline for the export provider
+type exportPipeline struct {
+	// path is the file the configuration was exported to, empty when written to the standard output
+	path string
+}
+
+func (p *exportPipeline) name() string {
+	return "export"
+}
+
+func (p *exportPipeline) url() string {
+	return p.path
+}
@@ -0,0 +1,202 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/azure/azure-dev/cli/azd/pkg/entraid"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/graphsdk"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockenv"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_export_provider_getRepoDetails(t *testing.T) {
+	remotes := map[string]string{
+		"https": "https://git.contoso.com/team/apps/todo.git",
+		"scp":   "git@git.contoso.com:team/apps/todo.git",
+	}
+
+	for name, remoteUrl := range remotes {
+		t.Run(name, func(t *testing.T) {
+			provider := &ExportScmProvider{}
+			details, err := provider.gitRepoDetails(context.Background(), remoteUrl)
+			require.NoError(t, err)
+			require.Equal(t, "team/apps", details.owner)
+			require.Equal(t, "todo", details.repoName)
+			require.Equal(t, "https://git.contoso.com/team/apps/todo", details.url)
+		})
+	}
+}
+
+func Test_export_provider_credentialOptions(t *testing.T) {
+	t.Run("client credentials by default", func(t *testing.T) {
+		provider := NewExportCiProvider(nil, environment.New("test"), nil, &PipelineManagerArgs{})
+		options, err := provider.credentialOptions(
+			context.Background(), &gitRepositoryDetails{}, provisioning.Options{}, "", &entraid.AzureCredentials{})
+		require.NoError(t, err)
+		require.True(t, options.EnableClientCredentials)
+		require.False(t, options.EnableFederatedCredentials)
+	})
+
+	t.Run("federated when issuer is set", func(t *testing.T) {
+		env := environment.NewWithValues("test", map[string]string{
+			exportOidcIssuerEnvVarName:  "https://ci.contoso.com",
+			exportOidcSubjectEnvVarName: "repo:todo:ref:main",
+		})
+		provider := NewExportCiProvider(nil, env, nil, &PipelineManagerArgs{})
+		options, err := provider.credentialOptions(
+			context.Background(), &gitRepositoryDetails{}, provisioning.Options{}, "", &entraid.AzureCredentials{})
+		require.NoError(t, err)
+		require.True(t, options.EnableFederatedCredentials)
+		require.False(t, options.EnableClientCredentials)
+		require.Len(t, options.FederatedCredentialOptions, 1)
+
+		credential := options.FederatedCredentialOptions[0]
+		require.Equal(t, "azd-export-test", credential.Name)
+		require.Equal(t, "https://ci.contoso.com", credential.Issuer)
+		require.Equal(t, "repo:todo:ref:main", credential.Subject)
+		require.Equal(t, []string{federatedIdentityAudience}, credential.Audiences)
+	})
+}
+
+func Test_export_provider_preConfigure_check(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.Console.WhenPrompt(func(options input.ConsoleOptions) bool {
+		return true
+	}).RespondFn(func(options input.ConsoleOptions) (any, error) {
+		if options.Message == "Enter the issuer of the OIDC tokens of your CI/CD system:" {
+			return "https://ci.contoso.com", nil
+		}
+		return "repo:todo:ref:main", nil
+	})
+
+	env := environment.New("test")
+	envManager := &mockenv.MockEnvManager{}
+	envManager.On("Save", mock.Anything, env).Return(nil)
+
+	provider := NewExportCiProvider(envManager, env, mockContext.Console, &PipelineManagerArgs{})
+	_, err := provider.preConfigureCheck(*mockContext.Context, PipelineManagerArgs{}, provisioning.Options{}, "")
+	require.ErrorContains(t, err, "--export-file")
+
+	updated, err := provider.preConfigureCheck(
+		*mockContext.Context,
+		PipelineManagerArgs{PipelineAuthTypeName: string(AuthTypeFederated), PipelineExportFile: "pipeline.json"},
+		provisioning.Options{},
+		"",
+	)
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	// the claims are saved to the environment for the next runs
+	require.Equal(t, "https://ci.contoso.com", env.Getenv(exportOidcIssuerEnvVarName))
+	require.Equal(t, "repo:todo:ref:main", env.Getenv(exportOidcSubjectEnvVarName))
+	envManager.AssertNumberOfCalls(t, "Save", 2)
+}
+
+func Test_export_provider_configurePipeline(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	exportFile := filepath.Join(t.TempDir(), "pipeline.json")
+	env := environment.NewWithValues("test", map[string]string{
+		environment.LocationEnvVarName:       "eastus2",
+		environment.SubscriptionIdEnvVarName: "SUBSCRIPTION_ID",
+	})
+
+	provider := NewExportCiProvider(nil, env, mockContext.Console, &PipelineManagerArgs{
+		PipelineExportFile: exportFile,
+	})
+
+	err := provider.configureConnection(
+		*mockContext.Context,
+		&gitRepositoryDetails{},
+		provisioning.Options{Provider: provisioning.Bicep},
+		&graphsdk.ServicePrincipal{AppId: "CLIENT_ID", AppOwnerOrganizationId: to.Ptr("TENANT_ID")},
+		&CredentialOptions{EnableClientCredentials: true},
+		&entraid.AzureCredentials{ClientSecret: "CLIENT_SECRET"},
+	)
+	require.NoError(t, err)
+
+	pipeline, err := provider.configurePipeline(*mockContext.Context, &gitRepositoryDetails{}, &configurePipelineOptions{
+		variables: map[string]string{"VAR_1": "value"},
+		secrets: map[string]string{
+			"SECRET_1": "secret",
+			environment.AzdInitialEnvironmentConfigName: "{}",
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, exportFile, pipeline.url())
+
+	info, err := os.Stat(exportFile)
+	require.NoError(t, err)
+	if runtime.GOOS != "windows" {
+		require.Equal(t, osutil.PermissionFileOwnerOnly, info.Mode().Perm())
+	}
+
+	content, err := os.ReadFile(exportFile)
+	require.NoError(t, err)
+
+	var exported exportedPipelineConfig
+	require.NoError(t, json.Unmarshal(content, &exported))
+	require.Equal(t, AuthTypeClientCredentials, exported.AuthType)
+	require.Empty(t, exported.FederatedCredentials)
+	require.Equal(t, map[string]string{
+		environment.EnvNameEnvVarName:        "test",
+		environment.LocationEnvVarName:       "eastus2",
+		environment.SubscriptionIdEnvVarName: "SUBSCRIPTION_ID",
+		environment.TenantIdEnvVarName:       "TENANT_ID",
+		"AZURE_CLIENT_ID":                    "CLIENT_ID",
+		"VAR_1":                              "value",
+	}, exported.Variables)
+	require.Equal(t, map[string]string{
+		"AZURE_CLIENT_SECRET": "CLIENT_SECRET",
+		"SECRET_1":            "secret",
+		environment.AzdInitialEnvironmentConfigName: "{}",
+	}, exported.Secrets)
+}
+
+func Test_export_provider_configurePipeline_stdout(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	args := &PipelineManagerArgs{PipelineExportFile: exportStdoutFile}
+	env := environment.NewWithValues("test", map[string]string{
+		environment.LocationEnvVarName:       "eastus2",
+		environment.SubscriptionIdEnvVarName: "SUBSCRIPTION_ID",
+	})
+	provider := NewExportCiProvider(nil, env, mockContext.Console, args)
+
+	_, err := provider.preConfigureCheck(*mockContext.Context, *args, provisioning.Options{}, "")
+	require.NoError(t, err)
+
+	err = provider.configureConnection(
+		*mockContext.Context,
+		&gitRepositoryDetails{},
+		provisioning.Options{Provider: provisioning.Bicep},
+		&graphsdk.ServicePrincipal{AppId: "CLIENT_ID", AppOwnerOrganizationId: to.Ptr("TENANT_ID")},
+		&CredentialOptions{EnableClientCredentials: true},
+		&entraid.AzureCredentials{ClientSecret: "CLIENT_SECRET"},
+	)
+	require.NoError(t, err)
+
+	pipeline, err := provider.configurePipeline(*mockContext.Context, &gitRepositoryDetails{}, &configurePipelineOptions{
+		variables: map[string]string{"VAR_1": "value"},
+		secrets:   map[string]string{"SECRET_1": "secret"},
+	})
+	require.NoError(t, err)
+	require.Empty(t, pipeline.url())
+
+	// the configuration is not written to a file named after the flag value
+	_, err = os.Stat(exportStdoutFile)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
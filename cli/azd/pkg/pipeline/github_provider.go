@@ -437,6 +437,17 @@ func (p *GitHubCiProvider) credentialOptions(
 			federatedCredentials = append(federatedCredentials, branchCredentials)
 		}
 
+		// Jobs deploying to a GitHub environment are issued tokens with the environment as subject
+		for _, environment := range repoDetails.environments {
+			federatedCredentials = append(federatedCredentials, &graphsdk.FederatedIdentityCredential{
+				Name:        url.PathEscape(fmt.Sprintf("%s-environment-%s", credentialSafeName, environment)),
+				Issuer:      federatedIdentityIssuer,
+				Subject:     fmt.Sprintf("repo:%s:environment:%s", repoSlug, environment),
+				Description: to.Ptr("Created by Azure Developer CLI"),
+				Audiences:   []string{federatedIdentityAudience},
+			})
+		}
+
 		return &CredentialOptions{
 			EnableFederatedCredentials: true,
 			FederatedCredentialOptions: federatedCredentials,
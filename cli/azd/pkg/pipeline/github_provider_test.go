@@ -145,3 +145,33 @@ func setupGithubCliMocks(mockContext *mocks.MockContext) {
 		return exec.NewRunResult(0, fmt.Sprintf("gh version %s", github.Version), ""), nil
 	})
 }
+
+func Test_gitHub_provider_credentialOptions_environments(t *testing.T) {
+	provider := &GitHubCiProvider{}
+	options, err := provider.credentialOptions(
+		context.Background(),
+		&gitRepositoryDetails{
+			owner:        "Azure",
+			repoName:     "azure-dev",
+			branch:       "main",
+			environments: []string{"dev", "prod"},
+		},
+		provisioning.Options{},
+		AuthTypeFederated,
+		&entraid.AzureCredentials{},
+	)
+	require.NoError(t, err)
+	require.True(t, options.EnableFederatedCredentials)
+
+	var subjects []string
+	for _, credential := range options.FederatedCredentialOptions {
+		subjects = append(subjects, credential.Subject)
+	}
+
+	require.Equal(t, []string{
+		"repo:Azure/azure-dev:pull_request",
+		"repo:Azure/azure-dev:ref:refs/heads/main",
+		"repo:Azure/azure-dev:environment:dev",
+		"repo:Azure/azure-dev:environment:prod",
+	}, subjects)
+}
@@ -0,0 +1,429 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/azure/azure-dev/cli/azd/pkg/entraid"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/gitlab"
+	"github.com/azure/azure-dev/cli/azd/pkg/graphsdk"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
+)
+
+// GitLabScmProvider implements ScmProvider using GitLab as the provider
+// for source control manager.
+type GitLabScmProvider struct {
+	env     *environment.Environment
+	console input.Console
+	gitCli  *git.Cli
+}
+
+func NewGitLabScmProvider(
+	env *environment.Environment,
+	console input.Console,
+	gitCli *git.Cli,
+) ScmProvider {
+	return &GitLabScmProvider{
+		env:     env,
+		console: console,
+		gitCli:  gitCli,
+	}
+}
+
+// gitLabRepositoryDetails provides extra state needed for the GitLab provider.
+// this is stored as the details property in repoDetails
+type gitLabRepositoryDetails struct {
+	// projectPath is the full path of the project, including the group and subgroups
+	projectPath string
+	// host is the hostname of the GitLab instance
+	host string
+	// apiUrl is the base address of the GitLab REST API
+	apiUrl string
+}
+
+// ***  subareaProvider implementation ******
+
+// requiredTools return the list of external tools required by
+// GitLab provider during its execution.
+func (p *GitLabScmProvider) requiredTools(_ context.Context) ([]tools.ExternalTool, error) {
+	return []tools.ExternalTool{}, nil
+}
+
+// preConfigureCheck ensures a GitLab access token is available.
+func (p *GitLabScmProvider) preConfigureCheck(
+	ctx context.Context,
+	pipelineManagerArgs PipelineManagerArgs,
+	infraOptions provisioning.Options,
+	projectPath string,
+) (bool, error) {
+	_, updated, err := gitlab.EnsureTokenExists(ctx, p.env, p.console)
+	return updated, err
+}
+
+// name returns the name of the provider
+func (p *GitLabScmProvider) Name() string {
+	return gitLabDisplayName
+}
+
+// ***  scmProvider implementation ******
+
+// configureGitRemote prompts the user for the url of the GitLab project to use as git remote
+func (p *GitLabScmProvider) configureGitRemote(
+	ctx context.Context,
+	repoPath string,
+	remoteName string,
+) (string, error) {
+	for {
+		remoteUrl, err := p.console.Prompt(ctx, input.ConsoleOptions{
+			Message: fmt.Sprintf("Enter the url of the GitLab project to use for remote %s:", remoteName),
+		})
+		if err != nil {
+			return "", fmt.Errorf("prompting for remote url: %w", err)
+		}
+
+		if _, err := gitlab.ParseRemote(remoteUrl); err != nil {
+			p.console.Message(ctx, fmt.Sprintf("error: \"%s\" is not a valid GitLab URL.\n", remoteUrl))
+			continue
+		}
+
+		return remoteUrl, nil
+	}
+}
+
+// gitRepoDetails extracts the information from a GitLab remote url into general scm concepts
+// like owner, name and path
+func (p *GitLabScmProvider) gitRepoDetails(ctx context.Context, remoteUrl string) (*gitRepositoryDetails, error) {
+	remote, err := gitlab.ParseRemote(remoteUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gitRepositoryDetails{
+		owner:    remote.Namespace(),
+		repoName: remote.Name(),
+		remote:   remoteUrl,
+		url:      remote.WebUrl(),
+		details: &gitLabRepositoryDetails{
+			projectPath: remote.ProjectPath,
+			host:        remote.Host,
+			apiUrl:      remote.ApiUrl(),
+		},
+	}, nil
+}
+
+// preventGitPush is nil for GitLab
+func (p *GitLabScmProvider) preventGitPush(
+	ctx context.Context,
+	gitRepo *gitRepositoryDetails,
+	remoteName string,
+	branchName string) (bool, error) {
+	return false, nil
+}
+
+func (p *GitLabScmProvider) GitPush(
+	ctx context.Context,
+	gitRepo *gitRepositoryDetails,
+	remoteName string,
+	branchName string) error {
+	return p.gitCli.PushUpstream(ctx, gitRepo.gitProjectPath, remoteName, branchName)
+}
+
+// GitLabCiProvider implements a CiProvider using GitLab CI/CD to run the pipeline defined in .gitlab-ci.yml.
+type GitLabCiProvider struct {
+	env           *environment.Environment
+	console       input.Console
+	clientOptions *azcore.ClientOptions
+}
+
+func NewGitLabCiProvider(
+	env *environment.Environment,
+	console input.Console,
+	clientOptions *azcore.ClientOptions,
+) CiProvider {
+	return &GitLabCiProvider{
+		env:           env,
+		console:       console,
+		clientOptions: clientOptions,
+	}
+}
+
+// ***  subareaProvider implementation ******
+
+// requiredTools defines the requires tools for GitLab to be used as CI manager
+func (p *GitLabCiProvider) requiredTools(_ context.Context) ([]tools.ExternalTool, error) {
+	return []tools.ExternalTool{}, nil
+}
+
+// preConfigureCheck validates a GitLab access token is available and the authentication type is supported.
+func (p *GitLabCiProvider) preConfigureCheck(
+	ctx context.Context,
+	pipelineManagerArgs PipelineManagerArgs,
+	infraOptions provisioning.Options,
+	projectPath string,
+) (bool, error) {
+	_, updated, err := gitlab.EnsureTokenExists(ctx, p.env, p.console)
+	if err != nil {
+		return updated, err
+	}
+
+	authType := PipelineAuthType(pipelineManagerArgs.PipelineAuthTypeName)
+
+	// Federated Auth + Terraform is not a supported combination
+	if infraOptions.Provider == provisioning.Terraform {
+		// Throw error if Federated auth is explicitly requested
+		if authType == AuthTypeFederated {
+			return false, fmt.Errorf(
+				//nolint:lll
+				"Terraform does not support federated authentication. To explicitly use client credentials set the %s flag. %w",
+				output.WithBackticks("--auth-type client-credentials"),
+				ErrAuthNotSupported,
+			)
+		} else if authType == "" {
+			// If not explicitly set, show warning
+			p.console.MessageUxItem(
+				ctx,
+				&ux.WarningMessage{
+					//nolint:lll
+					Description: "Terraform provisioning does not support federated authentication, defaulting to Service Principal with client ID and client secret.\n",
+				},
+			)
+		}
+	}
+
+	return updated, nil
+}
+
+// name returns the name of the provider.
+func (p *GitLabCiProvider) Name() string {
+	return gitLabDisplayName
+}
+
+// ***  ciProvider implementation ******
+
+// credentialOptions returns the federated credentials matching the ID tokens issued by GitLab for pipelines
+// running on the current and main branches.
+func (p *GitLabCiProvider) credentialOptions(
+	ctx context.Context,
+	repoDetails *gitRepositoryDetails,
+	infraOptions provisioning.Options,
+	authType PipelineAuthType,
+	credentials *entraid.AzureCredentials,
+) (*CredentialOptions, error) {
+	// Default auth type to client-credentials for terraform
+	if infraOptions.Provider == provisioning.Terraform && authType == "" {
+		authType = AuthTypeClientCredentials
+	}
+
+	if authType == AuthTypeClientCredentials {
+		return &CredentialOptions{
+			EnableClientCredentials: true,
+		}, nil
+	}
+
+	// If not specified default to federated credentials
+	if authType == "" || authType == AuthTypeFederated {
+		details := repoDetails.details.(*gitLabRepositoryDetails)
+
+		// Configure federated auth for both main branch and current branch
+		branches := []string{repoDetails.branch}
+		if !slices.Contains(branches, "main") {
+			branches = append(branches, "main")
+		}
+
+		credentialSafeName := strings.ReplaceAll(details.projectPath, "/", "-")
+		federatedCredentials := []*graphsdk.FederatedIdentityCredential{}
+
+		for _, branch := range branches {
+			federatedCredentials = append(federatedCredentials, &graphsdk.FederatedIdentityCredential{
+				Name:        url.PathEscape(fmt.Sprintf("gitlab-%s-%s", credentialSafeName, branch)),
+				Issuer:      fmt.Sprintf("https://%s", details.host),
+				Subject:     fmt.Sprintf("project_path:%s:ref_type:branch:ref:%s", details.projectPath, branch),
+				Description: to.Ptr("Created by Azure Developer CLI"),
+				Audiences:   []string{federatedIdentityAudience},
+			})
+		}
+
+		return &CredentialOptions{
+			EnableFederatedCredentials: true,
+			FederatedCredentialOptions: federatedCredentials,
+		}, nil
+	}
+
+	return &CredentialOptions{
+		EnableClientCredentials:    false,
+		EnableFederatedCredentials: false,
+	}, nil
+}
+
+// configureConnection sets the CI/CD variables the pipeline uses to log in to Azure
+func (p *GitLabCiProvider) configureConnection(
+	ctx context.Context,
+	repoDetails *gitRepositoryDetails,
+	infraOptions provisioning.Options,
+	servicePrincipal *graphsdk.ServicePrincipal,
+	credentialOptions *CredentialOptions,
+	credentials *entraid.AzureCredentials,
+) error {
+	client, details, err := p.gitLabClient(ctx, repoDetails)
+	if err != nil {
+		return err
+	}
+
+	variables, secrets, err := azureConnectionValues(
+		ctx, p.console, p.env, infraOptions, servicePrincipal, credentialOptions, credentials)
+	if err != nil {
+		return err
+	}
+
+	if err := p.setVariables(ctx, client, details.projectPath, variables, secrets); err != nil {
+		return fmt.Errorf("failed setting pipeline variables: %w", err)
+	}
+
+	return nil
+}
+
+// configurePipeline sets the project variables and secrets as CI/CD variables of the GitLab project.
+// The pipeline itself is defined by the .gitlab-ci.yml file and runs when the changes are pushed.
+func (p *GitLabCiProvider) configurePipeline(
+	ctx context.Context,
+	repoDetails *gitRepositoryDetails,
+	options *configurePipelineOptions,
+) (CiPipeline, error) {
+	client, details, err := p.gitLabClient(ctx, repoDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	// Variables and secrets are set on the GitLab project. Clean up the previous values of the project variables and
+	// secrets (azure.yaml) which are no longer set, so values unset from .env don't leak to the pipeline.
+	existingVariables, err := client.ListVariables(ctx, details.projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get list of project variables: %w", err)
+	}
+
+	projectValues := slices.Concat(options.projectVariables, options.projectSecrets)
+	for _, existingVariable := range existingVariables {
+		_, isVariable := options.variables[existingVariable]
+		_, isSecret := options.secrets[existingVariable]
+		if isVariable || isSecret || !slices.Contains(projectValues, existingVariable) {
+			continue
+		}
+
+		if err := client.DeleteVariable(ctx, details.projectPath, existingVariable); err != nil {
+			return nil, fmt.Errorf("failed deleting %s variable: %w", existingVariable, err)
+		}
+	}
+
+	if err := p.setVariables(ctx, client, details.projectPath, options.variables, options.secrets); err != nil {
+		return nil, err
+	}
+
+	p.console.MessageUxItem(ctx, &ux.MultilineMessage{
+		Lines: []string{
+			"",
+			"GitLab CI/CD variables are now configured. You can view the variables that were created at this link:",
+			output.WithLinkFormat("%s/-/settings/ci_cd#js-cicd-variables-settings", repoDetails.url),
+			""},
+	})
+
+	return &gitLabPipeline{
+		repoDetails: repoDetails,
+	}, nil
+}
+
+// setVariables creates or updates the CI/CD variables of the GitLab project. Secrets are masked in the job logs
+// when GitLab supports masking the value.
+func (p *GitLabCiProvider) setVariables(
+	ctx context.Context,
+	client *gitlab.Client,
+	projectPath string,
+	variables map[string]string,
+	secrets map[string]string,
+) error {
+	for key, value := range variables {
+		if err := client.SetVariable(ctx, projectPath, gitlab.Variable{Key: key, Value: value, Raw: true}); err != nil {
+			return fmt.Errorf("failed setting %s variable: %w", key, err)
+		}
+		p.console.MessageUxItem(ctx, &ux.CreatedRepoValue{
+			Name: key,
+			Kind: ux.GitHubVariable,
+		})
+	}
+
+	unmasked := []string{}
+	for key, value := range secrets {
+		variable := gitlab.Variable{
+			Key:    key,
+			Value:  value,
+			Raw:    true,
+			Masked: gitlab.CanMask(value),
+		}
+		if !variable.Masked {
+			unmasked = append(unmasked, key)
+		}
+
+		if err := client.SetVariable(ctx, projectPath, variable); err != nil {
+			return fmt.Errorf("failed setting %s secret: %w", key, err)
+		}
+		p.console.MessageUxItem(ctx, &ux.CreatedRepoValue{
+			Name: key,
+			Kind: ux.GitHubSecret,
+		})
+	}
+
+	if len(unmasked) > 0 {
+		slices.Sort(unmasked)
+		log.Printf("gitlab: values of secrets %s can't be masked", strings.Join(unmasked, ", "))
+		p.console.MessageUxItem(ctx, &ux.WarningMessage{
+			Description: fmt.Sprintf(
+				"GitLab can't mask the values of %s in job logs. Avoid printing these variables in the pipeline.",
+				strings.Join(unmasked, ", "),
+			),
+		})
+	}
+
+	return nil
+}
+
+// gitLabClient returns a client for the GitLab instance hosting the repository
+func (p *GitLabCiProvider) gitLabClient(
+	ctx context.Context,
+	repoDetails *gitRepositoryDetails,
+) (*gitlab.Client, *gitLabRepositoryDetails, error) {
+	details := repoDetails.details.(*gitLabRepositoryDetails)
+
+	token, _, err := gitlab.EnsureTokenExists(ctx, p.env, p.console)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return gitlab.NewClient(details.apiUrl, token, p.clientOptions), details, nil
+}
+
+// gitLabPipeline is the implementation for a CiPipeline for GitLab
+type gitLabPipeline struct {
+	repoDetails *gitRepositoryDetails
+}
+
+func (p *gitLabPipeline) name() string {
+	return "pipelines"
+}
+
+func (p *gitLabPipeline) url() string {
+	return p.repoDetails.url + "/-/pipelines"
+}
@@ -0,0 +1,230 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/azure/azure-dev/cli/azd/pkg/entraid"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/gitlab"
+	"github.com/azure/azure-dev/cli/azd/pkg/graphsdk"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_gitLab_provider_getRepoDetails(t *testing.T) {
+	t.Run("https", func(t *testing.T) {
+		provider := &GitLabScmProvider{}
+		details, err := provider.gitRepoDetails(context.Background(), "https://gitlab.com/contoso/apps/todo.git")
+		require.NoError(t, err)
+		require.Equal(t, "contoso/apps", details.owner)
+		require.Equal(t, "todo", details.repoName)
+		require.Equal(t, "https://gitlab.com/contoso/apps/todo", details.url)
+
+		gitLabDetails := details.details.(*gitLabRepositoryDetails)
+		require.Equal(t, "contoso/apps/todo", gitLabDetails.projectPath)
+		require.Equal(t, "https://gitlab.com/api/v4", gitLabDetails.apiUrl)
+	})
+	t.Run("ssh", func(t *testing.T) {
+		provider := &GitLabScmProvider{}
+		details, err := provider.gitRepoDetails(context.Background(), "git@gitlab.com:contoso/todo.git")
+		require.NoError(t, err)
+		require.Equal(t, "contoso", details.owner)
+		require.Equal(t, "todo", details.repoName)
+	})
+	t.Run("error", func(t *testing.T) {
+		provider := &GitLabScmProvider{}
+		details, err := provider.gitRepoDetails(context.Background(), "https://github.com/Azure/azure-dev.git")
+		require.ErrorIs(t, err, gitlab.ErrRemoteHostIsNotGitLab)
+		require.Nil(t, details)
+	})
+}
+
+func Test_gitLab_provider_credentialOptions(t *testing.T) {
+	repoDetails := &gitRepositoryDetails{
+		branch: "feature",
+		details: &gitLabRepositoryDetails{
+			projectPath: "contoso/apps/todo",
+			host:        "gitlab.com",
+		},
+	}
+
+	t.Run("federated", func(t *testing.T) {
+		provider := &GitLabCiProvider{}
+		options, err := provider.credentialOptions(
+			context.Background(), repoDetails, provisioning.Options{}, "", &entraid.AzureCredentials{})
+		require.NoError(t, err)
+		require.True(t, options.EnableFederatedCredentials)
+		require.False(t, options.EnableClientCredentials)
+		require.Len(t, options.FederatedCredentialOptions, 2)
+
+		credential := options.FederatedCredentialOptions[0]
+		require.Equal(t, "gitlab-contoso-apps-todo-feature", credential.Name)
+		require.Equal(t, "https://gitlab.com", credential.Issuer)
+		require.Equal(t, "project_path:contoso/apps/todo:ref_type:branch:ref:feature", credential.Subject)
+		require.Equal(t, []string{federatedIdentityAudience}, credential.Audiences)
+
+		require.Equal(t,
+			"project_path:contoso/apps/todo:ref_type:branch:ref:main", options.FederatedCredentialOptions[1].Subject)
+	})
+
+	t.Run("terraform defaults to client credentials", func(t *testing.T) {
+		provider := &GitLabCiProvider{}
+		options, err := provider.credentialOptions(
+			context.Background(),
+			repoDetails,
+			provisioning.Options{Provider: provisioning.Terraform},
+			"",
+			&entraid.AzureCredentials{},
+		)
+		require.NoError(t, err)
+		require.True(t, options.EnableClientCredentials)
+		require.False(t, options.EnableFederatedCredentials)
+	})
+}
+
+func Test_gitLab_provider_configureConnection(t *testing.T) {
+	server := newFakeGitLabServer(t)
+	mockContext := mocks.NewMockContext(context.Background())
+	env := environment.NewWithValues("test", map[string]string{
+		gitlab.GitLabTokenName:               "token",
+		environment.LocationEnvVarName:       "eastus2",
+		environment.SubscriptionIdEnvVarName: "SUBSCRIPTION_ID",
+	})
+
+	provider := NewGitLabCiProvider(env, mockContext.Console, nil)
+	err := provider.configureConnection(
+		*mockContext.Context,
+		server.repoDetails(),
+		provisioning.Options{Provider: provisioning.Bicep},
+		&graphsdk.ServicePrincipal{AppId: "CLIENT_ID", AppOwnerOrganizationId: to.Ptr("TENANT_ID")},
+		&CredentialOptions{EnableClientCredentials: true},
+		&entraid.AzureCredentials{ClientSecret: "c2VjcmV0LXZhbHVl"},
+	)
+	require.NoError(t, err)
+
+	require.Equal(t, "test", server.variables[environment.EnvNameEnvVarName].Value)
+	require.Equal(t, "eastus2", server.variables[environment.LocationEnvVarName].Value)
+	require.Equal(t, "SUBSCRIPTION_ID", server.variables[environment.SubscriptionIdEnvVarName].Value)
+	require.Equal(t, "TENANT_ID", server.variables[environment.TenantIdEnvVarName].Value)
+	require.Equal(t, "CLIENT_ID", server.variables["AZURE_CLIENT_ID"].Value)
+
+	secret := server.variables["AZURE_CLIENT_SECRET"]
+	require.Equal(t, "c2VjcmV0LXZhbHVl", secret.Value)
+	require.True(t, secret.Masked)
+	require.True(t, secret.Raw)
+}
+
+func Test_gitLab_provider_configurePipeline(t *testing.T) {
+	server := newFakeGitLabServer(t)
+	server.variables["STALE_VARIABLE"] = gitlab.Variable{Key: "STALE_VARIABLE", Value: "old"}
+	server.variables["UNMANAGED_VARIABLE"] = gitlab.Variable{Key: "UNMANAGED_VARIABLE", Value: "keep"}
+
+	mockContext := mocks.NewMockContext(context.Background())
+	env := environment.NewWithValues("test", map[string]string{
+		gitlab.GitLabTokenName: "token",
+	})
+
+	provider := NewGitLabCiProvider(env, mockContext.Console, nil)
+	pipeline, err := provider.configurePipeline(*mockContext.Context, server.repoDetails(), &configurePipelineOptions{
+		variables:        map[string]string{"VAR_1": "value"},
+		secrets:          map[string]string{"SECRET_1": `{"not":"maskable"}`},
+		projectVariables: []string{"VAR_1", "STALE_VARIABLE"},
+		projectSecrets:   []string{"SECRET_1"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, server.URL+"/contoso/todo/-/pipelines", pipeline.url())
+
+	// variables of azure.yaml no longer set are removed, other variables of the project are kept
+	require.NotContains(t, server.variables, "STALE_VARIABLE")
+	require.Contains(t, server.variables, "UNMANAGED_VARIABLE")
+	require.Equal(t, "value", server.variables["VAR_1"].Value)
+
+	secret := server.variables["SECRET_1"]
+	require.False(t, secret.Masked)
+
+	// a warning is displayed for secrets that can't be masked
+	require.True(t, strings.Contains(strings.Join(mockContext.Console.Output(), "\n"), "SECRET_1"))
+}
+
+// fakeGitLabServer implements the GitLab CI/CD variables API for a single project
+type fakeGitLabServer struct {
+	*httptest.Server
+	mu        sync.Mutex
+	variables map[string]gitlab.Variable
+}
+
+func newFakeGitLabServer(t *testing.T) *fakeGitLabServer {
+	fake := &fakeGitLabServer{
+		variables: map[string]gitlab.Variable{},
+	}
+
+	const variablesPath = "/api/v4/projects/contoso%2Ftodo/variables"
+
+	fake.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+
+		require.Equal(t, "token", r.Header.Get("PRIVATE-TOKEN"))
+
+		path := r.URL.EscapedPath()
+		key := strings.TrimPrefix(strings.TrimPrefix(path, variablesPath), "/")
+
+		switch {
+		case r.Method == http.MethodGet && path == variablesPath:
+			variables := []gitlab.Variable{}
+			for _, variable := range fake.variables {
+				variables = append(variables, variable)
+			}
+			_ = json.NewEncoder(w).Encode(variables)
+		case r.Method == http.MethodPost && path == variablesPath:
+			var variable gitlab.Variable
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&variable))
+			fake.variables[variable.Key] = variable
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodPut || r.Method == http.MethodDelete:
+			if _, has := fake.variables[key]; !has {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			if r.Method == http.MethodDelete {
+				delete(fake.variables, key)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			var variable gitlab.Variable
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&variable))
+			fake.variables[key] = variable
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(fake.Close)
+
+	return fake
+}
+
+func (f *fakeGitLabServer) repoDetails() *gitRepositoryDetails {
+	return &gitRepositoryDetails{
+		owner:    "contoso",
+		repoName: "todo",
+		url:      f.URL + "/contoso/todo",
+		details: &gitLabRepositoryDetails{
+			projectPath: "contoso/todo",
+			host:        strings.TrimPrefix(f.URL, "http://"),
+			apiUrl:      f.URL + "/api/v4",
+		},
+	}
+}
@@ -5,14 +5,20 @@ package pipeline
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"maps"
 	"path/filepath"
 	"slices"
+	"strings"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/entraid"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/graphsdk"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
 )
 
@@ -52,6 +58,8 @@ type gitRepositoryDetails struct {
 	url string
 	// branch
 	branch string
+	// environments are the azd environments deployed by the stages of the pipeline, in promotion order
+	environments []string
 
 	details interface{}
 }
@@ -159,21 +167,99 @@ func mergeProjectVariablesAndSecrets(
 	return variables, secrets
 }
 
+// azureConnectionValues returns the variables and secrets a pipeline uses to log in to Azure and to provision the
+// infrastructure, for CI providers storing them as plain pipeline variables.
+func azureConnectionValues(
+	ctx context.Context,
+	console input.Console,
+	env *environment.Environment,
+	infraOptions provisioning.Options,
+	servicePrincipal *graphsdk.ServicePrincipal,
+	credentialOptions *CredentialOptions,
+	credentials *entraid.AzureCredentials,
+) (variables, secrets map[string]string, err error) {
+	variables = map[string]string{
+		environment.EnvNameEnvVarName:        env.Name(),
+		environment.LocationEnvVarName:       env.GetLocation(),
+		environment.SubscriptionIdEnvVarName: env.GetSubscriptionId(),
+		environment.TenantIdEnvVarName:       *servicePrincipal.AppOwnerOrganizationId,
+		"AZURE_CLIENT_ID":                    servicePrincipal.AppId,
+	}
+	secrets = map[string]string{}
+
+	if credentialOptions.EnableClientCredentials {
+		secrets["AZURE_CLIENT_SECRET"] = credentials.ClientSecret
+
+		if infraOptions.Provider == provisioning.Terraform {
+			variables["ARM_TENANT_ID"] = credentials.TenantId
+			variables["ARM_CLIENT_ID"] = credentials.ClientId
+			secrets["ARM_CLIENT_SECRET"] = credentials.ClientSecret
+		}
+	}
+
+	if infraOptions.Provider == provisioning.Terraform {
+		for _, key := range []string{"RS_RESOURCE_GROUP", "RS_STORAGE_ACCOUNT", "RS_CONTAINER_NAME"} {
+			value, ok := env.LookupEnv(key)
+			if !ok || strings.TrimSpace(value) == "" {
+				console.StopSpinner(ctx, "Configuring terraform", input.StepWarning)
+				console.MessageUxItem(ctx, &ux.WarningMessage{
+					Description: "Terraform Remote State configuration is invalid",
+					HidePrefix:  true,
+				})
+				console.Message(
+					ctx,
+					fmt.Sprintf(
+						"Visit %s for more information on configuring Terraform remote state",
+						output.WithLinkFormat("https://aka.ms/azure-dev/terraform"),
+					),
+				)
+				console.Message(ctx, "")
+				return nil, nil, errors.New("terraform remote state is not correctly configured")
+			}
+
+			variables[key] = value
+		}
+	}
+
+	if infraOptions.Provider == provisioning.Bicep {
+		if rgName, has := env.LookupEnv(environment.ResourceGroupEnvVarName); has {
+			variables[environment.ResourceGroupEnvVarName] = rgName
+		}
+	}
+
+	return variables, secrets, nil
+}
+
 const (
-	gitHubDisplayName string = "GitHub"
-	gitHubCode               = "github"
-	gitHubRoot        string = ".github"
-	gitHubWorkflows   string = "workflows"
-	azdoDisplayName   string = "Azure DevOps"
-	azdoCode                 = "azdo"
-	azdoRoot          string = ".azdo"
-	azdoRootAlt       string = ".azuredevops"
-	azdoPipelines     string = "pipelines"
-	envPersistedKey   string = "AZD_PIPELINE_PROVIDER"
+	gitHubDisplayName      string = "GitHub"
+	gitHubCode                    = "github"
+	gitHubRoot             string = ".github"
+	gitHubWorkflows        string = "workflows"
+	azdoDisplayName        string = "Azure DevOps"
+	azdoCode                      = "azdo"
+	azdoRoot               string = ".azdo"
+	azdoRootAlt            string = ".azuredevops"
+	azdoPipelines          string = "pipelines"
+	gitLabDisplayName      string = "GitLab"
+	gitLabCode                    = "gitlab"
+	gitLabCiFile           string = ".gitlab-ci.yml"
+	bitbucketDisplayName   string = "Bitbucket"
+	bitbucketCode                 = "bitbucket"
+	bitbucketPipelinesFile string = "bitbucket-pipelines.yml"
+	exportDisplayName      string = "Export"
+	exportCode                    = "export"
+	envPersistedKey        string = "AZD_PIPELINE_PROVIDER"
+	// repoRootDirectory is the pipeline directory of providers reading the pipeline definition from the repository root
+	repoRootDirectory string = "."
 )
 
 var (
 	pipelineFileNames = []string{"azure-dev.yml", "azure-dev.yaml"}
+	// selectableProviders are the providers detected from the repository and offered when prompting for a provider.
+	// The export provider is only used when requested explicitly.
+	selectableProviders = []ciProviderType{
+		ciProviderGitHubActions, ciProviderAzureDevOps, ciProviderGitLab, ciProviderBitbucket,
+	}
 )
 
 var (
@@ -182,6 +268,8 @@ var (
 		PipelineDirectories []string
 		Files               []string
 		DefaultFile         string
+		TemplateFile        string
+		StagesTemplateFile  string
 		DisplayName         string
 		Code                string
 	}{
@@ -190,6 +278,8 @@ var (
 			PipelineDirectories: []string{filepath.Join(gitHubRoot, gitHubWorkflows)},
 			Files:               generateFilePaths([]string{filepath.Join(gitHubRoot, gitHubWorkflows)}, pipelineFileNames),
 			DefaultFile:         pipelineFileNames[0],
+			TemplateFile:        "azure-dev.ymlt",
+			StagesTemplateFile:  "azure-dev-stages.ymlt",
 			DisplayName:         gitHubDisplayName,
 		},
 		ciProviderAzureDevOps: {
@@ -197,8 +287,32 @@ var (
 			PipelineDirectories: []string{filepath.Join(azdoRoot, azdoPipelines), filepath.Join(azdoRootAlt, azdoPipelines)},
 			Files: generateFilePaths([]string{filepath.Join(azdoRoot, azdoPipelines),
 				filepath.Join(azdoRootAlt, azdoPipelines)}, pipelineFileNames),
-			DefaultFile: pipelineFileNames[0],
-			DisplayName: azdoDisplayName,
+			DefaultFile:        pipelineFileNames[0],
+			TemplateFile:       "azure-dev.ymlt",
+			StagesTemplateFile: "azure-dev-stages.ymlt",
+			DisplayName:        azdoDisplayName,
+		},
+		ciProviderGitLab: {
+			RootDirectories:     []string{},
+			PipelineDirectories: []string{repoRootDirectory},
+			Files:               []string{gitLabCiFile},
+			DefaultFile:         gitLabCiFile,
+			TemplateFile:        "gitlab-ci.ymlt",
+			StagesTemplateFile:  "gitlab-ci-stages.ymlt",
+			DisplayName:         gitLabDisplayName,
+		},
+		ciProviderBitbucket: {
+			RootDirectories:     []string{},
+			PipelineDirectories: []string{repoRootDirectory},
+			Files:               []string{bitbucketPipelinesFile},
+			DefaultFile:         bitbucketPipelinesFile,
+			TemplateFile:        "bitbucket-pipelines.ymlt",
+			StagesTemplateFile:  "bitbucket-pipelines-stages.ymlt",
+			DisplayName:         bitbucketDisplayName,
+		},
+		// export doesn't use a pipeline definition, the exported configuration is applied by the user
+		ciProviderExport: {
+			DisplayName: exportDisplayName,
 		},
 	}
 )
@@ -218,11 +332,14 @@ type ciProviderType string
 const (
 	ciProviderGitHubActions ciProviderType = gitHubCode
 	ciProviderAzureDevOps   ciProviderType = azdoCode
+	ciProviderGitLab        ciProviderType = gitLabCode
+	ciProviderBitbucket     ciProviderType = bitbucketCode
+	ciProviderExport        ciProviderType = exportCode
 )
 
 func toCiProviderType(provider string) (ciProviderType, error) {
 	result := ciProviderType(provider)
-	if result == ciProviderGitHubActions || result == ciProviderAzureDevOps {
+	if _, has := pipelineProviderFiles[result]; has {
 		return result, nil
 	}
 	return "", fmt.Errorf("invalid ci provider type %s", provider)
@@ -253,4 +370,9 @@ type projectProperties struct {
 	AuthType      PipelineAuthType
 	Variables     []string
 	Secrets       []string
+	// Services are the services of the project, packaged and deployed by separate jobs when Stages is set.
+	Services []pipelineService
+	// Stages are the ordered deployment stages. When empty, a single job provisions and deploys the environment
+	// configured on the CI provider.
+	Stages []pipelineStage
 }
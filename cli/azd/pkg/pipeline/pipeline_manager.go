@@ -16,10 +16,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/azure/azure-dev/cli/azd/pkg/bitbucket"
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/azure/azure-dev/cli/azd/pkg/entraid"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/gitlab"
 	"github.com/azure/azure-dev/cli/azd/pkg/graphsdk"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
@@ -64,6 +66,8 @@ type PipelineManagerArgs struct {
 	PipelineProvider             string
 	PipelineAuthTypeName         string
 	ServiceManagementReference   string
+	// PipelineExportFile is the file the export provider writes the pipeline configuration to
+	PipelineExportFile string
 }
 
 // CredentialOptions represents the options for configuring credentials for a pipeline.
@@ -76,6 +80,9 @@ type CredentialOptions struct {
 type PipelineConfigResult struct {
 	RepositoryLink string
 	PipelineLink   string
+	// ExportOnly is true when the pipeline configuration was exported instead of applied to a CI/CD provider.
+	// PipelineLink holds the path of the export file, or is empty when the configuration was written to stdout.
+	ExportOnly bool
 }
 
 // PipelineManager takes care of setting up the scm and pipeline.
@@ -84,6 +91,8 @@ type PipelineManager struct {
 	envManager        environment.Manager
 	scmProvider       ScmProvider
 	ciProvider        CiProvider
+	providerType      ciProviderType
+	stages            []pipelineStage
 	args              *PipelineManagerArgs
 	azdCtx            *azdcontext.AzdContext
 	env               *environment.Environment
@@ -257,9 +266,18 @@ func (pm *PipelineManager) Configure(ctx context.Context, projectName string) (r
 	}
 
 	// Get git repo details
-	gitRepoInfo, err := pm.getGitRepoDetails(ctx)
-	if err != nil {
-		return result, fmt.Errorf("ensuring git remote: %w", err)
+	var gitRepoInfo *gitRepositoryDetails
+	if pm.providerType == ciProviderExport {
+		gitRepoInfo = pm.getExportRepoDetails(ctx)
+	} else {
+		gitRepoInfo, err = pm.getGitRepoDetails(ctx)
+		if err != nil {
+			return result, fmt.Errorf("ensuring git remote: %w", err)
+		}
+	}
+
+	for _, stage := range pm.stages {
+		gitRepoInfo.environments = append(gitRepoInfo.environments, stage.Environment)
 	}
 
 	if pm.args.PipelineServicePrincipalName != "" && pm.args.PipelineServicePrincipalId != "" {
@@ -484,6 +502,15 @@ func (pm *PipelineManager) Configure(ctx context.Context, projectName string) (r
 		return result, err
 	}
 
+	// The exported configuration must be applied by the user before the pipeline can run, there is nothing to push
+	if pm.providerType == ciProviderExport {
+		return &PipelineConfigResult{
+			RepositoryLink: gitRepoInfo.url,
+			PipelineLink:   ciPipeline.url(),
+			ExportOnly:     true,
+		}, nil
+	}
+
 	// The CI pipeline should be set-up and ready at this point.
 	// azd offers to push changes to the scm to start a new pipeline run
 	doPush, err := pm.console.Confirm(ctx, input.ConsoleOptions{
@@ -699,6 +726,23 @@ func (pm *PipelineManager) getGitRepoDetails(ctx context.Context) (*gitRepositor
 	}
 }
 
+// getExportRepoDetails gets the details about the git project for exporting the pipeline configuration.
+// Unlike getGitRepoDetails, a git repository or remote is not required and the user is never prompted.
+func (pm *PipelineManager) getExportRepoDetails(ctx context.Context) *gitRepositoryDetails {
+	repoPath := pm.azdCtx.ProjectDirectory()
+
+	repoDetails, err := pm.ensureRemote(ctx, repoPath, pm.args.PipelineRemoteName)
+	if err == nil {
+		return repoDetails
+	}
+
+	log.Printf("exporting pipeline configuration without git remote details: %v", err)
+	return &gitRepositoryDetails{
+		repoName:       filepath.Base(repoPath),
+		gitProjectPath: repoPath,
+	}
+}
+
 // pushGitRepo commit all changes in the git project and push it to upstream.
 func (pm *PipelineManager) pushGitRepo(ctx context.Context, gitRepoInfo *gitRepositoryDetails, currentBranch string) error {
 	if err := pm.gitCli.AddFile(ctx, pm.azdCtx.ProjectDirectory(), "."); err != nil {
@@ -803,6 +847,13 @@ func (pm *PipelineManager) initialize(ctx context.Context, override string) erro
 	defer func() { _ = infra.Cleanup() }()
 	pm.infra = infra
 
+	// The pipeline of an infrastructure with layers is configured for terraform when any layer uses terraform
+	for _, layer := range infra.Options.Layers {
+		if layer.Provider == provisioning.Terraform {
+			pm.infra.Options.Provider = provisioning.Terraform
+		}
+	}
+
 	hasAppHost := pm.importManager.HasAppHost(ctx, prjConfig)
 
 	infraProvider, err := toInfraProviderType(string(pm.infra.Options.Provider))
@@ -820,6 +871,11 @@ func (pm *PipelineManager) initialize(ctx context.Context, override string) erro
 		branchName = customBranchName
 	}
 
+	stages, err := newPipelineStages(prjConfig.Pipeline.Stages)
+	if err != nil {
+		return err
+	}
+
 	// default auth type for all providers
 	authType := AuthTypeFederated
 	if pm.args.PipelineAuthTypeName == "" && infraProvider == infraProviderTerraform {
@@ -838,6 +894,8 @@ func (pm *PipelineManager) initialize(ctx context.Context, override string) erro
 			AuthType:      authType,
 			Variables:     prjConfig.Pipeline.Variables,
 			Secrets:       prjConfig.Pipeline.Secrets,
+			Services:      newPipelineServices(prjConfig),
+			Stages:        stages,
 		}); err != nil {
 		return err
 	}
@@ -847,16 +905,9 @@ func (pm *PipelineManager) initialize(ctx context.Context, override string) erro
 		return err
 	}
 
-	var scmProviderName, ciProviderName, displayName string
-	if pipelineProvider == ciProviderAzureDevOps {
-		scmProviderName = string(ciProviderAzureDevOps)
-		ciProviderName = scmProviderName
-		displayName = azdoDisplayName
-	} else {
-		scmProviderName = string(ciProviderGitHubActions)
-		ciProviderName = scmProviderName
-		displayName = gitHubDisplayName
-	}
+	scmProviderName := string(pipelineProvider)
+	ciProviderName := scmProviderName
+	displayName := pipelineProviderFiles[pipelineProvider].DisplayName
 	log.Printf("Using pipeline provider: %s", output.WithHighLightFormat(displayName))
 
 	var scmProvider ScmProvider
@@ -871,6 +922,8 @@ func (pm *PipelineManager) initialize(ctx context.Context, override string) erro
 
 	pm.scmProvider = scmProvider
 	pm.ciProvider = ciProvider
+	pm.providerType = pipelineProvider
+	pm.stages = stages
 
 	pm.configOptions = &configurePipelineOptions{
 		projectVariables:     slices.Clone(prjConfig.Pipeline.Variables),
@@ -898,6 +951,11 @@ func (pm *PipelineManager) savePipelineProviderToEnv(
 func (pm *PipelineManager) checkAndPromptForProviderFiles(ctx context.Context, props projectProperties) error {
 	log.Printf("Checking for provider files for: %s", props.CiProvider)
 
+	// providers without a pipeline definition (export) have no files to check
+	if len(pipelineProviderFiles[props.CiProvider].Files) == 0 {
+		return nil
+	}
+
 	if !hasPipelineFile(props.CiProvider, props.RepoRoot) {
 		log.Printf("%s YAML not found, prompting for creation", props.CiProvider)
 		if err := pm.promptForCiFiles(ctx, props); err != nil {
@@ -905,10 +963,22 @@ func (pm *PipelineManager) checkAndPromptForProviderFiles(ctx context.Context, p
 			return err
 		}
 		log.Println("Prompt for CI files completed successfully.")
+	} else if len(props.Stages) > 0 {
+		if err := pm.promptForCiFilesUpdate(ctx, props); err != nil {
+			return err
+		}
 	}
 
 	var dirPaths []string
 	for _, dir := range pipelineProviderFiles[props.CiProvider].PipelineDirectories {
+		// The repository root is never empty, look for the pipeline file instead
+		if dir == repoRootDirectory {
+			if hasPipelineFile(props.CiProvider, props.RepoRoot) {
+				log.Printf("Provider files are present in directory: %s", props.RepoRoot)
+				return nil
+			}
+			continue
+		}
 		dirPaths = append(dirPaths, filepath.Join(props.RepoRoot, dir))
 	}
 
@@ -925,11 +995,16 @@ func (pm *PipelineManager) checkAndPromptForProviderFiles(ctx context.Context, p
 		}
 	}
 
+	expectedLocations := slices.Clone(pipelineProviderFiles[props.CiProvider].PipelineDirectories)
+	if idx := slices.Index(expectedLocations, repoRootDirectory); idx >= 0 {
+		expectedLocations[idx] = pipelineProviderFiles[props.CiProvider].DefaultFile
+	}
+
 	message := fmt.Sprintf(
 		"%s provider selected, but no pipeline files were found in any expected directories:\n%s\n"+
 			"Please add pipeline files.",
 		pipelineProviderFiles[props.CiProvider].DisplayName,
-		strings.Join(pipelineProviderFiles[props.CiProvider].PipelineDirectories, "\n"))
+		strings.Join(expectedLocations, "\n"))
 
 	if props.CiProvider == ciProviderAzureDevOps {
 		message = fmt.Sprintf(
@@ -973,7 +1048,7 @@ func (pm *PipelineManager) promptForCiFiles(ctx context.Context, props projectPr
 		ctx,
 		fmt.Sprintf(
 			"The default %s file, which contains a basic workflow to help you get started, is missing from your project.",
-			output.WithHighLightFormat("azure-dev.yml"),
+			output.WithHighLightFormat(pipelineProviderFiles[props.CiProvider].DefaultFile),
 		),
 	)
 	pm.console.Message(ctx, "")
@@ -1033,14 +1108,91 @@ func (pm *PipelineManager) promptForCiFiles(ctx context.Context, props projectPr
 	return nil
 }
 
+// promptForCiFilesUpdate shows the changes between the existing pipeline definition and the one generated for the
+// stages of azure.yaml, and overwrites the existing file when the user confirms it.
+func (pm *PipelineManager) promptForCiFilesUpdate(ctx context.Context, props projectProperties) error {
+	path := pipelineFilePath(props.CiProvider, props.RepoRoot)
+
+	// the pipeline is defined in a file azd doesn't generate
+	if path == "" {
+		return nil
+	}
+
+	existing, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	generated, err := renderPipelineDefinition(props)
+	if err != nil {
+		return err
+	}
+
+	existingContent := strings.ReplaceAll(string(existing), "\r\n", "\n")
+	if existingContent == generated {
+		return nil
+	}
+
+	pm.console.Message(ctx, "")
+	pm.console.Message(ctx, fmt.Sprintf(
+		"The %s file doesn't match the pipeline stages defined in %s. Changes:",
+		output.WithHighLightFormat(path),
+		output.WithHighLightFormat(azdcontext.ProjectFileName),
+	))
+	pm.console.Message(ctx, "")
+	pm.console.Message(ctx, diffLines(existingContent, generated))
+
+	confirm, err := pm.console.Confirm(ctx, input.ConsoleOptions{
+		Message:      "Would you like to overwrite it?",
+		DefaultValue: false,
+	})
+	if err != nil {
+		return fmt.Errorf("prompting to update file: %w", err)
+	}
+	pm.console.Message(ctx, "")
+
+	if !confirm {
+		log.Printf("User declined update of %s", path)
+		return nil
+	}
+
+	if err := os.WriteFile(path, []byte(generated), osutil.PermissionFile); err != nil {
+		return fmt.Errorf("updating file %s: %w", path, err)
+	}
+
+	pm.console.Message(ctx, fmt.Sprintf("The %s file has been updated.", output.WithHighLightFormat(path)))
+	pm.console.Message(ctx, "")
+	return nil
+}
+
 func generatePipelineDefinition(path string, props projectProperties) error {
-	embedFilePath := fmt.Sprintf("pipeline/.%s/azure-dev.ymlt", props.CiProvider)
+	contents, err := renderPipelineDefinition(props)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Creating file %s", path)
+	if err := os.WriteFile(path, []byte(contents), osutil.PermissionFile); err != nil {
+		return fmt.Errorf("creating file %s: %w", path, err)
+	}
+	return nil
+}
+
+// renderPipelineDefinition renders the pipeline definition of the CI provider. Projects with stages use the
+// multi-stage template of the provider, which packages and deploys each service in separate jobs.
+func renderPipelineDefinition(props projectProperties) (string, error) {
+	templateFile := pipelineProviderFiles[props.CiProvider].TemplateFile
+	if len(props.Stages) > 0 {
+		templateFile = pipelineProviderFiles[props.CiProvider].StagesTemplateFile
+	}
+
+	embedFilePath := fmt.Sprintf("pipeline/.%s/%s", props.CiProvider, templateFile)
 	tmpl, err := template.
-		New("azure-dev.yml").
+		New(pipelineProviderFiles[props.CiProvider].DefaultFile).
 		Option("missingkey=error").
 		ParseFS(resources.PipelineFiles, embedFilePath)
 	if err != nil {
-		return fmt.Errorf("parsing embedded file %s: %w", embedFilePath, err)
+		return "", fmt.Errorf("parsing embedded file %s: %w", embedFilePath, err)
 	}
 	builder := strings.Builder{}
 	err = tmpl.Execute(&builder, struct {
@@ -1049,90 +1201,113 @@ func generatePipelineDefinition(path string, props projectProperties) error {
 		InstallDotNetForAspire bool
 		Variables              []string
 		Secrets                []string
+		Services               []pipelineService
+		Stages                 []pipelineStage
 	}{
 		BranchName:             props.BranchName,
 		FedCredLogIn:           props.AuthType == AuthTypeFederated,
 		InstallDotNetForAspire: props.HasAppHost,
 		Variables:              props.Variables,
 		Secrets:                props.Secrets,
+		Services:               props.Services,
+		Stages:                 props.Stages,
 	})
 	if err != nil {
-		return fmt.Errorf("executing template: %w", err)
+		return "", fmt.Errorf("executing template: %w", err)
 	}
 
-	contents := []byte(builder.String())
-	log.Printf("Creating file %s", path)
-	if err := os.WriteFile(path, contents, osutil.PermissionFile); err != nil {
-		return fmt.Errorf("creating file %s: %w", path, err)
-	}
-	return nil
+	return builder.String(), nil
 }
 
 // hasPipelineFile checks if any pipeline files exist for the given provider in the specified repository root.
 func hasPipelineFile(provider ciProviderType, repoRoot string) bool {
+	return pipelineFilePath(provider, repoRoot) != ""
+}
+
+// pipelineFilePath returns the path of the first pipeline file of the given provider that exists in the specified
+// repository root, or an empty string when none exists.
+func pipelineFilePath(provider ciProviderType, repoRoot string) string {
 	for _, path := range pipelineProviderFiles[provider].Files {
 		fullPath := filepath.Join(repoRoot, path)
 		if osutil.FileExists(fullPath) {
-			return true
+			return fullPath
 		}
 	}
-	return false
+	return ""
 }
 
 func (pm *PipelineManager) determineProvider(ctx context.Context, repoRoot string) (ciProviderType, error) {
 	log.Printf("Checking for CI/CD YAML files in the repository root: %s", repoRoot)
 
 	// Check for existence of official YAML files in the repo root
-	hasGitHubYml := hasPipelineFile(ciProviderGitHubActions, repoRoot)
-	hasAzDevOpsYml := hasPipelineFile(ciProviderAzureDevOps, repoRoot)
+	detected := []ciProviderType{}
+	for _, provider := range selectableProviders {
+		hasYml := hasPipelineFile(provider, repoRoot)
+		log.Printf("%s YAML exists: %v", pipelineProviderFiles[provider].DisplayName, hasYml)
+		if hasYml {
+			detected = append(detected, provider)
+		}
+	}
 
-	log.Printf("GitHub Actions YAML exists: %v", hasGitHubYml)
-	log.Printf("Azure DevOps YAML exists: %v", hasAzDevOpsYml)
+	if len(detected) == 1 {
+		// Only the YAML of a single provider found
+		displayName := pipelineProviderFiles[detected[0]].DisplayName
+		log.Printf("Only %s YAML found. Selecting %s as the provider.", displayName, displayName)
+		return detected[0], nil
+	}
 
-	switch {
-	case (!hasGitHubYml && !hasAzDevOpsYml) || (hasGitHubYml && hasAzDevOpsYml):
-		// No official YAML files found for either provider or both are found
-		log.Printf("Neither or both YAML files found. Prompting user for provider selection.")
-		return pm.promptForProvider(ctx)
+	if len(detected) == 0 {
+		if provider, has := pm.remoteProvider(ctx, repoRoot); has {
+			// No YAML files found, but the repository is hosted on a provider which is not offered by default
+			log.Printf("No YAML files found and the git remote is hosted on %s. Selecting it as the provider.",
+				pipelineProviderFiles[provider].DisplayName)
+			return provider, nil
+		}
+	}
+
+	// No official YAML files found for any provider or several are found
+	log.Printf("None or several YAML files found. Prompting user for provider selection.")
+	return pm.promptForProvider(ctx)
+}
 
-	case hasGitHubYml && !hasAzDevOpsYml:
-		// GitHub Actions YAML found, Azure DevOps YAML not found
-		log.Printf("Only GitHub Actions YAML found. Selecting GitHub Actions as the provider.")
-		return ciProviderGitHubActions, nil
+// remoteProvider returns the provider hosting the pipeline git remote of the repository, for the GitLab and Bitbucket
+// providers
+func (pm *PipelineManager) remoteProvider(ctx context.Context, repoRoot string) (ciProviderType, bool) {
+	remoteUrl, err := pm.gitCli.GetRemoteUrl(ctx, repoRoot, pm.args.PipelineRemoteName)
+	if err != nil {
+		return "", false
+	}
 
-	case hasAzDevOpsYml && !hasGitHubYml:
-		// Azure DevOps YAML found, GitHub Actions YAML not found
-		log.Printf("Only Azure DevOps YAML found. Selecting Azure DevOps as the provider.")
-		return ciProviderAzureDevOps, nil
+	if _, err := gitlab.ParseRemote(remoteUrl); err == nil {
+		return ciProviderGitLab, true
+	}
 
-	default:
-		// Default to GitHub Actions if no provider is specified
-		log.Printf("Defaulting to GitHub Actions as the provider.")
-		return ciProviderGitHubActions, nil
+	if _, err := bitbucket.ParseRemote(remoteUrl); err == nil {
+		return ciProviderBitbucket, true
 	}
+
+	return "", false
 }
 
 // promptForProvider prompts the user to select a CI/CD provider.
 func (pm *PipelineManager) promptForProvider(ctx context.Context) (ciProviderType, error) {
 	log.Printf("Prompting user to select a CI/CD provider.")
 	pm.console.Message(ctx, "")
+	options := []string{}
+	for _, provider := range selectableProviders {
+		options = append(options, pipelineProviderFiles[provider].DisplayName)
+	}
+
 	choice, err := pm.console.Select(ctx, input.ConsoleOptions{
 		Message: "Select a provider:",
-		Options: []string{gitHubDisplayName, azdoDisplayName},
+		Options: options,
 	})
 	if err != nil {
 		return "", fmt.Errorf("prompting for CI/CD provider: %w", err)
 	}
 
 	log.Printf("User selected choice: %d", choice)
-
-	if choice == 0 {
-		return ciProviderGitHubActions, nil
-	} else if choice == 1 {
-		return ciProviderAzureDevOps, nil
-	}
-
-	return "", nil // This case should never occur with the current options.
+	return selectableProviders[choice], nil
 }
 
 // resolveSmr resolves the service management reference from the user, project, or environment configuration.
@@ -5,6 +5,7 @@ package pipeline
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -128,6 +129,64 @@ func Test_PipelineManager_Initialize(t *testing.T) {
 		deleteYamlFiles(t, tempDir)
 	})
 
+	t.Run("no files - gitlab selected", func(t *testing.T) {
+		mockContext = resetContext(tempDir, ctx)
+
+		deleteYamlFiles(t, tempDir)
+
+		simulateUserInteraction(mockContext, ciProviderGitLab, true)
+
+		manager, err := createPipelineManager(mockContext, azdContext, nil, nil)
+		assert.NotNil(t, manager)
+		assert.NoError(t, err)
+
+		err = manager.initialize(ctx, "")
+		verifyProvider(t, manager, ciProviderGitLab, err)
+
+		// .gitlab-ci.yml is created at the root of the repository
+		gitLabCiPath := filepath.Join(tempDir, pipelineProviderFiles[ciProviderGitLab].Files[0])
+		assert.FileExists(t, gitLabCiPath)
+		deleteYamlFiles(t, tempDir)
+	})
+
+	t.Run("no files - bitbucket selected", func(t *testing.T) {
+		mockContext = resetContext(tempDir, ctx)
+
+		deleteYamlFiles(t, tempDir)
+
+		simulateUserInteraction(mockContext, ciProviderBitbucket, true)
+
+		manager, err := createPipelineManager(mockContext, azdContext, nil, nil)
+		assert.NotNil(t, manager)
+		assert.NoError(t, err)
+
+		err = manager.initialize(ctx, "")
+		verifyProvider(t, manager, ciProviderBitbucket, err)
+
+		// bitbucket-pipelines.yml is created at the root of the repository
+		bitbucketPipelinesPath := filepath.Join(tempDir, pipelineProviderFiles[ciProviderBitbucket].Files[0])
+		assert.FileExists(t, bitbucketPipelinesPath)
+		deleteYamlFiles(t, tempDir)
+	})
+
+	t.Run("export override", func(t *testing.T) {
+		mockContext = resetContext(tempDir, ctx)
+
+		deleteYamlFiles(t, tempDir)
+
+		args := &PipelineManagerArgs{
+			PipelineProvider: "export",
+		}
+
+		manager, err := createPipelineManager(mockContext, azdContext, nil, args)
+		verifyProvider(t, manager, ciProviderExport, err)
+
+		// the export provider doesn't use a pipeline definition
+		for _, provider := range selectableProviders {
+			assert.False(t, hasPipelineFile(provider, tempDir))
+		}
+	})
+
 	t.Run("from persisted data azdo error", func(t *testing.T) {
 		// User selects Azure DevOps, but the required directory is missing
 		mockContext = resetContext(tempDir, ctx)
@@ -691,6 +750,74 @@ func Test_promptForCiFiles(t *testing.T) {
 		assert.NoError(t, err)
 		snapshot.SnapshotT(t, normalizeEOL(content))
 	})
+	t.Run("no files - gitlab selected - no app host - fed Cred", func(t *testing.T) {
+		tempDir := t.TempDir()
+		expectedPath := filepath.Join(tempDir, pipelineProviderFiles[ciProviderGitLab].Files[0])
+		err := generatePipelineDefinition(expectedPath, projectProperties{
+			CiProvider:    ciProviderGitLab,
+			InfraProvider: infraProviderBicep,
+			RepoRoot:      tempDir,
+			HasAppHost:    false,
+			BranchName:    "main",
+			AuthType:      AuthTypeFederated,
+		})
+		assert.NoError(t, err)
+		assert.FileExists(t, expectedPath)
+		content, err := os.ReadFile(expectedPath)
+		assert.NoError(t, err)
+		snapshot.SnapshotT(t, normalizeEOL(content))
+	})
+	t.Run("no files - gitlab selected - App host - client cred", func(t *testing.T) {
+		tempDir := t.TempDir()
+		expectedPath := filepath.Join(tempDir, pipelineProviderFiles[ciProviderGitLab].Files[0])
+		err := generatePipelineDefinition(expectedPath, projectProperties{
+			CiProvider:    ciProviderGitLab,
+			InfraProvider: infraProviderBicep,
+			RepoRoot:      tempDir,
+			HasAppHost:    true,
+			BranchName:    "main",
+			AuthType:      AuthTypeClientCredentials,
+		})
+		assert.NoError(t, err)
+		assert.FileExists(t, expectedPath)
+		content, err := os.ReadFile(expectedPath)
+		assert.NoError(t, err)
+		snapshot.SnapshotT(t, normalizeEOL(content))
+	})
+	t.Run("no files - bitbucket selected - no app host", func(t *testing.T) {
+		tempDir := t.TempDir()
+		expectedPath := filepath.Join(tempDir, pipelineProviderFiles[ciProviderBitbucket].Files[0])
+		err := generatePipelineDefinition(expectedPath, projectProperties{
+			CiProvider:    ciProviderBitbucket,
+			InfraProvider: infraProviderBicep,
+			RepoRoot:      tempDir,
+			HasAppHost:    false,
+			BranchName:    "main",
+			AuthType:      AuthTypeClientCredentials,
+		})
+		assert.NoError(t, err)
+		assert.FileExists(t, expectedPath)
+		content, err := os.ReadFile(expectedPath)
+		assert.NoError(t, err)
+		snapshot.SnapshotT(t, normalizeEOL(content))
+	})
+	t.Run("no files - bitbucket selected - App host", func(t *testing.T) {
+		tempDir := t.TempDir()
+		expectedPath := filepath.Join(tempDir, pipelineProviderFiles[ciProviderBitbucket].Files[0])
+		err := generatePipelineDefinition(expectedPath, projectProperties{
+			CiProvider:    ciProviderBitbucket,
+			InfraProvider: infraProviderBicep,
+			RepoRoot:      tempDir,
+			HasAppHost:    true,
+			BranchName:    "main",
+			AuthType:      AuthTypeClientCredentials,
+		})
+		assert.NoError(t, err)
+		assert.FileExists(t, expectedPath)
+		content, err := os.ReadFile(expectedPath)
+		assert.NoError(t, err)
+		snapshot.SnapshotT(t, normalizeEOL(content))
+	})
 	t.Run("no files - azdo selected - branch name", func(t *testing.T) {
 		tempDir := t.TempDir()
 		path := filepath.Join(tempDir, pipelineProviderFiles[ciProviderAzureDevOps].PipelineDirectories[0])
@@ -798,13 +925,21 @@ func createPipelineManager(
 	)
 	mockContext.Container.MustRegisterSingleton(github.NewGitHubCli)
 	mockContext.Container.MustRegisterSingleton(git.NewCli)
+	ioc.RegisterInstance(mockContext.Container, mockContext.CoreClientOptions)
+	ioc.RegisterInstance(mockContext.Container, args)
 
 	// Pipeline providers
 	pipelineProviderMap := map[string]any{
-		"github-ci":  NewGitHubCiProvider,
-		"github-scm": NewGitHubScmProvider,
-		"azdo-ci":    NewAzdoCiProvider,
-		"azdo-scm":   NewAzdoScmProvider,
+		"github-ci":     NewGitHubCiProvider,
+		"github-scm":    NewGitHubScmProvider,
+		"azdo-ci":       NewAzdoCiProvider,
+		"azdo-scm":      NewAzdoScmProvider,
+		"gitlab-ci":     NewGitLabCiProvider,
+		"gitlab-scm":    NewGitLabScmProvider,
+		"bitbucket-ci":  NewBitbucketCiProvider,
+		"bitbucket-scm": NewBitbucketScmProvider,
+		"export-ci":     NewExportCiProvider,
+		"export-scm":    NewExportScmProvider,
 	}
 
 	for provider, constructor := range pipelineProviderMap {
@@ -849,6 +984,11 @@ func setupGitCliMocks(mockContext *mocks.MockContext, repoPath string) {
 	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
 		return exec.NewRunResult(0, "main", ""), nil
 	})
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return strings.Contains(command, "remote get-url")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		return exec.NewRunResult(2, "", "error: No such remote 'origin'"), errors.New("no such remote")
+	})
 }
 
 func resetAzureYaml(t *testing.T, projectFilePath string) {
@@ -957,16 +1097,24 @@ func createPipelineFiles(t *testing.T, baseDir string, provider ciProviderType,
 func deleteYamlFiles(t *testing.T, tempDir string, deleteOptions ...ciProviderType) {
 	shouldDeleteGitHub := true
 	shouldDeleteAzdo := true
+	shouldDeleteGitLab := true
+	shouldDeleteBitbucket := true
 
 	if len(deleteOptions) > 0 {
 		shouldDeleteGitHub = false
 		shouldDeleteAzdo = false
+		shouldDeleteGitLab = false
+		shouldDeleteBitbucket = false
 		for _, option := range deleteOptions {
 			switch option {
 			case ciProviderGitHubActions:
 				shouldDeleteGitHub = true
 			case ciProviderAzureDevOps:
 				shouldDeleteAzdo = true
+			case ciProviderGitLab:
+				shouldDeleteGitLab = true
+			case ciProviderBitbucket:
+				shouldDeleteBitbucket = true
 			}
 		}
 	}
@@ -978,6 +1126,14 @@ func deleteYamlFiles(t *testing.T, tempDir string, deleteOptions ...ciProviderTy
 	if shouldDeleteAzdo {
 		deletePipelineFiles(t, tempDir, ciProviderAzureDevOps)
 	}
+
+	if shouldDeleteGitLab {
+		deletePipelineFiles(t, tempDir, ciProviderGitLab)
+	}
+
+	if shouldDeleteBitbucket {
+		deletePipelineFiles(t, tempDir, ciProviderBitbucket)
+	}
 }
 
 // Helper function to delete pipeline files and directories
@@ -997,6 +1153,10 @@ func simulateUserInteraction(mockContext *mocks.MockContext, providerLabel ciPro
 		providerIndex = 0
 	case ciProviderAzureDevOps:
 		providerIndex = 1
+	case ciProviderGitLab:
+		providerIndex = 2
+	case ciProviderBitbucket:
+		providerIndex = 3
 	default:
 		providerIndex = 0
 	}
@@ -1024,6 +1184,15 @@ func verifyProvider(t *testing.T, manager *PipelineManager, providerLabel ciProv
 	case ciProviderAzureDevOps:
 		assert.IsType(t, &AzdoScmProvider{}, manager.scmProvider)
 		assert.IsType(t, &AzdoCiProvider{}, manager.ciProvider)
+	case ciProviderGitLab:
+		assert.IsType(t, &GitLabScmProvider{}, manager.scmProvider)
+		assert.IsType(t, &GitLabCiProvider{}, manager.ciProvider)
+	case ciProviderBitbucket:
+		assert.IsType(t, &BitbucketScmProvider{}, manager.scmProvider)
+		assert.IsType(t, &BitbucketCiProvider{}, manager.ciProvider)
+	case ciProviderExport:
+		assert.IsType(t, &ExportScmProvider{}, manager.scmProvider)
+		assert.IsType(t, &ExportCiProvider{}, manager.ciProvider)
 	default:
 		t.Fatalf("%s is not a known pipeline provider", providerLabel)
 	}
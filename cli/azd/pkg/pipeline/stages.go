@@ -0,0 +1,213 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package pipeline
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/fatih/color"
+	dmp "github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// pipelineService is a service of the project as seen by the pipeline definition templates.
+type pipelineService struct {
+	// Name is the name of the service in azure.yaml
+	Name string
+	// Id is the name of the service usable as part of job and stage identifiers
+	Id string
+	// Toolchain is the language toolchain installed and cached to package the service: dotnet, node, python, java,
+	// or empty when the service doesn't need one.
+	Toolchain string
+	// FromPackage is true when the service is packaged once to a file which is promoted through the stages.
+	// Services running container images, or hosted in Static Web Apps, are packaged by each deploy job instead.
+	FromPackage bool
+	// PackagePath is the path, relative to the repository root, where the package of the service is written.
+	PackagePath string
+}
+
+// pipelineStage is a deployment stage of the pipeline definition templates.
+type pipelineStage struct {
+	// Environment is the azd environment deployed by the stage
+	Environment string
+	// Id is the name of the environment usable as part of job and stage identifiers
+	Id string
+	// Approval requires a manual approval before the stage is deployed
+	Approval bool
+	// PreviousId is the Id of the stage deployed before this one, empty for the first stage
+	PreviousId string
+}
+
+var invalidIdCharsRegex = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// pipelineId converts a name to an identifier accepted by all the CI providers for jobs and stages.
+func pipelineId(name string) string {
+	return invalidIdCharsRegex.ReplaceAllString(name, "_")
+}
+
+// serviceToolchain returns the toolchain used to package services written in the given language.
+func serviceToolchain(language project.ServiceLanguageKind) string {
+	switch language {
+	case project.ServiceLanguageDotNet, project.ServiceLanguageCsharp, project.ServiceLanguageFsharp:
+		return "dotnet"
+	case project.ServiceLanguageJavaScript, project.ServiceLanguageTypeScript:
+		return "node"
+	case project.ServiceLanguagePython:
+		return "python"
+	case project.ServiceLanguageJava:
+		return "java"
+	default:
+		return ""
+	}
+}
+
+// newPipelineServices returns the services of the project, sorted by name.
+func newPipelineServices(prjConfig *project.ProjectConfig) []pipelineService {
+	services := make([]pipelineService, 0, len(prjConfig.Services))
+	for name, svc := range prjConfig.Services {
+		fromPackage := !svc.Host.RequiresContainer() &&
+			svc.Host != project.DotNetContainerAppTarget &&
+			svc.Host != project.StaticWebAppTarget
+
+		service := pipelineService{
+			Name:        name,
+			Id:          pipelineId(name),
+			Toolchain:   serviceToolchain(svc.Language),
+			FromPackage: fromPackage,
+		}
+		if fromPackage {
+			service.PackagePath = fmt.Sprintf("dist/%s.zip", service.Id)
+		}
+
+		services = append(services, service)
+	}
+
+	slices.SortFunc(services, func(a, b pipelineService) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return services
+}
+
+// newPipelineStages validates the stages of azure.yaml and returns them in promotion order.
+func newPipelineStages(stages []project.PipelineStage) ([]pipelineStage, error) {
+	result := make([]pipelineStage, 0, len(stages))
+	seen := map[string]bool{}
+
+	for _, stage := range stages {
+		if !environment.IsValidEnvironmentName(stage.Environment) {
+			return nil, fmt.Errorf(
+				"invalid pipeline stage environment '%s': environment names may only contain alphanumeric characters "+
+					"and '-', '(', ')', '_' or '.' and be at most %d characters long",
+				stage.Environment,
+				environment.EnvironmentNameMaxLength,
+			)
+		}
+
+		id := pipelineId(stage.Environment)
+		if seen[id] {
+			return nil, fmt.Errorf("pipeline stage environment '%s' is defined more than once", stage.Environment)
+		}
+		seen[id] = true
+
+		previousId := ""
+		if len(result) > 0 {
+			previousId = result[len(result)-1].Id
+		}
+
+		result = append(result, pipelineStage{
+			Environment: stage.Environment,
+			Id:          id,
+			Approval:    stage.Approval,
+			PreviousId:  previousId,
+		})
+	}
+
+	return result, nil
+}
+
+// diffContextLines is the number of unchanged lines displayed around each change of a diff.
+const diffContextLines = 2
+
+// diffLines returns a line diff of the changes from oldContent to newContent, where removed lines are prefixed with
+// '-' and added lines with '+'. Unchanged lines further than diffContextLines from a change are elided.
+func diffLines(oldContent string, newContent string) string {
+	// Each distinct line is encoded as a rune so the diff is computed line by line. The line helpers of diffmatchpatch
+	// are not used since they encode the lines as separated indexes which are split by the character diff.
+	var lines []string
+	lineRunes := map[string]rune{}
+	toRunes := func(content string) []rune {
+		var runes []rune
+		for _, line := range strings.SplitAfter(content, "\n") {
+			if line == "" {
+				continue
+			}
+			line = strings.TrimSuffix(line, "\n")
+			r, has := lineRunes[line]
+			if !has {
+				// start after the surrogates range to only produce valid runes
+				r = rune(0xE000 + len(lines))
+				lineRunes[line] = r
+				lines = append(lines, line)
+			}
+			runes = append(runes, r)
+		}
+		return runes
+	}
+
+	oldRunes := toRunes(oldContent)
+	newRunes := toRunes(newContent)
+
+	type diffLine struct {
+		op   dmp.Operation
+		text string
+	}
+
+	var all []diffLine
+	for _, diff := range dmp.New().DiffMainRunes(oldRunes, newRunes, false) {
+		for _, r := range diff.Text {
+			all = append(all, diffLine{op: diff.Type, text: lines[r-0xE000]})
+		}
+	}
+
+	// keep the unchanged lines close to a change
+	keep := make([]bool, len(all))
+	for i, line := range all {
+		if line.op == dmp.DiffEqual {
+			continue
+		}
+		for j := max(0, i-diffContextLines); j <= min(len(all)-1, i+diffContextLines); j++ {
+			keep[j] = true
+		}
+	}
+
+	var sb strings.Builder
+	elided := false
+	for i, line := range all {
+		if !keep[i] {
+			if !elided {
+				sb.WriteString("  ...\n")
+				elided = true
+			}
+			continue
+		}
+		elided = false
+
+		switch line.op {
+		case dmp.DiffInsert:
+			sb.WriteString(color.GreenString("+ %s", line.text))
+		case dmp.DiffDelete:
+			sb.WriteString(color.RedString("- %s", line.text))
+		default:
+			sb.WriteString("  " + line.text)
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
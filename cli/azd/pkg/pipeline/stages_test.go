@@ -0,0 +1,193 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package pipeline
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/snapshot"
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newPipelineServices(t *testing.T) {
+	services := newPipelineServices(&project.ProjectConfig{
+		Services: map[string]*project.ServiceConfig{
+			"web":     {Host: project.StaticWebAppTarget, Language: project.ServiceLanguageTypeScript},
+			"api":     {Host: project.AppServiceTarget, Language: project.ServiceLanguagePython},
+			"worker":  {Host: project.ContainerAppTarget, Language: project.ServiceLanguageDocker},
+			"jobs.fn": {Host: project.AzureFunctionTarget, Language: project.ServiceLanguageCsharp},
+		},
+	})
+
+	require.Equal(t, []pipelineService{
+		{Name: "api", Id: "api", Toolchain: "python", FromPackage: true, PackagePath: "dist/api.zip"},
+		{Name: "jobs.fn", Id: "jobs_fn", Toolchain: "dotnet", FromPackage: true, PackagePath: "dist/jobs_fn.zip"},
+		{Name: "web", Id: "web", Toolchain: "node"},
+		{Name: "worker", Id: "worker"},
+	}, services)
+}
+
+func Test_newPipelineStages(t *testing.T) {
+	t.Run("ordered", func(t *testing.T) {
+		stages, err := newPipelineStages([]project.PipelineStage{
+			{Environment: "dev"},
+			{Environment: "staging"},
+			{Environment: "prod-eu", Approval: true},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []pipelineStage{
+			{Environment: "dev", Id: "dev"},
+			{Environment: "staging", Id: "staging", PreviousId: "dev"},
+			{Environment: "prod-eu", Id: "prod_eu", Approval: true, PreviousId: "staging"},
+		}, stages)
+	})
+
+	t.Run("invalid environment name", func(t *testing.T) {
+		_, err := newPipelineStages([]project.PipelineStage{{Environment: "dev env"}})
+		require.ErrorContains(t, err, "invalid pipeline stage environment 'dev env'")
+	})
+
+	t.Run("duplicated environment", func(t *testing.T) {
+		_, err := newPipelineStages([]project.PipelineStage{{Environment: "prod-eu"}, {Environment: "prod_eu"}})
+		require.ErrorContains(t, err, "pipeline stage environment 'prod_eu' is defined more than once")
+	})
+}
+
+func Test_diffLines(t *testing.T) {
+	color.NoColor = true
+	t.Cleanup(func() { color.NoColor = false })
+
+	oldContent := "a\nb\nc\nd\ne\nf\ng\nh\n"
+	newContent := "a\nb\nc\nd\ne\nF\ng\nh\ni\n"
+
+	require.Equal(t, "  ...\n  d\n  e\n- f\n+ F\n  g\n  h\n+ i\n", diffLines(oldContent, newContent))
+}
+
+func Test_generatePipelineDefinition_stages(t *testing.T) {
+	services := []pipelineService{
+		{Name: "api", Id: "api", Toolchain: "python", FromPackage: true, PackagePath: "dist/api.zip"},
+		{Name: "web", Id: "web", Toolchain: "node"},
+	}
+	stages := []pipelineStage{
+		{Environment: "dev", Id: "dev"},
+		{Environment: "prod", Id: "prod", Approval: true, PreviousId: "dev"},
+	}
+
+	providers := []ciProviderType{ciProviderGitHubActions, ciProviderAzureDevOps, ciProviderGitLab, ciProviderBitbucket}
+	for _, provider := range providers {
+		t.Run(string(provider), func(t *testing.T) {
+			content, err := renderPipelineDefinition(projectProperties{
+				CiProvider:    provider,
+				InfraProvider: infraProviderBicep,
+				BranchName:    "main",
+				AuthType:      AuthTypeFederated,
+				Variables:     []string{"VAR_1"},
+				Secrets:       []string{"SECRET_1"},
+				Services:      services,
+				Stages:        stages,
+			})
+			require.NoError(t, err)
+			snapshot.SnapshotT(t, content)
+		})
+	}
+}
+
+func Test_promptForCiFilesUpdate(t *testing.T) {
+	color.NoColor = true
+	t.Cleanup(func() { color.NoColor = false })
+
+	props := projectProperties{
+		CiProvider:    ciProviderGitLab,
+		InfraProvider: infraProviderBicep,
+		BranchName:    "main",
+		AuthType:      AuthTypeFederated,
+		Services:      []pipelineService{{Name: "api", Id: "api"}},
+		Stages:        []pipelineStage{{Environment: "dev", Id: "dev"}},
+	}
+
+	for _, confirm := range []bool{true, false} {
+		name := "declined"
+		if confirm {
+			name = "confirmed"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			props.RepoRoot = t.TempDir()
+			path := filepath.Join(props.RepoRoot, gitLabCiFile)
+			require.NoError(t, os.WriteFile(path, []byte("stages:\n  - deploy\n"), osutil.PermissionFile))
+
+			mockContext := mocks.NewMockContext(context.Background())
+			mockContext.Console.WhenConfirm(func(options input.ConsoleOptions) bool {
+				return options.Message == "Would you like to overwrite it?"
+			}).Respond(confirm)
+
+			manager := &PipelineManager{console: mockContext.Console}
+			require.NoError(t, manager.promptForCiFilesUpdate(*mockContext.Context, props))
+
+			// the changes are displayed before confirming
+			output := strings.Join(mockContext.Console.Output(), "\n")
+			require.Contains(t, output, "-   - deploy\n")
+			require.Contains(t, output, "deploy-dev-api:")
+
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+			if confirm {
+				expected, err := renderPipelineDefinition(props)
+				require.NoError(t, err)
+				require.Equal(t, expected, string(content))
+			} else {
+				require.Equal(t, "stages:\n  - deploy\n", string(content))
+			}
+		})
+	}
+
+	t.Run("up to date", func(t *testing.T) {
+		props.RepoRoot = t.TempDir()
+		expected, err := renderPipelineDefinition(props)
+		require.NoError(t, err)
+		path := filepath.Join(props.RepoRoot, gitLabCiFile)
+		require.NoError(t, os.WriteFile(path, []byte(expected), osutil.PermissionFile))
+
+		// no confirmation is mocked, prompting would fail the test
+		mockContext := mocks.NewMockContext(context.Background())
+		manager := &PipelineManager{console: mockContext.Console}
+		require.NoError(t, manager.promptForCiFilesUpdate(*mockContext.Context, props))
+		require.Empty(t, mockContext.Console.Output())
+	})
+
+	t.Run("alternate file name", func(t *testing.T) {
+		githubProps := props
+		githubProps.CiProvider = ciProviderGitHubActions
+		githubProps.RepoRoot = t.TempDir()
+
+		// the pipeline files of the provider are considered, not only the default file
+		path := filepath.Join(githubProps.RepoRoot, pipelineProviderFiles[ciProviderGitHubActions].Files[1])
+		require.NotEqual(t, pipelineProviderFiles[ciProviderGitHubActions].DefaultFile, filepath.Base(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), osutil.PermissionDirectory))
+		require.NoError(t, os.WriteFile(path, []byte("on:\n  push:\n"), osutil.PermissionFile))
+
+		mockContext := mocks.NewMockContext(context.Background())
+		mockContext.Console.WhenConfirm(func(options input.ConsoleOptions) bool {
+			return options.Message == "Would you like to overwrite it?"
+		}).Respond(true)
+
+		manager := &PipelineManager{console: mockContext.Console}
+		require.NoError(t, manager.promptForCiFilesUpdate(*mockContext.Context, githubProps))
+
+		expected, err := renderPipelineDefinition(githubProps)
+		require.NoError(t, err)
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, expected, string(content))
+	})
+}
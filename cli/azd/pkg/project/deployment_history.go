@@ -0,0 +1,116 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/convert"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+)
+
+// maxDeploymentHistory is the maximum number of deployments recorded for each service
+const maxDeploymentHistory = 10
+
+// ServiceDeployment is a record of a single deployment of a service
+type ServiceDeployment struct {
+	// The container image that was deployed
+	Image string `json:"image,omitempty"`
+	// The revision that was created by the deployment
+	Revision  string    `json:"revision,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// DeploymentHistory records the deployments of each service within the environment config,
+// which allows rolling back a service to a previous deployment.
+type DeploymentHistory struct {
+	env        *environment.Environment
+	envManager environment.Manager
+}
+
+// NewDeploymentHistory creates a new DeploymentHistory for the specified environment
+func NewDeploymentHistory(env *environment.Environment, envManager environment.Manager) *DeploymentHistory {
+	return &DeploymentHistory{
+		env:        env,
+		envManager: envManager,
+	}
+}
+
+// List returns the recorded deployments of the service ordered from oldest to newest
+func (h *DeploymentHistory) List(serviceName string) ([]*ServiceDeployment, error) {
+	var deployments []*ServiceDeployment
+	if _, err := h.env.Config.GetSection(deploymentHistoryPath(serviceName), &deployments); err != nil {
+		return nil, fmt.Errorf("reading deployment history for service '%s': %w", serviceName, err)
+	}
+
+	return deployments, nil
+}
+
+// Record adds the deployment to the history of the service and saves the environment.
+// Only the latest deployments are kept.
+func (h *DeploymentHistory) Record(ctx context.Context, serviceName string, deployment *ServiceDeployment) error {
+	deployments, err := h.List(serviceName)
+	if err != nil {
+		return err
+	}
+
+	deployments = append(deployments, deployment)
+	if len(deployments) > maxDeploymentHistory {
+		deployments = deployments[len(deployments)-maxDeploymentHistory:]
+	}
+
+	return h.save(ctx, serviceName, deployments)
+}
+
+// Previous returns the deployment prior to the latest deployment of the service, or nil when there is none.
+func (h *DeploymentHistory) Previous(serviceName string) (*ServiceDeployment, error) {
+	deployments, err := h.List(serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(deployments) < 2 {
+		return nil, nil
+	}
+
+	return deployments[len(deployments)-2], nil
+}
+
+// RemoveLatest removes the latest deployment from the history of the service and saves the environment.
+// This is used after a rollback so that subsequent rollbacks continue moving back through the history.
+func (h *DeploymentHistory) RemoveLatest(ctx context.Context, serviceName string) error {
+	deployments, err := h.List(serviceName)
+	if err != nil {
+		return err
+	}
+
+	if len(deployments) == 0 {
+		return nil
+	}
+
+	return h.save(ctx, serviceName, deployments[:len(deployments)-1])
+}
+
+func (h *DeploymentHistory) save(ctx context.Context, serviceName string, deployments []*ServiceDeployment) error {
+	deploymentsJson, err := convert.ToJsonArray(deployments)
+	if err != nil {
+		return fmt.Errorf("converting deployment history to JSON: %w", err)
+	}
+
+	if err := h.env.Config.Set(deploymentHistoryPath(serviceName), deploymentsJson); err != nil {
+		return fmt.Errorf("setting deployment history for service '%s': %w", serviceName, err)
+	}
+
+	if err := h.envManager.Save(ctx, h.env); err != nil {
+		return fmt.Errorf("saving deployment history for service '%s': %w", serviceName, err)
+	}
+
+	return nil
+}
+
+func deploymentHistoryPath(serviceName string) string {
+	return fmt.Sprintf("deployments.%s", serviceName)
+}
@@ -0,0 +1,59 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockenv"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DeploymentHistory(t *testing.T) {
+	env := environment.New("test")
+	envManager := &mockenv.MockEnvManager{}
+	envManager.On("Save", mock.Anything, env).Return(nil)
+
+	history := NewDeploymentHistory(env, envManager)
+
+	previous, err := history.Previous("api")
+	require.NoError(t, err)
+	require.Nil(t, previous)
+
+	for i := 1; i <= maxDeploymentHistory+2; i++ {
+		err := history.Record(context.Background(), "api", &ServiceDeployment{
+			Image:     fmt.Sprintf("registry.azurecr.io/api:azd-deploy-%d", i),
+			Revision:  fmt.Sprintf("api--azd-%d", i),
+			Timestamp: time.Now(),
+		})
+		require.NoError(t, err)
+	}
+
+	deployments, err := history.List("api")
+	require.NoError(t, err)
+	require.Len(t, deployments, maxDeploymentHistory)
+	require.Equal(t, "api--azd-3", deployments[0].Revision)
+	require.Equal(t, fmt.Sprintf("api--azd-%d", maxDeploymentHistory+2), deployments[len(deployments)-1].Revision)
+
+	previous, err = history.Previous("api")
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("api--azd-%d", maxDeploymentHistory+1), previous.Revision)
+
+	err = history.RemoveLatest(context.Background(), "api")
+	require.NoError(t, err)
+
+	previous, err = history.Previous("api")
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("api--azd-%d", maxDeploymentHistory), previous.Revision)
+
+	// History is tracked per service
+	deployments, err = history.List("web")
+	require.NoError(t, err)
+	require.Empty(t, deployments)
+}
@@ -27,13 +27,10 @@ const (
 	ServiceLanguageSwa        ServiceLanguageKind = "swa"
 )
 
-func parseServiceLanguage(kind ServiceLanguageKind) (ServiceLanguageKind, error) {
-	// aliases
-	if string(kind) == "py" {
-		return ServiceLanguagePython, nil
-	}
-
-	switch kind {
+// IsBuiltIn returns true if the service language kind is implemented by azd.
+// Framework services for other languages may be provided by extensions.
+func (slk ServiceLanguageKind) IsBuiltIn() bool {
+	switch slk {
 	case ServiceLanguageNone,
 		ServiceLanguageDotNet,
 		ServiceLanguageCsharp,
@@ -42,13 +39,32 @@ func parseServiceLanguage(kind ServiceLanguageKind) (ServiceLanguageKind, error)
 		ServiceLanguageTypeScript,
 		ServiceLanguagePython,
 		ServiceLanguageJava,
-		ServiceLanguageDocker:
-		// Excluding ServiceLanguageSwa since it is implicitly derived currently,
-		// and not an actual language
+		ServiceLanguageDocker,
+		ServiceLanguageSwa:
+		return true
+	}
+
+	return false
+}
+
+func parseServiceLanguage(kind ServiceLanguageKind) (ServiceLanguageKind, error) {
+	// aliases
+	if string(kind) == "py" {
+		return ServiceLanguagePython, nil
+	}
+
+	// Excluding ServiceLanguageSwa since it is implicitly derived currently,
+	// and not an actual language
+	if kind == ServiceLanguageSwa {
+		return ServiceLanguageKind("Unsupported"), fmt.Errorf("unsupported language '%s'", kind)
+	}
+
+	if kind.IsBuiltIn() || isExternalServiceLanguage(kind) {
 		return kind, nil
 	}
 
-	return ServiceLanguageKind("Unsupported"), fmt.Errorf("unsupported language '%s'", kind)
+	return ServiceLanguageKind("Unsupported"), fmt.Errorf(
+		"unsupported language '%s', the language must be built into azd or provided by an installed extension", kind)
 }
 
 type FrameworkRequirements struct {
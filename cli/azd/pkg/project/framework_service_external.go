@@ -0,0 +1,76 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"fmt"
+	"sync"
+)
+
+var (
+	externalServiceLanguagesMu sync.RWMutex
+	externalServiceLanguages   = map[ServiceLanguageKind]bool{}
+)
+
+// RegisterExternalServiceLanguage registers a service language that is not built into azd, ex) a service language
+// declared by an installed extension. Projects are validated against the built-in and the registered service languages
+// when loaded.
+func RegisterExternalServiceLanguage(language ServiceLanguageKind) {
+	externalServiceLanguagesMu.Lock()
+	defer externalServiceLanguagesMu.Unlock()
+
+	externalServiceLanguages[language] = true
+}
+
+// isExternalServiceLanguage returns true if the service language has been registered by RegisterExternalServiceLanguage
+func isExternalServiceLanguage(language ServiceLanguageKind) bool {
+	externalServiceLanguagesMu.RLock()
+	defer externalServiceLanguagesMu.RUnlock()
+
+	return externalServiceLanguages[language]
+}
+
+// ExternalFrameworkServiceRegistry contains the framework services that are provided at runtime for service languages
+// that are not built into azd, ex) framework services provided by extensions.
+type ExternalFrameworkServiceRegistry struct {
+	frameworks sync.Map // key: ServiceLanguageKind, value: FrameworkService
+}
+
+// NewExternalFrameworkServiceRegistry creates a new empty ExternalFrameworkServiceRegistry
+func NewExternalFrameworkServiceRegistry() *ExternalFrameworkServiceRegistry {
+	return &ExternalFrameworkServiceRegistry{}
+}
+
+// Register registers the framework service for the specified service language.
+// Built-in service languages cannot be overridden and a service language can only be registered once.
+func (r *ExternalFrameworkServiceRegistry) Register(language ServiceLanguageKind, framework FrameworkService) error {
+	if language == ServiceLanguageNone {
+		return fmt.Errorf("service language is required")
+	}
+
+	if language.IsBuiltIn() {
+		return fmt.Errorf("service language '%s' is built into azd and cannot be overridden", language)
+	}
+
+	if _, loaded := r.frameworks.LoadOrStore(language, framework); loaded {
+		return fmt.Errorf("service language '%s' has already been registered", language)
+	}
+
+	return nil
+}
+
+// Unregister removes the framework service registered for the specified service language
+func (r *ExternalFrameworkServiceRegistry) Unregister(language ServiceLanguageKind) {
+	r.frameworks.Delete(language)
+}
+
+// Get returns the framework service registered for the specified service language
+func (r *ExternalFrameworkServiceRegistry) Get(language ServiceLanguageKind) (FrameworkService, bool) {
+	framework, has := r.frameworks.Load(language)
+	if !has {
+		return nil, false
+	}
+
+	return framework.(FrameworkService), true
+}
@@ -134,6 +134,14 @@ func (im *ImportManager) ProjectInfrastructure(ctx context.Context, projectConfi
 		projectConfig.Infra.Path = DefaultPath
 	}
 
+	// Each layer defines the path and module of its own infrastructure.
+	if len(projectConfig.Infra.Layers) > 0 {
+		log.Printf("using %d infrastructure layers", len(projectConfig.Infra.Layers))
+		return &Infra{
+			Options: projectConfig.Infra,
+		}, nil
+	}
+
 	infraRoot := projectConfig.Infra.Path
 	if !filepath.IsAbs(infraRoot) {
 		infraRoot = filepath.Join(projectConfig.Path, infraRoot)
@@ -263,6 +263,30 @@ func TestImportManagerProjectInfrastructure(t *testing.T) {
 	require.Equal(t, expectedDefaultModule, r.Options.Module)
 }
 
+func TestImportManagerProjectInfrastructureLayers(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	manager := NewImportManager(&DotNetImporter{
+		alphaFeatureManager: mockContext.AlphaFeaturesManager,
+	})
+
+	// the infrastructure of each layer is in its own folder, there is no module at the root of the infrastructure
+	infraOptions := provisioning.Options{
+		Layers: []provisioning.Options{
+			{Name: "network", Path: "infra/network", Module: DefaultModule},
+			{Name: "app", Path: "infra/app", Module: DefaultModule},
+		},
+	}
+
+	r, e := manager.ProjectInfrastructure(*mockContext.Context, &ProjectConfig{
+		Path:  t.TempDir(),
+		Infra: infraOptions,
+	})
+
+	require.NoError(t, e)
+	require.Equal(t, infraOptions.Layers, r.Options.Layers)
+	require.Len(t, r.Options.GetLayers(), 2)
+}
+
 //go:embed testdata/aspire-simple.json
 var aspireSimpleManifest []byte
 
@@ -90,6 +90,44 @@ func Parse(ctx context.Context, yamlContent string) (*ProjectConfig, error) {
 
 	projectConfig.Infra.Path = filepath.FromSlash(projectConfig.Infra.Path)
 
+	layerNames := map[string]bool{}
+	for i := range projectConfig.Infra.Layers {
+		layer := &projectConfig.Infra.Layers[i]
+		if layer.Name == "" {
+			return nil, fmt.Errorf("parsing project %s: infra layer %d must specify a name", projectConfig.Name, i+1)
+		}
+
+		if layerNames[layer.Name] {
+			return nil, fmt.Errorf("parsing project %s: duplicate infra layer '%s'", projectConfig.Name, layer.Name)
+		}
+		layerNames[layer.Name] = true
+
+		if layer.Path == "" {
+			return nil, fmt.Errorf(
+				"parsing project %s: infra layer '%s' must specify a path", projectConfig.Name, layer.Name)
+		}
+
+		if len(layer.Layers) > 0 {
+			return nil, fmt.Errorf(
+				"parsing project %s: infra layer '%s' can't define nested layers", projectConfig.Name, layer.Name)
+		}
+
+		layer.Provider, err = provisioning.ParseProvider(layer.Provider)
+		if err != nil {
+			return nil, fmt.Errorf("parsing project %s: infra layer '%s': %w", projectConfig.Name, layer.Name, err)
+		}
+
+		if layer.Module == "" {
+			layer.Module = DefaultModule
+		}
+
+		if strings.Contains(layer.Path, "\\") && !strings.Contains(layer.Path, "/") {
+			layer.Path = strings.ReplaceAll(layer.Path, "\\", "/")
+		}
+
+		layer.Path = filepath.FromSlash(layer.Path)
+	}
+
 	for key, svc := range projectConfig.Services {
 		svc.Name = key
 		svc.Project = &projectConfig
@@ -145,6 +183,25 @@ func Parse(ctx context.Context, yamlContent string) (*ProjectConfig, error) {
 	return &projectConfig, nil
 }
 
+// LoadRequiredVersions reads the required versions of the azure.yaml without validating the rest of the project, ex) to
+// install the extensions required by the project, which may provide the service hosts and languages of the project.
+func LoadRequiredVersions(projectFilePath string) (*RequiredVersions, error) {
+	bytes, err := os.ReadFile(projectFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading project file: %w", err)
+	}
+
+	var projectConfig struct {
+		RequiredVersions *RequiredVersions `yaml:"requiredVersions,omitempty"`
+	}
+
+	if err := yaml.Unmarshal(bytes, &projectConfig); err != nil {
+		return nil, fmt.Errorf("parsing project file: %w", err)
+	}
+
+	return projectConfig.RequiredVersions, nil
+}
+
 // Load hydrates the azure.yaml configuring into an viewable structure
 // This does not evaluate any tooling
 func Load(ctx context.Context, projectFilePath string) (*ProjectConfig, error) {
@@ -266,6 +323,10 @@ func Save(ctx context.Context, projectConfig *ProjectConfig, projectFilePath str
 	copy := *projectConfig
 
 	copy.Infra.Path = filepath.ToSlash(copy.Infra.Path)
+	copy.Infra.Layers = slices.Clone(projectConfig.Infra.Layers)
+	for i := range copy.Infra.Layers {
+		copy.Infra.Layers[i].Path = filepath.ToSlash(copy.Infra.Layers[i].Path)
+	}
 	copy.Services = make(map[string]*ServiceConfig, len(projectConfig.Services))
 
 	for name, svc := range projectConfig.Services {
@@ -26,20 +26,21 @@ type ProjectConfig struct {
 	// This should include the "v" prefix used in official version numbers.
 	MetaSchemaVersion string `yaml:"-"`
 
-	RequiredVersions  *RequiredVersions          `yaml:"requiredVersions,omitempty"`
-	Name              string                     `yaml:"name"`
-	ResourceGroupName osutil.ExpandableString    `yaml:"resourceGroup,omitempty"`
-	Path              string                     `yaml:"-"`
-	Metadata          *ProjectMetadata           `yaml:"metadata,omitempty"`
-	Services          map[string]*ServiceConfig  `yaml:"services,omitempty"`
-	Infra             provisioning.Options       `yaml:"infra,omitempty"`
-	Pipeline          PipelineOptions            `yaml:"pipeline,omitempty"`
-	Hooks             HooksConfig                `yaml:"hooks,omitempty"`
-	State             *state.Config              `yaml:"state,omitempty"`
-	Platform          *platform.Config           `yaml:"platform,omitempty"`
-	Workflows         workflow.WorkflowMap       `yaml:"workflows,omitempty"`
-	Cloud             *cloud.Config              `yaml:"cloud,omitempty"`
-	Resources         map[string]*ResourceConfig `yaml:"resources,omitempty"`
+	RequiredVersions   *RequiredVersions          `yaml:"requiredVersions,omitempty"`
+	Name               string                     `yaml:"name"`
+	ResourceGroupName  osutil.ExpandableString    `yaml:"resourceGroup,omitempty"`
+	Path               string                     `yaml:"-"`
+	Metadata           *ProjectMetadata           `yaml:"metadata,omitempty"`
+	ServiceParallelism int                        `yaml:"serviceParallelism,omitempty"`
+	Services           map[string]*ServiceConfig  `yaml:"services,omitempty"`
+	Infra              provisioning.Options       `yaml:"infra,omitempty"`
+	Pipeline           PipelineOptions            `yaml:"pipeline,omitempty"`
+	Hooks              HooksConfig                `yaml:"hooks,omitempty"`
+	State              *state.Config              `yaml:"state,omitempty"`
+	Platform           *platform.Config           `yaml:"platform,omitempty"`
+	Workflows          workflow.WorkflowMap       `yaml:"workflows,omitempty"`
+	Cloud              *cloud.Config              `yaml:"cloud,omitempty"`
+	Resources          map[string]*ResourceConfig `yaml:"resources,omitempty"`
 
 	*ext.EventDispatcher[ProjectLifecycleEventArgs] `yaml:"-"`
 }
@@ -57,6 +58,16 @@ type PipelineOptions struct {
 	Provider  string   `yaml:"provider"`
 	Variables []string `yaml:"variables"`
 	Secrets   []string `yaml:"secrets"`
+	// Stages are the azd environments the generated pipeline deploys to, in promotion order.
+	Stages []PipelineStage `yaml:"stages,omitempty"`
+}
+
+// PipelineStage is a deployment stage of the generated pipeline.
+type PipelineStage struct {
+	// Environment is the name of the azd environment deployed by the stage.
+	Environment string `yaml:"environment"`
+	// Approval requires a manual approval before the stage is deployed.
+	Approval bool `yaml:"approval,omitempty"`
 }
 
 // Project lifecycle event arguments
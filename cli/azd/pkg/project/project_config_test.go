@@ -7,12 +7,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/ext"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
 	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 	"github.com/azure/azure-dev/cli/azd/test/mocks"
 	"github.com/azure/azure-dev/cli/azd/test/snapshot"
@@ -65,6 +67,44 @@ func TestProjectConfigParse_Invalid(t *testing.T) {
 	}
 }
 
+func TestProjectConfigExternalServiceHost(t *testing.T) {
+	projectConfig := "name: proj-external-host\nservices:\n  web:\n    language: python\n    host: vm\n"
+
+	_, err := Parse(context.Background(), projectConfig)
+	require.ErrorContains(t, err, "unsupported host 'vm'")
+
+	RegisterExternalServiceHost("vm")
+	t.Cleanup(func() {
+		externalServiceHostsMu.Lock()
+		defer externalServiceHostsMu.Unlock()
+
+		delete(externalServiceHosts, "vm")
+	})
+
+	project, err := Parse(context.Background(), projectConfig)
+	require.NoError(t, err)
+	require.Equal(t, ServiceTargetKind("vm"), project.Services["web"].Host)
+}
+
+func TestProjectConfigExternalServiceLanguage(t *testing.T) {
+	projectConfig := "name: proj-external-lang\nservices:\n  web:\n    language: go\n    host: containerapp\n"
+
+	_, err := Parse(context.Background(), projectConfig)
+	require.ErrorContains(t, err, "unsupported language 'go'")
+
+	RegisterExternalServiceLanguage("go")
+	t.Cleanup(func() {
+		externalServiceLanguagesMu.Lock()
+		defer externalServiceLanguagesMu.Unlock()
+
+		delete(externalServiceLanguages, "go")
+	})
+
+	project, err := Parse(context.Background(), projectConfig)
+	require.NoError(t, err)
+	require.Equal(t, ServiceLanguageKind("go"), project.Services["web"].Language)
+}
+
 func TestProjectConfigDefaults(t *testing.T) {
 	const testProj = `
 name: test-proj
@@ -102,6 +142,81 @@ services:
 	}
 }
 
+func TestProjectConfigInfraLayers(t *testing.T) {
+	const testProj = `
+name: test-proj
+infra:
+  provider: terraform
+  layers:
+    - name: network
+      path: infra\network
+    - name: app
+      path: infra/app
+      provider: bicep
+      module: app
+      resourceGroup: rg-${AZURE_ENV_NAME}-app
+`
+
+	projectConfig, err := Parse(context.Background(), testProj)
+	require.NoError(t, err)
+
+	layers := projectConfig.Infra.GetLayers()
+	require.Len(t, layers, 2)
+
+	require.Equal(t, "network", layers[0].Name)
+	require.Equal(t, filepath.FromSlash("infra/network"), layers[0].Path)
+	require.Equal(t, DefaultModule, layers[0].Module)
+	// layers inherit the provider of the infrastructure
+	require.Equal(t, provisioning.Terraform, layers[0].Provider)
+
+	require.Equal(t, "app", layers[1].Name)
+	require.Equal(t, filepath.FromSlash("infra/app"), layers[1].Path)
+	require.Equal(t, "app", layers[1].Module)
+	require.Equal(t, provisioning.Bicep, layers[1].Provider)
+	require.Equal(t, "rg-${AZURE_ENV_NAME}-app", layers[1].ResourceGroup)
+
+	invalid := map[string]string{
+		"must specify a name": `
+name: test-proj
+infra:
+  layers:
+    - path: infra/network
+`,
+		"duplicate infra layer 'app'": `
+name: test-proj
+infra:
+  layers:
+    - name: app
+      path: infra/app
+    - name: app
+      path: infra/app2
+`,
+		"infra layer 'app' must specify a path": `
+name: test-proj
+infra:
+  layers:
+    - name: app
+`,
+		"can't define nested layers": `
+name: test-proj
+infra:
+  layers:
+    - name: app
+      path: infra/app
+      layers:
+        - name: nested
+          path: infra/nested
+`,
+	}
+
+	for message, projectConfig := range invalid {
+		t.Run(message, func(t *testing.T) {
+			_, err := Parse(context.Background(), projectConfig)
+			require.ErrorContains(t, err, message)
+		})
+	}
+}
+
 func TestProjectWithCustomDockerOptions(t *testing.T) {
 	const testProj = `
 name: test-proj
@@ -39,10 +39,16 @@ type ServiceConfig struct {
 	K8s AksOptions `yaml:"k8s,omitempty"`
 	// The optional Azure Spring Apps options
 	Spring SpringOptions `yaml:"spring,omitempty"`
+	// The optional deployment slot options for App Service and Function App targets
+	DeploymentSlot DeploymentSlotOptions `yaml:"deploymentSlot,omitempty"`
+	// The optional canary options for gradually shifting traffic to new Container App revisions
+	Canary CanaryOptions `yaml:"canary,omitempty"`
 	// The infrastructure provisioning configuration
 	Infra provisioning.Options `yaml:"infra,omitempty"`
 	// Hook configuration for service
 	Hooks HooksConfig `yaml:"hooks,omitempty"`
+	// The names of other services that must complete before lifecycle operations run for this service
+	DependsOn []string `yaml:"dependsOn,omitempty"`
 	// Options specific to the DotNetContainerApp target. These are set by the importer and
 	// can not be controlled via the project file today.
 	DotNetContainerApp *DotNetContainerAppOptions `yaml:"-,omitempty"`
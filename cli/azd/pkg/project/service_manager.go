@@ -13,7 +13,9 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
+	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/alpha"
 	"github.com/azure/azure-dev/cli/azd/pkg/async"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
@@ -96,6 +98,18 @@ type ServiceManager interface {
 		progress *async.Progress[ServiceProgress],
 	) (*ServiceDeployResult, error)
 
+	// Rolls back the service to the previous deployment recorded in the deployment history.
+	// Only supported by service targets that implement RollbackServiceTarget.
+	Rollback(
+		ctx context.Context,
+		serviceConfig *ServiceConfig,
+		progress *async.Progress[ServiceProgress],
+	) (*ServiceDeployResult, error)
+
+	// Gets the result of the deployment of the specified service performed by the current azd command.
+	// Returns false when the service has not been deployed.
+	GetDeployResult(serviceConfig *ServiceConfig) (*ServiceDeployResult, bool)
+
 	// Gets the framework service for the specified service config
 	// The framework service performs the restoration and building of the service app code
 	GetFrameworkService(ctx context.Context, serviceConfig *ServiceConfig) (FrameworkService, error)
@@ -118,6 +132,8 @@ type serviceManager struct {
 	operationCache      ServiceOperationCache
 	alphaFeatureManager *alpha.FeatureManager
 	initialized         map[*ServiceConfig]map[any]bool
+	// mu guards the operation cache and initialized components since services may be processed concurrently
+	mu sync.Mutex
 }
 
 // NewServiceManager creates a new instance of the ServiceManager component
@@ -175,7 +191,7 @@ func (sm *serviceManager) Initialize(ctx context.Context, serviceConfig *Service
 			return err
 		}
 
-		sm.initialized[serviceConfig][frameworkService] = true
+		sm.setComponentInitialized(serviceConfig, frameworkService)
 	}
 
 	if ok := sm.isComponentInitialized(serviceConfig, serviceTarget); !ok {
@@ -183,7 +199,7 @@ func (sm *serviceManager) Initialize(ctx context.Context, serviceConfig *Service
 			return err
 		}
 
-		sm.initialized[serviceConfig][serviceTarget] = true
+		sm.setComponentInitialized(serviceConfig, serviceTarget)
 	}
 
 	return nil
@@ -502,6 +518,51 @@ func (sm *serviceManager) Deploy(
 	return deployResult, nil
 }
 
+// Rolls back the service to the previous deployment recorded in the deployment history
+func (sm *serviceManager) Rollback(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	progress *async.Progress[ServiceProgress],
+) (*ServiceDeployResult, error) {
+	serviceTarget, err := sm.GetServiceTarget(ctx, serviceConfig)
+	if err != nil {
+		return nil, fmt.Errorf("getting service target: %w", err)
+	}
+
+	rollbackTarget, ok := serviceTarget.(RollbackServiceTarget)
+	if !ok {
+		return nil, fmt.Errorf(
+			"rolling back service '%s' is not supported for host '%s'", serviceConfig.Name, serviceConfig.Host)
+	}
+
+	targetResource, err := sm.resourceManager.GetTargetResource(ctx, sm.env.GetSubscriptionId(), serviceConfig)
+	if err != nil {
+		return nil, fmt.Errorf("getting target resource: %w", err)
+	}
+
+	deployResult, err := rollbackTarget.Rollback(ctx, serviceConfig, targetResource, progress)
+	if err != nil {
+		return nil, fmt.Errorf("failed rolling back service '%s': %w", serviceConfig.Name, err)
+	}
+
+	overriddenEndpoints := OverriddenEndpoints(ctx, serviceConfig, sm.env)
+	if len(overriddenEndpoints) > 0 {
+		deployResult.Endpoints = overriddenEndpoints
+	}
+
+	return deployResult, nil
+}
+
+// Gets the result of the deployment of the specified service performed by the current azd command
+func (sm *serviceManager) GetDeployResult(serviceConfig *ServiceConfig) (*ServiceDeployResult, bool) {
+	cachedResult, ok := sm.getOperationResult(serviceConfig, string(ServiceEventDeploy))
+	if !ok || cachedResult == nil {
+		return nil, false
+	}
+
+	return cachedResult.(*ServiceDeployResult), true
+}
+
 // GetServiceTarget constructs a ServiceTarget from the underlying service configuration
 func (sm *serviceManager) GetServiceTarget(ctx context.Context, serviceConfig *ServiceConfig) (ServiceTarget, error) {
 	var target ServiceTarget
@@ -519,6 +580,10 @@ func (sm *serviceManager) GetServiceTarget(ctx context.Context, serviceConfig *S
 	}
 
 	if err := sm.serviceLocator.ResolveNamed(host, &target); err != nil {
+		if !serviceConfig.Host.IsBuiltIn() {
+			return sm.getExternalServiceTarget(serviceConfig)
+		}
+
 		return nil, fmt.Errorf(
 			"failed to resolve service host '%s' for service '%s', %w",
 			serviceConfig.Host,
@@ -530,6 +595,28 @@ func (sm *serviceManager) GetServiceTarget(ctx context.Context, serviceConfig *S
 	return target, nil
 }
 
+// getExternalServiceTarget returns the service target registered for a service host that is not built into azd
+func (sm *serviceManager) getExternalServiceTarget(serviceConfig *ServiceConfig) (ServiceTarget, error) {
+	var registry *ExternalServiceTargetRegistry
+	if err := sm.serviceLocator.Resolve(&registry); err == nil {
+		if target, has := registry.Get(serviceConfig.Host); has {
+			return target, nil
+		}
+	}
+
+	return nil, &internal.ErrorWithSuggestion{
+		Err: fmt.Errorf(
+			"unsupported host '%s' for service '%s'",
+			serviceConfig.Host,
+			serviceConfig.Name,
+		),
+		Suggestion: fmt.Sprintf(
+			"Install an extension that provides the '%s' service host, or use one of the built-in hosts.",
+			serviceConfig.Host,
+		),
+	}
+}
+
 // GetFrameworkService constructs a framework service from the underlying service configuration
 func (sm *serviceManager) GetFrameworkService(ctx context.Context, serviceConfig *ServiceConfig) (FrameworkService, error) {
 	var frameworkService FrameworkService
@@ -540,12 +627,19 @@ func (sm *serviceManager) GetFrameworkService(ctx context.Context, serviceConfig
 	}
 
 	if err := sm.serviceLocator.ResolveNamed(string(serviceConfig.Language), &frameworkService); err != nil {
-		return nil, fmt.Errorf(
-			"failed to resolve language '%s' for service '%s', %w",
-			serviceConfig.Language,
-			serviceConfig.Name,
-			err,
-		)
+		if serviceConfig.Language.IsBuiltIn() {
+			return nil, fmt.Errorf(
+				"failed to resolve language '%s' for service '%s', %w",
+				serviceConfig.Language,
+				serviceConfig.Name,
+				err,
+			)
+		}
+
+		frameworkService, err = sm.getExternalFrameworkService(serviceConfig)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	var compositeFramework CompositeFrameworkService
@@ -586,6 +680,28 @@ func (sm *serviceManager) GetFrameworkService(ctx context.Context, serviceConfig
 	return frameworkService, nil
 }
 
+// getExternalFrameworkService returns the framework service registered for a service language that is not built into azd
+func (sm *serviceManager) getExternalFrameworkService(serviceConfig *ServiceConfig) (FrameworkService, error) {
+	var registry *ExternalFrameworkServiceRegistry
+	if err := sm.serviceLocator.Resolve(&registry); err == nil {
+		if framework, has := registry.Get(serviceConfig.Language); has {
+			return framework, nil
+		}
+	}
+
+	return nil, &internal.ErrorWithSuggestion{
+		Err: fmt.Errorf(
+			"unsupported language '%s' for service '%s'",
+			serviceConfig.Language,
+			serviceConfig.Name,
+		),
+		Suggestion: fmt.Sprintf(
+			"Install an extension that provides the '%s' service language, or use one of the built-in languages.",
+			serviceConfig.Language,
+		),
+	}
+}
+
 func OverriddenEndpoints(ctx context.Context, serviceConfig *ServiceConfig, env *environment.Environment) []string {
 	overriddenEndpoints := env.GetServiceProperty(serviceConfig.Name, "ENDPOINTS")
 	if overriddenEndpoints != "" {
@@ -609,6 +725,10 @@ func OverriddenEndpoints(ctx context.Context, serviceConfig *ServiceConfig, env
 // Attempts to retrieve the result of a previous operation from the cache
 func (sm *serviceManager) getOperationResult(serviceConfig *ServiceConfig, operationName string) (any, bool) {
 	key := fmt.Sprintf("%s:%s:%s", sm.env.Name(), serviceConfig.Name, operationName)
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
 	value, ok := sm.operationCache[key]
 
 	return value, ok
@@ -617,11 +737,18 @@ func (sm *serviceManager) getOperationResult(serviceConfig *ServiceConfig, opera
 // Sets the result of an operation in the cache
 func (sm *serviceManager) setOperationResult(serviceConfig *ServiceConfig, operationName string, result any) {
 	key := fmt.Sprintf("%s:%s:%s", sm.env.Name(), serviceConfig.Name, operationName)
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
 	sm.operationCache[key] = result
 }
 
 // isComponentInitialized Checks if a component has been initialized for a service configuration
 func (sm *serviceManager) isComponentInitialized(serviceConfig *ServiceConfig, component any) bool {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
 	if componentMap, has := sm.initialized[serviceConfig]; has && len(componentMap) > 0 {
 		initialized := false
 		if ok, has := componentMap[component]; has && ok {
@@ -636,6 +763,14 @@ func (sm *serviceManager) isComponentInitialized(serviceConfig *ServiceConfig, c
 	return false
 }
 
+// setComponentInitialized marks a component as initialized for a service configuration
+func (sm *serviceManager) setComponentInitialized(serviceConfig *ServiceConfig, component any) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	sm.initialized[serviceConfig][component] = true
+}
+
 func runCommand[T any](
 	ctx context.Context,
 	eventName ext.Event,
@@ -266,6 +266,35 @@ func Test_ServiceManager_GetFrameworkService(t *testing.T) {
 		_, err := sm.GetFrameworkService(*mockContext.Context, serviceConfig)
 		require.Error(t, err)
 	})
+
+	t.Run("External language", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		setupMocksForServiceManager(mockContext)
+		registry := NewExternalFrameworkServiceRegistry()
+		mockContext.Container.MustRegisterSingleton(func() *ExternalFrameworkServiceRegistry {
+			return registry
+		})
+
+		env := environment.New("test")
+		sm := createServiceManager(mockContext, env, ServiceOperationCache{})
+		serviceConfig := createTestServiceConfig("./src/api", ServiceTargetFake, ServiceLanguageKind("go"))
+
+		_, err := sm.GetFrameworkService(*mockContext.Context, serviceConfig)
+		require.ErrorContains(t, err, "unsupported language 'go'")
+
+		externalFramework := newFakeFramework(mockContext.CommandRunner)
+		require.NoError(t, registry.Register("go", externalFramework))
+		require.Error(t, registry.Register("go", externalFramework))
+		require.Error(t, registry.Register(ServiceLanguagePython, externalFramework))
+
+		framework, err := sm.GetFrameworkService(*mockContext.Context, serviceConfig)
+		require.NoError(t, err)
+		require.Same(t, externalFramework, framework)
+
+		registry.Unregister("go")
+		_, err = sm.GetFrameworkService(*mockContext.Context, serviceConfig)
+		require.Error(t, err)
+	})
 }
 
 func Test_ServiceManager_GetServiceTarget(t *testing.T) {
@@ -281,6 +310,35 @@ func Test_ServiceManager_GetServiceTarget(t *testing.T) {
 	require.IsType(t, new(fakeServiceTarget), serviceTarget)
 }
 
+func Test_ServiceManager_GetServiceTarget_External(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	setupMocksForServiceManager(mockContext)
+	registry := NewExternalServiceTargetRegistry()
+	mockContext.Container.MustRegisterSingleton(func() *ExternalServiceTargetRegistry {
+		return registry
+	})
+
+	env := environment.New("test")
+	sm := createServiceManager(mockContext, env, ServiceOperationCache{})
+	serviceConfig := createTestServiceConfig("./src/api", ServiceTargetKind("vm"), ServiceLanguageFake)
+
+	_, err := sm.GetServiceTarget(*mockContext.Context, serviceConfig)
+	require.ErrorContains(t, err, "unsupported host 'vm'")
+
+	externalTarget := newFakeServiceTarget(mockContext.CommandRunner)
+	require.NoError(t, registry.Register("vm", externalTarget))
+	require.Error(t, registry.Register("vm", externalTarget))
+	require.Error(t, registry.Register(AppServiceTarget, externalTarget))
+
+	serviceTarget, err := sm.GetServiceTarget(*mockContext.Context, serviceConfig)
+	require.NoError(t, err)
+	require.Same(t, externalTarget, serviceTarget)
+
+	registry.Unregister("vm")
+	_, err = sm.GetServiceTarget(*mockContext.Context, serviceConfig)
+	require.Error(t, err)
+}
+
 func Test_ServiceManager_CacheResults(t *testing.T) {
 	mockContext := mocks.NewMockContext(context.Background())
 	setupMocksForServiceManager(mockContext)
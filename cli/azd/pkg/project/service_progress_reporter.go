@@ -0,0 +1,139 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
+)
+
+// ServiceProgressReporter renders the progress of service operations to the console.
+// Operations for multiple services may be running concurrently, in which case a single spinner summarizing
+// all running services is displayed and each service reports its final status as it completes.
+type ServiceProgressReporter struct {
+	console input.Console
+	// The verb displayed for the operation, ex) Deploying
+	verb string
+
+	mu       sync.Mutex
+	running  []string
+	messages map[string]string
+}
+
+// NewServiceProgressReporter creates a new ServiceProgressReporter for the specified operation verb, ex) Deploying
+func NewServiceProgressReporter(console input.Console, verb string) *ServiceProgressReporter {
+	return &ServiceProgressReporter{
+		console:  console,
+		verb:     verb,
+		messages: map[string]string{},
+	}
+}
+
+// Skip reports that the service was skipped
+func (r *ServiceProgressReporter) Skip(ctx context.Context, serviceName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stepMessage := r.stepMessage(serviceName)
+	r.console.ShowSpinner(ctx, stepMessage, input.Step)
+	r.console.StopSpinner(ctx, stepMessage, input.StepSkipped)
+	r.refresh(ctx)
+}
+
+// SkipFailedDependency reports that the service was skipped because one of its dependencies failed
+func (r *ServiceProgressReporter) SkipFailedDependency(ctx context.Context, serviceConfig *ServiceConfig, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stepMessage := r.stepMessage(serviceConfig.Name)
+	r.console.ShowSpinner(ctx, stepMessage, input.Step)
+	r.console.StopSpinner(ctx, stepMessage, input.StepSkipped)
+	r.console.Message(ctx, output.WithGrayFormat("  (%s)", err.Error()))
+	r.refresh(ctx)
+}
+
+// Start reports that the operation for the service has started
+func (r *ServiceProgressReporter) Start(ctx context.Context, serviceName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.running = append(r.running, serviceName)
+	r.messages[serviceName] = ""
+	r.refresh(ctx)
+}
+
+// Progress reports an incremental progress update for the service
+func (r *ServiceProgressReporter) Progress(ctx context.Context, serviceName string, progress ServiceProgress) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, has := r.messages[serviceName]; !has {
+		return
+	}
+
+	r.messages[serviceName] = progress.Message
+	r.refresh(ctx)
+}
+
+// Stop reports the final status of the operation for the service, followed by any additional result items
+func (r *ServiceProgressReporter) Stop(ctx context.Context, serviceName string, err error, items ...ux.UxItem) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.running = slices.DeleteFunc(r.running, func(name string) bool { return name == serviceName })
+	delete(r.messages, serviceName)
+
+	stepMessage := r.stepMessage(serviceName)
+	if !r.console.IsSpinnerRunning(ctx) {
+		r.console.ShowSpinner(ctx, stepMessage, input.Step)
+	}
+	r.console.StopSpinner(ctx, stepMessage, input.GetStepResultFormat(err))
+
+	for _, item := range items {
+		if item != nil {
+			r.console.MessageUxItem(ctx, item)
+		}
+	}
+
+	r.refresh(ctx)
+}
+
+// refresh updates the spinner to reflect the services that are currently running
+// Callers are expected to hold the lock.
+func (r *ServiceProgressReporter) refresh(ctx context.Context) {
+	switch len(r.running) {
+	case 0:
+		return
+	case 1:
+		serviceName := r.running[0]
+		spinnerMessage := r.stepMessage(serviceName)
+		if message := r.messages[serviceName]; message != "" {
+			spinnerMessage = fmt.Sprintf("%s (%s)", spinnerMessage, message)
+		}
+
+		r.console.ShowSpinner(ctx, spinnerMessage, input.Step)
+	default:
+		services := make([]string, len(r.running))
+		for i, serviceName := range r.running {
+			services[i] = serviceName
+			if message := r.messages[serviceName]; message != "" {
+				services[i] = fmt.Sprintf("%s (%s)", serviceName, message)
+			}
+		}
+
+		spinnerMessage := fmt.Sprintf("%s services %s", r.verb, strings.Join(services, ", "))
+		r.console.ShowSpinner(ctx, spinnerMessage, input.Step)
+	}
+}
+
+func (r *ServiceProgressReporter) stepMessage(serviceName string) string {
+	return fmt.Sprintf("%s service %s", r.verb, serviceName)
+}
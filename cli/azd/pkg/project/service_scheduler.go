@@ -0,0 +1,327 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ServiceMaxParallelismEnvVarName is the name of the environment variable used to configure how many services
+// are processed concurrently by service lifecycle commands (restore, build, package & deploy).
+const ServiceMaxParallelismEnvVarName = "AZD_SERVICE_MAX_PARALLELISM"
+
+// DefaultServiceMaxParallelism is the number of services processed concurrently when neither azure.yaml nor the
+// environment configure it. Services with interactive hooks are always run on their own, see ServiceScheduler.
+const DefaultServiceMaxParallelism = 4
+
+// ErrServiceDependencyFailed is returned for services that were not run because one of their dependencies failed.
+var ErrServiceDependencyFailed = errors.New("service dependency failed")
+
+// ErrServiceCancelled is returned for services that were not started because the operation was cancelled.
+var ErrServiceCancelled = errors.New("service cancelled")
+
+// ServiceOperationFn is a function that performs a lifecycle operation against a single service
+type ServiceOperationFn func(ctx context.Context, serviceConfig *ServiceConfig) error
+
+// ServiceSkippedFn is invoked for a service that is skipped because one of its dependencies failed
+type ServiceSkippedFn func(ctx context.Context, serviceConfig *ServiceConfig, err error)
+
+// ServiceError is the error produced by running an operation against a single service
+type ServiceError struct {
+	ServiceName string
+	Err         error
+}
+
+func (e *ServiceError) Error() string {
+	return fmt.Sprintf("service '%s': %s", e.ServiceName, e.Err.Error())
+}
+
+func (e *ServiceError) Unwrap() error {
+	return e.Err
+}
+
+// ServiceErrors is the aggregated set of per-service errors returned by the ServiceScheduler
+type ServiceErrors []*ServiceError
+
+func (e ServiceErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Err.Error()
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("%d services failed:", len(e)))
+	for _, serviceErr := range e {
+		sb.WriteString("\n  - ")
+		sb.WriteString(serviceErr.Error())
+	}
+
+	return sb.String()
+}
+
+// Unwrap allows errors.Is and errors.As to inspect each of the underlying service errors
+func (e ServiceErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, serviceErr := range e {
+		errs[i] = serviceErr
+	}
+
+	return errs
+}
+
+// ServiceScheduler runs service lifecycle operations across a set of services.
+// Services are run concurrently up to the configured max parallelism, and a service is only started
+// after all of the services it depends on (via `dependsOn` in azure.yaml) have completed successfully.
+// Services with interactive hooks bind the console while they run, so they are never run alongside other services.
+type ServiceScheduler struct {
+	maxParallelism int
+	onSkipped      ServiceSkippedFn
+}
+
+// NewServiceScheduler creates a new ServiceScheduler that runs at most maxParallelism operations at once.
+// Values less than 1 are treated as 1, which runs services sequentially.
+func NewServiceScheduler(maxParallelism int) *ServiceScheduler {
+	if maxParallelism < 1 {
+		maxParallelism = 1
+	}
+
+	return &ServiceScheduler{
+		maxParallelism: maxParallelism,
+	}
+}
+
+// OnSkipped registers fn to be invoked for each service that is skipped because one of its dependencies failed
+func (s *ServiceScheduler) OnSkipped(fn ServiceSkippedFn) {
+	s.onSkipped = fn
+}
+
+// ServiceMaxParallelism returns the configured max parallelism for service operations.
+// The AZD_SERVICE_MAX_PARALLELISM environment variable overrides the `serviceParallelism` of azure.yaml, which defaults
+// to DefaultServiceMaxParallelism.
+func ServiceMaxParallelism(projectConfig *ProjectConfig) int {
+	if value, has := os.LookupEnv(ServiceMaxParallelismEnvVarName); has {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+
+	if projectConfig != nil && projectConfig.ServiceParallelism > 0 {
+		return projectConfig.ServiceParallelism
+	}
+
+	return DefaultServiceMaxParallelism
+}
+
+// Run invokes fn for each of the specified services.
+//
+// Services that have no pending dependencies are started in the order they were specified. Dependencies that reference
+// services which are not part of the specified set are ignored, which allows running an operation against a
+// single service. When a service fails, all services that depend on it are skipped and reported with
+// ErrServiceDependencyFailed while unrelated services continue to run. When the context is cancelled, the services that
+// weren't started are reported with ErrServiceCancelled.
+//
+// When one or more services fail a ServiceErrors value is returned containing the error for each failed service.
+func (s *ServiceScheduler) Run(ctx context.Context, services []*ServiceConfig, fn ServiceOperationFn) error {
+	dependencies, err := serviceDependencies(services)
+	if err != nil {
+		return err
+	}
+
+	type serviceResult struct {
+		name string
+		err  error
+	}
+
+	pending := slices.Clone(services)
+	completed := map[string]error{}
+	results := make(chan serviceResult)
+	running := 0
+	exclusiveRunning := false
+	var errs ServiceErrors
+	var wg sync.WaitGroup
+
+	for len(pending) > 0 || running > 0 {
+		// Start as many ready services as allowed by the max parallelism
+		for i := 0; i < len(pending) && running < s.maxParallelism && !exclusiveRunning; {
+			svc := pending[i]
+
+			ready, failedDependency := dependenciesState(dependencies[svc.Name], completed)
+			if failedDependency != "" {
+				pending = slices.Delete(pending, i, i+1)
+				depErr := fmt.Errorf(
+					"skipped because dependency '%s' failed: %w", failedDependency, ErrServiceDependencyFailed)
+				completed[svc.Name] = depErr
+				errs = append(errs, &ServiceError{ServiceName: svc.Name, Err: depErr})
+				if s.onSkipped != nil {
+					s.onSkipped(ctx, svc, depErr)
+				}
+				// Restart the scan since skipping this service may have unblocked or failed others
+				i = 0
+				continue
+			}
+
+			if !ready {
+				i++
+				continue
+			}
+
+			if ctx.Err() != nil {
+				break
+			}
+
+			exclusive := hasInteractiveHooks(svc)
+			if exclusive && running > 0 {
+				// Wait for the running services to complete before starting a service that uses the console
+				break
+			}
+
+			pending = slices.Delete(pending, i, i+1)
+			running++
+			exclusiveRunning = exclusive
+			wg.Add(1)
+
+			go func(svc *ServiceConfig) {
+				defer wg.Done()
+				results <- serviceResult{name: svc.Name, err: fn(ctx, svc)}
+			}(svc)
+		}
+
+		if running == 0 {
+			// Nothing is running and nothing more could be started, either the context was cancelled
+			// or all remaining services are blocked.
+			break
+		}
+
+		result := <-results
+		running--
+		exclusiveRunning = false
+		completed[result.name] = result.err
+		if result.err != nil {
+			errs = append(errs, &ServiceError{ServiceName: result.name, Err: result.err})
+		}
+	}
+
+	wg.Wait()
+
+	for _, svc := range pending {
+		errs = append(errs, &ServiceError{
+			ServiceName: svc.Name,
+			Err:         fmt.Errorf("not started: %w: %w", ErrServiceCancelled, context.Cause(ctx)),
+		})
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return ctx.Err()
+}
+
+// hasInteractiveHooks returns whether any of the hooks of the service binds the console
+func hasInteractiveHooks(serviceConfig *ServiceConfig) bool {
+	for _, hooks := range serviceConfig.Hooks {
+		for _, hook := range hooks {
+			if hook == nil {
+				continue
+			}
+
+			if hook.Interactive ||
+				(hook.Windows != nil && hook.Windows.Interactive) ||
+				(hook.Posix != nil && hook.Posix.Interactive) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// dependenciesState returns whether all of the dependencies have completed successfully, or the name of the first
+// dependency that failed.
+func dependenciesState(dependencies []string, completed map[string]error) (bool, string) {
+	ready := true
+	for _, dependency := range dependencies {
+		err, has := completed[dependency]
+		if !has {
+			ready = false
+			continue
+		}
+
+		if err != nil {
+			return false, dependency
+		}
+	}
+
+	return ready, ""
+}
+
+// serviceDependencies returns the dependencies of each service, limited to the services within the specified set.
+// An error is returned when a service depends on an unknown service or when the dependencies contain a cycle.
+func serviceDependencies(services []*ServiceConfig) (map[string][]string, error) {
+	inSet := map[string]bool{}
+	for _, svc := range services {
+		inSet[svc.Name] = true
+	}
+
+	dependencies := map[string][]string{}
+	for _, svc := range services {
+		for _, dependency := range svc.DependsOn {
+			if dependency == svc.Name {
+				return nil, fmt.Errorf("service '%s' cannot depend on itself", svc.Name)
+			}
+
+			if !inSet[dependency] {
+				// Dependencies outside of the current set are valid as long as they exist within the project
+				if svc.Project != nil && svc.Project.Services[dependency] == nil {
+					return nil, fmt.Errorf("service '%s' depends on unknown service '%s'", svc.Name, dependency)
+				}
+
+				continue
+			}
+
+			dependencies[svc.Name] = append(dependencies[svc.Name], dependency)
+		}
+	}
+
+	// Detect cycles with a depth first search
+	const (
+		visiting = 1
+		visited  = 2
+	)
+
+	state := map[string]int{}
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("circular service dependency detected: %s", strings.Join(append(path, name), " -> "))
+		case visited:
+			return nil
+		}
+
+		state[name] = visiting
+		for _, dependency := range dependencies[name] {
+			if err := visit(dependency, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+
+		return nil
+	}
+
+	for _, svc := range services {
+		if err := visit(svc.Name, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return dependencies, nil
+}
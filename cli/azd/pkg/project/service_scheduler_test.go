@@ -0,0 +1,254 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/ext"
+	"github.com/stretchr/testify/require"
+)
+
+func newSchedulerTestServices(dependsOn map[string][]string, names ...string) []*ServiceConfig {
+	project := &ProjectConfig{Services: map[string]*ServiceConfig{}}
+	services := []*ServiceConfig{}
+	for _, name := range names {
+		svc := &ServiceConfig{
+			Project:   project,
+			Name:      name,
+			DependsOn: dependsOn[name],
+		}
+		project.Services[name] = svc
+		services = append(services, svc)
+	}
+
+	return services
+}
+
+func Test_ServiceScheduler_Sequential(t *testing.T) {
+	services := newSchedulerTestServices(nil, "api", "web", "worker")
+	scheduler := NewServiceScheduler(1)
+
+	order := []string{}
+	err := scheduler.Run(context.Background(), services, func(ctx context.Context, svc *ServiceConfig) error {
+		order = append(order, svc.Name)
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"api", "web", "worker"}, order)
+}
+
+func Test_ServiceScheduler_DependsOn(t *testing.T) {
+	services := newSchedulerTestServices(map[string][]string{
+		"api": {"db"},
+		"web": {"api"},
+	}, "api", "db", "web")
+
+	for _, parallelism := range []int{1, 3} {
+		scheduler := NewServiceScheduler(parallelism)
+
+		var mu sync.Mutex
+		completed := map[string]bool{}
+		err := scheduler.Run(context.Background(), services, func(ctx context.Context, svc *ServiceConfig) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			for _, dependency := range svc.DependsOn {
+				require.True(t, completed[dependency], "%s started before %s completed", svc.Name, dependency)
+			}
+			completed[svc.Name] = true
+			return nil
+		})
+
+		require.NoError(t, err)
+		require.Len(t, completed, 3)
+	}
+}
+
+func Test_ServiceScheduler_MaxParallelism(t *testing.T) {
+	services := newSchedulerTestServices(nil, "a", "b", "c", "d", "e", "f")
+	scheduler := NewServiceScheduler(2)
+
+	var current atomic.Int32
+	var peak atomic.Int32
+	err := scheduler.Run(context.Background(), services, func(ctx context.Context, svc *ServiceConfig) error {
+		value := current.Add(1)
+		for {
+			prev := peak.Load()
+			if value <= prev || peak.CompareAndSwap(prev, value) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		current.Add(-1)
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, int32(2), peak.Load())
+}
+
+func Test_ServiceScheduler_InteractiveHooks(t *testing.T) {
+	services := newSchedulerTestServices(nil, "a", "b", "c", "d")
+	services[1].Hooks = HooksConfig{
+		"predeploy": {{Run: "./prompt.sh", Interactive: true}},
+	}
+	services[3].Hooks = HooksConfig{
+		"postdeploy": {{Run: "./prompt.sh", Posix: &ext.HookConfig{Run: "./prompt.sh", Interactive: true}}},
+	}
+	scheduler := NewServiceScheduler(4)
+
+	var mu sync.Mutex
+	running := map[string]bool{}
+	err := scheduler.Run(context.Background(), services, func(ctx context.Context, svc *ServiceConfig) error {
+		mu.Lock()
+		running[svc.Name] = true
+		if hasInteractiveHooks(svc) {
+			require.Len(t, running, 1, "%s ran alongside other services", svc.Name)
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		delete(running, svc.Name)
+		mu.Unlock()
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.False(t, hasInteractiveHooks(services[0]))
+	require.True(t, hasInteractiveHooks(services[1]))
+	require.True(t, hasInteractiveHooks(services[3]))
+}
+
+func Test_ServiceScheduler_Failures(t *testing.T) {
+	services := newSchedulerTestServices(map[string][]string{
+		"web": {"api"},
+	}, "api", "web", "worker", "jobs")
+	scheduler := NewServiceScheduler(2)
+
+	var skipped []string
+	scheduler.OnSkipped(func(ctx context.Context, serviceConfig *ServiceConfig, err error) {
+		require.ErrorIs(t, err, ErrServiceDependencyFailed)
+		skipped = append(skipped, serviceConfig.Name)
+	})
+
+	var mu sync.Mutex
+	ran := map[string]bool{}
+	err := scheduler.Run(context.Background(), services, func(ctx context.Context, svc *ServiceConfig) error {
+		mu.Lock()
+		ran[svc.Name] = true
+		mu.Unlock()
+
+		if svc.Name == "api" || svc.Name == "worker" {
+			return errors.New("boom")
+		}
+
+		return nil
+	})
+
+	require.Error(t, err)
+	require.True(t, ran["jobs"])
+	require.False(t, ran["web"])
+	require.Equal(t, []string{"web"}, skipped)
+
+	var serviceErrs ServiceErrors
+	require.True(t, errors.As(err, &serviceErrs))
+	require.Len(t, serviceErrs, 3)
+	require.True(t, errors.Is(err, ErrServiceDependencyFailed))
+
+	failed := map[string]bool{}
+	for _, serviceErr := range serviceErrs {
+		failed[serviceErr.ServiceName] = true
+	}
+	require.Equal(t, map[string]bool{"api": true, "web": true, "worker": true}, failed)
+}
+
+func Test_ServiceScheduler_SingleFailure(t *testing.T) {
+	services := newSchedulerTestServices(nil, "api")
+	scheduler := NewServiceScheduler(1)
+	expected := errors.New("failed deploying service 'api'")
+
+	err := scheduler.Run(context.Background(), services, func(ctx context.Context, svc *ServiceConfig) error {
+		return expected
+	})
+
+	require.ErrorIs(t, err, expected)
+	require.Equal(t, expected.Error(), err.Error())
+}
+
+func Test_ServiceScheduler_InvalidDependencies(t *testing.T) {
+	t.Run("Cycle", func(t *testing.T) {
+		services := newSchedulerTestServices(map[string][]string{
+			"api": {"web"},
+			"web": {"api"},
+		}, "api", "web")
+
+		err := NewServiceScheduler(1).Run(context.Background(), services, func(context.Context, *ServiceConfig) error {
+			return nil
+		})
+		require.ErrorContains(t, err, "circular service dependency")
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		services := newSchedulerTestServices(map[string][]string{
+			"api": {"missing"},
+		}, "api")
+
+		err := NewServiceScheduler(1).Run(context.Background(), services, func(context.Context, *ServiceConfig) error {
+			return nil
+		})
+		require.ErrorContains(t, err, "unknown service 'missing'")
+	})
+
+	t.Run("OutsideOfSet", func(t *testing.T) {
+		services := newSchedulerTestServices(map[string][]string{
+			"api": {"db"},
+		}, "api", "db")
+
+		// Only run the 'api' service, the dependency on 'db' is ignored
+		err := NewServiceScheduler(1).Run(context.Background(), services[:1], func(context.Context, *ServiceConfig) error {
+			return nil
+		})
+		require.NoError(t, err)
+	})
+}
+
+func Test_ServiceScheduler_Cancelled(t *testing.T) {
+	services := newSchedulerTestServices(nil, "a", "b", "c")
+	scheduler := NewServiceScheduler(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := scheduler.Run(ctx, services, func(ctx context.Context, svc *ServiceConfig) error {
+		cancel()
+		return nil
+	})
+
+	var serviceErrs ServiceErrors
+	require.ErrorAs(t, err, &serviceErrs)
+	require.Len(t, serviceErrs, 2)
+	require.Equal(t, "b", serviceErrs[0].ServiceName)
+	require.Equal(t, "c", serviceErrs[1].ServiceName)
+	require.ErrorIs(t, err, ErrServiceCancelled)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func Test_ServiceMaxParallelism(t *testing.T) {
+	require.Equal(t, DefaultServiceMaxParallelism, ServiceMaxParallelism(nil))
+	require.Equal(t, DefaultServiceMaxParallelism, ServiceMaxParallelism(&ProjectConfig{}))
+	require.Equal(t, 8, ServiceMaxParallelism(&ProjectConfig{ServiceParallelism: 8}))
+
+	t.Setenv(ServiceMaxParallelismEnvVarName, "2")
+	require.Equal(t, 2, ServiceMaxParallelism(&ProjectConfig{ServiceParallelism: 8}))
+}
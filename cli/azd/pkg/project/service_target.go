@@ -40,24 +40,39 @@ func (stk ServiceTargetKind) RequiresContainer() bool {
 	return false
 }
 
-func parseServiceHost(kind ServiceTargetKind) (ServiceTargetKind, error) {
-	switch kind {
-
-	// NOTE: We do not support DotNetContainerAppTarget as a listed service host type in azure.yaml, hence
-	// it not include in this switch statement. We should think about if we should support this in azure.yaml because
-	// presently it's the only service target that is tied to a language.
-	case AppServiceTarget,
+// IsBuiltIn returns true if the service target kind is implemented by azd.
+// Service targets for other kinds may be provided by extensions.
+func (stk ServiceTargetKind) IsBuiltIn() bool {
+	switch stk {
+	case NonSpecifiedTarget,
+		AppServiceTarget,
 		ContainerAppTarget,
 		AzureFunctionTarget,
 		StaticWebAppTarget,
 		SpringAppTarget,
 		AksTarget,
+		DotNetContainerAppTarget,
 		AiEndpointTarget:
+		return true
+	}
+
+	return false
+}
+
+func parseServiceHost(kind ServiceTargetKind) (ServiceTargetKind, error) {
+	// NOTE: We do not support DotNetContainerAppTarget as a listed service host type in azure.yaml. We should think
+	// about if we should support this in azure.yaml because presently it's the only service target that is tied to a
+	// language.
+	if kind == NonSpecifiedTarget || kind == DotNetContainerAppTarget {
+		return ServiceTargetKind(""), fmt.Errorf("unsupported host '%s'", kind)
+	}
 
+	if kind.IsBuiltIn() || isExternalServiceHost(kind) {
 		return kind, nil
 	}
 
-	return ServiceTargetKind(""), fmt.Errorf("unsupported host '%s'", kind)
+	return ServiceTargetKind(""), fmt.Errorf(
+		"unsupported host '%s', the host must be built into azd or provided by an installed extension", kind)
 }
 
 type ServiceTarget interface {
@@ -94,6 +109,18 @@ type ServiceTarget interface {
 	) ([]string, error)
 }
 
+// RollbackServiceTarget is implemented by service targets that support rolling back a service
+// to its previous deployment.
+type RollbackServiceTarget interface {
+	// Rollback restores the previous deployment of the service recorded in the deployment history
+	Rollback(
+		ctx context.Context,
+		serviceConfig *ServiceConfig,
+		targetResource *environment.TargetResource,
+		progress *async.Progress[ServiceProgress],
+	) (*ServiceDeployResult, error)
+}
+
 // NewServiceDeployResult is a helper function to create a new ServiceDeployResult
 func NewServiceDeployResult(
 	relatedResourceId string,
@@ -142,6 +169,12 @@ func (st ServiceTargetKind) SupportsDelayedProvisioning() bool {
 	return st == AksTarget
 }
 
+// SupportsDeploymentSlots returns true if the service target kind supports deploying to a deployment slot
+// and swapping the slot into production.
+func (st ServiceTargetKind) SupportsDeploymentSlots() bool {
+	return st == AppServiceTarget || st == AzureFunctionTarget
+}
+
 func checkResourceType(resource *environment.TargetResource, expectedResourceType azapi.AzureResourceType) error {
 	if !strings.EqualFold(resource.ResourceType(), string(expectedResourceType)) {
 		return resourceTypeMismatchError(
@@ -13,9 +13,18 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
 	"github.com/azure/azure-dev/cli/azd/pkg/azure"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
 )
 
+// DeploymentSlotOptions are the optional deployment slot settings for App Service and Function App targets
+type DeploymentSlotOptions struct {
+	// The name of the deployment slot to deploy to. When empty, the production site is deployed.
+	Name osutil.ExpandableString `yaml:"name,omitempty"`
+	// When true, the deployment slot is swapped with the production site after a successful deployment.
+	Swap bool `yaml:"swap,omitempty"`
+}
+
 type appServiceTarget struct {
 	env *environment.Environment
 	cli *azapi.AzureClient
@@ -85,19 +94,42 @@ func (st *appServiceTarget) Deploy(
 	defer os.Remove(packageOutput.PackagePath)
 	defer zipFile.Close()
 
-	progress.SetProgress(NewServiceProgress("Uploading deployment package"))
-	res, err := st.cli.DeployAppServiceZip(
-		ctx,
-		targetResource.SubscriptionId(),
-		targetResource.ResourceGroupName(),
-		targetResource.ResourceName(),
-		zipFile,
-		func(logProgress string) { progress.SetProgress(NewServiceProgress(logProgress)) },
-	)
+	slotName, err := deploymentSlotName(serviceConfig, st.env)
+	if err != nil {
+		return nil, err
+	}
+
+	var res *string
+	if slotName == "" {
+		progress.SetProgress(NewServiceProgress("Uploading deployment package"))
+		res, err = st.cli.DeployAppServiceZip(
+			ctx,
+			targetResource.SubscriptionId(),
+			targetResource.ResourceGroupName(),
+			targetResource.ResourceName(),
+			zipFile,
+			func(logProgress string) { progress.SetProgress(NewServiceProgress(logProgress)) },
+		)
+	} else {
+		progress.SetProgress(NewServiceProgress(fmt.Sprintf("Uploading deployment package to slot %s", slotName)))
+		res, err = st.cli.DeployAppServiceSlotZip(
+			ctx,
+			targetResource.SubscriptionId(),
+			targetResource.ResourceGroupName(),
+			targetResource.ResourceName(),
+			slotName,
+			zipFile,
+			func(logProgress string) { progress.SetProgress(NewServiceProgress(logProgress)) },
+		)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("deploying service %s: %w", serviceConfig.Name, err)
 	}
 
+	if err := swapDeploymentSlot(ctx, st.cli, serviceConfig, slotName, targetResource, progress); err != nil {
+		return nil, err
+	}
+
 	progress.SetProgress(NewServiceProgress("Fetching endpoints for app service"))
 	endpoints, err := st.Endpoints(ctx, serviceConfig, targetResource)
 	if err != nil {
@@ -105,11 +137,7 @@ func (st *appServiceTarget) Deploy(
 	}
 
 	sdr := NewServiceDeployResult(
-		azure.WebsiteRID(
-			targetResource.SubscriptionId(),
-			targetResource.ResourceGroupName(),
-			targetResource.ResourceName(),
-		),
+		deploymentSlotResourceId(serviceConfig, slotName, targetResource),
 		AppServiceTarget,
 		*res,
 		endpoints,
@@ -125,12 +153,28 @@ func (st *appServiceTarget) Endpoints(
 	serviceConfig *ServiceConfig,
 	targetResource *environment.TargetResource,
 ) ([]string, error) {
-	appServiceProperties, err := st.cli.GetAppServiceProperties(
-		ctx,
-		targetResource.SubscriptionId(),
-		targetResource.ResourceGroupName(),
-		targetResource.ResourceName(),
-	)
+	slotName, err := deploymentSlotName(serviceConfig, st.env)
+	if err != nil {
+		return nil, err
+	}
+
+	var appServiceProperties *azapi.AzCliAppServiceProperties
+	if slotName != "" && !serviceConfig.DeploymentSlot.Swap {
+		appServiceProperties, err = st.cli.GetAppServiceSlotProperties(
+			ctx,
+			targetResource.SubscriptionId(),
+			targetResource.ResourceGroupName(),
+			targetResource.ResourceName(),
+			slotName,
+		)
+	} else {
+		appServiceProperties, err = st.cli.GetAppServiceProperties(
+			ctx,
+			targetResource.SubscriptionId(),
+			targetResource.ResourceGroupName(),
+			targetResource.ResourceName(),
+		)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("fetching service properties: %w", err)
 	}
@@ -156,3 +200,68 @@ func (st *appServiceTarget) validateTargetResource(
 
 	return nil
 }
+
+// deploymentSlotName resolves the name of the deployment slot configured for the service.
+// An empty string is returned when the service deploys to the production site.
+func deploymentSlotName(serviceConfig *ServiceConfig, env *environment.Environment) (string, error) {
+	slotName, err := serviceConfig.DeploymentSlot.Name.Envsubst(env.Getenv)
+	if err != nil {
+		return "", fmt.Errorf("expanding deployment slot name: %w", err)
+	}
+
+	if slotName == "" && serviceConfig.DeploymentSlot.Swap {
+		return "", fmt.Errorf(
+			"service '%s' is configured to swap deployment slots but no deployment slot name is set", serviceConfig.Name)
+	}
+
+	return slotName, nil
+}
+
+// swapDeploymentSlot swaps the deployment slot with the production site when the service is configured to do so
+func swapDeploymentSlot(
+	ctx context.Context,
+	cli *azapi.AzureClient,
+	serviceConfig *ServiceConfig,
+	slotName string,
+	targetResource *environment.TargetResource,
+	progress *async.Progress[ServiceProgress],
+) error {
+	if slotName == "" || !serviceConfig.DeploymentSlot.Swap {
+		return nil
+	}
+
+	progress.SetProgress(NewServiceProgress(fmt.Sprintf("Swapping slot %s with production", slotName)))
+	if err := cli.SwapAppServiceSlot(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		slotName,
+	); err != nil {
+		return fmt.Errorf("promoting service %s: %w", serviceConfig.Name, err)
+	}
+
+	return nil
+}
+
+// deploymentSlotResourceId returns the resource id of the site that is serving the deployed package
+func deploymentSlotResourceId(
+	serviceConfig *ServiceConfig,
+	slotName string,
+	targetResource *environment.TargetResource,
+) string {
+	if slotName != "" && !serviceConfig.DeploymentSlot.Swap {
+		return azure.WebsiteSlotRID(
+			targetResource.SubscriptionId(),
+			targetResource.ResourceGroupName(),
+			targetResource.ResourceName(),
+			slotName,
+		)
+	}
+
+	return azure.WebsiteRID(
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+	)
+}
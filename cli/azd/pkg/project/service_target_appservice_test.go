@@ -9,6 +9,7 @@ import (
 
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 	"github.com/stretchr/testify/require"
 )
 
@@ -53,3 +54,48 @@ func TestNewAppServiceTargetTypeValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestDeploymentSlotName(t *testing.T) {
+	env := environment.NewWithValues("test", map[string]string{
+		"SLOT_NAME": "staging",
+	})
+
+	t.Run("Production", func(t *testing.T) {
+		slotName, err := deploymentSlotName(&ServiceConfig{Name: "api"}, env)
+		require.NoError(t, err)
+		require.Empty(t, slotName)
+	})
+
+	t.Run("Expanded", func(t *testing.T) {
+		serviceConfig := &ServiceConfig{
+			Name: "api",
+			DeploymentSlot: DeploymentSlotOptions{
+				Name: osutil.NewExpandableString("${SLOT_NAME}"),
+			},
+		}
+
+		slotName, err := deploymentSlotName(serviceConfig, env)
+		require.NoError(t, err)
+		require.Equal(t, "staging", slotName)
+		require.Equal(t,
+			"/subscriptions/SUB_ID/resourceGroups/RG_ID/providers/Microsoft.Web/sites/res/slots/staging",
+			deploymentSlotResourceId(
+				serviceConfig,
+				slotName,
+				environment.NewTargetResource("SUB_ID", "RG_ID", "res", string(azapi.AzureResourceTypeWebSite)),
+			),
+		)
+	})
+
+	t.Run("SwapWithoutSlot", func(t *testing.T) {
+		serviceConfig := &ServiceConfig{
+			Name: "api",
+			DeploymentSlot: DeploymentSlotOptions{
+				Swap: true,
+			},
+		}
+
+		_, err := deploymentSlotName(serviceConfig, env)
+		require.Error(t, err)
+	})
+}
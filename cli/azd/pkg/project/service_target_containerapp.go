@@ -5,23 +5,99 @@ package project
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
+	"time"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/async"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
 	"github.com/azure/azure-dev/cli/azd/pkg/azure"
 	"github.com/azure/azure-dev/cli/azd/pkg/containerapps"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
 )
 
+// CanaryOptions are the optional settings for gradually shifting traffic to a new container app revision
+type CanaryOptions struct {
+	// The percentages of traffic routed to the new revision at each step, ex) [10, 50, 100].
+	// A final step of 100 is added when the last step is lower.
+	Steps []int `yaml:"steps,omitempty"`
+	// The time to wait after each step before moving to the next step, ex) 5m
+	Interval string `yaml:"interval,omitempty"`
+	// The URL checked after each step, including the final step, and again after the interval, sent to the new
+	// revision through its revision FQDN. Traffic is reverted to the prior revision when it does not respond with 2xx.
+	HealthProbeUrl osutil.ExpandableString `yaml:"healthProbeUrl,omitempty"`
+}
+
+// Enabled returns true when canary steps have been configured
+func (co *CanaryOptions) Enabled() bool {
+	return len(co.Steps) > 0
+}
+
+// steps validates and returns the canary steps, always ending with a step of 100
+func (co *CanaryOptions) steps() ([]int, error) {
+	steps := slices.Clone(co.Steps)
+	for i, step := range steps {
+		if step < 1 || step > 100 {
+			return nil, fmt.Errorf("canary step '%d' must be between 1 and 100", step)
+		}
+
+		if i > 0 && step <= steps[i-1] {
+			return nil, fmt.Errorf("canary steps must be increasing, '%d' follows '%d'", step, steps[i-1])
+		}
+	}
+
+	if len(steps) > 0 && steps[len(steps)-1] != 100 {
+		steps = append(steps, 100)
+	}
+
+	return steps, nil
+}
+
+// interval parses the time to wait between canary steps
+func (co *CanaryOptions) interval() (time.Duration, error) {
+	if co.Interval == "" {
+		return 0, nil
+	}
+
+	interval, err := time.ParseDuration(co.Interval)
+	if err != nil {
+		return 0, fmt.Errorf("parsing canary interval '%s': %w", co.Interval, err)
+	}
+
+	if interval < 0 {
+		return 0, fmt.Errorf("canary interval '%s' must not be negative", co.Interval)
+	}
+
+	return interval, nil
+}
+
+// validate ensures the canary options are valid
+func (co *CanaryOptions) validate() error {
+	if _, err := co.steps(); err != nil {
+		return err
+	}
+
+	if _, err := co.interval(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 type containerAppTarget struct {
 	env                 *environment.Environment
 	envManager          environment.Manager
 	containerHelper     *ContainerHelper
 	containerAppService containerapps.ContainerAppService
 	resourceManager     ResourceManager
+	deploymentHistory   *DeploymentHistory
 }
 
 // NewContainerAppTarget creates the container app service target.
@@ -41,6 +117,7 @@ func NewContainerAppTarget(
 		containerHelper:     containerHelper,
 		containerAppService: containerAppService,
 		resourceManager:     resourceManager,
+		deploymentHistory:   NewDeploymentHistory(env, envManager),
 	}
 }
 
@@ -51,6 +128,10 @@ func (at *containerAppTarget) RequiredExternalTools(ctx context.Context, service
 
 // Initializes the Container App target
 func (at *containerAppTarget) Initialize(ctx context.Context, serviceConfig *ServiceConfig) error {
+	if err := serviceConfig.Canary.validate(); err != nil {
+		return fmt.Errorf("validating canary options for service '%s': %w", serviceConfig.Name, err)
+	}
+
 	if err := at.addPreProvisionChecks(ctx, serviceConfig); err != nil {
 		return fmt.Errorf("initializing container app target: %w", err)
 	}
@@ -90,6 +171,28 @@ func (at *containerAppTarget) Deploy(
 		ApiVersion: serviceConfig.ApiVersion,
 	}
 
+	// When deploying a canary, the traffic of the prior revisions is kept while the new revision is created and then
+	// shifted to the new revision in steps.
+	var priorWeights []containerapps.RevisionWeight
+	if serviceConfig.Canary.Enabled() {
+		priorWeights, err = at.containerAppService.GetTrafficWeights(
+			ctx,
+			targetResource.SubscriptionId(),
+			targetResource.ResourceGroupName(),
+			targetResource.ResourceName(),
+			&containerAppOptions,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("getting traffic weights: %w", err)
+		}
+
+		if len(priorWeights) == 0 {
+			return nil, fmt.Errorf("canary deployments require ingress to be enabled for service '%s'", serviceConfig.Name)
+		}
+
+		containerAppOptions.KeepTraffic = true
+	}
+
 	imageName := at.env.GetServiceProperty(serviceConfig.Name, "IMAGE_NAME")
 	progress.SetProgress(NewServiceProgress("Updating container app revision"))
 	err = at.containerAppService.AddRevision(
@@ -104,6 +207,15 @@ func (at *containerAppTarget) Deploy(
 		return nil, fmt.Errorf("updating container app service: %w", err)
 	}
 
+	if serviceConfig.Canary.Enabled() {
+		err := at.deployCanary(ctx, serviceConfig, targetResource, priorWeights, &containerAppOptions, progress)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	at.recordDeployment(ctx, serviceConfig, targetResource, imageName, &containerAppOptions)
+
 	progress.SetProgress(NewServiceProgress("Fetching endpoints for container app service"))
 	endpoints, err := at.Endpoints(ctx, serviceConfig, targetResource)
 	if err != nil {
@@ -122,6 +234,320 @@ func (at *containerAppTarget) Deploy(
 	}, nil
 }
 
+// Rolls back the container app to the previous deployment recorded in the deployment history.
+//
+// When the container app is in multiple revision mode and the previous revision still exists, all traffic is shifted
+// back to the previous revision. Otherwise a new revision is created using the previously deployed image, which replaces
+// the previous deployment in the deployment history.
+func (at *containerAppTarget) Rollback(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	progress *async.Progress[ServiceProgress],
+) (*ServiceDeployResult, error) {
+	if err := at.validateTargetResource(targetResource); err != nil {
+		return nil, fmt.Errorf("validating target resource: %w", err)
+	}
+
+	previous, err := at.deploymentHistory.Previous(serviceConfig.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if previous == nil {
+		return nil, fmt.Errorf(
+			"no previous deployment has been recorded for service '%s' in environment '%s'",
+			serviceConfig.Name,
+			at.env.Name(),
+		)
+	}
+
+	containerAppOptions := containerapps.ContainerAppOptions{
+		ApiVersion: serviceConfig.ApiVersion,
+	}
+
+	rolledBack := false
+	if previous.Revision != "" {
+		progress.SetProgress(NewServiceProgress(fmt.Sprintf("Shifting traffic to revision %s", previous.Revision)))
+		err := at.containerAppService.ShiftTrafficToRevision(
+			ctx,
+			targetResource.SubscriptionId(),
+			targetResource.ResourceGroupName(),
+			targetResource.ResourceName(),
+			previous.Revision,
+			&containerAppOptions,
+		)
+
+		switch {
+		case err == nil:
+			rolledBack = true
+		case errors.Is(err, containerapps.ErrSingleRevisionMode), errors.Is(err, containerapps.ErrRevisionNotFound):
+			log.Printf("unable to shift traffic to revision '%s', redeploying previous image: %v", previous.Revision, err)
+		default:
+			return nil, fmt.Errorf("shifting traffic to revision '%s': %w", previous.Revision, err)
+		}
+	}
+
+	if !rolledBack {
+		if previous.Image == "" {
+			return nil, fmt.Errorf("the previous deployment of service '%s' has no recorded image", serviceConfig.Name)
+		}
+
+		progress.SetProgress(NewServiceProgress("Creating container app revision from previous image"))
+		err := at.containerAppService.AddRevision(
+			ctx,
+			targetResource.SubscriptionId(),
+			targetResource.ResourceGroupName(),
+			targetResource.ResourceName(),
+			previous.Image,
+			&containerAppOptions,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("updating container app service: %w", err)
+		}
+	}
+
+	if previous.Image != "" {
+		at.env.SetServiceProperty(serviceConfig.Name, "IMAGE_NAME", previous.Image)
+	}
+
+	// Drop the deployment that was rolled back so that a subsequent rollback continues back through the history
+	if err := at.deploymentHistory.RemoveLatest(ctx, serviceConfig.Name); err != nil {
+		return nil, err
+	}
+
+	if !rolledBack {
+		// The previous deployment is replaced by the revision created from its image
+		if err := at.deploymentHistory.RemoveLatest(ctx, serviceConfig.Name); err != nil {
+			return nil, err
+		}
+
+		at.recordDeployment(ctx, serviceConfig, targetResource, previous.Image, &containerAppOptions)
+	}
+
+	progress.SetProgress(NewServiceProgress("Fetching endpoints for container app service"))
+	endpoints, err := at.Endpoints(ctx, serviceConfig, targetResource)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ServiceDeployResult{
+		TargetResourceId: azure.ContainerAppRID(
+			targetResource.SubscriptionId(),
+			targetResource.ResourceGroupName(),
+			targetResource.ResourceName(),
+		),
+		Kind:      ContainerAppTarget,
+		Endpoints: endpoints,
+	}, nil
+}
+
+// deployCanary gradually shifts the traffic from the prior revision to the latest revision using the configured
+// canary steps. When the health probe fails, the traffic is reverted to the prior weights.
+func (at *containerAppTarget) deployCanary(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	priorWeights []containerapps.RevisionWeight,
+	options *containerapps.ContainerAppOptions,
+	progress *async.Progress[ServiceProgress],
+) error {
+	steps, err := serviceConfig.Canary.steps()
+	if err != nil {
+		return err
+	}
+
+	interval, err := serviceConfig.Canary.interval()
+	if err != nil {
+		return err
+	}
+
+	probeUrl, err := serviceConfig.Canary.HealthProbeUrl.Envsubst(at.env.Getenv)
+	if err != nil {
+		return fmt.Errorf("expanding canary health probe url: %w", err)
+	}
+
+	revision, err := at.containerAppService.GetLatestRevision(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		options,
+	)
+	if err != nil {
+		return fmt.Errorf("getting latest revision: %w", err)
+	}
+
+	if probeUrl != "" {
+		probeUrl, err = revisionProbeUrl(probeUrl, revision)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The prior revision is the revision receiving the most traffic before the deployment
+	priorRevision := slices.MaxFunc(priorWeights, func(a, b containerapps.RevisionWeight) int {
+		return int(a.Weight - b.Weight)
+	}).RevisionName
+
+	// The new revision is probed as soon as it receives more traffic and again before more traffic is shifted to it,
+	// reverting all traffic to the prior revision on failure
+	probe := func(step int) error {
+		if probeUrl == "" {
+			return nil
+		}
+
+		progress.SetProgress(NewServiceProgress(fmt.Sprintf("Checking health of revision %s", revision.Name)))
+		probeErr := checkHealthProbe(ctx, probeUrl)
+		if probeErr == nil {
+			return nil
+		}
+
+		progress.SetProgress(NewServiceProgress(fmt.Sprintf("Reverting traffic to revision %s", priorRevision)))
+		if err := at.setTrafficWeights(ctx, targetResource, priorWeights, options); err != nil {
+			return fmt.Errorf("reverting traffic after failed health probe (%w): %w", probeErr, err)
+		}
+
+		return fmt.Errorf(
+			"canary deployment of revision '%s' failed at %d%% of traffic, reverted to revision '%s': %w",
+			revision.Name,
+			step,
+			priorRevision,
+			probeErr,
+		)
+	}
+
+	for i, step := range steps {
+		progress.SetProgress(NewServiceProgress(
+			fmt.Sprintf("Shifting %d%% of traffic to revision %s", step, revision.Name),
+		))
+
+		weights := []containerapps.RevisionWeight{{RevisionName: revision.Name, Weight: int32(step)}}
+		if step < 100 && priorRevision != revision.Name {
+			weights = append(weights, containerapps.RevisionWeight{RevisionName: priorRevision, Weight: int32(100 - step)})
+		}
+
+		if err := at.setTrafficWeights(ctx, targetResource, weights, options); err != nil {
+			return err
+		}
+
+		if err := probe(step); err != nil {
+			return err
+		}
+
+		// The deployment is complete once all traffic has been shifted to the healthy revision
+		if i == len(steps)-1 || interval == 0 {
+			continue
+		}
+
+		progress.SetProgress(NewServiceProgress(fmt.Sprintf("Waiting %s before the next canary step", interval)))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+
+		if err := probe(step); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (at *containerAppTarget) setTrafficWeights(
+	ctx context.Context,
+	targetResource *environment.TargetResource,
+	weights []containerapps.RevisionWeight,
+	options *containerapps.ContainerAppOptions,
+) error {
+	err := at.containerAppService.SetTrafficWeights(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		weights,
+		options,
+	)
+	if err != nil {
+		return fmt.Errorf("setting traffic weights: %w", err)
+	}
+
+	return nil
+}
+
+// revisionProbeUrl returns the health probe url of the revision. The host of the health probe url, usually the
+// application FQDN splitting the traffic between revisions, is replaced with the FQDN of the revision.
+func revisionProbeUrl(probeUrl string, revision *containerapps.ContainerAppRevision) (string, error) {
+	if revision.Fqdn == "" {
+		return "", fmt.Errorf("revision '%s' has no FQDN, the health probe requires ingress to be enabled", revision.Name)
+	}
+
+	parsedUrl, err := url.Parse(probeUrl)
+	if err != nil {
+		return "", fmt.Errorf("parsing canary health probe url: %w", err)
+	}
+
+	parsedUrl.Host = revision.Fqdn
+
+	return parsedUrl.String(), nil
+}
+
+// healthProbeClient sends the health probe requests, a probe not responding within the timeout fails
+var healthProbeClient = &http.Client{Timeout: 30 * time.Second}
+
+// checkHealthProbe sends a GET request to the health probe url and expects a successful status code
+func checkHealthProbe(ctx context.Context, probeUrl string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, probeUrl, nil)
+	if err != nil {
+		return fmt.Errorf("creating health probe request: %w", err)
+	}
+
+	res, err := healthProbeClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("health probe request to '%s' failed: %w", probeUrl, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("health probe '%s' returned status code %d", probeUrl, res.StatusCode)
+	}
+
+	return nil
+}
+
+// recordDeployment records the deployed image and revision in the deployment history of the service.
+// Failures are logged and do not fail the deployment.
+func (at *containerAppTarget) recordDeployment(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	imageName string,
+	options *containerapps.ContainerAppOptions,
+) {
+	deployment := &ServiceDeployment{
+		Image:     imageName,
+		Timestamp: time.Now().UTC(),
+	}
+
+	revision, err := at.containerAppService.GetLatestRevision(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		options,
+	)
+	if err != nil {
+		log.Printf("failed getting latest revision for service '%s': %v", serviceConfig.Name, err)
+	} else {
+		deployment.Revision = revision.Name
+	}
+
+	if err := at.deploymentHistory.Record(ctx, serviceConfig.Name, deployment); err != nil {
+		log.Printf("failed recording deployment history for service '%s': %v", serviceConfig.Name, err)
+	}
+}
+
 // Gets endpoint for the container app service
 func (at *containerAppTarget) Endpoints(
 	ctx context.Context,
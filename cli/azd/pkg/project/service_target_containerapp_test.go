@@ -6,6 +6,8 @@ package project
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
@@ -19,6 +21,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/containerregistry"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/docker"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/dotnet"
 	"github.com/azure/azure-dev/cli/azd/test/mocks"
@@ -125,6 +128,264 @@ func Test_ContainerApp_Deploy(t *testing.T) {
 	require.Greater(t, len(deployResult.Endpoints), 0)
 	// New env variable is created
 	require.Equal(t, "REGISTRY.azurecr.io/test-app/api-test:azd-deploy-0", env.Dotenv()["SERVICE_API_IMAGE_NAME"])
+
+	// The deployment is recorded in the deployment history
+	deployments, err := NewDeploymentHistory(env, &mockenv.MockEnvManager{}).List(serviceConfig.Name)
+	require.NoError(t, err)
+	require.Len(t, deployments, 1)
+	require.Equal(t, "REGISTRY.azurecr.io/test-app/api-test:azd-deploy-0", deployments[0].Image)
+	require.Equal(t, "ORIGINAL_REVISION_NAME", deployments[0].Revision)
+}
+
+func Test_ContainerApp_Rollback(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	setupMocksForContainerApps(mockContext)
+
+	serviceConfig := createTestServiceConfig(t.TempDir(), ContainerAppTarget, ServiceLanguageTypeScript)
+	env := createEnv()
+	serviceTarget := createContainerAppServiceTarget(mockContext, env)
+
+	scope := environment.NewTargetResource(
+		"SUBSCRIPTION_ID",
+		"RESOURCE_GROUP",
+		"CONTAINER_APP",
+		string(azapi.AzureResourceTypeContainerApp),
+	)
+
+	rollback := func() (*ServiceDeployResult, error) {
+		return logProgress(
+			t, func(progress *async.Progress[ServiceProgress]) (*ServiceDeployResult, error) {
+				return serviceTarget.(RollbackServiceTarget).Rollback(*mockContext.Context, serviceConfig, scope, progress)
+			},
+		)
+	}
+
+	// Nothing to roll back to without a previous deployment
+	_, err := rollback()
+	require.ErrorContains(t, err, "no previous deployment")
+
+	history := NewDeploymentHistory(env, &mockenv.MockEnvManager{})
+	require.NoError(t, env.Config.Set(deploymentHistoryPath("api"), []any{
+		map[string]any{"image": "REGISTRY.azurecr.io/api:azd-deploy-1", "revision": "CONTAINER_APP--azd-1"},
+		map[string]any{"image": "REGISTRY.azurecr.io/api:azd-deploy-2", "revision": "CONTAINER_APP--azd-2"},
+	}))
+
+	// The container app is in single revision mode, so the previous image is redeployed as a new revision
+	deployResult, err := rollback()
+	require.NoError(t, err)
+	require.NotNil(t, deployResult)
+	require.Greater(t, len(deployResult.Endpoints), 0)
+	require.Equal(t, "REGISTRY.azurecr.io/api:azd-deploy-1", env.GetServiceProperty("api", "IMAGE_NAME"))
+
+	// The new revision replaces the previous deployment in the history
+	deployments, err := history.List("api")
+	require.NoError(t, err)
+	require.Len(t, deployments, 1)
+	require.Equal(t, "REGISTRY.azurecr.io/api:azd-deploy-1", deployments[0].Image)
+	require.Equal(t, "ORIGINAL_REVISION_NAME", deployments[0].Revision)
+}
+
+func Test_ContainerApp_DeployCanary(t *testing.T) {
+	tests := map[string]struct {
+		probeStatus int
+		// The number of probes answered with 200 before responding with probeStatus
+		healthyProbes   int
+		expectedTraffic []map[string]int32
+		expectError     bool
+	}{
+		"Success": {
+			probeStatus: http.StatusOK,
+			expectedTraffic: []map[string]int32{
+				{"ORIGINAL_REVISION_NAME": 100},
+				{"UPDATED_REVISION_NAME": 10, "ORIGINAL_REVISION_NAME": 90},
+				{"UPDATED_REVISION_NAME": 50, "ORIGINAL_REVISION_NAME": 50},
+				{"UPDATED_REVISION_NAME": 100},
+			},
+		},
+		"HealthProbeFailed": {
+			probeStatus: http.StatusServiceUnavailable,
+			expectedTraffic: []map[string]int32{
+				{"ORIGINAL_REVISION_NAME": 100},
+				{"UPDATED_REVISION_NAME": 10, "ORIGINAL_REVISION_NAME": 90},
+				{"ORIGINAL_REVISION_NAME": 100},
+			},
+			expectError: true,
+		},
+		"FinalStepHealthProbeFailed": {
+			probeStatus:   http.StatusServiceUnavailable,
+			healthyProbes: 2,
+			expectedTraffic: []map[string]int32{
+				{"ORIGINAL_REVISION_NAME": 100},
+				{"UPDATED_REVISION_NAME": 10, "ORIGINAL_REVISION_NAME": 90},
+				{"UPDATED_REVISION_NAME": 50, "ORIGINAL_REVISION_NAME": 50},
+				{"UPDATED_REVISION_NAME": 100},
+				{"ORIGINAL_REVISION_NAME": 100},
+			},
+			expectError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			ostest.Chdir(t, tempDir)
+
+			// The probe server is the new revision, the application FQDN splitting the traffic isn't probed
+			probes := 0
+			probeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/health" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				probes++
+				if probes <= test.healthyProbes {
+					w.WriteHeader(http.StatusOK)
+					return
+				}
+
+				w.WriteHeader(test.probeStatus)
+			}))
+			defer probeServer.Close()
+
+			mockContext := mocks.NewMockContext(context.Background())
+			setupMocksForContainerAppTarget(mockContext)
+			traffic := setupMocksForContainerAppCanary(mockContext, strings.TrimPrefix(probeServer.URL, "http://"))
+
+			serviceConfig := createTestServiceConfig(tempDir, ContainerAppTarget, ServiceLanguageTypeScript)
+			serviceConfig.Canary = CanaryOptions{
+				Steps:          []int{10, 50},
+				HealthProbeUrl: osutil.NewExpandableString("http://CONTAINER_APP.eastus2.azurecontainerapps.io/health"),
+			}
+
+			env := createEnv()
+			serviceTarget := createContainerAppServiceTarget(mockContext, env)
+			scope := environment.NewTargetResource(
+				"SUBSCRIPTION_ID",
+				"RESOURCE_GROUP",
+				"CONTAINER_APP",
+				string(azapi.AzureResourceTypeContainerApp),
+			)
+
+			packageResult := &ServicePackageResult{
+				PackagePath: "test-app/api-test:azd-deploy-0",
+				Details: &dockerPackageResult{
+					ImageHash:   "IMAGE_HASH",
+					TargetImage: "test-app/api-test:azd-deploy-0",
+				},
+			}
+
+			_, err := logProgress(
+				t, func(progress *async.Progress[ServiceProgress]) (*ServiceDeployResult, error) {
+					return serviceTarget.Deploy(*mockContext.Context, serviceConfig, packageResult, scope, progress)
+				},
+			)
+
+			if test.expectError {
+				require.ErrorContains(t, err, "reverted to revision 'ORIGINAL_REVISION_NAME'")
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, test.expectedTraffic, *traffic)
+
+			// Only successful canary deployments are recorded in the deployment history
+			deployments, err := NewDeploymentHistory(env, &mockenv.MockEnvManager{}).List(serviceConfig.Name)
+			require.NoError(t, err)
+			if test.expectError {
+				require.Empty(t, deployments)
+			} else {
+				require.Len(t, deployments, 1)
+				require.Equal(t, "UPDATED_REVISION_NAME", deployments[0].Revision)
+			}
+		})
+	}
+}
+
+func Test_CanaryOptions_Steps(t *testing.T) {
+	tests := map[string]struct {
+		steps       []int
+		expected    []int
+		expectError bool
+	}{
+		"AppendsFinalStep":  {steps: []int{10, 50}, expected: []int{10, 50, 100}},
+		"EndsAtFullTraffic": {steps: []int{25, 100}, expected: []int{25, 100}},
+		"OutOfRange":        {steps: []int{0, 50}, expectError: true},
+		"NotIncreasing":     {steps: []int{50, 10}, expectError: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			canary := CanaryOptions{Steps: test.steps}
+			steps, err := canary.steps()
+			if test.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, steps)
+			}
+		})
+	}
+}
+
+// setupMocksForContainerAppCanary configures the container app in multiple revision mode with all traffic on the
+// original revision. The updated revision is reachable at the specified FQDN. The returned slice records the traffic
+// weights of each container app update.
+func setupMocksForContainerAppCanary(mockContext *mocks.MockContext, updatedRevisionFqdn string) *[]map[string]int32 {
+	subscriptionId := "SUBSCRIPTION_ID"
+	resourceGroup := "RESOURCE_GROUP"
+	appName := "CONTAINER_APP"
+	hostName := "CONTAINER_APP.eastus2.azurecontainerapps.io"
+
+	containerApp := &armappcontainers.ContainerApp{
+		Location: to.Ptr("eastus2"),
+		Name:     &appName,
+		Properties: &armappcontainers.ContainerAppProperties{
+			LatestRevisionName: to.Ptr("ORIGINAL_REVISION_NAME"),
+			LatestRevisionFqdn: to.Ptr("CONTAINER_APP--ORIGINAL_REVISION_NAME.eastus2.azurecontainerapps.io"),
+			Configuration: &armappcontainers.Configuration{
+				ActiveRevisionsMode: to.Ptr(armappcontainers.ActiveRevisionsModeMultiple),
+				Ingress: &armappcontainers.Ingress{
+					Fqdn: &hostName,
+					Traffic: []*armappcontainers.TrafficWeight{
+						{LatestRevision: to.Ptr(true), Weight: to.Ptr(int32(100))},
+					},
+				},
+			},
+			Template: &armappcontainers.Template{
+				Containers: []*armappcontainers.Container{
+					{Image: to.Ptr("ORIGINAL_IMAGE_NAME")},
+				},
+			},
+		},
+	}
+
+	mockazsdk.MockContainerAppGet(mockContext, subscriptionId, resourceGroup, appName, containerApp)
+
+	traffic := []map[string]int32{}
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPatch && strings.Contains(request.URL.Path, appName)
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		var updated *armappcontainers.ContainerApp
+		if err := mocks.ReadHttpBody(request.Body, &updated); err != nil {
+			return nil, err
+		}
+
+		weights := map[string]int32{}
+		for _, weight := range updated.Properties.Configuration.Ingress.Traffic {
+			weights[*weight.RevisionName] = *weight.Weight
+		}
+		traffic = append(traffic, weights)
+
+		// The first update creates the new revision
+		containerApp.Properties.LatestRevisionName = to.Ptr("UPDATED_REVISION_NAME")
+		containerApp.Properties.LatestRevisionFqdn = &updatedRevisionFqdn
+
+		return mocks.CreateHttpResponseWithBody(
+			request, http.StatusAccepted, armappcontainers.ContainerAppsClientUpdateResponse{})
+	})
+
+	return &traffic
 }
 
 func createContainerAppServiceTarget(
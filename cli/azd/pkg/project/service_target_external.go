@@ -0,0 +1,75 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"fmt"
+	"sync"
+)
+
+var (
+	externalServiceHostsMu sync.RWMutex
+	externalServiceHosts   = map[ServiceTargetKind]bool{}
+)
+
+// RegisterExternalServiceHost registers a service host that is not built into azd, ex) a service host declared by an
+// installed extension. Projects are validated against the built-in and the registered service hosts when loaded.
+func RegisterExternalServiceHost(host ServiceTargetKind) {
+	externalServiceHostsMu.Lock()
+	defer externalServiceHostsMu.Unlock()
+
+	externalServiceHosts[host] = true
+}
+
+// isExternalServiceHost returns true if the service host has been registered by RegisterExternalServiceHost
+func isExternalServiceHost(host ServiceTargetKind) bool {
+	externalServiceHostsMu.RLock()
+	defer externalServiceHostsMu.RUnlock()
+
+	return externalServiceHosts[host]
+}
+
+// ExternalServiceTargetRegistry contains the service targets that are provided at runtime for service hosts
+// that are not built into azd, ex) service targets provided by extensions.
+type ExternalServiceTargetRegistry struct {
+	targets sync.Map // key: ServiceTargetKind, value: ServiceTarget
+}
+
+// NewExternalServiceTargetRegistry creates a new empty ExternalServiceTargetRegistry
+func NewExternalServiceTargetRegistry() *ExternalServiceTargetRegistry {
+	return &ExternalServiceTargetRegistry{}
+}
+
+// Register registers the service target for the specified service host.
+// Built-in service hosts cannot be overridden and a service host can only be registered once.
+func (r *ExternalServiceTargetRegistry) Register(host ServiceTargetKind, target ServiceTarget) error {
+	if host == NonSpecifiedTarget {
+		return fmt.Errorf("service host is required")
+	}
+
+	if host.IsBuiltIn() {
+		return fmt.Errorf("service host '%s' is built into azd and cannot be overridden", host)
+	}
+
+	if _, loaded := r.targets.LoadOrStore(host, target); loaded {
+		return fmt.Errorf("service host '%s' has already been registered", host)
+	}
+
+	return nil
+}
+
+// Unregister removes the service target registered for the specified service host
+func (r *ExternalServiceTargetRegistry) Unregister(host ServiceTargetKind) {
+	r.targets.Delete(host)
+}
+
+// Get returns the service target registered for the specified service host
+func (r *ExternalServiceTargetRegistry) Get(host ServiceTargetKind) (ServiceTarget, bool) {
+	target, has := r.targets.Load(host)
+	if !has {
+		return nil, false
+	}
+
+	return target.(ServiceTarget), true
+}
@@ -11,7 +11,6 @@ import (
 
 	"github.com/azure/azure-dev/cli/azd/pkg/async"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
-	"github.com/azure/azure-dev/cli/azd/pkg/azure"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
 )
@@ -87,22 +86,46 @@ func (f *functionAppTarget) Deploy(
 	defer os.Remove(packageOutput.PackagePath)
 	defer zipFile.Close()
 
-	progress.SetProgress(NewServiceProgress("Uploading deployment package"))
+	slotName, err := deploymentSlotName(serviceConfig, f.env)
+	if err != nil {
+		return nil, err
+	}
+
 	remoteBuild := serviceConfig.Language == ServiceLanguageJavaScript ||
 		serviceConfig.Language == ServiceLanguageTypeScript ||
 		serviceConfig.Language == ServiceLanguagePython
-	res, err := f.cli.DeployFunctionAppUsingZipFile(
-		ctx,
-		targetResource.SubscriptionId(),
-		targetResource.ResourceGroupName(),
-		targetResource.ResourceName(),
-		zipFile,
-		remoteBuild,
-	)
+
+	var res *string
+	if slotName == "" {
+		progress.SetProgress(NewServiceProgress("Uploading deployment package"))
+		res, err = f.cli.DeployFunctionAppUsingZipFile(
+			ctx,
+			targetResource.SubscriptionId(),
+			targetResource.ResourceGroupName(),
+			targetResource.ResourceName(),
+			zipFile,
+			remoteBuild,
+		)
+	} else {
+		progress.SetProgress(NewServiceProgress(fmt.Sprintf("Uploading deployment package to slot %s", slotName)))
+		res, err = f.cli.DeployFunctionAppSlotUsingZipFile(
+			ctx,
+			targetResource.SubscriptionId(),
+			targetResource.ResourceGroupName(),
+			targetResource.ResourceName(),
+			slotName,
+			zipFile,
+			remoteBuild,
+		)
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	if err := swapDeploymentSlot(ctx, f.cli, serviceConfig, slotName, targetResource, progress); err != nil {
+		return nil, err
+	}
+
 	progress.SetProgress(NewServiceProgress("Fetching endpoints for function app"))
 	endpoints, err := f.Endpoints(ctx, serviceConfig, targetResource)
 	if err != nil {
@@ -110,11 +133,7 @@ func (f *functionAppTarget) Deploy(
 	}
 
 	sdr := NewServiceDeployResult(
-		azure.WebsiteRID(
-			targetResource.SubscriptionId(),
-			targetResource.ResourceGroupName(),
-			targetResource.ResourceName(),
-		),
+		deploymentSlotResourceId(serviceConfig, slotName, targetResource),
 		AzureFunctionTarget,
 		*res,
 		endpoints,
@@ -130,6 +149,32 @@ func (f *functionAppTarget) Endpoints(
 	serviceConfig *ServiceConfig,
 	targetResource *environment.TargetResource,
 ) ([]string, error) {
+	slotName, err := deploymentSlotName(serviceConfig, f.env)
+	if err != nil {
+		return nil, err
+	}
+
+	// Packages deployed to a slot without swapping are only served by the slot
+	if slotName != "" && !serviceConfig.DeploymentSlot.Swap {
+		props, err := f.cli.GetAppServiceSlotProperties(
+			ctx,
+			targetResource.SubscriptionId(),
+			targetResource.ResourceGroupName(),
+			targetResource.ResourceName(),
+			slotName,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("fetching service properties: %w", err)
+		}
+
+		endpoints := make([]string, len(props.HostNames))
+		for idx, hostName := range props.HostNames {
+			endpoints[idx] = fmt.Sprintf("https://%s/", hostName)
+		}
+
+		return endpoints, nil
+	}
+
 	// TODO(azure/azure-dev#670) Implement this. For now we just return an empty set of endpoints and
 	// a nil error.  In `deploy` we just loop over the endpoint array and print any endpoints, so returning
 	// an empty array and nil error will mean "no endpoints".
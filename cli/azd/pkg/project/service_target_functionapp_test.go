@@ -4,11 +4,24 @@
 package project
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appservice/armappservice/v2"
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
+	"github.com/azure/azure-dev/cli/azd/pkg/azsdk"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockaccount"
 	"github.com/stretchr/testify/require"
 )
 
@@ -45,3 +58,122 @@ func TestNewFunctionAppTargetTypeValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestFunctionAppTargetDeploySlotRemoteBuild(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	env := environment.NewWithValues("test", map[string]string{})
+	credentialProvider := mockaccount.SubscriptionCredentialProviderFunc(
+		func(_ context.Context, _ string) (azcore.TokenCredential, error) {
+			return mockContext.Credentials, nil
+		})
+	serviceTarget := NewFunctionAppTarget(
+		env, azapi.NewAzureClient(credentialProvider, mockContext.ArmClientOptions))
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodGet && strings.HasSuffix(request.URL.Path, "/sites/FUNC_APP_NAME")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, armappservice.WebAppsClientGetResponse{
+			Site: armappservice.Site{
+				Properties: &armappservice.SiteProperties{
+					ServerFarmID: to.Ptr(
+						"/subscriptions/SUB_ID/resourceGroups/RG_ID/providers/Microsoft.Web/serverfarms/PLAN_NAME"),
+				},
+			},
+		})
+	})
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodGet && strings.HasSuffix(request.URL.Path, "/serverfarms/PLAN_NAME")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, armappservice.PlansClientGetResponse{
+			Plan: armappservice.Plan{
+				SKU: &armappservice.SKUDescription{Name: to.Ptr("EP1"), Tier: to.Ptr("ElasticPremium")},
+			},
+		})
+	})
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodGet && strings.HasSuffix(request.URL.Path, "/sites/FUNC_APP_NAME/slots/staging")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, armappservice.WebAppsClientGetSlotResponse{
+			Site: armappservice.Site{
+				Kind: to.Ptr("functionapp,linux"),
+				Properties: &armappservice.SiteProperties{
+					DefaultHostName: to.Ptr("FUNC_APP_NAME-staging.azurewebsites.net"),
+					HostNameSSLStates: []*armappservice.HostNameSSLState{
+						{
+							HostType: to.Ptr(armappservice.HostTypeRepository),
+							Name:     to.Ptr("FUNC_APP_NAME-staging.scm.azurewebsites.net"),
+						},
+					},
+				},
+			},
+		})
+	})
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPost &&
+			strings.HasSuffix(request.URL.Path, "/slots/staging/config/appsettings/list")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, armappservice.StringDictionary{})
+	})
+
+	var appSettings armappservice.StringDictionary
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPut && strings.HasSuffix(request.URL.Path, "/slots/staging/config/appsettings")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		if err := json.NewDecoder(request.Body).Decode(&appSettings); err != nil {
+			return nil, err
+		}
+
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, appSettings)
+	})
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPost &&
+			request.URL.Host == "FUNC_APP_NAME-staging.scm.azurewebsites.net" &&
+			request.URL.Path == "/api/zipdeploy"
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		response, _ := mocks.CreateEmptyHttpResponse(request, http.StatusAccepted)
+		response.Header.Set("Location", "https://FUNC_APP_NAME-staging.scm.azurewebsites.net/deployments/latest")
+
+		return response, nil
+	})
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodGet && request.URL.Path == "/deployments/latest"
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, azsdk.DeployStatusResponse{
+			DeployStatus: azsdk.DeployStatus{StatusText: "OK", Complete: true},
+		})
+	})
+
+	packagePath := filepath.Join(t.TempDir(), "api.zip")
+	require.NoError(t, os.WriteFile(packagePath, []byte{}, osutil.PermissionFile))
+
+	serviceConfig := &ServiceConfig{
+		Name:     "api",
+		Language: ServiceLanguagePython,
+		DeploymentSlot: DeploymentSlotOptions{
+			Name: osutil.NewExpandableString("staging"),
+		},
+	}
+
+	deployResult, err := logProgress(
+		t, func(progress *async.Progress[ServiceProgress]) (*ServiceDeployResult, error) {
+			return serviceTarget.Deploy(
+				*mockContext.Context,
+				serviceConfig,
+				&ServicePackageResult{PackagePath: packagePath},
+				environment.NewTargetResource(
+					"SUB_ID", "RG_ID", "FUNC_APP_NAME", string(azapi.AzureResourceTypeWebSite)),
+				progress,
+			)
+		},
+	)
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"https://FUNC_APP_NAME-staging.azurewebsites.net/"}, deployResult.Endpoints)
+	require.Equal(t, "true", *appSettings.Properties["SCM_DO_BUILD_DURING_DEPLOYMENT"])
+	require.Equal(t, "true", *appSettings.Properties["ENABLE_ORYX_BUILD"])
+}
@@ -0,0 +1,291 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package templates
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/azure/azure-dev/cli/azd/pkg/oci"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+)
+
+// Templates are published to OCI registries as artifacts made of a config blob, a JSON document with the metadata of
+// the template (same schema as the entries of a url template source), and a single layer, a gzipped tarball of the
+// template files. For example, with the oras CLI:
+//
+//	oras push myregistry.azurecr.io/templates/api:1.2 \
+//	  --artifact-type application/vnd.microsoft.azd.template.v1 \
+//	  --config template.json:application/vnd.microsoft.azd.template.config.v1+json \
+//	  template.tar.gz:application/vnd.microsoft.azd.template.content.v1.tar+gzip
+const (
+	OciArtifactType     = "application/vnd.microsoft.azd.template.v1"
+	OciConfigMediaType  = "application/vnd.microsoft.azd.template.config.v1+json"
+	OciContentMediaType = "application/vnd.microsoft.azd.template.content.v1.tar+gzip"
+)
+
+var errNotOciTemplate = errors.New("artifact is not an azd template")
+
+// OciTemplate is a template fetched from an OCI registry.
+type OciTemplate struct {
+	// Path is the reference to the fetched artifact, pinned to the digest of its manifest.
+	Path string
+	// ExecutableFiles are the slash separated relative paths of the executable files of the template.
+	ExecutableFiles []string
+}
+
+// newOciTemplateSource creates a new template source listing the templates published to an OCI registry.
+//
+// The location is either a single template, e.g. oci://myregistry.azurecr.io/templates/api:1.2, or a namespace of the
+// registry, e.g. oci://myregistry.azurecr.io/templates, listing the latest version of each repository in the namespace.
+func newOciTemplateSource(ctx context.Context, name string, location string, client *oci.Client) (Source, error) {
+	var ref oci.Reference
+	if registry, _, found := strings.Cut(strings.TrimPrefix(location, oci.Scheme), "/"); !found {
+		ref = oci.Reference{Registry: registry}
+	} else {
+		parsed, err := oci.ParseReference(location)
+		if err != nil {
+			return nil, err
+		}
+
+		ref = parsed
+	}
+
+	refs := []oci.Reference{ref}
+	if ref.Tag == "" && ref.Digest == "" {
+		latest, err := listLatestOciReferences(ctx, client, ref)
+		if err != nil {
+			return nil, err
+		}
+
+		refs = latest
+	}
+
+	templates := []*Template{}
+	for _, ref := range refs {
+		template, err := getOciTemplate(ctx, client, ref)
+		if errors.Is(err, errNotOciTemplate) {
+			log.Printf("skipping '%s' in template source '%s': %v", ref, name, err)
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		templates = append(templates, template)
+	}
+
+	return newTemplateSource(name, templates)
+}
+
+// listLatestOciReferences returns a reference to the latest tag of each repository under the namespace.
+func listLatestOciReferences(ctx context.Context, client *oci.Client, namespace oci.Reference) ([]oci.Reference, error) {
+	repositories, err := client.ListRepositories(ctx, namespace.Registry)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Sort(repositories)
+
+	refs := []oci.Reference{}
+	for _, repository := range repositories {
+		if namespace.Repository != "" &&
+			repository != namespace.Repository &&
+			!strings.HasPrefix(repository, namespace.Repository+"/") {
+			continue
+		}
+
+		tags, err := client.ListTags(ctx, namespace.Registry, repository)
+		if err != nil {
+			return nil, err
+		}
+
+		if tag := latestTag(tags); tag != "" {
+			refs = append(refs, oci.Reference{Registry: namespace.Registry, Repository: repository, Tag: tag})
+		}
+	}
+
+	return refs, nil
+}
+
+// latestTag returns the 'latest' tag when present, otherwise the highest semantic version.
+func latestTag(tags []string) string {
+	if slices.Contains(tags, "latest") {
+		return "latest"
+	}
+
+	var latest string
+	var latestVersion *semver.Version
+	for _, tag := range tags {
+		version, err := semver.NewVersion(tag)
+		if err != nil {
+			continue
+		}
+
+		if latestVersion == nil || version.GreaterThan(latestVersion) {
+			latest = tag
+			latestVersion = version
+		}
+	}
+
+	return latest
+}
+
+// getOciTemplate returns the template published at the reference, with a repository path pinned to its digest.
+func getOciTemplate(ctx context.Context, client *oci.Client, ref oci.Reference) (*Template, error) {
+	manifest, digest, err := getOciTemplateManifest(ctx, client, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := client.GetBlob(ctx, ref, manifest.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	var template Template
+	if err := json.Unmarshal(config, &template); err != nil {
+		return nil, fmt.Errorf("parsing template metadata of '%s': %w", ref, err)
+	}
+
+	ref.Digest = digest
+	template.RepositoryPath = oci.Scheme + ref.String()
+	if template.Name == "" {
+		template.Name = path.Base(ref.Repository)
+	}
+
+	return &template, nil
+}
+
+func getOciTemplateManifest(ctx context.Context, client *oci.Client, ref oci.Reference) (*oci.Manifest, string, error) {
+	manifest, digest, err := client.GetManifest(ctx, ref)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if manifest.ArtifactType != OciArtifactType && manifest.Config.MediaType != OciConfigMediaType {
+		return nil, "", fmt.Errorf("'%s' has artifact type '%s', %w", ref, manifest.ArtifactType, errNotOciTemplate)
+	}
+
+	return manifest, digest, nil
+}
+
+// FetchOciTemplate downloads the files of the template published at the OCI reference templatePath, e.g.
+// oci://myregistry.azurecr.io/templates/api:1.2, into the destination directory.
+func FetchOciTemplate(
+	ctx context.Context, client *oci.Client, templatePath string, destination string) (*OciTemplate, error) {
+	ref, err := oci.ParseReference(templatePath)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, digest, err := getOciTemplateManifest(ctx, client, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	index := slices.IndexFunc(manifest.Layers, func(layer oci.Descriptor) bool {
+		return layer.MediaType == OciContentMediaType
+	})
+	if index == -1 {
+		return nil, fmt.Errorf("'%s' has no layer of media type '%s', %w", ref, OciContentMediaType, errNotOciTemplate)
+	}
+
+	content, err := client.GetBlob(ctx, ref, manifest.Layers[index])
+	if err != nil {
+		return nil, err
+	}
+
+	executableFiles, err := extractOciTemplateContent(content, destination)
+	if err != nil {
+		return nil, fmt.Errorf("extracting template '%s': %w", ref, err)
+	}
+
+	ref.Digest = digest
+	return &OciTemplate{
+		Path:            oci.Scheme + ref.String(),
+		ExecutableFiles: executableFiles,
+	}, nil
+}
+
+// extractOciTemplateContent extracts the gzipped tarball of the template files into the destination directory and
+// returns the paths of the executable files.
+func extractOciTemplateContent(content []byte, destination string) ([]string, error) {
+	gzReader, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	defer gzReader.Close()
+
+	executableFiles := []string{}
+	tarReader := tar.NewReader(gzReader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return executableFiles, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		name := path.Clean(strings.TrimPrefix(header.Name, "./"))
+		if name == "." {
+			continue
+		}
+
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return nil, fmt.Errorf("invalid file path '%s' outside of the template", header.Name)
+		}
+
+		target := filepath.Join(destination, filepath.FromSlash(name))
+
+		// cspell: disable-next-line `Typeflag` is coming from *tar.Header
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, osutil.PermissionDirectory); err != nil {
+				return nil, err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), osutil.PermissionDirectory); err != nil {
+				return nil, err
+			}
+
+			mode := osutil.PermissionFile
+			if header.FileInfo().Mode()&0111 != 0 {
+				mode = osutil.PermissionExecutableFile
+				executableFiles = append(executableFiles, name)
+			}
+
+			if err := writeTarFile(target, mode, tarReader); err != nil {
+				return nil, err
+			}
+		default:
+			log.Printf("skipping unsupported entry '%s' of type '%c' in template content", header.Name, header.Typeflag)
+		}
+	}
+}
+
+func writeTarFile(target string, mode os.FileMode, reader io.Reader) error {
+	file, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	/* #nosec G110 - the content comes from an artifact verified against its digest */
+	_, err = io.Copy(file, reader)
+	return err
+}
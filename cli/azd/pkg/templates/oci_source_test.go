@@ -0,0 +1,189 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package templates
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/oci"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockoci"
+	"github.com/stretchr/testify/require"
+)
+
+type ociTestFile struct {
+	name string
+	mode int64
+	body string
+}
+
+// pushOciTemplate publishes a template artifact to the registry and returns the digest of its manifest.
+func pushOciTemplate(
+	t *testing.T, registry *mockoci.Registry, repository string, tag string, template Template, files []ociTestFile,
+) string {
+	config, err := json.Marshal(template)
+	require.NoError(t, err)
+
+	var content bytes.Buffer
+	gzWriter := gzip.NewWriter(&content)
+	tarWriter := tar.NewWriter(gzWriter)
+	for _, file := range files {
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{
+			Name:     file.name,
+			Mode:     file.mode,
+			Size:     int64(len(file.body)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tarWriter.Write([]byte(file.body))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzWriter.Close())
+
+	return registry.PushManifest(repository, tag, oci.Manifest{
+		SchemaVersion: 2,
+		MediaType:     oci.MediaTypeImageManifest,
+		ArtifactType:  OciArtifactType,
+		Config: oci.Descriptor{
+			MediaType: OciConfigMediaType,
+			Digest:    registry.PushBlob(config),
+			Size:      int64(len(config)),
+		},
+		Layers: []oci.Descriptor{{
+			MediaType: OciContentMediaType,
+			Digest:    registry.PushBlob(content.Bytes()),
+			Size:      int64(content.Len()),
+		}},
+	})
+}
+
+func Test_newOciTemplateSource(t *testing.T) {
+	registry := mockoci.NewRegistry(t)
+	registry.RequireAuth = true
+
+	pushOciTemplate(t, registry, "templates/api", "1.0", Template{Name: "API", Tags: []string{"python"}}, nil)
+	apiDigest := pushOciTemplate(t, registry, "templates/api", "1.10", Template{
+		Name:        "API",
+		Description: "An API template",
+		Tags:        []string{"python"},
+	}, nil)
+	webDigest := pushOciTemplate(t, registry, "templates/web", "latest", Template{}, nil)
+	pushOciTemplate(t, registry, "samples/api", "1.0", Template{Name: "Outside of namespace"}, nil)
+	// artifacts of other types are ignored
+	registry.PushManifest("templates/image", "1.0", oci.Manifest{SchemaVersion: 2, MediaType: oci.MediaTypeImageManifest})
+
+	client := oci.NewClient(registry.Client(), nil, nil)
+
+	t.Run("namespace", func(t *testing.T) {
+		source, err := newOciTemplateSource(context.Background(), "oci", "oci://"+registry.Host+"/templates", client)
+		require.NoError(t, err)
+
+		templates, err := source.ListTemplates(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []*Template{
+			{
+				Name:           "API",
+				Description:    "An API template",
+				Source:         "oci",
+				RepositoryPath: "oci://" + registry.Host + "/templates/api:1.10@" + apiDigest,
+				Tags:           []string{"python"},
+			},
+			{
+				Name:           "web",
+				Source:         "oci",
+				RepositoryPath: "oci://" + registry.Host + "/templates/web:latest@" + webDigest,
+			},
+		}, templates)
+	})
+
+	t.Run("template", func(t *testing.T) {
+		source, err := newOciTemplateSource(
+			context.Background(), "oci", "oci://"+registry.Host+"/templates/api:1.0", client)
+		require.NoError(t, err)
+
+		templates, err := source.ListTemplates(context.Background())
+		require.NoError(t, err)
+		require.Len(t, templates, 1)
+		require.Equal(t, "API", templates[0].Name)
+		require.Contains(t, templates[0].RepositoryPath, "/templates/api:1.0@sha256:")
+	})
+
+	t.Run("registry", func(t *testing.T) {
+		source, err := newOciTemplateSource(context.Background(), "oci", "oci://"+registry.Host, client)
+		require.NoError(t, err)
+
+		templates, err := source.ListTemplates(context.Background())
+		require.NoError(t, err)
+		require.Len(t, templates, 3)
+	})
+}
+
+func Test_FetchOciTemplate(t *testing.T) {
+	registry := mockoci.NewRegistry(t)
+	digest := pushOciTemplate(t, registry, "templates/api", "1.2", Template{Name: "API"}, []ociTestFile{
+		{name: "azure.yaml", mode: 0644, body: "name: api\n"},
+		{name: "./infra/main.bicep", mode: 0644, body: "targetScope = 'subscription'\n"},
+		{name: "scripts/setup.sh", mode: 0755, body: "#!/bin/sh\n"},
+	})
+
+	client := oci.NewClient(registry.Client(), nil, nil)
+
+	t.Run("tag", func(t *testing.T) {
+		destination := t.TempDir()
+		template, err := FetchOciTemplate(
+			context.Background(), client, "oci://"+registry.Host+"/templates/api:1.2", destination)
+		require.NoError(t, err)
+		require.Equal(t, "oci://"+registry.Host+"/templates/api:1.2@"+digest, template.Path)
+		require.Equal(t, []string{"scripts/setup.sh"}, template.ExecutableFiles)
+
+		content, err := os.ReadFile(filepath.Join(destination, "infra", "main.bicep"))
+		require.NoError(t, err)
+		require.Equal(t, "targetScope = 'subscription'\n", string(content))
+
+		if runtime.GOOS != "windows" {
+			info, err := os.Stat(filepath.Join(destination, "scripts", "setup.sh"))
+			require.NoError(t, err)
+			require.NotZero(t, info.Mode()&0111)
+		}
+	})
+
+	t.Run("digest", func(t *testing.T) {
+		template, err := FetchOciTemplate(
+			context.Background(), client, "oci://"+registry.Host+"/templates/api@"+digest, t.TempDir())
+		require.NoError(t, err)
+		require.Equal(t, "oci://"+registry.Host+"/templates/api@"+digest, template.Path)
+	})
+
+	t.Run("path outside of the template", func(t *testing.T) {
+		pushOciTemplate(t, registry, "templates/evil", "1.0", Template{}, []ociTestFile{
+			{name: "../outside.txt", mode: 0644, body: "outside"},
+		})
+
+		destination := t.TempDir()
+		_, err := FetchOciTemplate(
+			context.Background(), client, "oci://"+registry.Host+"/templates/evil:1.0", destination)
+		require.ErrorContains(t, err, "invalid file path '../outside.txt' outside of the template")
+		require.NoFileExists(t, filepath.Join(filepath.Dir(destination), "outside.txt"))
+	})
+
+	t.Run("not a template", func(t *testing.T) {
+		registry.PushManifest("images/api", "1.0", oci.Manifest{SchemaVersion: 2})
+
+		_, err := FetchOciTemplate(context.Background(), client, "oci://"+registry.Host+"/images/api:1.0", t.TempDir())
+		require.ErrorIs(t, err, errNotOciTemplate)
+	})
+}
+
+func Test_latestTag(t *testing.T) {
+	require.Equal(t, "latest", latestTag([]string{"1.0", "latest", "2.0"}))
+	require.Equal(t, "1.10.0", latestTag([]string{"1.2.0", "1.10.0", "1.9.0", "dev"}))
+	require.Equal(t, "", latestTag([]string{"dev", "main"}))
+}
@@ -8,16 +8,17 @@ import (
 	"log"
 	"strings"
 
+	"github.com/azure/azure-dev/cli/azd/pkg/oci"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 )
 
 // Absolute returns an absolute template path, given a possibly relative template path. An absolute path also corresponds to
-// a fully-qualified URI to a git repository.
+// a fully-qualified URI to a git repository, or to an OCI artifact (oci://<registry>/<repository>[:<tag>][@<digest>]).
 //
 // See Template.Path for more details.
 func Absolute(path string) (string, error) {
-	// already a git URI, return as-is
-	if strings.HasPrefix(path, "git") || strings.HasPrefix(path, "http") {
+	// already a git URI or an OCI reference, return as-is
+	if strings.HasPrefix(path, "git") || strings.HasPrefix(path, "http") || oci.IsReference(path) {
 		return path, nil
 	}
 
@@ -36,8 +37,12 @@ func Absolute(path string) (string, error) {
 }
 
 // Hyperlink returns a hyperlink to the given template path.
-// If the path is cannot be resolved absolutely, it is returned as-is.
+// If the path is cannot be resolved absolutely, or refers to an OCI artifact, it is returned as-is.
 func Hyperlink(path string) string {
+	if oci.IsReference(path) {
+		return path
+	}
+
 	url, err := Absolute(path)
 	if err != nil {
 		log.Printf("error: getting absolute url from template: %v", err)
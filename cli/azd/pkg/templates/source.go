@@ -28,6 +28,7 @@ const (
 	SourceKindGh         SourceKind = "gh"
 	SourceKindResource   SourceKind = "default"
 	SourceKindAwesomeAzd SourceKind = "awesome-azd"
+	SourceKindOci        SourceKind = "oci"
 )
 
 type SourceConfig struct {
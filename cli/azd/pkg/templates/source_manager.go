@@ -14,6 +14,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/pkg/ioc"
+	"github.com/azure/azure-dev/cli/azd/pkg/oci"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/github"
 	"github.com/azure/azure-dev/cli/azd/resources"
 )
@@ -238,6 +239,11 @@ func (sm *sourceManager) CreateSource(ctx context.Context, config *SourceConfig)
 			source, err = newGhTemplateSource(ctx, config.Name, config.Location, ghCli, console)
 			return err
 		})
+	case SourceKindOci:
+		err = sm.serviceLocator.Invoke(func(ociClient *oci.Client) error {
+			source, err = newOciTemplateSource(ctx, config.Name, config.Location, ociClient)
+			return err
+		})
 	default:
 		err = sm.serviceLocator.ResolveNamed(string(config.Type), &source)
 		if err != nil {
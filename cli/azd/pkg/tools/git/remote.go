@@ -0,0 +1,52 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package git
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// RemoteUrl is the host and repository path of a git remote url
+type RemoteUrl struct {
+	// The scheme of the remote url, ssh for scp-like remotes
+	Scheme string
+	// The host name of the remote, without the port
+	Host string
+	// The path of the repository on the host without the .git suffix, ex) owner/repo or group/subgroup/project
+	Path string
+}
+
+// defines the structure of an scp-like ssh git remote, e.g. git@host:owner/repo.git
+var remoteScpUrlRegex = regexp.MustCompile(`^[a-zA-Z0-9._-]+@([a-zA-Z0-9.-]+):(.+?)(?:\.git)?/?$`)
+
+// ParseRemoteUrl extracts the host and the repository path from a git remote url.
+// The url can be in the form of:
+//   - https://host/[owner]/[repo].git
+//   - https://[user]@host/[owner]/[repo].git
+//   - ssh://git@host[:port]/[owner]/[repo].git
+//   - git@host:[owner]/[repo].git
+func ParseRemoteUrl(remoteUrl string) (*RemoteUrl, error) {
+	if captures := remoteScpUrlRegex.FindStringSubmatch(remoteUrl); captures != nil &&
+		!strings.Contains(remoteUrl, "://") {
+		return &RemoteUrl{
+			Scheme: "ssh",
+			Host:   captures[1],
+			Path:   captures[2],
+		}, nil
+	}
+
+	parsed, err := url.Parse(remoteUrl)
+	if err != nil || parsed.Host == "" {
+		return nil, fmt.Errorf("unsupported git remote url: %s", remoteUrl)
+	}
+
+	return &RemoteUrl{
+		Scheme: parsed.Scheme,
+		Host:   parsed.Hostname(),
+		Path:   strings.TrimSuffix(strings.Trim(parsed.Path, "/"), ".git"),
+	}, nil
+}
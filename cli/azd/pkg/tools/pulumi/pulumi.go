@@ -0,0 +1,291 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package pulumi
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/blang/semver/v4"
+)
+
+var _ tools.ExternalTool = (*Cli)(nil)
+
+type Cli struct {
+	commandRunner exec.CommandRunner
+	env           []string
+}
+
+func NewCli(commandRunner exec.CommandRunner) *Cli {
+	return &Cli{
+		commandRunner: commandRunner,
+	}
+}
+
+func (cli *Cli) Name() string {
+	return "Pulumi CLI"
+}
+
+func (cli *Cli) InstallUrl() string {
+	return "https://www.pulumi.com/docs/install/"
+}
+
+func (cli *Cli) versionInfo() tools.VersionInfo {
+	return tools.VersionInfo{
+		MinimumVersion: semver.Version{
+			Major: 3,
+			Minor: 0,
+			Patch: 0},
+		UpdateCommand: "Download newer version from https://www.pulumi.com/docs/install/",
+	}
+}
+
+func (cli *Cli) CheckInstalled(ctx context.Context) error {
+	err := tools.ToolInPath("pulumi")
+	if err != nil {
+		return err
+	}
+
+	pulumiRes, err := tools.ExecuteCommand(ctx, cli.commandRunner, "pulumi", "version")
+	if err != nil {
+		return fmt.Errorf("checking %s version: %w", cli.Name(), err)
+	}
+
+	log.Printf("pulumi version: %s", pulumiRes)
+
+	// `pulumi version` prints the version prefixed with a 'v', ex) v3.113.0
+	pulumiSemver, err := tools.ExtractVersion(pulumiRes)
+	if err != nil {
+		return fmt.Errorf("converting to semver version fails: %w", err)
+	}
+	updateDetail := cli.versionInfo()
+	if pulumiSemver.LT(updateDetail.MinimumVersion) {
+		return &tools.ErrSemver{ToolName: cli.Name(), VersionInfo: updateDetail}
+	}
+	return nil
+}
+
+// Set environment variables to be used in all pulumi commands
+func (cli *Cli) SetEnv(env []string) {
+	cli.env = env
+}
+
+func (cli *Cli) runCommand(ctx context.Context, args ...string) (exec.RunResult, error) {
+	runArgs := exec.
+		NewRunArgs("pulumi", args...).
+		WithEnv(cli.env)
+
+	return cli.commandRunner.Run(ctx, runArgs)
+}
+
+func (cli *Cli) runInteractive(ctx context.Context, args ...string) (exec.RunResult, error) {
+	runArgs := exec.
+		NewRunArgs("pulumi", args...).
+		WithEnv(cli.env).
+		WithInteractive(true)
+
+	return cli.commandRunner.Run(ctx, runArgs)
+}
+
+// SelectStack selects the specified stack for the project at projectPath, creating the stack when it does not exist
+func (cli *Cli) SelectStack(ctx context.Context, projectPath string, stackName string) error {
+	args := []string{
+		"stack", "select", stackName,
+		"--create",
+		"--non-interactive",
+		fmt.Sprintf("--cwd=%s", projectPath),
+	}
+
+	cmdRes, err := cli.runCommand(ctx, args...)
+	if err != nil {
+		return fmt.Errorf(
+			"failed running pulumi stack select: %s (%w)",
+			cmdRes.Stderr,
+			err,
+		)
+	}
+	return nil
+}
+
+// ConfigValue is a value of the stack configuration
+type ConfigValue struct {
+	Value string
+	// Secret values are encrypted by the secrets provider of the stack
+	Secret bool
+}
+
+// SetConfig sets the specified configuration values on the stack. Secret values are set one at a time through stdin, so
+// they are neither stored as plain text nor visible in the arguments of the pulumi process.
+func (cli *Cli) SetConfig(
+	ctx context.Context,
+	projectPath string,
+	stackName string,
+	values map[string]ConfigValue,
+) error {
+	args := []string{
+		"config", "set-all",
+		fmt.Sprintf("--stack=%s", stackName),
+		"--non-interactive",
+		fmt.Sprintf("--cwd=%s", projectPath),
+	}
+
+	var plaintext bool
+	var secretKeys []string
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		if values[key].Secret {
+			secretKeys = append(secretKeys, key)
+			continue
+		}
+
+		plaintext = true
+		args = append(args, "--plaintext", fmt.Sprintf("%s=%s", key, values[key].Value))
+	}
+
+	if plaintext {
+		cmdRes, err := cli.runCommand(ctx, args...)
+		if err != nil {
+			return fmt.Errorf(
+				"failed running pulumi config set-all: %s (%w)",
+				cmdRes.Stderr,
+				err,
+			)
+		}
+	}
+
+	for _, key := range secretKeys {
+		runArgs := exec.
+			NewRunArgs(
+				"pulumi",
+				"config", "set", key,
+				"--secret",
+				fmt.Sprintf("--stack=%s", stackName),
+				"--non-interactive",
+				fmt.Sprintf("--cwd=%s", projectPath),
+			).
+			WithEnv(cli.env).
+			WithStdIn(strings.NewReader(values[key].Value))
+
+		cmdRes, err := cli.commandRunner.Run(ctx, runArgs)
+		if err != nil {
+			return fmt.Errorf(
+				"failed running pulumi config set --secret: %s (%w)",
+				cmdRes.Stderr,
+				err,
+			)
+		}
+	}
+
+	return nil
+}
+
+// Preview runs `pulumi preview` and returns the JSON representation of the changes
+func (cli *Cli) Preview(ctx context.Context, projectPath string, stackName string) (string, error) {
+	args := []string{
+		"preview",
+		fmt.Sprintf("--stack=%s", stackName),
+		"--json",
+		"--diff",
+		"--non-interactive",
+		fmt.Sprintf("--cwd=%s", projectPath),
+	}
+
+	cmdRes, err := cli.runCommand(ctx, args...)
+	if err != nil {
+		return "", fmt.Errorf(
+			"failed running pulumi preview: %s%s (%w)",
+			cmdRes.Stdout,
+			cmdRes.Stderr,
+			err,
+		)
+	}
+	return cmdRes.Stdout, nil
+}
+
+// Up runs `pulumi up` to create or update the resources of the stack
+func (cli *Cli) Up(ctx context.Context, projectPath string, stackName string) (string, error) {
+	args := []string{
+		"up",
+		fmt.Sprintf("--stack=%s", stackName),
+		"--yes",
+		"--skip-preview",
+		"--non-interactive",
+		fmt.Sprintf("--cwd=%s", projectPath),
+	}
+
+	// The output of interactive commands is written to the console and isn't captured in the result
+	cmdRes, err := cli.runInteractive(ctx, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed running pulumi up: %w", err)
+	}
+	return cmdRes.Stdout, nil
+}
+
+// StackOutput returns the JSON representation of the stack outputs, including secret values
+func (cli *Cli) StackOutput(ctx context.Context, projectPath string, stackName string) (string, error) {
+	args := []string{
+		"stack", "output",
+		fmt.Sprintf("--stack=%s", stackName),
+		"--json",
+		"--show-secrets",
+		"--non-interactive",
+		fmt.Sprintf("--cwd=%s", projectPath),
+	}
+
+	cmdRes, err := cli.runCommand(ctx, args...)
+	if err != nil {
+		return "", fmt.Errorf(
+			"failed running pulumi stack output: %s (%w)",
+			cmdRes.Stderr,
+			err,
+		)
+	}
+	return cmdRes.Stdout, nil
+}
+
+// StackExport returns the JSON representation of the stack deployment state
+func (cli *Cli) StackExport(ctx context.Context, projectPath string, stackName string) (string, error) {
+	args := []string{
+		"stack", "export",
+		fmt.Sprintf("--stack=%s", stackName),
+		"--non-interactive",
+		fmt.Sprintf("--cwd=%s", projectPath),
+	}
+
+	cmdRes, err := cli.runCommand(ctx, args...)
+	if err != nil {
+		return "", fmt.Errorf(
+			"failed running pulumi stack export: %s (%w)",
+			cmdRes.Stderr,
+			err,
+		)
+	}
+	return cmdRes.Stdout, nil
+}
+
+// Destroy runs `pulumi destroy` to delete all resources of the stack.
+// When autoApprove is false the user is prompted by pulumi to confirm the operation.
+func (cli *Cli) Destroy(ctx context.Context, projectPath string, stackName string, autoApprove bool) (string, error) {
+	args := []string{
+		"destroy",
+		fmt.Sprintf("--stack=%s", stackName),
+		fmt.Sprintf("--cwd=%s", projectPath),
+	}
+
+	if autoApprove {
+		args = append(args, "--yes", "--skip-preview", "--non-interactive")
+	}
+
+	// The output of interactive commands is written to the console and isn't captured in the result
+	cmdRes, err := cli.runInteractive(ctx, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed running pulumi destroy: %w", err)
+	}
+	return cmdRes.Stdout, nil
+}
@@ -0,0 +1,82 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package pulumi
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithEnv(t *testing.T) {
+	ran := false
+	expectedEnvVars := []string{"PULUMI_BACKEND_URL=file:///path/to/backend"}
+
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "pulumi"
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		ran = true
+		require.Equal(t, expectedEnvVars, args.Env)
+
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
+	cli := NewCli(mockContext.CommandRunner)
+	cli.SetEnv(expectedEnvVars)
+
+	err := cli.SelectStack(*mockContext.Context, "path/to/project", "dev")
+
+	require.NoError(t, err)
+	require.True(t, ran)
+}
+
+func Test_SetConfig(t *testing.T) {
+	var runArgs []exec.RunArgs
+	var stdIn []string
+
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "pulumi"
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		runArgs = append(runArgs, args)
+		if args.StdIn != nil {
+			value, err := io.ReadAll(args.StdIn)
+			require.NoError(t, err)
+			stdIn = append(stdIn, string(value))
+		}
+
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
+	cli := NewCli(mockContext.CommandRunner)
+	err := cli.SetConfig(*mockContext.Context, "path/to/project", "dev", map[string]ConfigValue{
+		"location":              {Value: "westus2"},
+		"azure-native:location": {Value: "westus2"},
+		"dbPassword":            {Value: "p@ssw0rd", Secret: true},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, runArgs, 2)
+	require.Equal(t, []string{
+		"config", "set-all",
+		"--stack=dev",
+		"--non-interactive",
+		"--cwd=path/to/project",
+		"--plaintext", "azure-native:location=westus2",
+		"--plaintext", "location=westus2",
+	}, runArgs[0].Args)
+	require.Equal(t, []string{
+		"config", "set", "dbPassword",
+		"--secret",
+		"--stack=dev",
+		"--non-interactive",
+		"--cwd=path/to/project",
+	}, runArgs[1].Args)
+	require.Equal(t, []string{"p@ssw0rd"}, stdIn)
+}
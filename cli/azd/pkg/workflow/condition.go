@@ -0,0 +1,87 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+)
+
+// EvaluateCondition evaluates the `if` condition of a workflow step.
+//
+// The condition may compare two values using `==` or `!=`, otherwise the condition is true when the value is not empty,
+// `false` or `0`. Variables within the values are expanded using the mapping function, ex) ${AZURE_ENV_TYPE}, after the
+// condition has been split on the operator, so values containing `==` or `!=` are compared as is. Values may optionally
+// be wrapped in single or double quotes.
+func EvaluateCondition(condition string, mapping func(string) string) (bool, error) {
+	for _, operator := range []string{"!=", "=="} {
+		left, right, has := cutOperator(condition, operator)
+		if !has {
+			continue
+		}
+
+		leftValue, err := expandConditionValue(left, mapping)
+		if err != nil {
+			return false, fmt.Errorf("expanding condition '%s': %w", condition, err)
+		}
+
+		rightValue, err := expandConditionValue(right, mapping)
+		if err != nil {
+			return false, fmt.Errorf("expanding condition '%s': %w", condition, err)
+		}
+
+		return (leftValue == rightValue) == (operator == "=="), nil
+	}
+
+	value, err := expandConditionValue(condition, mapping)
+	if err != nil {
+		return false, fmt.Errorf("expanding condition '%s': %w", condition, err)
+	}
+
+	switch strings.ToLower(value) {
+	case "", "false", "0":
+		return false, nil
+	default:
+		return true, nil
+	}
+}
+
+// cutOperator slices the condition around the first instance of the operator outside of variable references, ex) the
+// `==` of ${NAME:-a==b} is part of the default value.
+func cutOperator(condition string, operator string) (string, string, bool) {
+	depth := 0
+	for i := 0; i < len(condition); i++ {
+		switch {
+		case strings.HasPrefix(condition[i:], "${"):
+			depth++
+			i++
+		case condition[i] == '}' && depth > 0:
+			depth--
+		case depth == 0 && strings.HasPrefix(condition[i:], operator):
+			return condition[:i], condition[i+len(operator):], true
+		}
+	}
+
+	return condition, "", false
+}
+
+// expandConditionValue trims surrounding whitespace and quotes from a value within a condition, then expands the
+// variables of the value
+func expandConditionValue(value string, mapping func(string) string) (string, error) {
+	return osutil.NewExpandableString(conditionValue(value)).Envsubst(mapping)
+}
+
+// conditionValue trims surrounding whitespace and quotes from a value within a condition
+func conditionValue(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 {
+		if (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			return value[1 : len(value)-1]
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,47 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_EvaluateCondition(t *testing.T) {
+	values := map[string]string{
+		"AZURE_ENV_TYPE": "prod",
+		"DEPLOY_WEB":     "true",
+		"SKIP_TESTS":     "false",
+		"OPERATOR":       "a!=b",
+	}
+	mapping := func(name string) string {
+		return values[name]
+	}
+
+	tests := map[string]struct {
+		condition string
+		expected  bool
+	}{
+		"Equals":              {condition: "${AZURE_ENV_TYPE} == prod", expected: true},
+		"EqualsQuoted":        {condition: `"${AZURE_ENV_TYPE}" == 'prod'`, expected: true},
+		"EqualsMismatch":      {condition: "${AZURE_ENV_TYPE} == dev", expected: false},
+		"NotEquals":           {condition: "${AZURE_ENV_TYPE} != dev", expected: true},
+		"Truthy":              {condition: "${DEPLOY_WEB}", expected: true},
+		"False":               {condition: "${SKIP_TESTS}", expected: false},
+		"Missing":             {condition: "${MISSING}", expected: false},
+		"DefaultValue":        {condition: "${MISSING:-dev} == dev", expected: true},
+		"EqualsEmptyVariable": {condition: "${MISSING} == ''", expected: true},
+		"ValueWithOperator":   {condition: "${OPERATOR} == 'a!=b'", expected: true},
+		"DefaultWithOperator": {condition: "${MISSING:-a==b} != a", expected: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := EvaluateCondition(test.condition, mapping)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, actual)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/azure/azure-dev/cli/azd/pkg/ext"
 	"github.com/braydonk/yaml"
 	"github.com/stretchr/testify/require"
 )
@@ -100,6 +101,73 @@ func Test_WorkflowMap_UnmarshallYAML(t *testing.T) {
 		assertWorkflow(t, upWorkflow)
 	})
 
+	t.Run("quoted arguments", func(t *testing.T) {
+		var workflowMap WorkflowMap
+		yamlString := heredoc.Doc(`
+			up:
+			  - azd: deploy api --from-package "./dist/my app.zip"
+			  - azd: env set GREETING 'hello world'
+		`)
+
+		err := yaml.Unmarshal([]byte(yamlString), &workflowMap)
+		require.NoError(t, err)
+
+		steps := workflowMap["up"].Steps
+		require.Equal(t, []string{"deploy", "api", "--from-package", "./dist/my app.zip"}, steps[0].AzdCommand.Args)
+		require.Equal(t, []string{"env", "set", "GREETING", "hello world"}, steps[1].AzdCommand.Args)
+	})
+
+	t.Run("script steps", func(t *testing.T) {
+		var workflowMap WorkflowMap
+		yamlString := heredoc.Doc(`
+			up:
+			  - azd: provision
+			  - name: version
+			    script:
+			      shell: sh
+			      run: echo "tag=v1" >> "$AZD_STEP_OUTPUT"
+			  - azd: deploy --all
+			    if: ${AZURE_ENV_TYPE} == prod
+			    continueOnError: true
+		`)
+
+		err := yaml.Unmarshal([]byte(yamlString), &workflowMap)
+		require.NoError(t, err)
+
+		steps := workflowMap["up"].Steps
+		require.Len(t, steps, 3)
+		require.Equal(t, "version", steps[1].Name)
+		require.NotNil(t, steps[1].Script)
+		require.Equal(t, ext.ShellTypeBash, steps[1].Script.Shell)
+		require.Equal(t, `echo "tag=v1" >> "$AZD_STEP_OUTPUT"`, steps[1].Script.Run)
+		require.Equal(t, "${AZURE_ENV_TYPE} == prod", steps[2].If)
+		require.True(t, steps[2].ContinueOnError)
+	})
+
+	t.Run("invalid step", func(t *testing.T) {
+		var workflowMap WorkflowMap
+		yamlString := heredoc.Doc(`
+			up:
+			  - azd: provision
+			    script:
+			      run: ./scripts/release.sh
+		`)
+
+		err := yaml.Unmarshal([]byte(yamlString), &workflowMap)
+		require.ErrorContains(t, err, "either 'azd' or 'script'")
+	})
+
+	t.Run("unterminated quote", func(t *testing.T) {
+		var workflowMap WorkflowMap
+		yamlString := heredoc.Doc(`
+			up:
+			  - azd: deploy --from-package "./dist/app.zip
+		`)
+
+		err := yaml.Unmarshal([]byte(yamlString), &workflowMap)
+		require.Error(t, err)
+	})
+
 	t.Run("invalid workflow", func(t *testing.T) {
 		var workflowMap WorkflowMap
 		yamlString := heredoc.Doc(`
@@ -6,11 +6,27 @@ package workflow
 import (
 	"context"
 	"fmt"
+	"log"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
 	"strings"
 
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/ext"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/ioc"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/joho/godotenv"
 )
 
+// StepOutputEnvVarName is the environment variable containing the path of the file that script steps write their
+// outputs to. Outputs are written as KEY=VALUE lines.
+const StepOutputEnvVarName = "AZD_STEP_OUTPUT"
+
 // AzdCommandRunner abstracts the execution of an azd command given an set of arguments and context.
 type AzdCommandRunner interface {
 	SetArgs(args []string)
@@ -19,29 +35,158 @@ type AzdCommandRunner interface {
 
 // Runner is responsible for executing a workflow
 type Runner struct {
-	azdRunner AzdCommandRunner
-	console   input.Console
+	azdRunner      AzdCommandRunner
+	console        input.Console
+	serviceLocator ioc.ServiceLocator
 }
 
 // NewRunner creates a new instance of the Runner.
-func NewRunner(azdRunner AzdCommandRunner, console input.Console) *Runner {
+func NewRunner(azdRunner AzdCommandRunner, console input.Console, serviceLocator ioc.ServiceLocator) *Runner {
 	return &Runner{
-		azdRunner: azdRunner,
-		console:   console,
+		azdRunner:      azdRunner,
+		console:        console,
+		serviceLocator: serviceLocator,
 	}
 }
 
 // Run executes the specified workflow against the root cobra command
 func (r *Runner) Run(ctx context.Context, workflow *Workflow) error {
-	for _, step := range workflow.Steps {
-		if len(step.AzdCommand.Args) > 0 {
-			r.azdRunner.SetArgs(step.AzdCommand.Args)
+	// Outputs of named script steps, keyed by the variable name available to subsequent steps
+	outputs := map[string]string{}
+
+	for i, step := range workflow.Steps {
+		stepName := step.Name
+		if stepName == "" {
+			stepName = fmt.Sprintf("step%d", i+1)
+		}
+
+		if step.If != "" {
+			run, err := EvaluateCondition(step.If, r.mapping(ctx, outputs))
+			if err != nil {
+				return fmt.Errorf("evaluating condition for step '%s': %w", stepName, err)
+			}
+
+			if !run {
+				log.Printf("skipping workflow step '%s' since condition '%s' is false", stepName, step.If)
+				continue
+			}
 		}
 
-		if err := r.azdRunner.ExecuteContext(ctx); err != nil {
-			return fmt.Errorf("error executing step command '%s': %w", strings.Join(step.AzdCommand.Args, " "), err)
+		var err error
+		if step.Script != nil {
+			err = r.runScript(ctx, stepName, step, outputs)
+		} else {
+			err = r.runAzdCommand(ctx, step)
 		}
+
+		if err != nil {
+			if !step.ContinueOnError {
+				return err
+			}
+
+			r.console.Message(ctx, output.WithWarningFormat("WARNING: %s", err.Error()))
+			r.console.Message(
+				ctx,
+				output.WithWarningFormat("Execution will continue since continueOnError has been set to true."),
+			)
+			log.Println(err.Error())
+		}
+	}
+
+	return nil
+}
+
+func (r *Runner) runAzdCommand(ctx context.Context, step *Step) error {
+	if len(step.AzdCommand.Args) > 0 {
+		r.azdRunner.SetArgs(step.AzdCommand.Args)
+	}
+
+	if err := r.azdRunner.ExecuteContext(ctx); err != nil {
+		return fmt.Errorf("error executing step command '%s': %w", strings.Join(step.AzdCommand.Args, " "), err)
+	}
+
+	return nil
+}
+
+// runScript runs the script of the step using the hooks runner.
+// When the step is named, the outputs written by the script are added to the workflow outputs.
+func (r *Runner) runScript(ctx context.Context, stepName string, step *Step, outputs map[string]string) error {
+	outputFile, err := os.CreateTemp("", "azd-step-output-*")
+	if err != nil {
+		return fmt.Errorf("creating output file for step '%s': %w", stepName, err)
+	}
+	outputFile.Close()
+	defer os.Remove(outputFile.Name())
+
+	envVars := []string{fmt.Sprintf("%s=%s", StepOutputEnvVarName, outputFile.Name())}
+	for _, key := range slices.Sorted(maps.Keys(outputs)) {
+		envVars = append(envVars, fmt.Sprintf("%s=%s", key, outputs[key]))
+	}
+
+	err = r.serviceLocator.Invoke(func(
+		azdCtx *azdcontext.AzdContext,
+		env *environment.Environment,
+		envManager environment.Manager,
+		commandRunner exec.CommandRunner,
+	) error {
+		cwd := azdCtx.ProjectDirectory()
+		hooksRunner := ext.NewHooksRunner(
+			ext.NewHooksManager(cwd), commandRunner, envManager, r.console, cwd, nil, env, r.serviceLocator)
+
+		return hooksRunner.RunHook(ctx, stepName, step.Script, envVars, nil)
+	})
+	if err != nil {
+		return fmt.Errorf("error executing step script '%s': %w", stepName, err)
+	}
+
+	if step.Name == "" {
+		return nil
+	}
+
+	stepOutputs, err := godotenv.Read(outputFile.Name())
+	if err != nil {
+		return fmt.Errorf("reading outputs of step '%s': %w", stepName, err)
+	}
+
+	for key, value := range stepOutputs {
+		outputs[StepOutputVarName(step.Name, key)] = value
 	}
 
 	return nil
 }
+
+// mapping returns the function used to expand variables within step conditions.
+// Step outputs are considered first, followed by the values of the azd environment.
+func (r *Runner) mapping(ctx context.Context, outputs map[string]string) func(string) string {
+	getenv := os.Getenv
+
+	err := r.serviceLocator.Invoke(func(env *environment.Environment, envManager environment.Manager) error {
+		// Previous steps may have changed the environment
+		if err := envManager.Reload(ctx, env); err != nil {
+			return err
+		}
+
+		getenv = env.Getenv
+		return nil
+	})
+	if err != nil {
+		log.Printf("azd environment is not available for workflow conditions: %v", err)
+	}
+
+	return func(name string) string {
+		if value, has := outputs[name]; has {
+			return value
+		}
+
+		return getenv(name)
+	}
+}
+
+var invalidVarNameChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// StepOutputVarName returns the name of the variable that holds the output of a named step,
+// ex) the output 'version' of the step 'build-info' is available as STEP_BUILD_INFO_VERSION.
+func StepOutputVarName(stepName string, outputName string) string {
+	name := fmt.Sprintf("STEP_%s_%s", stepName, outputName)
+	return strings.ToUpper(invalidVarNameChars.ReplaceAllString(name, "_"))
+}
@@ -0,0 +1,148 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package workflow
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/ext"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockenv"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Runner_Run(t *testing.T) {
+	t.Run("Conditions", func(t *testing.T) {
+		mockContext := createRunnerMockContext(t, map[string]string{"AZURE_ENV_TYPE": "dev"})
+		azdRunner := &mockAzdCommandRunner{}
+
+		runner := NewRunner(azdRunner, mockContext.Console, mockContext.Container)
+		err := runner.Run(*mockContext.Context, &Workflow{
+			Name: "up",
+			Steps: []*Step{
+				{AzdCommand: Command{Args: []string{"provision"}}},
+				{AzdCommand: Command{Args: []string{"deploy", "--all"}}, If: "${AZURE_ENV_TYPE} == prod"},
+				{AzdCommand: Command{Args: []string{"deploy", "web"}}, If: "${AZURE_ENV_TYPE} != prod"},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, [][]string{{"provision"}, {"deploy", "web"}}, azdRunner.executed)
+	})
+
+	t.Run("ContinueOnError", func(t *testing.T) {
+		mockContext := createRunnerMockContext(t, nil)
+		azdRunner := &mockAzdCommandRunner{failing: "provision"}
+
+		runner := NewRunner(azdRunner, mockContext.Console, mockContext.Container)
+		err := runner.Run(*mockContext.Context, &Workflow{
+			Name: "up",
+			Steps: []*Step{
+				{AzdCommand: Command{Args: []string{"provision"}}, ContinueOnError: true},
+				{AzdCommand: Command{Args: []string{"deploy", "--all"}}},
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, [][]string{{"provision"}, {"deploy", "--all"}}, azdRunner.executed)
+
+		azdRunner = &mockAzdCommandRunner{failing: "provision"}
+		runner = NewRunner(azdRunner, mockContext.Console, mockContext.Container)
+		err = runner.Run(*mockContext.Context, &Workflow{
+			Name: "up",
+			Steps: []*Step{
+				{AzdCommand: Command{Args: []string{"provision"}}},
+				{AzdCommand: Command{Args: []string{"deploy", "--all"}}},
+			},
+		})
+		require.ErrorContains(t, err, "error executing step command 'provision'")
+		require.Equal(t, [][]string{{"provision"}}, azdRunner.executed)
+	})
+
+	t.Run("ScriptOutputs", func(t *testing.T) {
+		mockContext := createRunnerMockContext(t, nil)
+		azdRunner := &mockAzdCommandRunner{}
+
+		var scriptEnv []string
+		mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+			return true
+		}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+			scriptEnv = args.Env
+			for _, envVar := range args.Env {
+				if path, has := strings.CutPrefix(envVar, StepOutputEnvVarName+"="); has {
+					err := os.WriteFile(path, []byte("version=1.2.3\n"), 0600)
+					return exec.NewRunResult(0, "", ""), err
+				}
+			}
+
+			return exec.NewRunResult(1, "", ""), errors.New("missing step output file")
+		})
+
+		runner := NewRunner(azdRunner, mockContext.Console, mockContext.Container)
+		err := runner.Run(*mockContext.Context, &Workflow{
+			Name: "up",
+			Steps: []*Step{
+				{Name: "build-info", Script: &ext.HookConfig{Shell: ext.ShellTypeBash, Run: "echo version=1.2.3"}},
+				{AzdCommand: Command{Args: []string{"deploy", "--all"}}, If: "${STEP_BUILD_INFO_VERSION} == 1.2.3"},
+				{Script: &ext.HookConfig{Shell: ext.ShellTypeBash, Run: "echo $STEP_BUILD_INFO_VERSION"}},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, [][]string{{"deploy", "--all"}}, azdRunner.executed)
+		require.Contains(t, scriptEnv, "STEP_BUILD_INFO_VERSION=1.2.3")
+	})
+}
+
+func Test_StepOutputVarName(t *testing.T) {
+	require.Equal(t, "STEP_BUILD_INFO_VERSION", StepOutputVarName("build-info", "version"))
+	require.Equal(t, "STEP_RELEASE_IMAGE_TAG", StepOutputVarName("release", "image.tag"))
+}
+
+// createRunnerMockContext creates a mock context with the services required to run script steps and evaluate
+// conditions against an azd environment with the specified values.
+func createRunnerMockContext(
+	t *testing.T,
+	values map[string]string,
+) *mocks.MockContext {
+	mockContext := mocks.NewMockContext(context.Background())
+	env := environment.NewWithValues("test", values)
+
+	envManager := &mockenv.MockEnvManager{}
+	envManager.On("Reload", mock.Anything, mock.Anything).Return(nil)
+
+	azdCtx := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+
+	mockContext.Container.MustRegisterSingleton(func() *environment.Environment { return env })
+	mockContext.Container.MustRegisterSingleton(func() environment.Manager { return envManager })
+	mockContext.Container.MustRegisterSingleton(func() *azdcontext.AzdContext { return azdCtx })
+
+	return mockContext
+}
+
+type mockAzdCommandRunner struct {
+	args     []string
+	executed [][]string
+	failing  string
+}
+
+func (m *mockAzdCommandRunner) SetArgs(args []string) {
+	m.args = args
+}
+
+func (m *mockAzdCommandRunner) ExecuteContext(ctx context.Context) error {
+	m.executed = append(m.executed, m.args)
+	if m.failing != "" && m.args[0] == m.failing {
+		return errors.New("command failed")
+	}
+
+	return nil
+}
@@ -5,9 +5,10 @@ package workflow
 
 import (
 	"fmt"
-	"strings"
 
+	"github.com/azure/azure-dev/cli/azd/pkg/ext"
 	"github.com/braydonk/yaml"
+	"github.com/kballard/go-shellquote"
 )
 
 // Workflow stores a list of steps to execute
@@ -81,6 +82,10 @@ func (w *Workflow) unmarshalSteps(rawSteps any) ([]*Step, error) {
 			return nil, err
 		}
 
+		if err := step.validate(); err != nil {
+			return nil, err
+		}
+
 		steps = append(steps, &step)
 	}
 
@@ -88,9 +93,34 @@ func (w *Workflow) unmarshalSteps(rawSteps any) ([]*Step, error) {
 }
 
 // Step stores a single step to execute within a workflow
-// This struct can be expanded over time to support other types of steps/commands
+// A step either executes an azd command or a hook-style script.
 type Step struct {
+	// The optional name of the step. Outputs written by named script steps are available to subsequent steps.
+	Name string `yaml:"name,omitempty"`
+	// The optional condition that must evaluate to true for the step to run, ex) ${AZURE_ENV_TYPE} == prod
+	If string `yaml:"if,omitempty"`
+	// When set to true the workflow continues to the next step even when the step fails
+	ContinueOnError bool `yaml:"continueOnError,omitempty"`
+	// The azd command to execute
 	AzdCommand Command `yaml:"azd,omitempty"`
+	// The script to execute
+	Script *ext.HookConfig `yaml:"script,omitempty"`
+}
+
+// validate ensures the step runs exactly one azd command or script
+func (s *Step) validate() error {
+	hasCommand := len(s.AzdCommand.Args) > 0
+	hasScript := s.Script != nil
+
+	if hasCommand && hasScript {
+		return fmt.Errorf("step '%s' must specify either 'azd' or 'script', not both", s.Name)
+	}
+
+	if !hasCommand && !hasScript {
+		return fmt.Errorf("step '%s' must specify either 'azd' or 'script'", s.Name)
+	}
+
+	return nil
 }
 
 // NewAzdCommandStep creates a new step that executes an azd command with the specified name and args
@@ -134,9 +164,14 @@ func (c *Command) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	// String
 	var s string
 	if err := unmarshal(&s); err == nil {
-		parts := strings.Split(s, " ")
-		if len(parts) > 0 {
-			c.Args = parts
+		// Arguments are split using shell-style quoting rules, ex) deploy --from-package "my app.zip"
+		args, err := shellquote.Split(s)
+		if err != nil {
+			return fmt.Errorf("parsing command '%s': %w", s, err)
+		}
+
+		if len(args) > 0 {
+			c.Args = args
 		}
 
 		parsed = true
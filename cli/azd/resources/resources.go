@@ -33,3 +33,6 @@ var AiPythonApp embed.FS
 
 //go:embed pipeline/*
 var PipelineFiles embed.FS
+
+//go:embed preview/report.html.tmpl
+var PreviewReportHtml []byte
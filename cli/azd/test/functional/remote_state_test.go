@@ -43,7 +43,7 @@ func Test_StorageBlobClient(t *testing.T) {
 
 			// Upload
 			reader := bytes.NewBuffer([]byte(envValues))
-			err := blobClient.Upload(*mockContext.Context, blobPath, reader)
+			_, err := blobClient.Upload(*mockContext.Context, blobPath, reader, nil)
 			require.NoError(t, err)
 
 			// Download
@@ -61,7 +61,7 @@ func Test_StorageBlobClient(t *testing.T) {
 			require.NotEmpty(t, blobs)
 
 			// Delete
-			err = blobClient.Delete(*mockContext.Context, blobPath)
+			err = blobClient.Delete(*mockContext.Context, blobPath, nil)
 			require.NoError(t, err)
 		})
 	})
@@ -67,3 +67,27 @@ func (m *MockEnvManager) Delete(ctx context.Context, name string) error {
 	args := m.Called(name)
 	return args.Error(0)
 }
+
+func (m *MockEnvManager) DeleteWithOptions(
+	ctx context.Context,
+	name string,
+	options *environment.DeleteOptions,
+) error {
+	args := m.Called(ctx, name, options)
+	return args.Error(0)
+}
+
+func (m *MockEnvManager) HasRemote() bool {
+	args := m.Called()
+	return args.Bool(0)
+}
+
+func (m *MockEnvManager) Lock(ctx context.Context, name string, operation string) (*environment.LockInfo, error) {
+	args := m.Called(ctx, name, operation)
+	return args.Get(0).(*environment.LockInfo), args.Error(1)
+}
+
+func (m *MockEnvManager) Unlock(ctx context.Context, name string, lock *environment.LockInfo) error {
+	args := m.Called(ctx, name, lock)
+	return args.Error(0)
+}
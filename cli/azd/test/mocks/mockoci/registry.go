@@ -0,0 +1,206 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+// Package mockoci provides an in-memory registry implementing the pull endpoints of the OCI distribution API.
+package mockoci
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const manifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+
+// Token is the bearer token issued by the token endpoint of the registry.
+const Token = "mock-registry-token"
+
+// Registry is an in-memory OCI registry served over plain HTTP on the loopback interface, like a local registry:2
+// container.
+type Registry struct {
+	server *httptest.Server
+
+	// Host is the host and port of the registry, e.g. 127.0.0.1:12345
+	Host string
+	// RequireAuth requests clients to authenticate with a bearer token issued by the token endpoint of the registry.
+	RequireAuth bool
+	// PageSize is the number of entries returned per page when listing repositories and tags, 0 returns all.
+	PageSize int
+	// TokenScopes are the scopes requested to the token endpoint.
+	TokenScopes []string
+
+	mu sync.Mutex
+	// manifests by repository and tag or digest
+	manifests map[string]map[string][]byte
+	// blobs by digest
+	blobs map[string][]byte
+}
+
+// NewRegistry starts a new registry, closed at the end of the test.
+func NewRegistry(t *testing.T) *Registry {
+	registry := &Registry{
+		manifests: map[string]map[string][]byte{},
+		blobs:     map[string][]byte{},
+	}
+
+	registry.server = httptest.NewServer(http.HandlerFunc(registry.serve))
+	registry.Host = strings.TrimPrefix(registry.server.URL, "http://")
+	t.Cleanup(registry.server.Close)
+
+	return registry
+}
+
+// Client returns an HTTP client for the registry.
+func (r *Registry) Client() *http.Client {
+	return r.server.Client()
+}
+
+// PushBlob stores the content and returns its digest.
+func (r *Registry) PushBlob(content []byte) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	digest := Digest(content)
+	r.blobs[digest] = content
+	return digest
+}
+
+// PushManifest stores the manifest in the repository under its digest and the tag, when not empty, and returns its
+// digest.
+func (r *Registry) PushManifest(repository string, tag string, manifest any) string {
+	content, err := json.Marshal(manifest)
+	if err != nil {
+		panic(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	digest := Digest(content)
+	if r.manifests[repository] == nil {
+		r.manifests[repository] = map[string][]byte{}
+	}
+
+	r.manifests[repository][digest] = content
+	if tag != "" {
+		r.manifests[repository][tag] = content
+	}
+
+	return digest
+}
+
+// Digest returns the sha256 digest of the content.
+func Digest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func (r *Registry) serve(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if req.URL.Path == "/token" {
+		r.TokenScopes = append(r.TokenScopes, req.URL.Query().Get("scope"))
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": Token})
+		return
+	}
+
+	path, found := strings.CutPrefix(req.URL.Path, "/v2/")
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var repository, scope string
+	var handler func()
+
+	switch {
+	case path == "_catalog":
+		scope = "registry:catalog:*"
+		handler = func() {
+			repositories := []string{}
+			for repository := range r.manifests {
+				repositories = append(repositories, repository)
+			}
+
+			r.writePage(w, req, "repositories", repositories)
+		}
+	case strings.HasSuffix(path, "/tags/list"):
+		repository = strings.TrimSuffix(path, "/tags/list")
+		handler = func() {
+			tags := []string{}
+			for reference := range r.manifests[repository] {
+				if !strings.HasPrefix(reference, "sha256:") {
+					tags = append(tags, reference)
+				}
+			}
+
+			r.writePage(w, req, "tags", tags)
+		}
+	case strings.Contains(path, "/manifests/"):
+		var reference string
+		repository, reference, _ = strings.Cut(path, "/manifests/")
+		handler = func() {
+			r.writeContent(w, r.manifests[repository][reference], manifestMediaType)
+		}
+	case strings.Contains(path, "/blobs/"):
+		var digest string
+		repository, digest, _ = strings.Cut(path, "/blobs/")
+		handler = func() {
+			r.writeContent(w, r.blobs[digest], "application/octet-stream")
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", repository)
+	}
+
+	if r.RequireAuth && req.Header.Get("Authorization") != "Bearer "+Token {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(
+			`Bearer realm="%s/token",service="%s",scope="%s"`, r.server.URL, r.Host, scope))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	handler()
+}
+
+func (r *Registry) writeContent(w http.ResponseWriter, content []byte, mediaType string) {
+	if content == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", mediaType)
+	w.Header().Set("Docker-Content-Digest", Digest(content))
+	_, _ = w.Write(content)
+}
+
+// writePage writes a page of the sorted values, starting after the 'last' query parameter.
+func (r *Registry) writePage(w http.ResponseWriter, req *http.Request, key string, values []string) {
+	slices.Sort(values)
+
+	if last := req.URL.Query().Get("last"); last != "" {
+		index, _ := slices.BinarySearch(values, last)
+		values = values[min(index+1, len(values)):]
+	}
+
+	if r.PageSize > 0 && len(values) > r.PageSize {
+		values = values[:r.PageSize]
+		w.Header().Set("Link", fmt.Sprintf(
+			`<%s?n=%s&last=%s>; rel="next"`, req.URL.Path, strconv.Itoa(r.PageSize), values[len(values)-1]))
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string][]string{key: values})
+}